@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"finfit-backend/internal/application"
+	"flag"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const usage = `usage: migrate <command>
+
+commands:
+  up         applies every pending migration
+  down N     reverts the last N applied migrations
+  status     lists the migrations and whether they are applied
+  force V    records the migrations up to V as applied and the newer ones as pending, without running any of them.
+             A database migrated by hand before the migrations were versioned is adopted with force 24.
+`
+
+// errUsage is returned for commands that don't exist or have the wrong arguments.
+var errUsage = errors.New("invalid command")
+
+// Applies and reverts the migrations of the database schema embedded in the binary, connecting to the database of the
+// same environment variables as the application.
+func main() {
+	flag.Usage = func() { fmt.Fprint(flag.CommandLine.Output(), usage) }
+	flag.Parse()
+
+	if err := run(flag.Args()); errors.Is(err, errUsage) {
+		flag.Usage()
+		os.Exit(2)
+	} else if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+}
+
+// run executes the command, returning instead of exiting so the database is closed whatever happens.
+func run(args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	app := application.NewApplication(echo.New())
+	defer app.Finish()
+	app.LoadDependencyConfiguration()
+	migrator := app.Migrator()
+
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		applied, err := migrator.Up()
+		for _, migration := range applied {
+			log.Infof("migration %d_%s applied", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		log.Infof("%d migrations applied", len(applied))
+	case args[0] == "down" && len(args) == 2:
+		steps, err := strconv.Atoi(args[1])
+		if err != nil {
+			return errors.New("the migrations to revert must be a number")
+		}
+
+		reverted, err := migrator.Down(steps)
+		for _, migration := range reverted {
+			log.Infof("migration %d_%s reverted", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+	case args[0] == "status" && len(args) == 1:
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(writer, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return writer.Flush()
+	case args[0] == "force" && len(args) == 2:
+		version, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return errors.New("the version must be a number")
+		}
+
+		if err = migrator.Force(version); err != nil {
+			return err
+		}
+		log.Infof("migrations up to %d recorded as applied", version)
+	default:
+		return errUsage
+	}
+
+	return nil
+}
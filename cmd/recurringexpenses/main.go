@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"finfit-backend/internal/application"
+	"flag"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"time"
+)
+
+// Materializes the recurring expenses due up to the given date, meant to be run daily by cron. Running it more than
+// once for the same date doesn't create duplicated expenses.
+func main() {
+	date := flag.String("date", time.Now().Format("2006-01-02"), "generate the occurrences due up to this date (YYYY-MM-DD)")
+	flag.Parse()
+
+	until, err := time.Parse("2006-01-02", *date)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	app := application.NewApplication(echo.New())
+	defer app.Finish()
+	app.LoadDependencyConfiguration()
+
+	generatedExpenses, err := app.GenerateRecurringExpenses(context.Background(), until)
+	log.Infof("%d recurring expenses generated up to %s", generatedExpenses, *date)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,5 @@
+-- Fails when an expense type has a name longer than 16 characters, rename it first.
+ALTER TABLE public.expense_type
+    DROP CONSTRAINT expense_type_name_unique_constraint,
+    ALTER COLUMN name DROP NOT NULL,
+    ALTER COLUMN name TYPE VARCHAR(16);
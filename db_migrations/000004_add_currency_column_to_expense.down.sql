@@ -0,0 +1,2 @@
+ALTER TABLE public.expense
+    DROP COLUMN currency;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS income_source
+(
+    id         uuid PRIMARY KEY,
+    name       VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP,
+    CONSTRAINT income_source_name_unique_constraint UNIQUE (name)
+);
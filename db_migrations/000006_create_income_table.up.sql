@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS income(
+    id uuid PRIMARY KEY,
+    income_source_id uuid NOT NULL,
+    amount decimal NOT NULL,
+    currency VARCHAR(3) NOT NULL CHECK ( currency <> ''),
+    description VARCHAR(40),
+    income_date DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP,
+    CONSTRAINT fk_income_source
+        FOREIGN KEY(income_source_id)
+            REFERENCES income_source(id)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS account
+(
+    id              uuid PRIMARY KEY,
+    name            VARCHAR(32) NOT NULL,
+    kind            VARCHAR(16) NOT NULL CHECK ( kind IN ('bank', 'credit_card', 'cash', 'savings') ),
+    currency        VARCHAR(3)  NOT NULL CHECK ( currency <> '' ),
+    opening_balance decimal     NOT NULL DEFAULT 0,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP
+);
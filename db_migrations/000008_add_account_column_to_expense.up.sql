@@ -0,0 +1,5 @@
+ALTER TABLE public.expense
+    ADD COLUMN account_id uuid NULL,
+    ADD CONSTRAINT fk_expense_account
+        FOREIGN KEY (account_id)
+            REFERENCES account (id);
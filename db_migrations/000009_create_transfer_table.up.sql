@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS transfer
+(
+    id              uuid PRIMARY KEY,
+    from_account_id uuid       NOT NULL,
+    to_account_id   uuid       NOT NULL CHECK ( to_account_id <> from_account_id ),
+    amount          decimal    NOT NULL CHECK ( amount > 0 ),
+    currency        VARCHAR(3) NOT NULL CHECK ( currency <> '' ),
+    description     VARCHAR(40),
+    transfer_date   DATE       NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP,
+    CONSTRAINT fk_transfer_from_account
+        FOREIGN KEY (from_account_id)
+            REFERENCES account (id),
+    CONSTRAINT fk_transfer_to_account
+        FOREIGN KEY (to_account_id)
+            REFERENCES account (id)
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS budget
+(
+    id              uuid PRIMARY KEY,
+    expense_type_id uuid        NOT NULL,
+    period          VARCHAR(16) NOT NULL CHECK ( period IN ('monthly') ),
+    limit_amount    decimal     NOT NULL CHECK ( limit_amount > 0 ),
+    currency        VARCHAR(3)  NOT NULL CHECK ( currency <> '' ),
+    rollover        BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP,
+    CONSTRAINT budget_expense_type_period_unique_constraint UNIQUE (expense_type_id, period),
+    CONSTRAINT fk_budget_expense_type
+        FOREIGN KEY (expense_type_id)
+            REFERENCES expense_type (id)
+            ON DELETE CASCADE
+);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS recurring_expense
+(
+    id                uuid PRIMARY KEY,
+    expense_type_id   uuid        NOT NULL,
+    amount            decimal     NOT NULL CHECK ( amount > 0 ),
+    currency          VARCHAR(3)  NOT NULL CHECK ( currency <> '' ),
+    description       VARCHAR(40),
+    frequency         VARCHAR(16) NOT NULL CHECK ( frequency IN ('daily', 'weekly', 'monthly', 'yearly') ),
+    schedule_interval INTEGER     NOT NULL CHECK ( schedule_interval > 0 ),
+    start_date        DATE        NOT NULL,
+    end_date          DATE,
+    occurrences_count INTEGER     NOT NULL DEFAULT 0 CHECK ( occurrences_count >= 0 ),
+    last_occurrence   DATE,
+    created_at        TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at        TIMESTAMP,
+    CONSTRAINT fk_recurring_expense_expense_type
+        FOREIGN KEY (expense_type_id)
+            REFERENCES expense_type (id)
+);
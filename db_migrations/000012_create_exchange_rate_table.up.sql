@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS exchange_rate
+(
+    base_currency  VARCHAR(3)      NOT NULL CHECK ( base_currency <> '' ),
+    quote_currency VARCHAR(3)      NOT NULL CHECK ( quote_currency <> '' ),
+    rate_date      DATE            NOT NULL,
+    rate           decimal(30, 10) NOT NULL CHECK ( rate > 0 ),
+    created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP,
+    PRIMARY KEY (base_currency, quote_currency, rate_date),
+    CONSTRAINT exchange_rate_different_currencies_constraint CHECK ( base_currency <> quote_currency )
+);
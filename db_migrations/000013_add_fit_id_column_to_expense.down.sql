@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS expense_fit_id_index;
+
+ALTER TABLE public.expense
+    DROP COLUMN fit_id;
@@ -0,0 +1,4 @@
+ALTER TABLE public.expense
+    ADD COLUMN fit_id VARCHAR(255) NULL;
+
+CREATE INDEX IF NOT EXISTS expense_fit_id_index ON public.expense (fit_id);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS app_user
+(
+    id            uuid PRIMARY KEY,
+    email         VARCHAR(254) NOT NULL,
+    password_hash VARCHAR(72)  NOT NULL,
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP,
+    CONSTRAINT app_user_email_unique_constraint UNIQUE (email)
+);
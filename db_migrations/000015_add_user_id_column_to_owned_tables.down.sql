@@ -0,0 +1,29 @@
+-- Dropping the columns drops their indexes too. Fails when two users have expense types or income sources with the
+-- same name.
+ALTER TABLE public.expense_type
+    DROP CONSTRAINT expense_type_user_name_unique_constraint,
+    DROP COLUMN user_id,
+    ADD CONSTRAINT expense_type_name_unique_constraint UNIQUE (name);
+
+ALTER TABLE public.expense
+    DROP COLUMN user_id;
+
+ALTER TABLE public.income_source
+    DROP CONSTRAINT income_source_user_name_unique_constraint,
+    DROP COLUMN user_id,
+    ADD CONSTRAINT income_source_name_unique_constraint UNIQUE (name);
+
+ALTER TABLE public.income
+    DROP COLUMN user_id;
+
+ALTER TABLE public.account
+    DROP COLUMN user_id;
+
+ALTER TABLE public.transfer
+    DROP COLUMN user_id;
+
+ALTER TABLE public.budget
+    DROP COLUMN user_id;
+
+ALTER TABLE public.recurring_expense
+    DROP COLUMN user_id;
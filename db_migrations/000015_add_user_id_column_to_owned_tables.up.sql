@@ -0,0 +1,38 @@
+-- Rows stored before users existed have no owner and are not visible to anyone, assign them with
+-- UPDATE <table> SET user_id = '<user id>' WHERE user_id IS NULL before setting the columns as NOT NULL.
+ALTER TABLE public.expense_type
+    ADD COLUMN user_id uuid NULL REFERENCES app_user (id),
+    DROP CONSTRAINT expense_type_name_unique_constraint,
+    ADD CONSTRAINT expense_type_user_name_unique_constraint UNIQUE (user_id, name);
+
+ALTER TABLE public.expense
+    ADD COLUMN user_id uuid NULL REFERENCES app_user (id);
+
+ALTER TABLE public.income_source
+    ADD COLUMN user_id uuid NULL REFERENCES app_user (id),
+    DROP CONSTRAINT income_source_name_unique_constraint,
+    ADD CONSTRAINT income_source_user_name_unique_constraint UNIQUE (user_id, name);
+
+ALTER TABLE public.income
+    ADD COLUMN user_id uuid NULL REFERENCES app_user (id);
+
+ALTER TABLE public.account
+    ADD COLUMN user_id uuid NULL REFERENCES app_user (id);
+
+ALTER TABLE public.transfer
+    ADD COLUMN user_id uuid NULL REFERENCES app_user (id);
+
+ALTER TABLE public.budget
+    ADD COLUMN user_id uuid NULL REFERENCES app_user (id);
+
+ALTER TABLE public.recurring_expense
+    ADD COLUMN user_id uuid NULL REFERENCES app_user (id);
+
+CREATE INDEX IF NOT EXISTS expense_type_user_id_index ON public.expense_type (user_id);
+CREATE INDEX IF NOT EXISTS expense_user_id_expense_date_index ON public.expense (user_id, expense_date);
+CREATE INDEX IF NOT EXISTS income_source_user_id_index ON public.income_source (user_id);
+CREATE INDEX IF NOT EXISTS income_user_id_income_date_index ON public.income (user_id, income_date);
+CREATE INDEX IF NOT EXISTS account_user_id_index ON public.account (user_id);
+CREATE INDEX IF NOT EXISTS transfer_user_id_index ON public.transfer (user_id);
+CREATE INDEX IF NOT EXISTS budget_user_id_index ON public.budget (user_id);
+CREATE INDEX IF NOT EXISTS recurring_expense_user_id_index ON public.recurring_expense (user_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS ledger_invitation;
+DROP TABLE IF EXISTS ledger_member;
+DROP TABLE IF EXISTS ledger;
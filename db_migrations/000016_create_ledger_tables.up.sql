@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS ledger
+(
+    id         uuid PRIMARY KEY,
+    name       VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS ledger_member
+(
+    ledger_id  uuid        NOT NULL REFERENCES ledger (id),
+    user_id    uuid        NOT NULL REFERENCES app_user (id),
+    role       VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ledger_id, user_id),
+    CONSTRAINT ledger_member_role_check CHECK (role IN ('owner', 'editor', 'viewer'))
+);
+
+CREATE INDEX IF NOT EXISTS ledger_member_user_id_index ON public.ledger_member (user_id);
+
+CREATE TABLE IF NOT EXISTS ledger_invitation
+(
+    id         uuid PRIMARY KEY,
+    ledger_id  uuid        NOT NULL REFERENCES ledger (id),
+    role       VARCHAR(16) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP   NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT ledger_invitation_token_hash_unique_constraint UNIQUE (token_hash),
+    CONSTRAINT ledger_invitation_role_check CHECK (role IN ('editor', 'viewer'))
+);
@@ -0,0 +1,22 @@
+-- Expenses and expense types go back to the user of their personal ledger. The ones of shared ledgers are left without
+-- owner, like the rows stored before users existed.
+ALTER TABLE public.expense_type
+    ADD COLUMN user_id uuid NULL REFERENCES app_user (id);
+
+UPDATE public.expense_type SET user_id = ledger_id WHERE ledger_id IN (SELECT id FROM public.app_user);
+
+ALTER TABLE public.expense_type
+    DROP CONSTRAINT expense_type_ledger_name_unique_constraint,
+    ADD CONSTRAINT expense_type_user_name_unique_constraint UNIQUE (user_id, name),
+    DROP COLUMN ledger_id;
+
+ALTER TABLE public.expense
+    ADD COLUMN user_id uuid NULL REFERENCES app_user (id);
+
+UPDATE public.expense SET user_id = ledger_id WHERE ledger_id IN (SELECT id FROM public.app_user);
+
+ALTER TABLE public.expense
+    DROP COLUMN ledger_id;
+
+CREATE INDEX IF NOT EXISTS expense_type_user_id_index ON public.expense_type (user_id);
+CREATE INDEX IF NOT EXISTS expense_user_id_expense_date_index ON public.expense (user_id, expense_date);
@@ -0,0 +1,24 @@
+-- Expenses and expense types belong to a ledger instead of a user. The personal ledger of a user has the id of the
+-- user and isn't stored in the ledger table, so ledger_id has no foreign key.
+ALTER TABLE public.expense_type
+    ADD COLUMN ledger_id uuid NULL;
+
+UPDATE public.expense_type SET ledger_id = user_id;
+
+ALTER TABLE public.expense_type
+    ALTER COLUMN ledger_id SET NOT NULL,
+    DROP CONSTRAINT expense_type_user_name_unique_constraint,
+    ADD CONSTRAINT expense_type_ledger_name_unique_constraint UNIQUE (ledger_id, name),
+    DROP COLUMN user_id;
+
+ALTER TABLE public.expense
+    ADD COLUMN ledger_id uuid NULL;
+
+UPDATE public.expense SET ledger_id = user_id;
+
+ALTER TABLE public.expense
+    ALTER COLUMN ledger_id SET NOT NULL,
+    DROP COLUMN user_id;
+
+CREATE INDEX IF NOT EXISTS expense_type_ledger_id_index ON public.expense_type (ledger_id);
+CREATE INDEX IF NOT EXISTS expense_ledger_id_expense_date_index ON public.expense (ledger_id, expense_date);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS expense_split_participant;
+
+ALTER TABLE public.expense
+    DROP CONSTRAINT expense_split_method_check,
+    DROP COLUMN split_method,
+    DROP COLUMN split_paid_by;
@@ -0,0 +1,16 @@
+ALTER TABLE public.expense
+    ADD COLUMN split_paid_by uuid NULL REFERENCES app_user (id),
+    ADD COLUMN split_method  VARCHAR(16) NULL,
+    ADD CONSTRAINT expense_split_method_check CHECK (split_method IN ('equal', 'percentage', 'shares', 'exact'));
+
+CREATE TABLE IF NOT EXISTS expense_split_participant
+(
+    expense_id uuid        NOT NULL REFERENCES expense (id) ON DELETE CASCADE,
+    user_id    uuid        NOT NULL REFERENCES app_user (id),
+    position   INTEGER     NOT NULL,
+    value      VARCHAR(20) NOT NULL,
+    amount     decimal     NOT NULL CHECK ( amount >= 0 ),
+    PRIMARY KEY (expense_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS expense_split_participant_user_id_index ON public.expense_split_participant (user_id);
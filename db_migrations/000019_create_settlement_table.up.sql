@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS settlement
+(
+    id           uuid PRIMARY KEY,
+    ledger_id    uuid       NOT NULL REFERENCES ledger (id),
+    from_user_id uuid       NOT NULL REFERENCES app_user (id),
+    to_user_id   uuid       NOT NULL REFERENCES app_user (id) CHECK ( to_user_id <> from_user_id ),
+    amount       decimal    NOT NULL CHECK ( amount > 0 ),
+    currency     VARCHAR(3) NOT NULL CHECK ( currency <> '' ),
+    settled_at   DATE       NOT NULL,
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS settlement_ledger_id_index ON public.settlement (ledger_id);
@@ -0,0 +1,8 @@
+-- Fails when expense types of different parents share a name, rename them first.
+DROP INDEX IF EXISTS expense_type_parent_id_index;
+DROP INDEX IF EXISTS expense_type_ledger_root_name_unique_index;
+DROP INDEX IF EXISTS expense_type_ledger_parent_name_unique_index;
+
+ALTER TABLE public.expense_type
+    DROP COLUMN parent_id,
+    ADD CONSTRAINT expense_type_ledger_name_unique_constraint UNIQUE (ledger_id, name);
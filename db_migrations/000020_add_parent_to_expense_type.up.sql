@@ -0,0 +1,9 @@
+-- Expense types can be nested under another expense type of the same ledger. Names are unique among siblings, which
+-- needs two partial indexes because NULL parents never collide in a unique constraint.
+ALTER TABLE public.expense_type
+    ADD COLUMN parent_id uuid NULL REFERENCES expense_type (id) CHECK ( parent_id <> id ),
+    DROP CONSTRAINT expense_type_ledger_name_unique_constraint;
+
+CREATE UNIQUE INDEX IF NOT EXISTS expense_type_ledger_parent_name_unique_index ON public.expense_type (ledger_id, parent_id, name) WHERE parent_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS expense_type_ledger_root_name_unique_index ON public.expense_type (ledger_id, name) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS expense_type_parent_id_index ON public.expense_type (parent_id);
@@ -0,0 +1,21 @@
+-- Tags are free-form labels of the expenses of a ledger. Their names are stored normalized, so the unique constraint
+-- also catches names that only differ in case or spacing. Like on expenses, ledger_id has no foreign key since personal
+-- ledgers aren't stored in the ledger table.
+CREATE TABLE IF NOT EXISTS tag
+(
+    id         uuid PRIMARY KEY,
+    ledger_id  uuid        NOT NULL,
+    name       VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP,
+    CONSTRAINT tag_ledger_name_unique_constraint UNIQUE (ledger_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS expense_tag
+(
+    expense_id uuid NOT NULL REFERENCES expense (id) ON DELETE CASCADE,
+    tag_id     uuid NOT NULL REFERENCES tag (id) ON DELETE CASCADE,
+    PRIMARY KEY (expense_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS expense_tag_tag_id_index ON public.expense_tag (tag_id);
@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS expense_ledger_id_expense_date_index ON public.expense (ledger_id, expense_date);
+DROP INDEX IF EXISTS expense_ledger_id_amount_id_index;
+DROP INDEX IF EXISTS expense_ledger_id_expense_date_id_index;
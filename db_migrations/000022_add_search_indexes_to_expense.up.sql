@@ -0,0 +1,5 @@
+-- The expense search pages through the expenses of a ledger sorted by date or by amount, with the id breaking ties,
+-- so the keyset of every page is read from an index. The first one replaces the index on the date alone.
+CREATE INDEX IF NOT EXISTS expense_ledger_id_expense_date_id_index ON public.expense (ledger_id, expense_date, id);
+CREATE INDEX IF NOT EXISTS expense_ledger_id_amount_id_index ON public.expense (ledger_id, amount, id);
+DROP INDEX IF EXISTS expense_ledger_id_expense_date_index;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS expense_type_name_text_search_index;
+DROP INDEX IF EXISTS expense_description_text_search_index;
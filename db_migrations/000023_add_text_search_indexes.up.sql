@@ -0,0 +1,4 @@
+-- The text search matches the words of the descriptions of the expenses and of the names of the expense types, with the
+-- 'simple' configuration so the words aren't stemmed in any language.
+CREATE INDEX IF NOT EXISTS expense_description_text_search_index ON public.expense USING GIN (to_tsvector('simple', coalesce(description, '')));
+CREATE INDEX IF NOT EXISTS expense_type_name_text_search_index ON public.expense_type USING GIN (to_tsvector('simple', name));
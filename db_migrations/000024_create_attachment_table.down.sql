@@ -0,0 +1,2 @@
+-- The content of the attachments is left in the blob store.
+DROP TABLE IF EXISTS attachment;
@@ -0,0 +1,14 @@
+-- Attachments are the files of the receipts of the expenses. Their content is kept in the blob store under
+-- ledger_id/expense_id/id, and the checksum is the hex SHA-256 of the content, which finds the files uploaded twice.
+CREATE TABLE IF NOT EXISTS attachment
+(
+    id           uuid PRIMARY KEY,
+    ledger_id    uuid         NOT NULL,
+    expense_id   uuid         NOT NULL REFERENCES expense (id) ON DELETE CASCADE,
+    file_name    VARCHAR(255) NOT NULL,
+    content_type VARCHAR(64)  NOT NULL,
+    size         BIGINT       NOT NULL,
+    checksum     CHAR(64)     NOT NULL,
+    uploaded_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT attachment_expense_checksum_unique_constraint UNIQUE (expense_id, checksum)
+);
@@ -0,0 +1,11 @@
+-- The refresh tokens are single use, a token is recorded here by its jti when it's exchanged for a new session and
+-- rejected from then on. The rows are only needed until the token expires.
+CREATE TABLE IF NOT EXISTS used_refresh_token
+(
+    id         CHAR(32)  PRIMARY KEY,
+    user_id    uuid      NOT NULL REFERENCES app_user (id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS used_refresh_token_user_id_index ON used_refresh_token (user_id);
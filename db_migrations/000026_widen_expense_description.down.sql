@@ -0,0 +1,3 @@
+-- Truncates the descriptions that don't fit anymore.
+ALTER TABLE public.expense
+    ALTER COLUMN description TYPE VARCHAR(40) USING substring(description FROM 1 FOR 40);
@@ -0,0 +1,3 @@
+-- The descriptions of the imported bank statements are longer than the 40 characters typed by hand.
+ALTER TABLE public.expense
+    ALTER COLUMN description TYPE VARCHAR(255);
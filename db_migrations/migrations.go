@@ -0,0 +1,25 @@
+// Package dbmigrations embeds the migrations of the database schema into the binaries. Each migration is a pair of
+// files, VERSION_NAME.up.sql and VERSION_NAME.down.sql, applied in the order of their versions. The Postgres migrations
+// are in this directory and the SQLite ones in the sqlite directory.
+package dbmigrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed *.sql
+var Files embed.FS
+
+//go:embed sqlite/*.sql
+var sqliteFiles embed.FS
+
+// SQLiteFiles are the migrations of the SQLite schema, with the same versions as the Postgres ones from the version
+// SQLite starts at.
+func SQLiteFiles() fs.FS {
+	files, err := fs.Sub(sqliteFiles, "sqlite")
+	if err != nil {
+		panic(err)
+	}
+	return files
+}
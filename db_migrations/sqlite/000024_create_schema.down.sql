@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS attachment;
+DROP TABLE IF EXISTS expense_tag;
+DROP TABLE IF EXISTS tag;
+DROP TABLE IF EXISTS settlement;
+DROP TABLE IF EXISTS exchange_rate;
+DROP TABLE IF EXISTS recurring_expense;
+DROP TABLE IF EXISTS budget;
+DROP TABLE IF EXISTS transfer;
+DROP TABLE IF EXISTS income;
+DROP TABLE IF EXISTS income_source;
+DROP TABLE IF EXISTS expense_split_participant;
+DROP TABLE IF EXISTS expense;
+DROP TABLE IF EXISTS account;
+DROP TABLE IF EXISTS expense_type;
+DROP TABLE IF EXISTS ledger_invitation;
+DROP TABLE IF EXISTS ledger_member;
+DROP TABLE IF EXISTS ledger;
+DROP TABLE IF EXISTS app_user;
@@ -0,0 +1,268 @@
+-- SQLite starts from the schema the Postgres migrations reach at version 24, written in a single migration since SQLite
+-- can't alter the constraints of a table. Every later migration has the same version in both directories. Ids are
+-- stored as text and dates as the text the driver writes. Amounts are integers of ten-thousandths, since the decimals of
+-- SQLite are floating point, and exchange rates keep numeric affinity. The text search of the expenses scans them
+-- instead of using an index.
+CREATE TABLE IF NOT EXISTS app_user
+(
+    id            VARCHAR(36) PRIMARY KEY,
+    email         VARCHAR(254) NOT NULL,
+    password_hash VARCHAR(72)  NOT NULL,
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP,
+    CONSTRAINT app_user_email_unique_constraint UNIQUE (email)
+);
+
+CREATE TABLE IF NOT EXISTS ledger
+(
+    id         VARCHAR(36) PRIMARY KEY,
+    name       VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS ledger_member
+(
+    ledger_id  VARCHAR(36) NOT NULL REFERENCES ledger (id),
+    user_id    VARCHAR(36) NOT NULL REFERENCES app_user (id),
+    role       VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ledger_id, user_id),
+    CONSTRAINT ledger_member_role_check CHECK (role IN ('owner', 'editor', 'viewer'))
+);
+
+CREATE INDEX IF NOT EXISTS ledger_member_user_id_index ON ledger_member (user_id);
+
+CREATE TABLE IF NOT EXISTS ledger_invitation
+(
+    id         VARCHAR(36) PRIMARY KEY,
+    ledger_id  VARCHAR(36) NOT NULL REFERENCES ledger (id),
+    role       VARCHAR(16) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP   NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT ledger_invitation_token_hash_unique_constraint UNIQUE (token_hash),
+    CONSTRAINT ledger_invitation_role_check CHECK (role IN ('editor', 'viewer'))
+);
+
+CREATE TABLE IF NOT EXISTS expense_type
+(
+    id         VARCHAR(36) PRIMARY KEY,
+    ledger_id  VARCHAR(36) NOT NULL,
+    parent_id  VARCHAR(36) NULL REFERENCES expense_type (id) CHECK ( parent_id <> id ),
+    name       VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS expense_type_ledger_parent_name_unique_index ON expense_type (ledger_id, parent_id, name) WHERE parent_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS expense_type_ledger_root_name_unique_index ON expense_type (ledger_id, name) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS expense_type_ledger_id_index ON expense_type (ledger_id);
+CREATE INDEX IF NOT EXISTS expense_type_parent_id_index ON expense_type (parent_id);
+
+CREATE TABLE IF NOT EXISTS account
+(
+    id              VARCHAR(36) PRIMARY KEY,
+    user_id         VARCHAR(36) NULL REFERENCES app_user (id),
+    name            VARCHAR(32) NOT NULL,
+    kind            VARCHAR(16) NOT NULL CHECK ( kind IN ('bank', 'credit_card', 'cash', 'savings') ),
+    currency        VARCHAR(3)  NOT NULL CHECK ( currency <> '' ),
+    opening_balance INTEGER     NOT NULL DEFAULT 0,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS account_user_id_index ON account (user_id);
+
+CREATE TABLE IF NOT EXISTS expense
+(
+    id              VARCHAR(36) PRIMARY KEY,
+    ledger_id       VARCHAR(36)  NOT NULL,
+    expense_type_id VARCHAR(36)  NOT NULL,
+    account_id      VARCHAR(36)  NULL,
+    amount          INTEGER      NOT NULL,
+    currency        VARCHAR(3)   NOT NULL CHECK ( currency <> '') DEFAULT 'ARS',
+    description     VARCHAR(40),
+    expense_date    DATE         NOT NULL,
+    fit_id          VARCHAR(255) NULL,
+    split_paid_by   VARCHAR(36)  NULL REFERENCES app_user (id),
+    split_method    VARCHAR(16)  NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP,
+    CONSTRAINT fk_expense_type
+        FOREIGN KEY (expense_type_id)
+            REFERENCES expense_type (id),
+    CONSTRAINT fk_expense_account
+        FOREIGN KEY (account_id)
+            REFERENCES account (id),
+    CONSTRAINT expense_split_method_check CHECK (split_method IN ('equal', 'percentage', 'shares', 'exact'))
+);
+
+CREATE INDEX IF NOT EXISTS expense_fit_id_index ON expense (fit_id);
+CREATE INDEX IF NOT EXISTS expense_ledger_id_expense_date_id_index ON expense (ledger_id, expense_date, id);
+CREATE INDEX IF NOT EXISTS expense_ledger_id_amount_id_index ON expense (ledger_id, amount, id);
+
+CREATE TABLE IF NOT EXISTS expense_split_participant
+(
+    expense_id VARCHAR(36) NOT NULL REFERENCES expense (id) ON DELETE CASCADE,
+    user_id    VARCHAR(36) NOT NULL REFERENCES app_user (id),
+    position   INTEGER     NOT NULL,
+    value      VARCHAR(20) NOT NULL,
+    amount     INTEGER     NOT NULL CHECK ( amount >= 0 ),
+    PRIMARY KEY (expense_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS expense_split_participant_user_id_index ON expense_split_participant (user_id);
+
+CREATE TABLE IF NOT EXISTS income_source
+(
+    id         VARCHAR(36) PRIMARY KEY,
+    user_id    VARCHAR(36) NULL REFERENCES app_user (id),
+    name       VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP,
+    CONSTRAINT income_source_user_name_unique_constraint UNIQUE (user_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS income_source_user_id_index ON income_source (user_id);
+
+CREATE TABLE IF NOT EXISTS income
+(
+    id               VARCHAR(36) PRIMARY KEY,
+    user_id          VARCHAR(36) NULL REFERENCES app_user (id),
+    income_source_id VARCHAR(36) NOT NULL,
+    amount           INTEGER     NOT NULL,
+    currency         VARCHAR(3)  NOT NULL CHECK ( currency <> ''),
+    description      VARCHAR(40),
+    income_date      DATE        NOT NULL,
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP,
+    CONSTRAINT fk_income_source
+        FOREIGN KEY (income_source_id)
+            REFERENCES income_source (id)
+);
+
+CREATE INDEX IF NOT EXISTS income_user_id_income_date_index ON income (user_id, income_date);
+
+CREATE TABLE IF NOT EXISTS transfer
+(
+    id              VARCHAR(36) PRIMARY KEY,
+    user_id         VARCHAR(36) NULL REFERENCES app_user (id),
+    from_account_id VARCHAR(36) NOT NULL,
+    to_account_id   VARCHAR(36) NOT NULL CHECK ( to_account_id <> from_account_id ),
+    amount          INTEGER     NOT NULL CHECK ( amount > 0 ),
+    currency        VARCHAR(3)  NOT NULL CHECK ( currency <> '' ),
+    description     VARCHAR(40),
+    transfer_date   DATE        NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP,
+    CONSTRAINT fk_transfer_from_account
+        FOREIGN KEY (from_account_id)
+            REFERENCES account (id),
+    CONSTRAINT fk_transfer_to_account
+        FOREIGN KEY (to_account_id)
+            REFERENCES account (id)
+);
+
+CREATE INDEX IF NOT EXISTS transfer_user_id_index ON transfer (user_id);
+
+CREATE TABLE IF NOT EXISTS budget
+(
+    id              VARCHAR(36) PRIMARY KEY,
+    user_id         VARCHAR(36) NULL REFERENCES app_user (id),
+    expense_type_id VARCHAR(36) NOT NULL,
+    period          VARCHAR(16) NOT NULL CHECK ( period IN ('monthly') ),
+    limit_amount    INTEGER     NOT NULL CHECK ( limit_amount > 0 ),
+    currency        VARCHAR(3)  NOT NULL CHECK ( currency <> '' ),
+    rollover        BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP,
+    CONSTRAINT budget_expense_type_period_unique_constraint UNIQUE (expense_type_id, period),
+    CONSTRAINT fk_budget_expense_type
+        FOREIGN KEY (expense_type_id)
+            REFERENCES expense_type (id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS budget_user_id_index ON budget (user_id);
+
+CREATE TABLE IF NOT EXISTS recurring_expense
+(
+    id                VARCHAR(36) PRIMARY KEY,
+    user_id           VARCHAR(36) NULL REFERENCES app_user (id),
+    expense_type_id   VARCHAR(36) NOT NULL,
+    amount            INTEGER     NOT NULL CHECK ( amount > 0 ),
+    currency          VARCHAR(3)  NOT NULL CHECK ( currency <> '' ),
+    description       VARCHAR(40),
+    frequency         VARCHAR(16) NOT NULL CHECK ( frequency IN ('daily', 'weekly', 'monthly', 'yearly') ),
+    schedule_interval INTEGER     NOT NULL CHECK ( schedule_interval > 0 ),
+    start_date        DATE        NOT NULL,
+    end_date          DATE,
+    occurrences_count INTEGER     NOT NULL DEFAULT 0 CHECK ( occurrences_count >= 0 ),
+    last_occurrence   DATE,
+    created_at        TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at        TIMESTAMP,
+    CONSTRAINT fk_recurring_expense_expense_type
+        FOREIGN KEY (expense_type_id)
+            REFERENCES expense_type (id)
+);
+
+CREATE INDEX IF NOT EXISTS recurring_expense_user_id_index ON recurring_expense (user_id);
+
+CREATE TABLE IF NOT EXISTS exchange_rate
+(
+    base_currency  VARCHAR(3)      NOT NULL CHECK ( base_currency <> '' ),
+    quote_currency VARCHAR(3)      NOT NULL CHECK ( quote_currency <> '' ),
+    rate_date      DATE            NOT NULL,
+    rate           decimal(30, 10) NOT NULL CHECK ( rate > 0 ),
+    created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP,
+    PRIMARY KEY (base_currency, quote_currency, rate_date),
+    CONSTRAINT exchange_rate_different_currencies_constraint CHECK ( base_currency <> quote_currency )
+);
+
+CREATE TABLE IF NOT EXISTS settlement
+(
+    id           VARCHAR(36) PRIMARY KEY,
+    ledger_id    VARCHAR(36) NOT NULL REFERENCES ledger (id),
+    from_user_id VARCHAR(36) NOT NULL REFERENCES app_user (id),
+    to_user_id   VARCHAR(36) NOT NULL REFERENCES app_user (id) CHECK ( to_user_id <> from_user_id ),
+    amount       INTEGER     NOT NULL CHECK ( amount > 0 ),
+    currency     VARCHAR(3)  NOT NULL CHECK ( currency <> '' ),
+    settled_at   DATE        NOT NULL,
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS settlement_ledger_id_index ON settlement (ledger_id);
+
+CREATE TABLE IF NOT EXISTS tag
+(
+    id         VARCHAR(36) PRIMARY KEY,
+    ledger_id  VARCHAR(36) NOT NULL,
+    name       VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP,
+    CONSTRAINT tag_ledger_name_unique_constraint UNIQUE (ledger_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS expense_tag
+(
+    expense_id VARCHAR(36) NOT NULL REFERENCES expense (id) ON DELETE CASCADE,
+    tag_id     VARCHAR(36) NOT NULL REFERENCES tag (id) ON DELETE CASCADE,
+    PRIMARY KEY (expense_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS expense_tag_tag_id_index ON expense_tag (tag_id);
+
+CREATE TABLE IF NOT EXISTS attachment
+(
+    id           VARCHAR(36) PRIMARY KEY,
+    ledger_id    VARCHAR(36)  NOT NULL,
+    expense_id   VARCHAR(36)  NOT NULL REFERENCES expense (id) ON DELETE CASCADE,
+    file_name    VARCHAR(255) NOT NULL,
+    content_type VARCHAR(64)  NOT NULL,
+    size         BIGINT       NOT NULL,
+    checksum     CHAR(64)     NOT NULL,
+    uploaded_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT attachment_expense_checksum_unique_constraint UNIQUE (expense_id, checksum)
+);
@@ -0,0 +1,2 @@
+-- SQLite doesn't enforce the length of VARCHAR columns, so the expense descriptions of 255 characters already fit.
+SELECT 1;
@@ -1,12 +1,19 @@
 package application
 
 import (
+	"context"
+	"finfit-backend/internal/domain/services/recurringexpense"
+	"finfit-backend/internal/infrastructure/repository/sql/migration"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"time"
 )
 
 type Application interface {
 	LoadDependencyConfiguration()
 	Start() error
+	Migrator() *migration.Migrator
+	GenerateRecurringExpenses(ctx context.Context, until time.Time) (int, error)
 	Finish()
 }
 
@@ -21,21 +28,141 @@ func NewApplication(echo *echo.Echo) *application {
 func (a application) LoadDependencyConfiguration() {
 	WireExpenseTypeRepository = wireExpenseTypeRepository
 	WireExpenseRepository = wireExpenseRepository
+	WireExpenseTypeUnitOfWork = wireExpenseTypeUnitOfWork
+	WireExpenseUnitOfWork = wireExpenseUnitOfWork
 	WireExpenseTypeService = wireExpenseTypeService
 	WireExpenseService = wireExpenseService
 	WireExpenseHandler = wireExpenseHandler
 	WireExpenseTypeHandler = wireExpenseTypeHandler
+	WireIncomeSourceRepository = wireIncomeSourceRepository
+	WireIncomeRepository = wireIncomeRepository
+	WireIncomeSourceService = wireIncomeSourceService
+	WireIncomeService = wireIncomeService
+	WireIncomeHandler = wireIncomeHandler
+	WireIncomeSourceHandler = wireIncomeSourceHandler
+	WireAccountRepository = wireAccountRepository
+	WireAccountService = wireAccountService
+	WireAccountHandler = wireAccountHandler
+	WireBudgetRepository = wireBudgetRepository
+	WireBudgetService = wireBudgetService
+	WireBudgetHandler = wireBudgetHandler
+	WireRecurringExpenseRepository = wireRecurringExpenseRepository
+	WireRecurringExpenseUnitOfWork = wireRecurringExpenseUnitOfWork
+	WireRecurringExpenseService = wireRecurringExpenseService
+	WireRecurringExpenseHandler = wireRecurringExpenseHandler
+	WireReportRepository = wireReportRepository
+	WireReportService = wireReportService
+	WireReportHandler = wireReportHandler
+	WireExchangeRateRepository = wireExchangeRateRepository
+	WireExchangeRateService = wireExchangeRateService
+	WireExchangeRateHandler = wireExchangeRateHandler
+	WireStatementImportHandler = wireStatementImportHandler
+	WireExportHandler = wireExportHandler
+	WireUserRepository = wireUserRepository
+	WireUserService = wireUserService
+	WireAuthHandler = wireAuthHandler
+	WireLedgerRepository = wireLedgerRepository
+	WireLedgerService = wireLedgerService
+	WireLedgerHandler = wireLedgerHandler
+	WireBalanceRepository = wireBalanceRepository
+	WireBalanceService = wireBalanceService
+	WireBalanceHandler = wireBalanceHandler
+	WireTagRepository = wireTagRepository
+	WireTagService = wireTagService
+	WireTagHandler = wireTagHandler
+	WireAttachmentRepository = wireAttachmentRepository
+	WireAttachmentService = wireAttachmentService
+	WireAttachmentHandler = wireAttachmentHandler
+	WireMigrator = wireMigrator
+	WireBlobStore = wireBlobStore
 	WireDbConnection = wireDbConnection
 	WireGenericFieldsValidator = wireGenericFieldsValidator
 	WireConfigurations = wireConfigurations
+
+	WireConfigurations()
+	if usesMemoryRepositories() {
+		WireExpenseTypeRepository = wireMemoryExpenseTypeRepository
+		WireExpenseRepository = wireMemoryExpenseRepository
+		WireExpenseTypeUnitOfWork = wireMemoryExpenseTypeUnitOfWork
+		WireExpenseUnitOfWork = wireMemoryExpenseUnitOfWork
+		WireIncomeSourceRepository = wireMemoryIncomeSourceRepository
+		WireIncomeRepository = wireMemoryIncomeRepository
+		WireAccountRepository = wireMemoryAccountRepository
+		WireBudgetRepository = wireMemoryBudgetRepository
+		WireRecurringExpenseRepository = wireMemoryRecurringExpenseRepository
+		WireRecurringExpenseUnitOfWork = wireMemoryRecurringExpenseUnitOfWork
+		WireReportRepository = wireMemoryReportRepository
+		WireExchangeRateRepository = wireMemoryExchangeRateRepository
+		WireUserRepository = wireMemoryUserRepository
+		WireLedgerRepository = wireMemoryLedgerRepository
+		WireBalanceRepository = wireMemoryBalanceRepository
+		WireTagRepository = wireMemoryTagRepository
+		WireAttachmentRepository = wireMemoryAttachmentRepository
+		WireMigrator = wireWithoutDatabase
+		WireDbConnection = wireWithoutDatabase
+	}
 }
 
 func (a application) Start() error {
 	injectDependencies()
-	mapRoutes(a.echo)
+	// There's nothing to migrate when every entity is kept in memory.
+	if Configs.GetString(migrateOnStartConfigKey) == "true" && !usesMemoryRepositories() {
+		if err := migrateDatabase(); err != nil {
+			return err
+		}
+	}
+
+	if err := mapRoutes(a.echo); err != nil {
+		return err
+	}
 	return a.echo.Start(":8080")
 }
 
+// Migrator wires the database connection alone, without the rest of the dependencies, and returns the migrator of
+// its schema. It's the database configured by the DATABASE_ variables even when the entities are kept in memory.
+func (a application) Migrator() *migration.Migrator {
+	WireConfigurations()
+	wireDbConnection()
+	wireMigrator()
+	return Migrator
+}
+
+// GenerateRecurringExpenses wires the dependencies without starting the server and materializes the recurring
+// expenses of every user due up to the given date. It returns how many expenses were created.
+func (a application) GenerateRecurringExpenses(ctx context.Context, until time.Time) (int, error) {
+	injectDependencies()
+	command, err := recurringexpense.NewGenerateCommand(until)
+	if err != nil {
+		return 0, err
+	}
+
+	users, err := UserService.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	for _, user := range users {
+		generatedExpenses, err := RecurringExpenseService.Generate(ctx, user.Id(), command)
+		generated += len(generatedExpenses)
+		if err != nil {
+			return generated, err
+		}
+	}
+
+	return generated, nil
+}
+
+func migrateDatabase() error {
+	log.Info("applying the pending migrations...")
+	applied, err := Migrator.Up()
+	for _, migration := range applied {
+		log.Infof("migration %d_%s applied", migration.Version, migration.Name)
+	}
+
+	return err
+}
+
 func (a application) Finish() {
 	if SqlDbConnection != nil {
 		_ = SqlDbConnection.Close()
@@ -2,28 +2,121 @@ package application
 
 import (
 	"database/sql"
+	dbmigrations "finfit-backend/db_migrations"
+	accountServ "finfit-backend/internal/domain/services/account"
+	attachmentServ "finfit-backend/internal/domain/services/attachment"
+	balanceServ "finfit-backend/internal/domain/services/balance"
+	budgetServ "finfit-backend/internal/domain/services/budget"
+	exchangeRateServ "finfit-backend/internal/domain/services/exchangerate"
 	expenseService "finfit-backend/internal/domain/services/expense"
 	expenseTypeServ "finfit-backend/internal/domain/services/expensetype"
+	incomeServ "finfit-backend/internal/domain/services/income"
+	incomeSourceServ "finfit-backend/internal/domain/services/incomesource"
+	ledgerServ "finfit-backend/internal/domain/services/ledger"
+	recurringExpenseServ "finfit-backend/internal/domain/services/recurringexpense"
+	reportServ "finfit-backend/internal/domain/services/report"
+	tagServ "finfit-backend/internal/domain/services/tag"
+	"finfit-backend/internal/domain/services/unitofwork"
+	userServ "finfit-backend/internal/domain/services/user"
+	account2 "finfit-backend/internal/infrastructure/interfaces/handler/rest/account"
+	attachment2 "finfit-backend/internal/infrastructure/interfaces/handler/rest/attachment"
+	auth2 "finfit-backend/internal/infrastructure/interfaces/handler/rest/auth"
+	balance2 "finfit-backend/internal/infrastructure/interfaces/handler/rest/balance"
+	budget2 "finfit-backend/internal/infrastructure/interfaces/handler/rest/budget"
+	exchangerate2 "finfit-backend/internal/infrastructure/interfaces/handler/rest/exchangerate"
 	expense2 "finfit-backend/internal/infrastructure/interfaces/handler/rest/expense"
 	expensetype2 "finfit-backend/internal/infrastructure/interfaces/handler/rest/expensetype"
+	export2 "finfit-backend/internal/infrastructure/interfaces/handler/rest/export"
+	income2 "finfit-backend/internal/infrastructure/interfaces/handler/rest/income"
+	incomesource2 "finfit-backend/internal/infrastructure/interfaces/handler/rest/incomesource"
+	ledger2 "finfit-backend/internal/infrastructure/interfaces/handler/rest/ledger"
+	recurringexpense2 "finfit-backend/internal/infrastructure/interfaces/handler/rest/recurringexpense"
+	report2 "finfit-backend/internal/infrastructure/interfaces/handler/rest/report"
+	statementimport2 "finfit-backend/internal/infrastructure/interfaces/handler/rest/statementimport"
+	tag2 "finfit-backend/internal/infrastructure/interfaces/handler/rest/tag"
+	"finfit-backend/internal/infrastructure/repository/blobstore/local"
+	"finfit-backend/internal/infrastructure/repository/blobstore/s3"
+	"finfit-backend/internal/infrastructure/repository/memory"
+	sqlRepository "finfit-backend/internal/infrastructure/repository/sql"
+	"finfit-backend/internal/infrastructure/repository/sql/account"
+	"finfit-backend/internal/infrastructure/repository/sql/attachment"
+	"finfit-backend/internal/infrastructure/repository/sql/balance"
+	"finfit-backend/internal/infrastructure/repository/sql/budget"
+	"finfit-backend/internal/infrastructure/repository/sql/exchangerate"
 	"finfit-backend/internal/infrastructure/repository/sql/expense"
 	"finfit-backend/internal/infrastructure/repository/sql/expensetype"
+	"finfit-backend/internal/infrastructure/repository/sql/income"
+	"finfit-backend/internal/infrastructure/repository/sql/incomesource"
+	"finfit-backend/internal/infrastructure/repository/sql/ledger"
+	"finfit-backend/internal/infrastructure/repository/sql/migration"
+	"finfit-backend/internal/infrastructure/repository/sql/recurringexpense"
+	"finfit-backend/internal/infrastructure/repository/sql/report"
+	"finfit-backend/internal/infrastructure/repository/sql/tag"
+	"finfit-backend/internal/infrastructure/repository/sql/user"
 	"finfit-backend/pkg/fieldvalidation"
+	"finfit-backend/pkg/token"
 	"fmt"
+	"github.com/glebarez/sqlite"
 	"github.com/labstack/gommon/log"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
+	"io/fs"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 )
 
 var WireExpenseTypeRepository func()
 var WireExpenseRepository func()
+var WireExpenseTypeUnitOfWork func()
+var WireExpenseUnitOfWork func()
 var WireExpenseTypeService func()
 var WireExpenseService func()
 var WireExpenseHandler func()
 var WireExpenseTypeHandler func()
+var WireIncomeSourceRepository func()
+var WireIncomeRepository func()
+var WireIncomeSourceService func()
+var WireIncomeService func()
+var WireIncomeHandler func()
+var WireIncomeSourceHandler func()
+var WireAccountRepository func()
+var WireAccountService func()
+var WireAccountHandler func()
+var WireBudgetRepository func()
+var WireBudgetService func()
+var WireBudgetHandler func()
+var WireRecurringExpenseRepository func()
+var WireRecurringExpenseUnitOfWork func()
+var WireRecurringExpenseService func()
+var WireRecurringExpenseHandler func()
+var WireReportRepository func()
+var WireReportService func()
+var WireReportHandler func()
+var WireExchangeRateRepository func()
+var WireExchangeRateService func()
+var WireExchangeRateHandler func()
+var WireStatementImportHandler func()
+var WireExportHandler func()
+var WireUserRepository func()
+var WireUserService func()
+var WireAuthHandler func()
+var WireLedgerRepository func()
+var WireLedgerService func()
+var WireLedgerHandler func()
+var WireBalanceRepository func()
+var WireBalanceService func()
+var WireBalanceHandler func()
+var WireTagRepository func()
+var WireTagService func()
+var WireTagHandler func()
+var WireAttachmentRepository func()
+var WireAttachmentService func()
+var WireAttachmentHandler func()
+var WireMigrator func()
+var WireBlobStore func()
 var WireDbConnection func()
 var WireGenericFieldsValidator func()
 var WireConfigurations func()
@@ -34,7 +127,36 @@ const (
 	databaseUserConfigKey     = "DATABASE_USER"
 	databasePasswordConfigKey = "DATABASE_PASSWORD"
 	databaseNameConfigKey     = "DATABASE_NAME"
-	databaseDriverConfigKey   = "DATABASE_DRIVER"
+	// DATABASE_DRIVER is sqlite to keep the data in the SQLite database file named by DATABASE_NAME, or the name of the
+	// database/sql driver of Postgres, like pgx.
+	databaseDriverConfigKey = "DATABASE_DRIVER"
+	sqliteDatabaseDriver    = "sqlite"
+	jwtSecretConfigKey      = "JWT_SECRET"
+	// REPOSITORY_DRIVER set to memory keeps every entity in memory instead of the database, for demos. No database is
+	// connected to then, and everything is lost when the app stops.
+	repositoryDriverConfigKey = "REPOSITORY_DRIVER"
+	memoryRepositoryDriver    = "memory"
+	// MIGRATE_ON_START set to true applies the pending migrations before the server starts.
+	migrateOnStartConfigKey = "MIGRATE_ON_START"
+	// BLOB_STORE_DRIVER is local, the default, to keep the attachments under BLOB_STORE_PATH, or s3 to keep them in a
+	// bucket of S3 or of a store compatible with it.
+	blobStoreDriverConfigKey   = "BLOB_STORE_DRIVER"
+	blobStorePathConfigKey     = "BLOB_STORE_PATH"
+	s3EndpointConfigKey        = "S3_ENDPOINT"
+	s3RegionConfigKey          = "S3_REGION"
+	s3BucketConfigKey          = "S3_BUCKET"
+	s3AccessKeyIdConfigKey     = "S3_ACCESS_KEY_ID"
+	s3SecretAccessKeyConfigKey = "S3_SECRET_ACCESS_KEY"
+	defaultBlobStorePath       = "attachments"
+	blobStoreTimeout           = 30 * time.Second
+	accessTokenTTL             = 15 * time.Minute
+	refreshTokenTTL            = 30 * 24 * time.Hour
+	// REQUEST_TIMEOUT is how long a request can take before its queries are cancelled, as a Go duration like 10s.
+	requestTimeoutConfigKey = "REQUEST_TIMEOUT"
+	defaultRequestTimeout   = 30 * time.Second
+	// FILE_TRANSFER_TIMEOUT is the same for the exports and the attachments, which stream files and take longer.
+	fileTransferTimeoutConfigKey = "FILE_TRANSFER_TIMEOUT"
+	defaultFileTransferTimeout   = 10 * time.Minute
 )
 
 type Configurations interface {
@@ -49,7 +171,7 @@ func (c *configurations) Load() {
 	c.configs = map[string]string{}
 	environmentVariables := os.Environ()
 	for _, variable := range environmentVariables {
-		keyValuePair := strings.Split(variable, "=")
+		keyValuePair := strings.SplitN(variable, "=", 2)
 		c.configs[keyValuePair[0]] = keyValuePair[1]
 	}
 }
@@ -60,19 +182,144 @@ func (c *configurations) GetString(key string) string {
 
 // TODO: el nombre de las tablas tiene que venir por config
 func wireExpenseTypeRepository() {
-	ExpenseTypeRepository = expensetype.NewRepository(Database, "expense_type")
+	ExpenseTypeRepository = newExpenseTypeRepository(Database)
 }
 
 func wireExpenseRepository() {
-	ExpenseRepository = expense.NewRepository(Database, "expense")
+	ExpenseRepository = newExpenseRepository(Database)
+}
+
+func newExpenseTypeRepository(db *gorm.DB) expenseTypeServ.Repository {
+	return expensetype.NewRepository(db, "expense_type", "expense")
+}
+
+func newExpenseRepository(db *gorm.DB) expenseService.Repository {
+	repository := expense.NewRepository(db, "expense", "expense_split_participant", "expense_type", "tag", "expense_tag")
+	if db.Dialector.Name() == sqliteDatabaseDriver {
+		return expense.WithoutFullTextSearch(repository)
+	}
+	return repository
+}
+
+// wireExpenseTypeUnitOfWork builds the repository of the expense type service on the transaction of every operation.
+func wireExpenseTypeUnitOfWork() {
+	ExpenseTypeUnitOfWork = sqlRepository.NewUnitOfWork(Database, newExpenseTypeRepository)
+}
+
+// wireExpenseUnitOfWork builds the repositories of the expense service on the transaction of every operation.
+func wireExpenseUnitOfWork() {
+	ExpenseUnitOfWork = sqlRepository.NewUnitOfWork(Database, func(db *gorm.DB) expenseService.Repositories {
+		return expenseService.Repositories{Expenses: newExpenseRepository(db), ExpenseTypes: newExpenseTypeRepository(db)}
+	})
+}
+
+// usesMemoryRepositories tells whether every entity is kept in memory instead of the database.
+func usesMemoryRepositories() bool {
+	return Configs.GetString(repositoryDriverConfigKey) == memoryRepositoryDriver
+}
+
+// wireWithoutDatabase replaces the wiring of the database connection and of its migrator, there's no database to
+// connect to when every entity is kept in memory.
+func wireWithoutDatabase() {}
+
+func wireMemoryExpenseTypeRepository() {
+	wireMemoryDatabase()
+	ExpenseTypeRepository = memory.NewExpenseTypeRepository(MemoryDatabase)
+}
+
+func wireMemoryExpenseRepository() {
+	wireMemoryDatabase()
+	ExpenseRepository = memory.NewExpenseRepository(MemoryDatabase)
+}
+
+// wireMemoryExpenseTypeUnitOfWork shares the in-memory repository, which doesn't roll back its changes.
+func wireMemoryExpenseTypeUnitOfWork() {
+	ExpenseTypeUnitOfWork = unitofwork.WithoutTransaction(ExpenseTypeRepository)
+}
+
+// wireMemoryExpenseUnitOfWork shares the in-memory repositories, which don't roll back their changes.
+func wireMemoryExpenseUnitOfWork() {
+	ExpenseUnitOfWork = unitofwork.WithoutTransaction(expenseService.Repositories{Expenses: ExpenseRepository, ExpenseTypes: ExpenseTypeRepository})
+}
+
+func wireMemoryIncomeSourceRepository() {
+	wireMemoryDatabase()
+	IncomeSourceRepository = memory.NewIncomeSourceRepository(MemoryDatabase)
+}
+
+func wireMemoryIncomeRepository() {
+	wireMemoryDatabase()
+	IncomeRepository = memory.NewIncomeRepository(MemoryDatabase)
+}
+
+func wireMemoryAccountRepository() {
+	wireMemoryDatabase()
+	AccountRepository = memory.NewAccountRepository(MemoryDatabase)
+}
+
+func wireMemoryBudgetRepository() {
+	wireMemoryDatabase()
+	BudgetRepository = memory.NewBudgetRepository(MemoryDatabase)
+}
+
+func wireMemoryRecurringExpenseRepository() {
+	wireMemoryDatabase()
+	RecurringExpenseRepository = memory.NewRecurringExpenseRepository(MemoryDatabase)
+}
+
+// wireMemoryRecurringExpenseUnitOfWork shares the in-memory repositories, which don't roll back their changes.
+func wireMemoryRecurringExpenseUnitOfWork() {
+	RecurringExpenseUnitOfWork = unitofwork.WithoutTransaction(recurringExpenseServ.Repositories{RecurringExpenses: RecurringExpenseRepository, Expenses: ExpenseRepository})
+}
+
+func wireMemoryReportRepository() {
+	wireMemoryDatabase()
+	ReportRepository = memory.NewReportRepository(MemoryDatabase)
+}
+
+func wireMemoryExchangeRateRepository() {
+	wireMemoryDatabase()
+	ExchangeRateRepository = memory.NewExchangeRateRepository(MemoryDatabase)
+}
+
+func wireMemoryUserRepository() {
+	wireMemoryDatabase()
+	UserRepository = memory.NewUserRepository(MemoryDatabase)
+}
+
+func wireMemoryLedgerRepository() {
+	wireMemoryDatabase()
+	LedgerRepository = memory.NewLedgerRepository(MemoryDatabase)
+}
+
+func wireMemoryBalanceRepository() {
+	wireMemoryDatabase()
+	BalanceRepository = memory.NewBalanceRepository(MemoryDatabase)
+}
+
+func wireMemoryTagRepository() {
+	wireMemoryDatabase()
+	TagRepository = memory.NewTagRepository(MemoryDatabase)
+}
+
+func wireMemoryAttachmentRepository() {
+	wireMemoryDatabase()
+	AttachmentRepository = memory.NewAttachmentRepository(MemoryDatabase)
+}
+
+// wireMemoryDatabase creates the in-memory database once, all the in-memory repositories must share it.
+func wireMemoryDatabase() {
+	if MemoryDatabase == nil {
+		MemoryDatabase = memory.NewDatabase()
+	}
 }
 
 func wireExpenseTypeService() {
-	ExpenseTypeService = expenseTypeServ.NewService(ExpenseTypeRepository)
+	ExpenseTypeService = expenseTypeServ.NewService(ExpenseTypeRepository, ExpenseTypeUnitOfWork, LedgerService)
 }
 
 func wireExpenseService() {
-	ExpenseService = expenseService.NewService(ExpenseRepository, ExpenseTypeService)
+	ExpenseService = expenseService.NewService(ExpenseRepository, ExpenseUnitOfWork, ExpenseTypeService, AccountService, ExchangeRateService, LedgerService)
 }
 
 func wireExpenseHandler() {
@@ -83,25 +330,256 @@ func wireExpenseTypeHandler() {
 	ExpenseTypeHandler = expensetype2.NewHandler(ExpenseTypeService, GenericFieldsValidator)
 }
 
-// TODO: el nombre del schema tiene que venir por config
+func wireIncomeSourceRepository() {
+	IncomeSourceRepository = incomesource.NewRepository(Database, "income_source")
+}
+
+func wireIncomeRepository() {
+	IncomeRepository = income.NewRepository(Database, "income")
+}
+
+func wireIncomeSourceService() {
+	IncomeSourceService = incomeSourceServ.NewService(IncomeSourceRepository)
+}
+
+func wireIncomeService() {
+	IncomeService = incomeServ.NewService(IncomeRepository, IncomeSourceService)
+}
+
+func wireIncomeHandler() {
+	IncomeHandler = income2.NewHandler(IncomeService, GenericFieldsValidator)
+}
+
+func wireIncomeSourceHandler() {
+	IncomeSourceHandler = incomesource2.NewHandler(IncomeSourceService, GenericFieldsValidator)
+}
+
+func wireAccountRepository() {
+	AccountRepository = account.NewRepository(Database, "account", "transfer", "expense")
+}
+
+func wireAccountService() {
+	AccountService = accountServ.NewService(AccountRepository)
+}
+
+func wireAccountHandler() {
+	AccountHandler = account2.NewHandler(AccountService, GenericFieldsValidator)
+}
+
+func wireBudgetRepository() {
+	BudgetRepository = budget.NewRepository(Database, "budget")
+}
+
+func wireBudgetService() {
+	BudgetService = budgetServ.NewService(BudgetRepository, ExpenseTypeService, ExpenseService)
+}
+
+func wireBudgetHandler() {
+	BudgetHandler = budget2.NewHandler(BudgetService, GenericFieldsValidator)
+}
+
+func wireRecurringExpenseRepository() {
+	RecurringExpenseRepository = recurringexpense.NewRepository(Database, "recurring_expense")
+}
+
+// wireRecurringExpenseUnitOfWork builds the repositories the recurring expenses are generated through on the
+// transaction of every operation.
+func wireRecurringExpenseUnitOfWork() {
+	RecurringExpenseUnitOfWork = sqlRepository.NewUnitOfWork(Database, func(db *gorm.DB) recurringExpenseServ.Repositories {
+		return recurringExpenseServ.Repositories{RecurringExpenses: recurringexpense.NewRepository(db, "recurring_expense"), Expenses: newExpenseRepository(db)}
+	})
+}
+
+func wireRecurringExpenseService() {
+	RecurringExpenseService = recurringExpenseServ.NewService(RecurringExpenseRepository, RecurringExpenseUnitOfWork, ExpenseTypeService)
+}
+
+func wireRecurringExpenseHandler() {
+	RecurringExpenseHandler = recurringexpense2.NewHandler(RecurringExpenseService, GenericFieldsValidator)
+}
+
+func wireReportRepository() {
+	ReportRepository = report.NewRepository(Database, "expense", "expense_type")
+}
+
+func wireReportService() {
+	ReportService = reportServ.NewService(ReportRepository, ExchangeRateService, ExpenseTypeService, LedgerService)
+}
+
+func wireReportHandler() {
+	ReportHandler = report2.NewHandler(ReportService, GenericFieldsValidator)
+}
+
+func wireExchangeRateRepository() {
+	ExchangeRateRepository = exchangerate.NewRepository(Database, "exchange_rate")
+}
+
+func wireExchangeRateService() {
+	ExchangeRateService = exchangeRateServ.NewService(ExchangeRateRepository, ExchangeRateRepository)
+}
+
+func wireExchangeRateHandler() {
+	ExchangeRateHandler = exchangerate2.NewHandler(ExchangeRateService, GenericFieldsValidator)
+}
+
+func wireStatementImportHandler() {
+	StatementImportHandler = statementimport2.NewHandler(ExpenseService, GenericFieldsValidator)
+}
+
+func wireExportHandler() {
+	ExportHandler = export2.NewHandler(ExpenseService, GenericFieldsValidator)
+}
+
+func wireUserRepository() {
+	UserRepository = user.NewRepository(Database, "app_user", "used_refresh_token")
+}
+
+func wireUserService() {
+	tokenManager, err := token.NewManager([]byte(Configs.GetString(jwtSecretConfigKey)), accessTokenTTL, refreshTokenTTL)
+	if err != nil {
+		log.Panic(err)
+	}
+	UserService = userServ.NewService(UserRepository, tokenManager)
+}
+
+func wireAuthHandler() {
+	AuthHandler = auth2.NewHandler(UserService, GenericFieldsValidator)
+}
+
+func wireLedgerRepository() {
+	LedgerRepository = ledger.NewRepository(Database, "ledger", "ledger_member", "ledger_invitation")
+}
+
+func wireLedgerService() {
+	LedgerService = ledgerServ.NewService(LedgerRepository)
+}
+
+func wireLedgerHandler() {
+	LedgerHandler = ledger2.NewHandler(LedgerService, GenericFieldsValidator)
+}
+
+func wireBalanceRepository() {
+	BalanceRepository = balance.NewRepository(Database, "expense", "expense_split_participant", "settlement")
+}
+
+func wireBalanceService() {
+	BalanceService = balanceServ.NewService(BalanceRepository, LedgerService)
+}
+
+func wireBalanceHandler() {
+	BalanceHandler = balance2.NewHandler(BalanceService, GenericFieldsValidator)
+}
+
+func wireTagRepository() {
+	TagRepository = tag.NewRepository(Database, "tag", "expense_tag")
+}
+
+func wireTagService() {
+	TagService = tagServ.NewService(TagRepository, LedgerService)
+}
+
+func wireTagHandler() {
+	TagHandler = tag2.NewHandler(TagService, GenericFieldsValidator)
+}
+
+func wireAttachmentRepository() {
+	AttachmentRepository = attachment.NewRepository(Database, "attachment")
+}
+
+func wireAttachmentService() {
+	AttachmentService = attachmentServ.NewService(AttachmentRepository, AttachmentBlobStore, ExpenseService, LedgerService)
+}
+
+func wireAttachmentHandler() {
+	AttachmentHandler = attachment2.NewHandler(AttachmentService, GenericFieldsValidator)
+}
+
+func wireMigrator() {
+	dialect, files := migration.PostgresDialect, fs.FS(dbmigrations.Files)
+	if Configs.GetString(databaseDriverConfigKey) == sqliteDatabaseDriver {
+		dialect, files = migration.SQLiteDialect, dbmigrations.SQLiteFiles()
+	}
+
+	migrator, err := migration.NewMigrator(SqlDbConnection, dialect, files)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	Migrator = migrator
+}
+
+func wireBlobStore() {
+	switch Configs.GetString(blobStoreDriverConfigKey) {
+	case "s3":
+		client := &http.Client{Timeout: blobStoreTimeout}
+		AttachmentBlobStore = s3.NewBlobStore(
+			Configs.GetString(s3EndpointConfigKey),
+			Configs.GetString(s3RegionConfigKey),
+			Configs.GetString(s3BucketConfigKey),
+			Configs.GetString(s3AccessKeyIdConfigKey),
+			Configs.GetString(s3SecretAccessKeyConfigKey),
+			client)
+	case "", "local":
+		path := Configs.GetString(blobStorePathConfigKey)
+		if path == "" {
+			path = defaultBlobStorePath
+		}
+		AttachmentBlobStore = local.NewBlobStore(path)
+	default:
+		log.Panic("unknown blob store driver " + Configs.GetString(blobStoreDriverConfigKey))
+	}
+}
+
+// requestTimeout is REQUEST_TIMEOUT, or defaultRequestTimeout when it isn't set.
+func requestTimeout() (time.Duration, error) {
+	return durationConfig(requestTimeoutConfigKey, defaultRequestTimeout)
+}
+
+// fileTransferTimeout is FILE_TRANSFER_TIMEOUT, or defaultFileTransferTimeout when it isn't set.
+func fileTransferTimeout() (time.Duration, error) {
+	return durationConfig(fileTransferTimeoutConfigKey, defaultFileTransferTimeout)
+}
+
+func durationConfig(key string, defaultDuration time.Duration) (time.Duration, error) {
+	rawDuration := Configs.GetString(key)
+	if rawDuration == "" {
+		return defaultDuration, nil
+	}
+
+	duration, err := time.ParseDuration(rawDuration)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid %s %q, must be a positive Go duration like 10s", key, rawDuration)
+	}
+	return duration, nil
+}
+
+// wireDbConnection opens the SQLite database file named by DATABASE_NAME when DATABASE_DRIVER is sqlite, and otherwise
+// the Postgres database through the DATABASE_DRIVER database/sql driver.
 func wireDbConnection() {
 	log.Info("starting database connection...")
 
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		Configs.GetString(databaseHostConfigKey),
-		Configs.GetString(databasePortConfigKey),
-		Configs.GetString(databaseUserConfigKey),
-		Configs.GetString(databasePasswordConfigKey),
-		Configs.GetString(databaseNameConfigKey))
-	sqlDB, err := sql.Open(Configs.GetString(databaseDriverConfigKey), dsn)
-	log.Info("DSN: " + dsn)
+	var sqlDB *sql.DB
+	var dialector gorm.Dialector
+	var err error
+	if Configs.GetString(databaseDriverConfigKey) == sqliteDatabaseDriver {
+		sqlDB, err = sql.Open(sqliteDatabaseDriver, sqlRepository.SQLiteDSN(Configs.GetString(databaseNameConfigKey)))
+		dialector = sqlite.Dialector{Conn: sqlDB}
+	} else {
+		dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+			Configs.GetString(databaseHostConfigKey),
+			Configs.GetString(databasePortConfigKey),
+			Configs.GetString(databaseUserConfigKey),
+			Configs.GetString(databasePasswordConfigKey),
+			Configs.GetString(databaseNameConfigKey))
+		sqlDB, err = sql.Open(Configs.GetString(databaseDriverConfigKey), dsn)
+		log.Info("database driver: " + Configs.GetString(databaseDriverConfigKey) + ", host: " + Configs.GetString(databaseHostConfigKey))
+		dialector = postgres.New(postgres.Config{Conn: sqlDB})
+	}
 	if err != nil {
 		log.Panic(err)
 	}
 
-	db, err := gorm.Open(postgres.New(postgres.Config{
-		Conn: sqlDB,
-	}), &gorm.Config{NamingStrategy: schema.NamingStrategy{TablePrefix: "public.", SingularTable: true}})
+	db, err := gorm.Open(dialector, &gorm.Config{NamingStrategy: schema.NamingStrategy{SingularTable: true}})
 
 	if err != nil {
 		log.Panic(err)
@@ -2,30 +2,105 @@ package application
 
 import (
 	"database/sql"
+	accountService "finfit-backend/internal/domain/services/account"
+	attachmentService "finfit-backend/internal/domain/services/attachment"
+	balanceService "finfit-backend/internal/domain/services/balance"
+	budgetService "finfit-backend/internal/domain/services/budget"
+	exchangeRateService "finfit-backend/internal/domain/services/exchangerate"
 	expenseService "finfit-backend/internal/domain/services/expense"
 	expenseTypeService "finfit-backend/internal/domain/services/expensetype"
+	incomeService "finfit-backend/internal/domain/services/income"
+	incomeSourceService "finfit-backend/internal/domain/services/incomesource"
+	ledgerService "finfit-backend/internal/domain/services/ledger"
+	recurringExpenseService "finfit-backend/internal/domain/services/recurringexpense"
+	reportService "finfit-backend/internal/domain/services/report"
+	tagService "finfit-backend/internal/domain/services/tag"
+	"finfit-backend/internal/domain/services/unitofwork"
+	userService "finfit-backend/internal/domain/services/user"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/account"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/attachment"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/auth"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/balance"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/budget"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/exchangerate"
 	"finfit-backend/internal/infrastructure/interfaces/handler/rest/expense"
 	"finfit-backend/internal/infrastructure/interfaces/handler/rest/expensetype"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/export"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/income"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/incomesource"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/ledger"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/recurringexpense"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/report"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/statementimport"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/tag"
+	"finfit-backend/internal/infrastructure/repository/memory"
+	"finfit-backend/internal/infrastructure/repository/sql/migration"
 	"finfit-backend/pkg/fieldvalidation"
 	"gorm.io/gorm"
 )
 
 var (
-	ExpenseHandler         expense.Handler
-	ExpenseTypeHandler     expensetype.Handler
-	Database               *gorm.DB
-	GenericFieldsValidator fieldvalidation.FieldsValidator
-	ExpenseRepository      expenseService.Repository
-	ExpenseTypeRepository  expenseTypeService.Repository
-	ExpenseService         expenseService.Service
-	ExpenseTypeService     expenseTypeService.Service
-	SqlDbConnection        *sql.DB
-	Configs                Configurations
+	ExpenseHandler             expense.Handler
+	ExpenseTypeHandler         expensetype.Handler
+	Database                   *gorm.DB
+	GenericFieldsValidator     fieldvalidation.FieldsValidator
+	ExpenseRepository          expenseService.Repository
+	ExpenseTypeRepository      expenseTypeService.Repository
+	ExpenseTypeUnitOfWork      unitofwork.UnitOfWork[expenseTypeService.Repository]
+	ExpenseUnitOfWork          unitofwork.UnitOfWork[expenseService.Repositories]
+	ExpenseService             expenseService.Service
+	ExpenseTypeService         expenseTypeService.Service
+	IncomeHandler              income.Handler
+	IncomeSourceHandler        incomesource.Handler
+	IncomeRepository           incomeService.Repository
+	IncomeSourceRepository     incomeSourceService.Repository
+	IncomeService              incomeService.Service
+	IncomeSourceService        incomeSourceService.Service
+	AccountHandler             account.Handler
+	AccountRepository          accountService.Repository
+	AccountService             accountService.Service
+	BudgetHandler              budget.Handler
+	BudgetRepository           budgetService.Repository
+	BudgetService              budgetService.Service
+	RecurringExpenseHandler    recurringexpense.Handler
+	RecurringExpenseRepository recurringExpenseService.Repository
+	RecurringExpenseUnitOfWork unitofwork.UnitOfWork[recurringExpenseService.Repositories]
+	RecurringExpenseService    recurringExpenseService.Service
+	ReportHandler              report.Handler
+	ReportRepository           reportService.Repository
+	ReportService              reportService.Service
+	ExchangeRateHandler        exchangerate.Handler
+	ExchangeRateRepository     exchangeRateService.Repository
+	ExchangeRateService        exchangeRateService.Service
+	StatementImportHandler     statementimport.Handler
+	ExportHandler              export.Handler
+	UserRepository             userService.Repository
+	UserService                userService.Service
+	AuthHandler                auth.Handler
+	LedgerRepository           ledgerService.Repository
+	LedgerService              ledgerService.Service
+	LedgerHandler              ledger.Handler
+	BalanceRepository          balanceService.Repository
+	BalanceService             balanceService.Service
+	BalanceHandler             balance.Handler
+	TagRepository              tagService.Repository
+	TagService                 tagService.Service
+	TagHandler                 tag.Handler
+	AttachmentRepository       attachmentService.Repository
+	AttachmentBlobStore        attachmentService.BlobStore
+	AttachmentService          attachmentService.Service
+	AttachmentHandler          attachment.Handler
+	MemoryDatabase             *memory.Database
+	Migrator                   *migration.Migrator
+	SqlDbConnection            *sql.DB
+	Configs                    Configurations
 )
 
 func injectDependencies() {
 	WireConfigurations()
 	WireDbConnection()
+	WireMigrator()
+	WireBlobStore()
 	WireGenericFieldsValidator()
 	wireRepositories()
 	wireServices()
@@ -35,14 +110,55 @@ func injectDependencies() {
 func wireRepositories() {
 	WireExpenseTypeRepository()
 	WireExpenseRepository()
+	WireExpenseTypeUnitOfWork()
+	WireExpenseUnitOfWork()
+	WireIncomeSourceRepository()
+	WireIncomeRepository()
+	WireAccountRepository()
+	WireBudgetRepository()
+	WireRecurringExpenseRepository()
+	WireRecurringExpenseUnitOfWork()
+	WireReportRepository()
+	WireExchangeRateRepository()
+	WireUserRepository()
+	WireLedgerRepository()
+	WireBalanceRepository()
+	WireTagRepository()
+	WireAttachmentRepository()
 }
 
 func wireServices() {
+	WireExchangeRateService()
+	WireAccountService()
+	WireLedgerService()
 	WireExpenseTypeService()
 	WireExpenseService()
+	WireIncomeSourceService()
+	WireIncomeService()
+	WireBudgetService()
+	WireRecurringExpenseService()
+	WireReportService()
+	WireUserService()
+	WireBalanceService()
+	WireTagService()
+	WireAttachmentService()
 }
 
 func wireHandlers() {
 	WireExpenseHandler()
 	WireExpenseTypeHandler()
+	WireIncomeHandler()
+	WireIncomeSourceHandler()
+	WireAccountHandler()
+	WireBudgetHandler()
+	WireRecurringExpenseHandler()
+	WireReportHandler()
+	WireExchangeRateHandler()
+	WireStatementImportHandler()
+	WireExportHandler()
+	WireAuthHandler()
+	WireLedgerHandler()
+	WireBalanceHandler()
+	WireTagHandler()
+	WireAttachmentHandler()
 }
@@ -1,10 +1,93 @@
 package application
 
-import "github.com/labstack/echo/v4"
+import (
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"github.com/labstack/echo/v4"
+)
 
-func mapRoutes(e *echo.Echo) {
-	v1Group := e.Group("/v1")
-	v1Group.POST("/expenses", ExpenseHandler.Add)
-	v1Group.POST("/expense-types", ExpenseTypeHandler.Add)
-	v1Group.GET("/expenses", ExpenseHandler.SearchInPeriod)
+func mapRoutes(e *echo.Echo) error {
+	timeout, err := requestTimeout()
+	if err != nil {
+		return err
+	}
+	transferTimeout, err := fileTransferTimeout()
+	if err != nil {
+		return err
+	}
+	e.Use(rest.RequestDeadlineWithConfig(rest.RequestDeadlineConfig{Skipper: isFileTransfer, Timeout: timeout}))
+	fileTransferDeadline := rest.RequestDeadline(transferTimeout)
+
+	authGroup := e.Group("/v1/auth")
+	authGroup.POST("/register", AuthHandler.Register)
+	authGroup.POST("/login", AuthHandler.Login)
+	authGroup.POST("/refresh", AuthHandler.Refresh)
+
+	v1Group := e.Group("/v1", rest.Authentication(UserService))
+	// Expenses, expense types and balances are read from the ledger given in the ledger_id query param, the personal one by default
+	ledgerScope := rest.LedgerScope()
+	v1Group.POST("/expenses", ExpenseHandler.Add, ledgerScope)
+	v1Group.GET("/expenses/:id", ExpenseHandler.GetById, ledgerScope)
+	v1Group.PUT("/expenses/:id", ExpenseHandler.Update, ledgerScope)
+	v1Group.PATCH("/expenses/:id", ExpenseHandler.Patch, ledgerScope)
+	v1Group.DELETE("/expenses/:id", ExpenseHandler.Delete, ledgerScope)
+	v1Group.POST("/expense-types", ExpenseTypeHandler.Add, ledgerScope)
+	v1Group.GET("/expense-types", ExpenseTypeHandler.GetAll, ledgerScope)
+	v1Group.PUT("/expense-types/:id", ExpenseTypeHandler.Update, ledgerScope)
+	v1Group.DELETE("/expense-types/:id", ExpenseTypeHandler.Delete, ledgerScope)
+	v1Group.GET("/expenses", ExpenseHandler.SearchInPeriod, ledgerScope)
+	v1Group.GET("/expenses/search", ExpenseHandler.SearchText, ledgerScope)
+	v1Group.POST("/incomes", IncomeHandler.Add)
+	v1Group.GET("/incomes", IncomeHandler.SearchInPeriod)
+	v1Group.GET("/incomes/:id", IncomeHandler.GetById)
+	v1Group.POST("/income-sources", IncomeSourceHandler.Add)
+	v1Group.GET("/income-sources", IncomeSourceHandler.GetAll)
+	v1Group.POST("/accounts", AccountHandler.Add)
+	v1Group.GET("/accounts", AccountHandler.GetAll)
+	v1Group.GET("/accounts/:id", AccountHandler.GetById)
+	v1Group.GET("/accounts/:id/balance", AccountHandler.GetBalance)
+	v1Group.POST("/transfers", AccountHandler.Transfer)
+	v1Group.POST("/budgets", BudgetHandler.Add)
+	v1Group.GET("/budgets", BudgetHandler.GetAll)
+	v1Group.GET("/budgets/status", BudgetHandler.GetStatus)
+	v1Group.GET("/budgets/:id", BudgetHandler.GetById)
+	v1Group.PUT("/budgets/:id", BudgetHandler.Update)
+	v1Group.DELETE("/budgets/:id", BudgetHandler.Delete)
+	v1Group.POST("/recurring-expenses", RecurringExpenseHandler.Add)
+	v1Group.GET("/recurring-expenses", RecurringExpenseHandler.GetAll)
+	v1Group.POST("/recurring-expenses/generate", RecurringExpenseHandler.Generate)
+	v1Group.GET("/recurring-expenses/:id", RecurringExpenseHandler.GetById)
+	v1Group.DELETE("/recurring-expenses/:id", RecurringExpenseHandler.Delete)
+	v1Group.GET("/reports/spending", ReportHandler.GetSpending, ledgerScope)
+	v1Group.POST("/exchange-rates/import", ExchangeRateHandler.Import)
+	v1Group.POST("/imports/csv", StatementImportHandler.ImportCSV, ledgerScope)
+	v1Group.POST("/imports/ofx", StatementImportHandler.ImportOFX, ledgerScope)
+	v1Group.POST("/imports/qif", StatementImportHandler.ImportQIF, ledgerScope)
+	v1Group.GET("/exports/expenses", ExportHandler.ExportExpenses, ledgerScope, fileTransferDeadline)
+	v1Group.POST("/ledgers", LedgerHandler.Add)
+	v1Group.GET("/ledgers", LedgerHandler.GetAll)
+	v1Group.POST("/ledgers/invitations/accept", LedgerHandler.AcceptInvitation)
+	v1Group.GET("/ledgers/:id/members", LedgerHandler.GetMembers)
+	v1Group.POST("/ledgers/:id/invitations", LedgerHandler.Invite)
+	v1Group.GET("/balances", BalanceHandler.GetBalances, ledgerScope)
+	v1Group.POST("/balances/settlements", BalanceHandler.Settle, ledgerScope)
+	v1Group.GET("/tags", TagHandler.GetAll, ledgerScope)
+	v1Group.PATCH("/tags/:id", TagHandler.Rename, ledgerScope)
+	v1Group.POST("/tags/:id/merge", TagHandler.Merge, ledgerScope)
+	v1Group.POST("/expenses/:id/attachments", AttachmentHandler.Add, ledgerScope, fileTransferDeadline)
+	v1Group.GET("/expenses/:id/attachments", AttachmentHandler.GetAll, ledgerScope, fileTransferDeadline)
+	v1Group.GET("/expenses/:id/attachments/:attachment_id", AttachmentHandler.Download, ledgerScope, fileTransferDeadline)
+	v1Group.DELETE("/expenses/:id/attachments/:attachment_id", AttachmentHandler.Delete, ledgerScope, fileTransferDeadline)
+	return nil
+}
+
+// fileTransferPaths are the routes of the exports and the attachments, which stream files and have the deadline of
+// FILE_TRANSFER_TIMEOUT instead of the one of the rest of the requests.
+var fileTransferPaths = map[string]bool{
+	"/v1/exports/expenses":                        true,
+	"/v1/expenses/:id/attachments":                true,
+	"/v1/expenses/:id/attachments/:attachment_id": true,
+}
+
+func isFileTransfer(context echo.Context) bool {
+	return fileTransferPaths[context.Path()]
 }
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"errors"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+)
+
+type AccountKind string
+
+const (
+	BankAccountKind       AccountKind = "bank"
+	CreditCardAccountKind AccountKind = "credit_card"
+	CashAccountKind       AccountKind = "cash"
+	SavingsAccountKind    AccountKind = "savings"
+)
+
+var validAccountKinds = map[AccountKind]bool{
+	BankAccountKind:       true,
+	CreditCardAccountKind: true,
+	CashAccountKind:       true,
+	SavingsAccountKind:    true,
+}
+
+// Account is a source of funds (a bank account, a credit card, a wallet with cash) that expenses are paid from. Its
+// currency is the currency of the opening balance.
+type Account struct {
+	id             uuid.UUID
+	name           string
+	kind           AccountKind
+	openingBalance *Money
+}
+
+func NewAccount(name string, kind AccountKind, openingBalance *Money) (*Account, error) {
+	id := pkg.NewUUID()
+	err := validateAccount(id, name, kind, openingBalance)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{id: id, name: name, kind: kind, openingBalance: openingBalance}, nil
+}
+
+func NewAccountWithId(id uuid.UUID, name string, kind AccountKind, openingBalance *Money) (*Account, error) {
+	err := validateAccount(id, name, kind, openingBalance)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{id: id, name: name, kind: kind, openingBalance: openingBalance}, nil
+}
+
+func IsValidAccountKind(kind string) bool {
+	return validAccountKinds[AccountKind(kind)]
+}
+
+func validateAccount(id uuid.UUID, name string, kind AccountKind, openingBalance *Money) error {
+	if id == uuid.Nil {
+		return errors.New("invalid id, is must be a valid UUID")
+	}
+
+	if pkg.IsEmptyOrBlankString(name) {
+		return errors.New("invalid name, cannot be empty")
+	}
+
+	if !validAccountKinds[kind] {
+		return errors.New("invalid account kind")
+	}
+
+	if openingBalance == nil {
+		return errors.New("invalid opening balance, it cannot be null")
+	}
+	return nil
+}
+
+func (a Account) Id() uuid.UUID {
+	return a.id
+}
+
+func (a Account) Name() string {
+	return a.name
+}
+
+func (a Account) Kind() AccountKind {
+	return a.kind
+}
+
+func (a Account) Currency() string {
+	return a.openingBalance.Currency()
+}
+
+func (a Account) OpeningBalance() *Money {
+	return a.openingBalance
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"errors"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"regexp"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxAttachmentSize is the size of the largest file that can be attached to an expense, 10 MiB.
+	MaxAttachmentSize = 10 << 20
+	// MaxAttachmentFileNameLength is the length of the file_name column of attachments.
+	MaxAttachmentFileNameLength = 255
+)
+
+// attachmentContentTypes are the formats of the photos and scans of receipts.
+var attachmentContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/webp":      true,
+	"image/gif":       true,
+}
+
+var sha256Regexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Attachment is a file attached to an expense, usually the photo or the PDF of its receipt. Its content is kept in a
+// blob store apart from the expenses, and the checksum is the hex SHA-256 of the content, which tells apart the files
+// uploaded twice.
+type Attachment struct {
+	id          uuid.UUID
+	expenseId   uuid.UUID
+	fileName    string
+	contentType string
+	size        int64
+	checksum    string
+	uploadedAt  time.Time
+}
+
+func NewAttachment(expenseId uuid.UUID, fileName string, contentType string, size int64, checksum string, uploadedAt time.Time) (*Attachment, error) {
+	id := pkg.NewUUID()
+	return NewAttachmentWithId(id, expenseId, fileName, contentType, size, checksum, uploadedAt)
+}
+
+func NewAttachmentWithId(id uuid.UUID, expenseId uuid.UUID, fileName string, contentType string, size int64, checksum string, uploadedAt time.Time) (*Attachment, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("invalid id, is must be a valid UUID")
+	}
+
+	if expenseId == uuid.Nil {
+		return nil, errors.New("invalid expense, is must be a valid UUID")
+	}
+
+	if fileName == "" || utf8.RuneCountInString(fileName) > MaxAttachmentFileNameLength {
+		return nil, errors.New("invalid file name, it cannot be empty nor longer than 255 characters")
+	}
+
+	if !IsAllowedAttachmentContentType(contentType) {
+		return nil, errors.New("invalid content type, only PDF files and JPEG, PNG, WebP or GIF images can be attached")
+	}
+
+	if size <= 0 || size > MaxAttachmentSize {
+		return nil, errors.New("invalid size, the file cannot be empty nor larger than 10 MiB")
+	}
+
+	if !sha256Regexp.MatchString(checksum) {
+		return nil, errors.New("invalid checksum, it must be a hex SHA-256")
+	}
+
+	if uploadedAt.IsZero() {
+		return nil, errors.New("invalid upload date, it cannot be zero")
+	}
+
+	return &Attachment{id: id, expenseId: expenseId, fileName: fileName, contentType: contentType, size: size, checksum: checksum, uploadedAt: uploadedAt}, nil
+}
+
+func IsAllowedAttachmentContentType(contentType string) bool {
+	return attachmentContentTypes[contentType]
+}
+
+func (a Attachment) Id() uuid.UUID {
+	return a.id
+}
+
+func (a Attachment) ExpenseId() uuid.UUID {
+	return a.expenseId
+}
+
+func (a Attachment) FileName() string {
+	return a.fileName
+}
+
+func (a Attachment) ContentType() string {
+	return a.contentType
+}
+
+func (a Attachment) Size() int64 {
+	return a.size
+}
+
+func (a Attachment) Checksum() string {
+	return a.checksum
+}
+
+func (a Attachment) UploadedAt() time.Time {
+	return a.uploadedAt
+}
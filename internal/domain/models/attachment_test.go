@@ -0,0 +1,71 @@
+package models_test
+
+import (
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+	"time"
+)
+
+type AttachmentTestSuite struct {
+	suite.Suite
+}
+
+func TestAttachmentTestSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentTestSuite))
+}
+
+func (suite *AttachmentTestSuite) TestGivenAReceipt_WhenNewAttachment_ThenReturnIt() {
+	expenseId := uuid.New()
+	checksum := strings.Repeat("0f", 32)
+	uploadedAt := time.Date(2022, 5, 13, 10, 0, 0, 0, time.UTC)
+
+	attachment, err := models.NewAttachment(expenseId, "receipt.pdf", "application/pdf", 1024, checksum, uploadedAt)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expenseId, attachment.ExpenseId())
+	assert.Equal(suite.T(), "receipt.pdf", attachment.FileName())
+	assert.Equal(suite.T(), int64(1024), attachment.Size())
+	assert.Equal(suite.T(), checksum, attachment.Checksum())
+}
+
+func (suite *AttachmentTestSuite) TestGivenInvalidFields_WhenNewAttachment_ThenReturnError() {
+	expenseId := uuid.New()
+	checksum := strings.Repeat("0f", 32)
+	uploadedAt := time.Date(2022, 5, 13, 10, 0, 0, 0, time.UTC)
+
+	invalidAttachments := map[string]func() (*models.Attachment, error){
+		"no expense": func() (*models.Attachment, error) {
+			return models.NewAttachment(uuid.Nil, "a.pdf", "application/pdf", 1, checksum, uploadedAt)
+		},
+		"no file name": func() (*models.Attachment, error) {
+			return models.NewAttachment(expenseId, "", "application/pdf", 1, checksum, uploadedAt)
+		},
+		"text file": func() (*models.Attachment, error) {
+			return models.NewAttachment(expenseId, "a.txt", "text/plain", 1, checksum, uploadedAt)
+		},
+		"empty file": func() (*models.Attachment, error) {
+			return models.NewAttachment(expenseId, "a.pdf", "application/pdf", 0, checksum, uploadedAt)
+		},
+		"too large file": func() (*models.Attachment, error) {
+			return models.NewAttachment(expenseId, "a.pdf", "application/pdf", models.MaxAttachmentSize+1, checksum, uploadedAt)
+		},
+		"uppercase digest": func() (*models.Attachment, error) {
+			return models.NewAttachment(expenseId, "a.pdf", "application/pdf", 1, strings.ToUpper(checksum), uploadedAt)
+		},
+		"no upload date": func() (*models.Attachment, error) {
+			return models.NewAttachment(expenseId, "a.pdf", "application/pdf", 1, checksum, time.Time{})
+		},
+	}
+
+	for name, newAttachment := range invalidAttachments {
+		attachment, err := newAttachment()
+
+		assert.Error(suite.T(), err, name)
+		assert.Nil(suite.T(), attachment, name)
+	}
+}
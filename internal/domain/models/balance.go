@@ -0,0 +1,309 @@
+package models
+
+import (
+	"errors"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+// maxExactSimplificationSize is the largest number of users whose debts are simplified looking for the fewest
+// transfers. The search doubles its cost with every user, bigger groups use a greedy simplification instead.
+const maxExactSimplificationSize = 16
+
+// Debt is an amount a user owes another one.
+type Debt struct {
+	from   uuid.UUID
+	to     uuid.UUID
+	amount *Money
+}
+
+func NewDebt(from uuid.UUID, to uuid.UUID, amount *Money) (*Debt, error) {
+	if from == uuid.Nil || to == uuid.Nil {
+		return nil, errors.New("invalid users, they must be valid UUIDs")
+	}
+
+	if from == to {
+		return nil, errors.New("invalid users, a user can't owe money to themselves")
+	}
+
+	if amount == nil || !amount.IsPositive() {
+		return nil, errors.New("invalid debt amount, it must be greater than zero")
+	}
+
+	return &Debt{from: from, to: to, amount: amount}, nil
+}
+
+func (d Debt) From() uuid.UUID {
+	return d.from
+}
+
+func (d Debt) To() uuid.UUID {
+	return d.to
+}
+
+func (d Debt) Amount() *Money {
+	return d.amount
+}
+
+// Settlement is a repayment between two members of a ledger, which lowers what the payer owes the receiver.
+type Settlement struct {
+	id        uuid.UUID
+	from      uuid.UUID
+	to        uuid.UUID
+	amount    *Money
+	settledAt time.Time
+}
+
+func NewSettlement(from uuid.UUID, to uuid.UUID, amount *Money, settledAt time.Time) (*Settlement, error) {
+	id := pkg.NewUUID()
+	return NewSettlementWithId(id, from, to, amount, settledAt)
+}
+
+func NewSettlementWithId(id uuid.UUID, from uuid.UUID, to uuid.UUID, amount *Money, settledAt time.Time) (*Settlement, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("invalid id, is must be a valid UUID")
+	}
+
+	if _, err := NewDebt(from, to, amount); err != nil {
+		return nil, err
+	}
+
+	if settledAt.IsZero() {
+		return nil, errors.New("invalid settlement date, it cannot be zero")
+	}
+
+	return &Settlement{id: id, from: from, to: to, amount: amount, settledAt: settledAt}, nil
+}
+
+func (s Settlement) Id() uuid.UUID {
+	return s.id
+}
+
+func (s Settlement) From() uuid.UUID {
+	return s.from
+}
+
+func (s Settlement) To() uuid.UUID {
+	return s.to
+}
+
+func (s Settlement) Amount() *Money {
+	return s.amount
+}
+
+func (s Settlement) SettledAt() time.Time {
+	return s.settledAt
+}
+
+// Balance is the net amount of a currency a user is owed, when positive, or owes, when negative.
+type Balance struct {
+	userId uuid.UUID
+	amount *Money
+}
+
+func NewBalance(userId uuid.UUID, amount *Money) (*Balance, error) {
+	if userId == uuid.Nil {
+		return nil, errors.New("invalid user, is must be a valid UUID")
+	}
+
+	if amount == nil {
+		return nil, errors.New("invalid balance amount, it cannot be null")
+	}
+
+	return &Balance{userId: userId, amount: amount}, nil
+}
+
+func (b Balance) UserId() uuid.UUID {
+	return b.userId
+}
+
+func (b Balance) Amount() *Money {
+	return b.amount
+}
+
+// CalculateBalances nets the debts and the settlements of every user in every currency. Users who are even in a
+// currency have no balance in it. Balances are ordered by currency and then by user.
+func CalculateBalances(debts []*Debt, settlements []*Settlement) ([]*Balance, error) {
+	netAmounts := map[string]map[uuid.UUID]int64{}
+	move := func(from uuid.UUID, to uuid.UUID, amount *Money) {
+		currencyAmounts, ok := netAmounts[amount.Currency()]
+		if !ok {
+			currencyAmounts = map[uuid.UUID]int64{}
+			netAmounts[amount.Currency()] = currencyAmounts
+		}
+		currencyAmounts[from] -= amount.MinorUnits()
+		currencyAmounts[to] += amount.MinorUnits()
+	}
+
+	for _, debt := range debts {
+		move(debt.from, debt.to, debt.amount)
+	}
+
+	// the payer of a settlement owes less, as if the receiver had taken on a debt with them
+	for _, settlement := range settlements {
+		move(settlement.to, settlement.from, settlement.amount)
+	}
+
+	balances := []*Balance{}
+	for currency, currencyAmounts := range netAmounts {
+		for userId, netAmount := range currencyAmounts {
+			if netAmount == 0 {
+				continue
+			}
+			amount, err := NewMoneyFromMinorUnits(netAmount, currency)
+			if err != nil {
+				return nil, err
+			}
+			balances = append(balances, &Balance{userId: userId, amount: amount})
+		}
+	}
+
+	sortBalances(balances)
+	return balances, nil
+}
+
+// SimplifyDebts returns the transfers that even out the balances. When there are no more than
+// maxExactSimplificationSize users with a balance in a currency the transfers are the fewest possible: the users are
+// split into as many groups that add up to zero as possible, and each group of n users is settled with n-1 transfers.
+func SimplifyDebts(balances []*Balance) ([]*Debt, error) {
+	balancesByCurrency := map[string][]*Balance{}
+	currencies := []string{}
+	for _, balance := range balances {
+		if balance.amount.IsZero() {
+			continue
+		}
+		currency := balance.amount.Currency()
+		if _, ok := balancesByCurrency[currency]; !ok {
+			currencies = append(currencies, currency)
+		}
+		balancesByCurrency[currency] = append(balancesByCurrency[currency], balance)
+	}
+	sort.Strings(currencies)
+
+	debts := []*Debt{}
+	for _, currency := range currencies {
+		currencyBalances := balancesByCurrency[currency]
+		sortBalances(currencyBalances)
+
+		var total int64
+		for _, balance := range currencyBalances {
+			total += balance.amount.MinorUnits()
+		}
+		if total != 0 {
+			return nil, errors.New("invalid balances, they must add up to zero in every currency")
+		}
+
+		groups := [][]*Balance{currencyBalances}
+		if len(currencyBalances) <= maxExactSimplificationSize {
+			groups = splitIntoZeroSumGroups(currencyBalances)
+		}
+
+		for _, group := range groups {
+			groupDebts, err := settleGroup(group)
+			if err != nil {
+				return nil, err
+			}
+			debts = append(debts, groupDebts...)
+		}
+	}
+
+	return debts, nil
+}
+
+// splitIntoZeroSumGroups finds the largest number of disjoint groups of balances that add up to zero. For every set of
+// users, groups[set] is the most groups its users can be split into, which is the most groups of the set without one of
+// its users plus one when the set itself adds up to zero.
+func splitIntoZeroSumGroups(balances []*Balance) [][]*Balance {
+	size := len(balances)
+	setsCount := 1 << size
+	sums := make([]int64, setsCount)
+	groups := make([]int, setsCount)
+	removedUser := make([]int, setsCount)
+	for set := 1; set < setsCount; set++ {
+		lowestUser := 0
+		for set&(1<<lowestUser) == 0 {
+			lowestUser++
+		}
+		sums[set] = sums[set&^(1<<lowestUser)] + balances[lowestUser].amount.MinorUnits()
+
+		groups[set] = -1
+		for user := 0; user < size; user++ {
+			if set&(1<<user) != 0 && groups[set&^(1<<user)] > groups[set] {
+				groups[set] = groups[set&^(1<<user)]
+				removedUser[set] = user
+			}
+		}
+		if sums[set] == 0 {
+			groups[set]++
+		}
+	}
+
+	// removing users in the chosen order, every set that adds up to zero closes a group
+	zeroSumGroups := [][]*Balance{}
+	group := []*Balance{}
+	for set := setsCount - 1; set != 0; {
+		if sums[set] == 0 && len(group) > 0 {
+			zeroSumGroups = append(zeroSumGroups, group)
+			group = []*Balance{}
+		}
+		user := removedUser[set]
+		group = append(group, balances[user])
+		set &^= 1 << user
+	}
+	return append(zeroSumGroups, group)
+}
+
+// settleGroup pays the creditors of a group that adds up to zero, largest debts first. Every transfer evens out at
+// least one user and the last one evens out two, so n users need at most n-1 transfers.
+func settleGroup(group []*Balance) ([]*Debt, error) {
+	creditors := []*Balance{}
+	debtors := []*Balance{}
+	for _, balance := range group {
+		if balance.amount.IsPositive() {
+			creditors = append(creditors, &Balance{userId: balance.userId, amount: balance.amount})
+		} else {
+			debtors = append(debtors, &Balance{userId: balance.userId, amount: balance.amount})
+		}
+	}
+	sort.SliceStable(creditors, func(i, j int) bool { return creditors[i].amount.MinorUnits() > creditors[j].amount.MinorUnits() })
+	sort.SliceStable(debtors, func(i, j int) bool { return debtors[i].amount.MinorUnits() < debtors[j].amount.MinorUnits() })
+
+	debts := []*Debt{}
+	for creditorIndex, debtorIndex := 0, 0; creditorIndex < len(creditors) && debtorIndex < len(debtors); {
+		creditor := creditors[creditorIndex]
+		debtor := debtors[debtorIndex]
+
+		transferred := creditor.amount.MinorUnits()
+		if -debtor.amount.MinorUnits() < transferred {
+			transferred = -debtor.amount.MinorUnits()
+		}
+
+		amount, err := NewMoneyFromMinorUnits(transferred, creditor.amount.Currency())
+		if err != nil {
+			return nil, err
+		}
+		debts = append(debts, &Debt{from: debtor.userId, to: creditor.userId, amount: amount})
+
+		creditor.amount, _ = creditor.amount.Subtract(amount)
+		debtor.amount, _ = debtor.amount.Add(amount)
+		if creditor.amount.IsZero() {
+			creditorIndex++
+		}
+		if debtor.amount.IsZero() {
+			debtorIndex++
+		}
+	}
+
+	return debts, nil
+}
+
+func sortBalances(balances []*Balance) {
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].amount.Currency() != balances[j].amount.Currency() {
+			return balances[i].amount.Currency() < balances[j].amount.Currency()
+		}
+		return balances[i].userId.String() < balances[j].userId.String()
+	})
+}
@@ -0,0 +1,121 @@
+package models_test
+
+import (
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"sort"
+	"testing"
+	"time"
+)
+
+type BalanceTestSuite struct {
+	suite.Suite
+	users []uuid.UUID
+}
+
+func (suite *BalanceTestSuite) SetupSuite() {
+	suite.users = []uuid.UUID{}
+	for i := 0; i < 6; i++ {
+		suite.users = append(suite.users, uuid.New())
+	}
+	sort.Slice(suite.users, func(i, j int) bool { return suite.users[i].String() < suite.users[j].String() })
+}
+
+func TestBalanceTestSuite(t *testing.T) {
+	suite.Run(t, new(BalanceTestSuite))
+}
+
+func (suite *BalanceTestSuite) TestGivenDebtsAndSettlements_WhenCalculateBalances_ThenNetThemByUserAndCurrency() {
+	debts := []*models.Debt{
+		suite.debt(1, 0, "30", "EUR"),
+		suite.debt(2, 0, "30", "EUR"),
+		suite.debt(0, 1, "10", "EUR"),
+		suite.debt(2, 1, "5", "USD"),
+	}
+	settlement, _ := models.NewSettlement(suite.users[2], suite.users[0], suite.money("30", "EUR"), time.Now())
+
+	balances, err := models.CalculateBalances(debts, []*models.Settlement{settlement})
+
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), balances, 4)
+	suite.assertBalance(balances[0], 0, "20.00", "EUR")
+	suite.assertBalance(balances[1], 1, "-20.00", "EUR")
+	suite.assertBalance(balances[2], 1, "5.00", "USD")
+	suite.assertBalance(balances[3], 2, "-5.00", "USD")
+}
+
+func (suite *BalanceTestSuite) TestGivenTwoGroupsThatEvenOutApart_WhenSimplifyDebts_ThenSettleEachGroupApart() {
+	// settling the largest amounts first takes four transfers, the fewest are three: 2 pays 1, and 3 and 4 pay 0
+	balances := []*models.Balance{
+		suite.balance(0, "7"),
+		suite.balance(1, "6"),
+		suite.balance(2, "-6"),
+		suite.balance(3, "-4"),
+		suite.balance(4, "-3"),
+	}
+
+	debts, err := models.SimplifyDebts(balances)
+
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), debts, 3)
+	suite.assertEvensOut(balances, debts)
+}
+
+func (suite *BalanceTestSuite) TestGivenOneCreditor_WhenSimplifyDebts_ThenEveryDebtorPaysThem() {
+	balances := []*models.Balance{
+		suite.balance(0, "-10"),
+		suite.balance(1, "30"),
+		suite.balance(2, "-20"),
+	}
+
+	debts, err := models.SimplifyDebts(balances)
+
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), debts, 2)
+	suite.assertEvensOut(balances, debts)
+}
+
+func (suite *BalanceTestSuite) TestGivenBalancesThatDoNotAddUpToZero_WhenSimplifyDebts_ThenReturnError() {
+	_, err := models.SimplifyDebts([]*models.Balance{suite.balance(0, "10"), suite.balance(1, "-5")})
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *BalanceTestSuite) assertEvensOut(balances []*models.Balance, debts []*models.Debt) {
+	netAmounts := map[uuid.UUID]int64{}
+	for _, balance := range balances {
+		netAmounts[balance.UserId()] = balance.Amount().MinorUnits()
+	}
+	for _, debt := range debts {
+		assert.True(suite.T(), debt.Amount().IsPositive())
+		netAmounts[debt.From()] += debt.Amount().MinorUnits()
+		netAmounts[debt.To()] -= debt.Amount().MinorUnits()
+	}
+	for _, netAmount := range netAmounts {
+		assert.Zero(suite.T(), netAmount)
+	}
+}
+
+func (suite *BalanceTestSuite) assertBalance(balance *models.Balance, user int, amount string, currency string) {
+	assert.Equal(suite.T(), suite.users[user], balance.UserId())
+	assert.Equal(suite.T(), amount, balance.Amount().Amount())
+	assert.Equal(suite.T(), currency, balance.Amount().Currency())
+}
+
+func (suite *BalanceTestSuite) debt(from int, to int, amount string, currency string) *models.Debt {
+	debt, _ := models.NewDebt(suite.users[from], suite.users[to], suite.money(amount, currency))
+	return debt
+}
+
+func (suite *BalanceTestSuite) balance(user int, amount string) *models.Balance {
+	balance, _ := models.NewBalance(suite.users[user], suite.money(amount, "EUR"))
+	return balance
+}
+
+func (suite *BalanceTestSuite) money(amount string, currency string) *models.Money {
+	money, _ := models.NewMoney(amount, currency)
+	return money
+}
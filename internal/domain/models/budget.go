@@ -0,0 +1,94 @@
+package models
+
+import (
+	"errors"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"time"
+)
+
+type BudgetPeriod string
+
+const (
+	MonthlyBudgetPeriod BudgetPeriod = "monthly"
+)
+
+var validBudgetPeriods = map[BudgetPeriod]bool{
+	MonthlyBudgetPeriod: true,
+}
+
+// Budget limits how much can be spent on an expense type in each period. When rollover is enabled, the amount left
+// unspent in a period is added to the limit of the next one.
+type Budget struct {
+	id          uuid.UUID
+	expenseType *ExpenseType
+	period      BudgetPeriod
+	limit       *Money
+	rollover    bool
+}
+
+func NewBudget(expenseType *ExpenseType, period BudgetPeriod, limit *Money, rollover bool) (*Budget, error) {
+	id := pkg.NewUUID()
+	err := validateBudget(id, expenseType, period, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &Budget{id: id, expenseType: expenseType, period: period, limit: limit, rollover: rollover}, nil
+}
+
+func NewBudgetWithId(id uuid.UUID, expenseType *ExpenseType, period BudgetPeriod, limit *Money, rollover bool) (*Budget, error) {
+	err := validateBudget(id, expenseType, period, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &Budget{id: id, expenseType: expenseType, period: period, limit: limit, rollover: rollover}, nil
+}
+
+func IsValidBudgetPeriod(period string) bool {
+	return validBudgetPeriods[BudgetPeriod(period)]
+}
+
+func validateBudget(id uuid.UUID, expenseType *ExpenseType, period BudgetPeriod, limit *Money) error {
+	if id == uuid.Nil {
+		return errors.New("invalid id, is must be a valid UUID")
+	}
+
+	if expenseType == nil {
+		return errors.New("invalid expense type, it cannot be null")
+	}
+
+	if !validBudgetPeriods[period] {
+		return errors.New("invalid budget period")
+	}
+
+	if limit == nil || !limit.IsPositive() {
+		return errors.New("invalid budget limit, it must be greater than zero")
+	}
+	return nil
+}
+
+// PeriodBounds returns the first and the last day of the budget period that contains the given date.
+func (b Budget) PeriodBounds(date time.Time) (time.Time, time.Time) {
+	start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, -1)
+}
+
+func (b Budget) Id() uuid.UUID {
+	return b.id
+}
+
+func (b Budget) ExpenseType() *ExpenseType {
+	return b.expenseType
+}
+
+func (b Budget) Period() BudgetPeriod {
+	return b.period
+}
+
+func (b Budget) Limit() *Money {
+	return b.limit
+}
+
+func (b Budget) Rollover() bool {
+	return b.rollover
+}
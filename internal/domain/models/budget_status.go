@@ -0,0 +1,78 @@
+package models
+
+import (
+	"errors"
+	"math"
+)
+
+// BudgetStatus is the usage of a budget in a single period.
+type BudgetStatus struct {
+	budget      *Budget
+	spent       *Money
+	rolledOver  *Money
+	available   *Money
+	remaining   *Money
+	percentUsed float64
+}
+
+// NewBudgetStatus computes the usage of the budget given what was spent in the period and the amount rolled over from
+// the previous one. Both amounts must be in the currency of the budget limit.
+func NewBudgetStatus(budget *Budget, spent *Money, rolledOver *Money) (*BudgetStatus, error) {
+	if budget == nil || spent == nil || rolledOver == nil {
+		return nil, errors.New("invalid budget status, budget, spent and rolled over amounts cannot be null")
+	}
+
+	available, err := budget.Limit().Add(rolledOver)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining, err := available.Subtract(spent)
+	if err != nil {
+		return nil, err
+	}
+
+	percentUsed := 0.0
+	if available.IsPositive() {
+		percentUsed = math.Round(float64(spent.MinorUnits())*10000/float64(available.MinorUnits())) / 100
+	}
+
+	return &BudgetStatus{
+		budget:      budget,
+		spent:       spent,
+		rolledOver:  rolledOver,
+		available:   available,
+		remaining:   remaining,
+		percentUsed: percentUsed,
+	}, nil
+}
+
+func (b BudgetStatus) Budget() *Budget {
+	return b.budget
+}
+
+func (b BudgetStatus) Spent() *Money {
+	return b.spent
+}
+
+func (b BudgetStatus) RolledOver() *Money {
+	return b.rolledOver
+}
+
+// Available is the limit plus the amount rolled over from the previous period.
+func (b BudgetStatus) Available() *Money {
+	return b.available
+}
+
+// Remaining is negative when the budget is overspent.
+func (b BudgetStatus) Remaining() *Money {
+	return b.remaining
+}
+
+func (b BudgetStatus) PercentUsed() float64 {
+	return b.percentUsed
+}
+
+func (b BudgetStatus) IsOverspent() bool {
+	return b.remaining.MinorUnits() < 0
+}
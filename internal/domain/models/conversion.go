@@ -0,0 +1,55 @@
+package models
+
+import "errors"
+
+// Conversion is an amount expressed in a target currency together with the exchange rate used to get it. When no
+// rate is available the converted amount is nil and the conversion is flagged as missing its rate. Amounts already in
+// the target currency are kept as they are and don't need any rate.
+type Conversion struct {
+	targetCurrency string
+	amount         *Money
+	rate           *ExchangeRate
+}
+
+func NewConversion(original *Money, targetCurrency string, rate *ExchangeRate) (*Conversion, error) {
+	if original == nil || !validCurrencyCodes[targetCurrency] {
+		return nil, errors.New("invalid conversion, the amount cannot be null and the target currency must be valid")
+	}
+
+	if original.Currency() == targetCurrency {
+		return &Conversion{targetCurrency: targetCurrency, amount: original}, nil
+	}
+
+	if rate == nil {
+		return &Conversion{targetCurrency: targetCurrency}, nil
+	}
+
+	amount, err := rate.Convert(original)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount.Currency() != targetCurrency {
+		return nil, errors.New("invalid conversion, the rate doesn't convert to the target currency")
+	}
+
+	return &Conversion{targetCurrency: targetCurrency, amount: amount, rate: rate}, nil
+}
+
+func (c Conversion) TargetCurrency() string {
+	return c.targetCurrency
+}
+
+// Amount returns the converted amount, or nil when the rate is missing.
+func (c Conversion) Amount() *Money {
+	return c.amount
+}
+
+// Rate returns the rate used, or nil when the amount didn't need to be converted or the rate is missing.
+func (c Conversion) Rate() *ExchangeRate {
+	return c.rate
+}
+
+func (c Conversion) IsMissingRate() bool {
+	return c.amount == nil
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// RateDecimalPlaces is the maximum number of decimal places of an exchange rate.
+const RateDecimalPlaces = 10
+
+// ExchangeRate is the price of one unit of the base currency in the quote currency on a given day, e.g. 1 USD = 108.5
+// ARS. It converts amounts in both directions.
+type ExchangeRate struct {
+	baseCurrency  string
+	quoteCurrency string
+	date          time.Time
+	rate          *big.Rat
+}
+
+func NewExchangeRate(baseCurrency string, quoteCurrency string, date time.Time, rate string) (*ExchangeRate, error) {
+	if !validCurrencyCodes[baseCurrency] || !validCurrencyCodes[quoteCurrency] {
+		return nil, errors.New("invalid currency, must be a valid ISO 4217 currency code")
+	}
+
+	if baseCurrency == quoteCurrency {
+		return nil, errors.New("invalid exchange rate, base and quote currencies must be different")
+	}
+
+	if date.IsZero() {
+		return nil, errors.New("invalid exchange rate date, it cannot be zero")
+	}
+
+	parsedRate, err := parseRate(rate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExchangeRate{
+		baseCurrency:  baseCurrency,
+		quoteCurrency: quoteCurrency,
+		date:          time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+		rate:          parsedRate,
+	}, nil
+}
+
+func parseRate(rate string) (*big.Rat, error) {
+	invalidRateErr := errors.New("invalid rate, it must be a decimal number greater than zero")
+	rate = strings.TrimSpace(rate)
+
+	integerPart, fractionalPart, _ := strings.Cut(rate, ".")
+	if integerPart == "" && fractionalPart == "" {
+		return nil, invalidRateErr
+	}
+
+	for _, digit := range integerPart + fractionalPart {
+		if digit < '0' || digit > '9' {
+			return nil, invalidRateErr
+		}
+	}
+
+	if len(strings.TrimRight(fractionalPart, "0")) > RateDecimalPlaces {
+		return nil, errors.New("invalid rate, it has more decimal places than allowed")
+	}
+
+	parsedRate, ok := new(big.Rat).SetString(rate)
+	if !ok || parsedRate.Sign() <= 0 {
+		return nil, invalidRateErr
+	}
+
+	return parsedRate, nil
+}
+
+// Convert converts an amount in the base currency to the quote currency or the other way around, rounding half away
+// from zero to the minor units of the resulting currency.
+func (e ExchangeRate) Convert(amount *Money) (*Money, error) {
+	var targetCurrency string
+	factor := new(big.Rat)
+
+	switch amount.Currency() {
+	case e.baseCurrency:
+		targetCurrency = e.quoteCurrency
+		factor.Set(e.rate)
+	case e.quoteCurrency:
+		targetCurrency = e.baseCurrency
+		factor.Inv(e.rate)
+	default:
+		return nil, ErrCurrencyMismatch
+	}
+
+	converted := new(big.Rat).SetFrac(
+		new(big.Int).Mul(big.NewInt(amount.MinorUnits()), pow10(MinorUnitsOf(targetCurrency))),
+		pow10(MinorUnitsOf(amount.Currency())))
+	converted.Mul(converted, factor)
+
+	minorUnits := roundHalfAwayFromZero(converted)
+	if !minorUnits.IsInt64() {
+		return nil, ErrMoneyOverflow
+	}
+
+	return NewMoneyFromMinorUnits(minorUnits.Int64(), targetCurrency)
+}
+
+func (e ExchangeRate) BaseCurrency() string {
+	return e.baseCurrency
+}
+
+func (e ExchangeRate) QuoteCurrency() string {
+	return e.quoteCurrency
+}
+
+func (e ExchangeRate) Date() time.Time {
+	return e.date
+}
+
+// Rate returns the rate as a decimal string without trailing zeros.
+func (e ExchangeRate) Rate() string {
+	rate := e.rate.FloatString(RateDecimalPlaces)
+	if strings.Contains(rate, ".") {
+		rate = strings.TrimRight(strings.TrimRight(rate, "0"), ".")
+	}
+	return rate
+}
+
+func pow10(exponent int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
+
+func roundHalfAwayFromZero(value *big.Rat) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(value.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(value.Sign())))
+	}
+	return quotient
+}
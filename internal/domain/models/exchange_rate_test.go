@@ -0,0 +1,87 @@
+package models_test
+
+import (
+	"finfit-backend/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type ExchangeRateTestSuite struct {
+	suite.Suite
+}
+
+func TestExchangeRateTestSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeRateTestSuite))
+}
+
+func (suite *ExchangeRateTestSuite) TestGivenAnAmountInTheBaseCurrency_WhenConvert_ThenMultiplyByTheRate() {
+	rate, _ := models.NewExchangeRate("USD", "ARS", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), "108.455")
+	amount, _ := models.NewMoney("10.01", "USD")
+
+	converted, err := rate.Convert(amount)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "1085.63", converted.Amount())
+	assert.Equal(suite.T(), "ARS", converted.Currency())
+}
+
+func (suite *ExchangeRateTestSuite) TestGivenAnAmountInTheQuoteCurrency_WhenConvert_ThenDivideByTheRate() {
+	rate, _ := models.NewExchangeRate("EUR", "JPY", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), "130")
+	amount, _ := models.NewMoney("1000", "JPY")
+
+	converted, err := rate.Convert(amount)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "7.69", converted.Amount())
+	assert.Equal(suite.T(), "EUR", converted.Currency())
+}
+
+func (suite *ExchangeRateTestSuite) TestGivenAnAmountInAnotherCurrency_WhenConvert_ThenReturnCurrencyMismatchError() {
+	rate, _ := models.NewExchangeRate("USD", "ARS", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), "108.5")
+	amount, _ := models.NewMoney("10", "EUR")
+
+	_, err := rate.Convert(amount)
+
+	require.ErrorIs(suite.T(), err, models.ErrCurrencyMismatch)
+}
+
+func (suite *ExchangeRateTestSuite) TestGivenInvalidRates_WhenNewExchangeRate_ThenReturnError() {
+	date := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	for _, rate := range []string{"", "0", "-1", "1e3", "1/3", "0.00000000001"} {
+		_, err := models.NewExchangeRate("USD", "ARS", date, rate)
+		require.Error(suite.T(), err, rate)
+	}
+
+	_, err := models.NewExchangeRate("USD", "USD", date, "1")
+	require.Error(suite.T(), err)
+}
+
+func (suite *ExchangeRateTestSuite) TestGivenARateWithTrailingZeros_WhenRate_ThenReturnItTrimmed() {
+	rate, _ := models.NewExchangeRate("USD", "ARS", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), "108.5000")
+
+	assert.Equal(suite.T(), "108.5", rate.Rate())
+}
+
+func (suite *ExchangeRateTestSuite) TestGivenAMissingRate_WhenNewConversion_ThenFlagIt() {
+	amount, _ := models.NewMoney("10", "USD")
+
+	conversion, err := models.NewConversion(amount, "ARS", nil)
+
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), conversion.IsMissingRate())
+	assert.Nil(suite.T(), conversion.Amount())
+}
+
+func (suite *ExchangeRateTestSuite) TestGivenAnAmountInTheTargetCurrency_WhenNewConversion_ThenKeepIt() {
+	amount, _ := models.NewMoney("10", "ARS")
+
+	conversion, err := models.NewConversion(amount, "ARS", nil)
+
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), conversion.IsMissingRate())
+	assert.Equal(suite.T(), amount, conversion.Amount())
+	assert.Nil(suite.T(), conversion.Rate())
+}
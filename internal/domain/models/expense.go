@@ -4,6 +4,7 @@ import (
 	"errors"
 	"finfit-backend/pkg"
 	"github.com/google/uuid"
+	"strings"
 	"time"
 )
 
@@ -13,6 +14,11 @@ type Expense struct {
 	expenseDate time.Time
 	description string
 	expenseType *ExpenseType
+	account     *Account
+	conversion  *Conversion
+	fitId       string
+	split       *ExpenseSplit
+	tags        []string
 }
 
 func NewExpense(amount *Money, expenseDate time.Time, description string, expenseType *ExpenseType) (*Expense, error) {
@@ -39,8 +45,8 @@ func validateExpense(id uuid.UUID, amount *Money, expenseDate time.Time, expense
 		return errors.New("invalid id, is must be a valid UUID")
 	}
 
-	if amount.Amount() <= 0 {
-		return errors.New("invalid expense amount, it cannot be lower than 1.0")
+	if amount == nil || !amount.IsPositive() {
+		return errors.New("invalid expense amount, it must be greater than zero")
 	}
 
 	if expenseDate.IsZero() {
@@ -53,6 +59,52 @@ func validateExpense(id uuid.UUID, amount *Money, expenseDate time.Time, expense
 	return nil
 }
 
+// WithAccount returns a copy of the expense paid from the given account, which must use the expense currency.
+func (e Expense) WithAccount(account *Account) (*Expense, error) {
+	if account != nil && account.Currency() != e.amount.Currency() {
+		return nil, errors.New("invalid account, its currency must match the expense currency")
+	}
+
+	e.account = account
+	return &e, nil
+}
+
+// WithConversion returns a copy of the expense that also carries its amount converted to another currency.
+func (e Expense) WithConversion(conversion *Conversion) *Expense {
+	e.conversion = conversion
+	return &e
+}
+
+// WithFitId returns a copy of the expense tied to the transaction id (FITID) its bank gave it in an OFX statement.
+func (e Expense) WithFitId(fitId string) *Expense {
+	e.fitId = strings.TrimSpace(fitId)
+	return &e
+}
+
+// WithSplit returns a copy of the expense shared among the participants of the split, which must split its amount. A
+// nil split makes the expense belong to whoever records it again.
+func (e Expense) WithSplit(split *ExpenseSplit) (*Expense, error) {
+	if split != nil {
+		if comparison, err := split.Total().Compare(e.amount); err != nil || comparison != 0 {
+			return nil, errors.New("invalid split, its allocations must add up to the expense amount")
+		}
+	}
+
+	e.split = split
+	return &e, nil
+}
+
+// WithTags returns a copy of the expense labeled with the given tags, normalized, without duplicates and sorted.
+func (e Expense) WithTags(tags []string) (*Expense, error) {
+	normalizedTags, err := normalizeTagNames(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	e.tags = normalizedTags
+	return &e, nil
+}
+
 func (e Expense) Id() uuid.UUID {
 	return e.id
 }
@@ -72,3 +124,35 @@ func (e Expense) Description() string {
 func (e Expense) ExpenseType() *ExpenseType {
 	return e.expenseType
 }
+
+// Account returns the account the expense was paid from, or nil when it isn't tied to any account.
+func (e Expense) Account() *Account {
+	return e.account
+}
+
+// Conversion returns the amount converted to the currency requested when searching, or nil when none was requested.
+func (e Expense) Conversion() *Conversion {
+	return e.conversion
+}
+
+// FitId returns the bank transaction id of an expense imported from an OFX statement, or an empty string.
+func (e Expense) FitId() string {
+	return e.fitId
+}
+
+// Split returns how the expense is shared among the members of its ledger, or nil when it isn't split.
+func (e Expense) Split() *ExpenseSplit {
+	return e.split
+}
+
+// Tags returns the names of the tags of the expense, sorted.
+func (e Expense) Tags() []string {
+	return e.tags
+}
+
+// Fingerprint identifies the expense by its date, amount and description, ignoring case and spacing in the latter.
+// Two expenses with the same fingerprint are taken as the same bank transaction when there is no FITID to compare.
+func (e Expense) Fingerprint() string {
+	description := strings.Join(strings.Fields(strings.ToLower(e.description)), " ")
+	return e.expenseDate.Format("2006-01-02") + "|" + e.amount.Amount() + "|" + e.amount.Currency() + "|" + description
+}
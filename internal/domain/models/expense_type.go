@@ -4,11 +4,18 @@ import (
 	"errors"
 	"finfit-backend/pkg"
 	"github.com/google/uuid"
+	"strings"
 )
 
+// ExpenseTypePathSeparator joins the names of the ancestors of an expense type, e.g. Food > Restaurants > Delivery.
+const ExpenseTypePathSeparator = " > "
+
+// ExpenseType is a category of expenses. It can be nested under a parent one, which is linked along with all its
+// ancestors so the whole path is known.
 type ExpenseType struct {
-	id   uuid.UUID
-	name string
+	id     uuid.UUID
+	name   string
+	parent *ExpenseType
 }
 
 func NewExpenseType(name string) (*ExpenseType, error) {
@@ -47,3 +54,49 @@ func (e ExpenseType) Id() uuid.UUID {
 func (e ExpenseType) Name() string {
 	return e.name
 }
+
+// WithParent returns a copy of the expense type nested under parent, or at the top level when parent is nil. It fails
+// when parent is the expense type itself or one of its descendants, since the hierarchy would have a cycle.
+func (e ExpenseType) WithParent(parent *ExpenseType) (*ExpenseType, error) {
+	if parent != nil && parent.IsInSubtreeOf(e.id) {
+		return nil, errors.New("invalid parent, an expense type can't be nested under itself or one of its subtypes")
+	}
+
+	e.parent = parent
+	return &e, nil
+}
+
+func (e ExpenseType) Parent() *ExpenseType {
+	return e.parent
+}
+
+// ParentId returns uuid.Nil for the expense types at the top level.
+func (e ExpenseType) ParentId() uuid.UUID {
+	if e.parent == nil {
+		return uuid.Nil
+	}
+	return e.parent.id
+}
+
+// IsInSubtreeOf tells whether the expense type is the one with the given id or one of its descendants.
+func (e ExpenseType) IsInSubtreeOf(id uuid.UUID) bool {
+	for expenseType := &e; expenseType != nil; expenseType = expenseType.parent {
+		if expenseType.id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Path returns the names from the top level expense type down to this one.
+func (e ExpenseType) Path() []string {
+	path := []string{}
+	for expenseType := &e; expenseType != nil; expenseType = expenseType.parent {
+		path = append([]string{expenseType.name}, path...)
+	}
+	return path
+}
+
+func (e ExpenseType) FullPath() string {
+	return strings.Join(e.Path(), ExpenseTypePathSeparator)
+}
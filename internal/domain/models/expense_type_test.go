@@ -0,0 +1,63 @@
+package models_test
+
+import (
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGivenNestedExpenseTypes_WhenFullPath_ThenReturnTheNamesFromTheTopLevel(t *testing.T) {
+	food, delivery := getFoodAndDelivery()
+
+	assert.Equal(t, "Food > Restaurants > Delivery", delivery.FullPath())
+	assert.Equal(t, "Food", food.FullPath())
+	assert.Equal(t, uuid.Nil, food.ParentId())
+}
+
+func TestGivenNestedExpenseTypes_WhenIsInSubtreeOf_ThenIncludeItselfAndItsDescendants(t *testing.T) {
+	food, delivery := getFoodAndDelivery()
+	other, _ := models.NewExpenseType("Transport")
+
+	assert.True(t, delivery.IsInSubtreeOf(food.Id()))
+	assert.True(t, delivery.IsInSubtreeOf(delivery.Id()))
+	assert.False(t, food.IsInSubtreeOf(delivery.Id()))
+	assert.False(t, delivery.IsInSubtreeOf(other.Id()))
+}
+
+func TestGivenADescendantAsParent_WhenWithParent_ThenReturnError(t *testing.T) {
+	food, delivery := getFoodAndDelivery()
+
+	movedFood, err := food.WithParent(delivery)
+
+	assert.Nil(t, movedFood)
+	assert.EqualError(t, err, "invalid parent, an expense type can't be nested under itself or one of its subtypes")
+}
+
+func TestGivenItselfAsParent_WhenWithParent_ThenReturnError(t *testing.T) {
+	food, _ := models.NewExpenseType("Food")
+
+	movedFood, err := food.WithParent(food)
+
+	assert.Nil(t, movedFood)
+	assert.Error(t, err)
+}
+
+func TestGivenANilParent_WhenWithParent_ThenMoveItToTheTopLevel(t *testing.T) {
+	_, delivery := getFoodAndDelivery()
+
+	movedDelivery, err := delivery.WithParent(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Delivery", movedDelivery.FullPath())
+	assert.Equal(t, "Food > Restaurants > Delivery", delivery.FullPath())
+}
+
+func getFoodAndDelivery() (*models.ExpenseType, *models.ExpenseType) {
+	food, _ := models.NewExpenseType("Food")
+	restaurants, _ := models.NewExpenseType("Restaurants")
+	restaurants, _ = restaurants.WithParent(food)
+	delivery, _ := models.NewExpenseType("Delivery")
+	delivery, _ = delivery.WithParent(restaurants)
+	return food, delivery
+}
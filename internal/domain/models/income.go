@@ -0,0 +1,74 @@
+package models
+
+import (
+	"errors"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Income struct {
+	id           uuid.UUID
+	amount       *Money
+	incomeDate   time.Time
+	description  string
+	incomeSource *IncomeSource
+}
+
+func NewIncome(amount *Money, incomeDate time.Time, description string, incomeSource *IncomeSource) (*Income, error) {
+	id := pkg.NewUUID()
+	err := validateIncome(id, amount, incomeDate, incomeSource)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Income{id: id, amount: amount, incomeDate: incomeDate, description: description, incomeSource: incomeSource}, nil
+}
+
+func NewIncomeWithId(id uuid.UUID, amount *Money, incomeDate time.Time, description string, incomeSource *IncomeSource) (*Income, error) {
+	err := validateIncome(id, amount, incomeDate, incomeSource)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Income{id: id, amount: amount, incomeDate: incomeDate, description: description, incomeSource: incomeSource}, nil
+}
+
+func validateIncome(id uuid.UUID, amount *Money, incomeDate time.Time, incomeSource *IncomeSource) error {
+	if id == uuid.Nil {
+		return errors.New("invalid id, is must be a valid UUID")
+	}
+
+	if amount == nil || !amount.IsPositive() {
+		return errors.New("invalid income amount, it must be greater than zero")
+	}
+
+	if incomeDate.IsZero() {
+		return errors.New("invalid income date, it cannot be zero")
+	}
+
+	if incomeSource == nil {
+		return errors.New("invalid income source, it cannot be null")
+	}
+	return nil
+}
+
+func (i Income) Id() uuid.UUID {
+	return i.id
+}
+
+func (i Income) Amount() *Money {
+	return i.amount
+}
+
+func (i Income) IncomeDate() time.Time {
+	return i.incomeDate
+}
+
+func (i Income) Description() string {
+	return i.description
+}
+
+func (i Income) IncomeSource() *IncomeSource {
+	return i.incomeSource
+}
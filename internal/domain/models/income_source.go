@@ -0,0 +1,49 @@
+package models
+
+import (
+	"errors"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+)
+
+type IncomeSource struct {
+	id   uuid.UUID
+	name string
+}
+
+func NewIncomeSource(name string) (*IncomeSource, error) {
+	id := pkg.NewUUID()
+	err := validateIncomeSource(id, name)
+	if err != nil {
+		return nil, err
+	}
+	return &IncomeSource{id: id, name: name}, nil
+}
+
+func NewIncomeSourceWithId(id uuid.UUID, name string) (*IncomeSource, error) {
+	err := validateIncomeSource(id, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IncomeSource{id: id, name: name}, nil
+}
+
+func validateIncomeSource(id uuid.UUID, name string) error {
+	if id == uuid.Nil {
+		return errors.New("invalid id, is must be a valid UUID")
+	}
+
+	if pkg.IsEmptyOrBlankString(name) {
+		return errors.New("invalid name, cannot be empty")
+	}
+	return nil
+}
+
+func (i IncomeSource) Id() uuid.UUID {
+	return i.id
+}
+
+func (i IncomeSource) Name() string {
+	return i.name
+}
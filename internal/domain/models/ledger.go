@@ -0,0 +1,187 @@
+package models
+
+import (
+	"errors"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"time"
+)
+
+type LedgerRole string
+
+const (
+	OwnerLedgerRole  LedgerRole = "owner"
+	EditorLedgerRole LedgerRole = "editor"
+	ViewerLedgerRole LedgerRole = "viewer"
+)
+
+// ledgerRoleRanks orders the roles, every role can do everything the ones with a lower rank can.
+var ledgerRoleRanks = map[LedgerRole]int{
+	ViewerLedgerRole: 1,
+	EditorLedgerRole: 2,
+	OwnerLedgerRole:  3,
+}
+
+func IsValidLedgerRole(role string) bool {
+	return ledgerRoleRanks[LedgerRole(role)] > 0
+}
+
+// Allows tells if a member with the role can do what requires the given one.
+func (r LedgerRole) Allows(required LedgerRole) bool {
+	return ledgerRoleRanks[r] > 0 && ledgerRoleRanks[r] >= ledgerRoleRanks[required]
+}
+
+// PersonalLedgerId returns the id of the ledger every user has for their own expenses. It has the id of the user, so
+// it doesn't need to be stored, and nobody else can be a member of it.
+func PersonalLedgerId(userId uuid.UUID) uuid.UUID {
+	return userId
+}
+
+// Ledger groups the expenses and expense types shared by its members, like the ones of a household.
+type Ledger struct {
+	id   uuid.UUID
+	name string
+}
+
+func NewLedger(name string) (*Ledger, error) {
+	id := pkg.NewUUID()
+	return NewLedgerWithId(id, name)
+}
+
+func NewLedgerWithId(id uuid.UUID, name string) (*Ledger, error) {
+	err := validateLedger(id, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Ledger{id: id, name: name}, nil
+}
+
+func validateLedger(id uuid.UUID, name string) error {
+	if id == uuid.Nil {
+		return errors.New("invalid id, is must be a valid UUID")
+	}
+
+	if pkg.IsEmptyOrBlankString(name) {
+		return errors.New("invalid name, cannot be empty")
+	}
+
+	if pkg.ExceedsMax(name, 50) {
+		return errors.New("invalid name length, must be lower than 50")
+	}
+	return nil
+}
+
+func (l Ledger) Id() uuid.UUID {
+	return l.id
+}
+
+func (l Ledger) Name() string {
+	return l.name
+}
+
+// LedgerMember is the role a user has in a ledger.
+type LedgerMember struct {
+	ledgerId uuid.UUID
+	userId   uuid.UUID
+	role     LedgerRole
+}
+
+func NewLedgerMember(ledgerId uuid.UUID, userId uuid.UUID, role LedgerRole) (*LedgerMember, error) {
+	if ledgerId == uuid.Nil {
+		return nil, errors.New("invalid ledger id, is must be a valid UUID")
+	}
+
+	if userId == uuid.Nil {
+		return nil, errors.New("invalid user id, is must be a valid UUID")
+	}
+
+	if !IsValidLedgerRole(string(role)) {
+		return nil, errors.New("invalid role, it must be owner, editor or viewer")
+	}
+
+	return &LedgerMember{ledgerId: ledgerId, userId: userId, role: role}, nil
+}
+
+func (m LedgerMember) LedgerId() uuid.UUID {
+	return m.ledgerId
+}
+
+func (m LedgerMember) UserId() uuid.UUID {
+	return m.userId
+}
+
+func (m LedgerMember) Role() LedgerRole {
+	return m.role
+}
+
+// LedgerInvitation lets whoever has its token join the ledger with the role. Only the hash of the token is kept, and
+// the invitation can be used once, before it expires.
+type LedgerInvitation struct {
+	id        uuid.UUID
+	ledgerId  uuid.UUID
+	role      LedgerRole
+	tokenHash string
+	expiresAt time.Time
+}
+
+func NewLedgerInvitation(ledgerId uuid.UUID, role LedgerRole, tokenHash string, expiresAt time.Time) (*LedgerInvitation, error) {
+	id := pkg.NewUUID()
+	return NewLedgerInvitationWithId(id, ledgerId, role, tokenHash, expiresAt)
+}
+
+func NewLedgerInvitationWithId(id uuid.UUID, ledgerId uuid.UUID, role LedgerRole, tokenHash string, expiresAt time.Time) (*LedgerInvitation, error) {
+	err := validateLedgerInvitation(id, ledgerId, role, tokenHash, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LedgerInvitation{id: id, ledgerId: ledgerId, role: role, tokenHash: tokenHash, expiresAt: expiresAt}, nil
+}
+
+func validateLedgerInvitation(id uuid.UUID, ledgerId uuid.UUID, role LedgerRole, tokenHash string, expiresAt time.Time) error {
+	if id == uuid.Nil {
+		return errors.New("invalid id, is must be a valid UUID")
+	}
+
+	if ledgerId == uuid.Nil {
+		return errors.New("invalid ledger id, is must be a valid UUID")
+	}
+
+	if role != EditorLedgerRole && role != ViewerLedgerRole {
+		return errors.New("invalid role, members can only be invited as editor or viewer")
+	}
+
+	if pkg.IsEmptyOrBlankString(tokenHash) {
+		return errors.New("invalid token hash, cannot be empty")
+	}
+
+	if expiresAt.IsZero() {
+		return errors.New("invalid expiration date, cannot be empty")
+	}
+	return nil
+}
+
+func (i LedgerInvitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.expiresAt)
+}
+
+func (i LedgerInvitation) Id() uuid.UUID {
+	return i.id
+}
+
+func (i LedgerInvitation) LedgerId() uuid.UUID {
+	return i.ledgerId
+}
+
+func (i LedgerInvitation) Role() LedgerRole {
+	return i.role
+}
+
+func (i LedgerInvitation) TokenHash() string {
+	return i.tokenHash
+}
+
+func (i LedgerInvitation) ExpiresAt() time.Time {
+	return i.expiresAt
+}
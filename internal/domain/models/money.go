@@ -1,6 +1,12 @@
 package models
 
-import "errors"
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
 
 var validCurrencyCodes = map[string]bool{
 	"AFN": true, "EUR": true, "ALL": true, "DZD": true, "USD": true,
@@ -41,22 +47,242 @@ var validCurrencyCodes = map[string]bool{
 	"XAU": true, "XPD": true, "XPT": true, "XAG": true,
 }
 
+// currencyMinorUnits holds the ISO 4217 exponent of every currency that doesn't use the default of two decimal
+// places. Funds and precious metals without a defined exponent keep the default.
+var currencyMinorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+const defaultMinorUnits = 2
+
+var (
+	ErrCurrencyMismatch = errors.New("invalid operation, money amounts must have the same currency")
+	ErrMoneyOverflow    = errors.New("invalid operation, the resulting amount overflows")
+)
+
+// Money is a fixed-point amount of a currency, stored as an integer number of the currency's minor units (cents
+// for EUR, yen for JPY, fils for BHD) so arithmetic never drifts.
 type Money struct {
-	amount   float64
+	amount   int64
 	currency string
 }
 
-func NewMoney(amount float64, currency string) (*Money, error) {
+// NewMoney parses a decimal amount such as "10.50". It fails when the amount has more significant decimal places than
+// the currency allows.
+func NewMoney(amount string, currency string) (*Money, error) {
+	if !validCurrencyCodes[currency] {
+		return nil, errors.New("invalid currency, must be a valid ISO 4217 currency code")
+	}
+
+	minorUnits, err := parseMinorUnits(amount, MinorUnitsOf(currency))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Money{amount: minorUnits, currency: currency}, nil
+}
+
+// NewMoneyFromMinorUnits builds a Money from an amount already expressed in the currency's minor units.
+func NewMoneyFromMinorUnits(amount int64, currency string) (*Money, error) {
 	if !validCurrencyCodes[currency] {
 		return nil, errors.New("invalid currency, must be a valid ISO 4217 currency code")
 	}
 	return &Money{amount: amount, currency: currency}, nil
 }
 
-func (m Money) Amount() float64 {
+// MinorUnitsOf returns the number of decimal places used by the currency.
+func MinorUnitsOf(currency string) int {
+	if minorUnits, ok := currencyMinorUnits[currency]; ok {
+		return minorUnits
+	}
+	return defaultMinorUnits
+}
+
+func parseMinorUnits(amount string, exponent int) (int64, error) {
+	invalidAmountErr := errors.New("invalid amount, must be a decimal number")
+	amount = strings.TrimSpace(amount)
+	negative := strings.HasPrefix(amount, "-")
+	if negative || strings.HasPrefix(amount, "+") {
+		amount = amount[1:]
+	}
+
+	integerPart, fractionalPart, _ := strings.Cut(amount, ".")
+	if integerPart == "" && fractionalPart == "" {
+		return 0, invalidAmountErr
+	}
+
+	if len(fractionalPart) > exponent {
+		if strings.TrimRight(fractionalPart[exponent:], "0") != "" {
+			return 0, errors.New("invalid amount, it has more decimal places than its currency allows")
+		}
+		fractionalPart = fractionalPart[:exponent]
+	}
+	fractionalPart += strings.Repeat("0", exponent-len(fractionalPart))
+
+	digits := strings.TrimLeft(integerPart+fractionalPart, "0")
+	if digits == "" {
+		return 0, nil
+	}
+
+	for _, digit := range digits {
+		if digit < '0' || digit > '9' {
+			return 0, invalidAmountErr
+		}
+	}
+
+	minorUnits, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, ErrMoneyOverflow
+	}
+
+	if negative {
+		minorUnits = -minorUnits
+	}
+	return minorUnits, nil
+}
+
+// Amount returns the amount as a decimal string with exactly as many decimal places as the currency uses.
+func (m Money) Amount() string {
+	exponent := MinorUnitsOf(m.currency)
+	absoluteAmount := strconv.FormatUint(absolute(m.amount), 10)
+	sign := ""
+	if m.amount < 0 {
+		sign = "-"
+	}
+
+	if exponent == 0 {
+		return sign + absoluteAmount
+	}
+
+	if len(absoluteAmount) <= exponent {
+		absoluteAmount = strings.Repeat("0", exponent-len(absoluteAmount)+1) + absoluteAmount
+	}
+
+	integerPartLength := len(absoluteAmount) - exponent
+	return sign + absoluteAmount[:integerPartLength] + "." + absoluteAmount[integerPartLength:]
+}
+
+func (m Money) MinorUnits() int64 {
 	return m.amount
 }
 
 func (m Money) Currency() string {
 	return m.currency
 }
+
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+func (m Money) Add(other *Money) (*Money, error) {
+	if m.currency != other.currency {
+		return nil, ErrCurrencyMismatch
+	}
+
+	result := m.amount + other.amount
+	if (other.amount > 0 && result < m.amount) || (other.amount < 0 && result > m.amount) {
+		return nil, ErrMoneyOverflow
+	}
+
+	return &Money{amount: result, currency: m.currency}, nil
+}
+
+func (m Money) Subtract(other *Money) (*Money, error) {
+	if other.amount == math.MinInt64 {
+		return nil, ErrMoneyOverflow
+	}
+	return m.Add(&Money{amount: -other.amount, currency: other.currency})
+}
+
+func (m Money) Multiply(factor int64) (*Money, error) {
+	if m.amount == 0 || factor == 0 {
+		return &Money{amount: 0, currency: m.currency}, nil
+	}
+
+	result := m.amount * factor
+	if result/factor != m.amount || (m.amount == -1 && factor == math.MinInt64) || (factor == -1 && m.amount == math.MinInt64) {
+		return nil, ErrMoneyOverflow
+	}
+
+	return &Money{amount: result, currency: m.currency}, nil
+}
+
+// Allocate splits the amount proportionally to the given ratios. The minor units that can't be split evenly are
+// handed out one by one starting from the first part, so the parts always add up to the original amount.
+func (m Money) Allocate(ratios ...int64) ([]*Money, error) {
+	if len(ratios) == 0 {
+		return nil, errors.New("invalid ratios, at least one is required")
+	}
+
+	var ratiosTotal int64
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, errors.New("invalid ratios, they cannot be negative")
+		}
+		if ratio > math.MaxInt64-ratiosTotal {
+			return nil, ErrMoneyOverflow
+		}
+		ratiosTotal += ratio
+	}
+
+	if ratiosTotal == 0 {
+		return nil, errors.New("invalid ratios, at least one must be greater than zero")
+	}
+
+	parts := make([]*Money, len(ratios))
+	remainder := m.amount
+	for i, ratio := range ratios {
+		share := m.share(ratio, ratiosTotal)
+		parts[i] = &Money{amount: share, currency: m.currency}
+		remainder -= share
+	}
+
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(parts) {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i].amount += step
+		remainder -= step
+	}
+
+	return parts, nil
+}
+
+// share is the amount times ratio / ratiosTotal truncated towards zero. The product is computed with arbitrary
+// precision, and the result fits in the amount because the ratio is at most the total.
+func (m Money) share(ratio int64, ratiosTotal int64) int64 {
+	share := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(ratio))
+	return share.Quo(share, big.NewInt(ratiosTotal)).Int64()
+}
+
+// Compare returns -1, 0 or 1 when the amount is lower than, equal to or greater than the other one.
+func (m Money) Compare(other *Money) (int, error) {
+	if m.currency != other.currency {
+		return 0, ErrCurrencyMismatch
+	}
+
+	if m.amount < other.amount {
+		return -1, nil
+	} else if m.amount > other.amount {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func absolute(amount int64) uint64 {
+	if amount < 0 {
+		return uint64(-(amount + 1)) + 1
+	}
+	return uint64(amount)
+}
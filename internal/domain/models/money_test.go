@@ -0,0 +1,160 @@
+package models_test
+
+import (
+	"finfit-backend/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"math"
+	"testing"
+)
+
+type MoneyTestSuite struct {
+	suite.Suite
+}
+
+func TestMoneyTestSuite(t *testing.T) {
+	suite.Run(t, new(MoneyTestSuite))
+}
+
+func (suite *MoneyTestSuite) TestGivenADecimalAmount_WhenNewMoney_ThenUseCurrencyMinorUnits() {
+	testCases := []struct {
+		amount             string
+		currency           string
+		expectedAmount     string
+		expectedMinorUnits int64
+	}{
+		{amount: "10.5", currency: "EUR", expectedAmount: "10.50", expectedMinorUnits: 1050},
+		{amount: "0.07", currency: "USD", expectedAmount: "0.07", expectedMinorUnits: 7},
+		{amount: "1500", currency: "JPY", expectedAmount: "1500", expectedMinorUnits: 1500},
+		{amount: "1500.000", currency: "CLP", expectedAmount: "1500", expectedMinorUnits: 1500},
+		{amount: "1.5", currency: "BHD", expectedAmount: "1.500", expectedMinorUnits: 1500},
+		{amount: "0.001", currency: "KWD", expectedAmount: "0.001", expectedMinorUnits: 1},
+		{amount: "-3.2", currency: "ARS", expectedAmount: "-3.20", expectedMinorUnits: -320},
+	}
+
+	for _, testCase := range testCases {
+		money, err := models.NewMoney(testCase.amount, testCase.currency)
+
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), testCase.expectedAmount, money.Amount())
+		assert.Equal(suite.T(), testCase.expectedMinorUnits, money.MinorUnits())
+	}
+}
+
+func (suite *MoneyTestSuite) TestGivenMoreDecimalPlacesThanTheCurrencyAllows_WhenNewMoney_ThenReturnError() {
+	_, err := models.NewMoney("10.5", "JPY")
+	require.Error(suite.T(), err)
+
+	_, err = models.NewMoney("10.505", "EUR")
+	require.Error(suite.T(), err)
+}
+
+func (suite *MoneyTestSuite) TestGivenAnInvalidAmount_WhenNewMoney_ThenReturnError() {
+	for _, amount := range []string{"", "abc", "1e3", "1.2.3", ".", "-+5", "+-5", "--5", "-"} {
+		_, err := models.NewMoney(amount, "EUR")
+		require.Error(suite.T(), err, amount)
+	}
+}
+
+func (suite *MoneyTestSuite) TestGivenTwoAmounts_WhenAddAndSubtract_ThenReturnExactResult() {
+	a, _ := models.NewMoney("0.10", "USD")
+	b, _ := models.NewMoney("0.20", "USD")
+
+	sum, err := a.Add(b)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "0.30", sum.Amount())
+
+	difference, err := a.Subtract(b)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "-0.10", difference.Amount())
+}
+
+func (suite *MoneyTestSuite) TestGivenDifferentCurrencies_WhenOperate_ThenReturnCurrencyMismatchError() {
+	euros, _ := models.NewMoney("1", "EUR")
+	dollars, _ := models.NewMoney("1", "USD")
+
+	_, err := euros.Add(dollars)
+	require.ErrorIs(suite.T(), err, models.ErrCurrencyMismatch)
+
+	_, err = euros.Subtract(dollars)
+	require.ErrorIs(suite.T(), err, models.ErrCurrencyMismatch)
+
+	_, err = euros.Compare(dollars)
+	require.ErrorIs(suite.T(), err, models.ErrCurrencyMismatch)
+}
+
+func (suite *MoneyTestSuite) TestGivenAFactor_WhenMultiply_ThenReturnMultipliedAmount() {
+	money, _ := models.NewMoney("19.99", "EUR")
+
+	result, err := money.Multiply(3)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "59.97", result.Amount())
+}
+
+func (suite *MoneyTestSuite) TestGivenRatios_WhenAllocate_ThenPartsSumTheOriginalAmount() {
+	money, _ := models.NewMoney("100", "EUR")
+
+	parts, err := money.Allocate(1, 1, 1)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "33.34", parts[0].Amount())
+	assert.Equal(suite.T(), "33.33", parts[1].Amount())
+	assert.Equal(suite.T(), "33.33", parts[2].Amount())
+}
+
+func (suite *MoneyTestSuite) TestGivenUnevenRatios_WhenAllocate_ThenPartsSumTheOriginalAmount() {
+	money, _ := models.NewMoney("0.05", "USD")
+
+	parts, err := money.Allocate(3, 7)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "0.02", parts[0].Amount())
+	assert.Equal(suite.T(), "0.03", parts[1].Amount())
+}
+
+func (suite *MoneyTestSuite) TestGivenInvalidRatios_WhenAllocate_ThenReturnError() {
+	money, _ := models.NewMoney("10", "USD")
+
+	_, err := money.Allocate()
+	require.Error(suite.T(), err)
+
+	_, err = money.Allocate(0, 0)
+	require.Error(suite.T(), err)
+
+	_, err = money.Allocate(1, -1)
+	require.Error(suite.T(), err)
+}
+
+func (suite *MoneyTestSuite) TestGivenRatiosThatAddUpToMoreThanTheMaximum_WhenAllocate_ThenReturnOverflowError() {
+	money, _ := models.NewMoney("10", "USD")
+
+	_, err := money.Allocate(math.MaxInt64, 1)
+
+	assert.ErrorIs(suite.T(), err, models.ErrMoneyOverflow)
+}
+
+func (suite *MoneyTestSuite) TestGivenALargeAmountAndLargeRatios_WhenAllocate_ThenPartsSumTheOriginalAmount() {
+	money, _ := models.NewMoneyFromMinorUnits(math.MaxInt64/2, "USD")
+
+	parts, err := money.Allocate(math.MaxInt64/2, math.MaxInt64/2)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(math.MaxInt64/4+1), parts[0].MinorUnits())
+	assert.Equal(suite.T(), int64(math.MaxInt64/4), parts[1].MinorUnits())
+}
+
+func (suite *MoneyTestSuite) TestGivenTwoAmounts_WhenCompare_ThenReturnOrder() {
+	lower, _ := models.NewMoney("9.99", "EUR")
+	greater, _ := models.NewMoney("10", "EUR")
+
+	result, _ := lower.Compare(greater)
+	assert.Equal(suite.T(), -1, result)
+
+	result, _ = greater.Compare(lower)
+	assert.Equal(suite.T(), 1, result)
+
+	result, _ = lower.Compare(lower)
+	assert.Equal(suite.T(), 0, result)
+}
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+type RecurrenceFrequency string
+
+const (
+	DailyRecurrenceFrequency   RecurrenceFrequency = "daily"
+	WeeklyRecurrenceFrequency  RecurrenceFrequency = "weekly"
+	MonthlyRecurrenceFrequency RecurrenceFrequency = "monthly"
+	YearlyRecurrenceFrequency  RecurrenceFrequency = "yearly"
+)
+
+var validRecurrenceFrequencies = map[RecurrenceFrequency]bool{
+	DailyRecurrenceFrequency:   true,
+	WeeklyRecurrenceFrequency:  true,
+	MonthlyRecurrenceFrequency: true,
+	YearlyRecurrenceFrequency:  true,
+}
+
+// RecurrenceRule is a subset of the iCalendar RRULE: it repeats every interval days, weeks, months or years from the
+// start date, until the end date or until count occurrences were produced. A zero end date and a zero count mean that
+// it never ends. Monthly and yearly rules that start on a day missing in a month (e.g. the 31st) fall on the last day
+// of that month.
+type RecurrenceRule struct {
+	frequency RecurrenceFrequency
+	interval  int
+	startDate time.Time
+	endDate   time.Time
+	count     int
+}
+
+func NewRecurrenceRule(frequency RecurrenceFrequency, interval int, startDate time.Time, endDate time.Time, count int) (*RecurrenceRule, error) {
+	if !validRecurrenceFrequencies[frequency] {
+		return nil, errors.New("invalid recurrence frequency")
+	}
+
+	if interval < 1 {
+		return nil, errors.New("invalid recurrence interval, it must be greater than zero")
+	}
+
+	if startDate.IsZero() {
+		return nil, errors.New("invalid recurrence start date, it cannot be zero")
+	}
+
+	if !endDate.IsZero() && endDate.Before(startDate) {
+		return nil, errors.New("invalid recurrence end date, it cannot be before the start date")
+	}
+
+	if count < 0 {
+		return nil, errors.New("invalid recurrence count, it cannot be negative")
+	}
+
+	if !endDate.IsZero() && count > 0 {
+		return nil, errors.New("invalid recurrence, it can end on a date or after a count of occurrences but not both")
+	}
+
+	return &RecurrenceRule{frequency: frequency, interval: interval, startDate: startDate, endDate: endDate, count: count}, nil
+}
+
+func IsValidRecurrenceFrequency(frequency string) bool {
+	return validRecurrenceFrequencies[RecurrenceFrequency(frequency)]
+}
+
+// Occurrences returns the dates of the rule that are after the given date and not after until. A zero after date
+// returns them from the start date.
+func (r RecurrenceRule) Occurrences(after time.Time, until time.Time) []time.Time {
+	occurrences := []time.Time{}
+	for n := 0; r.count == 0 || n < r.count; n++ {
+		occurrence := r.occurrence(n)
+		if occurrence.After(until) || (!r.endDate.IsZero() && occurrence.After(r.endDate)) {
+			break
+		}
+
+		if occurrence.After(after) {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+
+	return occurrences
+}
+
+// occurrence computes the n-th date from the start date instead of from the previous occurrence, so a day clamped in a
+// short month doesn't shift the following ones.
+func (r RecurrenceRule) occurrence(n int) time.Time {
+	switch r.frequency {
+	case DailyRecurrenceFrequency:
+		return r.startDate.AddDate(0, 0, n*r.interval)
+	case WeeklyRecurrenceFrequency:
+		return r.startDate.AddDate(0, 0, 7*n*r.interval)
+	case MonthlyRecurrenceFrequency:
+		return addMonths(r.startDate, n*r.interval)
+	default:
+		return addMonths(r.startDate, 12*n*r.interval)
+	}
+}
+
+func addMonths(date time.Time, months int) time.Time {
+	firstDayOfMonth := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, date.Location())
+	lastDayOfMonth := firstDayOfMonth.AddDate(0, 1, -1).Day()
+
+	day := date.Day()
+	if day > lastDayOfMonth {
+		day = lastDayOfMonth
+	}
+
+	return time.Date(firstDayOfMonth.Year(), firstDayOfMonth.Month(), day, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
+}
+
+func (r RecurrenceRule) Frequency() RecurrenceFrequency {
+	return r.frequency
+}
+
+func (r RecurrenceRule) Interval() int {
+	return r.interval
+}
+
+func (r RecurrenceRule) StartDate() time.Time {
+	return r.startDate
+}
+
+// EndDate is zero when the rule doesn't end on a date.
+func (r RecurrenceRule) EndDate() time.Time {
+	return r.endDate
+}
+
+// Count is zero when the rule doesn't end after a number of occurrences.
+func (r RecurrenceRule) Count() int {
+	return r.count
+}
@@ -0,0 +1,77 @@
+package models_test
+
+import (
+	"finfit-backend/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type RecurrenceRuleTestSuite struct {
+	suite.Suite
+}
+
+func TestRecurrenceRuleTestSuite(t *testing.T) {
+	suite.Run(t, new(RecurrenceRuleTestSuite))
+}
+
+func (suite *RecurrenceRuleTestSuite) TestGivenAMonthlyRuleStartingOnThe31st_WhenOccurrences_ThenFallOnTheLastDayOfShortMonths() {
+	rule, err := models.NewRecurrenceRule(models.MonthlyRecurrenceFrequency, 1, date(2022, 1, 31), time.Time{}, 0)
+	require.NoError(suite.T(), err)
+
+	occurrences := rule.Occurrences(time.Time{}, date(2022, 5, 1))
+
+	assert.Equal(suite.T(), []time.Time{date(2022, 1, 31), date(2022, 2, 28), date(2022, 3, 31), date(2022, 4, 30)}, occurrences)
+}
+
+func (suite *RecurrenceRuleTestSuite) TestGivenAWeeklyRuleWithInterval_WhenOccurrences_ThenSkipWeeks() {
+	rule, _ := models.NewRecurrenceRule(models.WeeklyRecurrenceFrequency, 2, date(2022, 3, 1), time.Time{}, 0)
+
+	occurrences := rule.Occurrences(time.Time{}, date(2022, 3, 31))
+
+	assert.Equal(suite.T(), []time.Time{date(2022, 3, 1), date(2022, 3, 15), date(2022, 3, 29)}, occurrences)
+}
+
+func (suite *RecurrenceRuleTestSuite) TestGivenARuleWithCount_WhenOccurrences_ThenStopAfterCount() {
+	rule, _ := models.NewRecurrenceRule(models.DailyRecurrenceFrequency, 1, date(2022, 3, 1), time.Time{}, 3)
+
+	occurrences := rule.Occurrences(time.Time{}, date(2022, 12, 31))
+
+	assert.Equal(suite.T(), []time.Time{date(2022, 3, 1), date(2022, 3, 2), date(2022, 3, 3)}, occurrences)
+}
+
+func (suite *RecurrenceRuleTestSuite) TestGivenARuleWithEndDate_WhenOccurrences_ThenStopAtEndDate() {
+	rule, _ := models.NewRecurrenceRule(models.YearlyRecurrenceFrequency, 1, date(2020, 2, 29), date(2022, 6, 1), 0)
+
+	occurrences := rule.Occurrences(time.Time{}, date(2030, 1, 1))
+
+	assert.Equal(suite.T(), []time.Time{date(2020, 2, 29), date(2021, 2, 28), date(2022, 2, 28)}, occurrences)
+}
+
+func (suite *RecurrenceRuleTestSuite) TestGivenAnAfterDate_WhenOccurrences_ThenReturnOnlyLaterOccurrences() {
+	rule, _ := models.NewRecurrenceRule(models.MonthlyRecurrenceFrequency, 1, date(2022, 1, 5), time.Time{}, 0)
+
+	occurrences := rule.Occurrences(date(2022, 2, 5), date(2022, 3, 5))
+
+	assert.Equal(suite.T(), []time.Time{date(2022, 3, 5)}, occurrences)
+}
+
+func (suite *RecurrenceRuleTestSuite) TestGivenAnInvalidRule_WhenNewRecurrenceRule_ThenReturnError() {
+	_, err := models.NewRecurrenceRule("hourly", 1, date(2022, 1, 1), time.Time{}, 0)
+	require.Error(suite.T(), err)
+
+	_, err = models.NewRecurrenceRule(models.DailyRecurrenceFrequency, 0, date(2022, 1, 1), time.Time{}, 0)
+	require.Error(suite.T(), err)
+
+	_, err = models.NewRecurrenceRule(models.DailyRecurrenceFrequency, 1, date(2022, 1, 1), date(2021, 1, 1), 0)
+	require.Error(suite.T(), err)
+
+	_, err = models.NewRecurrenceRule(models.DailyRecurrenceFrequency, 1, date(2022, 1, 1), date(2022, 2, 1), 3)
+	require.Error(suite.T(), err)
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"errors"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"time"
+)
+
+// RecurringExpense is a template that materializes an expense on each occurrence of its schedule. lastOccurrence is
+// the date of the last expense generated from it, zero when none was generated yet.
+type RecurringExpense struct {
+	id             uuid.UUID
+	amount         *Money
+	description    string
+	expenseType    *ExpenseType
+	schedule       *RecurrenceRule
+	lastOccurrence time.Time
+}
+
+func NewRecurringExpense(amount *Money, description string, expenseType *ExpenseType, schedule *RecurrenceRule) (*RecurringExpense, error) {
+	id := pkg.NewUUID()
+	err := validateRecurringExpense(id, amount, expenseType, schedule)
+	if err != nil {
+		return nil, err
+	}
+	return &RecurringExpense{id: id, amount: amount, description: description, expenseType: expenseType, schedule: schedule}, nil
+}
+
+func NewRecurringExpenseWithId(id uuid.UUID, amount *Money, description string, expenseType *ExpenseType, schedule *RecurrenceRule, lastOccurrence time.Time) (*RecurringExpense, error) {
+	err := validateRecurringExpense(id, amount, expenseType, schedule)
+	if err != nil {
+		return nil, err
+	}
+	return &RecurringExpense{id: id, amount: amount, description: description, expenseType: expenseType, schedule: schedule, lastOccurrence: lastOccurrence}, nil
+}
+
+func validateRecurringExpense(id uuid.UUID, amount *Money, expenseType *ExpenseType, schedule *RecurrenceRule) error {
+	if id == uuid.Nil {
+		return errors.New("invalid id, is must be a valid UUID")
+	}
+
+	if amount == nil || !amount.IsPositive() {
+		return errors.New("invalid expense amount, it must be greater than zero")
+	}
+
+	if expenseType == nil {
+		return errors.New("invalid expense type, it cannot be null")
+	}
+
+	if schedule == nil {
+		return errors.New("invalid schedule, it cannot be null")
+	}
+	return nil
+}
+
+// DueOccurrences returns the occurrences that weren't generated yet up to the given date, inclusive.
+func (r RecurringExpense) DueOccurrences(until time.Time) []time.Time {
+	return r.schedule.Occurrences(r.lastOccurrence, until)
+}
+
+func (r RecurringExpense) Id() uuid.UUID {
+	return r.id
+}
+
+func (r RecurringExpense) Amount() *Money {
+	return r.amount
+}
+
+func (r RecurringExpense) Description() string {
+	return r.description
+}
+
+func (r RecurringExpense) ExpenseType() *ExpenseType {
+	return r.expenseType
+}
+
+func (r RecurringExpense) Schedule() *RecurrenceRule {
+	return r.schedule
+}
+
+func (r RecurringExpense) LastOccurrence() time.Time {
+	return r.lastOccurrence
+}
@@ -0,0 +1,220 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+type ReportGrouping string
+
+const (
+	ExpenseTypeReportGrouping ReportGrouping = "expense_type"
+	DayReportGrouping         ReportGrouping = "day"
+	WeekReportGrouping        ReportGrouping = "week"
+	MonthReportGrouping       ReportGrouping = "month"
+	YearReportGrouping        ReportGrouping = "year"
+)
+
+var validReportGroupings = map[ReportGrouping]bool{
+	ExpenseTypeReportGrouping: true,
+	DayReportGrouping:         true,
+	WeekReportGrouping:        true,
+	MonthReportGrouping:       true,
+	YearReportGrouping:        true,
+}
+
+func IsValidReportGrouping(grouping string) bool {
+	return validReportGroupings[ReportGrouping(grouping)]
+}
+
+// SpendingGroup aggregates the expenses in one currency that share the same expense type or time bucket. The key
+// identifies the group (the expense type id or the bucket, e.g. 2022-03 or 2022-W09) and the label is its name.
+type SpendingGroup struct {
+	key     string
+	label   string
+	total   *Money
+	count   int64
+	average *Money
+	share   float64
+}
+
+// NewSpendingGroup computes the average of the group and its share, as a percentage, of the currency total.
+func NewSpendingGroup(key string, label string, total *Money, count int64, currencyTotal *Money) (*SpendingGroup, error) {
+	if total == nil || currencyTotal == nil || total.Currency() != currencyTotal.Currency() {
+		return nil, errors.New("invalid spending group, totals cannot be null and must have the same currency")
+	}
+
+	average, err := averageOf(total, count)
+	if err != nil {
+		return nil, err
+	}
+
+	share := 0.0
+	if !currencyTotal.IsZero() {
+		share = math.Round(float64(total.MinorUnits())*10000/float64(currencyTotal.MinorUnits())) / 100
+	}
+
+	return &SpendingGroup{key: key, label: label, total: total, count: count, average: average, share: share}, nil
+}
+
+func (s SpendingGroup) Key() string {
+	return s.key
+}
+
+func (s SpendingGroup) Label() string {
+	return s.label
+}
+
+func (s SpendingGroup) Total() *Money {
+	return s.total
+}
+
+func (s SpendingGroup) Count() int64 {
+	return s.count
+}
+
+func (s SpendingGroup) Average() *Money {
+	return s.average
+}
+
+func (s SpendingGroup) Share() float64 {
+	return s.share
+}
+
+// CurrencySpending aggregates all the expenses in one currency. Amounts in different currencies are never added up.
+type CurrencySpending struct {
+	total   *Money
+	count   int64
+	average *Money
+	groups  []*SpendingGroup
+}
+
+func NewCurrencySpending(total *Money, count int64, groups []*SpendingGroup) (*CurrencySpending, error) {
+	if total == nil {
+		return nil, errors.New("invalid currency spending, total cannot be null")
+	}
+
+	average, err := averageOf(total, count)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CurrencySpending{total: total, count: count, average: average, groups: groups}, nil
+}
+
+func (c CurrencySpending) Currency() string {
+	return c.total.Currency()
+}
+
+func (c CurrencySpending) Total() *Money {
+	return c.total
+}
+
+func (c CurrencySpending) Count() int64 {
+	return c.count
+}
+
+func (c CurrencySpending) Average() *Money {
+	return c.average
+}
+
+func (c CurrencySpending) Groups() []*SpendingGroup {
+	return c.groups
+}
+
+// SpendingEntry is what was spent in one currency on a single day for one group. Reports converted to another currency
+// are built from these entries so every day is converted at its own rate.
+type SpendingEntry struct {
+	key   string
+	label string
+	date  time.Time
+	total *Money
+	count int64
+}
+
+func NewSpendingEntry(key string, label string, date time.Time, total *Money, count int64) (*SpendingEntry, error) {
+	if total == nil || date.IsZero() || count <= 0 {
+		return nil, errors.New("invalid spending entry, total and date are required and count must be greater than zero")
+	}
+	return &SpendingEntry{key: key, label: label, date: date, total: total, count: count}, nil
+}
+
+func (s SpendingEntry) Key() string {
+	return s.key
+}
+
+func (s SpendingEntry) Label() string {
+	return s.label
+}
+
+func (s SpendingEntry) Date() time.Time {
+	return s.date
+}
+
+func (s SpendingEntry) Total() *Money {
+	return s.total
+}
+
+func (s SpendingEntry) Count() int64 {
+	return s.count
+}
+
+// MissingRate identifies a currency and day without any exchange rate, so its expenses were left out of a report.
+type MissingRate struct {
+	currency string
+	date     time.Time
+}
+
+func NewMissingRate(currency string, date time.Time) *MissingRate {
+	return &MissingRate{currency: currency, date: date}
+}
+
+func (m MissingRate) Currency() string {
+	return m.currency
+}
+
+func (m MissingRate) Date() time.Time {
+	return m.date
+}
+
+// SpendingReport holds the spending of every currency. When it was converted to a target currency it also holds the
+// rates used and the rates that were missing.
+type SpendingReport struct {
+	currencies   []*CurrencySpending
+	rates        []*ExchangeRate
+	missingRates []*MissingRate
+}
+
+func NewSpendingReport(currencies []*CurrencySpending, rates []*ExchangeRate, missingRates []*MissingRate) *SpendingReport {
+	return &SpendingReport{currencies: currencies, rates: rates, missingRates: missingRates}
+}
+
+func (s SpendingReport) Currencies() []*CurrencySpending {
+	return s.currencies
+}
+
+func (s SpendingReport) Rates() []*ExchangeRate {
+	return s.rates
+}
+
+func (s SpendingReport) MissingRates() []*MissingRate {
+	return s.missingRates
+}
+
+// averageOf divides the total by count rounding half away from zero to the currency minor unit.
+func averageOf(total *Money, count int64) (*Money, error) {
+	if count <= 0 {
+		return nil, errors.New("invalid count, it must be greater than zero")
+	}
+
+	quotient := total.MinorUnits() / count
+	remainder := total.MinorUnits() % count
+	if remainder*2 >= count {
+		quotient++
+	} else if remainder*2 <= -count {
+		quotient--
+	}
+
+	return NewMoneyFromMinorUnits(quotient, total.Currency())
+}
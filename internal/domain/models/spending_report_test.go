@@ -0,0 +1,47 @@
+package models_test
+
+import (
+	"finfit-backend/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type SpendingReportTestSuite struct {
+	suite.Suite
+}
+
+func TestSpendingReportTestSuite(t *testing.T) {
+	suite.Run(t, new(SpendingReportTestSuite))
+}
+
+func (suite *SpendingReportTestSuite) TestGivenAGroup_WhenNewSpendingGroup_ThenComputeAverageAndShare() {
+	total, _ := models.NewMoney("10", "EUR")
+	currencyTotal, _ := models.NewMoney("30", "EUR")
+
+	group, err := models.NewSpendingGroup("food", "Food", total, 3, currencyTotal)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "3.33", group.Average().Amount())
+	assert.Equal(suite.T(), 33.33, group.Share())
+}
+
+func (suite *SpendingReportTestSuite) TestGivenAnAverageWithHalfMinorUnit_WhenNewCurrencySpending_ThenRoundHalfUp() {
+	total, _ := models.NewMoney("0.05", "USD")
+
+	spending, err := models.NewCurrencySpending(total, 2, nil)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "0.03", spending.Average().Amount())
+	assert.Equal(suite.T(), "USD", spending.Currency())
+}
+
+func (suite *SpendingReportTestSuite) TestGivenDifferentCurrencies_WhenNewSpendingGroup_ThenReturnError() {
+	total, _ := models.NewMoney("10", "EUR")
+	currencyTotal, _ := models.NewMoney("30", "USD")
+
+	_, err := models.NewSpendingGroup("food", "Food", total, 1, currencyTotal)
+
+	require.Error(suite.T(), err)
+}
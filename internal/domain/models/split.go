@@ -0,0 +1,212 @@
+package models
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+)
+
+type SplitMethod string
+
+const (
+	EqualSplitMethod      SplitMethod = "equal"
+	PercentageSplitMethod SplitMethod = "percentage"
+	SharesSplitMethod     SplitMethod = "shares"
+	ExactSplitMethod      SplitMethod = "exact"
+)
+
+// percentageDecimalPlaces lets percentages such as 33.33 be split without rounding them.
+const percentageDecimalPlaces = 2
+
+func IsValidSplitMethod(method string) bool {
+	switch SplitMethod(method) {
+	case EqualSplitMethod, PercentageSplitMethod, SharesSplitMethod, ExactSplitMethod:
+		return true
+	}
+	return false
+}
+
+// SplitParticipant is a user who takes part in a split. Its value is the percentage, the number of shares or the exact
+// amount the user owes, depending on the split method, and it is ignored by the equal method.
+type SplitParticipant struct {
+	userId uuid.UUID
+	value  string
+}
+
+func NewSplitParticipant(userId uuid.UUID, value string) (*SplitParticipant, error) {
+	if userId == uuid.Nil {
+		return nil, errors.New("invalid participant, is must be a valid UUID")
+	}
+	return &SplitParticipant{userId: userId, value: strings.TrimSpace(value)}, nil
+}
+
+func (p SplitParticipant) UserId() uuid.UUID {
+	return p.userId
+}
+
+func (p SplitParticipant) Value() string {
+	return p.value
+}
+
+// SplitAllocation is the part of the expense a participant owes.
+type SplitAllocation struct {
+	userId uuid.UUID
+	amount *Money
+}
+
+func (a SplitAllocation) UserId() uuid.UUID {
+	return a.userId
+}
+
+func (a SplitAllocation) Amount() *Money {
+	return a.amount
+}
+
+// ExpenseSplit shares the cost of an expense paid by a user among the participants. The allocations always add up to
+// the total, the minor units that can't be split evenly go one by one to the first participants.
+type ExpenseSplit struct {
+	paidBy       uuid.UUID
+	method       SplitMethod
+	participants []*SplitParticipant
+	allocations  []*SplitAllocation
+}
+
+func NewExpenseSplit(total *Money, paidBy uuid.UUID, method SplitMethod, participants []*SplitParticipant) (*ExpenseSplit, error) {
+	if total == nil || !total.IsPositive() {
+		return nil, errors.New("invalid split total, it must be greater than zero")
+	}
+
+	if paidBy == uuid.Nil {
+		return nil, errors.New("invalid payer, is must be a valid UUID")
+	}
+
+	if len(participants) == 0 {
+		return nil, errors.New("invalid participants, at least one is required")
+	}
+
+	seenParticipants := map[uuid.UUID]bool{}
+	for _, participant := range participants {
+		if participant == nil {
+			return nil, errors.New("invalid participants, they cannot be null")
+		}
+		if seenParticipants[participant.userId] {
+			return nil, errors.New("invalid participants, a user can only take part once")
+		}
+		seenParticipants[participant.userId] = true
+	}
+
+	amounts, err := allocateSplit(total, method, participants)
+	if err != nil {
+		return nil, err
+	}
+
+	allocations := make([]*SplitAllocation, len(participants))
+	for i, participant := range participants {
+		allocations[i] = &SplitAllocation{userId: participant.userId, amount: amounts[i]}
+	}
+
+	return &ExpenseSplit{paidBy: paidBy, method: method, participants: participants, allocations: allocations}, nil
+}
+
+func allocateSplit(total *Money, method SplitMethod, participants []*SplitParticipant) ([]*Money, error) {
+	switch method {
+	case EqualSplitMethod:
+		ratios := make([]int64, len(participants))
+		for i := range ratios {
+			ratios[i] = 1
+		}
+		return total.Allocate(ratios...)
+	case PercentageSplitMethod:
+		return allocateByPercentage(total, participants)
+	case SharesSplitMethod:
+		return allocateByShares(total, participants)
+	case ExactSplitMethod:
+		return allocateExactly(total, participants)
+	}
+	return nil, errors.New("invalid split method, it must be equal, percentage, shares or exact")
+}
+
+func allocateByPercentage(total *Money, participants []*SplitParticipant) ([]*Money, error) {
+	ratios := make([]int64, len(participants))
+	var percentagesTotal int64
+	for i, participant := range participants {
+		percentage, err := parseMinorUnits(participant.value, percentageDecimalPlaces)
+		if err != nil || percentage < 0 {
+			return nil, errors.New("invalid percentage, it must be a decimal number between 0 and 100 with up to two decimal places")
+		}
+		ratios[i] = percentage
+		percentagesTotal += percentage
+	}
+
+	if percentagesTotal != 100*100 {
+		return nil, errors.New("invalid percentages, they must add up to 100")
+	}
+
+	return total.Allocate(ratios...)
+}
+
+func allocateByShares(total *Money, participants []*SplitParticipant) ([]*Money, error) {
+	ratios := make([]int64, len(participants))
+	for i, participant := range participants {
+		shares, err := parseMinorUnits(participant.value, 0)
+		if err != nil || shares <= 0 {
+			return nil, errors.New("invalid shares, they must be whole numbers greater than zero")
+		}
+		ratios[i] = shares
+	}
+
+	return total.Allocate(ratios...)
+}
+
+func allocateExactly(total *Money, participants []*SplitParticipant) ([]*Money, error) {
+	amounts := make([]*Money, len(participants))
+	allocatedTotal, _ := NewMoneyFromMinorUnits(0, total.Currency())
+	for i, participant := range participants {
+		amount, err := NewMoney(participant.value, total.Currency())
+		if err != nil || amount.MinorUnits() < 0 {
+			return nil, errors.New("invalid exact amount, it must be a decimal number not lower than zero with the decimal places of the expense currency")
+		}
+
+		if allocatedTotal, err = allocatedTotal.Add(amount); err != nil {
+			return nil, err
+		}
+		amounts[i] = amount
+	}
+
+	if comparison, _ := allocatedTotal.Compare(total); comparison != 0 {
+		return nil, errors.New("invalid exact amounts, they must add up to the expense amount")
+	}
+
+	return amounts, nil
+}
+
+// Reallocate splits another total among the same participants with the same method, for when the amount of the
+// expense changes. Exact amounts can't follow the new total, so they fail unless they still add up to it.
+func (s ExpenseSplit) Reallocate(total *Money) (*ExpenseSplit, error) {
+	return NewExpenseSplit(total, s.paidBy, s.method, s.participants)
+}
+
+// Total returns the sum of the allocations, which is the amount of the expense that was split.
+func (s ExpenseSplit) Total() *Money {
+	total := s.allocations[0].amount
+	for _, allocation := range s.allocations[1:] {
+		total, _ = total.Add(allocation.amount)
+	}
+	return total
+}
+
+func (s ExpenseSplit) PaidBy() uuid.UUID {
+	return s.paidBy
+}
+
+func (s ExpenseSplit) Method() SplitMethod {
+	return s.method
+}
+
+func (s ExpenseSplit) Participants() []*SplitParticipant {
+	return s.participants
+}
+
+func (s ExpenseSplit) Allocations() []*SplitAllocation {
+	return s.allocations
+}
@@ -0,0 +1,117 @@
+package models_test
+
+import (
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type SplitTestSuite struct {
+	suite.Suite
+	payer uuid.UUID
+	users []uuid.UUID
+}
+
+func (suite *SplitTestSuite) SetupSuite() {
+	suite.payer = uuid.New()
+	suite.users = []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+}
+
+func TestSplitTestSuite(t *testing.T) {
+	suite.Run(t, new(SplitTestSuite))
+}
+
+func (suite *SplitTestSuite) TestGivenEachMethod_WhenNewExpenseSplit_ThenTheAllocationsAddUpToTheTotal() {
+	testCases := []struct {
+		method   models.SplitMethod
+		values   []string
+		expected []string
+	}{
+		{method: models.EqualSplitMethod, values: []string{"", "", ""}, expected: []string{"33.34", "33.33", "33.33"}},
+		{method: models.PercentageSplitMethod, values: []string{"50", "33.33", "16.67"}, expected: []string{"50.00", "33.33", "16.67"}},
+		{method: models.SharesSplitMethod, values: []string{"2", "1", "0003"}, expected: []string{"33.34", "16.66", "50.00"}},
+		{method: models.ExactSplitMethod, values: []string{"70", "30", "0"}, expected: []string{"70.00", "30.00", "0.00"}},
+	}
+
+	total, _ := models.NewMoney("100", "EUR")
+	for _, testCase := range testCases {
+		split, err := models.NewExpenseSplit(total, suite.payer, testCase.method, suite.participants(testCase.values...))
+
+		require.NoError(suite.T(), err, testCase.method)
+		for i, allocation := range split.Allocations() {
+			assert.Equal(suite.T(), suite.users[i], allocation.UserId())
+			assert.Equal(suite.T(), testCase.expected[i], allocation.Amount().Amount(), testCase.method)
+		}
+		assert.Equal(suite.T(), total, split.Total())
+	}
+}
+
+func (suite *SplitTestSuite) TestGivenValuesThatDoNotMatchTheTotal_WhenNewExpenseSplit_ThenReturnError() {
+	testCases := []struct {
+		method models.SplitMethod
+		values []string
+	}{
+		{method: models.PercentageSplitMethod, values: []string{"50", "30", "10"}},
+		{method: models.PercentageSplitMethod, values: []string{"50", "50.001", "0"}},
+		{method: models.SharesSplitMethod, values: []string{"1", "0", "1"}},
+		{method: models.SharesSplitMethod, values: []string{"1", "1.5", "1"}},
+		{method: models.ExactSplitMethod, values: []string{"70", "20", "0"}},
+		{method: models.ExactSplitMethod, values: []string{"110", "-10", "0"}},
+		{method: "unknown", values: []string{"", "", ""}},
+	}
+
+	total, _ := models.NewMoney("100", "EUR")
+	for _, testCase := range testCases {
+		split, err := models.NewExpenseSplit(total, suite.payer, testCase.method, suite.participants(testCase.values...))
+
+		assert.Error(suite.T(), err, testCase)
+		assert.Nil(suite.T(), split)
+	}
+}
+
+func (suite *SplitTestSuite) TestGivenARepeatedParticipant_WhenNewExpenseSplit_ThenReturnError() {
+	total, _ := models.NewMoney("100", "EUR")
+	participant, _ := models.NewSplitParticipant(suite.users[0], "")
+
+	_, err := models.NewExpenseSplit(total, suite.payer, models.EqualSplitMethod, []*models.SplitParticipant{participant, participant})
+
+	assert.EqualError(suite.T(), err, "invalid participants, a user can only take part once")
+}
+
+func (suite *SplitTestSuite) TestGivenASplitExpense_WhenReallocate_ThenSplitTheNewTotalTheSameWay() {
+	total, _ := models.NewMoney("100", "EUR")
+	newTotal, _ := models.NewMoney("10", "EUR")
+	split, _ := models.NewExpenseSplit(total, suite.payer, models.SharesSplitMethod, suite.participants("1", "1", "1"))
+
+	reallocatedSplit, err := split.Reallocate(newTotal)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "3.34", reallocatedSplit.Allocations()[0].Amount().Amount())
+	assert.Equal(suite.T(), newTotal, reallocatedSplit.Total())
+}
+
+func (suite *SplitTestSuite) TestGivenASplitOfAnotherAmount_WhenWithSplit_ThenReturnError() {
+	total, _ := models.NewMoney("100", "EUR")
+	otherTotal, _ := models.NewMoney("90", "EUR")
+	expenseType, _ := models.NewExpenseType("Food")
+	expense, _ := models.NewExpense(total, time.Now(), "Dinner", expenseType)
+	split, _ := models.NewExpenseSplit(otherTotal, suite.payer, models.EqualSplitMethod, suite.participants("", "", ""))
+
+	splitExpense, err := expense.WithSplit(split)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), splitExpense)
+}
+
+func (suite *SplitTestSuite) participants(values ...string) []*models.SplitParticipant {
+	participants := []*models.SplitParticipant{}
+	for i, value := range values {
+		participant, _ := models.NewSplitParticipant(suite.users[i], value)
+		participants = append(participants, participant)
+	}
+	return participants
+}
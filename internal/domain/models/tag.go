@@ -0,0 +1,164 @@
+package models
+
+import (
+	"errors"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxTagNameLength is the length of the name column of tags.
+const MaxTagNameLength = 32
+
+type TagMatch string
+
+const (
+	AnyTagMatch TagMatch = "any"
+	AllTagMatch TagMatch = "all"
+)
+
+// Tag is a free-form label of the expenses of a ledger, such as vacation-2026 or reimbursable. Unlike expense types, an
+// expense can have many tags. Tags are told apart by their name, which is normalized with NormalizeTagName.
+type Tag struct {
+	id   uuid.UUID
+	name string
+}
+
+func NewTag(name string) (*Tag, error) {
+	id := pkg.NewUUID()
+	return NewTagWithId(id, name)
+}
+
+func NewTagWithId(id uuid.UUID, name string) (*Tag, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("invalid id, is must be a valid UUID")
+	}
+
+	name = NormalizeTagName(name)
+	if err := validateTagName(name); err != nil {
+		return nil, err
+	}
+
+	return &Tag{id: id, name: name}, nil
+}
+
+// NormalizeTagName lowercases the name and collapses its spaces, so Vacation  2026 and vacation 2026 are the same tag.
+func NormalizeTagName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// validateTagName rejects commas since tags are filtered with a comma separated list.
+func validateTagName(name string) error {
+	if name == "" {
+		return errors.New("invalid tag name, cannot be empty")
+	}
+
+	if utf8.RuneCountInString(name) > MaxTagNameLength {
+		return errors.New("invalid tag name, it cannot be longer than 32 characters")
+	}
+
+	if strings.Contains(name, ",") {
+		return errors.New("invalid tag name, it cannot contain commas")
+	}
+
+	return nil
+}
+
+// normalizeTagNames returns the names normalized, without duplicates and sorted, or nil when there are no names.
+func normalizeTagNames(names []string) ([]string, error) {
+	uniqueNames := map[string]bool{}
+	var normalizedNames []string
+	for _, name := range names {
+		name = NormalizeTagName(name)
+		if err := validateTagName(name); err != nil {
+			return nil, err
+		}
+
+		if !uniqueNames[name] {
+			uniqueNames[name] = true
+			normalizedNames = append(normalizedNames, name)
+		}
+	}
+
+	sort.Strings(normalizedNames)
+	return normalizedNames, nil
+}
+
+func (t Tag) Id() uuid.UUID {
+	return t.id
+}
+
+func (t Tag) Name() string {
+	return t.name
+}
+
+// WithName returns a copy of the tag with another name.
+func (t Tag) WithName(name string) (*Tag, error) {
+	return NewTagWithId(t.id, name)
+}
+
+func IsValidTagMatch(match string) bool {
+	switch TagMatch(match) {
+	case AnyTagMatch, AllTagMatch:
+		return true
+	}
+	return false
+}
+
+// TagFilter selects the expenses with any or with all of its tags.
+type TagFilter struct {
+	tags  []string
+	match TagMatch
+}
+
+// NewTagFilter builds a filter of the given tags, which defaults to matching any of them when match is empty.
+func NewTagFilter(tags []string, match string) (*TagFilter, error) {
+	if match == "" {
+		match = string(AnyTagMatch)
+	}
+
+	if !IsValidTagMatch(match) {
+		return nil, errors.New("invalid tag match, it must be any or all")
+	}
+
+	normalizedTags, err := normalizeTagNames(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(normalizedTags) == 0 {
+		return nil, errors.New("invalid tag filter, at least one tag is required")
+	}
+
+	return &TagFilter{tags: normalizedTags, match: TagMatch(match)}, nil
+}
+
+func (f TagFilter) Tags() []string {
+	return f.tags
+}
+
+func (f TagFilter) Match() TagMatch {
+	return f.match
+}
+
+// Matches tells whether the tags of an expense pass the filter.
+func (f TagFilter) Matches(tags []string) bool {
+	expenseTags := map[string]bool{}
+	for _, tag := range tags {
+		expenseTags[tag] = true
+	}
+
+	matched := 0
+	for _, tag := range f.tags {
+		if expenseTags[tag] {
+			matched++
+		}
+	}
+
+	if f.match == AllTagMatch {
+		return matched == len(f.tags)
+	}
+	return matched > 0
+}
@@ -0,0 +1,77 @@
+package models_test
+
+import (
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+	"time"
+)
+
+type TagTestSuite struct {
+	suite.Suite
+}
+
+func TestTagTestSuite(t *testing.T) {
+	suite.Run(t, new(TagTestSuite))
+}
+
+func (suite *TagTestSuite) TestGivenANameWithUppercaseAndSpaces_WhenNewTag_ThenNormalizeIt() {
+	tag, err := models.NewTag("  Vacation   2026 ")
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "vacation 2026", tag.Name())
+}
+
+func (suite *TagTestSuite) TestGivenInvalidNames_WhenNewTag_ThenReturnError() {
+	for _, name := range []string{"", "   ", "a,b", strings.Repeat("a", models.MaxTagNameLength+1)} {
+		tag, err := models.NewTag(name)
+
+		assert.Error(suite.T(), err, name)
+		assert.Nil(suite.T(), tag, name)
+	}
+}
+
+func (suite *TagTestSuite) TestGivenRepeatedTags_WhenWithTags_ThenKeepThemOnceAndSorted() {
+	amount, _ := models.NewMoney("10", "EUR")
+	expenseType, _ := models.NewExpenseTypeWithId(uuid.New(), "Travel")
+	expense, _ := models.NewExpenseWithId(uuid.New(), amount, time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), "Hotel", expenseType)
+
+	taggedExpense, err := expense.WithTags([]string{"Reimbursable", "vacation-2026", "reimbursable "})
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"reimbursable", "vacation-2026"}, taggedExpense.Tags())
+	assert.Empty(suite.T(), expense.Tags())
+}
+
+func (suite *TagTestSuite) TestGivenAFilter_WhenMatches_ThenFollowItsMatchMode() {
+	testCases := []struct {
+		match    string
+		tags     []string
+		expected bool
+	}{
+		{match: "any", tags: []string{"reimbursable"}, expected: true},
+		{match: "any", tags: []string{"work"}, expected: false},
+		{match: "all", tags: []string{"reimbursable", "vacation-2026", "work"}, expected: true},
+		{match: "all", tags: []string{"reimbursable"}, expected: false},
+		{match: "", tags: []string{"vacation-2026"}, expected: true},
+	}
+
+	for _, testCase := range testCases {
+		filter, err := models.NewTagFilter([]string{"Reimbursable", "vacation-2026"}, testCase.match)
+
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), testCase.expected, filter.Matches(testCase.tags), testCase)
+	}
+}
+
+func (suite *TagTestSuite) TestGivenAnInvalidMatchOrNoTags_WhenNewTagFilter_ThenReturnError() {
+	_, err := models.NewTagFilter([]string{"work"}, "some")
+	assert.Error(suite.T(), err)
+
+	_, err = models.NewTagFilter([]string{}, "all")
+	assert.Error(suite.T(), err)
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"errors"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Transfer moves money between two accounts of the same currency. It changes both balances but it is not an expense.
+type Transfer struct {
+	id           uuid.UUID
+	fromAccount  *Account
+	toAccount    *Account
+	amount       *Money
+	transferDate time.Time
+	description  string
+}
+
+func NewTransfer(fromAccount *Account, toAccount *Account, amount *Money, transferDate time.Time, description string) (*Transfer, error) {
+	id := pkg.NewUUID()
+	err := validateTransfer(id, fromAccount, toAccount, amount, transferDate)
+	if err != nil {
+		return nil, err
+	}
+	return &Transfer{id: id, fromAccount: fromAccount, toAccount: toAccount, amount: amount, transferDate: transferDate, description: description}, nil
+}
+
+func NewTransferWithId(id uuid.UUID, fromAccount *Account, toAccount *Account, amount *Money, transferDate time.Time, description string) (*Transfer, error) {
+	err := validateTransfer(id, fromAccount, toAccount, amount, transferDate)
+	if err != nil {
+		return nil, err
+	}
+	return &Transfer{id: id, fromAccount: fromAccount, toAccount: toAccount, amount: amount, transferDate: transferDate, description: description}, nil
+}
+
+func validateTransfer(id uuid.UUID, fromAccount *Account, toAccount *Account, amount *Money, transferDate time.Time) error {
+	if id == uuid.Nil {
+		return errors.New("invalid id, is must be a valid UUID")
+	}
+
+	if fromAccount == nil || toAccount == nil {
+		return errors.New("invalid accounts, they cannot be null")
+	}
+
+	if fromAccount.Id() == toAccount.Id() {
+		return errors.New("invalid accounts, they must be different")
+	}
+
+	if amount == nil || !amount.IsPositive() {
+		return errors.New("invalid transfer amount, it must be greater than zero")
+	}
+
+	if amount.Currency() != fromAccount.Currency() || amount.Currency() != toAccount.Currency() {
+		return errors.New("invalid transfer currency, it must match the currency of both accounts")
+	}
+
+	if transferDate.IsZero() {
+		return errors.New("invalid transfer date, it cannot be zero")
+	}
+	return nil
+}
+
+func (t Transfer) Id() uuid.UUID {
+	return t.id
+}
+
+func (t Transfer) FromAccount() *Account {
+	return t.fromAccount
+}
+
+func (t Transfer) ToAccount() *Account {
+	return t.toAccount
+}
+
+func (t Transfer) Amount() *Money {
+	return t.amount
+}
+
+func (t Transfer) TransferDate() time.Time {
+	return t.transferDate
+}
+
+func (t Transfer) Description() string {
+	return t.description
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"errors"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"strings"
+)
+
+type User struct {
+	id           uuid.UUID
+	email        string
+	passwordHash string
+}
+
+func NewUser(email string, passwordHash string) (*User, error) {
+	id := pkg.NewUUID()
+	return NewUserWithId(id, email, passwordHash)
+}
+
+func NewUserWithId(id uuid.UUID, email string, passwordHash string) (*User, error) {
+	email = NormalizeEmail(email)
+	err := validateUser(id, email, passwordHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &User{id: id, email: email, passwordHash: passwordHash}, nil
+}
+
+func validateUser(id uuid.UUID, email string, passwordHash string) error {
+	if id == uuid.Nil {
+		return errors.New("invalid id, is must be a valid UUID")
+	}
+
+	if pkg.IsEmptyOrBlankString(email) || !strings.Contains(email, "@") {
+		return errors.New("invalid email, it must be a valid address")
+	}
+
+	if pkg.IsEmptyOrBlankString(passwordHash) {
+		return errors.New("invalid password hash, cannot be empty")
+	}
+	return nil
+}
+
+// NormalizeEmail trims and lowercases the address, so the same user can't register twice with different casing.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (u User) Id() uuid.UUID {
+	return u.id
+}
+
+func (u User) Email() string {
+	return u.email
+}
+
+func (u User) PasswordHash() string {
+	return u.passwordHash
+}
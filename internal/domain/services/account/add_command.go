@@ -0,0 +1,26 @@
+package account
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/pkg"
+)
+
+type AddCommand struct {
+	name           string
+	kind           string
+	openingBalance string
+	currency       string
+}
+
+func NewAddCommand(name string, kind string, openingBalance string, currency string) (*AddCommand, error) {
+	if pkg.IsEmptyOrBlankString(name) || pkg.ExceedsMax(name, 32) || !models.IsValidAccountKind(kind) {
+		return nil, errors.New("invalid command")
+	}
+
+	if _, err := models.NewMoney(openingBalance, currency); err != nil {
+		return nil, errors.New("invalid command")
+	}
+
+	return &AddCommand{name: name, kind: kind, openingBalance: openingBalance, currency: currency}, nil
+}
@@ -0,0 +1,23 @@
+package account
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+type GetBalanceCommand struct {
+	accountId uuid.UUID
+	asOf      time.Time
+}
+
+func NewGetBalanceCommand(accountId uuid.UUID, asOf time.Time) (*GetBalanceCommand, error) {
+	if accountId == uuid.Nil || asOf.IsZero() {
+		return nil, errors.New("invalid command")
+	}
+	return &GetBalanceCommand{accountId: accountId, asOf: asOf}, nil
+}
+
+func (g GetBalanceCommand) AsOf() time.Time {
+	return g.asOf
+}
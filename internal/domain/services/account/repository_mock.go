@@ -0,0 +1,125 @@
+package account
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func NewRepositoryMock() *RepositoryMock {
+	return &RepositoryMock{}
+}
+
+func (r *RepositoryMock) Add(ctx context.Context, userId uuid.UUID, account *models.Account) (*models.Account, error) {
+	args := r.Called(ctx, userId, account)
+
+	savedAccount := args.Get(0)
+	err := args.Error(1)
+	if err == nil && savedAccount == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return args.Get(0).(*models.Account), nil
+	}
+}
+
+func (r *RepositoryMock) GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Account, error) {
+	args := r.Called(ctx, userId, id)
+
+	storedAccount := args.Get(0)
+	err := args.Error(1)
+	if err == nil && storedAccount == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return args.Get(0).(*models.Account), nil
+	}
+}
+
+func (r *RepositoryMock) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.Account, error) {
+	args := r.Called(ctx, userId)
+
+	accounts := args.Get(0)
+	err := args.Error(1)
+	if err == nil && accounts == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return args.Get(0).([]*models.Account), nil
+	}
+}
+
+func (r *RepositoryMock) AddTransfer(ctx context.Context, userId uuid.UUID, transfer *models.Transfer) (*models.Transfer, error) {
+	args := r.Called(ctx, userId, transfer)
+
+	savedTransfer := args.Get(0)
+	err := args.Error(1)
+	if err == nil && savedTransfer == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return args.Get(0).(*models.Transfer), nil
+	}
+}
+
+func (r *RepositoryMock) GetExpensesTotal(ctx context.Context, userId uuid.UUID, account *models.Account, until time.Time) (*models.Money, error) {
+	args := r.Called(ctx, userId, account, until)
+
+	total := args.Get(0)
+	err := args.Error(1)
+	if err == nil && total == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return args.Get(0).(*models.Money), nil
+	}
+}
+
+func (r *RepositoryMock) GetTransfersTotals(ctx context.Context, userId uuid.UUID, account *models.Account, until time.Time) (*models.Money, *models.Money, error) {
+	args := r.Called(ctx, userId, account, until)
+
+	err := args.Error(2)
+	if err != nil {
+		return nil, nil, err
+	}
+	return args.Get(0).(*models.Money), args.Get(1).(*models.Money), nil
+}
+
+func (r *RepositoryMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
+	r.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetByID(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetByID", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetAll(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockAddTransfer(callArguments, returnArguments []interface{}, times int) {
+	r.On("AddTransfer", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetExpensesTotal(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetExpensesTotal", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetTransfersTotals(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetTransfersTotals", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func anyContext(callArguments []interface{}) []interface{} {
+	return append([]interface{}{mock.Anything}, callArguments...)
+}
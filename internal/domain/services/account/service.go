@@ -0,0 +1,178 @@
+package account
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	accountNotFoundErrorMsg = "the account doesn't exists"
+)
+
+type Repository interface {
+	Add(ctx context.Context, userId uuid.UUID, account *models.Account) (*models.Account, error)
+	GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Account, error)
+	GetAll(ctx context.Context, userId uuid.UUID) ([]*models.Account, error)
+	AddTransfer(ctx context.Context, userId uuid.UUID, transfer *models.Transfer) (*models.Transfer, error)
+	// GetExpensesTotal sums the expenses paid from the account up to the given date, inclusive.
+	GetExpensesTotal(ctx context.Context, userId uuid.UUID, account *models.Account, until time.Time) (*models.Money, error)
+	// GetTransfersTotals sums the transfers received and sent by the account up to the given date, inclusive.
+	GetTransfersTotals(ctx context.Context, userId uuid.UUID, account *models.Account, until time.Time) (incoming *models.Money, outgoing *models.Money, err error)
+}
+
+type Service interface {
+	Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.Account, error)
+	GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Account, error)
+	GetAll(ctx context.Context, userId uuid.UUID) ([]*models.Account, error)
+	Transfer(ctx context.Context, userId uuid.UUID, command *TransferCommand) (*models.Transfer, error)
+	GetBalance(ctx context.Context, userId uuid.UUID, command *GetBalanceCommand) (*models.Money, error)
+}
+
+type service struct {
+	repository Repository
+}
+
+func NewService(repository Repository) *service {
+	return &service{repository: repository}
+}
+
+func (s service) Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.Account, error) {
+	accountToAdd, err := s.mapAddCommandToAccount(command)
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	addedAccount, err := s.repository.Add(ctx, userId, accountToAdd)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return addedAccount, nil
+}
+
+func (s service) GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Account, error) {
+	storedAccount, err := s.repository.GetByID(ctx, userId, id)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return storedAccount, nil
+}
+
+func (s service) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.Account, error) {
+	accounts, err := s.repository.GetAll(ctx, userId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return accounts, nil
+}
+
+func (s service) Transfer(ctx context.Context, userId uuid.UUID, command *TransferCommand) (*models.Transfer, error) {
+	fromAccount, err := s.getExistingAccount(ctx, userId, command.fromAccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	toAccount, err := s.getExistingAccount(ctx, userId, command.toAccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := models.NewMoney(command.amount, command.currency)
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	transferToAdd, err := models.NewTransfer(fromAccount, toAccount, amount, command.transferDate, command.description)
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	addedTransfer, err := s.repository.AddTransfer(ctx, userId, transferToAdd)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return addedTransfer, nil
+}
+
+// GetBalance computes the balance of the account at the end of the given date: the opening balance, minus the
+// expenses paid from it, plus the transfers it received, minus the transfers it sent.
+func (s service) GetBalance(ctx context.Context, userId uuid.UUID, command *GetBalanceCommand) (*models.Money, error) {
+	storedAccount, err := s.getExistingAccount(ctx, userId, command.accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	expensesTotal, err := s.repository.GetExpensesTotal(ctx, userId, storedAccount, command.asOf)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	incomingTransfers, outgoingTransfers, err := s.repository.GetTransfersTotals(ctx, userId, storedAccount, command.asOf)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	balance, err := storedAccount.OpeningBalance().Subtract(expensesTotal)
+	if err == nil {
+		balance, err = balance.Add(incomingTransfers)
+	}
+	if err == nil {
+		balance, err = balance.Subtract(outgoingTransfers)
+	}
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return balance, nil
+}
+
+func (s service) getExistingAccount(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Account, error) {
+	storedAccount, err := s.repository.GetByID(ctx, userId, id)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	if storedAccount == nil {
+		return nil, AccountNotFoundError{Msg: accountNotFoundErrorMsg}
+	}
+
+	return storedAccount, nil
+}
+
+func (s service) mapAddCommandToAccount(command *AddCommand) (*models.Account, error) {
+	openingBalance, err := models.NewMoney(command.openingBalance, command.currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewAccount(command.name, models.AccountKind(command.kind), openingBalance)
+}
+
+type UnexpectedError struct {
+	Msg string
+}
+
+func (receiver UnexpectedError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidDomainModelError struct {
+	Msg string
+}
+
+func (receiver InvalidDomainModelError) Error() string {
+	return receiver.Msg
+}
+
+type AccountNotFoundError struct {
+	Msg string
+}
+
+func (receiver AccountNotFoundError) Error() string {
+	return receiver.Msg
+}
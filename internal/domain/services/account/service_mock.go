@@ -0,0 +1,106 @@
+package account
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type ServiceMock struct {
+	mock.Mock
+}
+
+func NewServiceMock() *ServiceMock {
+	return &ServiceMock{}
+}
+
+func (s *ServiceMock) Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.Account, error) {
+	args := s.Called(ctx, userId, command)
+
+	err := args.Error(1)
+	accountToReturn := args.Get(0)
+	if err == nil && accountToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return accountToReturn.(*models.Account), nil
+	}
+}
+
+func (s *ServiceMock) GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Account, error) {
+	args := s.Called(ctx, userId, id)
+
+	err := args.Error(1)
+	accountToReturn := args.Get(0)
+	if err == nil && accountToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return accountToReturn.(*models.Account), nil
+	}
+}
+
+func (s *ServiceMock) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.Account, error) {
+	args := s.Called(ctx, userId)
+
+	err := args.Error(1)
+	accounts := args.Get(0)
+	if err == nil && accounts == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return accounts.([]*models.Account), nil
+	}
+}
+
+func (s *ServiceMock) Transfer(ctx context.Context, userId uuid.UUID, command *TransferCommand) (*models.Transfer, error) {
+	args := s.Called(ctx, userId, command)
+
+	err := args.Error(1)
+	transferToReturn := args.Get(0)
+	if err == nil && transferToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return transferToReturn.(*models.Transfer), nil
+	}
+}
+
+func (s *ServiceMock) GetBalance(ctx context.Context, userId uuid.UUID, command *GetBalanceCommand) (*models.Money, error) {
+	args := s.Called(ctx, userId, command)
+
+	err := args.Error(1)
+	balance := args.Get(0)
+	if err == nil && balance == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return balance.(*models.Money), nil
+	}
+}
+
+func (s *ServiceMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
+	s.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGetByID(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetById", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGetAll(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockTransfer(callArguments, returnArguments []interface{}, times int) {
+	s.On("Transfer", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGetBalance(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetBalance", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
@@ -0,0 +1,142 @@
+package account_test
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/account"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type AccountServiceTestSuite struct {
+	suite.Suite
+	userId         uuid.UUID
+	repositoryMock *account.RepositoryMock
+	service        account.Service
+}
+
+func (suite *AccountServiceTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.repositoryMock = account.NewRepositoryMock()
+	suite.service = account.NewService(suite.repositoryMock)
+	id := uuid.New()
+	pkg.NewUUID = func() uuid.UUID {
+		return id
+	}
+}
+
+func (suite *AccountServiceTestSuite) TearDownSuite() {
+	pkg.NewUUID = uuid.New
+}
+
+func (suite *AccountServiceTestSuite) TearDownTest() {
+	suite.repositoryMock.ExpectedCalls = nil
+	suite.repositoryMock.Calls = nil
+}
+
+func TestAccountServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountServiceTestSuite))
+}
+
+func (suite *AccountServiceTestSuite) TestGivenAnAccount_WhenAdd_ThenReturnCreatedAccount() {
+	openingBalance, _ := models.NewMoney("1000", "EUR")
+	expectedAccount, _ := models.NewAccount("Main bank", models.BankAccountKind, openingBalance)
+	suite.repositoryMock.MockAdd([]interface{}{suite.userId, expectedAccount}, []interface{}{expectedAccount, nil}, 1)
+
+	command, _ := account.NewAddCommand("Main bank", "bank", "1000", "EUR")
+	actualAccount, err := suite.service.Add(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedAccount, actualAccount)
+}
+
+func (suite *AccountServiceTestSuite) TestGivenAnInvalidKind_WhenNewAddCommand_ThenReturnError() {
+	_, err := account.NewAddCommand("Main bank", "piggy_bank", "1000", "EUR")
+
+	require.Error(suite.T(), err)
+}
+
+func (suite *AccountServiceTestSuite) TestGivenTwoAccounts_WhenTransfer_ThenReturnCreatedTransfer() {
+	fromAccount := suite.getAccount("Bank", "EUR")
+	toAccount := suite.getAccount("Cash", "EUR")
+	suite.repositoryMock.MockGetByID([]interface{}{suite.userId, fromAccount.Id()}, []interface{}{fromAccount, nil}, 1)
+	suite.repositoryMock.MockGetByID([]interface{}{suite.userId, toAccount.Id()}, []interface{}{toAccount, nil}, 1)
+	amount, _ := models.NewMoney("50", "EUR")
+	expectedTransfer, _ := models.NewTransfer(fromAccount, toAccount, amount, time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC), "ATM")
+	suite.repositoryMock.MockAddTransfer([]interface{}{suite.userId, expectedTransfer}, []interface{}{expectedTransfer, nil}, 1)
+
+	command, _ := account.NewTransferCommand(fromAccount.Id(), toAccount.Id(), "50", "EUR", time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC), "ATM")
+	transfer, err := suite.service.Transfer(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), fromAccount, transfer.FromAccount())
+	assert.Equal(suite.T(), toAccount, transfer.ToAccount())
+	assert.Equal(suite.T(), "50.00", transfer.Amount().Amount())
+}
+
+func (suite *AccountServiceTestSuite) TestGivenAccountsWithDifferentCurrencies_WhenTransfer_ThenReturnError() {
+	fromAccount := suite.getAccount("Bank", "EUR")
+	toAccount := suite.getAccount("Dollars", "USD")
+	suite.repositoryMock.MockGetByID([]interface{}{suite.userId, fromAccount.Id()}, []interface{}{fromAccount, nil}, 1)
+	suite.repositoryMock.MockGetByID([]interface{}{suite.userId, toAccount.Id()}, []interface{}{toAccount, nil}, 1)
+
+	command, _ := account.NewTransferCommand(fromAccount.Id(), toAccount.Id(), "50", "EUR", time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC), "")
+	transfer, err := suite.service.Transfer(context.Background(), suite.userId, command)
+
+	require.ErrorAs(suite.T(), err, &account.InvalidDomainModelError{})
+	require.Nil(suite.T(), transfer)
+}
+
+func (suite *AccountServiceTestSuite) TestGivenThatAccountNotExists_WhenTransfer_ThenReturnNotFoundError() {
+	fromId := uuid.New()
+	suite.repositoryMock.MockGetByID([]interface{}{suite.userId, fromId}, []interface{}{nil, nil}, 1)
+
+	command, _ := account.NewTransferCommand(fromId, uuid.New(), "50", "EUR", time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC), "")
+	transfer, err := suite.service.Transfer(context.Background(), suite.userId, command)
+
+	require.ErrorAs(suite.T(), err, &account.AccountNotFoundError{})
+	require.Nil(suite.T(), transfer)
+}
+
+func (suite *AccountServiceTestSuite) TestGivenMovements_WhenGetBalance_ThenReturnOpeningBalancePlusMovements() {
+	storedAccount := suite.getAccount("Bank", "EUR")
+	asOf := time.Date(2022, 6, 30, 0, 0, 0, 0, time.UTC)
+	expensesTotal, _ := models.NewMoney("120.35", "EUR")
+	incoming, _ := models.NewMoney("200", "EUR")
+	outgoing, _ := models.NewMoney("50.10", "EUR")
+	suite.repositoryMock.MockGetByID([]interface{}{suite.userId, storedAccount.Id()}, []interface{}{storedAccount, nil}, 1)
+	suite.repositoryMock.MockGetExpensesTotal([]interface{}{suite.userId, storedAccount, asOf}, []interface{}{expensesTotal, nil}, 1)
+	suite.repositoryMock.MockGetTransfersTotals([]interface{}{suite.userId, storedAccount, asOf}, []interface{}{incoming, outgoing, nil}, 1)
+
+	command, _ := account.NewGetBalanceCommand(storedAccount.Id(), asOf)
+	balance, err := suite.service.GetBalance(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "1029.55", balance.Amount())
+	assert.Equal(suite.T(), "EUR", balance.Currency())
+}
+
+func (suite *AccountServiceTestSuite) TestGivenThatRepositoryFails_WhenGetBalance_ThenReturnError() {
+	storedAccount := suite.getAccount("Bank", "EUR")
+	asOf := time.Date(2022, 6, 30, 0, 0, 0, 0, time.UTC)
+	suite.repositoryMock.MockGetByID([]interface{}{suite.userId, storedAccount.Id()}, []interface{}{storedAccount, nil}, 1)
+	suite.repositoryMock.MockGetExpensesTotal([]interface{}{suite.userId, storedAccount, asOf}, []interface{}{nil, errors.New("fail")}, 1)
+
+	command, _ := account.NewGetBalanceCommand(storedAccount.Id(), asOf)
+	balance, err := suite.service.GetBalance(context.Background(), suite.userId, command)
+
+	require.ErrorAs(suite.T(), err, &account.UnexpectedError{})
+	require.Nil(suite.T(), balance)
+}
+
+func (suite *AccountServiceTestSuite) getAccount(name string, currency string) *models.Account {
+	openingBalance, _ := models.NewMoney("1000", currency)
+	newAccount, _ := models.NewAccountWithId(uuid.New(), name, models.BankAccountKind, openingBalance)
+	return newAccount
+}
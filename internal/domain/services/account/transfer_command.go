@@ -0,0 +1,38 @@
+package account
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+type TransferCommand struct {
+	fromAccountId uuid.UUID
+	toAccountId   uuid.UUID
+	amount        string
+	currency      string
+	transferDate  time.Time
+	description   string
+}
+
+func NewTransferCommand(fromAccountId uuid.UUID, toAccountId uuid.UUID, amount string, currency string, transferDate time.Time, description string) (*TransferCommand, error) {
+	if fromAccountId == uuid.Nil || toAccountId == uuid.Nil || fromAccountId == toAccountId || transferDate.IsZero() {
+		return nil, errors.New("invalid command")
+	}
+
+	money, err := models.NewMoney(amount, currency)
+	if err != nil || !money.IsPositive() {
+		return nil, errors.New("invalid command")
+	}
+
+	return &TransferCommand{
+		fromAccountId: fromAccountId,
+		toAccountId:   toAccountId,
+		amount:        amount,
+		currency:      currency,
+		transferDate:  transferDate,
+		description:   strings.TrimSpace(description),
+	}, nil
+}
@@ -0,0 +1,84 @@
+package attachment
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"net/http"
+	"path"
+	"strings"
+)
+
+const (
+	attachmentTooLargeErrorMsg      = "the file is larger than 10 MiB"
+	unsupportedContentTypeErrorMsg  = "only PDF files and JPEG, PNG, WebP or GIF images can be attached"
+	defaultAttachmentFileNamePrefix = "receipt"
+)
+
+type AddCommand struct {
+	expenseId   uuid.UUID
+	fileName    string
+	contentType string
+	content     []byte
+	checksum    string
+}
+
+// NewAddCommand attaches the content to the expense. The content type is sniffed from the content rather than trusted
+// from the client, and the file name is stripped of its directories.
+func NewAddCommand(expenseId uuid.UUID, fileName string, content []byte) (*AddCommand, error) {
+	if expenseId == uuid.Nil || len(content) == 0 {
+		return nil, errors.New("invalid command")
+	}
+
+	if len(content) > models.MaxAttachmentSize {
+		return nil, AttachmentTooLargeError{Msg: attachmentTooLargeErrorMsg}
+	}
+
+	contentType := http.DetectContentType(content)
+	if !models.IsAllowedAttachmentContentType(contentType) {
+		return nil, UnsupportedContentTypeError{Msg: unsupportedContentTypeErrorMsg}
+	}
+
+	checksum := sha256.Sum256(content)
+	return &AddCommand{
+		expenseId:   expenseId,
+		fileName:    cleanFileName(fileName, contentType),
+		contentType: contentType,
+		content:     content,
+		checksum:    hex.EncodeToString(checksum[:]),
+	}, nil
+}
+
+// cleanFileName keeps the last segment of the name, since browsers may send the whole path of the file, and names the
+// files sent without a name after their content type.
+func cleanFileName(fileName string, contentType string) string {
+	fileName = strings.TrimSpace(path.Base(strings.ReplaceAll(fileName, "\\", "/")))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		fileName = defaultAttachmentFileNamePrefix + "." + path.Base(contentType)
+	}
+
+	runes := []rune(fileName)
+	if len(runes) > models.MaxAttachmentFileNameLength {
+		fileName = string(runes[len(runes)-models.MaxAttachmentFileNameLength:])
+	}
+	return fileName
+}
+
+func (c AddCommand) ExpenseId() uuid.UUID {
+	return c.expenseId
+}
+
+func (c AddCommand) FileName() string {
+	return c.fileName
+}
+
+func (c AddCommand) ContentType() string {
+	return c.contentType
+}
+
+// Checksum is the hex SHA-256 of the content.
+func (c AddCommand) Checksum() string {
+	return c.checksum
+}
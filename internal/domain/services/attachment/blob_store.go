@@ -0,0 +1,23 @@
+package attachment
+
+import "io"
+
+// BlobStore keeps the content of the attachments by key, apart from their metadata. Keys are paths of segments split by
+// slashes, such as ledgerId/expenseId/attachmentId.
+type BlobStore interface {
+	// Put stores the content under the key, replacing any content already stored there.
+	Put(key string, content []byte, contentType string) error
+	// Get returns a reader of the content, which the caller must close. It returns BlobNotFoundError when nothing is
+	// stored under the key.
+	Get(key string) (io.ReadCloser, error)
+	// Delete removes the content, and does nothing when nothing is stored under the key.
+	Delete(key string) error
+}
+
+type BlobNotFoundError struct {
+	Msg string
+}
+
+func (receiver BlobNotFoundError) Error() string {
+	return receiver.Msg
+}
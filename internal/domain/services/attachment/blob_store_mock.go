@@ -0,0 +1,50 @@
+package attachment
+
+import (
+	"github.com/stretchr/testify/mock"
+	"io"
+)
+
+type BlobStoreMock struct {
+	mock.Mock
+}
+
+func NewBlobStoreMock() *BlobStoreMock {
+	return &BlobStoreMock{}
+}
+
+func (b *BlobStoreMock) Put(key string, content []byte, contentType string) error {
+	args := b.Called(key, content, contentType)
+	return args.Error(0)
+}
+
+func (b *BlobStoreMock) Get(key string) (io.ReadCloser, error) {
+	args := b.Called(key)
+
+	err := args.Error(1)
+	content := args.Get(0)
+	if err == nil && content == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return content.(io.ReadCloser), nil
+	}
+}
+
+func (b *BlobStoreMock) Delete(key string) error {
+	args := b.Called(key)
+	return args.Error(0)
+}
+
+func (b *BlobStoreMock) MockPut(callArguments, returnArguments []interface{}, times int) {
+	b.On("Put", callArguments...).Return(returnArguments...).Times(times)
+}
+
+func (b *BlobStoreMock) MockGet(callArguments, returnArguments []interface{}, times int) {
+	b.On("Get", callArguments...).Return(returnArguments...).Times(times)
+}
+
+func (b *BlobStoreMock) MockDelete(callArguments, returnArguments []interface{}, times int) {
+	b.On("Delete", callArguments...).Return(returnArguments...).Times(times)
+}
@@ -0,0 +1,101 @@
+package attachment
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func NewRepositoryMock() *RepositoryMock {
+	return &RepositoryMock{}
+}
+
+func (r *RepositoryMock) Add(ctx context.Context, ledgerId uuid.UUID, attachment *models.Attachment) (*models.Attachment, error) {
+	args := r.Called(ctx, ledgerId, attachment)
+
+	err := args.Error(1)
+	createdAttachment := args.Get(0)
+	if err == nil && createdAttachment == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return createdAttachment.(*models.Attachment), nil
+	}
+}
+
+func (r *RepositoryMock) GetAll(ctx context.Context, ledgerId uuid.UUID, expenseId uuid.UUID) ([]*models.Attachment, error) {
+	args := r.Called(ctx, ledgerId, expenseId)
+
+	err := args.Error(1)
+	attachments := args.Get(0)
+	if err == nil && attachments == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return attachments.([]*models.Attachment), nil
+	}
+}
+
+func (r *RepositoryMock) GetByID(ctx context.Context, ledgerId uuid.UUID, expenseId uuid.UUID, id uuid.UUID) (*models.Attachment, error) {
+	args := r.Called(ctx, ledgerId, expenseId, id)
+
+	err := args.Error(1)
+	attachment := args.Get(0)
+	if err == nil && attachment == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return attachment.(*models.Attachment), nil
+	}
+}
+
+func (r *RepositoryMock) GetByChecksum(ctx context.Context, ledgerId uuid.UUID, expenseId uuid.UUID, checksum string) (*models.Attachment, error) {
+	args := r.Called(ctx, ledgerId, expenseId, checksum)
+
+	err := args.Error(1)
+	attachment := args.Get(0)
+	if err == nil && attachment == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return attachment.(*models.Attachment), nil
+	}
+}
+
+func (r *RepositoryMock) Delete(ctx context.Context, ledgerId uuid.UUID, expenseId uuid.UUID, id uuid.UUID) error {
+	args := r.Called(ctx, ledgerId, expenseId, id)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
+	r.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetAll(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetByID(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetByID", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetByChecksum(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetByChecksum", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockDelete(callArguments, returnArguments []interface{}, times int) {
+	r.On("Delete", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func anyContext(callArguments []interface{}) []interface{} {
+	return append([]interface{}{mock.Anything}, callArguments...)
+}
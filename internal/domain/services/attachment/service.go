@@ -0,0 +1,210 @@
+package attachment
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/expense"
+	"finfit-backend/internal/domain/services/ledger"
+	"github.com/google/uuid"
+	"io"
+	"time"
+)
+
+const (
+	attachmentNotFoundErrorMsg  = "the attachment doesn't exists"
+	duplicateAttachmentErrorMsg = "the same file is already attached to the expense"
+)
+
+type Repository interface {
+	Add(ctx context.Context, ledgerId uuid.UUID, attachment *models.Attachment) (*models.Attachment, error)
+	GetAll(ctx context.Context, ledgerId uuid.UUID, expenseId uuid.UUID) ([]*models.Attachment, error)
+	GetByID(ctx context.Context, ledgerId uuid.UUID, expenseId uuid.UUID, id uuid.UUID) (*models.Attachment, error)
+	// GetByChecksum returns the attachment of the expense with the same content, if any.
+	GetByChecksum(ctx context.Context, ledgerId uuid.UUID, expenseId uuid.UUID, checksum string) (*models.Attachment, error)
+	Delete(ctx context.Context, ledgerId uuid.UUID, expenseId uuid.UUID, id uuid.UUID) error
+}
+
+// Service works on the files attached to the expenses of a ledger. Their metadata is stored in the repository and
+// their content in the blob store. Every method checks the role of the user in the ledger first and returns the errors
+// of the ledger service when it isn't enough.
+type Service interface {
+	Add(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *AddCommand) (*models.Attachment, error)
+	GetAll(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, expenseId uuid.UUID) ([]*models.Attachment, error)
+	Download(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, expenseId uuid.UUID, id uuid.UUID) (*models.Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, expenseId uuid.UUID, id uuid.UUID) error
+}
+
+type service struct {
+	repository     Repository
+	blobStore      BlobStore
+	expenseService expense.Service
+	ledgerService  ledger.Service
+}
+
+func NewService(repository Repository, blobStore BlobStore, expenseService expense.Service, ledgerService ledger.Service) *service {
+	return &service{repository: repository, blobStore: blobStore, expenseService: expenseService, ledgerService: ledgerService}
+}
+
+// Add returns DuplicateAttachmentError when the expense already has a file with the same content. The content is
+// stored first, and removed again if the attachment can't be stored, so no attachment points to missing content.
+func (s service) Add(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *AddCommand) (*models.Attachment, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.EditorLedgerRole); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.expenseService.GetById(ctx, userId, ledgerId, command.expenseId); err != nil {
+		return nil, err
+	}
+
+	duplicateAttachment, err := s.repository.GetByChecksum(ctx, ledgerId, command.expenseId, command.checksum)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	if duplicateAttachment != nil {
+		return nil, DuplicateAttachmentError{Msg: duplicateAttachmentErrorMsg}
+	}
+
+	attachment, err := models.NewAttachment(command.expenseId, command.fileName, command.contentType, int64(len(command.content)), command.checksum, time.Now().UTC())
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	key := blobKey(ledgerId, attachment)
+	if err = s.blobStore.Put(key, command.content, attachment.ContentType()); err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	createdAttachment, err := s.repository.Add(ctx, ledgerId, attachment)
+	if err != nil {
+		_ = s.blobStore.Delete(key)
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return createdAttachment, nil
+}
+
+// GetAll returns ExpenseNotFoundError of the expense service when the expense doesn't exist.
+func (s service) GetAll(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, expenseId uuid.UUID) ([]*models.Attachment, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.ViewerLedgerRole); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.expenseService.GetById(ctx, userId, ledgerId, expenseId); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.repository.GetAll(ctx, ledgerId, expenseId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return attachments, nil
+}
+
+// Download returns the attachment along with a reader of its content, which the caller must close.
+func (s service) Download(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, expenseId uuid.UUID, id uuid.UUID) (*models.Attachment, io.ReadCloser, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.ViewerLedgerRole); err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := s.getAttachment(ctx, ledgerId, expenseId, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.blobStore.Get(blobKey(ledgerId, attachment))
+	if err != nil {
+		return nil, nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return attachment, content, nil
+}
+
+// Delete removes the attachment before its content, so a failure leaves unreachable content behind rather than an
+// attachment without content.
+func (s service) Delete(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, expenseId uuid.UUID, id uuid.UUID) error {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.EditorLedgerRole); err != nil {
+		return err
+	}
+
+	attachment, err := s.getAttachment(ctx, ledgerId, expenseId, id)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repository.Delete(ctx, ledgerId, expenseId, id); err != nil {
+		return UnexpectedError{Msg: err.Error()}
+	}
+
+	if err = s.blobStore.Delete(blobKey(ledgerId, attachment)); err != nil {
+		return UnexpectedError{Msg: err.Error()}
+	}
+
+	return nil
+}
+
+func (s service) getAttachment(ctx context.Context, ledgerId uuid.UUID, expenseId uuid.UUID, id uuid.UUID) (*models.Attachment, error) {
+	attachment, err := s.repository.GetByID(ctx, ledgerId, expenseId, id)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	if attachment == nil {
+		return nil, AttachmentNotFoundError{Msg: attachmentNotFoundErrorMsg}
+	}
+
+	return attachment, nil
+}
+
+func blobKey(ledgerId uuid.UUID, attachment *models.Attachment) string {
+	return ledgerId.String() + "/" + attachment.ExpenseId().String() + "/" + attachment.Id().String()
+}
+
+type UnexpectedError struct {
+	Msg string
+}
+
+func (receiver UnexpectedError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidDomainModelError struct {
+	Msg string
+}
+
+func (receiver InvalidDomainModelError) Error() string {
+	return receiver.Msg
+}
+
+type AttachmentNotFoundError struct {
+	Msg string
+}
+
+func (receiver AttachmentNotFoundError) Error() string {
+	return receiver.Msg
+}
+
+type DuplicateAttachmentError struct {
+	Msg string
+}
+
+func (receiver DuplicateAttachmentError) Error() string {
+	return receiver.Msg
+}
+
+type AttachmentTooLargeError struct {
+	Msg string
+}
+
+func (receiver AttachmentTooLargeError) Error() string {
+	return receiver.Msg
+}
+
+type UnsupportedContentTypeError struct {
+	Msg string
+}
+
+func (receiver UnsupportedContentTypeError) Error() string {
+	return receiver.Msg
+}
@@ -0,0 +1,80 @@
+package attachment
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"io"
+)
+
+type ServiceMock struct {
+	mock.Mock
+}
+
+func NewServiceMock() *ServiceMock {
+	return &ServiceMock{}
+}
+
+func (s *ServiceMock) Add(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *AddCommand) (*models.Attachment, error) {
+	args := s.Called(ctx, userId, ledgerId, command)
+
+	err := args.Error(1)
+	attachment := args.Get(0)
+	if err == nil && attachment == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return attachment.(*models.Attachment), nil
+	}
+}
+
+func (s *ServiceMock) GetAll(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, expenseId uuid.UUID) ([]*models.Attachment, error) {
+	args := s.Called(ctx, userId, ledgerId, expenseId)
+
+	err := args.Error(1)
+	attachments := args.Get(0)
+	if err == nil && attachments == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return attachments.([]*models.Attachment), nil
+	}
+}
+
+func (s *ServiceMock) Download(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, expenseId uuid.UUID, id uuid.UUID) (*models.Attachment, io.ReadCloser, error) {
+	args := s.Called(ctx, userId, ledgerId, expenseId, id)
+
+	err := args.Error(2)
+	attachment := args.Get(0)
+	if err != nil {
+		return nil, nil, err
+	} else if attachment == nil {
+		return nil, nil, nil
+	} else {
+		return attachment.(*models.Attachment), args.Get(1).(io.ReadCloser), nil
+	}
+}
+
+func (s *ServiceMock) Delete(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, expenseId uuid.UUID, id uuid.UUID) error {
+	args := s.Called(ctx, userId, ledgerId, expenseId, id)
+	return args.Error(0)
+}
+
+func (s *ServiceMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
+	s.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGetAll(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockDownload(callArguments, returnArguments []interface{}, times int) {
+	s.On("Download", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockDelete(callArguments, returnArguments []interface{}, times int) {
+	s.On("Delete", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
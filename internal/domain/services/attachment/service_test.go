@@ -0,0 +1,210 @@
+package attachment_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/attachment"
+	"finfit-backend/internal/domain/services/expense"
+	"finfit-backend/internal/domain/services/ledger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+const pdfContent = "%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\n%%EOF"
+
+type AttachmentServiceTestSuite struct {
+	suite.Suite
+	userId             uuid.UUID
+	ledgerId           uuid.UUID
+	expenseId          uuid.UUID
+	repositoryMock     *attachment.RepositoryMock
+	blobStoreMock      *attachment.BlobStoreMock
+	expenseServiceMock *expense.ServiceMock
+	ledgerServiceMock  *ledger.ServiceMock
+	service            attachment.Service
+}
+
+func (suite *AttachmentServiceTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.ledgerId = uuid.New()
+	suite.expenseId = uuid.New()
+	suite.repositoryMock = attachment.NewRepositoryMock()
+	suite.blobStoreMock = attachment.NewBlobStoreMock()
+	suite.expenseServiceMock = expense.NewServiceMock()
+	suite.ledgerServiceMock = ledger.NewServiceMock()
+	suite.service = attachment.NewService(suite.repositoryMock, suite.blobStoreMock, suite.expenseServiceMock, suite.ledgerServiceMock)
+}
+
+func (suite *AttachmentServiceTestSuite) SetupTest() {
+	suite.ledgerServiceMock.MockAuthorize([]interface{}{suite.userId, suite.ledgerId, mock.Anything}, []interface{}{nil}, 0)
+}
+
+func (suite *AttachmentServiceTestSuite) TearDownTest() {
+	suite.repositoryMock.ExpectedCalls = nil
+	suite.repositoryMock.Calls = nil
+	suite.blobStoreMock.ExpectedCalls = nil
+	suite.blobStoreMock.Calls = nil
+	suite.expenseServiceMock.ExpectedCalls = nil
+	suite.expenseServiceMock.Calls = nil
+	suite.ledgerServiceMock.ExpectedCalls = nil
+	suite.ledgerServiceMock.Calls = nil
+}
+
+func TestAttachmentServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentServiceTestSuite))
+}
+
+func (suite *AttachmentServiceTestSuite) TestGivenAReceipt_WhenAdd_ThenStoreItsContentAndItsMetadata() {
+	command, _ := attachment.NewAddCommand(suite.expenseId, "C:\\Users\\me\\receipt.pdf", []byte(pdfContent))
+	suite.expenseServiceMock.MockGetByID([]interface{}{suite.userId, suite.ledgerId, suite.expenseId}, []interface{}{suite.getExpense(), nil}, 1)
+	suite.repositoryMock.MockGetByChecksum([]interface{}{suite.ledgerId, suite.expenseId, command.Checksum()}, []interface{}{nil, nil}, 1)
+	suite.blobStoreMock.MockPut([]interface{}{mock.Anything, []byte(pdfContent), "application/pdf"}, []interface{}{nil}, 1)
+	suite.repositoryMock.MockAdd([]interface{}{suite.ledgerId, mock.AnythingOfType("*models.Attachment")}, []interface{}{suite.getAttachment(), nil}, 1)
+
+	_, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	addedAttachment := suite.repositoryMock.Calls[1].Arguments.Get(2).(*models.Attachment)
+	assert.Equal(suite.T(), "receipt.pdf", addedAttachment.FileName())
+	assert.Equal(suite.T(), "application/pdf", addedAttachment.ContentType())
+	assert.Equal(suite.T(), int64(len(pdfContent)), addedAttachment.Size())
+	assert.Equal(suite.T(), command.Checksum(), addedAttachment.Checksum())
+	suite.blobStoreMock.AssertCalled(suite.T(), "Put", suite.ledgerId.String()+"/"+suite.expenseId.String()+"/"+addedAttachment.Id().String(), []byte(pdfContent), "application/pdf")
+	suite.ledgerServiceMock.AssertCalled(suite.T(), "Authorize", mock.Anything, suite.userId, suite.ledgerId, models.EditorLedgerRole)
+}
+
+func (suite *AttachmentServiceTestSuite) TestGivenAFileAlreadyAttached_WhenAdd_ThenReturnDuplicateAttachmentError() {
+	command, _ := attachment.NewAddCommand(suite.expenseId, "receipt.pdf", []byte(pdfContent))
+	suite.expenseServiceMock.MockGetByID([]interface{}{suite.userId, suite.ledgerId, suite.expenseId}, []interface{}{suite.getExpense(), nil}, 1)
+	suite.repositoryMock.MockGetByChecksum([]interface{}{suite.ledgerId, suite.expenseId, command.Checksum()}, []interface{}{suite.getAttachment(), nil}, 1)
+
+	createdAttachment, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.Nil(suite.T(), createdAttachment)
+	assert.ErrorAs(suite.T(), err, &attachment.DuplicateAttachmentError{})
+	suite.blobStoreMock.AssertNotCalled(suite.T(), "Put", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AttachmentServiceTestSuite) TestGivenAnExpenseThatNotExists_WhenAdd_ThenReturnTheErrorOfTheExpenseService() {
+	command, _ := attachment.NewAddCommand(suite.expenseId, "receipt.pdf", []byte(pdfContent))
+	expectedError := expense.ExpenseNotFoundError{Msg: "the expense doesn't exists"}
+	suite.expenseServiceMock.MockGetByID([]interface{}{suite.userId, suite.ledgerId, suite.expenseId}, []interface{}{nil, expectedError}, 1)
+
+	createdAttachment, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.Nil(suite.T(), createdAttachment)
+	assert.Equal(suite.T(), expectedError, err)
+}
+
+func (suite *AttachmentServiceTestSuite) TestGivenThatTheRepositoryFails_WhenAdd_ThenRemoveTheStoredContent() {
+	command, _ := attachment.NewAddCommand(suite.expenseId, "receipt.pdf", []byte(pdfContent))
+	suite.expenseServiceMock.MockGetByID([]interface{}{suite.userId, suite.ledgerId, suite.expenseId}, []interface{}{suite.getExpense(), nil}, 1)
+	suite.repositoryMock.MockGetByChecksum([]interface{}{suite.ledgerId, suite.expenseId, command.Checksum()}, []interface{}{nil, nil}, 1)
+	suite.blobStoreMock.MockPut([]interface{}{mock.Anything, []byte(pdfContent), "application/pdf"}, []interface{}{nil}, 1)
+	suite.repositoryMock.MockAdd([]interface{}{suite.ledgerId, mock.AnythingOfType("*models.Attachment")}, []interface{}{nil, errors.New("connection lost")}, 1)
+	suite.blobStoreMock.MockDelete([]interface{}{mock.Anything}, []interface{}{nil}, 1)
+
+	createdAttachment, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.Nil(suite.T(), createdAttachment)
+	assert.ErrorAs(suite.T(), err, &attachment.UnexpectedError{})
+	storedKey := suite.blobStoreMock.Calls[0].Arguments.String(0)
+	suite.blobStoreMock.AssertCalled(suite.T(), "Delete", storedKey)
+}
+
+func (suite *AttachmentServiceTestSuite) TestGivenAnExpense_WhenGetAll_ThenReturnItsAttachments() {
+	expectedAttachments := []*models.Attachment{suite.getAttachment()}
+	suite.expenseServiceMock.MockGetByID([]interface{}{suite.userId, suite.ledgerId, suite.expenseId}, []interface{}{suite.getExpense(), nil}, 1)
+	suite.repositoryMock.MockGetAll([]interface{}{suite.ledgerId, suite.expenseId}, []interface{}{expectedAttachments, nil}, 1)
+
+	attachments, err := suite.service.GetAll(context.Background(), suite.userId, suite.ledgerId, suite.expenseId)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedAttachments, attachments)
+	suite.ledgerServiceMock.AssertCalled(suite.T(), "Authorize", mock.Anything, suite.userId, suite.ledgerId, models.ViewerLedgerRole)
+}
+
+func (suite *AttachmentServiceTestSuite) TestGivenAnAttachment_WhenDownload_ThenReturnItWithItsContent() {
+	storedAttachment := suite.getAttachment()
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, suite.expenseId, storedAttachment.Id()}, []interface{}{storedAttachment, nil}, 1)
+	suite.blobStoreMock.MockGet([]interface{}{suite.ledgerId.String() + "/" + suite.expenseId.String() + "/" + storedAttachment.Id().String()}, []interface{}{io.NopCloser(strings.NewReader(pdfContent)), nil}, 1)
+
+	downloadedAttachment, content, err := suite.service.Download(context.Background(), suite.userId, suite.ledgerId, suite.expenseId, storedAttachment.Id())
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), storedAttachment, downloadedAttachment)
+	readContent, _ := io.ReadAll(content)
+	assert.Equal(suite.T(), pdfContent, string(readContent))
+}
+
+func (suite *AttachmentServiceTestSuite) TestGivenAnAttachmentThatNotExists_WhenDownload_ThenReturnAttachmentNotFoundError() {
+	id := uuid.New()
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, suite.expenseId, id}, []interface{}{nil, nil}, 1)
+
+	downloadedAttachment, content, err := suite.service.Download(context.Background(), suite.userId, suite.ledgerId, suite.expenseId, id)
+
+	require.Nil(suite.T(), downloadedAttachment)
+	require.Nil(suite.T(), content)
+	assert.ErrorAs(suite.T(), err, &attachment.AttachmentNotFoundError{})
+}
+
+func (suite *AttachmentServiceTestSuite) TestGivenAnAttachment_WhenDelete_ThenDeleteItAndItsContent() {
+	storedAttachment := suite.getAttachment()
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, suite.expenseId, storedAttachment.Id()}, []interface{}{storedAttachment, nil}, 1)
+	suite.repositoryMock.MockDelete([]interface{}{suite.ledgerId, suite.expenseId, storedAttachment.Id()}, []interface{}{nil}, 1)
+	blobKey := suite.ledgerId.String() + "/" + suite.expenseId.String() + "/" + storedAttachment.Id().String()
+	suite.blobStoreMock.MockDelete([]interface{}{blobKey}, []interface{}{nil}, 1)
+
+	err := suite.service.Delete(context.Background(), suite.userId, suite.ledgerId, suite.expenseId, storedAttachment.Id())
+
+	require.NoError(suite.T(), err)
+	suite.repositoryMock.AssertCalled(suite.T(), "Delete", mock.Anything, suite.ledgerId, suite.expenseId, storedAttachment.Id())
+	suite.blobStoreMock.AssertCalled(suite.T(), "Delete", blobKey)
+	suite.ledgerServiceMock.AssertCalled(suite.T(), "Authorize", mock.Anything, suite.userId, suite.ledgerId, models.EditorLedgerRole)
+}
+
+func (suite *AttachmentServiceTestSuite) TestGivenAnUnsupportedFile_WhenNewAddCommand_ThenReturnUnsupportedContentTypeError() {
+	command, err := attachment.NewAddCommand(suite.expenseId, "notes.txt", []byte("just some notes"))
+
+	require.Nil(suite.T(), command)
+	assert.ErrorAs(suite.T(), err, &attachment.UnsupportedContentTypeError{})
+}
+
+func (suite *AttachmentServiceTestSuite) TestGivenATooLargeFile_WhenNewAddCommand_ThenReturnAttachmentTooLargeError() {
+	content := append([]byte(pdfContent), bytes.Repeat([]byte{0}, models.MaxAttachmentSize)...)
+
+	command, err := attachment.NewAddCommand(suite.expenseId, "scan.pdf", content)
+
+	require.Nil(suite.T(), command)
+	assert.ErrorAs(suite.T(), err, &attachment.AttachmentTooLargeError{})
+}
+
+func (suite *AttachmentServiceTestSuite) TestGivenAFileWithoutName_WhenNewAddCommand_ThenNameItAfterItsContentType() {
+	command, err := attachment.NewAddCommand(suite.expenseId, "", []byte("\x89PNG\r\n\x1a\n0000"))
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "receipt.png", command.FileName())
+	assert.Equal(suite.T(), "image/png", command.ContentType())
+}
+
+func (suite *AttachmentServiceTestSuite) getExpense() *models.Expense {
+	amount, _ := models.NewMoney("120.50", "EUR")
+	expenseType, _ := models.NewExpenseTypeWithId(uuid.New(), "Dinner")
+	expense, _ := models.NewExpenseWithId(suite.expenseId, amount, time.Date(2022, 5, 13, 0, 0, 0, 0, time.UTC), "Lomitos", expenseType)
+	return expense
+}
+
+func (suite *AttachmentServiceTestSuite) getAttachment() *models.Attachment {
+	checksum := strings.Repeat("ab", 32)
+	storedAttachment, _ := models.NewAttachmentWithId(uuid.New(), suite.expenseId, "receipt.pdf", "application/pdf", int64(len(pdfContent)), checksum, time.Date(2022, 5, 14, 10, 0, 0, 0, time.UTC))
+	return storedAttachment
+}
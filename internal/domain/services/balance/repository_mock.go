@@ -0,0 +1,74 @@
+package balance
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func NewRepositoryMock() *RepositoryMock {
+	return &RepositoryMock{}
+}
+
+func (r *RepositoryMock) GetDebts(ctx context.Context, ledgerId uuid.UUID) ([]*models.Debt, error) {
+	args := r.Called(ctx, ledgerId)
+
+	err := args.Error(1)
+	debts := args.Get(0)
+	if err == nil && debts == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return debts.([]*models.Debt), nil
+	}
+}
+
+func (r *RepositoryMock) GetSettlements(ctx context.Context, ledgerId uuid.UUID) ([]*models.Settlement, error) {
+	args := r.Called(ctx, ledgerId)
+
+	err := args.Error(1)
+	settlements := args.Get(0)
+	if err == nil && settlements == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return settlements.([]*models.Settlement), nil
+	}
+}
+
+func (r *RepositoryMock) AddSettlement(ctx context.Context, ledgerId uuid.UUID, settlement *models.Settlement) (*models.Settlement, error) {
+	args := r.Called(ctx, ledgerId, settlement)
+
+	err := args.Error(1)
+	addedSettlement := args.Get(0)
+	if err == nil && addedSettlement == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return addedSettlement.(*models.Settlement), nil
+	}
+}
+
+func (r *RepositoryMock) MockGetDebts(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetDebts", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetSettlements(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetSettlements", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockAddSettlement(callArguments, returnArguments []interface{}, times int) {
+	r.On("AddSettlement", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func anyContext(callArguments []interface{}) []interface{} {
+	return append([]interface{}{mock.Anything}, callArguments...)
+}
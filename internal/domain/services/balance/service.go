@@ -0,0 +1,130 @@
+package balance
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/ledger"
+	"github.com/google/uuid"
+)
+
+const (
+	invalidMemberErrorMsg = "both users of a settlement must be members of the ledger"
+)
+
+type Repository interface {
+	// GetDebts returns what every participant of the split expenses of the ledger owes their payer.
+	GetDebts(ctx context.Context, ledgerId uuid.UUID) ([]*models.Debt, error)
+	GetSettlements(ctx context.Context, ledgerId uuid.UUID) ([]*models.Settlement, error)
+	AddSettlement(ctx context.Context, ledgerId uuid.UUID, settlement *models.Settlement) (*models.Settlement, error)
+}
+
+// Service tells who owes whom in a ledger, from its split expenses and the repayments recorded between its members.
+type Service interface {
+	GetBalances(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID) (*Balances, error)
+	Settle(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *SettleCommand) (*models.Settlement, error)
+}
+
+type service struct {
+	repository    Repository
+	ledgerService ledger.Service
+}
+
+func NewService(repository Repository, ledgerService ledger.Service) *service {
+	return &service{repository: repository, ledgerService: ledgerService}
+}
+
+// GetBalances also suggests the fewest transfers that even out the balances.
+func (s service) GetBalances(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID) (*Balances, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.ViewerLedgerRole); err != nil {
+		return nil, err
+	}
+
+	debts, err := s.repository.GetDebts(ctx, ledgerId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	settlements, err := s.repository.GetSettlements(ctx, ledgerId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	balances, err := models.CalculateBalances(debts, settlements)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	transfers, err := models.SimplifyDebts(balances)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return &Balances{Balances: balances, Transfers: transfers}, nil
+}
+
+func (s service) Settle(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *SettleCommand) (*models.Settlement, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.EditorLedgerRole); err != nil {
+		return nil, err
+	}
+
+	members, err := s.ledgerService.GetMembers(ctx, userId, ledgerId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	isMember := map[uuid.UUID]bool{}
+	for _, member := range members {
+		isMember[member.UserId()] = true
+	}
+
+	if !isMember[command.from] || !isMember[command.to] {
+		return nil, InvalidMemberError{Msg: invalidMemberErrorMsg}
+	}
+
+	amount, err := models.NewMoney(command.amount, command.currency)
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	settlement, err := models.NewSettlement(command.from, command.to, amount, command.settledAt)
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	addedSettlement, err := s.repository.AddSettlement(ctx, ledgerId, settlement)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return addedSettlement, nil
+}
+
+// Balances has the net amount every member is owed, or owes when negative, and the transfers that settle them up.
+type Balances struct {
+	Balances  []*models.Balance
+	Transfers []*models.Debt
+}
+
+type UnexpectedError struct {
+	Msg string
+}
+
+func (receiver UnexpectedError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidDomainModelError struct {
+	Msg string
+}
+
+func (receiver InvalidDomainModelError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidMemberError struct {
+	Msg string
+}
+
+func (receiver InvalidMemberError) Error() string {
+	return receiver.Msg
+}
@@ -0,0 +1,52 @@
+package balance
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type ServiceMock struct {
+	mock.Mock
+}
+
+func NewServiceMock() *ServiceMock {
+	return &ServiceMock{}
+}
+
+func (s *ServiceMock) GetBalances(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID) (*Balances, error) {
+	args := s.Called(ctx, userId, ledgerId)
+
+	err := args.Error(1)
+	balances := args.Get(0)
+	if err == nil && balances == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return balances.(*Balances), nil
+	}
+}
+
+func (s *ServiceMock) Settle(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *SettleCommand) (*models.Settlement, error) {
+	args := s.Called(ctx, userId, ledgerId, command)
+
+	err := args.Error(1)
+	settlement := args.Get(0)
+	if err == nil && settlement == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return settlement.(*models.Settlement), nil
+	}
+}
+
+func (s *ServiceMock) MockGetBalances(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetBalances", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockSettle(callArguments, returnArguments []interface{}, times int) {
+	s.On("Settle", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
@@ -0,0 +1,135 @@
+package balance_test
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/balance"
+	"finfit-backend/internal/domain/services/ledger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type ServiceTestSuite struct {
+	suite.Suite
+	userId            uuid.UUID
+	partnerId         uuid.UUID
+	ledgerId          uuid.UUID
+	repositoryMock    *balance.RepositoryMock
+	ledgerServiceMock *ledger.ServiceMock
+	service           balance.Service
+}
+
+func (suite *ServiceTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.partnerId = uuid.New()
+	suite.ledgerId = uuid.New()
+	suite.repositoryMock = balance.NewRepositoryMock()
+	suite.ledgerServiceMock = ledger.NewServiceMock()
+	suite.service = balance.NewService(suite.repositoryMock, suite.ledgerServiceMock)
+}
+
+func (suite *ServiceTestSuite) SetupTest() {
+	suite.ledgerServiceMock.MockAuthorize([]interface{}{suite.userId, suite.ledgerId, mock.Anything}, []interface{}{nil}, 0)
+}
+
+func (suite *ServiceTestSuite) TearDownTest() {
+	suite.repositoryMock.ExpectedCalls = nil
+	suite.repositoryMock.Calls = nil
+	suite.ledgerServiceMock.ExpectedCalls = nil
+	suite.ledgerServiceMock.Calls = nil
+}
+
+func TestServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ServiceTestSuite))
+}
+
+func (suite *ServiceTestSuite) TestGivenDebtsAndSettlements_WhenGetBalances_ThenReturnWhatIsLeftAndHowToSettleIt() {
+	debt, _ := models.NewDebt(suite.partnerId, suite.userId, suite.money("30"))
+	settlement, _ := models.NewSettlement(suite.partnerId, suite.userId, suite.money("10"), time.Now())
+	suite.repositoryMock.MockGetDebts([]interface{}{suite.ledgerId}, []interface{}{[]*models.Debt{debt}, nil}, 1)
+	suite.repositoryMock.MockGetSettlements([]interface{}{suite.ledgerId}, []interface{}{[]*models.Settlement{settlement}, nil}, 1)
+
+	balances, err := suite.service.GetBalances(context.Background(), suite.userId, suite.ledgerId)
+
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), balances.Balances, 2)
+	require.Len(suite.T(), balances.Transfers, 1)
+	assert.Equal(suite.T(), suite.partnerId, balances.Transfers[0].From())
+	assert.Equal(suite.T(), suite.userId, balances.Transfers[0].To())
+	assert.Equal(suite.T(), "20.00", balances.Transfers[0].Amount().Amount())
+}
+
+func (suite *ServiceTestSuite) TestGivenThatRepositoryFails_WhenGetBalances_ThenReturnUnexpectedError() {
+	suite.repositoryMock.MockGetDebts([]interface{}{suite.ledgerId}, []interface{}{nil, errors.New("fail")}, 1)
+
+	balances, err := suite.service.GetBalances(context.Background(), suite.userId, suite.ledgerId)
+
+	assert.ErrorAs(suite.T(), err, &balance.UnexpectedError{})
+	assert.Nil(suite.T(), balances)
+}
+
+func (suite *ServiceTestSuite) TestGivenARepaymentBetweenMembers_WhenSettle_ThenStoreIt() {
+	suite.mockMembers(suite.userId, suite.partnerId)
+	isRepayment := mock.MatchedBy(func(settlement *models.Settlement) bool {
+		return settlement.From() == suite.partnerId && settlement.To() == suite.userId && settlement.Amount().Amount() == "20.00"
+	})
+	storedSettlement, _ := models.NewSettlement(suite.partnerId, suite.userId, suite.money("20"), time.Now())
+	suite.repositoryMock.MockAddSettlement([]interface{}{suite.ledgerId, isRepayment}, []interface{}{storedSettlement, nil}, 1)
+	command, _ := balance.NewSettleCommand(suite.partnerId, suite.userId, "20", "EUR", time.Now())
+
+	settlement, err := suite.service.Settle(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), storedSettlement, settlement)
+	suite.repositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestGivenAUserOutsideTheLedger_WhenSettle_ThenReturnInvalidMemberError() {
+	suite.mockMembers(suite.userId)
+	command, _ := balance.NewSettleCommand(suite.partnerId, suite.userId, "20", "EUR", time.Now())
+
+	settlement, err := suite.service.Settle(context.Background(), suite.userId, suite.ledgerId, command)
+
+	assert.ErrorAs(suite.T(), err, &balance.InvalidMemberError{})
+	assert.Nil(suite.T(), settlement)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "AddSettlement", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestGivenAViewer_WhenSettle_ThenReturnForbiddenError() {
+	suite.ledgerServiceMock.ExpectedCalls = nil
+	forbiddenError := ledger.ForbiddenError{Msg: "your role in the ledger doesn't allow it"}
+	suite.ledgerServiceMock.MockAuthorize([]interface{}{suite.userId, suite.ledgerId, models.EditorLedgerRole}, []interface{}{forbiddenError}, 1)
+	command, _ := balance.NewSettleCommand(suite.partnerId, suite.userId, "20", "EUR", time.Now())
+
+	settlement, err := suite.service.Settle(context.Background(), suite.userId, suite.ledgerId, command)
+
+	assert.Equal(suite.T(), forbiddenError, err)
+	assert.Nil(suite.T(), settlement)
+}
+
+func (suite *ServiceTestSuite) TestGivenTheSameUser_WhenNewSettleCommand_ThenReturnError() {
+	command, err := balance.NewSettleCommand(suite.userId, suite.userId, "20", "EUR", time.Now())
+
+	assert.EqualError(suite.T(), err, "invalid command")
+	assert.Nil(suite.T(), command)
+}
+
+func (suite *ServiceTestSuite) mockMembers(userIds ...uuid.UUID) {
+	members := []*models.LedgerMember{}
+	for _, userId := range userIds {
+		member, _ := models.NewLedgerMember(suite.ledgerId, userId, models.EditorLedgerRole)
+		members = append(members, member)
+	}
+	suite.ledgerServiceMock.MockGetMembers([]interface{}{suite.userId, suite.ledgerId}, []interface{}{members, nil}, 1)
+}
+
+func (suite *ServiceTestSuite) money(amount string) *models.Money {
+	money, _ := models.NewMoney(amount, "EUR")
+	return money
+}
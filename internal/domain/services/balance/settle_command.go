@@ -0,0 +1,30 @@
+package balance
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+type SettleCommand struct {
+	from      uuid.UUID
+	to        uuid.UUID
+	amount    string
+	currency  string
+	settledAt time.Time
+}
+
+// NewSettleCommand builds the command to record that from paid amount back to to.
+func NewSettleCommand(from uuid.UUID, to uuid.UUID, amount string, currency string, settledAt time.Time) (*SettleCommand, error) {
+	if from == uuid.Nil || to == uuid.Nil || from == to || settledAt.IsZero() {
+		return nil, errors.New("invalid command")
+	}
+
+	money, err := models.NewMoney(amount, currency)
+	if err != nil || !money.IsPositive() {
+		return nil, errors.New("invalid command")
+	}
+
+	return &SettleCommand{from: from, to: to, amount: amount, currency: currency, settledAt: settledAt}, nil
+}
@@ -0,0 +1,27 @@
+package budget
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type AddCommand struct {
+	expenseTypeId uuid.UUID
+	period        string
+	limit         string
+	currency      string
+	rollover      bool
+}
+
+func NewAddCommand(expenseTypeId uuid.UUID, period string, limit string, currency string, rollover bool) (*AddCommand, error) {
+	if expenseTypeId == uuid.Nil || !models.IsValidBudgetPeriod(period) || !isPositiveAmount(limit, currency) {
+		return nil, errors.New("invalid command")
+	}
+	return &AddCommand{expenseTypeId: expenseTypeId, period: period, limit: limit, currency: currency, rollover: rollover}, nil
+}
+
+func isPositiveAmount(amount string, currency string) bool {
+	money, err := models.NewMoney(amount, currency)
+	return err == nil && money.IsPositive()
+}
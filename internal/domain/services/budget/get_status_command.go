@@ -0,0 +1,22 @@
+package budget
+
+import (
+	"errors"
+	"time"
+)
+
+type GetStatusCommand struct {
+	month time.Time
+}
+
+// NewGetStatusCommand builds the command to get the status of the budgets in the month of the given date.
+func NewGetStatusCommand(month time.Time) (*GetStatusCommand, error) {
+	if month.IsZero() {
+		return nil, errors.New("invalid command")
+	}
+	return &GetStatusCommand{month: time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)}, nil
+}
+
+func (g GetStatusCommand) Month() time.Time {
+	return g.month
+}
@@ -0,0 +1,119 @@
+package budget
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func NewRepositoryMock() *RepositoryMock {
+	return &RepositoryMock{}
+}
+
+func (r *RepositoryMock) Add(ctx context.Context, userId uuid.UUID, budget *models.Budget) (*models.Budget, error) {
+	args := r.Called(ctx, userId, budget)
+
+	err := args.Error(1)
+	budgetToReturn := args.Get(0)
+	if err == nil && budgetToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return budgetToReturn.(*models.Budget), nil
+	}
+}
+
+func (r *RepositoryMock) GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Budget, error) {
+	args := r.Called(ctx, userId, id)
+
+	err := args.Error(1)
+	budgetToReturn := args.Get(0)
+	if err == nil && budgetToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return budgetToReturn.(*models.Budget), nil
+	}
+}
+
+func (r *RepositoryMock) GetByExpenseTypeAndPeriod(ctx context.Context, userId uuid.UUID, expenseTypeId uuid.UUID, period models.BudgetPeriod) (*models.Budget, error) {
+	args := r.Called(ctx, userId, expenseTypeId, period)
+
+	err := args.Error(1)
+	budgetToReturn := args.Get(0)
+	if err == nil && budgetToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return budgetToReturn.(*models.Budget), nil
+	}
+}
+
+func (r *RepositoryMock) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.Budget, error) {
+	args := r.Called(ctx, userId)
+
+	err := args.Error(1)
+	budgets := args.Get(0)
+	if err == nil && budgets == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return budgets.([]*models.Budget), nil
+	}
+}
+
+func (r *RepositoryMock) Update(ctx context.Context, userId uuid.UUID, budget *models.Budget) (*models.Budget, error) {
+	args := r.Called(ctx, userId, budget)
+
+	err := args.Error(1)
+	budgetToReturn := args.Get(0)
+	if err == nil && budgetToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return budgetToReturn.(*models.Budget), nil
+	}
+}
+
+func (r *RepositoryMock) Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	args := r.Called(ctx, userId, id)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
+	r.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetByID(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetByID", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetByExpenseTypeAndPeriod(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetByExpenseTypeAndPeriod", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetAll(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockUpdate(callArguments, returnArguments []interface{}, times int) {
+	r.On("Update", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockDelete(callArguments, returnArguments []interface{}, times int) {
+	r.On("Delete", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func anyContext(callArguments []interface{}) []interface{} {
+	return append([]interface{}{mock.Anything}, callArguments...)
+}
@@ -0,0 +1,306 @@
+package budget
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/expense"
+	"finfit-backend/internal/domain/services/expensetype"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	budgetNotFoundErrorMsg      = "the budget doesn't exists"
+	budgetAlreadyExistsErrorMsg = "a budget for the same expense type and period already exists"
+	invalidExpenseTypeErrorMsg  = "the expense type doesn't exists"
+)
+
+type Repository interface {
+	Add(ctx context.Context, userId uuid.UUID, budget *models.Budget) (*models.Budget, error)
+	GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Budget, error)
+	GetByExpenseTypeAndPeriod(ctx context.Context, userId uuid.UUID, expenseTypeId uuid.UUID, period models.BudgetPeriod) (*models.Budget, error)
+	GetAll(ctx context.Context, userId uuid.UUID) ([]*models.Budget, error)
+	Update(ctx context.Context, userId uuid.UUID, budget *models.Budget) (*models.Budget, error)
+	Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error
+}
+
+// Service manages the budgets of a user, which limit the expense types of their personal ledger.
+type Service interface {
+	Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.Budget, error)
+	GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Budget, error)
+	GetAll(ctx context.Context, userId uuid.UUID) ([]*models.Budget, error)
+	Update(ctx context.Context, userId uuid.UUID, command *UpdateCommand) (*models.Budget, error)
+	Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error
+	GetStatus(ctx context.Context, userId uuid.UUID, command *GetStatusCommand) ([]*models.BudgetStatus, error)
+}
+
+type service struct {
+	repository         Repository
+	expenseTypeService expensetype.Service
+	expenseService     expense.Service
+}
+
+func NewService(repository Repository, expenseTypeService expensetype.Service, expenseService expense.Service) *service {
+	return &service{repository: repository, expenseTypeService: expenseTypeService, expenseService: expenseService}
+}
+
+func (s service) Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.Budget, error) {
+	expenseType, err := s.getExpenseType(ctx, userId, command.expenseTypeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.checkIfBudgetDoesNotExist(ctx, userId, uuid.Nil, command.expenseTypeId, models.BudgetPeriod(command.period)); err != nil {
+		return nil, err
+	}
+
+	limit, err := models.NewMoney(command.limit, command.currency)
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	budgetToAdd, err := models.NewBudget(expenseType, models.BudgetPeriod(command.period), limit, command.rollover)
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	addedBudget, err := s.repository.Add(ctx, userId, budgetToAdd)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return addedBudget, nil
+}
+
+func (s service) GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Budget, error) {
+	storedBudget, err := s.repository.GetByID(ctx, userId, id)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return storedBudget, nil
+}
+
+func (s service) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.Budget, error) {
+	budgets, err := s.repository.GetAll(ctx, userId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return budgets, nil
+}
+
+func (s service) Update(ctx context.Context, userId uuid.UUID, command *UpdateCommand) (*models.Budget, error) {
+	storedBudget, err := s.repository.GetByID(ctx, userId, command.id)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	if storedBudget == nil {
+		return nil, BudgetNotFoundError{Msg: budgetNotFoundErrorMsg}
+	}
+
+	expenseType, err := s.getExpenseType(ctx, userId, command.expenseTypeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.checkIfBudgetDoesNotExist(ctx, userId, command.id, command.expenseTypeId, models.BudgetPeriod(command.period)); err != nil {
+		return nil, err
+	}
+
+	limit, err := models.NewMoney(command.limit, command.currency)
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	budgetToUpdate, err := models.NewBudgetWithId(command.id, expenseType, models.BudgetPeriod(command.period), limit, command.rollover)
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	updatedBudget, err := s.repository.Update(ctx, userId, budgetToUpdate)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return updatedBudget, nil
+}
+
+func (s service) Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	storedBudget, err := s.repository.GetByID(ctx, userId, id)
+	if err != nil {
+		return UnexpectedError{Msg: err.Error()}
+	}
+
+	if storedBudget == nil {
+		return BudgetNotFoundError{Msg: budgetNotFoundErrorMsg}
+	}
+
+	if err = s.repository.Delete(ctx, userId, id); err != nil {
+		return UnexpectedError{Msg: err.Error()}
+	}
+
+	return nil
+}
+
+// GetStatus reports how much of each budget was used in the requested month. Only the expenses in the currency of
+// the budget limit count towards it. The amount rolled over is what was left unspent in the previous month.
+func (s service) GetStatus(ctx context.Context, userId uuid.UUID, command *GetStatusCommand) ([]*models.BudgetStatus, error) {
+	budgets, err := s.repository.GetAll(ctx, userId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	statuses := []*models.BudgetStatus{}
+	if len(budgets) == 0 {
+		return statuses, nil
+	}
+
+	previousMonth := command.month.AddDate(0, -1, 0)
+	expenses, err := s.searchExpenses(ctx, userId, previousMonth, command.month.AddDate(0, 1, -1))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, storedBudget := range budgets {
+		status, err := s.getBudgetStatus(storedBudget, expenses, command.month, previousMonth)
+		if err != nil {
+			return nil, UnexpectedError{Msg: err.Error()}
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (s service) getBudgetStatus(budget *models.Budget, expenses []*models.Expense, month time.Time, previousMonth time.Time) (*models.BudgetStatus, error) {
+	spent, err := sumSpent(budget, expenses, month)
+	if err != nil {
+		return nil, err
+	}
+
+	rolledOver, _ := models.NewMoneyFromMinorUnits(0, budget.Limit().Currency())
+	if budget.Rollover() {
+		previousSpent, err := sumSpent(budget, expenses, previousMonth)
+		if err != nil {
+			return nil, err
+		}
+
+		unspent, err := budget.Limit().Subtract(previousSpent)
+		if err != nil {
+			return nil, err
+		}
+
+		if unspent.IsPositive() {
+			rolledOver = unspent
+		}
+	}
+
+	return models.NewBudgetStatus(budget, spent, rolledOver)
+}
+
+func (s service) searchExpenses(ctx context.Context, userId uuid.UUID, startDate time.Time, endDate time.Time) ([]*models.Expense, error) {
+	command, err := expense.NewSearchInPeriodCommand(startDate, endDate)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	result, err := s.expenseService.SearchInPeriod(ctx, userId, models.PersonalLedgerId(userId), command)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return result.Expenses, nil
+}
+
+func (s service) getExpenseType(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.ExpenseType, error) {
+	expenseType, err := s.expenseTypeService.GetById(ctx, userId, models.PersonalLedgerId(userId), id)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	if expenseType == nil {
+		return nil, InvalidExpenseTypeError{Msg: invalidExpenseTypeErrorMsg}
+	}
+
+	return expenseType, nil
+}
+
+// checkIfBudgetDoesNotExist fails when another budget, other than the one with the given id, already limits the same
+// expense type in the same period.
+func (s service) checkIfBudgetDoesNotExist(ctx context.Context, userId uuid.UUID, id uuid.UUID, expenseTypeId uuid.UUID, period models.BudgetPeriod) error {
+	storedBudget, err := s.repository.GetByExpenseTypeAndPeriod(ctx, userId, expenseTypeId, period)
+	if err != nil {
+		return UnexpectedError{Msg: err.Error()}
+	}
+
+	if storedBudget != nil && storedBudget.Id() != id {
+		return BudgetAlreadyExistsError{Msg: budgetAlreadyExistsErrorMsg}
+	}
+
+	return nil
+}
+
+// sumSpent adds up the expenses of the period in the currency of the budget whose type is the one of the budget or any
+// of its subtypes.
+func sumSpent(budget *models.Budget, expenses []*models.Expense, month time.Time) (*models.Money, error) {
+	startDate, endDate := budget.PeriodBounds(month)
+	spent, _ := models.NewMoneyFromMinorUnits(0, budget.Limit().Currency())
+
+	for _, storedExpense := range expenses {
+		if !storedExpense.ExpenseType().IsInSubtreeOf(budget.ExpenseType().Id()) ||
+			storedExpense.Amount().Currency() != spent.Currency() ||
+			storedExpense.ExpenseDate().Before(startDate) ||
+			storedExpense.ExpenseDate().After(endDate) {
+			continue
+		}
+
+		var err error
+		if spent, err = spent.Add(storedExpense.Amount()); err != nil {
+			return nil, err
+		}
+	}
+
+	return spent, nil
+}
+
+type UnexpectedError struct {
+	Msg string
+}
+
+func (receiver UnexpectedError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidDomainModelError struct {
+	Msg string
+}
+
+func (receiver InvalidDomainModelError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidExpenseTypeError struct {
+	Msg string
+}
+
+func (receiver InvalidExpenseTypeError) Error() string {
+	return receiver.Msg
+}
+
+type BudgetNotFoundError struct {
+	Msg string
+}
+
+func (receiver BudgetNotFoundError) Error() string {
+	return receiver.Msg
+}
+
+type BudgetAlreadyExistsError struct {
+	Msg string
+}
+
+func (receiver BudgetAlreadyExistsError) Error() string {
+	return receiver.Msg
+}
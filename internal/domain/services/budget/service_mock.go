@@ -0,0 +1,115 @@
+package budget
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type ServiceMock struct {
+	mock.Mock
+}
+
+func NewServiceMock() *ServiceMock {
+	return &ServiceMock{}
+}
+
+func (s *ServiceMock) Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.Budget, error) {
+	args := s.Called(ctx, userId, command)
+
+	err := args.Error(1)
+	budgetToReturn := args.Get(0)
+	if err == nil && budgetToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return budgetToReturn.(*models.Budget), nil
+	}
+}
+
+func (s *ServiceMock) GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Budget, error) {
+	args := s.Called(ctx, userId, id)
+
+	err := args.Error(1)
+	budgetToReturn := args.Get(0)
+	if err == nil && budgetToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return budgetToReturn.(*models.Budget), nil
+	}
+}
+
+func (s *ServiceMock) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.Budget, error) {
+	args := s.Called(ctx, userId)
+
+	err := args.Error(1)
+	budgets := args.Get(0)
+	if err == nil && budgets == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return budgets.([]*models.Budget), nil
+	}
+}
+
+func (s *ServiceMock) Update(ctx context.Context, userId uuid.UUID, command *UpdateCommand) (*models.Budget, error) {
+	args := s.Called(ctx, userId, command)
+
+	err := args.Error(1)
+	budgetToReturn := args.Get(0)
+	if err == nil && budgetToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return budgetToReturn.(*models.Budget), nil
+	}
+}
+
+func (s *ServiceMock) Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	args := s.Called(ctx, userId, id)
+	return args.Error(0)
+}
+
+func (s *ServiceMock) GetStatus(ctx context.Context, userId uuid.UUID, command *GetStatusCommand) ([]*models.BudgetStatus, error) {
+	args := s.Called(ctx, userId, command)
+
+	err := args.Error(1)
+	statuses := args.Get(0)
+	if err == nil && statuses == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return statuses.([]*models.BudgetStatus), nil
+	}
+}
+
+func (s *ServiceMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
+	s.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGetByID(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetById", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGetAll(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockUpdate(callArguments, returnArguments []interface{}, times int) {
+	s.On("Update", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockDelete(callArguments, returnArguments []interface{}, times int) {
+	s.On("Delete", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGetStatus(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetStatus", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
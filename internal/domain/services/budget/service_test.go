@@ -0,0 +1,237 @@
+package budget_test
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/budget"
+	"finfit-backend/internal/domain/services/expense"
+	"finfit-backend/internal/domain/services/expensetype"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type BudgetServiceTestSuite struct {
+	suite.Suite
+	userId                 uuid.UUID
+	repositoryMock         *budget.RepositoryMock
+	expenseTypeServiceMock *expensetype.ServiceMock
+	expenseServiceMock     *expense.ServiceMock
+	service                budget.Service
+}
+
+func (suite *BudgetServiceTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.repositoryMock = budget.NewRepositoryMock()
+	suite.expenseTypeServiceMock = expensetype.NewServiceMock()
+	suite.expenseServiceMock = expense.NewServiceMock()
+	suite.service = budget.NewService(suite.repositoryMock, suite.expenseTypeServiceMock, suite.expenseServiceMock)
+	id := uuid.New()
+	pkg.NewUUID = func() uuid.UUID {
+		return id
+	}
+}
+
+func (suite *BudgetServiceTestSuite) TearDownSuite() {
+	pkg.NewUUID = uuid.New
+}
+
+func (suite *BudgetServiceTestSuite) TearDownTest() {
+	suite.repositoryMock.ExpectedCalls = nil
+	suite.repositoryMock.Calls = nil
+	suite.expenseTypeServiceMock.ExpectedCalls = nil
+	suite.expenseServiceMock.ExpectedCalls = nil
+}
+
+func TestBudgetServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(BudgetServiceTestSuite))
+}
+
+func (suite *BudgetServiceTestSuite) TestGivenABudget_WhenAdd_ThenReturnCreatedBudget() {
+	expectedBudget := suite.getBudget("400", false)
+	suite.expenseTypeServiceMock.MockGetByID([]interface{}{suite.userId, models.PersonalLedgerId(suite.userId), expectedBudget.ExpenseType().Id()}, []interface{}{expectedBudget.ExpenseType(), nil}, 1)
+	suite.repositoryMock.MockGetByExpenseTypeAndPeriod([]interface{}{suite.userId, expectedBudget.ExpenseType().Id(), models.MonthlyBudgetPeriod}, []interface{}{nil, nil}, 1)
+	suite.repositoryMock.MockAdd([]interface{}{suite.userId, expectedBudget}, []interface{}{expectedBudget, nil}, 1)
+
+	command, _ := budget.NewAddCommand(expectedBudget.ExpenseType().Id(), "monthly", "400", "EUR", false)
+	actualBudget, err := suite.service.Add(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedBudget, actualBudget)
+}
+
+func (suite *BudgetServiceTestSuite) TestGivenAnExistingBudgetForTheExpenseType_WhenAdd_ThenReturnAlreadyExistsError() {
+	storedBudget := suite.getBudgetWithId("400", false)
+	suite.expenseTypeServiceMock.MockGetByID([]interface{}{suite.userId, models.PersonalLedgerId(suite.userId), storedBudget.ExpenseType().Id()}, []interface{}{storedBudget.ExpenseType(), nil}, 1)
+	suite.repositoryMock.MockGetByExpenseTypeAndPeriod([]interface{}{suite.userId, storedBudget.ExpenseType().Id(), models.MonthlyBudgetPeriod}, []interface{}{storedBudget, nil}, 1)
+
+	command, _ := budget.NewAddCommand(storedBudget.ExpenseType().Id(), "monthly", "500", "EUR", false)
+	actualBudget, err := suite.service.Add(context.Background(), suite.userId, command)
+
+	assert.Nil(suite.T(), actualBudget)
+	assert.Equal(suite.T(), budget.BudgetAlreadyExistsError{Msg: "a budget for the same expense type and period already exists"}, err)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "Add", mock.Anything, suite.userId, mock.Anything)
+}
+
+func (suite *BudgetServiceTestSuite) TestGivenANonExistentExpenseType_WhenAdd_ThenReturnInvalidExpenseTypeError() {
+	expenseTypeId := uuid.New()
+	suite.expenseTypeServiceMock.MockGetByID([]interface{}{suite.userId, models.PersonalLedgerId(suite.userId), expenseTypeId}, []interface{}{nil, nil}, 1)
+
+	command, _ := budget.NewAddCommand(expenseTypeId, "monthly", "400", "EUR", false)
+	actualBudget, err := suite.service.Add(context.Background(), suite.userId, command)
+
+	assert.Nil(suite.T(), actualBudget)
+	assert.Equal(suite.T(), budget.InvalidExpenseTypeError{Msg: "the expense type doesn't exists"}, err)
+}
+
+func (suite *BudgetServiceTestSuite) TestGivenANonPositiveLimit_WhenNewAddCommand_ThenReturnError() {
+	_, err := budget.NewAddCommand(uuid.New(), "monthly", "0", "EUR", false)
+	require.Error(suite.T(), err)
+
+	_, err = budget.NewAddCommand(uuid.New(), "weekly", "10", "EUR", false)
+	require.Error(suite.T(), err)
+}
+
+func (suite *BudgetServiceTestSuite) TestGivenANonExistentBudget_WhenUpdate_ThenReturnNotFoundError() {
+	id := uuid.New()
+	suite.repositoryMock.MockGetByID([]interface{}{suite.userId, id}, []interface{}{nil, nil}, 1)
+
+	command, _ := budget.NewUpdateCommand(id, uuid.New(), "monthly", "400", "EUR", true)
+	actualBudget, err := suite.service.Update(context.Background(), suite.userId, command)
+
+	assert.Nil(suite.T(), actualBudget)
+	assert.Equal(suite.T(), budget.BudgetNotFoundError{Msg: "the budget doesn't exists"}, err)
+}
+
+func (suite *BudgetServiceTestSuite) TestGivenAStoredBudget_WhenUpdate_ThenReturnUpdatedBudget() {
+	storedBudget := suite.getBudgetWithId("400", false)
+	newLimit, _ := models.NewMoney("450", "EUR")
+	expectedBudget, _ := models.NewBudgetWithId(storedBudget.Id(), storedBudget.ExpenseType(), models.MonthlyBudgetPeriod, newLimit, true)
+	suite.repositoryMock.MockGetByID([]interface{}{suite.userId, storedBudget.Id()}, []interface{}{storedBudget, nil}, 1)
+	suite.expenseTypeServiceMock.MockGetByID([]interface{}{suite.userId, models.PersonalLedgerId(suite.userId), storedBudget.ExpenseType().Id()}, []interface{}{storedBudget.ExpenseType(), nil}, 1)
+	suite.repositoryMock.MockGetByExpenseTypeAndPeriod([]interface{}{suite.userId, storedBudget.ExpenseType().Id(), models.MonthlyBudgetPeriod}, []interface{}{storedBudget, nil}, 1)
+	suite.repositoryMock.MockUpdate([]interface{}{suite.userId, expectedBudget}, []interface{}{expectedBudget, nil}, 1)
+
+	command, _ := budget.NewUpdateCommand(storedBudget.Id(), storedBudget.ExpenseType().Id(), "monthly", "450", "EUR", true)
+	actualBudget, err := suite.service.Update(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedBudget, actualBudget)
+}
+
+func (suite *BudgetServiceTestSuite) TestGivenANonExistentBudget_WhenDelete_ThenReturnNotFoundError() {
+	id := uuid.New()
+	suite.repositoryMock.MockGetByID([]interface{}{suite.userId, id}, []interface{}{nil, nil}, 1)
+
+	err := suite.service.Delete(context.Background(), suite.userId, id)
+
+	assert.Equal(suite.T(), budget.BudgetNotFoundError{Msg: "the budget doesn't exists"}, err)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "Delete", mock.Anything, suite.userId, mock.Anything)
+}
+
+func (suite *BudgetServiceTestSuite) TestGivenExpensesInTheMonth_WhenGetStatus_ThenReturnSpentRemainingAndPercentUsed() {
+	storedBudget := suite.getBudgetWithId("400", false)
+	otherExpenseType, _ := models.NewExpenseTypeWithId(uuid.New(), "Rent")
+	expenses := []*models.Expense{
+		suite.getExpense(storedBudget.ExpenseType(), "100.25", "EUR", time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC)),
+		suite.getExpense(storedBudget.ExpenseType(), "200", "EUR", time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC)),
+		suite.getExpense(storedBudget.ExpenseType(), "50", "USD", time.Date(2022, 3, 10, 0, 0, 0, 0, time.UTC)),
+		suite.getExpense(storedBudget.ExpenseType(), "80", "EUR", time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC)),
+		suite.getExpense(otherExpenseType, "900", "EUR", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	suite.repositoryMock.MockGetAll([]interface{}{suite.userId}, []interface{}{[]*models.Budget{storedBudget}, nil}, 1)
+	searchCommand, _ := expense.NewSearchInPeriodCommand(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC))
+	suite.expenseServiceMock.MockSearchInPeriod([]interface{}{suite.userId, models.PersonalLedgerId(suite.userId), searchCommand}, []interface{}{&expense.SearchResult{Expenses: expenses}, nil}, 1)
+
+	command, _ := budget.NewGetStatusCommand(time.Date(2022, 3, 15, 0, 0, 0, 0, time.UTC))
+	statuses, err := suite.service.GetStatus(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), statuses, 1)
+	assert.Equal(suite.T(), "300.25", statuses[0].Spent().Amount())
+	assert.Equal(suite.T(), "0.00", statuses[0].RolledOver().Amount())
+	assert.Equal(suite.T(), "99.75", statuses[0].Remaining().Amount())
+	assert.Equal(suite.T(), 75.06, statuses[0].PercentUsed())
+	assert.False(suite.T(), statuses[0].IsOverspent())
+}
+
+func (suite *BudgetServiceTestSuite) TestGivenExpensesOfSubtypes_WhenGetStatus_ThenAddThemToTheBudgetOfTheirAncestor() {
+	storedBudget := suite.getBudgetWithId("400", false)
+	produce, _ := models.NewExpenseTypeWithId(uuid.New(), "Produce")
+	produce, _ = produce.WithParent(storedBudget.ExpenseType())
+	expenses := []*models.Expense{
+		suite.getExpense(storedBudget.ExpenseType(), "100", "EUR", time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC)),
+		suite.getExpense(produce, "50", "EUR", time.Date(2022, 3, 5, 0, 0, 0, 0, time.UTC)),
+	}
+	suite.repositoryMock.MockGetAll([]interface{}{suite.userId}, []interface{}{[]*models.Budget{storedBudget}, nil}, 1)
+	searchCommand, _ := expense.NewSearchInPeriodCommand(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC))
+	suite.expenseServiceMock.MockSearchInPeriod([]interface{}{suite.userId, models.PersonalLedgerId(suite.userId), searchCommand}, []interface{}{&expense.SearchResult{Expenses: expenses}, nil}, 1)
+
+	command, _ := budget.NewGetStatusCommand(time.Date(2022, 3, 15, 0, 0, 0, 0, time.UTC))
+	statuses, err := suite.service.GetStatus(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), statuses, 1)
+	assert.Equal(suite.T(), "150.00", statuses[0].Spent().Amount())
+}
+
+func (suite *BudgetServiceTestSuite) TestGivenABudgetWithRollover_WhenGetStatus_ThenAddUnspentAmountOfThePreviousMonth() {
+	storedBudget := suite.getBudgetWithId("400", true)
+	expenses := []*models.Expense{
+		suite.getExpense(storedBudget.ExpenseType(), "300", "EUR", time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC)),
+		suite.getExpense(storedBudget.ExpenseType(), "550", "EUR", time.Date(2022, 3, 10, 0, 0, 0, 0, time.UTC)),
+	}
+	suite.repositoryMock.MockGetAll([]interface{}{suite.userId}, []interface{}{[]*models.Budget{storedBudget}, nil}, 1)
+	searchCommand, _ := expense.NewSearchInPeriodCommand(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC))
+	suite.expenseServiceMock.MockSearchInPeriod([]interface{}{suite.userId, models.PersonalLedgerId(suite.userId), searchCommand}, []interface{}{&expense.SearchResult{Expenses: expenses}, nil}, 1)
+
+	command, _ := budget.NewGetStatusCommand(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	statuses, err := suite.service.GetStatus(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), statuses, 1)
+	assert.Equal(suite.T(), "100.00", statuses[0].RolledOver().Amount())
+	assert.Equal(suite.T(), "500.00", statuses[0].Available().Amount())
+	assert.Equal(suite.T(), "-50.00", statuses[0].Remaining().Amount())
+	assert.Equal(suite.T(), 110.0, statuses[0].PercentUsed())
+	assert.True(suite.T(), statuses[0].IsOverspent())
+}
+
+func (suite *BudgetServiceTestSuite) TestGivenThatFailToSearchExpenses_WhenGetStatus_ThenReturnUnexpectedError() {
+	storedBudget := suite.getBudgetWithId("400", false)
+	suite.repositoryMock.MockGetAll([]interface{}{suite.userId}, []interface{}{[]*models.Budget{storedBudget}, nil}, 1)
+	searchCommand, _ := expense.NewSearchInPeriodCommand(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC))
+	suite.expenseServiceMock.MockSearchInPeriod([]interface{}{suite.userId, models.PersonalLedgerId(suite.userId), searchCommand}, []interface{}{nil, errors.New("fail")}, 1)
+
+	command, _ := budget.NewGetStatusCommand(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	statuses, err := suite.service.GetStatus(context.Background(), suite.userId, command)
+
+	assert.Nil(suite.T(), statuses)
+	assert.Equal(suite.T(), budget.UnexpectedError{Msg: "fail"}, err)
+}
+
+func (suite *BudgetServiceTestSuite) getBudget(limit string, rollover bool) *models.Budget {
+	expenseType, _ := models.NewExpenseTypeWithId(uuid.New(), "Groceries")
+	money, _ := models.NewMoney(limit, "EUR")
+	newBudget, _ := models.NewBudget(expenseType, models.MonthlyBudgetPeriod, money, rollover)
+	return newBudget
+}
+
+func (suite *BudgetServiceTestSuite) getBudgetWithId(limit string, rollover bool) *models.Budget {
+	expenseType, _ := models.NewExpenseTypeWithId(uuid.New(), "Groceries")
+	money, _ := models.NewMoney(limit, "EUR")
+	storedBudget, _ := models.NewBudgetWithId(uuid.New(), expenseType, models.MonthlyBudgetPeriod, money, rollover)
+	return storedBudget
+}
+
+func (suite *BudgetServiceTestSuite) getExpense(expenseType *models.ExpenseType, amount string, currency string, date time.Time) *models.Expense {
+	money, _ := models.NewMoney(amount, currency)
+	storedExpense, _ := models.NewExpenseWithId(uuid.New(), money, date, "", expenseType)
+	return storedExpense
+}
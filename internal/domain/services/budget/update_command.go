@@ -0,0 +1,23 @@
+package budget
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type UpdateCommand struct {
+	id            uuid.UUID
+	expenseTypeId uuid.UUID
+	period        string
+	limit         string
+	currency      string
+	rollover      bool
+}
+
+func NewUpdateCommand(id uuid.UUID, expenseTypeId uuid.UUID, period string, limit string, currency string, rollover bool) (*UpdateCommand, error) {
+	if id == uuid.Nil || expenseTypeId == uuid.Nil || !models.IsValidBudgetPeriod(period) || !isPositiveAmount(limit, currency) {
+		return nil, errors.New("invalid command")
+	}
+	return &UpdateCommand{id: id, expenseTypeId: expenseTypeId, period: period, limit: limit, currency: currency, rollover: rollover}, nil
+}
@@ -0,0 +1,29 @@
+package exchangerate
+
+import (
+	"errors"
+	"time"
+)
+
+// RateToImport is one historical daily rate as read from an import file.
+type RateToImport struct {
+	Date          time.Time
+	BaseCurrency  string
+	QuoteCurrency string
+	Rate          string
+}
+
+type ImportCommand struct {
+	rates []RateToImport
+}
+
+func NewImportCommand(rates []RateToImport) (*ImportCommand, error) {
+	if len(rates) == 0 {
+		return nil, errors.New("invalid command")
+	}
+	return &ImportCommand{rates: rates}, nil
+}
+
+func (i ImportCommand) Rates() []RateToImport {
+	return i.rates
+}
@@ -0,0 +1,47 @@
+package exchangerate
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func NewRepositoryMock() *RepositoryMock {
+	return &RepositoryMock{}
+}
+
+func (r *RepositoryMock) GetRate(ctx context.Context, baseCurrency string, quoteCurrency string, date time.Time) (*models.ExchangeRate, error) {
+	args := r.Called(ctx, baseCurrency, quoteCurrency, date)
+
+	err := args.Error(1)
+	rate := args.Get(0)
+	if err == nil && rate == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return rate.(*models.ExchangeRate), nil
+	}
+}
+
+func (r *RepositoryMock) Save(ctx context.Context, rates []*models.ExchangeRate) error {
+	args := r.Called(ctx, rates)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) MockGetRate(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetRate", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockSave(callArguments, returnArguments []interface{}, times int) {
+	r.On("Save", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func anyContext(callArguments []interface{}) []interface{} {
+	return append([]interface{}{mock.Anything}, callArguments...)
+}
@@ -0,0 +1,91 @@
+package exchangerate
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"fmt"
+	"time"
+)
+
+// ExchangeRateProvider is the port used to look up historical rates. GetRate returns the most recent rate between the
+// two currencies, in any direction, published on or before the given date, or nil when there isn't any.
+type ExchangeRateProvider interface {
+	GetRate(ctx context.Context, baseCurrency string, quoteCurrency string, date time.Time) (*models.ExchangeRate, error)
+}
+
+type Repository interface {
+	ExchangeRateProvider
+	Save(ctx context.Context, rates []*models.ExchangeRate) error
+}
+
+type Service interface {
+	Import(ctx context.Context, command *ImportCommand) (int, error)
+	Convert(ctx context.Context, amount *models.Money, targetCurrency string, date time.Time) (*models.Conversion, error)
+}
+
+type service struct {
+	repository Repository
+	provider   ExchangeRateProvider
+}
+
+func NewService(repository Repository, provider ExchangeRateProvider) *service {
+	return &service{repository: repository, provider: provider}
+}
+
+// Import stores the rates of the command, replacing the ones already stored for the same currencies and date, and
+// returns how many were stored.
+func (s service) Import(ctx context.Context, command *ImportCommand) (int, error) {
+	rates := []*models.ExchangeRate{}
+	for i, rateToImport := range command.rates {
+		rate, err := models.NewExchangeRate(rateToImport.BaseCurrency, rateToImport.QuoteCurrency, rateToImport.Date, rateToImport.Rate)
+		if err != nil {
+			return 0, InvalidDomainModelError{Msg: fmt.Sprintf("rate %d: %s", i+1, err.Error())}
+		}
+		rates = append(rates, rate)
+	}
+
+	if err := s.repository.Save(ctx, rates); err != nil {
+		return 0, UnexpectedError{Msg: err.Error()}
+	}
+
+	return len(rates), nil
+}
+
+// Convert converts the amount to the target currency at the rate of the given date. A missing rate isn't an error,
+// the returned conversion is flagged instead so callers can report it.
+func (s service) Convert(ctx context.Context, amount *models.Money, targetCurrency string, date time.Time) (*models.Conversion, error) {
+	if amount.Currency() == targetCurrency {
+		return s.newConversion(amount, targetCurrency, nil)
+	}
+
+	rate, err := s.provider.GetRate(ctx, amount.Currency(), targetCurrency, date)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return s.newConversion(amount, targetCurrency, rate)
+}
+
+func (s service) newConversion(amount *models.Money, targetCurrency string, rate *models.ExchangeRate) (*models.Conversion, error) {
+	conversion, err := models.NewConversion(amount, targetCurrency, rate)
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+	return conversion, nil
+}
+
+type UnexpectedError struct {
+	Msg string
+}
+
+func (receiver UnexpectedError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidDomainModelError struct {
+	Msg string
+}
+
+func (receiver InvalidDomainModelError) Error() string {
+	return receiver.Msg
+}
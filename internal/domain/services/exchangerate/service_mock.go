@@ -0,0 +1,43 @@
+package exchangerate
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type ServiceMock struct {
+	mock.Mock
+}
+
+func NewServiceMock() *ServiceMock {
+	return &ServiceMock{}
+}
+
+func (s *ServiceMock) Import(ctx context.Context, command *ImportCommand) (int, error) {
+	args := s.Called(ctx, command)
+	return args.Int(0), args.Error(1)
+}
+
+func (s *ServiceMock) Convert(ctx context.Context, amount *models.Money, targetCurrency string, date time.Time) (*models.Conversion, error) {
+	args := s.Called(ctx, amount, targetCurrency, date)
+
+	err := args.Error(1)
+	conversion := args.Get(0)
+	if err == nil && conversion == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return conversion.(*models.Conversion), nil
+	}
+}
+
+func (s *ServiceMock) MockImport(callArguments, returnArguments []interface{}, times int) {
+	s.On("Import", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockConvert(callArguments, returnArguments []interface{}, times int) {
+	s.On("Convert", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
@@ -0,0 +1,106 @@
+package exchangerate_test
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/exchangerate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type ExchangeRateServiceTestSuite struct {
+	suite.Suite
+	repositoryMock *exchangerate.RepositoryMock
+	service        exchangerate.Service
+}
+
+func (suite *ExchangeRateServiceTestSuite) SetupSuite() {
+	suite.repositoryMock = exchangerate.NewRepositoryMock()
+	suite.service = exchangerate.NewService(suite.repositoryMock, suite.repositoryMock)
+}
+
+func (suite *ExchangeRateServiceTestSuite) TearDownTest() {
+	suite.repositoryMock.ExpectedCalls = nil
+	suite.repositoryMock.Calls = nil
+}
+
+func TestExchangeRateServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeRateServiceTestSuite))
+}
+
+func (suite *ExchangeRateServiceTestSuite) TestGivenRates_WhenImport_ThenSaveThemAndReturnTheCount() {
+	date := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	usdToArs, _ := models.NewExchangeRate("USD", "ARS", date, "108.5")
+	eurToUsd, _ := models.NewExchangeRate("EUR", "USD", date, "1.11")
+	suite.repositoryMock.MockSave([]interface{}{[]*models.ExchangeRate{usdToArs, eurToUsd}}, []interface{}{nil}, 1)
+
+	command, _ := exchangerate.NewImportCommand([]exchangerate.RateToImport{
+		{Date: date, BaseCurrency: "USD", QuoteCurrency: "ARS", Rate: "108.5"},
+		{Date: date, BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: "1.11"},
+	})
+	imported, err := suite.service.Import(context.Background(), command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, imported)
+}
+
+func (suite *ExchangeRateServiceTestSuite) TestGivenAnInvalidRate_WhenImport_ThenReturnInvalidDomainModelError() {
+	command, _ := exchangerate.NewImportCommand([]exchangerate.RateToImport{
+		{Date: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), BaseCurrency: "USD", QuoteCurrency: "ARS", Rate: "-1"},
+	})
+	imported, err := suite.service.Import(context.Background(), command)
+
+	require.ErrorAs(suite.T(), err, &exchangerate.InvalidDomainModelError{})
+	assert.Equal(suite.T(), 0, imported)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "Save", mock.Anything)
+}
+
+func (suite *ExchangeRateServiceTestSuite) TestGivenAStoredRate_WhenConvert_ThenReturnConvertedAmountWithTheRate() {
+	date := time.Date(2022, 3, 6, 0, 0, 0, 0, time.UTC)
+	rate, _ := models.NewExchangeRate("ARS", "USD", time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC), "0.0092")
+	suite.repositoryMock.MockGetRate([]interface{}{"USD", "ARS", date}, []interface{}{rate, nil}, 1)
+	amount, _ := models.NewMoney("9.20", "USD")
+
+	conversion, err := suite.service.Convert(context.Background(), amount, "ARS", date)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "1000.00", conversion.Amount().Amount())
+	assert.Equal(suite.T(), rate, conversion.Rate())
+}
+
+func (suite *ExchangeRateServiceTestSuite) TestGivenAMissingRate_WhenConvert_ThenReturnConversionFlaggedAsMissing() {
+	date := time.Date(2022, 3, 6, 0, 0, 0, 0, time.UTC)
+	suite.repositoryMock.MockGetRate([]interface{}{"USD", "ARS", date}, []interface{}{nil, nil}, 1)
+	amount, _ := models.NewMoney("10", "USD")
+
+	conversion, err := suite.service.Convert(context.Background(), amount, "ARS", date)
+
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), conversion.IsMissingRate())
+}
+
+func (suite *ExchangeRateServiceTestSuite) TestGivenAnAmountInTheTargetCurrency_WhenConvert_ThenDoNotLookUpAnyRate() {
+	amount, _ := models.NewMoney("10", "ARS")
+
+	conversion, err := suite.service.Convert(context.Background(), amount, "ARS", time.Date(2022, 3, 6, 0, 0, 0, 0, time.UTC))
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), amount, conversion.Amount())
+	suite.repositoryMock.AssertNotCalled(suite.T(), "GetRate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ExchangeRateServiceTestSuite) TestGivenThatProviderFails_WhenConvert_ThenReturnUnexpectedError() {
+	date := time.Date(2022, 3, 6, 0, 0, 0, 0, time.UTC)
+	suite.repositoryMock.MockGetRate([]interface{}{"USD", "ARS", date}, []interface{}{nil, errors.New("fail")}, 1)
+	amount, _ := models.NewMoney("10", "USD")
+
+	conversion, err := suite.service.Convert(context.Background(), amount, "ARS", date)
+
+	require.ErrorAs(suite.T(), err, &exchangerate.UnexpectedError{})
+	require.Nil(suite.T(), conversion)
+}
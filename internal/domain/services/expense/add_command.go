@@ -2,6 +2,7 @@ package expense
 
 import (
 	"errors"
+	"finfit-backend/internal/domain/models"
 	"github.com/google/uuid"
 	"strings"
 	"time"
@@ -47,16 +48,47 @@ var validCurrencyCodes = map[string]bool{
 }
 
 type AddCommand struct {
-	amount        float64
+	amount        string
 	currency      string
 	expenseDate   time.Time
 	description   string
 	expenseTypeId uuid.UUID
+	accountId     uuid.UUID
+	fitId         string
+	split         *SplitCommand
+	tags          []string
 }
 
-func NewAddCommand(amount float64, currency string, expenseDate time.Time, description string, expenseTypeId uuid.UUID) (*AddCommand, error) {
-	if amount <= 0 || expenseDate.IsZero() || expenseTypeId == uuid.Nil || !validCurrencyCodes[currency] {
+// NewAddCommand builds the command to add an expense. accountId is optional, uuid.Nil means that the expense isn't
+// paid from any account.
+func NewAddCommand(amount string, currency string, expenseDate time.Time, description string, expenseTypeId uuid.UUID, accountId uuid.UUID) (*AddCommand, error) {
+	if !isPositiveAmount(amount, currency) || expenseDate.IsZero() || expenseTypeId == uuid.Nil || !validCurrencyCodes[currency] {
 		return nil, errors.New("invalid command")
 	}
-	return &AddCommand{amount: amount, currency: currency, expenseDate: expenseDate, description: strings.TrimSpace(description), expenseTypeId: expenseTypeId}, nil
+	return &AddCommand{amount: amount, currency: currency, expenseDate: expenseDate, description: strings.TrimSpace(description), expenseTypeId: expenseTypeId, accountId: accountId}, nil
+}
+
+// WithFitId returns a copy of the command for an expense read from an OFX statement, keeping the bank transaction id
+// used to recognize it when the statement is imported again.
+func (a AddCommand) WithFitId(fitId string) *AddCommand {
+	a.fitId = strings.TrimSpace(fitId)
+	return &a
+}
+
+// WithSplit returns a copy of the command for an expense shared among members of the ledger.
+func (a AddCommand) WithSplit(split *SplitCommand) *AddCommand {
+	a.split = split
+	return &a
+}
+
+// WithTags returns a copy of the command for an expense labeled with the given tags, which are created in the ledger
+// when they don't exist yet.
+func (a AddCommand) WithTags(tags []string) *AddCommand {
+	a.tags = tags
+	return &a
+}
+
+func isPositiveAmount(amount string, currency string) bool {
+	money, err := models.NewMoney(amount, currency)
+	return err == nil && money.IsPositive()
 }
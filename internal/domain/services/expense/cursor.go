@@ -0,0 +1,98 @@
+package expense
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"strings"
+)
+
+// SearchSort is the order of the searched expenses, by expense date or by amount. A leading minus sorts them in
+// descending order. Expenses with the same date or amount are sorted by id, so the order is stable across pages.
+type SearchSort string
+
+const (
+	ExpenseDateAscSort  SearchSort = "expense_date"
+	ExpenseDateDescSort SearchSort = "-expense_date"
+	AmountAscSort       SearchSort = "amount"
+	AmountDescSort      SearchSort = "-amount"
+)
+
+func IsValidSearchSort(sort string) bool {
+	switch SearchSort(sort) {
+	case ExpenseDateAscSort, ExpenseDateDescSort, AmountAscSort, AmountDescSort:
+		return true
+	}
+	return false
+}
+
+// Field is the expense field the expenses are sorted by.
+func (s SearchSort) Field() string {
+	return strings.TrimPrefix(string(s), "-")
+}
+
+func (s SearchSort) Descending() bool {
+	return strings.HasPrefix(string(s), "-")
+}
+
+// Cursor points at the last expense of a page, so the next page starts right after it. It holds the value of the
+// sort field of that expense, the date or the amount, along with its id.
+type Cursor struct {
+	sort  SearchSort
+	value string
+	id    uuid.UUID
+}
+
+type cursorPayload struct {
+	Sort  SearchSort `json:"s"`
+	Value string     `json:"v"`
+	Id    uuid.UUID  `json:"i"`
+}
+
+func newCursor(sort SearchSort, expense *models.Expense) *Cursor {
+	value := expense.ExpenseDate().Format(dateFormat)
+	if sort.Field() == string(AmountAscSort) {
+		value = expense.Amount().Amount()
+	}
+
+	return &Cursor{sort: sort, value: value, id: expense.Id()}
+}
+
+// ParseCursor decodes a cursor returned by String.
+func ParseCursor(encodedCursor string) (*Cursor, error) {
+	decodedCursor, err := base64.RawURLEncoding.DecodeString(encodedCursor)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	payload := cursorPayload{}
+	if err := json.Unmarshal(decodedCursor, &payload); err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	if !IsValidSearchSort(string(payload.Sort)) || payload.Value == "" || payload.Id == uuid.Nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	return &Cursor{sort: payload.Sort, value: payload.Value, id: payload.Id}, nil
+}
+
+// String encodes the cursor as an opaque string that is safe to use in a query param.
+func (c Cursor) String() string {
+	encodedPayload, _ := json.Marshal(cursorPayload{Sort: c.sort, Value: c.value, Id: c.id})
+	return base64.RawURLEncoding.EncodeToString(encodedPayload)
+}
+
+func (c Cursor) Sort() SearchSort {
+	return c.sort
+}
+
+func (c Cursor) Value() string {
+	return c.value
+}
+
+func (c Cursor) Id() uuid.UUID {
+	return c.id
+}
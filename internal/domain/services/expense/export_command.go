@@ -0,0 +1,18 @@
+package expense
+
+import (
+	"errors"
+	"time"
+)
+
+type ExportCommand struct {
+	startDate time.Time
+	endDate   time.Time
+}
+
+func NewExportCommand(startDate time.Time, endDate time.Time) (*ExportCommand, error) {
+	if startDate.IsZero() || endDate.IsZero() || startDate.After(endDate) {
+		return nil, errors.New("invalid command")
+	}
+	return &ExportCommand{startDate: startDate, endDate: endDate}, nil
+}
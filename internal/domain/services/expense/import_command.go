@@ -0,0 +1,36 @@
+package expense
+
+import (
+	"errors"
+	"github.com/google/uuid"
+)
+
+// ImportRow is one line of a bank statement already split into the mapped columns. An empty ExpenseType means that
+// the default expense type of the import is used.
+type ImportRow struct {
+	Date        string
+	Amount      string
+	Currency    string
+	Description string
+	ExpenseType string
+}
+
+// ImportCommand carries the rows of a statement to import. The dates of every row are parsed with dateLayout, a Go
+// time layout. When dryRun is true the expenses are validated and returned but not stored.
+type ImportCommand struct {
+	rows                 []ImportRow
+	dateLayout           string
+	defaultExpenseTypeId uuid.UUID
+	dryRun               bool
+}
+
+func NewImportCommand(rows []ImportRow, dateLayout string, defaultExpenseTypeId uuid.UUID, dryRun bool) (*ImportCommand, error) {
+	if len(rows) == 0 || dateLayout == "" {
+		return nil, errors.New("invalid command")
+	}
+	return &ImportCommand{rows: rows, dateLayout: dateLayout, defaultExpenseTypeId: defaultExpenseTypeId, dryRun: dryRun}, nil
+}
+
+func (i ImportCommand) DryRun() bool {
+	return i.dryRun
+}
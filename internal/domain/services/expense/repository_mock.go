@@ -1,7 +1,9 @@
 package expense
 
 import (
+	"context"
 	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"time"
 )
@@ -14,8 +16,8 @@ func NewRepositoryMock() *RepositoryMock {
 	return &RepositoryMock{}
 }
 
-func (r *RepositoryMock) Add(expense *models.Expense) (*models.Expense, error) {
-	args := r.Called(expense)
+func (r *RepositoryMock) Add(ctx context.Context, ledgerId uuid.UUID, expense *models.Expense) (*models.Expense, error) {
+	args := r.Called(ctx, ledgerId, expense)
 
 	savedExpense := args.Get(0)
 	err := args.Error(1)
@@ -28,8 +30,41 @@ func (r *RepositoryMock) Add(expense *models.Expense) (*models.Expense, error) {
 	}
 }
 
-func (r *RepositoryMock) SearchInPeriod(startDate time.Time, endDate time.Time) ([]*models.Expense, error) {
-	args := r.Called(startDate, endDate)
+func (r *RepositoryMock) AddAll(ctx context.Context, ledgerId uuid.UUID, expenses []*models.Expense) error {
+	args := r.Called(ctx, ledgerId, expenses)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) GetByFitIds(ctx context.Context, ledgerId uuid.UUID, fitIds []string) ([]*models.Expense, error) {
+	args := r.Called(ctx, ledgerId, fitIds)
+
+	expenses := args.Get(0)
+	err := args.Error(1)
+	if err == nil && expenses == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return expenses.([]*models.Expense), nil
+	}
+}
+
+// ForEachInPeriod hands to consume the expenses given as the first return argument.
+func (r *RepositoryMock) ForEachInPeriod(ctx context.Context, ledgerId uuid.UUID, startDate time.Time, endDate time.Time, consume func(expense *models.Expense) error) error {
+	args := r.Called(ctx, ledgerId, startDate, endDate)
+
+	if expenses, ok := args.Get(0).([]*models.Expense); ok {
+		for _, expense := range expenses {
+			if err := consume(expense); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (r *RepositoryMock) SearchInPeriod(ctx context.Context, ledgerId uuid.UUID, criteria SearchCriteria) ([]*models.Expense, error) {
+	args := r.Called(ctx, ledgerId, criteria)
 
 	expenses := args.Get(0)
 	err := args.Error(1)
@@ -42,10 +77,100 @@ func (r *RepositoryMock) SearchInPeriod(startDate time.Time, endDate time.Time)
 	}
 }
 
+func (r *RepositoryMock) GetByID(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (*models.Expense, error) {
+	args := r.Called(ctx, ledgerId, id)
+
+	storedExpense := args.Get(0)
+	err := args.Error(1)
+	if err == nil && storedExpense == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return args.Get(0).(*models.Expense), nil
+	}
+}
+
+func (r *RepositoryMock) Update(ctx context.Context, ledgerId uuid.UUID, expense *models.Expense) (*models.Expense, error) {
+	args := r.Called(ctx, ledgerId, expense)
+
+	updatedExpense := args.Get(0)
+	err := args.Error(1)
+	if err == nil && updatedExpense == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return args.Get(0).(*models.Expense), nil
+	}
+}
+
+func (r *RepositoryMock) Delete(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) error {
+	args := r.Called(ctx, ledgerId, id)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) MockAddAll(callArguments, returnArguments []interface{}, times int) {
+	r.On("AddAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetByFitIds(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetByFitIds", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockForEachInPeriod(callArguments, returnArguments []interface{}, times int) {
+	r.On("ForEachInPeriod", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
 func (r *RepositoryMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
-	r.On("Add", callArguments...).Return(returnArguments...).Times(times)
+	r.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
 }
 
 func (r *RepositoryMock) MockSearchInPeriod(callArguments, returnArguments []interface{}, times int) {
-	r.On("SearchInPeriod", callArguments...).Return(returnArguments...).Times(times)
+	r.On("SearchInPeriod", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetByID(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetByID", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockUpdate(callArguments, returnArguments []interface{}, times int) {
+	r.On("Update", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockDelete(callArguments, returnArguments []interface{}, times int) {
+	r.On("Delete", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+// FullTextRepositoryMock is a repository with a full-text index.
+type FullTextRepositoryMock struct {
+	RepositoryMock
+}
+
+func NewFullTextRepositoryMock() *FullTextRepositoryMock {
+	return &FullTextRepositoryMock{}
+}
+
+func (r *FullTextRepositoryMock) SearchText(ctx context.Context, ledgerId uuid.UUID, terms []string, limit int) ([]*TextSearchHit, error) {
+	args := r.Called(ctx, ledgerId, terms, limit)
+
+	hits := args.Get(0)
+	err := args.Error(1)
+	if err == nil && hits == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return hits.([]*TextSearchHit), nil
+	}
+}
+
+func (r *FullTextRepositoryMock) MockSearchText(callArguments, returnArguments []interface{}, times int) {
+	r.On("SearchText", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+// anyContext matches the context the mocked methods receive first, so the expectations only list the rest of the
+// arguments.
+func anyContext(callArguments []interface{}) []interface{} {
+	return append([]interface{}{mock.Anything}, callArguments...)
 }
@@ -0,0 +1,33 @@
+package expense
+
+import (
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+// SearchCriteria selects the expenses of a ledger in a period. The zero value of every other field doesn't filter, and
+// a Limit of zero returns every expense after the cursor.
+type SearchCriteria struct {
+	StartDate time.Time
+	EndDate   time.Time
+	// ExpenseTypeIds matches any of the expense types, subtypes must be listed too.
+	ExpenseTypeIds []uuid.UUID
+	TagFilter      *models.TagFilter
+	// MinAmount and MaxAmount are inclusive and compare the amounts whatever their currency.
+	MinAmount string
+	MaxAmount string
+	Currency  string
+	// Description matches the expenses whose description contains it, ignoring case.
+	Description string
+	AccountId   uuid.UUID
+	Sort        SearchSort
+	After       *Cursor
+	Limit       int
+}
+
+// SearchResult is a page of the searched expenses. NextCursor is nil on the last page.
+type SearchResult struct {
+	Expenses   []*models.Expense
+	NextCursor *Cursor
+}
@@ -2,19 +2,151 @@ package expense
 
 import (
 	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"math/big"
+	"regexp"
+	"strings"
 	"time"
 )
 
+const (
+	// DefaultPageSize is the page size of the searches that don't ask for one.
+	DefaultPageSize = 50
+	// MaxPageSize caps the page size, larger ones are lowered to it.
+	MaxPageSize = 200
+)
+
+// amountRegexp matches the amounts of the range, with at most the four decimal places of the currencies that use the
+// most.
+var amountRegexp = regexp.MustCompile(`^[0-9]+(\.[0-9]{1,4})?$`)
+
 type SearchInPeriodCommand struct {
-	startDate time.Time
-	endDate   time.Time
+	startDate      time.Time
+	endDate        time.Time
+	targetCurrency string
+	expenseTypeIds []uuid.UUID
+	tagFilter      *models.TagFilter
+	minAmount      string
+	maxAmount      string
+	currency       string
+	description    string
+	accountId      uuid.UUID
+	sort           SearchSort
+	cursor         *Cursor
+	limit          int
 }
 
+// NewSearchInPeriodCommand searches every expense of the period, from the most recent one. Without WithPage all of
+// them are returned at once.
 func NewSearchInPeriodCommand(startDate time.Time, endDate time.Time) (*SearchInPeriodCommand, error) {
 	if startDate.IsZero() || endDate.IsZero() || startDate.After(endDate) {
 		return nil, errors.New("invalid command")
 	}
-	return &SearchInPeriodCommand{startDate: startDate, endDate: endDate}, nil
+	return &SearchInPeriodCommand{startDate: startDate, endDate: endDate, sort: ExpenseDateDescSort}, nil
+}
+
+// WithTargetCurrency returns a copy of the command that also asks for every expense converted to the given currency.
+func (s SearchInPeriodCommand) WithTargetCurrency(targetCurrency string) (*SearchInPeriodCommand, error) {
+	if targetCurrency != "" && !validCurrencyCodes[targetCurrency] {
+		return nil, errors.New("invalid command")
+	}
+
+	s.targetCurrency = targetCurrency
+	return &s, nil
+}
+
+// WithExpenseTypes returns a copy of the command that only asks for the expenses of any of the given types or of their
+// subtypes.
+func (s SearchInPeriodCommand) WithExpenseTypes(expenseTypeIds []uuid.UUID) *SearchInPeriodCommand {
+	s.expenseTypeIds = expenseTypeIds
+	return &s
+}
+
+// WithTagFilter returns a copy of the command that only asks for the expenses that pass the tag filter.
+func (s SearchInPeriodCommand) WithTagFilter(tagFilter *models.TagFilter) *SearchInPeriodCommand {
+	s.tagFilter = tagFilter
+	return &s
+}
+
+// WithAmountRange returns a copy of the command that only asks for the expenses with an amount between minAmount and
+// maxAmount, both inclusive. Either of them can be empty to leave that end open.
+func (s SearchInPeriodCommand) WithAmountRange(minAmount string, maxAmount string) (*SearchInPeriodCommand, error) {
+	if (minAmount != "" && !amountRegexp.MatchString(minAmount)) || (maxAmount != "" && !amountRegexp.MatchString(maxAmount)) {
+		return nil, errors.New("invalid command")
+	}
+
+	if minAmount != "" && maxAmount != "" {
+		min, _ := new(big.Rat).SetString(minAmount)
+		max, _ := new(big.Rat).SetString(maxAmount)
+		if min.Cmp(max) > 0 {
+			return nil, errors.New("invalid command")
+		}
+	}
+
+	s.minAmount = minAmount
+	s.maxAmount = maxAmount
+	return &s, nil
+}
+
+// WithCurrency returns a copy of the command that only asks for the expenses in the given currency.
+func (s SearchInPeriodCommand) WithCurrency(currency string) (*SearchInPeriodCommand, error) {
+	if currency != "" && !validCurrencyCodes[currency] {
+		return nil, errors.New("invalid command")
+	}
+
+	s.currency = currency
+	return &s, nil
+}
+
+// WithDescription returns a copy of the command that only asks for the expenses whose description contains the text.
+func (s SearchInPeriodCommand) WithDescription(description string) *SearchInPeriodCommand {
+	s.description = strings.TrimSpace(description)
+	return &s
+}
+
+// WithAccount returns a copy of the command that only asks for the expenses paid from the given account.
+func (s SearchInPeriodCommand) WithAccount(accountId uuid.UUID) *SearchInPeriodCommand {
+	s.accountId = accountId
+	return &s
+}
+
+// WithSort returns a copy of the command that sorts the expenses in another order, an empty sort keeps the current one.
+func (s SearchInPeriodCommand) WithSort(sort string) (*SearchInPeriodCommand, error) {
+	if sort == "" {
+		return &s, nil
+	}
+
+	if !IsValidSearchSort(sort) {
+		return nil, errors.New("invalid command")
+	}
+
+	s.sort = SearchSort(sort)
+	return &s, nil
+}
+
+// WithPage returns a copy of the command that asks for a page of at most limit expenses, lowered to MaxPageSize, that
+// starts after the cursor of the previous page. The cursor is empty for the first page.
+func (s SearchInPeriodCommand) WithPage(limit int, cursor string) (*SearchInPeriodCommand, error) {
+	if limit < 1 {
+		return nil, errors.New("invalid command")
+	}
+
+	if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+
+	s.limit = limit
+	s.cursor = nil
+	if cursor != "" {
+		parsedCursor, err := ParseCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		s.cursor = parsedCursor
+	}
+
+	return &s, nil
 }
 
 func (s SearchInPeriodCommand) StartDate() time.Time {
@@ -24,3 +156,24 @@ func (s SearchInPeriodCommand) StartDate() time.Time {
 func (s SearchInPeriodCommand) EndDate() time.Time {
 	return s.endDate
 }
+
+func (s SearchInPeriodCommand) TargetCurrency() string {
+	return s.targetCurrency
+}
+
+func (s SearchInPeriodCommand) ExpenseTypeIds() []uuid.UUID {
+	return s.expenseTypeIds
+}
+
+func (s SearchInPeriodCommand) TagFilter() *models.TagFilter {
+	return s.tagFilter
+}
+
+func (s SearchInPeriodCommand) Sort() SearchSort {
+	return s.sort
+}
+
+// Limit is zero when the command asks for every expense at once.
+func (s SearchInPeriodCommand) Limit() int {
+	return s.limit
+}
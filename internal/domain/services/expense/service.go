@@ -1,70 +1,756 @@
 package expense
 
 import (
+	"context"
+	"errors"
 	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/account"
+	"finfit-backend/internal/domain/services/exchangerate"
 	"finfit-backend/internal/domain/services/expensetype"
+	"finfit-backend/internal/domain/services/ledger"
+	"finfit-backend/internal/domain/services/unitofwork"
+	"github.com/google/uuid"
+	"strings"
 	"time"
 )
 
-const invalidExpenseTypeErrorMsg = "the expense type doesn't exists"
+const (
+	invalidExpenseTypeErrorMsg = "the expense type doesn't exists"
+	invalidAccountErrorMsg     = "the account doesn't exists"
+	expenseNotFoundErrorMsg    = "the expense doesn't exists"
+	invalidImportRowsErrorMsg  = "some rows are invalid, nothing was imported"
+	invalidSplitErrorMsg       = "the payer and the participants of a split must be members of the ledger"
+	invalidCursorErrorMsg      = "the cursor belongs to a search sorted in another order"
+	invalidSearchSortErrorMsg  = "the expenses can only be sorted by amount within a currency, filter them by one"
+)
+
+const (
+	dateFormat = "2006-01-02"
+	// maxImportedDescriptionLength is the length of the description column. It fits the memos of the OFX statements,
+	// which have at most 255 characters, so only longer CSV or QIF descriptions are truncated.
+	maxImportedDescriptionLength = 255
+)
 
 type Repository interface {
-	Add(entity *models.Expense) (*models.Expense, error)
-	SearchInPeriod(startDate time.Time, endDate time.Time) ([]*models.Expense, error)
+	Add(ctx context.Context, ledgerId uuid.UUID, entity *models.Expense) (*models.Expense, error)
+	AddAll(ctx context.Context, ledgerId uuid.UUID, expenses []*models.Expense) error
+	GetByFitIds(ctx context.Context, ledgerId uuid.UUID, fitIds []string) ([]*models.Expense, error)
+	ForEachInPeriod(ctx context.Context, ledgerId uuid.UUID, startDate time.Time, endDate time.Time, consume func(expense *models.Expense) error) error
+	// SearchInPeriod returns the expenses that meet the criteria in its sort order.
+	SearchInPeriod(ctx context.Context, ledgerId uuid.UUID, criteria SearchCriteria) ([]*models.Expense, error)
+	GetByID(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (*models.Expense, error)
+	Update(ctx context.Context, ledgerId uuid.UUID, entity *models.Expense) (*models.Expense, error)
+	Delete(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) error
+}
+
+// Repositories are the ones Add and Update read the expense type from and write the expense to, bound to the same
+// transaction by the unit of work of the service.
+type Repositories struct {
+	Expenses     Repository
+	ExpenseTypes expensetype.Repository
 }
 
+// Service works on the expenses of a ledger. Every method checks the role of the user in the ledger first and returns
+// the errors of the ledger service when it isn't enough. Accounts stay personal, an expense can only be paid from an
+// account of the user who records it.
 type Service interface {
-	Add(command *AddCommand) (*models.Expense, error)
-	SearchInPeriod(command *SearchInPeriodCommand) ([]*models.Expense, error)
+	Add(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *AddCommand) (*models.Expense, error)
+	SearchInPeriod(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *SearchInPeriodCommand) (*SearchResult, error)
+	SearchText(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *TextSearchCommand) ([]*TextSearchHit, error)
+	GetById(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, id uuid.UUID) (*models.Expense, error)
+	Update(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *UpdateCommand) (*models.Expense, error)
+	Delete(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, id uuid.UUID) error
+	Import(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *ImportCommand) ([]*models.Expense, error)
+	ImportStatement(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, commands []*AddCommand) (*StatementImportSummary, error)
+	Export(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *ExportCommand, consume func(expense *models.Expense) error) error
 }
 
 type service struct {
-	repository         Repository
-	expenseTypeService expensetype.Service
+	repository          Repository
+	unitOfWork          unitofwork.UnitOfWork[Repositories]
+	expenseTypeService  expensetype.Service
+	accountService      account.Service
+	exchangeRateService exchangerate.Service
+	ledgerService       ledger.Service
 }
 
-func NewService(expenseRepository Repository, expenseTypeService expensetype.Service) *service {
-	return &service{repository: expenseRepository, expenseTypeService: expenseTypeService}
+func NewService(expenseRepository Repository, unitOfWork unitofwork.UnitOfWork[Repositories], expenseTypeService expensetype.Service, accountService account.Service, exchangeRateService exchangerate.Service, ledgerService ledger.Service) *service {
+	return &service{repository: expenseRepository, unitOfWork: unitOfWork, expenseTypeService: expenseTypeService, accountService: accountService, exchangeRateService: exchangeRateService, ledgerService: ledgerService}
 }
 
-func (s service) Add(command *AddCommand) (*models.Expense, error) {
-	expenseType, expenseTypeServiceError := s.checkIfExpenseTypeExists(command)
+func (s service) Add(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *AddCommand) (*models.Expense, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.EditorLedgerRole); err != nil {
+		return nil, err
+	}
+
+	var createdExpense *models.Expense
+	err := s.unitOfWork.Do(ctx, func(repositories Repositories) error {
+		expenseType, err := repositories.ExpenseTypes.GetByID(ctx, ledgerId, command.expenseTypeId)
+		if err != nil {
+			return UnexpectedError{Msg: err.Error()}
+		}
+
+		if expenseType == nil {
+			return InvalidExpenseTypeError{Msg: invalidExpenseTypeErrorMsg}
+		}
 
-	if expenseTypeServiceError != nil {
-		return nil, UnexpectedError{Msg: expenseTypeServiceError.Error()}
+		expenseAccount, err := s.getAccount(ctx, userId, command.accountId)
+		if err != nil {
+			return err
+		}
+
+		expenseToCreate, err := s.mapAddCommandToExpense(command, expenseType, expenseAccount)
+		if err != nil {
+			return InvalidDomainModelError{Msg: err.Error()}
+		}
+
+		if command.split != nil {
+			if expenseToCreate, err = s.splitExpense(ctx, userId, ledgerId, expenseToCreate, command.split); err != nil {
+				return err
+			}
+		}
+
+		if createdExpense, err = repositories.Expenses.Add(ctx, ledgerId, expenseToCreate); err != nil {
+			return UnexpectedError{Msg: err.Error()}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if expenseType == nil {
-		return nil, InvalidExpenseTypeError{Msg: invalidExpenseTypeErrorMsg}
+	return createdExpense, nil
+}
+
+func (s service) checkIfExpenseTypeExists(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *AddCommand) (*models.ExpenseType, error) {
+	return s.expenseTypeService.GetById(ctx, userId, ledgerId, command.expenseTypeId)
+}
+
+// splitExpense shares the expense among the users of the command, who must be members of the ledger, and so must be
+// the payer.
+func (s service) splitExpense(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, expenseToSplit *models.Expense, command *SplitCommand) (*models.Expense, error) {
+	members, err := s.ledgerService.GetMembers(ctx, userId, ledgerId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	isMember := map[uuid.UUID]bool{}
+	for _, member := range members {
+		isMember[member.UserId()] = true
+	}
+
+	if !isMember[command.paidBy] {
+		return nil, InvalidSplitError{Msg: invalidSplitErrorMsg}
 	}
 
-	expenseToCreate, err := s.mapAddCommandToExpense(command, expenseType)
+	participants := []*models.SplitParticipant{}
+	for _, share := range command.shares {
+		if !isMember[share.UserId] {
+			return nil, InvalidSplitError{Msg: invalidSplitErrorMsg}
+		}
+
+		participant, err := models.NewSplitParticipant(share.UserId, share.Value)
+		if err != nil {
+			return nil, InvalidDomainModelError{Msg: err.Error()}
+		}
+		participants = append(participants, participant)
+	}
+
+	split, err := models.NewExpenseSplit(expenseToSplit.Amount(), command.paidBy, command.method, participants)
 	if err != nil {
 		return nil, InvalidDomainModelError{Msg: err.Error()}
 	}
 
-	createdExpense, repoError := s.repository.Add(expenseToCreate)
+	splitExpense, err := expenseToSplit.WithSplit(split)
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	return splitExpense, nil
+}
 
-	if repoError != nil {
-		return nil, UnexpectedError{Msg: repoError.Error()}
+// getAccount returns nil without error when no account is requested.
+func (s service) getAccount(ctx context.Context, userId uuid.UUID, accountId uuid.UUID) (*models.Account, error) {
+	if accountId == uuid.Nil {
+		return nil, nil
 	}
 
-	return createdExpense, nil
+	expenseAccount, err := s.accountService.GetById(ctx, userId, accountId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	if expenseAccount == nil {
+		return nil, InvalidAccountError{Msg: invalidAccountErrorMsg}
+	}
+
+	return expenseAccount, nil
+}
+
+// SearchInPeriod asks the repository for one expense more than the page size, which tells whether there's a next page
+// without counting the expenses.
+func (s service) SearchInPeriod(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *SearchInPeriodCommand) (*SearchResult, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.ViewerLedgerRole); err != nil {
+		return nil, err
+	}
+
+	if command.cursor != nil && command.cursor.sort != command.sort {
+		return nil, InvalidCursorError{Msg: invalidCursorErrorMsg}
+	}
+
+	// the amounts are compared in minor units, which only means something between amounts of the same currency
+	if command.sort.Field() == string(AmountAscSort) && command.currency == "" {
+		return nil, InvalidSearchSortError{Msg: invalidSearchSortErrorMsg}
+	}
+
+	expenseTypeIds, err := s.getExpenseTypesSubtreeIds(ctx, userId, ledgerId, command.expenseTypeIds)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria := SearchCriteria{
+		StartDate:      command.startDate,
+		EndDate:        command.endDate,
+		ExpenseTypeIds: expenseTypeIds,
+		TagFilter:      command.tagFilter,
+		MinAmount:      command.minAmount,
+		MaxAmount:      command.maxAmount,
+		Currency:       command.currency,
+		Description:    command.description,
+		AccountId:      command.accountId,
+		Sort:           command.sort,
+		After:          command.cursor,
+	}
+	if command.limit > 0 {
+		criteria.Limit = command.limit + 1
+	}
+
+	expenses, err := s.repository.SearchInPeriod(ctx, ledgerId, criteria)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	result := &SearchResult{Expenses: expenses}
+	if command.limit > 0 && len(expenses) > command.limit {
+		result.Expenses = expenses[:command.limit]
+		result.NextCursor = newCursor(command.sort, result.Expenses[command.limit-1])
+	}
+
+	if command.targetCurrency == "" {
+		return result, nil
+	}
+
+	result.Expenses, err = s.convertExpenses(ctx, result.Expenses, command.targetCurrency)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// getExpenseTypesSubtreeIds returns the ids of the expense types and all their subtypes, so searching for an expense
+// type rolls up the expenses of its whole subtree. It returns nil when there's no expense type to search for.
+func (s service) getExpenseTypesSubtreeIds(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, expenseTypeIds []uuid.UUID) ([]uuid.UUID, error) {
+	var subtreeIds []uuid.UUID
+	for _, expenseTypeId := range expenseTypeIds {
+		subtree, err := s.expenseTypeService.GetSubtree(ctx, userId, ledgerId, expenseTypeId)
+		if errors.As(err, &expensetype.ExpenseTypeNotFoundError{}) {
+			return nil, InvalidExpenseTypeError{Msg: invalidExpenseTypeErrorMsg}
+		}
+
+		if err != nil {
+			return nil, UnexpectedError{Msg: err.Error()}
+		}
+
+		for _, expenseType := range subtree {
+			subtreeIds = append(subtreeIds, expenseType.Id())
+		}
+	}
+	return subtreeIds, nil
+}
+
+// convertExpenses converts every expense at the rate of its own date. Expenses without a rate are kept and flagged.
+func (s service) convertExpenses(ctx context.Context, expenses []*models.Expense, targetCurrency string) ([]*models.Expense, error) {
+	convertedExpenses := []*models.Expense{}
+	for _, storedExpense := range expenses {
+		conversion, err := s.exchangeRateService.Convert(ctx, storedExpense.Amount(), targetCurrency, storedExpense.ExpenseDate())
+		if err != nil {
+			return nil, UnexpectedError{Msg: err.Error()}
+		}
+		convertedExpenses = append(convertedExpenses, storedExpense.WithConversion(conversion))
+	}
+
+	return convertedExpenses, nil
+}
+
+// Export hands the expenses of the period to consume one by one, ordered by date, without loading all of them at
+// once. An error returned by consume stops the export.
+func (s service) Export(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *ExportCommand, consume func(expense *models.Expense) error) error {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.ViewerLedgerRole); err != nil {
+		return err
+	}
+
+	if err := s.repository.ForEachInPeriod(ctx, ledgerId, command.startDate, command.endDate, consume); err != nil {
+		return UnexpectedError{Msg: err.Error()}
+	}
+	return nil
 }
 
-func (s service) checkIfExpenseTypeExists(command *AddCommand) (*models.ExpenseType, error) {
-	return s.expenseTypeService.GetById(command.expenseTypeId)
+// SearchText finds the expenses by the words of their description or of the name of their type, through the full-text
+// index of the repository when it has one.
+func (s service) SearchText(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *TextSearchCommand) ([]*TextSearchHit, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.ViewerLedgerRole); err != nil {
+		return nil, err
+	}
+
+	var hits []*TextSearchHit
+	var err error
+	if searcher, ok := s.repository.(FullTextSearcher); ok {
+		hits, err = searcher.SearchText(ctx, ledgerId, command.terms, command.limit)
+	} else {
+		hits, err = scanText(ctx, s.repository, ledgerId, command.terms, command.limit)
+	}
+
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+	return hits, nil
 }
 
-func (s service) SearchInPeriod(command *SearchInPeriodCommand) ([]*models.Expense, error) {
-	expenses, err := s.repository.SearchInPeriod(command.startDate, command.endDate)
+func (s service) GetById(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, id uuid.UUID) (*models.Expense, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.ViewerLedgerRole); err != nil {
+		return nil, err
+	}
+
+	storedExpense, err := s.repository.GetByID(ctx, ledgerId, id)
 	if err != nil {
 		return nil, UnexpectedError{Msg: err.Error()}
 	}
+
+	return storedExpense, nil
+}
+
+func (s service) Update(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *UpdateCommand) (*models.Expense, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.EditorLedgerRole); err != nil {
+		return nil, err
+	}
+
+	var updatedExpense *models.Expense
+	err := s.unitOfWork.Do(ctx, func(repositories Repositories) error {
+		storedExpense, err := repositories.Expenses.GetByID(ctx, ledgerId, command.id)
+		if err != nil {
+			return UnexpectedError{Msg: err.Error()}
+		}
+
+		if storedExpense == nil {
+			return ExpenseNotFoundError{Msg: expenseNotFoundErrorMsg}
+		}
+
+		expenseTypeId := storedExpense.ExpenseType().Id()
+		if command.expenseTypeId != uuid.Nil {
+			expenseTypeId = command.expenseTypeId
+		}
+
+		expenseType, err := repositories.ExpenseTypes.GetByID(ctx, ledgerId, expenseTypeId)
+		if err != nil {
+			return UnexpectedError{Msg: err.Error()}
+		}
+
+		if expenseType == nil {
+			return InvalidExpenseTypeError{Msg: invalidExpenseTypeErrorMsg}
+		}
+
+		expenseAccount := storedExpense.Account()
+		if command.accountId != uuid.Nil {
+			expenseAccount, err = s.getAccount(ctx, userId, command.accountId)
+			if err != nil {
+				return err
+			}
+		}
+
+		expenseToUpdate, err := s.mapUpdateCommandToExpense(command, storedExpense, expenseType, expenseAccount)
+		if err != nil {
+			return InvalidDomainModelError{Msg: err.Error()}
+		}
+
+		if command.split != nil {
+			if expenseToUpdate, err = s.splitExpense(ctx, userId, ledgerId, expenseToUpdate, command.split); err != nil {
+				return err
+			}
+		}
+
+		if updatedExpense, err = repositories.Expenses.Update(ctx, ledgerId, expenseToUpdate); err != nil {
+			return UnexpectedError{Msg: err.Error()}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedExpense, nil
+}
+
+func (s service) Delete(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, id uuid.UUID) error {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.EditorLedgerRole); err != nil {
+		return err
+	}
+
+	storedExpense, err := s.repository.GetByID(ctx, ledgerId, id)
+	if err != nil {
+		return UnexpectedError{Msg: err.Error()}
+	}
+
+	if storedExpense == nil {
+		return ExpenseNotFoundError{Msg: expenseNotFoundErrorMsg}
+	}
+
+	if err = s.repository.Delete(ctx, ledgerId, id); err != nil {
+		return UnexpectedError{Msg: err.Error()}
+	}
+
+	return nil
+}
+
+// Import validates every row through the same command and domain model used to add a single expense. Rows are only
+// stored, all together, when every one of them is valid; otherwise an InvalidImportRowsError lists what's wrong.
+func (s service) Import(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *ImportCommand) ([]*models.Expense, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.EditorLedgerRole); err != nil {
+		return nil, err
+	}
+
+	expenseTypes, err := s.getExpenseTypesByName(ctx, userId, ledgerId)
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultExpenseType *models.ExpenseType
+	if command.defaultExpenseTypeId != uuid.Nil {
+		if defaultExpenseType, err = s.expenseTypeService.GetById(ctx, userId, ledgerId, command.defaultExpenseTypeId); err != nil {
+			return nil, UnexpectedError{Msg: err.Error()}
+		}
+
+		if defaultExpenseType == nil {
+			return nil, InvalidExpenseTypeError{Msg: invalidExpenseTypeErrorMsg}
+		}
+	}
+
+	expenses := []*models.Expense{}
+	rowErrors := []ImportRowError{}
+	for i, row := range command.rows {
+		expenseToImport, rowError := s.mapImportRowToExpense(row, command.dateLayout, expenseTypes, defaultExpenseType)
+		if rowError != nil {
+			rowError.Row = i
+			rowErrors = append(rowErrors, *rowError)
+			continue
+		}
+		expenses = append(expenses, expenseToImport)
+	}
+
+	if len(rowErrors) > 0 {
+		return nil, InvalidImportRowsError{Msg: invalidImportRowsErrorMsg, RowErrors: rowErrors}
+	}
+
+	if command.dryRun {
+		return expenses, nil
+	}
+
+	err = s.unitOfWork.Do(ctx, func(repositories Repositories) error {
+		if err := repositories.Expenses.AddAll(ctx, ledgerId, expenses); err != nil {
+			return UnexpectedError{Msg: err.Error()}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return expenses, nil
 }
 
-func (s service) mapAddCommandToExpense(command *AddCommand, expenseType *models.ExpenseType) (*models.Expense, error) {
+func (s service) getExpenseTypesByName(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID) (map[string]*models.ExpenseType, error) {
+	storedExpenseTypes, err := s.expenseTypeService.GetAll(ctx, userId, ledgerId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	// names are only unique among siblings, so a row can also name the expense type by its full path. A bare name
+	// matches the first expense type with it, in the order of their paths.
+	expenseTypes := map[string]*models.ExpenseType{}
+	for _, expenseType := range storedExpenseTypes {
+		expenseTypes[strings.ToLower(expenseType.FullPath())] = expenseType
+		if _, ok := expenseTypes[strings.ToLower(expenseType.Name())]; !ok {
+			expenseTypes[strings.ToLower(expenseType.Name())] = expenseType
+		}
+	}
+	return expenseTypes, nil
+}
+
+func (s service) mapImportRowToExpense(row ImportRow, dateLayout string, expenseTypes map[string]*models.ExpenseType, defaultExpenseType *models.ExpenseType) (*models.Expense, *ImportRowError) {
+	expenseDate, err := time.Parse(dateLayout, strings.TrimSpace(row.Date))
+	if err != nil {
+		return nil, &ImportRowError{Field: "Date", Msg: "the date doesn't match the format " + dateLayout}
+	}
+
+	if !validCurrencyCodes[row.Currency] {
+		return nil, &ImportRowError{Field: "Currency", Msg: "the currency must be a valid ISO 4217 currency code"}
+	}
+
+	if !isPositiveAmount(row.Amount, row.Currency) {
+		return nil, &ImportRowError{Field: "Amount", Msg: "the amount must be a decimal number greater than 0 with the decimal places of its currency"}
+	}
+
+	expenseType := defaultExpenseType
+	if strings.TrimSpace(row.ExpenseType) != "" {
+		expenseType = expenseTypes[strings.ToLower(strings.TrimSpace(row.ExpenseType))]
+	}
+
+	if expenseType == nil {
+		return nil, &ImportRowError{Field: "ExpenseType", Msg: "the expense type doesn't exists and there isn't a default one"}
+	}
+
+	addCommand, err := NewAddCommand(row.Amount, row.Currency, expenseDate, truncateImportedDescription(row.Description), expenseType.Id(), uuid.Nil)
+	if err != nil {
+		return nil, &ImportRowError{Msg: err.Error()}
+	}
+
+	expenseToImport, err := s.mapAddCommandToExpense(addCommand, expenseType, nil)
+	if err != nil {
+		return nil, &ImportRowError{Msg: err.Error()}
+	}
+
+	return expenseToImport, nil
+}
+
+// ImportStatement adds the expenses read from a bank statement that aren't stored yet. A record is the same as a
+// stored expense when both have the same FITID or, for records without one, the same fingerprint. Records whose FITID
+// is stored with a different date or amount are reported as conflicting and left for the user to review.
+func (s service) ImportStatement(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, commands []*AddCommand) (*StatementImportSummary, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.EditorLedgerRole); err != nil {
+		return nil, err
+	}
+
+	records, err := s.mapStatementCommandsToExpenses(ctx, userId, ledgerId, commands)
+	if err != nil {
+		return nil, err
+	}
+
+	var summary *StatementImportSummary
+	err = s.unitOfWork.Do(ctx, func(repositories Repositories) error {
+		storedByFitId, storedByFingerprint, err := s.getStoredMatches(ctx, repositories.Expenses, ledgerId, records)
+		if err != nil {
+			return err
+		}
+
+		summary = classifyStatementRecords(records, storedByFitId, storedByFingerprint)
+		if len(summary.Created) == 0 {
+			return nil
+		}
+
+		if err := repositories.Expenses.AddAll(ctx, ledgerId, summary.Created); err != nil {
+			return UnexpectedError{Msg: err.Error()}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// classifyStatementRecords sorts the records into the ones to create, the ones already stored and the conflicting ones.
+func classifyStatementRecords(records []*models.Expense, storedByFitId map[string]*models.Expense, storedByFingerprint map[string][]*models.Expense) *StatementImportSummary {
+	summary := &StatementImportSummary{Created: []*models.Expense{}, Skipped: []StatementRecord{}, Conflicting: []StatementRecord{}}
+	importedFitIds := map[string]bool{}
+	for _, record := range records {
+		if record.FitId() == "" {
+			matches := storedByFingerprint[record.Fingerprint()]
+			if len(matches) == 0 {
+				summary.Created = append(summary.Created, record)
+				continue
+			}
+			// every stored expense matches a single record, so repeated transactions in a statement are kept
+			storedByFingerprint[record.Fingerprint()] = matches[1:]
+			summary.Skipped = append(summary.Skipped, StatementRecord{Expense: record, ExistingExpense: matches[0]})
+			continue
+		}
+
+		storedExpense, isStored := storedByFitId[record.FitId()]
+		switch {
+		case importedFitIds[record.FitId()]:
+			summary.Skipped = append(summary.Skipped, StatementRecord{Expense: record})
+		case !isStored:
+			importedFitIds[record.FitId()] = true
+			summary.Created = append(summary.Created, record)
+		case isSameTransaction(record, storedExpense):
+			summary.Skipped = append(summary.Skipped, StatementRecord{Expense: record, ExistingExpense: storedExpense})
+		default:
+			summary.Conflicting = append(summary.Conflicting, StatementRecord{Expense: record, ExistingExpense: storedExpense})
+		}
+	}
+
+	return summary
+}
+
+func (s service) mapStatementCommandsToExpenses(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, commands []*AddCommand) ([]*models.Expense, error) {
+	expenseTypes := map[uuid.UUID]*models.ExpenseType{}
+	accounts := map[uuid.UUID]*models.Account{}
+	records := []*models.Expense{}
+	for _, command := range commands {
+		expenseType, isLoaded := expenseTypes[command.expenseTypeId]
+		if !isLoaded {
+			storedExpenseType, err := s.checkIfExpenseTypeExists(ctx, userId, ledgerId, command)
+			if err != nil {
+				return nil, UnexpectedError{Msg: err.Error()}
+			}
+
+			if storedExpenseType == nil {
+				return nil, InvalidExpenseTypeError{Msg: invalidExpenseTypeErrorMsg}
+			}
+			expenseType = storedExpenseType
+			expenseTypes[command.expenseTypeId] = expenseType
+		}
+
+		expenseAccount, isLoaded := accounts[command.accountId]
+		if !isLoaded {
+			storedAccount, err := s.getAccount(ctx, userId, command.accountId)
+			if err != nil {
+				return nil, err
+			}
+			expenseAccount = storedAccount
+			accounts[command.accountId] = expenseAccount
+		}
+
+		recordCommand := *command
+		recordCommand.description = truncateImportedDescription(command.description)
+		record, err := s.mapAddCommandToExpense(&recordCommand, expenseType, expenseAccount)
+		if err != nil {
+			return nil, InvalidDomainModelError{Msg: err.Error()}
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// getStoredMatches returns the stored expenses that may be the same as the records: the ones with their FITIDs and,
+// grouped by fingerprint, the ones in the period of the records without FITID.
+func (s service) getStoredMatches(ctx context.Context, repository Repository, ledgerId uuid.UUID, records []*models.Expense) (map[string]*models.Expense, map[string][]*models.Expense, error) {
+	fitIds := []string{}
+	var startDate, endDate time.Time
+	for _, record := range records {
+		if record.FitId() != "" {
+			fitIds = append(fitIds, record.FitId())
+			continue
+		}
+
+		if startDate.IsZero() || record.ExpenseDate().Before(startDate) {
+			startDate = record.ExpenseDate()
+		}
+		if endDate.IsZero() || record.ExpenseDate().After(endDate) {
+			endDate = record.ExpenseDate()
+		}
+	}
+
+	storedByFitId := map[string]*models.Expense{}
+	if len(fitIds) > 0 {
+		storedExpenses, err := repository.GetByFitIds(ctx, ledgerId, fitIds)
+		if err != nil {
+			return nil, nil, UnexpectedError{Msg: err.Error()}
+		}
+
+		for _, storedExpense := range storedExpenses {
+			storedByFitId[storedExpense.FitId()] = storedExpense
+		}
+	}
+
+	storedByFingerprint := map[string][]*models.Expense{}
+	if !startDate.IsZero() {
+		storedExpenses, err := repository.SearchInPeriod(ctx, ledgerId, SearchCriteria{StartDate: startDate, EndDate: endDate})
+		if err != nil {
+			return nil, nil, UnexpectedError{Msg: err.Error()}
+		}
+
+		for _, storedExpense := range storedExpenses {
+			storedByFingerprint[storedExpense.Fingerprint()] = append(storedByFingerprint[storedExpense.Fingerprint()], storedExpense)
+		}
+	}
+
+	return storedByFitId, storedByFingerprint, nil
+}
+
+// isSameTransaction ignores the description, which the user may have edited after the first import.
+func isSameTransaction(record *models.Expense, storedExpense *models.Expense) bool {
+	sameAmount, err := record.Amount().Compare(storedExpense.Amount())
+	return err == nil && sameAmount == 0 && record.ExpenseDate().Format(dateFormat) == storedExpense.ExpenseDate().Format(dateFormat)
+}
+
+func truncateImportedDescription(description string) string {
+	truncatedDescription := []rune(strings.TrimSpace(description))
+	if len(truncatedDescription) > maxImportedDescriptionLength {
+		truncatedDescription = truncatedDescription[:maxImportedDescriptionLength]
+	}
+	return string(truncatedDescription)
+}
+
+func (s service) mapUpdateCommandToExpense(command *UpdateCommand, storedExpense *models.Expense, expenseType *models.ExpenseType, expenseAccount *models.Account) (*models.Expense, error) {
+	amount := storedExpense.Amount().Amount()
+	if command.amount != "" {
+		amount = command.amount
+	}
+
+	currency := storedExpense.Amount().Currency()
+	if command.currency != "" {
+		currency = command.currency
+	}
+
+	expenseDate := storedExpense.ExpenseDate()
+	if !command.expenseDate.IsZero() {
+		expenseDate = command.expenseDate
+	}
+
+	description := storedExpense.Description()
+	if command.description != nil {
+		description = *command.description
+	}
+
+	money, err := models.NewMoney(amount, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedExpense, err := models.NewExpenseWithId(storedExpense.Id(), money, expenseDate, description, expenseType)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedExpense, err = updatedExpense.WithAccount(expenseAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	if storedExpense.Split() != nil {
+		split, err := storedExpense.Split().Reallocate(money)
+		if err != nil {
+			return nil, err
+		}
+
+		if updatedExpense, err = updatedExpense.WithSplit(split); err != nil {
+			return nil, err
+		}
+	}
+
+	tags := storedExpense.Tags()
+	if command.tags != nil {
+		tags = command.tags
+	}
+
+	return updatedExpense.WithFitId(storedExpense.FitId()).WithTags(tags)
+}
+
+func (s service) mapAddCommandToExpense(command *AddCommand, expenseType *models.ExpenseType, expenseAccount *models.Account) (*models.Expense, error) {
 	money, err := models.NewMoney(command.amount, command.currency)
 	if err != nil {
 		return nil, err
@@ -74,7 +760,12 @@ func (s service) mapAddCommandToExpense(command *AddCommand, expenseType *models
 		return nil, err
 	}
 
-	return expenseToCreate, err
+	expenseWithAccount, err := expenseToCreate.WithAccount(expenseAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	return expenseWithAccount.WithFitId(command.fitId).WithTags(command.tags)
 }
 
 type UnexpectedError struct {
@@ -93,6 +784,30 @@ func (receiver InvalidExpenseTypeError) Error() string {
 	return receiver.Msg
 }
 
+type InvalidCursorError struct {
+	Msg string
+}
+
+func (receiver InvalidCursorError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidSearchSortError struct {
+	Msg string
+}
+
+func (receiver InvalidSearchSortError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidAccountError struct {
+	Msg string
+}
+
+func (receiver InvalidAccountError) Error() string {
+	return receiver.Msg
+}
+
 type InvalidDomainModelError struct {
 	Msg string
 }
@@ -100,3 +815,51 @@ type InvalidDomainModelError struct {
 func (receiver InvalidDomainModelError) Error() string {
 	return receiver.Msg
 }
+
+type ExpenseNotFoundError struct {
+	Msg string
+}
+
+func (receiver ExpenseNotFoundError) Error() string {
+	return receiver.Msg
+}
+
+// ImportRowError describes why a row of an import is invalid. Row is the zero based position of the row and Field is
+// empty when the error isn't about a single column.
+type ImportRowError struct {
+	Row   int
+	Field string
+	Msg   string
+}
+
+// StatementImportSummary tells what happened with every record of an imported statement. Only the created expenses
+// were stored.
+type StatementImportSummary struct {
+	Created     []*models.Expense
+	Skipped     []StatementRecord
+	Conflicting []StatementRecord
+}
+
+// StatementRecord is an expense read from a statement together with the stored expense it matched. ExistingExpense is
+// nil when the record was a repetition of another one of the same statement.
+type StatementRecord struct {
+	Expense         *models.Expense
+	ExistingExpense *models.Expense
+}
+
+type InvalidImportRowsError struct {
+	Msg       string
+	RowErrors []ImportRowError
+}
+
+func (receiver InvalidImportRowsError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidSplitError struct {
+	Msg string
+}
+
+func (receiver InvalidSplitError) Error() string {
+	return receiver.Msg
+}
@@ -1,7 +1,9 @@
 package expense
 
 import (
+	"context"
 	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -13,8 +15,8 @@ func NewServiceMock() *ServiceMock {
 	return &ServiceMock{}
 }
 
-func (s *ServiceMock) Add(command *AddCommand) (*models.Expense, error) {
-	args := s.Called(command)
+func (s *ServiceMock) Add(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *AddCommand) (*models.Expense, error) {
+	args := s.Called(ctx, userId, ledgerId, command)
 
 	err := args.Error(1)
 	expenseToReturn := args.Get(0)
@@ -27,8 +29,69 @@ func (s *ServiceMock) Add(command *AddCommand) (*models.Expense, error) {
 	}
 }
 
-func (s *ServiceMock) SearchInPeriod(command *SearchInPeriodCommand) ([]*models.Expense, error) {
-	args := s.Called(command)
+func (s *ServiceMock) SearchInPeriod(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *SearchInPeriodCommand) (*SearchResult, error) {
+	args := s.Called(ctx, userId, ledgerId, command)
+
+	err := args.Error(1)
+	result := args.Get(0)
+	if err == nil && result == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return result.(*SearchResult), nil
+	}
+}
+
+func (s *ServiceMock) SearchText(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *TextSearchCommand) ([]*TextSearchHit, error) {
+	args := s.Called(ctx, userId, ledgerId, command)
+
+	err := args.Error(1)
+	hits := args.Get(0)
+	if err == nil && hits == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return hits.([]*TextSearchHit), nil
+	}
+}
+
+func (s *ServiceMock) GetById(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, id uuid.UUID) (*models.Expense, error) {
+	args := s.Called(ctx, userId, ledgerId, id)
+
+	err := args.Error(1)
+	expenseToReturn := args.Get(0)
+	if err == nil && expenseToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return expenseToReturn.(*models.Expense), nil
+	}
+}
+
+func (s *ServiceMock) Update(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *UpdateCommand) (*models.Expense, error) {
+	args := s.Called(ctx, userId, ledgerId, command)
+
+	err := args.Error(1)
+	expenseToReturn := args.Get(0)
+	if err == nil && expenseToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return expenseToReturn.(*models.Expense), nil
+	}
+}
+
+func (s *ServiceMock) Delete(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, id uuid.UUID) error {
+	args := s.Called(ctx, userId, ledgerId, id)
+	return args.Error(0)
+}
+
+func (s *ServiceMock) Import(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *ImportCommand) ([]*models.Expense, error) {
+	args := s.Called(ctx, userId, ledgerId, command)
 
 	err := args.Error(1)
 	expenses := args.Get(0)
@@ -41,10 +104,66 @@ func (s *ServiceMock) SearchInPeriod(command *SearchInPeriodCommand) ([]*models.
 	}
 }
 
+func (s *ServiceMock) ImportStatement(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, commands []*AddCommand) (*StatementImportSummary, error) {
+	args := s.Called(ctx, userId, ledgerId, commands)
+
+	err := args.Error(1)
+	summary := args.Get(0)
+	if err == nil && summary == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return summary.(*StatementImportSummary), nil
+	}
+}
+
+// Export hands to consume the expenses given as the first return argument.
+func (s *ServiceMock) Export(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *ExportCommand, consume func(expense *models.Expense) error) error {
+	args := s.Called(ctx, userId, ledgerId, command)
+
+	if expenses, ok := args.Get(0).([]*models.Expense); ok {
+		for _, expense := range expenses {
+			if err := consume(expense); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
 func (s *ServiceMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
-	s.On("Add", callArguments...).Return(returnArguments...).Times(times)
+	s.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
 }
 
 func (s *ServiceMock) MockSearchInPeriod(callArguments, returnArguments []interface{}, times int) {
-	s.On("SearchInPeriod", callArguments...).Return(returnArguments...).Times(times)
+	s.On("SearchInPeriod", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockSearchText(callArguments, returnArguments []interface{}, times int) {
+	s.On("SearchText", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGetByID(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetById", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockUpdate(callArguments, returnArguments []interface{}, times int) {
+	s.On("Update", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockDelete(callArguments, returnArguments []interface{}, times int) {
+	s.On("Delete", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockImport(callArguments, returnArguments []interface{}, times int) {
+	s.On("Import", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockImportStatement(callArguments, returnArguments []interface{}, times int) {
+	s.On("ImportStatement", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockExport(callArguments, returnArguments []interface{}, times int) {
+	s.On("Export", anyContext(callArguments)...).Return(returnArguments...).Times(times)
 }
@@ -1,33 +1,56 @@
 package expense_test
 
 import (
+	"context"
 	"errors"
 	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/account"
+	"finfit-backend/internal/domain/services/exchangerate"
 	"finfit-backend/internal/domain/services/expense"
 	"finfit-backend/internal/domain/services/expensetype"
+	"finfit-backend/internal/domain/services/ledger"
+	"finfit-backend/internal/domain/services/unitofwork"
 	"finfit-backend/pkg"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"strings"
 	"testing"
 	"time"
 )
 
 type ExpenseServiceTestSuite struct {
 	suite.Suite
-	expenseRepositoryMock  *expense.RepositoryMock
-	expenseTypeServiceMock *expensetype.ServiceMock
-	service                expense.Service
+	userId                    uuid.UUID
+	ledgerId                  uuid.UUID
+	expenseRepositoryMock     *expense.RepositoryMock
+	expenseTypeRepositoryMock *expensetype.RepositoryMock
+	expenseTypeServiceMock    *expensetype.ServiceMock
+	accountServiceMock        *account.ServiceMock
+	exchangeRateServiceMock   *exchangerate.ServiceMock
+	ledgerServiceMock         *ledger.ServiceMock
+	service                   expense.Service
 }
 
 func (suite *ExpenseServiceTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.ledgerId = uuid.New()
 	suite.expenseRepositoryMock = expense.NewRepositoryMock()
+	suite.expenseTypeRepositoryMock = expensetype.NewRepositoryMock()
 	suite.expenseTypeServiceMock = expensetype.NewServiceMock()
-	suite.service = expense.NewService(suite.expenseRepositoryMock, suite.expenseTypeServiceMock)
+	suite.accountServiceMock = account.NewServiceMock()
+	suite.exchangeRateServiceMock = exchangerate.NewServiceMock()
+	suite.ledgerServiceMock = ledger.NewServiceMock()
+	suite.service = expense.NewService(suite.expenseRepositoryMock, suite.newUnitOfWork(suite.expenseRepositoryMock), suite.expenseTypeServiceMock, suite.accountServiceMock, suite.exchangeRateServiceMock, suite.ledgerServiceMock)
 	suite.patchUUIDFunction()
 }
 
+func (suite *ExpenseServiceTestSuite) SetupTest() {
+	suite.ledgerServiceMock.MockAuthorize([]interface{}{suite.userId, suite.ledgerId, mock.Anything}, []interface{}{nil}, 0)
+}
+
 func (suite *ExpenseServiceTestSuite) patchUUIDFunction() {
 	id := uuid.New()
 	pkg.NewUUID = func() uuid.UUID {
@@ -41,7 +64,17 @@ func (suite *ExpenseServiceTestSuite) TearDownSuite() {
 
 func (suite *ExpenseServiceTestSuite) TearDownTest() {
 	suite.expenseRepositoryMock.ExpectedCalls = nil
+	suite.expenseRepositoryMock.Calls = nil
+	suite.expenseTypeRepositoryMock.ExpectedCalls = nil
+	suite.expenseTypeRepositoryMock.Calls = nil
 	suite.expenseTypeServiceMock.ExpectedCalls = nil
+	suite.expenseTypeServiceMock.Calls = nil
+	suite.accountServiceMock.ExpectedCalls = nil
+	suite.accountServiceMock.Calls = nil
+	suite.exchangeRateServiceMock.ExpectedCalls = nil
+	suite.exchangeRateServiceMock.Calls = nil
+	suite.ledgerServiceMock.ExpectedCalls = nil
+	suite.ledgerServiceMock.Calls = nil
 }
 
 func TestServiceTestSuite(t *testing.T) {
@@ -52,10 +85,10 @@ func (suite *ExpenseServiceTestSuite) TestGivenAnExpense_WhenAdd_ThenReturnCreat
 	expenseToCreate := suite.getExpense1()
 	expectedCreatedExpense := expenseToCreate
 
-	suite.expenseRepositoryMock.MockAdd([]interface{}{expenseToCreate}, []interface{}{expectedCreatedExpense, nil}, 1)
-	suite.expenseTypeServiceMock.MockGetByID([]interface{}{expenseToCreate.ExpenseType().Id()}, []interface{}{expenseToCreate.ExpenseType(), nil}, 1)
+	suite.expenseRepositoryMock.MockAdd([]interface{}{suite.ledgerId, expenseToCreate}, []interface{}{expectedCreatedExpense, nil}, 1)
+	suite.expenseTypeRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, expenseToCreate.ExpenseType().Id()}, []interface{}{expenseToCreate.ExpenseType(), nil}, 1)
 
-	actualCreatedExpense, err := suite.service.Add(buildAddCommandFromExpense(expenseToCreate))
+	actualCreatedExpense, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, buildAddCommandFromExpense(expenseToCreate))
 
 	assert.Nil(suite.T(), err, "Error must to be nil")
 	assertEqualsExpense(suite.T(), expectedCreatedExpense, actualCreatedExpense)
@@ -69,9 +102,9 @@ func (suite *ExpenseServiceTestSuite) TestGivenThatFailToGetExpenseType_WhenAdd_
 		Msg: expenseTypeServiceError.Error(),
 	}
 
-	suite.expenseTypeServiceMock.MockGetByID([]interface{}{expenseToCreate.ExpenseType().Id()}, []interface{}{nil, expenseTypeServiceError}, 1)
+	suite.expenseTypeRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, expenseToCreate.ExpenseType().Id()}, []interface{}{nil, expenseTypeServiceError}, 1)
 
-	actualCreatedExpense, err := suite.service.Add(buildAddCommandFromExpense(expenseToCreate))
+	actualCreatedExpense, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, buildAddCommandFromExpense(expenseToCreate))
 
 	assert.Nil(suite.T(), actualCreatedExpense)
 	assert.NotNil(suite.T(), err, "Error must not be nil")
@@ -84,9 +117,9 @@ func (suite *ExpenseServiceTestSuite) TestGivenThatExpenseTypeNotExists_WhenAdd_
 		Msg: "the expense type doesn't exists",
 	}
 
-	suite.expenseTypeServiceMock.MockGetByID([]interface{}{expenseToCreate.ExpenseType().Id()}, []interface{}{nil, nil}, 1)
+	suite.expenseTypeRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, expenseToCreate.ExpenseType().Id()}, []interface{}{nil, nil}, 1)
 
-	actualCreatedExpense, err := suite.service.Add(buildAddCommandFromExpense(expenseToCreate))
+	actualCreatedExpense, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, buildAddCommandFromExpense(expenseToCreate))
 
 	assert.Nil(suite.T(), actualCreatedExpense)
 	assert.NotNil(suite.T(), err, "Error must not be nil")
@@ -101,10 +134,10 @@ func (suite *ExpenseServiceTestSuite) TestGivenThatSaveExpenseIntoDatabaseFails_
 		Msg: repoError.Error(),
 	}
 
-	suite.expenseTypeServiceMock.MockGetByID([]interface{}{expenseToCreate.ExpenseType().Id()}, []interface{}{expenseToCreate.ExpenseType(), nil}, 1)
-	suite.expenseRepositoryMock.MockAdd([]interface{}{expenseToCreate}, []interface{}{nil, repoError}, 1)
+	suite.expenseTypeRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, expenseToCreate.ExpenseType().Id()}, []interface{}{expenseToCreate.ExpenseType(), nil}, 1)
+	suite.expenseRepositoryMock.MockAdd([]interface{}{suite.ledgerId, expenseToCreate}, []interface{}{nil, repoError}, 1)
 
-	actualCreatedExpense, err := suite.service.Add(buildAddCommandFromExpense(expenseToCreate))
+	actualCreatedExpense, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, buildAddCommandFromExpense(expenseToCreate))
 
 	assert.Nil(suite.T(), actualCreatedExpense)
 	assert.NotNil(suite.T(), err, "Error must not be nil")
@@ -119,16 +152,17 @@ func (suite *ExpenseServiceTestSuite) TestGivenAPeriod_WhenSearchInPeriod_ThenRe
 		time.Date(2022, 8, 23, 0, 0, 0, 0, time.Local))
 
 	suite.expenseRepositoryMock.MockSearchInPeriod(
-		[]interface{}{searchInPeriodCommand.StartDate(), searchInPeriodCommand.EndDate()},
+		[]interface{}{suite.ledgerId, suite.getSearchCriteria(searchInPeriodCommand)},
 		[]interface{}{expensesToReturn, nil},
 		1)
 
-	actualExpenses, err := suite.service.SearchInPeriod(searchInPeriodCommand)
+	actualExpenses, err := suite.service.SearchInPeriod(context.Background(), suite.userId, suite.ledgerId, searchInPeriodCommand)
 
 	require.NoError(suite.T(), err)
 	for i, expectdExpense := range expensesToReturn {
-		assertEqualsExpense(suite.T(), expectdExpense, actualExpenses[i])
+		assertEqualsExpense(suite.T(), expectdExpense, actualExpenses.Expenses[i])
 	}
+	assert.Nil(suite.T(), actualExpenses.NextCursor)
 }
 
 func (suite *ExpenseServiceTestSuite) TestGivenThatRepositoryFails_WhenSearchInPeriod_ThenReturnError() {
@@ -137,16 +171,754 @@ func (suite *ExpenseServiceTestSuite) TestGivenThatRepositoryFails_WhenSearchInP
 		time.Date(2022, 8, 23, 0, 0, 0, 0, time.Local))
 
 	suite.expenseRepositoryMock.MockSearchInPeriod(
-		[]interface{}{searchInPeriodCommand.StartDate(), searchInPeriodCommand.EndDate()},
+		[]interface{}{suite.ledgerId, suite.getSearchCriteria(searchInPeriodCommand)},
 		[]interface{}{nil, errors.New("fail to get expenses")},
 		1)
 
-	actualExpenses, err := suite.service.SearchInPeriod(searchInPeriodCommand)
+	actualExpenses, err := suite.service.SearchInPeriod(context.Background(), suite.userId, suite.ledgerId, searchInPeriodCommand)
 
 	require.ErrorAs(suite.T(), err, &expense.UnexpectedError{})
 	require.Nil(suite.T(), actualExpenses)
 }
 
+func (suite *ExpenseServiceTestSuite) TestGivenATargetCurrency_WhenSearchInPeriod_ThenReturnExpensesWithTheirConversion() {
+	expensesToReturn := suite.getExpenses()
+	searchInPeriodCommand, _ := expense.NewSearchInPeriodCommand(
+		time.Date(2022, 5, 23, 0, 0, 0, 0, time.Local),
+		time.Date(2022, 8, 23, 0, 0, 0, 0, time.Local))
+	searchInPeriodCommand, _ = searchInPeriodCommand.WithTargetCurrency("USD")
+	suite.expenseRepositoryMock.MockSearchInPeriod(
+		[]interface{}{suite.ledgerId, suite.getSearchCriteria(searchInPeriodCommand)},
+		[]interface{}{expensesToReturn, nil},
+		1)
+	rate, _ := models.NewExchangeRate("USD", "ARS", time.Date(2022, 5, 27, 0, 0, 0, 0, time.UTC), "120")
+	convertedConversion, _ := models.NewConversion(expensesToReturn[0].Amount(), "USD", rate)
+	missingConversion, _ := models.NewConversion(expensesToReturn[1].Amount(), "USD", nil)
+	suite.exchangeRateServiceMock.MockConvert([]interface{}{expensesToReturn[0].Amount(), "USD", expensesToReturn[0].ExpenseDate()}, []interface{}{convertedConversion, nil}, 1)
+	suite.exchangeRateServiceMock.MockConvert([]interface{}{expensesToReturn[1].Amount(), "USD", expensesToReturn[1].ExpenseDate()}, []interface{}{missingConversion, nil}, 1)
+
+	actualExpenses, err := suite.service.SearchInPeriod(context.Background(), suite.userId, suite.ledgerId, searchInPeriodCommand)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), convertedConversion, actualExpenses.Expenses[0].Conversion())
+	assert.Equal(suite.T(), missingConversion, actualExpenses.Expenses[1].Conversion())
+	assert.True(suite.T(), actualExpenses.Expenses[1].Conversion().IsMissingRate())
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAnExpenseType_WhenSearchInPeriod_ThenReturnTheExpensesOfItsWholeSubtree() {
+	food, _ := models.NewExpenseTypeWithId(uuid.New(), "Food")
+	delivery, _ := suite.getExpenseType().WithParent(food)
+	expensesToReturn := suite.getExpenses()
+	searchInPeriodCommand, _ := expense.NewSearchInPeriodCommand(
+		time.Date(2022, 5, 23, 0, 0, 0, 0, time.Local),
+		time.Date(2022, 8, 23, 0, 0, 0, 0, time.Local))
+	searchInPeriodCommand = searchInPeriodCommand.WithExpenseTypes([]uuid.UUID{food.Id()})
+	suite.expenseTypeServiceMock.MockGetSubtree([]interface{}{suite.userId, suite.ledgerId, food.Id()}, []interface{}{[]*models.ExpenseType{food, delivery}, nil}, 1)
+	suite.expenseRepositoryMock.MockSearchInPeriod(
+		[]interface{}{suite.ledgerId, expense.SearchCriteria{StartDate: searchInPeriodCommand.StartDate(), EndDate: searchInPeriodCommand.EndDate(), ExpenseTypeIds: []uuid.UUID{food.Id(), delivery.Id()}, Sort: expense.ExpenseDateDescSort}},
+		[]interface{}{expensesToReturn, nil},
+		1)
+
+	actualExpenses, err := suite.service.SearchInPeriod(context.Background(), suite.userId, suite.ledgerId, searchInPeriodCommand)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expensesToReturn, actualExpenses.Expenses)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenANonExistentExpenseType_WhenSearchInPeriod_ThenReturnInvalidExpenseTypeError() {
+	expenseTypeId := uuid.New()
+	searchInPeriodCommand, _ := expense.NewSearchInPeriodCommand(
+		time.Date(2022, 5, 23, 0, 0, 0, 0, time.Local),
+		time.Date(2022, 8, 23, 0, 0, 0, 0, time.Local))
+	searchInPeriodCommand = searchInPeriodCommand.WithExpenseTypes([]uuid.UUID{expenseTypeId})
+	suite.expenseTypeServiceMock.MockGetSubtree([]interface{}{suite.userId, suite.ledgerId, expenseTypeId}, []interface{}{nil, expensetype.ExpenseTypeNotFoundError{Msg: "not found"}}, 1)
+
+	actualExpenses, err := suite.service.SearchInPeriod(context.Background(), suite.userId, suite.ledgerId, searchInPeriodCommand)
+
+	require.ErrorAs(suite.T(), err, &expense.InvalidExpenseTypeError{})
+	require.Nil(suite.T(), actualExpenses)
+	suite.expenseRepositoryMock.AssertNotCalled(suite.T(), "SearchInPeriod", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAPageSize_WhenSearchInPeriod_ThenReturnThePageWithTheCursorOfItsLastExpense() {
+	expensesToReturn := []*models.Expense{suite.getExpense2(), suite.getExpense1()}
+	searchInPeriodCommand, _ := expense.NewSearchInPeriodCommand(
+		time.Date(2022, 5, 23, 0, 0, 0, 0, time.Local),
+		time.Date(2022, 8, 23, 0, 0, 0, 0, time.Local))
+	searchInPeriodCommand, _ = searchInPeriodCommand.WithPage(1, "")
+	criteria := suite.getSearchCriteria(searchInPeriodCommand)
+	criteria.Limit = 2
+	suite.expenseRepositoryMock.MockSearchInPeriod([]interface{}{suite.ledgerId, criteria}, []interface{}{expensesToReturn, nil}, 1)
+
+	result, err := suite.service.SearchInPeriod(context.Background(), suite.userId, suite.ledgerId, searchInPeriodCommand)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expensesToReturn[:1], result.Expenses)
+	require.NotNil(suite.T(), result.NextCursor)
+	assert.Equal(suite.T(), expense.ExpenseDateDescSort, result.NextCursor.Sort())
+	assert.Equal(suite.T(), "2022-07-28", result.NextCursor.Value())
+
+	nextPageCommand, err := searchInPeriodCommand.WithPage(1, result.NextCursor.String())
+	require.NoError(suite.T(), err)
+	criteria.After = result.NextCursor
+	suite.expenseRepositoryMock.MockSearchInPeriod([]interface{}{suite.ledgerId, criteria}, []interface{}{expensesToReturn[1:], nil}, 1)
+
+	result, err = suite.service.SearchInPeriod(context.Background(), suite.userId, suite.ledgerId, nextPageCommand)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expensesToReturn[1:], result.Expenses)
+	assert.Nil(suite.T(), result.NextCursor)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenFilters_WhenSearchInPeriod_ThenSearchWithAllOfThem() {
+	accountId := uuid.New()
+	tagFilter, _ := models.NewTagFilter([]string{"work"}, "any")
+	searchInPeriodCommand, _ := expense.NewSearchInPeriodCommand(
+		time.Date(2022, 5, 23, 0, 0, 0, 0, time.Local),
+		time.Date(2022, 8, 23, 0, 0, 0, 0, time.Local))
+	searchInPeriodCommand, _ = searchInPeriodCommand.WithAmountRange("10", "200.50")
+	searchInPeriodCommand, _ = searchInPeriodCommand.WithCurrency("ARS")
+	searchInPeriodCommand, _ = searchInPeriodCommand.WithSort("amount")
+	searchInPeriodCommand = searchInPeriodCommand.WithDescription(" lomitos ").WithAccount(accountId).WithTagFilter(tagFilter)
+	expectedCriteria := expense.SearchCriteria{
+		StartDate:   searchInPeriodCommand.StartDate(),
+		EndDate:     searchInPeriodCommand.EndDate(),
+		TagFilter:   tagFilter,
+		MinAmount:   "10",
+		MaxAmount:   "200.50",
+		Currency:    "ARS",
+		Description: "lomitos",
+		AccountId:   accountId,
+		Sort:        expense.AmountAscSort,
+	}
+	suite.expenseRepositoryMock.MockSearchInPeriod([]interface{}{suite.ledgerId, expectedCriteria}, []interface{}{suite.getExpenses(), nil}, 1)
+
+	result, err := suite.service.SearchInPeriod(context.Background(), suite.userId, suite.ledgerId, searchInPeriodCommand)
+
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Expenses, 2)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenASortByAmountWithoutCurrency_WhenSearchInPeriod_ThenReturnInvalidSearchSortError() {
+	searchInPeriodCommand, _ := expense.NewSearchInPeriodCommand(
+		time.Date(2022, 5, 23, 0, 0, 0, 0, time.Local),
+		time.Date(2022, 8, 23, 0, 0, 0, 0, time.Local))
+	searchInPeriodCommand, _ = searchInPeriodCommand.WithSort("amount")
+
+	result, err := suite.service.SearchInPeriod(context.Background(), suite.userId, suite.ledgerId, searchInPeriodCommand)
+
+	require.ErrorAs(suite.T(), err, &expense.InvalidSearchSortError{})
+	require.Nil(suite.T(), result)
+	suite.expenseRepositoryMock.AssertNotCalled(suite.T(), "SearchInPeriod", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenTheCursorOfAnotherSort_WhenSearchInPeriod_ThenReturnInvalidCursorError() {
+	searchInPeriodCommand, _ := expense.NewSearchInPeriodCommand(
+		time.Date(2022, 5, 23, 0, 0, 0, 0, time.Local),
+		time.Date(2022, 8, 23, 0, 0, 0, 0, time.Local))
+	searchInPeriodCommand, _ = searchInPeriodCommand.WithSort("-amount")
+	searchInPeriodCommand, _ = searchInPeriodCommand.WithPage(10, suite.getCursor(expense.AmountAscSort))
+
+	result, err := suite.service.SearchInPeriod(context.Background(), suite.userId, suite.ledgerId, searchInPeriodCommand)
+
+	require.ErrorAs(suite.T(), err, &expense.InvalidCursorError{})
+	require.Nil(suite.T(), result)
+	suite.expenseRepositoryMock.AssertNotCalled(suite.T(), "SearchInPeriod", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAPageSizeOverTheMaximum_WhenWithPage_ThenLowerIt() {
+	searchInPeriodCommand, _ := expense.NewSearchInPeriodCommand(
+		time.Date(2022, 5, 23, 0, 0, 0, 0, time.Local),
+		time.Date(2022, 8, 23, 0, 0, 0, 0, time.Local))
+
+	searchInPeriodCommand, err := searchInPeriodCommand.WithPage(expense.MaxPageSize+1, "")
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expense.MaxPageSize, searchInPeriodCommand.Limit())
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAnInvalidAmountRangeOrCursor_WhenBuildingTheSearchCommand_ThenReturnError() {
+	searchInPeriodCommand, _ := expense.NewSearchInPeriodCommand(
+		time.Date(2022, 5, 23, 0, 0, 0, 0, time.Local),
+		time.Date(2022, 8, 23, 0, 0, 0, 0, time.Local))
+
+	_, err := searchInPeriodCommand.WithAmountRange("100", "9.99")
+	assert.Error(suite.T(), err)
+
+	_, err = searchInPeriodCommand.WithAmountRange("-1", "")
+	assert.Error(suite.T(), err)
+
+	_, err = searchInPeriodCommand.WithAmountRange("0.00001", "")
+	assert.Error(suite.T(), err)
+
+	_, err = searchInPeriodCommand.WithPage(10, "not-a-cursor")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenARepositoryWithoutFullText_WhenSearchText_ThenRankTheExpensesOfTheLedgerByTheirWords() {
+	amount := suite.getMoney()
+	transport, _ := models.NewExpenseTypeWithId(uuid.New(), "Transport")
+	uberEats, _ := models.NewExpenseTypeWithId(uuid.New(), "Uber Eats")
+	ride, _ := models.NewExpenseWithId(uuid.New(), amount, time.Date(2022, 3, 12, 0, 0, 0, 0, time.UTC), "Uber to the airport", transport)
+	dinner, _ := models.NewExpenseWithId(uuid.New(), amount, time.Date(2022, 3, 20, 0, 0, 0, 0, time.UTC), "", uberEats)
+	lomitos, _ := models.NewExpenseWithId(uuid.New(), amount, time.Date(2022, 3, 21, 0, 0, 0, 0, time.UTC), "Lomitos", suite.getExpenseType())
+	suite.expenseRepositoryMock.MockForEachInPeriod([]interface{}{suite.ledgerId, mock.Anything, mock.Anything}, []interface{}{[]*models.Expense{ride, dinner, lomitos}, nil}, 1)
+	command, _ := expense.NewTextSearchCommand("Uber ride")
+
+	hits, err := suite.service.SearchText(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), hits, 2)
+	assert.Equal(suite.T(), ride, hits[0].Expense)
+	assert.Equal(suite.T(), "<mark>Uber</mark> to the airport", hits[0].Snippet)
+	assert.Equal(suite.T(), dinner, hits[1].Expense)
+	assert.Equal(suite.T(), "<mark>Uber</mark> Eats", hits[1].Snippet)
+	assert.Greater(suite.T(), hits[0].Rank, hits[1].Rank)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenARepositoryWithFullText_WhenSearchText_ThenSearchThroughItsIndex() {
+	repositoryMock := expense.NewFullTextRepositoryMock()
+	service := expense.NewService(repositoryMock, suite.newUnitOfWork(repositoryMock), suite.expenseTypeServiceMock, suite.accountServiceMock, suite.exchangeRateServiceMock, suite.ledgerServiceMock)
+	expectedHits := []*expense.TextSearchHit{{Expense: suite.getExpense1(), Rank: 0.6, Snippet: "<mark>Lomitos</mark>"}}
+	repositoryMock.MockSearchText([]interface{}{suite.ledgerId, []string{"lomitos", "march"}, 5}, []interface{}{expectedHits, nil}, 1)
+	command, _ := expense.NewTextSearchCommand("Lomitos, march!")
+	command, _ = command.WithLimit(5)
+
+	hits, err := service.SearchText(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedHits, hits)
+	repositoryMock.AssertNotCalled(suite.T(), "ForEachInPeriod", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAQueryWithoutWords_WhenNewTextSearchCommand_ThenReturnError() {
+	command, err := expense.NewTextSearchCommand(" ?! ")
+
+	require.Error(suite.T(), err)
+	require.Nil(suite.T(), command)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenValidRows_WhenImport_ThenAddAllTheExpensesTogether() {
+	delivery := suite.getExpenseType()
+	groceries, _ := models.NewExpenseType("Groceries")
+	suite.expenseTypeServiceMock.MockGetAll([]interface{}{suite.userId, suite.ledgerId}, []interface{}{[]*models.ExpenseType{delivery, groceries}, nil}, 1)
+	suite.expenseTypeServiceMock.MockGetByID([]interface{}{suite.userId, suite.ledgerId, delivery.Id()}, []interface{}{delivery, nil}, 1)
+	firstAmount, _ := models.NewMoney("10.30", "ARS")
+	secondAmount, _ := models.NewMoney("99.99", "ARS")
+	firstExpense, _ := models.NewExpense(firstAmount, time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), "Lomitos", delivery)
+	secondExpense, _ := models.NewExpense(secondAmount, time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC), "Supermarket", groceries)
+	suite.expenseRepositoryMock.MockAddAll([]interface{}{suite.ledgerId, []*models.Expense{firstExpense, secondExpense}}, []interface{}{nil}, 1)
+
+	command, _ := expense.NewImportCommand([]expense.ImportRow{
+		{Date: "01/03/2022", Amount: "10.30", Currency: "ARS", Description: "Lomitos"},
+		{Date: "02/03/2022", Amount: "99.99", Currency: "ARS", Description: "Supermarket", ExpenseType: "groceries"},
+	}, "02/01/2006", delivery.Id(), false)
+	importedExpenses, err := suite.service.Import(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []*models.Expense{firstExpense, secondExpense}, importedExpenses)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenADryRun_WhenImport_ThenReturnTheExpensesWithoutStoringThem() {
+	delivery := suite.getExpenseType()
+	suite.expenseTypeServiceMock.MockGetAll([]interface{}{suite.userId, suite.ledgerId}, []interface{}{[]*models.ExpenseType{delivery}, nil}, 1)
+
+	command, _ := expense.NewImportCommand([]expense.ImportRow{
+		{Date: "2022-03-01", Amount: "10.30", Currency: "ARS", Description: "Lomitos", ExpenseType: "Delivery"},
+	}, "2006-01-02", uuid.Nil, true)
+	importedExpenses, err := suite.service.Import(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), importedExpenses, 1)
+	suite.expenseRepositoryMock.AssertNotCalled(suite.T(), "AddAll", mock.Anything, suite.ledgerId, mock.Anything)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenLongDescriptions_WhenImport_ThenKeepThemUpToTheLengthOfTheColumn() {
+	delivery := suite.getExpenseType()
+	suite.expenseTypeServiceMock.MockGetAll([]interface{}{suite.userId, suite.ledgerId}, []interface{}{[]*models.ExpenseType{delivery}, nil}, 1)
+	longDescription := strings.Repeat("a", 200)
+
+	command, _ := expense.NewImportCommand([]expense.ImportRow{
+		{Date: "2022-03-01", Amount: "10.30", Currency: "ARS", Description: longDescription, ExpenseType: "Delivery"},
+		{Date: "2022-03-01", Amount: "10.30", Currency: "ARS", Description: longDescription + longDescription, ExpenseType: "Delivery"},
+	}, "2006-01-02", uuid.Nil, true)
+	importedExpenses, err := suite.service.Import(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), longDescription, importedExpenses[0].Description())
+	assert.Len(suite.T(), importedExpenses[1].Description(), 255)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenInvalidRows_WhenImport_ThenReturnEveryRowErrorAndStoreNothing() {
+	delivery := suite.getExpenseType()
+	suite.expenseTypeServiceMock.MockGetAll([]interface{}{suite.userId, suite.ledgerId}, []interface{}{[]*models.ExpenseType{delivery}, nil}, 1)
+
+	command, _ := expense.NewImportCommand([]expense.ImportRow{
+		{Date: "2022-03-01", Amount: "10.30", Currency: "ARS", ExpenseType: "Delivery"},
+		{Date: "01/03/2022", Amount: "10.30", Currency: "ARS", ExpenseType: "Delivery"},
+		{Date: "2022-03-01", Amount: "-5", Currency: "ARS", ExpenseType: "Delivery"},
+		{Date: "2022-03-01", Amount: "5", Currency: "ARS", ExpenseType: "Travel"},
+	}, "2006-01-02", uuid.Nil, false)
+	importedExpenses, err := suite.service.Import(context.Background(), suite.userId, suite.ledgerId, command)
+
+	var rowsError expense.InvalidImportRowsError
+	require.ErrorAs(suite.T(), err, &rowsError)
+	require.Nil(suite.T(), importedExpenses)
+	assert.Equal(suite.T(), []expense.ImportRowError{
+		{Row: 1, Field: "Date", Msg: "the date doesn't match the format 2006-01-02"},
+		{Row: 2, Field: "Amount", Msg: "the amount must be a decimal number greater than 0 with the decimal places of its currency"},
+		{Row: 3, Field: "ExpenseType", Msg: "the expense type doesn't exists and there isn't a default one"},
+	}, rowsError.RowErrors)
+	suite.expenseRepositoryMock.AssertNotCalled(suite.T(), "AddAll", mock.Anything, suite.ledgerId, mock.Anything)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenThatDefaultExpenseTypeNotExists_WhenImport_ThenReturnInvalidExpenseTypeError() {
+	defaultExpenseTypeId := uuid.New()
+	suite.expenseTypeServiceMock.MockGetAll([]interface{}{suite.userId, suite.ledgerId}, []interface{}{[]*models.ExpenseType{}, nil}, 1)
+	suite.expenseTypeServiceMock.MockGetByID([]interface{}{suite.userId, suite.ledgerId, defaultExpenseTypeId}, []interface{}{nil, nil}, 1)
+
+	command, _ := expense.NewImportCommand([]expense.ImportRow{
+		{Date: "2022-03-01", Amount: "10.30", Currency: "ARS"},
+	}, "2006-01-02", defaultExpenseTypeId, false)
+	importedExpenses, err := suite.service.Import(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.ErrorAs(suite.T(), err, &expense.InvalidExpenseTypeError{})
+	require.Nil(suite.T(), importedExpenses)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAStatementWithFitIds_WhenImportStatement_ThenCreateOnlyTheNewTransactions() {
+	delivery := suite.getExpenseType()
+	suite.expenseTypeServiceMock.MockGetByID([]interface{}{suite.userId, suite.ledgerId, delivery.Id()}, []interface{}{delivery, nil}, 1)
+	marchFirst := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	newCommand := suite.getStatementCommand("10.30", marchFirst, "Lomitos", delivery).WithFitId("N1")
+	storedCommand := suite.getStatementCommand("20", marchFirst, "Pizza", delivery).WithFitId("S1")
+	changedCommand := suite.getStatementCommand("30", marchFirst, "Empanadas", delivery).WithFitId("C1")
+	storedExpense := suite.getStoredExpense("20", marchFirst, "Pizza edited by the user", delivery).WithFitId("S1")
+	changedExpense := suite.getStoredExpense("35", marchFirst, "Empanadas", delivery).WithFitId("C1")
+	suite.expenseRepositoryMock.MockGetByFitIds([]interface{}{suite.ledgerId, []string{"N1", "S1", "C1", "N1"}}, []interface{}{[]*models.Expense{storedExpense, changedExpense}, nil}, 1)
+	suite.expenseRepositoryMock.MockAddAll([]interface{}{suite.ledgerId, mock.Anything}, []interface{}{nil}, 1)
+
+	summary, err := suite.service.ImportStatement(context.Background(), suite.userId, suite.ledgerId, []*expense.AddCommand{newCommand, storedCommand, changedCommand, newCommand})
+
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), summary.Created, 1)
+	assert.Equal(suite.T(), "N1", summary.Created[0].FitId())
+	require.Len(suite.T(), summary.Skipped, 2)
+	assert.Equal(suite.T(), storedExpense, summary.Skipped[0].ExistingExpense)
+	assert.Nil(suite.T(), summary.Skipped[1].ExistingExpense)
+	require.Len(suite.T(), summary.Conflicting, 1)
+	assert.Equal(suite.T(), changedExpense, summary.Conflicting[0].ExistingExpense)
+	suite.expenseRepositoryMock.AssertCalled(suite.T(), "AddAll", mock.Anything, suite.ledgerId, summary.Created)
+	suite.expenseRepositoryMock.AssertNotCalled(suite.T(), "SearchInPeriod", mock.Anything, suite.ledgerId, mock.Anything)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAStatementWithoutFitIds_WhenImportStatement_ThenMatchTheStoredExpensesByFingerprint() {
+	delivery := suite.getExpenseType()
+	suite.expenseTypeServiceMock.MockGetByID([]interface{}{suite.userId, suite.ledgerId, delivery.Id()}, []interface{}{delivery, nil}, 1)
+	marchFirst := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	marchThird := time.Date(2022, 3, 3, 0, 0, 0, 0, time.UTC)
+	coffeeCommand := suite.getStatementCommand("3.50", marchFirst, "Coffee  shop", delivery)
+	taxiCommand := suite.getStatementCommand("12", marchThird, "Taxi", delivery)
+	storedCoffee := suite.getStoredExpense("3.5", marchFirst, "COFFEE SHOP", delivery)
+	suite.expenseRepositoryMock.MockSearchInPeriod([]interface{}{suite.ledgerId, expense.SearchCriteria{StartDate: marchFirst, EndDate: marchThird}}, []interface{}{[]*models.Expense{storedCoffee}, nil}, 1)
+	suite.expenseRepositoryMock.MockAddAll([]interface{}{suite.ledgerId, mock.Anything}, []interface{}{nil}, 1)
+
+	summary, err := suite.service.ImportStatement(context.Background(), suite.userId, suite.ledgerId, []*expense.AddCommand{coffeeCommand, coffeeCommand, taxiCommand})
+
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), summary.Skipped, 1)
+	assert.Equal(suite.T(), storedCoffee, summary.Skipped[0].ExistingExpense)
+	require.Len(suite.T(), summary.Created, 2)
+	assert.Equal(suite.T(), "Coffee  shop", summary.Created[0].Description())
+	assert.Equal(suite.T(), "Taxi", summary.Created[1].Description())
+	assert.Empty(suite.T(), summary.Conflicting)
+	suite.expenseRepositoryMock.AssertNotCalled(suite.T(), "GetByFitIds", mock.Anything, suite.ledgerId, mock.Anything)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAStatementAlreadyImported_WhenImportStatement_ThenStoreNothing() {
+	delivery := suite.getExpenseType()
+	suite.expenseTypeServiceMock.MockGetByID([]interface{}{suite.userId, suite.ledgerId, delivery.Id()}, []interface{}{delivery, nil}, 1)
+	marchFirst := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	command := suite.getStatementCommand("10.30", marchFirst, "Lomitos", delivery).WithFitId("N1")
+	suite.expenseRepositoryMock.MockGetByFitIds([]interface{}{suite.ledgerId, []string{"N1"}}, []interface{}{[]*models.Expense{suite.getStoredExpense("10.30", marchFirst, "Lomitos", delivery).WithFitId("N1")}, nil}, 1)
+
+	summary, err := suite.service.ImportStatement(context.Background(), suite.userId, suite.ledgerId, []*expense.AddCommand{command})
+
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), summary.Created)
+	assert.Len(suite.T(), summary.Skipped, 1)
+	suite.expenseRepositoryMock.AssertNotCalled(suite.T(), "AddAll", mock.Anything, suite.ledgerId, mock.Anything)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenThatExpenseTypeNotExists_WhenImportStatement_ThenReturnInvalidExpenseTypeError() {
+	delivery := suite.getExpenseType()
+	suite.expenseTypeServiceMock.MockGetByID([]interface{}{suite.userId, suite.ledgerId, delivery.Id()}, []interface{}{nil, nil}, 1)
+	command := suite.getStatementCommand("10.30", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), "Lomitos", delivery)
+
+	summary, err := suite.service.ImportStatement(context.Background(), suite.userId, suite.ledgerId, []*expense.AddCommand{command})
+
+	require.ErrorAs(suite.T(), err, &expense.InvalidExpenseTypeError{})
+	require.Nil(suite.T(), summary)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAPeriod_WhenExport_ThenConsumeEveryExpenseOfThePeriod() {
+	startDate := time.Date(2022, 5, 1, 0, 0, 0, 0, time.Local)
+	endDate := time.Date(2022, 7, 31, 0, 0, 0, 0, time.Local)
+	expenses := []*models.Expense{suite.getExpense1(), suite.getExpense2()}
+	suite.expenseRepositoryMock.MockForEachInPeriod([]interface{}{suite.ledgerId, startDate, endDate}, []interface{}{expenses, nil}, 1)
+
+	command, _ := expense.NewExportCommand(startDate, endDate)
+	consumedExpenses := []*models.Expense{}
+	err := suite.service.Export(context.Background(), suite.userId, suite.ledgerId, command, func(exportedExpense *models.Expense) error {
+		consumedExpenses = append(consumedExpenses, exportedExpense)
+		return nil
+	})
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expenses, consumedExpenses)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenThatConsumeFails_WhenExport_ThenStopAndReturnUnexpectedError() {
+	startDate := time.Date(2022, 5, 1, 0, 0, 0, 0, time.Local)
+	endDate := time.Date(2022, 7, 31, 0, 0, 0, 0, time.Local)
+	suite.expenseRepositoryMock.MockForEachInPeriod([]interface{}{suite.ledgerId, startDate, endDate}, []interface{}{[]*models.Expense{suite.getExpense1(), suite.getExpense2()}, nil}, 1)
+
+	command, _ := expense.NewExportCommand(startDate, endDate)
+	consumed := 0
+	err := suite.service.Export(context.Background(), suite.userId, suite.ledgerId, command, func(exportedExpense *models.Expense) error {
+		consumed++
+		return errors.New("broken pipe")
+	})
+
+	require.ErrorAs(suite.T(), err, &expense.UnexpectedError{})
+	assert.Equal(suite.T(), 1, consumed)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAnId_WhenGetById_ThenReturnExpense() {
+	expectedExpense := suite.getExpense1()
+	suite.expenseRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, expectedExpense.Id()}, []interface{}{expectedExpense, nil}, 1)
+
+	actualExpense, err := suite.service.GetById(context.Background(), suite.userId, suite.ledgerId, expectedExpense.Id())
+
+	require.NoError(suite.T(), err)
+	assertEqualsExpense(suite.T(), expectedExpense, actualExpense)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenThatRepositoryFails_WhenGetById_ThenReturnError() {
+	id := uuid.New()
+	suite.expenseRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, id}, []interface{}{nil, errors.New("fail")}, 1)
+
+	actualExpense, err := suite.service.GetById(context.Background(), suite.userId, suite.ledgerId, id)
+
+	require.ErrorAs(suite.T(), err, &expense.UnexpectedError{})
+	require.Nil(suite.T(), actualExpense)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAnExpenseWithAccount_WhenAdd_ThenReturnCreatedExpenseWithAccount() {
+	expenseToCreate := suite.getExpenseWithAccount("ARS")
+
+	suite.expenseTypeRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, expenseToCreate.ExpenseType().Id()}, []interface{}{expenseToCreate.ExpenseType(), nil}, 1)
+	suite.accountServiceMock.MockGetByID([]interface{}{suite.userId, expenseToCreate.Account().Id()}, []interface{}{expenseToCreate.Account(), nil}, 1)
+	suite.expenseRepositoryMock.MockAdd([]interface{}{suite.ledgerId, expenseToCreate}, []interface{}{expenseToCreate, nil}, 1)
+
+	actualCreatedExpense, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, buildAddCommandFromExpense(expenseToCreate))
+
+	require.NoError(suite.T(), err)
+	assertEqualsExpense(suite.T(), expenseToCreate, actualCreatedExpense)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenANonExistentAccount_WhenAdd_ThenReturnInvalidAccountError() {
+	expenseToCreate := suite.getExpenseWithAccount("ARS")
+
+	suite.expenseTypeRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, expenseToCreate.ExpenseType().Id()}, []interface{}{expenseToCreate.ExpenseType(), nil}, 1)
+	suite.accountServiceMock.MockGetByID([]interface{}{suite.userId, expenseToCreate.Account().Id()}, []interface{}{nil, nil}, 1)
+
+	actualCreatedExpense, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, buildAddCommandFromExpense(expenseToCreate))
+
+	assert.Nil(suite.T(), actualCreatedExpense)
+	assert.Equal(suite.T(), expense.InvalidAccountError{Msg: "the account doesn't exists"}, err)
+	suite.expenseRepositoryMock.AssertNotCalled(suite.T(), "Add", mock.Anything, suite.ledgerId, mock.Anything)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAnAccountWithAnotherCurrency_WhenAdd_ThenReturnInvalidDomainModelError() {
+	expenseToCreate := suite.getExpense1()
+	openingBalance, _ := models.NewMoney("100", "USD")
+	dollarAccount, _ := models.NewAccountWithId(uuid.New(), "Dollars", models.SavingsAccountKind, openingBalance)
+	command, _ := expense.NewAddCommand(expenseToCreate.Amount().Amount(), expenseToCreate.Amount().Currency(), expenseToCreate.ExpenseDate(), "", expenseToCreate.ExpenseType().Id(), dollarAccount.Id())
+
+	suite.expenseTypeRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, expenseToCreate.ExpenseType().Id()}, []interface{}{expenseToCreate.ExpenseType(), nil}, 1)
+	suite.accountServiceMock.MockGetByID([]interface{}{suite.userId, dollarAccount.Id()}, []interface{}{dollarAccount, nil}, 1)
+
+	actualCreatedExpense, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, command)
+
+	assert.Nil(suite.T(), actualCreatedExpense)
+	require.ErrorAs(suite.T(), err, &expense.InvalidDomainModelError{})
+	suite.expenseRepositoryMock.AssertNotCalled(suite.T(), "Add", mock.Anything, suite.ledgerId, mock.Anything)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAnUpdateWithoutAccount_WhenUpdate_ThenKeepStoredAccount() {
+	storedExpense := suite.getExpenseWithAccount("ARS")
+	newDescription := "new description"
+	command, _ := expense.NewUpdateCommand(storedExpense.Id(), "", "", time.Time{}, &newDescription, uuid.Nil, uuid.Nil)
+
+	suite.expenseRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpense.Id()}, []interface{}{storedExpense, nil}, 1)
+	suite.expenseTypeRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpense.ExpenseType().Id()}, []interface{}{storedExpense.ExpenseType(), nil}, 1)
+	expectedExpense, _ := models.NewExpenseWithId(storedExpense.Id(), storedExpense.Amount(), storedExpense.ExpenseDate(), newDescription, storedExpense.ExpenseType())
+	expectedExpense, _ = expectedExpense.WithAccount(storedExpense.Account())
+	suite.expenseRepositoryMock.MockUpdate([]interface{}{suite.ledgerId, expectedExpense}, []interface{}{expectedExpense, nil}, 1)
+
+	updatedExpense, err := suite.service.Update(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), storedExpense.Account(), updatedExpense.Account())
+	assert.Equal(suite.T(), newDescription, updatedExpense.Description())
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAnUpdateCommand_WhenUpdate_ThenReturnUpdatedExpense() {
+	storedExpense := suite.getExpense1()
+	newExpenseType, _ := models.NewExpenseTypeWithId(uuid.New(), "Restaurants")
+	newMoney, _ := models.NewMoney("20.5", "USD")
+	newDescription := "Pizza"
+	expectedExpense, _ := models.NewExpenseWithId(storedExpense.Id(), newMoney, storedExpense.ExpenseDate(), newDescription, newExpenseType)
+
+	suite.expenseRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpense.Id()}, []interface{}{storedExpense, nil}, 1)
+	suite.expenseTypeRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, newExpenseType.Id()}, []interface{}{newExpenseType, nil}, 1)
+	suite.expenseRepositoryMock.MockUpdate([]interface{}{suite.ledgerId, expectedExpense}, []interface{}{expectedExpense, nil}, 1)
+
+	command, _ := expense.NewUpdateCommand(storedExpense.Id(), "20.5", "USD", time.Time{}, &newDescription, newExpenseType.Id(), uuid.Nil)
+	actualExpense, err := suite.service.Update(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	assertEqualsExpense(suite.T(), expectedExpense, actualExpense)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAPartialUpdateCommand_WhenUpdate_ThenKeepStoredValues() {
+	storedExpense := suite.getExpense1()
+	newDate := time.Date(2022, 6, 1, 0, 0, 0, 0, time.Local)
+	expectedExpense, _ := models.NewExpenseWithId(storedExpense.Id(), storedExpense.Amount(), newDate, storedExpense.Description(), storedExpense.ExpenseType())
+
+	suite.expenseRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpense.Id()}, []interface{}{storedExpense, nil}, 1)
+	suite.expenseTypeRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpense.ExpenseType().Id()}, []interface{}{storedExpense.ExpenseType(), nil}, 1)
+	suite.expenseRepositoryMock.MockUpdate([]interface{}{suite.ledgerId, expectedExpense}, []interface{}{expectedExpense, nil}, 1)
+
+	command, _ := expense.NewUpdateCommand(storedExpense.Id(), "", "", newDate, nil, uuid.Nil, uuid.Nil)
+	actualExpense, err := suite.service.Update(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	assertEqualsExpense(suite.T(), expectedExpense, actualExpense)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAnAmountWithoutCurrency_WhenUpdate_ThenKeepTheStoredCurrency() {
+	storedExpense := suite.getExpense1()
+	newMoney, _ := models.NewMoney("20.5", "ARS")
+	expectedExpense, _ := models.NewExpenseWithId(storedExpense.Id(), newMoney, storedExpense.ExpenseDate(), storedExpense.Description(), storedExpense.ExpenseType())
+
+	suite.expenseRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpense.Id()}, []interface{}{storedExpense, nil}, 1)
+	suite.expenseTypeRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpense.ExpenseType().Id()}, []interface{}{storedExpense.ExpenseType(), nil}, 1)
+	suite.expenseRepositoryMock.MockUpdate([]interface{}{suite.ledgerId, expectedExpense}, []interface{}{expectedExpense, nil}, 1)
+
+	command, err := expense.NewUpdateCommand(storedExpense.Id(), "20.5", "", time.Time{}, nil, uuid.Nil, uuid.Nil)
+	require.NoError(suite.T(), err)
+	actualExpense, err := suite.service.Update(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	assertEqualsExpense(suite.T(), expectedExpense, actualExpense)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAnAmountWithMoreDecimalPlacesThanTheStoredCurrency_WhenUpdate_ThenReturnInvalidDomainModelError() {
+	storedExpense := suite.getExpense1()
+	suite.expenseRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpense.Id()}, []interface{}{storedExpense, nil}, 1)
+	suite.expenseTypeRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpense.ExpenseType().Id()}, []interface{}{storedExpense.ExpenseType(), nil}, 1)
+
+	command, err := expense.NewUpdateCommand(storedExpense.Id(), "20.505", "", time.Time{}, nil, uuid.Nil, uuid.Nil)
+	require.NoError(suite.T(), err)
+	actualExpense, err := suite.service.Update(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.ErrorAs(suite.T(), err, &expense.InvalidDomainModelError{})
+	require.Nil(suite.T(), actualExpense)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenThatExpenseNotExists_WhenUpdate_ThenReturnError() {
+	id := uuid.New()
+	suite.expenseRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, id}, []interface{}{nil, nil}, 1)
+
+	command, _ := expense.NewUpdateCommand(id, "10", "ARS", time.Time{}, nil, uuid.Nil, uuid.Nil)
+	actualExpense, err := suite.service.Update(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.ErrorAs(suite.T(), err, &expense.ExpenseNotFoundError{})
+	require.Nil(suite.T(), actualExpense)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenThatNewExpenseTypeNotExists_WhenUpdate_ThenReturnError() {
+	storedExpense := suite.getExpense1()
+	expenseTypeId := uuid.New()
+	suite.expenseRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpense.Id()}, []interface{}{storedExpense, nil}, 1)
+	suite.expenseTypeRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, expenseTypeId}, []interface{}{nil, nil}, 1)
+
+	command, _ := expense.NewUpdateCommand(storedExpense.Id(), "", "", time.Time{}, nil, expenseTypeId, uuid.Nil)
+	actualExpense, err := suite.service.Update(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.ErrorAs(suite.T(), err, &expense.InvalidExpenseTypeError{})
+	require.Nil(suite.T(), actualExpense)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenThatRepositoryFails_WhenUpdate_ThenReturnError() {
+	storedExpense := suite.getExpense1()
+	suite.expenseRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpense.Id()}, []interface{}{storedExpense, nil}, 1)
+	suite.expenseTypeRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpense.ExpenseType().Id()}, []interface{}{storedExpense.ExpenseType(), nil}, 1)
+	suite.expenseRepositoryMock.MockUpdate([]interface{}{suite.ledgerId, storedExpense}, []interface{}{nil, errors.New("fail")}, 1)
+
+	command, _ := expense.NewUpdateCommand(storedExpense.Id(), "", "", time.Time{}, nil, uuid.Nil, uuid.Nil)
+	actualExpense, err := suite.service.Update(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.ErrorAs(suite.T(), err, &expense.UnexpectedError{})
+	require.Nil(suite.T(), actualExpense)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAnId_WhenDelete_ThenDeleteExpense() {
+	storedExpense := suite.getExpense1()
+	suite.expenseRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpense.Id()}, []interface{}{storedExpense, nil}, 1)
+	suite.expenseRepositoryMock.MockDelete([]interface{}{suite.ledgerId, storedExpense.Id()}, []interface{}{nil}, 1)
+
+	err := suite.service.Delete(context.Background(), suite.userId, suite.ledgerId, storedExpense.Id())
+
+	require.NoError(suite.T(), err)
+	suite.expenseRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenThatExpenseNotExists_WhenDelete_ThenReturnError() {
+	id := uuid.New()
+	suite.expenseRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, id}, []interface{}{nil, nil}, 1)
+
+	err := suite.service.Delete(context.Background(), suite.userId, suite.ledgerId, id)
+
+	require.ErrorAs(suite.T(), err, &expense.ExpenseNotFoundError{})
+	suite.expenseRepositoryMock.AssertNotCalled(suite.T(), "Delete", mock.Anything, suite.ledgerId, id)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAViewerOfASharedLedger_WhenDelete_ThenReturnForbiddenError() {
+	sharedLedgerId := uuid.New()
+	id := uuid.New()
+	suite.ledgerServiceMock.MockAuthorize([]interface{}{suite.userId, sharedLedgerId, models.EditorLedgerRole}, []interface{}{ledger.ForbiddenError{Msg: "forbidden"}}, 1)
+
+	err := suite.service.Delete(context.Background(), suite.userId, sharedLedgerId, id)
+
+	require.ErrorAs(suite.T(), err, &ledger.ForbiddenError{})
+	suite.expenseRepositoryMock.AssertNotCalled(suite.T(), "GetByID", mock.Anything, sharedLedgerId, id)
+	suite.expenseRepositoryMock.AssertNotCalled(suite.T(), "Delete", mock.Anything, sharedLedgerId, id)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenAViewerOfASharedLedger_WhenSearchInPeriod_ThenReturnTheExpensesOfTheLedger() {
+	sharedLedgerId := uuid.New()
+	expectedExpenses := suite.getExpenses()
+	startDate := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC)
+	suite.ledgerServiceMock.MockAuthorize([]interface{}{suite.userId, sharedLedgerId, models.ViewerLedgerRole}, []interface{}{nil}, 1)
+	suite.expenseRepositoryMock.MockSearchInPeriod([]interface{}{sharedLedgerId, expense.SearchCriteria{StartDate: startDate, EndDate: endDate, Sort: expense.ExpenseDateDescSort}}, []interface{}{expectedExpenses, nil}, 1)
+	command, _ := expense.NewSearchInPeriodCommand(startDate, endDate)
+
+	expenses, err := suite.service.SearchInPeriod(context.Background(), suite.userId, sharedLedgerId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedExpenses, expenses.Expenses)
+	suite.ledgerServiceMock.AssertCalled(suite.T(), "Authorize", mock.Anything, suite.userId, sharedLedgerId, models.ViewerLedgerRole)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenASplitAmongMembers_WhenAdd_ThenStoreTheExpenseWithItsAllocations() {
+	partner := uuid.New()
+	expenseToCreate := suite.getExpense1()
+	suite.expenseTypeRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, expenseToCreate.ExpenseType().Id()}, []interface{}{expenseToCreate.ExpenseType(), nil}, 1)
+	suite.mockMembers(suite.userId, partner)
+	isSplitInHalves := mock.MatchedBy(func(splitExpense *models.Expense) bool {
+		allocations := splitExpense.Split().Allocations()
+		return splitExpense.Split().PaidBy() == suite.userId && allocations[0].Amount().Amount() == "5.15" && allocations[1].Amount().Amount() == "5.15"
+	})
+	suite.expenseRepositoryMock.MockAdd([]interface{}{suite.ledgerId, isSplitInHalves}, []interface{}{expenseToCreate, nil}, 1)
+	split, _ := expense.NewSplitCommand(suite.userId, "equal", []expense.SplitShare{{UserId: suite.userId}, {UserId: partner}})
+
+	_, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, buildAddCommandFromExpense(expenseToCreate).WithSplit(split))
+
+	require.NoError(suite.T(), err)
+	suite.expenseRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenASplitWithAUserOutsideTheLedger_WhenAdd_ThenReturnInvalidSplitError() {
+	expenseToCreate := suite.getExpense1()
+	suite.expenseTypeRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, expenseToCreate.ExpenseType().Id()}, []interface{}{expenseToCreate.ExpenseType(), nil}, 1)
+	suite.mockMembers(suite.userId)
+	split, _ := expense.NewSplitCommand(suite.userId, "equal", []expense.SplitShare{{UserId: suite.userId}, {UserId: uuid.New()}})
+
+	createdExpense, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, buildAddCommandFromExpense(expenseToCreate).WithSplit(split))
+
+	assert.ErrorAs(suite.T(), err, &expense.InvalidSplitError{})
+	assert.Nil(suite.T(), createdExpense)
+	suite.expenseRepositoryMock.AssertNotCalled(suite.T(), "Add", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ExpenseServiceTestSuite) TestGivenASplitExpense_WhenUpdateItsAmount_ThenReallocateTheSplit() {
+	partner := uuid.New()
+	storedExpense := suite.getSplitExpense(models.SharesSplitMethod, partner, "3", "1")
+	newAmount, _ := models.NewMoney("10", "ARS")
+	suite.expenseRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpense.Id()}, []interface{}{storedExpense, nil}, 1)
+	suite.expenseTypeRepositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpense.ExpenseType().Id()}, []interface{}{storedExpense.ExpenseType(), nil}, 1)
+	expectedSplit, _ := storedExpense.Split().Reallocate(newAmount)
+	expectedExpense, _ := models.NewExpenseWithId(storedExpense.Id(), newAmount, storedExpense.ExpenseDate(), storedExpense.Description(), storedExpense.ExpenseType())
+	expectedExpense, _ = expectedExpense.WithSplit(expectedSplit)
+	suite.expenseRepositoryMock.MockUpdate([]interface{}{suite.ledgerId, expectedExpense}, []interface{}{expectedExpense, nil}, 1)
+
+	command, _ := expense.NewUpdateCommand(storedExpense.Id(), "10", "ARS", time.Time{}, nil, uuid.Nil, uuid.Nil)
+	updatedExpense, err := suite.service.Update(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "7.50", updatedExpense.Split().Allocations()[0].Amount().Amount())
+	assert.Equal(suite.T(), "2.50", updatedExpense.Split().Allocations()[1].Amount().Amount())
+}
+
+// newUnitOfWork runs the operations on the mocks, the rollback is up to the implementations of the unit of work.
+func (suite *ExpenseServiceTestSuite) newUnitOfWork(repository expense.Repository) unitofwork.UnitOfWork[expense.Repositories] {
+	return unitofwork.WithoutTransaction(expense.Repositories{Expenses: repository, ExpenseTypes: suite.expenseTypeRepositoryMock})
+}
+
+func (suite *ExpenseServiceTestSuite) mockMembers(userIds ...uuid.UUID) {
+	members := []*models.LedgerMember{}
+	for _, userId := range userIds {
+		member, _ := models.NewLedgerMember(suite.ledgerId, userId, models.EditorLedgerRole)
+		members = append(members, member)
+	}
+	suite.ledgerServiceMock.MockGetMembers([]interface{}{suite.userId, suite.ledgerId}, []interface{}{members, nil}, 1)
+}
+
+func (suite *ExpenseServiceTestSuite) getSplitExpense(method models.SplitMethod, partner uuid.UUID, userValue string, partnerValue string) *models.Expense {
+	storedExpense := suite.getExpense1()
+	userParticipant, _ := models.NewSplitParticipant(suite.userId, userValue)
+	partnerParticipant, _ := models.NewSplitParticipant(partner, partnerValue)
+	split, _ := models.NewExpenseSplit(storedExpense.Amount(), suite.userId, method, []*models.SplitParticipant{userParticipant, partnerParticipant})
+	splitExpense, _ := storedExpense.WithSplit(split)
+	return splitExpense
+}
+
+// getSearchCriteria returns the criteria of a command without filters.
+func (suite *ExpenseServiceTestSuite) getSearchCriteria(command *expense.SearchInPeriodCommand) expense.SearchCriteria {
+	return expense.SearchCriteria{StartDate: command.StartDate(), EndDate: command.EndDate(), Sort: command.Sort()}
+}
+
+// getCursor returns the cursor of the first page of a search of the ARS expenses in the given order.
+func (suite *ExpenseServiceTestSuite) getCursor(sort expense.SearchSort) string {
+	command, _ := expense.NewSearchInPeriodCommand(time.Date(2022, 5, 23, 0, 0, 0, 0, time.Local), time.Date(2022, 8, 23, 0, 0, 0, 0, time.Local))
+	command, _ = command.WithCurrency("ARS")
+	command, _ = command.WithSort(string(sort))
+	command, _ = command.WithPage(1, "")
+	criteria := suite.getSearchCriteria(command)
+	criteria.Currency = "ARS"
+	criteria.Limit = 2
+	suite.expenseRepositoryMock.MockSearchInPeriod([]interface{}{suite.ledgerId, criteria}, []interface{}{suite.getExpenses(), nil}, 1)
+
+	result, _ := suite.service.SearchInPeriod(context.Background(), suite.userId, suite.ledgerId, command)
+	suite.expenseRepositoryMock.ExpectedCalls = nil
+	suite.expenseRepositoryMock.Calls = nil
+	return result.NextCursor.String()
+}
+
 func (suite *ExpenseServiceTestSuite) getExpenses() []*models.Expense {
 	expense1 := suite.getExpense1()
 	expense2 := suite.getExpense2()
@@ -167,13 +939,31 @@ func (suite *ExpenseServiceTestSuite) getExpense2() *models.Expense {
 	return newExpense
 }
 
+func (suite *ExpenseServiceTestSuite) getExpenseWithAccount(currency string) *models.Expense {
+	openingBalance, _ := models.NewMoney("100", currency)
+	expenseAccount, _ := models.NewAccountWithId(uuid.New(), "Wallet", models.CashAccountKind, openingBalance)
+	expenseWithAccount, _ := suite.getExpense1().WithAccount(expenseAccount)
+	return expenseWithAccount
+}
+
+func (suite *ExpenseServiceTestSuite) getStatementCommand(amount string, expenseDate time.Time, description string, expenseType *models.ExpenseType) *expense.AddCommand {
+	command, _ := expense.NewAddCommand(amount, "ARS", expenseDate, description, expenseType.Id(), uuid.Nil)
+	return command
+}
+
+func (suite *ExpenseServiceTestSuite) getStoredExpense(amount string, expenseDate time.Time, description string, expenseType *models.ExpenseType) *models.Expense {
+	money, _ := models.NewMoney(amount, "ARS")
+	storedExpense, _ := models.NewExpenseWithId(uuid.New(), money, expenseDate, description, expenseType)
+	return storedExpense
+}
+
 func (suite *ExpenseServiceTestSuite) getExpenseType() *models.ExpenseType {
 	expenseType, _ := models.NewExpenseType("Delivery")
 	return expenseType
 }
 
 func (suite *ExpenseServiceTestSuite) getMoney() *models.Money {
-	money, _ := models.NewMoney(10.3, "ARS")
+	money, _ := models.NewMoney("10.3", "ARS")
 	return money
 }
 
@@ -183,9 +973,17 @@ func assertEqualsExpense(t *testing.T, expected *models.Expense, actual *models.
 	assert.Equal(t, expected.Amount(), actual.Amount(), "amounts are not equals")
 	assert.Equalf(t, expected.ExpenseDate(), actual.ExpenseDate(), "expenseDates are not equals")
 	assert.Equalf(t, expected.Description(), actual.Description(), "descriptions are not equals")
+	assert.Equal(t, expected.Account(), actual.Account(), "accounts are not equals")
 }
 
 func buildAddCommandFromExpense(domainExpense *models.Expense) *expense.AddCommand {
-	addCommand, _ := expense.NewAddCommand(domainExpense.Amount().Amount(), domainExpense.Amount().Currency(), domainExpense.ExpenseDate(), domainExpense.Description(), domainExpense.ExpenseType().Id())
+	addCommand, _ := expense.NewAddCommand(domainExpense.Amount().Amount(), domainExpense.Amount().Currency(), domainExpense.ExpenseDate(), domainExpense.Description(), domainExpense.ExpenseType().Id(), accountIdOf(domainExpense))
 	return addCommand
 }
+
+func accountIdOf(domainExpense *models.Expense) uuid.UUID {
+	if domainExpense.Account() == nil {
+		return uuid.Nil
+	}
+	return domainExpense.Account().Id()
+}
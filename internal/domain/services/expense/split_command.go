@@ -0,0 +1,36 @@
+package expense
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+// SplitShare is a participant of a split and its percentage, number of shares or exact amount, which the equal method
+// ignores.
+type SplitShare struct {
+	UserId uuid.UUID
+	Value  string
+}
+
+type SplitCommand struct {
+	paidBy uuid.UUID
+	method models.SplitMethod
+	shares []SplitShare
+}
+
+// NewSplitCommand only checks the shape of the split. Whether the values add up is checked against the amount of the
+// expense, and whether the users are members of the ledger by the service.
+func NewSplitCommand(paidBy uuid.UUID, method string, shares []SplitShare) (*SplitCommand, error) {
+	if paidBy == uuid.Nil || !models.IsValidSplitMethod(method) || len(shares) == 0 {
+		return nil, errors.New("invalid command")
+	}
+
+	for _, share := range shares {
+		if share.UserId == uuid.Nil {
+			return nil, errors.New("invalid command")
+		}
+	}
+
+	return &SplitCommand{paidBy: paidBy, method: models.SplitMethod(method), shares: shares}, nil
+}
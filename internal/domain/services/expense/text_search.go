@@ -0,0 +1,118 @@
+package expense
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// SnippetStartSel and SnippetStopSel surround the matched words of the snippets.
+	SnippetStartSel = "<mark>"
+	SnippetStopSel  = "</mark>"
+	// A word of the description weighs more than a word of the name of the expense type.
+	descriptionMatchWeight = 1.0
+	expenseTypeMatchWeight = 0.4
+)
+
+// TextSearchHit is an expense found by a text search. Snippet is the description of the expense, or the name of its
+// type when it has none, with the matched words highlighted. Hits with a higher Rank match the search better.
+type TextSearchHit struct {
+	Expense *models.Expense
+	Rank    float64
+	Snippet string
+}
+
+// FullTextSearcher is implemented by the repositories backed by a full-text index. The service falls back to scanning
+// the expenses of the ledger with the others.
+type FullTextSearcher interface {
+	// SearchText returns at most limit hits, sorted by rank and then by date, the most recent first.
+	SearchText(ctx context.Context, ledgerId uuid.UUID, terms []string, limit int) ([]*TextSearchHit, error)
+}
+
+var (
+	firstExpenseDate = time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	lastExpenseDate  = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// scanText ranks every expense of the ledger the same way the full-text index does: the share of the terms that start
+// a word, weighing the matches on the description over the ones on the expense type.
+func scanText(ctx context.Context, repository Repository, ledgerId uuid.UUID, terms []string, limit int) ([]*TextSearchHit, error) {
+	hits := []*TextSearchHit{}
+	err := repository.ForEachInPeriod(ctx, ledgerId, firstExpenseDate, lastExpenseDate, func(expense *models.Expense) error {
+		rank := rankText(expense, terms)
+		if rank > 0 {
+			hits = append(hits, &TextSearchHit{Expense: expense, Rank: rank, Snippet: highlightSnippet(expense, terms)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		if !hits[i].Expense.ExpenseDate().Equal(hits[j].Expense.ExpenseDate()) {
+			return hits[i].Expense.ExpenseDate().After(hits[j].Expense.ExpenseDate())
+		}
+		return hits[i].Expense.Id().String() < hits[j].Expense.Id().String()
+	})
+
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+func rankText(expense *models.Expense, terms []string) float64 {
+	descriptionWords := splitWords(expense.Description())
+	expenseTypeWords := splitWords(expense.ExpenseType().Name())
+
+	rank := 0.0
+	for _, term := range terms {
+		if hasWordWithPrefix(descriptionWords, term) {
+			rank += descriptionMatchWeight
+		}
+		if hasWordWithPrefix(expenseTypeWords, term) {
+			rank += expenseTypeMatchWeight
+		}
+	}
+	return rank / float64(len(terms))
+}
+
+func highlightSnippet(expense *models.Expense, terms []string) string {
+	text := expense.Description()
+	if text == "" {
+		text = expense.ExpenseType().Name()
+	}
+
+	return wordRegexp.ReplaceAllStringFunc(text, func(word string) string {
+		if startsWithAnyTerm(strings.ToLower(word), terms) {
+			return SnippetStartSel + word + SnippetStopSel
+		}
+		return word
+	})
+}
+
+func hasWordWithPrefix(words []string, prefix string) bool {
+	for _, word := range words {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func startsWithAnyTerm(word string, terms []string) bool {
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package expense
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// maxTextSearchTerms bounds the words of a text search, the rest of them are ignored.
+const maxTextSearchTerms = 10
+
+var wordRegexp = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+type TextSearchCommand struct {
+	terms []string
+	limit int
+}
+
+// NewTextSearchCommand splits the query into lowercase words, dropping punctuation and repeated words. The expenses
+// match when any word starts any word of their description or of the name of their type.
+func NewTextSearchCommand(query string) (*TextSearchCommand, error) {
+	terms := splitWords(query)
+	if len(terms) == 0 {
+		return nil, errors.New("invalid command")
+	}
+
+	if len(terms) > maxTextSearchTerms {
+		terms = terms[:maxTextSearchTerms]
+	}
+
+	return &TextSearchCommand{terms: terms, limit: DefaultPageSize}, nil
+}
+
+// WithLimit returns a copy of the command that asks for at most limit expenses, lowered to MaxPageSize.
+func (t TextSearchCommand) WithLimit(limit int) (*TextSearchCommand, error) {
+	if limit < 1 {
+		return nil, errors.New("invalid command")
+	}
+
+	if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+
+	t.limit = limit
+	return &t, nil
+}
+
+func (t TextSearchCommand) Terms() []string {
+	return t.terms
+}
+
+func (t TextSearchCommand) Limit() int {
+	return t.limit
+}
+
+func splitWords(text string) []string {
+	uniqueWords := map[string]bool{}
+	words := []string{}
+	for _, word := range wordRegexp.FindAllString(strings.ToLower(text), -1) {
+		if !uniqueWords[word] {
+			uniqueWords[word] = true
+			words = append(words, word)
+		}
+	}
+	return words
+}
@@ -0,0 +1,63 @@
+package expense
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// UpdateCommand carries the new values of an expense. Empty or zero values (and a nil description) mean that the
+// stored value must be kept, so the same command works for full (PUT) and partial (PATCH) updates. An amount without
+// a currency is in the stored currency, and a currency without an amount applies to the stored amount.
+type UpdateCommand struct {
+	id            uuid.UUID
+	amount        string
+	currency      string
+	expenseDate   time.Time
+	description   *string
+	expenseTypeId uuid.UUID
+	accountId     uuid.UUID
+	split         *SplitCommand
+	tags          []string
+}
+
+func NewUpdateCommand(id uuid.UUID, amount string, currency string, expenseDate time.Time, description *string, expenseTypeId uuid.UUID, accountId uuid.UUID) (*UpdateCommand, error) {
+	if id == uuid.Nil || (currency != "" && !validCurrencyCodes[currency]) {
+		return nil, errors.New("invalid command")
+	}
+
+	// Without a currency the decimal places of the amount are checked against the stored one, when the expense is
+	// updated.
+	if amount != "" && currency != "" && !isPositiveAmount(amount, currency) {
+		return nil, errors.New("invalid command")
+	}
+
+	if description != nil {
+		trimmedDescription := strings.TrimSpace(*description)
+		description = &trimmedDescription
+	}
+
+	return &UpdateCommand{id: id, amount: amount, currency: currency, expenseDate: expenseDate, description: description, expenseTypeId: expenseTypeId, accountId: accountId}, nil
+}
+
+// WithSplit returns a copy of the command that also splits the expense again. Without it, a stored split is kept and
+// reallocated to the new amount.
+func (u UpdateCommand) WithSplit(split *SplitCommand) *UpdateCommand {
+	u.split = split
+	return &u
+}
+
+// WithTags returns a copy of the command that replaces the tags of the expense, an empty list removes them all.
+// Without it, the stored tags are kept.
+func (u UpdateCommand) WithTags(tags []string) *UpdateCommand {
+	if tags == nil {
+		tags = []string{}
+	}
+	u.tags = tags
+	return &u
+}
+
+func (u UpdateCommand) Id() uuid.UUID {
+	return u.id
+}
@@ -3,10 +3,12 @@ package expensetype
 import (
 	"errors"
 	"finfit-backend/pkg"
+	"github.com/google/uuid"
 )
 
 type AddCommand struct {
-	name string
+	name     string
+	parentId uuid.UUID
 }
 
 func NewAddCommand(name string) (*AddCommand, error) {
@@ -15,3 +17,9 @@ func NewAddCommand(name string) (*AddCommand, error) {
 	}
 	return &AddCommand{name: name}, nil
 }
+
+// WithParent returns a copy of the command that nests the expense type under the one with parentId.
+func (a AddCommand) WithParent(parentId uuid.UUID) *AddCommand {
+	a.parentId = parentId
+	return &a
+}
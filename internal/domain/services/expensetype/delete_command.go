@@ -0,0 +1,20 @@
+package expensetype
+
+import (
+	"errors"
+	"github.com/google/uuid"
+)
+
+// DeleteCommand removes an expense type. When reassignTo is set, the expenses that reference the deleted type are
+// moved to that type first; otherwise the deletion is refused while any expense still references it.
+type DeleteCommand struct {
+	id         uuid.UUID
+	reassignTo uuid.UUID
+}
+
+func NewDeleteCommand(id uuid.UUID, reassignTo uuid.UUID) (*DeleteCommand, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("invalid command")
+	}
+	return &DeleteCommand{id: id, reassignTo: reassignTo}, nil
+}
@@ -1,6 +1,7 @@
 package expensetype
 
 import (
+	"context"
 	"finfit-backend/internal/domain/models"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -14,8 +15,8 @@ func NewRepositoryMock() *RepositoryMock {
 	return &RepositoryMock{}
 }
 
-func (r *RepositoryMock) GetByID(id uuid.UUID) (*models.ExpenseType, error) {
-	args := r.Called(id)
+func (r *RepositoryMock) GetByID(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (*models.ExpenseType, error) {
+	args := r.Called(ctx, ledgerId, id)
 
 	err := args.Error(1)
 	expenseType := args.Get(0)
@@ -28,8 +29,8 @@ func (r *RepositoryMock) GetByID(id uuid.UUID) (*models.ExpenseType, error) {
 	}
 }
 
-func (r *RepositoryMock) GetByName(name string) (*models.ExpenseType, error) {
-	args := r.Called(name)
+func (r *RepositoryMock) GetByName(ctx context.Context, ledgerId uuid.UUID, parentId uuid.UUID, name string) (*models.ExpenseType, error) {
+	args := r.Called(ctx, ledgerId, parentId, name)
 
 	err := args.Error(1)
 	expenseType := args.Get(0)
@@ -42,8 +43,8 @@ func (r *RepositoryMock) GetByName(name string) (*models.ExpenseType, error) {
 	}
 }
 
-func (r *RepositoryMock) GetAll() ([]*models.ExpenseType, error) {
-	args := r.Called()
+func (r *RepositoryMock) GetAll(ctx context.Context, ledgerId uuid.UUID) ([]*models.ExpenseType, error) {
+	args := r.Called(ctx, ledgerId)
 
 	err := args.Error(1)
 	expenseType := args.Get(0)
@@ -56,8 +57,8 @@ func (r *RepositoryMock) GetAll() ([]*models.ExpenseType, error) {
 	}
 }
 
-func (r *RepositoryMock) Add(expenseType *models.ExpenseType) (*models.ExpenseType, error) {
-	args := r.Called(expenseType)
+func (r *RepositoryMock) Add(ctx context.Context, ledgerId uuid.UUID, expenseType *models.ExpenseType) (*models.ExpenseType, error) {
+	args := r.Called(ctx, ledgerId, expenseType)
 
 	savedExpense := args.Get(0)
 	err := args.Error(1)
@@ -70,18 +71,87 @@ func (r *RepositoryMock) Add(expenseType *models.ExpenseType) (*models.ExpenseTy
 	}
 }
 
+func (r *RepositoryMock) Update(ctx context.Context, ledgerId uuid.UUID, expenseType *models.ExpenseType) (*models.ExpenseType, error) {
+	args := r.Called(ctx, ledgerId, expenseType)
+
+	updatedExpenseType := args.Get(0)
+	err := args.Error(1)
+	if err == nil && updatedExpenseType == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return args.Get(0).(*models.ExpenseType), nil
+	}
+}
+
+func (r *RepositoryMock) Delete(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) error {
+	args := r.Called(ctx, ledgerId, id)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) HasSubtypes(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (bool, error) {
+	args := r.Called(ctx, ledgerId, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *RepositoryMock) LockAll(ctx context.Context, ledgerId uuid.UUID) error {
+	args := r.Called(ctx, ledgerId)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) IsReferencedByExpenses(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (bool, error) {
+	args := r.Called(ctx, ledgerId, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *RepositoryMock) ReassignExpenses(ctx context.Context, ledgerId uuid.UUID, fromId uuid.UUID, toId uuid.UUID) error {
+	args := r.Called(ctx, ledgerId, fromId, toId)
+	return args.Error(0)
+}
+
 func (r *RepositoryMock) MockGetByID(callArguments, returnArguments []interface{}, times int) {
-	r.On("GetByID", callArguments...).Return(returnArguments...).Times(times)
+	r.On("GetByID", anyContext(callArguments)...).Return(returnArguments...).Times(times)
 }
 
 func (r *RepositoryMock) MockGetByName(callArguments, returnArguments []interface{}, times int) {
-	r.On("GetByName", callArguments...).Return(returnArguments...).Times(times)
+	r.On("GetByName", anyContext(callArguments)...).Return(returnArguments...).Times(times)
 }
 
 func (r *RepositoryMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
-	r.On("Add", callArguments...).Return(returnArguments...).Times(times)
+	r.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
 }
 
 func (r *RepositoryMock) MockGetAll(callArguments, returnArguments []interface{}, times int) {
-	r.On("GetAll", callArguments...).Return(returnArguments...).Times(times)
+	r.On("GetAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockUpdate(callArguments, returnArguments []interface{}, times int) {
+	r.On("Update", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockDelete(callArguments, returnArguments []interface{}, times int) {
+	r.On("Delete", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockHasSubtypes(callArguments, returnArguments []interface{}, times int) {
+	r.On("HasSubtypes", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockLockAll(callArguments, returnArguments []interface{}, times int) {
+	r.On("LockAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockIsReferencedByExpenses(callArguments, returnArguments []interface{}, times int) {
+	r.On("IsReferencedByExpenses", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockReassignExpenses(callArguments, returnArguments []interface{}, times int) {
+	r.On("ReassignExpenses", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+// anyContext matches the context the mocked methods receive first, so the expectations only list the rest of the
+// arguments.
+func anyContext(callArguments []interface{}) []interface{} {
+	return append([]interface{}{mock.Anything}, callArguments...)
 }
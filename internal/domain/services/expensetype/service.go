@@ -1,32 +1,70 @@
 package expensetype
 
 import (
+	"context"
+	"errors"
 	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/ledger"
+	"finfit-backend/internal/domain/services/unitofwork"
 	"github.com/google/uuid"
 )
 
+const (
+	expenseTypeNotFoundErrorMsg      = "the expense type doesn't exists"
+	expenseTypeAlreadyExistsErrorMsg = "an expense type with the same name and parent already exists"
+	expenseTypeInUseErrorMsg         = "the expense type is referenced by expenses, reassign them to another type before deleting it"
+	expenseTypeHasSubtypesErrorMsg   = "the expense type has subtypes, move or delete them before deleting it"
+	invalidReassignTypeErrorMsg      = "the expense type to reassign expenses to doesn't exists"
+	reassignToItselfErrorMsg         = "the expenses can't be reassigned to the expense type being deleted"
+	invalidParentErrorMsg            = "the parent expense type doesn't exists"
+)
+
+// ErrExpenseTypeReferenced is returned by Repository.Delete when expenses still reference the expense type.
+var ErrExpenseTypeReferenced = errors.New("the expense type is referenced by expenses")
+
 type Repository interface {
-	GetByID(id uuid.UUID) (*models.ExpenseType, error)
-	GetByName(name string) (*models.ExpenseType, error)
-	GetAll() ([]*models.ExpenseType, error)
-	Add(expense *models.ExpenseType) (*models.ExpenseType, error)
+	GetByID(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (*models.ExpenseType, error)
+	// GetByName looks for the expense type among the children of parentId, or at the top level when it is uuid.Nil.
+	GetByName(ctx context.Context, ledgerId uuid.UUID, parentId uuid.UUID, name string) (*models.ExpenseType, error)
+	GetAll(ctx context.Context, ledgerId uuid.UUID) ([]*models.ExpenseType, error)
+	Add(ctx context.Context, ledgerId uuid.UUID, expense *models.ExpenseType) (*models.ExpenseType, error)
+	Update(ctx context.Context, ledgerId uuid.UUID, expenseType *models.ExpenseType) (*models.ExpenseType, error)
+	Delete(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) error
+	HasSubtypes(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (bool, error)
+	IsReferencedByExpenses(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (bool, error)
+	ReassignExpenses(ctx context.Context, ledgerId uuid.UUID, fromId uuid.UUID, toId uuid.UUID) error
+	// LockAll keeps the other units of work from changing the expense types of the ledger until the one it runs in
+	// ends, so a move checked against their hierarchy can't race another one.
+	LockAll(ctx context.Context, ledgerId uuid.UUID) error
 }
+
+// Service works on the expense types of a ledger. Every method checks the role of the user in the ledger first and
+// returns the errors of the ledger service when it isn't enough.
 type Service interface {
-	GetById(id uuid.UUID) (*models.ExpenseType, error)
-	Add(command *AddCommand) (*models.ExpenseType, error)
-	GetAll() ([]*models.ExpenseType, error)
+	GetById(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, id uuid.UUID) (*models.ExpenseType, error)
+	Add(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *AddCommand) (*models.ExpenseType, error)
+	GetAll(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID) ([]*models.ExpenseType, error)
+	Update(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *UpdateCommand) (*models.ExpenseType, error)
+	Delete(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *DeleteCommand) error
+	GetSubtree(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, id uuid.UUID) ([]*models.ExpenseType, error)
 }
 
 type service struct {
-	repo Repository
+	repo          Repository
+	unitOfWork    unitofwork.UnitOfWork[Repository]
+	ledgerService ledger.Service
 }
 
-func NewService(repo Repository) *service {
-	return &service{repo: repo}
+func NewService(repo Repository, unitOfWork unitofwork.UnitOfWork[Repository], ledgerService ledger.Service) *service {
+	return &service{repo: repo, unitOfWork: unitOfWork, ledgerService: ledgerService}
 }
 
-func (s service) GetById(id uuid.UUID) (*models.ExpenseType, error) {
-	expenseType, err := s.repo.GetByID(id)
+func (s service) GetById(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, id uuid.UUID) (*models.ExpenseType, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.ViewerLedgerRole); err != nil {
+		return nil, err
+	}
+
+	expenseType, err := s.repo.GetByID(ctx, ledgerId, id)
 	if err != nil {
 		return nil, UnexpectedError{Msg: err.Error()}
 	}
@@ -34,8 +72,17 @@ func (s service) GetById(id uuid.UUID) (*models.ExpenseType, error) {
 	return expenseType, nil
 }
 
-func (s service) Add(command *AddCommand) (*models.ExpenseType, error) {
-	storedExpenseType, err := s.repo.GetByName(command.name)
+func (s service) Add(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *AddCommand) (*models.ExpenseType, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.EditorLedgerRole); err != nil {
+		return nil, err
+	}
+
+	parent, err := getParent(ctx, s.repo, ledgerId, command.parentId)
+	if err != nil {
+		return nil, err
+	}
+
+	storedExpenseType, err := s.repo.GetByName(ctx, ledgerId, command.parentId, command.name)
 
 	if err != nil {
 		return nil, UnexpectedError{Msg: err.Error()}
@@ -45,12 +92,12 @@ func (s service) Add(command *AddCommand) (*models.ExpenseType, error) {
 		return storedExpenseType, nil
 	}
 
-	expenseTypeToAdd, err := mapExpenseTypeFromAddCommand(command)
+	expenseTypeToAdd, err := mapExpenseTypeFromAddCommand(command, parent)
 	if err != nil {
 		return nil, InvalidDomainModelError{Msg: err.Error()}
 	}
 
-	addedExpenseType, err := s.repo.Add(expenseTypeToAdd)
+	addedExpenseType, err := s.repo.Add(ctx, ledgerId, expenseTypeToAdd)
 	if err != nil {
 		return nil, UnexpectedError{Msg: err.Error()}
 	}
@@ -58,8 +105,12 @@ func (s service) Add(command *AddCommand) (*models.ExpenseType, error) {
 	return addedExpenseType, nil
 }
 
-func (s service) GetAll() ([]*models.ExpenseType, error) {
-	expenseTypes, err := s.repo.GetAll()
+func (s service) GetAll(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID) ([]*models.ExpenseType, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.ViewerLedgerRole); err != nil {
+		return nil, err
+	}
+
+	expenseTypes, err := s.repo.GetAll(ctx, ledgerId)
 	if err != nil {
 		return nil, UnexpectedError{Msg: err.Error()}
 	}
@@ -67,8 +118,196 @@ func (s service) GetAll() ([]*models.ExpenseType, error) {
 	return expenseTypes, nil
 }
 
-func mapExpenseTypeFromAddCommand(command *AddCommand) (*models.ExpenseType, error) {
-	return models.NewExpenseType(command.name)
+func (s service) Update(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *UpdateCommand) (*models.ExpenseType, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.EditorLedgerRole); err != nil {
+		return nil, err
+	}
+
+	var updatedExpenseType *models.ExpenseType
+	err := s.unitOfWork.Do(ctx, func(repo Repository) error {
+		// the hierarchy stays locked from the cycle check to the update, so two moves can't nest each other
+		if err := repo.LockAll(ctx, ledgerId); err != nil {
+			return UnexpectedError{Msg: err.Error()}
+		}
+
+		storedExpenseType, err := repo.GetByID(ctx, ledgerId, command.id)
+		if err != nil {
+			return UnexpectedError{Msg: err.Error()}
+		}
+
+		if storedExpenseType == nil {
+			return ExpenseTypeNotFoundError{Msg: expenseTypeNotFoundErrorMsg}
+		}
+
+		parent, err := getParent(ctx, repo, ledgerId, command.parentId)
+		if err != nil {
+			return err
+		}
+
+		expenseTypeWithSameName, err := repo.GetByName(ctx, ledgerId, command.parentId, command.name)
+		if err != nil {
+			return UnexpectedError{Msg: err.Error()}
+		}
+
+		if expenseTypeWithSameName != nil && expenseTypeWithSameName.Id() != command.id {
+			return ExpenseTypeAlreadyExistsError{Msg: expenseTypeAlreadyExistsErrorMsg}
+		}
+
+		expenseTypeToUpdate, err := models.NewExpenseTypeWithId(command.id, command.name)
+		if err != nil {
+			return InvalidDomainModelError{Msg: err.Error()}
+		}
+
+		// the parent is read with all its ancestors, so moving the expense type under one of its subtypes fails here
+		expenseTypeToUpdate, err = expenseTypeToUpdate.WithParent(parent)
+		if err != nil {
+			return InvalidParentExpenseTypeError{Msg: err.Error()}
+		}
+
+		if updatedExpenseType, err = repo.Update(ctx, ledgerId, expenseTypeToUpdate); err != nil {
+			return UnexpectedError{Msg: err.Error()}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedExpenseType, nil
+}
+
+func (s service) Delete(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *DeleteCommand) error {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.EditorLedgerRole); err != nil {
+		return err
+	}
+
+	// the expenses are reassigned or checked in the same transaction as the delete, so none can be added in between
+	return s.unitOfWork.Do(ctx, func(repo Repository) error {
+		storedExpenseType, err := repo.GetByID(ctx, ledgerId, command.id)
+		if err != nil {
+			return UnexpectedError{Msg: err.Error()}
+		}
+
+		if storedExpenseType == nil {
+			return ExpenseTypeNotFoundError{Msg: expenseTypeNotFoundErrorMsg}
+		}
+
+		hasSubtypes, err := repo.HasSubtypes(ctx, ledgerId, command.id)
+		if err != nil {
+			return UnexpectedError{Msg: err.Error()}
+		}
+
+		if hasSubtypes {
+			return ExpenseTypeHasSubtypesError{Msg: expenseTypeHasSubtypesErrorMsg}
+		}
+
+		if command.reassignTo != uuid.Nil {
+			err = reassignExpenses(ctx, repo, ledgerId, command.id, command.reassignTo)
+		} else {
+			err = checkIfIsNotReferencedByExpenses(ctx, repo, ledgerId, command.id)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		// an expense added meanwhile by a transaction that doesn't wait for this one still fails the delete
+		if err = repo.Delete(ctx, ledgerId, command.id); errors.Is(err, ErrExpenseTypeReferenced) {
+			return ExpenseTypeInUseError{Msg: expenseTypeInUseErrorMsg}
+		} else if err != nil {
+			return UnexpectedError{Msg: err.Error()}
+		}
+
+		return nil
+	})
+}
+
+// GetSubtree returns the expense type with the given id and all its descendants, so expenses of a type can be rolled
+// up with the ones of its subtypes.
+func (s service) GetSubtree(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, id uuid.UUID) ([]*models.ExpenseType, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.ViewerLedgerRole); err != nil {
+		return nil, err
+	}
+
+	expenseTypes, err := s.repo.GetAll(ctx, ledgerId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	subtree := []*models.ExpenseType{}
+	for _, expenseType := range expenseTypes {
+		if expenseType.IsInSubtreeOf(id) {
+			subtree = append(subtree, expenseType)
+		}
+	}
+
+	if len(subtree) == 0 {
+		return nil, ExpenseTypeNotFoundError{Msg: expenseTypeNotFoundErrorMsg}
+	}
+
+	return subtree, nil
+}
+
+// getParent returns nil for the expense types at the top level.
+func getParent(ctx context.Context, repo Repository, ledgerId uuid.UUID, parentId uuid.UUID) (*models.ExpenseType, error) {
+	if parentId == uuid.Nil {
+		return nil, nil
+	}
+
+	parent, err := repo.GetByID(ctx, ledgerId, parentId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	if parent == nil {
+		return nil, InvalidParentExpenseTypeError{Msg: invalidParentErrorMsg}
+	}
+
+	return parent, nil
+}
+
+func reassignExpenses(ctx context.Context, repo Repository, ledgerId uuid.UUID, fromId uuid.UUID, toId uuid.UUID) error {
+	if toId == fromId {
+		return InvalidReassignExpenseTypeError{Msg: reassignToItselfErrorMsg}
+	}
+
+	targetExpenseType, err := repo.GetByID(ctx, ledgerId, toId)
+	if err != nil {
+		return UnexpectedError{Msg: err.Error()}
+	}
+
+	if targetExpenseType == nil {
+		return InvalidReassignExpenseTypeError{Msg: invalidReassignTypeErrorMsg}
+	}
+
+	if err = repo.ReassignExpenses(ctx, ledgerId, fromId, toId); err != nil {
+		return UnexpectedError{Msg: err.Error()}
+	}
+
+	return nil
+}
+
+func checkIfIsNotReferencedByExpenses(ctx context.Context, repo Repository, ledgerId uuid.UUID, id uuid.UUID) error {
+	isReferenced, err := repo.IsReferencedByExpenses(ctx, ledgerId, id)
+	if err != nil {
+		return UnexpectedError{Msg: err.Error()}
+	}
+
+	if isReferenced {
+		return ExpenseTypeInUseError{Msg: expenseTypeInUseErrorMsg}
+	}
+
+	return nil
+}
+
+func mapExpenseTypeFromAddCommand(command *AddCommand, parent *models.ExpenseType) (*models.ExpenseType, error) {
+	expenseType, err := models.NewExpenseType(command.name)
+	if err != nil {
+		return nil, err
+	}
+
+	return expenseType.WithParent(parent)
 }
 
 type UnexpectedError struct {
@@ -86,3 +325,51 @@ type InvalidDomainModelError struct {
 func (receiver InvalidDomainModelError) Error() string {
 	return receiver.Msg
 }
+
+type ExpenseTypeNotFoundError struct {
+	Msg string
+}
+
+func (receiver ExpenseTypeNotFoundError) Error() string {
+	return receiver.Msg
+}
+
+type ExpenseTypeAlreadyExistsError struct {
+	Msg string
+}
+
+func (receiver ExpenseTypeAlreadyExistsError) Error() string {
+	return receiver.Msg
+}
+
+type ExpenseTypeInUseError struct {
+	Msg string
+}
+
+func (receiver ExpenseTypeInUseError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidReassignExpenseTypeError struct {
+	Msg string
+}
+
+func (receiver InvalidReassignExpenseTypeError) Error() string {
+	return receiver.Msg
+}
+
+type ExpenseTypeHasSubtypesError struct {
+	Msg string
+}
+
+func (receiver ExpenseTypeHasSubtypesError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidParentExpenseTypeError struct {
+	Msg string
+}
+
+func (receiver InvalidParentExpenseTypeError) Error() string {
+	return receiver.Msg
+}
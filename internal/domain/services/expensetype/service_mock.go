@@ -1,6 +1,7 @@
 package expensetype
 
 import (
+	"context"
 	"finfit-backend/internal/domain/models"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -14,8 +15,8 @@ func NewServiceMock() *ServiceMock {
 	return &ServiceMock{}
 }
 
-func (s *ServiceMock) GetById(id uuid.UUID) (*models.ExpenseType, error) {
-	args := s.Called(id)
+func (s *ServiceMock) GetById(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, id uuid.UUID) (*models.ExpenseType, error) {
+	args := s.Called(ctx, userId, ledgerId, id)
 
 	err := args.Error(1)
 	expenseType := args.Get(0)
@@ -28,8 +29,8 @@ func (s *ServiceMock) GetById(id uuid.UUID) (*models.ExpenseType, error) {
 	}
 }
 
-func (s *ServiceMock) Add(command *AddCommand) (*models.ExpenseType, error) {
-	args := s.Called(command)
+func (s *ServiceMock) Add(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *AddCommand) (*models.ExpenseType, error) {
+	args := s.Called(ctx, userId, ledgerId, command)
 
 	err := args.Error(1)
 	expenseTypeToReturn := args.Get(0)
@@ -42,8 +43,8 @@ func (s *ServiceMock) Add(command *AddCommand) (*models.ExpenseType, error) {
 	}
 }
 
-func (s *ServiceMock) GetAll() ([]*models.ExpenseType, error) {
-	args := s.Called()
+func (s *ServiceMock) GetAll(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID) ([]*models.ExpenseType, error) {
+	args := s.Called(ctx, userId, ledgerId)
 
 	err := args.Error(1)
 	expenseType := args.Get(0)
@@ -56,14 +57,59 @@ func (s *ServiceMock) GetAll() ([]*models.ExpenseType, error) {
 	}
 }
 
+func (s *ServiceMock) Update(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *UpdateCommand) (*models.ExpenseType, error) {
+	args := s.Called(ctx, userId, ledgerId, command)
+
+	err := args.Error(1)
+	expenseTypeToReturn := args.Get(0)
+	if err == nil && expenseTypeToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return expenseTypeToReturn.(*models.ExpenseType), nil
+	}
+}
+
+func (s *ServiceMock) Delete(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *DeleteCommand) error {
+	args := s.Called(ctx, userId, ledgerId, command)
+	return args.Error(0)
+}
+
+func (s *ServiceMock) GetSubtree(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, id uuid.UUID) ([]*models.ExpenseType, error) {
+	args := s.Called(ctx, userId, ledgerId, id)
+
+	err := args.Error(1)
+	expenseTypes := args.Get(0)
+	if err == nil && expenseTypes == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return expenseTypes.([]*models.ExpenseType), nil
+	}
+}
+
 func (s *ServiceMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
-	s.On("Add", callArguments...).Return(returnArguments...).Times(times)
+	s.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
 }
 
 func (s *ServiceMock) MockGetByID(callArguments, returnArguments []interface{}, times int) {
-	s.On("GetById", callArguments...).Return(returnArguments...).Times(times)
+	s.On("GetById", anyContext(callArguments)...).Return(returnArguments...).Times(times)
 }
 
 func (s *ServiceMock) MockGetAll(callArguments, returnArguments []interface{}, times int) {
-	s.On("GetAll", callArguments...).Return(returnArguments...).Times(times)
+	s.On("GetAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockUpdate(callArguments, returnArguments []interface{}, times int) {
+	s.On("Update", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockDelete(callArguments, returnArguments []interface{}, times int) {
+	s.On("Delete", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGetSubtree(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetSubtree", anyContext(callArguments)...).Return(returnArguments...).Times(times)
 }
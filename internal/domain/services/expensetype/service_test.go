@@ -1,12 +1,16 @@
 package expensetype_test
 
 import (
+	"context"
 	"errors"
 	"finfit-backend/internal/domain/models"
 	"finfit-backend/internal/domain/services/expensetype"
+	"finfit-backend/internal/domain/services/ledger"
+	"finfit-backend/internal/domain/services/unitofwork"
 	"finfit-backend/pkg"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"testing"
@@ -14,16 +18,26 @@ import (
 
 type ServiceTestSuite struct {
 	suite.Suite
-	repositoryMock *expensetype.RepositoryMock
-	service        expensetype.Service
+	userId            uuid.UUID
+	ledgerId          uuid.UUID
+	repositoryMock    *expensetype.RepositoryMock
+	ledgerServiceMock *ledger.ServiceMock
+	service           expensetype.Service
 }
 
 func (suite *ServiceTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.ledgerId = uuid.New()
 	suite.repositoryMock = expensetype.NewRepositoryMock()
-	suite.service = expensetype.NewService(suite.repositoryMock)
+	suite.ledgerServiceMock = ledger.NewServiceMock()
+	suite.service = expensetype.NewService(suite.repositoryMock, unitofwork.WithoutTransaction[expensetype.Repository](suite.repositoryMock), suite.ledgerServiceMock)
 	suite.patchUUIDFunction()
 }
 
+func (suite *ServiceTestSuite) SetupTest() {
+	suite.ledgerServiceMock.MockAuthorize([]interface{}{suite.userId, suite.ledgerId, mock.Anything}, []interface{}{nil}, 0)
+}
+
 func (suite *ServiceTestSuite) patchUUIDFunction() {
 	id := uuid.New()
 	pkg.NewUUID = func() uuid.UUID {
@@ -33,6 +47,9 @@ func (suite *ServiceTestSuite) patchUUIDFunction() {
 
 func (suite *ServiceTestSuite) TearDownTest() {
 	suite.repositoryMock.ExpectedCalls = nil
+	suite.repositoryMock.Calls = nil
+	suite.ledgerServiceMock.ExpectedCalls = nil
+	suite.ledgerServiceMock.Calls = nil
 }
 
 func (suite *ServiceTestSuite) TearDownSuite() {
@@ -45,9 +62,9 @@ func TestServiceTestSuite(t *testing.T) {
 
 func (suite *ServiceTestSuite) TestGivenAnID_whenGetById_thenReturnExpenseType() {
 	expectedExpenseType, _ := models.NewExpenseType("Servicios")
-	suite.repositoryMock.MockGetByID([]interface{}{expectedExpenseType.Id()}, []interface{}{expectedExpenseType, nil}, 1)
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, expectedExpenseType.Id()}, []interface{}{expectedExpenseType, nil}, 1)
 
-	actualExpenseType, err := suite.service.GetById(expectedExpenseType.Id())
+	actualExpenseType, err := suite.service.GetById(context.Background(), suite.userId, suite.ledgerId, expectedExpenseType.Id())
 
 	require.NoError(suite.T(), err)
 	suite.assertEqualsExpenseType(expectedExpenseType, actualExpenseType)
@@ -55,9 +72,9 @@ func (suite *ServiceTestSuite) TestGivenAnID_whenGetById_thenReturnExpenseType()
 
 func (suite *ServiceTestSuite) TestGivenThatRepositoryFails_whenGetById_thenReturnError() {
 	id := uuid.New()
-	suite.repositoryMock.MockGetByID([]interface{}{id}, []interface{}{nil, errors.New("fail")}, 1)
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, id}, []interface{}{nil, errors.New("fail")}, 1)
 
-	actualExpenseType, err := suite.service.GetById(id)
+	actualExpenseType, err := suite.service.GetById(context.Background(), suite.userId, suite.ledgerId, id)
 
 	require.ErrorAs(suite.T(), err, &expensetype.UnexpectedError{})
 	require.Nil(suite.T(), actualExpenseType)
@@ -65,10 +82,10 @@ func (suite *ServiceTestSuite) TestGivenThatRepositoryFails_whenGetById_thenRetu
 
 func (suite *ServiceTestSuite) TestGivenAnExpenseTypeToAddAndExpenseTypeNotExists_whenAdd_thenReturnAddedExpenseType() {
 	expectedExpenseType, _ := models.NewExpenseType("Servicios")
-	suite.repositoryMock.MockGetByName([]interface{}{expectedExpenseType.Name()}, []interface{}{nil, nil}, 1)
-	suite.repositoryMock.MockAdd([]interface{}{expectedExpenseType}, []interface{}{expectedExpenseType, nil}, 1)
+	suite.repositoryMock.MockGetByName([]interface{}{suite.ledgerId, uuid.Nil, expectedExpenseType.Name()}, []interface{}{nil, nil}, 1)
+	suite.repositoryMock.MockAdd([]interface{}{suite.ledgerId, expectedExpenseType}, []interface{}{expectedExpenseType, nil}, 1)
 
-	addedExpenseType, err := suite.service.Add(suite.buildAddCommandFromExpenseType(expectedExpenseType))
+	addedExpenseType, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, suite.buildAddCommandFromExpenseType(expectedExpenseType))
 
 	require.NoError(suite.T(), err)
 	suite.assertEqualsExpenseType(expectedExpenseType, addedExpenseType)
@@ -77,9 +94,9 @@ func (suite *ServiceTestSuite) TestGivenAnExpenseTypeToAddAndExpenseTypeNotExist
 
 func (suite *ServiceTestSuite) TestGivenThatExpenseTypeAlreadyExists_whenAdd_thenReturnAddedExpenseType() {
 	expectedExpenseType, _ := models.NewExpenseType("Servicios")
-	suite.repositoryMock.MockGetByName([]interface{}{expectedExpenseType.Name()}, []interface{}{expectedExpenseType, nil}, 1)
+	suite.repositoryMock.MockGetByName([]interface{}{suite.ledgerId, uuid.Nil, expectedExpenseType.Name()}, []interface{}{expectedExpenseType, nil}, 1)
 
-	addedExpenseType, err := suite.service.Add(suite.buildAddCommandFromExpenseType(expectedExpenseType))
+	addedExpenseType, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, suite.buildAddCommandFromExpenseType(expectedExpenseType))
 
 	require.NoError(suite.T(), err)
 	suite.assertEqualsExpenseType(expectedExpenseType, addedExpenseType)
@@ -88,9 +105,9 @@ func (suite *ServiceTestSuite) TestGivenThatExpenseTypeAlreadyExists_whenAdd_the
 
 func (suite *ServiceTestSuite) TestGivenThatRepositoryFailsGivingExpenseTypeByName_whenAdd_thenReturnError() {
 	expectedExpenseType, _ := models.NewExpenseType("Servicios")
-	suite.repositoryMock.MockGetByName([]interface{}{expectedExpenseType.Name()}, []interface{}{nil, errors.New("fail")}, 1)
+	suite.repositoryMock.MockGetByName([]interface{}{suite.ledgerId, uuid.Nil, expectedExpenseType.Name()}, []interface{}{nil, errors.New("fail")}, 1)
 
-	_, err := suite.service.Add(suite.buildAddCommandFromExpenseType(expectedExpenseType))
+	_, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, suite.buildAddCommandFromExpenseType(expectedExpenseType))
 
 	require.ErrorAs(suite.T(), err, &expensetype.UnexpectedError{})
 	suite.repositoryMock.AssertExpectations(suite.T())
@@ -98,10 +115,10 @@ func (suite *ServiceTestSuite) TestGivenThatRepositoryFailsGivingExpenseTypeByNa
 
 func (suite *ServiceTestSuite) TestGivenThatRepositoryFailsAddingExpenseType_whenAdd_thenReturnError() {
 	expectedExpenseType, _ := models.NewExpenseType("Servicios")
-	suite.repositoryMock.MockGetByName([]interface{}{expectedExpenseType.Name()}, []interface{}{nil, nil}, 1)
-	suite.repositoryMock.MockAdd([]interface{}{expectedExpenseType}, []interface{}{nil, errors.New("fail")}, 1)
+	suite.repositoryMock.MockGetByName([]interface{}{suite.ledgerId, uuid.Nil, expectedExpenseType.Name()}, []interface{}{nil, nil}, 1)
+	suite.repositoryMock.MockAdd([]interface{}{suite.ledgerId, expectedExpenseType}, []interface{}{nil, errors.New("fail")}, 1)
 
-	_, err := suite.service.Add(suite.buildAddCommandFromExpenseType(expectedExpenseType))
+	_, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, suite.buildAddCommandFromExpenseType(expectedExpenseType))
 
 	require.ErrorAs(suite.T(), err, &expensetype.UnexpectedError{})
 	suite.repositoryMock.AssertExpectations(suite.T())
@@ -109,9 +126,9 @@ func (suite *ServiceTestSuite) TestGivenThatRepositoryFailsAddingExpenseType_whe
 
 func (suite *ServiceTestSuite) TestGetAll_Success() {
 	expectedExpenseTypes := suite.getExpenseTypes()
-	suite.repositoryMock.MockGetAll([]interface{}{}, []interface{}{expectedExpenseTypes, nil}, 1)
+	suite.repositoryMock.MockGetAll([]interface{}{suite.ledgerId}, []interface{}{expectedExpenseTypes, nil}, 1)
 
-	actualExpenseTypes, err := suite.service.GetAll()
+	actualExpenseTypes, err := suite.service.GetAll(context.Background(), suite.userId, suite.ledgerId)
 
 	assert.Nil(suite.T(), err)
 	assert.Len(suite.T(), actualExpenseTypes, 2)
@@ -136,15 +153,245 @@ func (suite *ServiceTestSuite) getExpenseType2() *models.ExpenseType {
 }
 
 func (suite *ServiceTestSuite) TestGivenThatRepositoryFails_whenGetAll_thenReturnError() {
-	suite.repositoryMock.MockGetAll([]interface{}{}, []interface{}{nil, errors.New("fail")}, 1)
+	suite.repositoryMock.MockGetAll([]interface{}{suite.ledgerId}, []interface{}{nil, errors.New("fail")}, 1)
 
-	expenseTypes, err := suite.service.GetAll()
+	expenseTypes, err := suite.service.GetAll(context.Background(), suite.userId, suite.ledgerId)
 
 	assert.NotNil(suite.T(), err)
 	assert.ErrorAs(suite.T(), err, &expensetype.UnexpectedError{})
 	assert.Nil(suite.T(), expenseTypes)
 }
 
+func (suite *ServiceTestSuite) TestGivenANewName_whenUpdate_thenReturnRenamedExpenseType() {
+	storedExpenseType := suite.getExpenseType1()
+	expectedExpenseType, _ := models.NewExpenseTypeWithId(storedExpenseType.Id(), "Groceries")
+	suite.repositoryMock.MockLockAll([]interface{}{suite.ledgerId}, []interface{}{nil}, 1)
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{storedExpenseType, nil}, 1)
+	suite.repositoryMock.MockGetByName([]interface{}{suite.ledgerId, uuid.Nil, expectedExpenseType.Name()}, []interface{}{nil, nil}, 1)
+	suite.repositoryMock.MockUpdate([]interface{}{suite.ledgerId, expectedExpenseType}, []interface{}{expectedExpenseType, nil}, 1)
+
+	command, _ := expensetype.NewUpdateCommand(storedExpenseType.Id(), expectedExpenseType.Name())
+	actualExpenseType, err := suite.service.Update(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	suite.assertEqualsExpenseType(expectedExpenseType, actualExpenseType)
+}
+
+func (suite *ServiceTestSuite) TestGivenThatExpenseTypeNotExists_whenUpdate_thenReturnNotFoundError() {
+	id := uuid.New()
+	suite.repositoryMock.MockLockAll([]interface{}{suite.ledgerId}, []interface{}{nil}, 1)
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, id}, []interface{}{nil, nil}, 1)
+
+	command, _ := expensetype.NewUpdateCommand(id, "Groceries")
+	actualExpenseType, err := suite.service.Update(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.ErrorAs(suite.T(), err, &expensetype.ExpenseTypeNotFoundError{})
+	require.Nil(suite.T(), actualExpenseType)
+}
+
+func (suite *ServiceTestSuite) TestGivenANameUsedByAnotherExpenseType_whenUpdate_thenReturnAlreadyExistsError() {
+	storedExpenseType := suite.getExpenseType1()
+	otherExpenseType, _ := models.NewExpenseTypeWithId(uuid.New(), "Travel")
+	suite.repositoryMock.MockLockAll([]interface{}{suite.ledgerId}, []interface{}{nil}, 1)
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{storedExpenseType, nil}, 1)
+	suite.repositoryMock.MockGetByName([]interface{}{suite.ledgerId, uuid.Nil, otherExpenseType.Name()}, []interface{}{otherExpenseType, nil}, 1)
+
+	command, _ := expensetype.NewUpdateCommand(storedExpenseType.Id(), otherExpenseType.Name())
+	actualExpenseType, err := suite.service.Update(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.ErrorAs(suite.T(), err, &expensetype.ExpenseTypeAlreadyExistsError{})
+	require.Nil(suite.T(), actualExpenseType)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "Update", mock.Anything, suite.ledgerId, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestGivenAnUnreferencedExpenseType_whenDelete_thenDeleteIt() {
+	storedExpenseType := suite.getExpenseType1()
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{storedExpenseType, nil}, 1)
+	suite.repositoryMock.MockHasSubtypes([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{false, nil}, 1)
+	suite.repositoryMock.MockIsReferencedByExpenses([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{false, nil}, 1)
+	suite.repositoryMock.MockDelete([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{nil}, 1)
+
+	command, _ := expensetype.NewDeleteCommand(storedExpenseType.Id(), uuid.Nil)
+	err := suite.service.Delete(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	suite.repositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestGivenAReferencedExpenseType_whenDelete_thenReturnInUseError() {
+	storedExpenseType := suite.getExpenseType1()
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{storedExpenseType, nil}, 1)
+	suite.repositoryMock.MockHasSubtypes([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{false, nil}, 1)
+	suite.repositoryMock.MockIsReferencedByExpenses([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{true, nil}, 1)
+
+	command, _ := expensetype.NewDeleteCommand(storedExpenseType.Id(), uuid.Nil)
+	err := suite.service.Delete(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.ErrorAs(suite.T(), err, &expensetype.ExpenseTypeInUseError{})
+	suite.repositoryMock.AssertNotCalled(suite.T(), "Delete", mock.Anything, suite.ledgerId, storedExpenseType.Id())
+}
+
+func (suite *ServiceTestSuite) TestGivenAnExpenseTypeReferencedAfterTheCheck_whenDelete_thenReturnInUseError() {
+	storedExpenseType := suite.getExpenseType1()
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{storedExpenseType, nil}, 1)
+	suite.repositoryMock.MockHasSubtypes([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{false, nil}, 1)
+	suite.repositoryMock.MockIsReferencedByExpenses([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{false, nil}, 1)
+	suite.repositoryMock.MockDelete([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{expensetype.ErrExpenseTypeReferenced}, 1)
+
+	command, _ := expensetype.NewDeleteCommand(storedExpenseType.Id(), uuid.Nil)
+	err := suite.service.Delete(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.ErrorAs(suite.T(), err, &expensetype.ExpenseTypeInUseError{})
+}
+
+func (suite *ServiceTestSuite) TestGivenAReassignTarget_whenDelete_thenReassignExpensesAndDelete() {
+	storedExpenseType := suite.getExpenseType1()
+	targetExpenseType, _ := models.NewExpenseTypeWithId(uuid.New(), "Travel")
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{storedExpenseType, nil}, 1)
+	suite.repositoryMock.MockHasSubtypes([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{false, nil}, 1)
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, targetExpenseType.Id()}, []interface{}{targetExpenseType, nil}, 1)
+	suite.repositoryMock.MockReassignExpenses([]interface{}{suite.ledgerId, storedExpenseType.Id(), targetExpenseType.Id()}, []interface{}{nil}, 1)
+	suite.repositoryMock.MockDelete([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{nil}, 1)
+
+	command, _ := expensetype.NewDeleteCommand(storedExpenseType.Id(), targetExpenseType.Id())
+	err := suite.service.Delete(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	suite.repositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestGivenANonExistentReassignTarget_whenDelete_thenReturnError() {
+	storedExpenseType := suite.getExpenseType1()
+	targetId := uuid.New()
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{storedExpenseType, nil}, 1)
+	suite.repositoryMock.MockHasSubtypes([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{false, nil}, 1)
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, targetId}, []interface{}{nil, nil}, 1)
+
+	command, _ := expensetype.NewDeleteCommand(storedExpenseType.Id(), targetId)
+	err := suite.service.Delete(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.ErrorAs(suite.T(), err, &expensetype.InvalidReassignExpenseTypeError{})
+	suite.repositoryMock.AssertNotCalled(suite.T(), "Delete", mock.Anything, suite.ledgerId, storedExpenseType.Id())
+}
+
+func (suite *ServiceTestSuite) TestGivenTheDeletedExpenseTypeAsReassignTarget_whenDelete_thenReturnInvalidReassignError() {
+	storedExpenseType := suite.getExpenseType1()
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{storedExpenseType, nil}, 1)
+	suite.repositoryMock.MockHasSubtypes([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{false, nil}, 1)
+
+	command, _ := expensetype.NewDeleteCommand(storedExpenseType.Id(), storedExpenseType.Id())
+	err := suite.service.Delete(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.ErrorAs(suite.T(), err, &expensetype.InvalidReassignExpenseTypeError{})
+	suite.repositoryMock.AssertNotCalled(suite.T(), "ReassignExpenses", mock.Anything, suite.ledgerId, storedExpenseType.Id(), storedExpenseType.Id())
+	suite.repositoryMock.AssertNotCalled(suite.T(), "Delete", mock.Anything, suite.ledgerId, storedExpenseType.Id())
+}
+
+func (suite *ServiceTestSuite) TestGivenAnExpenseTypeWithSubtypes_whenDelete_thenReturnHasSubtypesError() {
+	storedExpenseType := suite.getExpenseType1()
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{storedExpenseType, nil}, 1)
+	suite.repositoryMock.MockHasSubtypes([]interface{}{suite.ledgerId, storedExpenseType.Id()}, []interface{}{true, nil}, 1)
+
+	command, _ := expensetype.NewDeleteCommand(storedExpenseType.Id(), uuid.Nil)
+	err := suite.service.Delete(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.ErrorAs(suite.T(), err, &expensetype.ExpenseTypeHasSubtypesError{})
+	suite.repositoryMock.AssertNotCalled(suite.T(), "Delete", mock.Anything, suite.ledgerId, storedExpenseType.Id())
+}
+
+func (suite *ServiceTestSuite) TestGivenAParent_whenAdd_thenReturnExpenseTypeNestedUnderIt() {
+	parent, _ := models.NewExpenseTypeWithId(uuid.New(), "Food")
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, parent.Id()}, []interface{}{parent, nil}, 1)
+	suite.repositoryMock.MockGetByName([]interface{}{suite.ledgerId, parent.Id(), "Restaurants"}, []interface{}{nil, nil}, 1)
+	expectedExpenseType, _ := models.NewExpenseType("Restaurants")
+	expectedExpenseType, _ = expectedExpenseType.WithParent(parent)
+	suite.repositoryMock.MockAdd([]interface{}{suite.ledgerId, expectedExpenseType}, []interface{}{expectedExpenseType, nil}, 1)
+
+	command, _ := expensetype.NewAddCommand("Restaurants")
+	addedExpenseType, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, command.WithParent(parent.Id()))
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), parent.Id(), addedExpenseType.ParentId())
+	assert.Equal(suite.T(), "Food > Restaurants", addedExpenseType.FullPath())
+}
+
+func (suite *ServiceTestSuite) TestGivenANonExistentParent_whenAdd_thenReturnInvalidParentError() {
+	parentId := uuid.New()
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, parentId}, []interface{}{nil, nil}, 1)
+
+	command, _ := expensetype.NewAddCommand("Restaurants")
+	addedExpenseType, err := suite.service.Add(context.Background(), suite.userId, suite.ledgerId, command.WithParent(parentId))
+
+	require.ErrorAs(suite.T(), err, &expensetype.InvalidParentExpenseTypeError{})
+	require.Nil(suite.T(), addedExpenseType)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "Add", mock.Anything, suite.ledgerId, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestGivenOneOfItsSubtypesAsParent_whenUpdate_thenReturnInvalidParentError() {
+	food, _ := models.NewExpenseTypeWithId(uuid.New(), "Food")
+	restaurants, _ := models.NewExpenseTypeWithId(uuid.New(), "Restaurants")
+	restaurants, _ = restaurants.WithParent(food)
+	suite.repositoryMock.MockLockAll([]interface{}{suite.ledgerId}, []interface{}{nil}, 1)
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, food.Id()}, []interface{}{food, nil}, 1)
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, restaurants.Id()}, []interface{}{restaurants, nil}, 1)
+	suite.repositoryMock.MockGetByName([]interface{}{suite.ledgerId, restaurants.Id(), "Food"}, []interface{}{nil, nil}, 1)
+
+	command, _ := expensetype.NewUpdateCommand(food.Id(), "Food")
+	updatedExpenseType, err := suite.service.Update(context.Background(), suite.userId, suite.ledgerId, command.WithParent(restaurants.Id()))
+
+	require.ErrorAs(suite.T(), err, &expensetype.InvalidParentExpenseTypeError{})
+	require.Nil(suite.T(), updatedExpenseType)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "Update", mock.Anything, suite.ledgerId, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestGivenAnExpenseTypeWithSubtypes_whenGetSubtree_thenReturnItAndAllItsDescendants() {
+	food, _ := models.NewExpenseTypeWithId(uuid.New(), "Food")
+	restaurants, _ := models.NewExpenseTypeWithId(uuid.New(), "Restaurants")
+	restaurants, _ = restaurants.WithParent(food)
+	delivery, _ := models.NewExpenseTypeWithId(uuid.New(), "Delivery")
+	delivery, _ = delivery.WithParent(restaurants)
+	travel, _ := models.NewExpenseTypeWithId(uuid.New(), "Travel")
+	suite.repositoryMock.MockGetAll([]interface{}{suite.ledgerId}, []interface{}{[]*models.ExpenseType{food, restaurants, delivery, travel}, nil}, 1)
+
+	subtree, err := suite.service.GetSubtree(context.Background(), suite.userId, suite.ledgerId, food.Id())
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []*models.ExpenseType{food, restaurants, delivery}, subtree)
+}
+
+func (suite *ServiceTestSuite) TestGivenANonExistentExpenseType_whenGetSubtree_thenReturnNotFoundError() {
+	suite.repositoryMock.MockGetAll([]interface{}{suite.ledgerId}, []interface{}{[]*models.ExpenseType{suite.getExpenseType1()}, nil}, 1)
+
+	subtree, err := suite.service.GetSubtree(context.Background(), suite.userId, suite.ledgerId, uuid.New())
+
+	require.ErrorAs(suite.T(), err, &expensetype.ExpenseTypeNotFoundError{})
+	require.Nil(suite.T(), subtree)
+}
+
+func (suite *ServiceTestSuite) TestGivenAViewerOfTheLedger_whenAdd_thenReturnForbiddenErrorWithoutAddingIt() {
+	sharedLedgerId := uuid.New()
+	suite.ledgerServiceMock.MockAuthorize([]interface{}{suite.userId, sharedLedgerId, models.EditorLedgerRole}, []interface{}{ledger.ForbiddenError{Msg: "forbidden"}}, 1)
+	command, _ := expensetype.NewAddCommand("Servicios")
+
+	addedExpenseType, err := suite.service.Add(context.Background(), suite.userId, sharedLedgerId, command)
+
+	require.ErrorAs(suite.T(), err, &ledger.ForbiddenError{})
+	require.Nil(suite.T(), addedExpenseType)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "GetByName", mock.Anything, sharedLedgerId, mock.Anything, mock.Anything)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "Add", mock.Anything, sharedLedgerId, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestGivenAUserOutsideTheLedger_whenGetAll_thenReturnLedgerNotFoundError() {
+	otherLedgerId := uuid.New()
+	suite.ledgerServiceMock.MockAuthorize([]interface{}{suite.userId, otherLedgerId, models.ViewerLedgerRole}, []interface{}{ledger.LedgerNotFoundError{Msg: "not found"}}, 1)
+
+	expenseTypes, err := suite.service.GetAll(context.Background(), suite.userId, otherLedgerId)
+
+	require.ErrorAs(suite.T(), err, &ledger.LedgerNotFoundError{})
+	require.Nil(suite.T(), expenseTypes)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "GetAll", mock.Anything, otherLedgerId)
+}
+
 func (suite *ServiceTestSuite) assertEqualsExpenseType(expected *models.ExpenseType, actual *models.ExpenseType) {
 	require.Equal(suite.T(), expected.Id(), actual.Id())
 	require.Equal(suite.T(), expected.Name(), actual.Name())
@@ -0,0 +1,27 @@
+package expensetype
+
+import (
+	"errors"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+)
+
+type UpdateCommand struct {
+	id       uuid.UUID
+	name     string
+	parentId uuid.UUID
+}
+
+func NewUpdateCommand(id uuid.UUID, name string) (*UpdateCommand, error) {
+	if id == uuid.Nil || pkg.IsEmptyOrBlankString(name) || !pkg.HasMin(name, 3) || pkg.ExceedsMax(name, 32) {
+		return nil, errors.New("invalid command")
+	}
+	return &UpdateCommand{id: id, name: name}, nil
+}
+
+// WithParent returns a copy of the command that moves the expense type under the one with parentId. Without it, the
+// expense type is moved to the top level.
+func (u UpdateCommand) WithParent(parentId uuid.UUID) *UpdateCommand {
+	u.parentId = parentId
+	return &u
+}
@@ -0,0 +1,29 @@
+package income
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+type AddCommand struct {
+	amount         string
+	currency       string
+	incomeDate     time.Time
+	description    string
+	incomeSourceId uuid.UUID
+}
+
+func NewAddCommand(amount string, currency string, incomeDate time.Time, description string, incomeSourceId uuid.UUID) (*AddCommand, error) {
+	if !isPositiveAmount(amount, currency) || incomeDate.IsZero() || incomeSourceId == uuid.Nil {
+		return nil, errors.New("invalid command")
+	}
+	return &AddCommand{amount: amount, currency: currency, incomeDate: incomeDate, description: strings.TrimSpace(description), incomeSourceId: incomeSourceId}, nil
+}
+
+func isPositiveAmount(amount string, currency string) bool {
+	money, err := models.NewMoney(amount, currency)
+	return err == nil && money.IsPositive()
+}
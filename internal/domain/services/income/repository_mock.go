@@ -0,0 +1,75 @@
+package income
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func NewRepositoryMock() *RepositoryMock {
+	return &RepositoryMock{}
+}
+
+func (r *RepositoryMock) Add(ctx context.Context, userId uuid.UUID, income *models.Income) (*models.Income, error) {
+	args := r.Called(ctx, userId, income)
+
+	savedIncome := args.Get(0)
+	err := args.Error(1)
+	if err == nil && savedIncome == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return args.Get(0).(*models.Income), nil
+	}
+}
+
+func (r *RepositoryMock) SearchInPeriod(ctx context.Context, userId uuid.UUID, startDate time.Time, endDate time.Time) ([]*models.Income, error) {
+	args := r.Called(ctx, userId, startDate, endDate)
+
+	incomes := args.Get(0)
+	err := args.Error(1)
+	if err == nil && incomes == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return args.Get(0).([]*models.Income), nil
+	}
+}
+
+func (r *RepositoryMock) GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Income, error) {
+	args := r.Called(ctx, userId, id)
+
+	storedIncome := args.Get(0)
+	err := args.Error(1)
+	if err == nil && storedIncome == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return args.Get(0).(*models.Income), nil
+	}
+}
+
+func (r *RepositoryMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
+	r.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockSearchInPeriod(callArguments, returnArguments []interface{}, times int) {
+	r.On("SearchInPeriod", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetByID(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetByID", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func anyContext(callArguments []interface{}) []interface{} {
+	return append([]interface{}{mock.Anything}, callArguments...)
+}
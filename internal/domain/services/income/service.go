@@ -0,0 +1,105 @@
+package income
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/incomesource"
+	"github.com/google/uuid"
+	"time"
+)
+
+const invalidIncomeSourceErrorMsg = "the income source doesn't exists"
+
+type Repository interface {
+	Add(ctx context.Context, userId uuid.UUID, entity *models.Income) (*models.Income, error)
+	SearchInPeriod(ctx context.Context, userId uuid.UUID, startDate time.Time, endDate time.Time) ([]*models.Income, error)
+	GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Income, error)
+}
+
+type Service interface {
+	Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.Income, error)
+	SearchInPeriod(ctx context.Context, userId uuid.UUID, command *SearchInPeriodCommand) ([]*models.Income, error)
+	GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Income, error)
+}
+
+type service struct {
+	repository          Repository
+	incomeSourceService incomesource.Service
+}
+
+func NewService(incomeRepository Repository, incomeSourceService incomesource.Service) *service {
+	return &service{repository: incomeRepository, incomeSourceService: incomeSourceService}
+}
+
+func (s service) Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.Income, error) {
+	incomeSource, err := s.incomeSourceService.GetById(ctx, userId, command.incomeSourceId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	if incomeSource == nil {
+		return nil, InvalidIncomeSourceError{Msg: invalidIncomeSourceErrorMsg}
+	}
+
+	incomeToCreate, err := s.mapAddCommandToIncome(command, incomeSource)
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	createdIncome, err := s.repository.Add(ctx, userId, incomeToCreate)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return createdIncome, nil
+}
+
+func (s service) SearchInPeriod(ctx context.Context, userId uuid.UUID, command *SearchInPeriodCommand) ([]*models.Income, error) {
+	incomes, err := s.repository.SearchInPeriod(ctx, userId, command.startDate, command.endDate)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+	return incomes, nil
+}
+
+func (s service) GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Income, error) {
+	storedIncome, err := s.repository.GetByID(ctx, userId, id)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return storedIncome, nil
+}
+
+func (s service) mapAddCommandToIncome(command *AddCommand, incomeSource *models.IncomeSource) (*models.Income, error) {
+	money, err := models.NewMoney(command.amount, command.currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewIncome(money, command.incomeDate, command.description, incomeSource)
+}
+
+type UnexpectedError struct {
+	Msg string
+}
+
+func (receiver UnexpectedError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidIncomeSourceError struct {
+	Msg string
+}
+
+func (receiver InvalidIncomeSourceError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidDomainModelError struct {
+	Msg string
+}
+
+func (receiver InvalidDomainModelError) Error() string {
+	return receiver.Msg
+}
@@ -0,0 +1,70 @@
+package income
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type ServiceMock struct {
+	mock.Mock
+}
+
+func NewServiceMock() *ServiceMock {
+	return &ServiceMock{}
+}
+
+func (s *ServiceMock) Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.Income, error) {
+	args := s.Called(ctx, userId, command)
+
+	err := args.Error(1)
+	incomeToReturn := args.Get(0)
+	if err == nil && incomeToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return incomeToReturn.(*models.Income), nil
+	}
+}
+
+func (s *ServiceMock) SearchInPeriod(ctx context.Context, userId uuid.UUID, command *SearchInPeriodCommand) ([]*models.Income, error) {
+	args := s.Called(ctx, userId, command)
+
+	err := args.Error(1)
+	incomes := args.Get(0)
+	if err == nil && incomes == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return incomes.([]*models.Income), nil
+	}
+}
+
+func (s *ServiceMock) GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Income, error) {
+	args := s.Called(ctx, userId, id)
+
+	err := args.Error(1)
+	incomeToReturn := args.Get(0)
+	if err == nil && incomeToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return incomeToReturn.(*models.Income), nil
+	}
+}
+
+func (s *ServiceMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
+	s.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockSearchInPeriod(callArguments, returnArguments []interface{}, times int) {
+	s.On("SearchInPeriod", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGetByID(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetById", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
@@ -0,0 +1,140 @@
+package income_test
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/income"
+	"finfit-backend/internal/domain/services/incomesource"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type IncomeServiceTestSuite struct {
+	suite.Suite
+	userId                  uuid.UUID
+	incomeRepositoryMock    *income.RepositoryMock
+	incomeSourceServiceMock *incomesource.ServiceMock
+	service                 income.Service
+}
+
+func (suite *IncomeServiceTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.incomeRepositoryMock = income.NewRepositoryMock()
+	suite.incomeSourceServiceMock = incomesource.NewServiceMock()
+	suite.service = income.NewService(suite.incomeRepositoryMock, suite.incomeSourceServiceMock)
+	suite.patchUUIDFunction()
+}
+
+func (suite *IncomeServiceTestSuite) patchUUIDFunction() {
+	id := uuid.New()
+	pkg.NewUUID = func() uuid.UUID {
+		return id
+	}
+}
+
+func (suite *IncomeServiceTestSuite) TearDownSuite() {
+	pkg.NewUUID = uuid.New
+}
+
+func (suite *IncomeServiceTestSuite) TearDownTest() {
+	suite.incomeRepositoryMock.ExpectedCalls = nil
+	suite.incomeRepositoryMock.Calls = nil
+	suite.incomeSourceServiceMock.ExpectedCalls = nil
+}
+
+func TestIncomeServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(IncomeServiceTestSuite))
+}
+
+func (suite *IncomeServiceTestSuite) TestGivenAnIncome_WhenAdd_ThenReturnCreatedIncome() {
+	incomeToCreate := suite.getIncome(time.Date(2022, 5, 28, 0, 0, 0, 0, time.Local))
+
+	suite.incomeSourceServiceMock.MockGetByID([]interface{}{suite.userId, incomeToCreate.IncomeSource().Id()}, []interface{}{incomeToCreate.IncomeSource(), nil}, 1)
+	suite.incomeRepositoryMock.MockAdd([]interface{}{suite.userId, incomeToCreate}, []interface{}{incomeToCreate, nil}, 1)
+
+	actualIncome, err := suite.service.Add(context.Background(), suite.userId, buildAddCommandFromIncome(incomeToCreate))
+
+	require.NoError(suite.T(), err)
+	assertEqualsIncome(suite.T(), incomeToCreate, actualIncome)
+}
+
+func (suite *IncomeServiceTestSuite) TestGivenThatIncomeSourceNotExists_WhenAdd_ThenReturnError() {
+	incomeToCreate := suite.getIncome(time.Date(2022, 5, 28, 0, 0, 0, 0, time.Local))
+
+	suite.incomeSourceServiceMock.MockGetByID([]interface{}{suite.userId, incomeToCreate.IncomeSource().Id()}, []interface{}{nil, nil}, 1)
+
+	actualIncome, err := suite.service.Add(context.Background(), suite.userId, buildAddCommandFromIncome(incomeToCreate))
+
+	require.ErrorAs(suite.T(), err, &income.InvalidIncomeSourceError{})
+	require.Nil(suite.T(), actualIncome)
+}
+
+func (suite *IncomeServiceTestSuite) TestGivenThatSaveIncomeFails_WhenAdd_ThenReturnError() {
+	incomeToCreate := suite.getIncome(time.Date(2022, 5, 28, 0, 0, 0, 0, time.Local))
+
+	suite.incomeSourceServiceMock.MockGetByID([]interface{}{suite.userId, incomeToCreate.IncomeSource().Id()}, []interface{}{incomeToCreate.IncomeSource(), nil}, 1)
+	suite.incomeRepositoryMock.MockAdd([]interface{}{suite.userId, incomeToCreate}, []interface{}{nil, errors.New("fail")}, 1)
+
+	actualIncome, err := suite.service.Add(context.Background(), suite.userId, buildAddCommandFromIncome(incomeToCreate))
+
+	require.ErrorAs(suite.T(), err, &income.UnexpectedError{})
+	require.Nil(suite.T(), actualIncome)
+}
+
+func (suite *IncomeServiceTestSuite) TestGivenAPeriod_WhenSearchInPeriod_ThenReturnAListOfIncomes() {
+	incomesToReturn := []*models.Income{
+		suite.getIncome(time.Date(2022, 6, 1, 0, 0, 0, 0, time.Local)),
+		suite.getIncome(time.Date(2022, 7, 1, 0, 0, 0, 0, time.Local)),
+	}
+	command, _ := income.NewSearchInPeriodCommand(
+		time.Date(2022, 5, 23, 0, 0, 0, 0, time.Local),
+		time.Date(2022, 8, 23, 0, 0, 0, 0, time.Local))
+
+	suite.incomeRepositoryMock.MockSearchInPeriod([]interface{}{suite.userId, command.StartDate(), command.EndDate()}, []interface{}{incomesToReturn, nil}, 1)
+
+	actualIncomes, err := suite.service.SearchInPeriod(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	for i, expectedIncome := range incomesToReturn {
+		assertEqualsIncome(suite.T(), expectedIncome, actualIncomes[i])
+	}
+}
+
+func (suite *IncomeServiceTestSuite) TestGivenThatRepositoryFails_WhenSearchInPeriod_ThenReturnError() {
+	command, _ := income.NewSearchInPeriodCommand(
+		time.Date(2022, 5, 23, 0, 0, 0, 0, time.Local),
+		time.Date(2022, 8, 23, 0, 0, 0, 0, time.Local))
+
+	suite.incomeRepositoryMock.MockSearchInPeriod([]interface{}{suite.userId, command.StartDate(), command.EndDate()}, []interface{}{nil, errors.New("fail")}, 1)
+
+	actualIncomes, err := suite.service.SearchInPeriod(context.Background(), suite.userId, command)
+
+	require.ErrorAs(suite.T(), err, &income.UnexpectedError{})
+	require.Nil(suite.T(), actualIncomes)
+}
+
+func (suite *IncomeServiceTestSuite) getIncome(incomeDate time.Time) *models.Income {
+	money, _ := models.NewMoney("1500.50", "ARS")
+	incomeSource, _ := models.NewIncomeSource("Salary")
+	newIncome, _ := models.NewIncome(money, incomeDate, "June salary", incomeSource)
+	return newIncome
+}
+
+func assertEqualsIncome(t *testing.T, expected *models.Income, actual *models.Income) {
+	assert.Equal(t, expected.Id(), actual.Id(), "id are not equals")
+	assert.Equal(t, expected.IncomeSource(), actual.IncomeSource(), "incomeSources are not equals")
+	assert.Equal(t, expected.Amount(), actual.Amount(), "amounts are not equals")
+	assert.Equal(t, expected.IncomeDate(), actual.IncomeDate(), "incomeDates are not equals")
+	assert.Equal(t, expected.Description(), actual.Description(), "descriptions are not equals")
+}
+
+func buildAddCommandFromIncome(domainIncome *models.Income) *income.AddCommand {
+	addCommand, _ := income.NewAddCommand(domainIncome.Amount().Amount(), domainIncome.Amount().Currency(), domainIncome.IncomeDate(), domainIncome.Description(), domainIncome.IncomeSource().Id())
+	return addCommand
+}
@@ -0,0 +1,92 @@
+package incomesource
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func NewRepositoryMock() *RepositoryMock {
+	return &RepositoryMock{}
+}
+
+func (r *RepositoryMock) GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.IncomeSource, error) {
+	args := r.Called(ctx, userId, id)
+
+	err := args.Error(1)
+	incomeSource := args.Get(0)
+	if err == nil && incomeSource == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return args.Get(0).(*models.IncomeSource), nil
+	}
+}
+
+func (r *RepositoryMock) GetByName(ctx context.Context, userId uuid.UUID, name string) (*models.IncomeSource, error) {
+	args := r.Called(ctx, userId, name)
+
+	err := args.Error(1)
+	incomeSource := args.Get(0)
+	if err == nil && incomeSource == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return args.Get(0).(*models.IncomeSource), nil
+	}
+}
+
+func (r *RepositoryMock) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.IncomeSource, error) {
+	args := r.Called(ctx, userId)
+
+	err := args.Error(1)
+	incomeSources := args.Get(0)
+	if err == nil && incomeSources == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return args.Get(0).([]*models.IncomeSource), nil
+	}
+}
+
+func (r *RepositoryMock) Add(ctx context.Context, userId uuid.UUID, incomeSource *models.IncomeSource) (*models.IncomeSource, error) {
+	args := r.Called(ctx, userId, incomeSource)
+
+	savedIncomeSource := args.Get(0)
+	err := args.Error(1)
+	if err == nil && savedIncomeSource == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return args.Get(0).(*models.IncomeSource), nil
+	}
+}
+
+func (r *RepositoryMock) MockGetByID(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetByID", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetByName(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetByName", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
+	r.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetAll(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func anyContext(callArguments []interface{}) []interface{} {
+	return append([]interface{}{mock.Anything}, callArguments...)
+}
@@ -0,0 +1,85 @@
+package incomesource
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.IncomeSource, error)
+	GetByName(ctx context.Context, userId uuid.UUID, name string) (*models.IncomeSource, error)
+	GetAll(ctx context.Context, userId uuid.UUID) ([]*models.IncomeSource, error)
+	Add(ctx context.Context, userId uuid.UUID, incomeSource *models.IncomeSource) (*models.IncomeSource, error)
+}
+type Service interface {
+	GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.IncomeSource, error)
+	Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.IncomeSource, error)
+	GetAll(ctx context.Context, userId uuid.UUID) ([]*models.IncomeSource, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *service {
+	return &service{repo: repo}
+}
+
+func (s service) GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.IncomeSource, error) {
+	incomeSource, err := s.repo.GetByID(ctx, userId, id)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return incomeSource, nil
+}
+
+func (s service) Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.IncomeSource, error) {
+	storedIncomeSource, err := s.repo.GetByName(ctx, userId, command.name)
+
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	if storedIncomeSource != nil {
+		return storedIncomeSource, nil
+	}
+
+	incomeSourceToAdd, err := models.NewIncomeSource(command.name)
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	addedIncomeSource, err := s.repo.Add(ctx, userId, incomeSourceToAdd)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return addedIncomeSource, nil
+}
+
+func (s service) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.IncomeSource, error) {
+	incomeSources, err := s.repo.GetAll(ctx, userId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return incomeSources, nil
+}
+
+type UnexpectedError struct {
+	Msg string
+}
+
+func (receiver UnexpectedError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidDomainModelError struct {
+	Msg string
+}
+
+func (receiver InvalidDomainModelError) Error() string {
+	return receiver.Msg
+}
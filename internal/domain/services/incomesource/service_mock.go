@@ -0,0 +1,70 @@
+package incomesource
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type ServiceMock struct {
+	mock.Mock
+}
+
+func NewServiceMock() *ServiceMock {
+	return &ServiceMock{}
+}
+
+func (s *ServiceMock) GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.IncomeSource, error) {
+	args := s.Called(ctx, userId, id)
+
+	err := args.Error(1)
+	incomeSource := args.Get(0)
+	if err == nil && incomeSource == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return args.Get(0).(*models.IncomeSource), nil
+	}
+}
+
+func (s *ServiceMock) Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.IncomeSource, error) {
+	args := s.Called(ctx, userId, command)
+
+	err := args.Error(1)
+	incomeSourceToReturn := args.Get(0)
+	if err == nil && incomeSourceToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return incomeSourceToReturn.(*models.IncomeSource), nil
+	}
+}
+
+func (s *ServiceMock) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.IncomeSource, error) {
+	args := s.Called(ctx, userId)
+
+	err := args.Error(1)
+	incomeSources := args.Get(0)
+	if err == nil && incomeSources == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return args.Get(0).([]*models.IncomeSource), nil
+	}
+}
+
+func (s *ServiceMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
+	s.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGetByID(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetById", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGetAll(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
@@ -0,0 +1,107 @@
+package incomesource_test
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/incomesource"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ServiceTestSuite struct {
+	suite.Suite
+	userId         uuid.UUID
+	repositoryMock *incomesource.RepositoryMock
+	service        incomesource.Service
+}
+
+func (suite *ServiceTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.repositoryMock = incomesource.NewRepositoryMock()
+	suite.service = incomesource.NewService(suite.repositoryMock)
+	suite.patchUUIDFunction()
+}
+
+func (suite *ServiceTestSuite) patchUUIDFunction() {
+	id := uuid.New()
+	pkg.NewUUID = func() uuid.UUID {
+		return id
+	}
+}
+
+func (suite *ServiceTestSuite) TearDownTest() {
+	suite.repositoryMock.ExpectedCalls = nil
+	suite.repositoryMock.Calls = nil
+}
+
+func (suite *ServiceTestSuite) TearDownSuite() {
+	pkg.NewUUID = uuid.New
+}
+
+func TestServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ServiceTestSuite))
+}
+
+func (suite *ServiceTestSuite) TestGivenAnID_whenGetById_thenReturnIncomeSource() {
+	expectedIncomeSource, _ := models.NewIncomeSource("Salary")
+	suite.repositoryMock.MockGetByID([]interface{}{suite.userId, expectedIncomeSource.Id()}, []interface{}{expectedIncomeSource, nil}, 1)
+
+	actualIncomeSource, err := suite.service.GetById(context.Background(), suite.userId, expectedIncomeSource.Id())
+
+	require.NoError(suite.T(), err)
+	suite.assertEqualsIncomeSource(expectedIncomeSource, actualIncomeSource)
+}
+
+func (suite *ServiceTestSuite) TestGivenThatRepositoryFails_whenGetById_thenReturnError() {
+	id := uuid.New()
+	suite.repositoryMock.MockGetByID([]interface{}{suite.userId, id}, []interface{}{nil, errors.New("fail")}, 1)
+
+	actualIncomeSource, err := suite.service.GetById(context.Background(), suite.userId, id)
+
+	require.ErrorAs(suite.T(), err, &incomesource.UnexpectedError{})
+	require.Nil(suite.T(), actualIncomeSource)
+}
+
+func (suite *ServiceTestSuite) TestGivenAnIncomeSourceToAddAndIncomeSourceNotExists_whenAdd_thenReturnAddedIncomeSource() {
+	expectedIncomeSource, _ := models.NewIncomeSource("Salary")
+	suite.repositoryMock.MockGetByName([]interface{}{suite.userId, expectedIncomeSource.Name()}, []interface{}{nil, nil}, 1)
+	suite.repositoryMock.MockAdd([]interface{}{suite.userId, expectedIncomeSource}, []interface{}{expectedIncomeSource, nil}, 1)
+
+	command, _ := incomesource.NewAddCommand(expectedIncomeSource.Name())
+	addedIncomeSource, err := suite.service.Add(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	suite.assertEqualsIncomeSource(expectedIncomeSource, addedIncomeSource)
+	suite.repositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestGivenThatIncomeSourceAlreadyExists_whenAdd_thenReturnStoredIncomeSource() {
+	expectedIncomeSource, _ := models.NewIncomeSource("Salary")
+	suite.repositoryMock.MockGetByName([]interface{}{suite.userId, expectedIncomeSource.Name()}, []interface{}{expectedIncomeSource, nil}, 1)
+
+	command, _ := incomesource.NewAddCommand(expectedIncomeSource.Name())
+	addedIncomeSource, err := suite.service.Add(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	suite.assertEqualsIncomeSource(expectedIncomeSource, addedIncomeSource)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "Add", mock.Anything, suite.userId, expectedIncomeSource)
+}
+
+func (suite *ServiceTestSuite) TestGivenThatRepositoryFails_whenGetAll_thenReturnError() {
+	suite.repositoryMock.MockGetAll([]interface{}{suite.userId}, []interface{}{nil, errors.New("fail")}, 1)
+
+	incomeSources, err := suite.service.GetAll(context.Background(), suite.userId)
+
+	require.ErrorAs(suite.T(), err, &incomesource.UnexpectedError{})
+	require.Nil(suite.T(), incomeSources)
+}
+
+func (suite *ServiceTestSuite) assertEqualsIncomeSource(expected *models.IncomeSource, actual *models.IncomeSource) {
+	require.Equal(suite.T(), expected.Id(), actual.Id())
+	require.Equal(suite.T(), expected.Name(), actual.Name())
+}
@@ -0,0 +1,17 @@
+package ledger
+
+import (
+	"errors"
+	"finfit-backend/pkg"
+)
+
+type AcceptInvitationCommand struct {
+	token string
+}
+
+func NewAcceptInvitationCommand(token string) (*AcceptInvitationCommand, error) {
+	if pkg.IsEmptyOrBlankString(token) {
+		return nil, errors.New("invalid command")
+	}
+	return &AcceptInvitationCommand{token: token}, nil
+}
@@ -0,0 +1,21 @@
+package ledger
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type InviteCommand struct {
+	ledgerId uuid.UUID
+	role     models.LedgerRole
+}
+
+// NewInviteCommand only accepts the editor and viewer roles, every ledger has a single owner.
+func NewInviteCommand(ledgerId uuid.UUID, role string) (*InviteCommand, error) {
+	ledgerRole := models.LedgerRole(role)
+	if ledgerId == uuid.Nil || (ledgerRole != models.EditorLedgerRole && ledgerRole != models.ViewerLedgerRole) {
+		return nil, errors.New("invalid command")
+	}
+	return &InviteCommand{ledgerId: ledgerId, role: ledgerRole}, nil
+}
@@ -0,0 +1,137 @@
+package ledger
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func NewRepositoryMock() *RepositoryMock {
+	return &RepositoryMock{}
+}
+
+func (r *RepositoryMock) Add(ctx context.Context, ledger *models.Ledger, owner *models.LedgerMember) (*models.Ledger, error) {
+	args := r.Called(ctx, ledger, owner)
+
+	err := args.Error(1)
+	addedLedger := args.Get(0)
+	if err == nil && addedLedger == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return addedLedger.(*models.Ledger), nil
+	}
+}
+
+func (r *RepositoryMock) GetAllByMember(ctx context.Context, userId uuid.UUID) ([]*models.Ledger, error) {
+	args := r.Called(ctx, userId)
+
+	err := args.Error(1)
+	ledgers := args.Get(0)
+	if err == nil && ledgers == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return ledgers.([]*models.Ledger), nil
+	}
+}
+
+func (r *RepositoryMock) GetMember(ctx context.Context, ledgerId uuid.UUID, userId uuid.UUID) (*models.LedgerMember, error) {
+	args := r.Called(ctx, ledgerId, userId)
+
+	err := args.Error(1)
+	member := args.Get(0)
+	if err == nil && member == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return member.(*models.LedgerMember), nil
+	}
+}
+
+func (r *RepositoryMock) GetMembers(ctx context.Context, ledgerId uuid.UUID) ([]*models.LedgerMember, error) {
+	args := r.Called(ctx, ledgerId)
+
+	err := args.Error(1)
+	members := args.Get(0)
+	if err == nil && members == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return members.([]*models.LedgerMember), nil
+	}
+}
+
+func (r *RepositoryMock) AddInvitation(ctx context.Context, invitation *models.LedgerInvitation) (*models.LedgerInvitation, error) {
+	args := r.Called(ctx, invitation)
+
+	err := args.Error(1)
+	addedInvitation := args.Get(0)
+	if err == nil && addedInvitation == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return addedInvitation.(*models.LedgerInvitation), nil
+	}
+}
+
+func (r *RepositoryMock) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*models.LedgerInvitation, error) {
+	args := r.Called(ctx, tokenHash)
+
+	err := args.Error(1)
+	invitation := args.Get(0)
+	if err == nil && invitation == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return invitation.(*models.LedgerInvitation), nil
+	}
+}
+
+func (r *RepositoryMock) AcceptInvitation(ctx context.Context, invitation *models.LedgerInvitation, member *models.LedgerMember) error {
+	args := r.Called(ctx, invitation, member)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
+	r.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetAllByMember(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetAllByMember", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetMember(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetMember", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetMembers(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetMembers", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockAddInvitation(callArguments, returnArguments []interface{}, times int) {
+	r.On("AddInvitation", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetInvitationByTokenHash(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetInvitationByTokenHash", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockAcceptInvitation(callArguments, returnArguments []interface{}, times int) {
+	r.On("AcceptInvitation", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func anyContext(callArguments []interface{}) []interface{} {
+	return append([]interface{}{mock.Anything}, callArguments...)
+}
@@ -0,0 +1,264 @@
+package ledger
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	ledgerNotFoundErrorMsg      = "the ledger doesn't exists"
+	forbiddenErrorMsg           = "your role in the ledger doesn't allow it"
+	invalidInvitationErrorMsg   = "the invitation is invalid or has expired"
+	memberAlreadyExistsErrorMsg = "the user is already a member of the ledger"
+	personalLedgerErrorMsg      = "the personal ledger can't be shared"
+)
+
+const (
+	invitationTTL         = 7 * 24 * time.Hour
+	invitationTokenLength = 32
+)
+
+// Now is the clock used to expire the invitations, tests replace it.
+var Now = time.Now
+
+type Repository interface {
+	// Add stores the ledger together with the membership of its owner.
+	Add(ctx context.Context, ledger *models.Ledger, owner *models.LedgerMember) (*models.Ledger, error)
+	GetAllByMember(ctx context.Context, userId uuid.UUID) ([]*models.Ledger, error)
+	GetMember(ctx context.Context, ledgerId uuid.UUID, userId uuid.UUID) (*models.LedgerMember, error)
+	GetMembers(ctx context.Context, ledgerId uuid.UUID) ([]*models.LedgerMember, error)
+	AddInvitation(ctx context.Context, invitation *models.LedgerInvitation) (*models.LedgerInvitation, error)
+	GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*models.LedgerInvitation, error)
+	// AcceptInvitation adds the member and deletes the invitation, so it can't be used again.
+	AcceptInvitation(ctx context.Context, invitation *models.LedgerInvitation, member *models.LedgerMember) error
+}
+
+type Service interface {
+	Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.Ledger, error)
+	GetAll(ctx context.Context, userId uuid.UUID) ([]*models.Ledger, error)
+	GetMembers(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID) ([]*models.LedgerMember, error)
+	Invite(ctx context.Context, userId uuid.UUID, command *InviteCommand) (*IssuedInvitation, error)
+	AcceptInvitation(ctx context.Context, userId uuid.UUID, command *AcceptInvitationCommand) (*models.LedgerMember, error)
+	// Authorize returns nil when the user is a member of the ledger with, at least, the required role.
+	Authorize(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, required models.LedgerRole) error
+}
+
+type service struct {
+	repository Repository
+}
+
+func NewService(repository Repository) *service {
+	return &service{repository: repository}
+}
+
+func (s service) Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.Ledger, error) {
+	ledgerToAdd, err := models.NewLedger(command.name)
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	owner, err := models.NewLedgerMember(ledgerToAdd.Id(), userId, models.OwnerLedgerRole)
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	addedLedger, err := s.repository.Add(ctx, ledgerToAdd, owner)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return addedLedger, nil
+}
+
+// GetAll returns the shared ledgers the user is a member of. The personal ledger isn't listed.
+func (s service) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.Ledger, error) {
+	ledgers, err := s.repository.GetAllByMember(ctx, userId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return ledgers, nil
+}
+
+func (s service) GetMembers(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID) ([]*models.LedgerMember, error) {
+	if err := s.Authorize(ctx, userId, ledgerId, models.ViewerLedgerRole); err != nil {
+		return nil, err
+	}
+
+	if ledgerId == models.PersonalLedgerId(userId) {
+		owner, err := models.NewLedgerMember(ledgerId, userId, models.OwnerLedgerRole)
+		if err != nil {
+			return nil, InvalidDomainModelError{Msg: err.Error()}
+		}
+		return []*models.LedgerMember{owner}, nil
+	}
+
+	members, err := s.repository.GetMembers(ctx, ledgerId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return members, nil
+}
+
+// Invite issues a single use token that adds whoever accepts it to the ledger. Only the owner can invite.
+func (s service) Invite(ctx context.Context, userId uuid.UUID, command *InviteCommand) (*IssuedInvitation, error) {
+	if command.ledgerId == models.PersonalLedgerId(userId) {
+		return nil, PersonalLedgerError{Msg: personalLedgerErrorMsg}
+	}
+
+	if err := s.Authorize(ctx, userId, command.ledgerId, models.OwnerLedgerRole); err != nil {
+		return nil, err
+	}
+
+	invitationToken, err := newInvitationToken()
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	invitation, err := models.NewLedgerInvitation(command.ledgerId, command.role, hashInvitationToken(invitationToken), Now().Add(invitationTTL))
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	addedInvitation, err := s.repository.AddInvitation(ctx, invitation)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return &IssuedInvitation{Invitation: addedInvitation, Token: invitationToken}, nil
+}
+
+func (s service) AcceptInvitation(ctx context.Context, userId uuid.UUID, command *AcceptInvitationCommand) (*models.LedgerMember, error) {
+	invitation, err := s.repository.GetInvitationByTokenHash(ctx, hashInvitationToken(command.token))
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	if invitation == nil || invitation.IsExpired(Now()) {
+		return nil, InvalidInvitationError{Msg: invalidInvitationErrorMsg}
+	}
+
+	storedMember, err := s.repository.GetMember(ctx, invitation.LedgerId(), userId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	if storedMember != nil {
+		return nil, MemberAlreadyExistsError{Msg: memberAlreadyExistsErrorMsg}
+	}
+
+	member, err := models.NewLedgerMember(invitation.LedgerId(), userId, invitation.Role())
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	if err = s.repository.AcceptInvitation(ctx, invitation, member); err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return member, nil
+}
+
+// Authorize answers LedgerNotFoundError to users who aren't members, so they can't tell which ledgers exist.
+func (s service) Authorize(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, required models.LedgerRole) error {
+	if ledgerId == models.PersonalLedgerId(userId) {
+		return nil
+	}
+
+	member, err := s.repository.GetMember(ctx, ledgerId, userId)
+	if err != nil {
+		return UnexpectedError{Msg: err.Error()}
+	}
+
+	if member == nil {
+		return LedgerNotFoundError{Msg: ledgerNotFoundErrorMsg}
+	}
+
+	if !member.Role().Allows(required) {
+		return ForbiddenError{Msg: forbiddenErrorMsg}
+	}
+
+	return nil
+}
+
+func newInvitationToken() (string, error) {
+	tokenBytes := make([]byte, invitationTokenLength)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+}
+
+func hashInvitationToken(invitationToken string) string {
+	hash := sha256.Sum256([]byte(invitationToken))
+	return hex.EncodeToString(hash[:])
+}
+
+// IssuedInvitation has the only copy of the invitation token, which must be handed to the invited user.
+type IssuedInvitation struct {
+	Invitation *models.LedgerInvitation
+	Token      string
+}
+
+type UnexpectedError struct {
+	Msg string
+}
+
+func (receiver UnexpectedError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidDomainModelError struct {
+	Msg string
+}
+
+func (receiver InvalidDomainModelError) Error() string {
+	return receiver.Msg
+}
+
+type LedgerNotFoundError struct {
+	Msg string
+}
+
+func (receiver LedgerNotFoundError) Error() string {
+	return receiver.Msg
+}
+
+type ForbiddenError struct {
+	Msg string
+}
+
+func (receiver ForbiddenError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidInvitationError struct {
+	Msg string
+}
+
+func (receiver InvalidInvitationError) Error() string {
+	return receiver.Msg
+}
+
+type MemberAlreadyExistsError struct {
+	Msg string
+}
+
+func (receiver MemberAlreadyExistsError) Error() string {
+	return receiver.Msg
+}
+
+type PersonalLedgerError struct {
+	Msg string
+}
+
+func (receiver PersonalLedgerError) Error() string {
+	return receiver.Msg
+}
@@ -0,0 +1,115 @@
+package ledger
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type ServiceMock struct {
+	mock.Mock
+}
+
+func NewServiceMock() *ServiceMock {
+	return &ServiceMock{}
+}
+
+func (s *ServiceMock) Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.Ledger, error) {
+	args := s.Called(ctx, userId, command)
+
+	err := args.Error(1)
+	addedLedger := args.Get(0)
+	if err == nil && addedLedger == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return addedLedger.(*models.Ledger), nil
+	}
+}
+
+func (s *ServiceMock) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.Ledger, error) {
+	args := s.Called(ctx, userId)
+
+	err := args.Error(1)
+	ledgers := args.Get(0)
+	if err == nil && ledgers == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return ledgers.([]*models.Ledger), nil
+	}
+}
+
+func (s *ServiceMock) GetMembers(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID) ([]*models.LedgerMember, error) {
+	args := s.Called(ctx, userId, ledgerId)
+
+	err := args.Error(1)
+	members := args.Get(0)
+	if err == nil && members == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return members.([]*models.LedgerMember), nil
+	}
+}
+
+func (s *ServiceMock) Invite(ctx context.Context, userId uuid.UUID, command *InviteCommand) (*IssuedInvitation, error) {
+	args := s.Called(ctx, userId, command)
+
+	err := args.Error(1)
+	invitation := args.Get(0)
+	if err == nil && invitation == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return invitation.(*IssuedInvitation), nil
+	}
+}
+
+func (s *ServiceMock) AcceptInvitation(ctx context.Context, userId uuid.UUID, command *AcceptInvitationCommand) (*models.LedgerMember, error) {
+	args := s.Called(ctx, userId, command)
+
+	err := args.Error(1)
+	member := args.Get(0)
+	if err == nil && member == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return member.(*models.LedgerMember), nil
+	}
+}
+
+func (s *ServiceMock) Authorize(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, required models.LedgerRole) error {
+	args := s.Called(ctx, userId, ledgerId, required)
+	return args.Error(0)
+}
+
+func (s *ServiceMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
+	s.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGetAll(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGetMembers(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetMembers", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockInvite(callArguments, returnArguments []interface{}, times int) {
+	s.On("Invite", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockAcceptInvitation(callArguments, returnArguments []interface{}, times int) {
+	s.On("AcceptInvitation", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockAuthorize(callArguments, returnArguments []interface{}, times int) {
+	s.On("Authorize", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
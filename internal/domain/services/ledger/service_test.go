@@ -0,0 +1,196 @@
+package ledger_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/ledger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type ServiceTestSuite struct {
+	suite.Suite
+	userId         uuid.UUID
+	ledgerId       uuid.UUID
+	repositoryMock *ledger.RepositoryMock
+	service        ledger.Service
+}
+
+func (suite *ServiceTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.ledgerId = uuid.New()
+	suite.repositoryMock = ledger.NewRepositoryMock()
+	suite.service = ledger.NewService(suite.repositoryMock)
+}
+
+func (suite *ServiceTestSuite) TearDownTest() {
+	suite.repositoryMock.ExpectedCalls = nil
+	suite.repositoryMock.Calls = nil
+	ledger.Now = time.Now
+}
+
+func TestServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ServiceTestSuite))
+}
+
+func (suite *ServiceTestSuite) TestGivenAName_WhenAdd_ThenStoreTheLedgerWithTheUserAsOwner() {
+	isOwner := mock.MatchedBy(func(owner *models.LedgerMember) bool {
+		return owner.UserId() == suite.userId && owner.Role() == models.OwnerLedgerRole
+	})
+	storedLedger, _ := models.NewLedgerWithId(suite.ledgerId, "Home")
+	suite.repositoryMock.MockAdd([]interface{}{mock.Anything, isOwner}, []interface{}{storedLedger, nil}, 1)
+	command, _ := ledger.NewAddCommand("Home")
+
+	addedLedger, err := suite.service.Add(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), storedLedger, addedLedger)
+	suite.repositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestGivenTheirPersonalLedger_WhenAuthorize_ThenAllowEverythingWithoutLookingForMembers() {
+	err := suite.service.Authorize(context.Background(), suite.userId, models.PersonalLedgerId(suite.userId), models.OwnerLedgerRole)
+
+	assert.NoError(suite.T(), err)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "GetMember", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestGivenAnEditor_WhenAuthorizeToEdit_ThenAllowIt() {
+	suite.mockMember(models.EditorLedgerRole)
+
+	err := suite.service.Authorize(context.Background(), suite.userId, suite.ledgerId, models.EditorLedgerRole)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *ServiceTestSuite) TestGivenAViewer_WhenAuthorizeToEdit_ThenReturnForbiddenError() {
+	suite.mockMember(models.ViewerLedgerRole)
+
+	err := suite.service.Authorize(context.Background(), suite.userId, suite.ledgerId, models.EditorLedgerRole)
+
+	assert.ErrorAs(suite.T(), err, &ledger.ForbiddenError{})
+}
+
+func (suite *ServiceTestSuite) TestGivenAUserThatIsNotAMember_WhenAuthorize_ThenReturnLedgerNotFoundError() {
+	suite.repositoryMock.MockGetMember([]interface{}{suite.ledgerId, suite.userId}, []interface{}{nil, nil}, 1)
+
+	err := suite.service.Authorize(context.Background(), suite.userId, suite.ledgerId, models.ViewerLedgerRole)
+
+	assert.ErrorAs(suite.T(), err, &ledger.LedgerNotFoundError{})
+}
+
+func (suite *ServiceTestSuite) TestGivenThatRepositoryFails_WhenAuthorize_ThenReturnUnexpectedError() {
+	suite.repositoryMock.MockGetMember([]interface{}{suite.ledgerId, suite.userId}, []interface{}{nil, errors.New("fail")}, 1)
+
+	err := suite.service.Authorize(context.Background(), suite.userId, suite.ledgerId, models.ViewerLedgerRole)
+
+	assert.ErrorAs(suite.T(), err, &ledger.UnexpectedError{})
+}
+
+func (suite *ServiceTestSuite) TestGivenTheOwner_WhenInvite_ThenStoreOnlyTheHashOfTheToken() {
+	suite.mockMember(models.OwnerLedgerRole)
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	ledger.Now = func() time.Time {
+		return now
+	}
+	var storedInvitation *models.LedgerInvitation
+	isInvitation := mock.MatchedBy(func(invitation *models.LedgerInvitation) bool {
+		storedInvitation = invitation
+		return invitation.LedgerId() == suite.ledgerId && invitation.Role() == models.EditorLedgerRole
+	})
+	addedInvitation, _ := models.NewLedgerInvitation(suite.ledgerId, models.EditorLedgerRole, "hash", now.Add(time.Hour))
+	suite.repositoryMock.MockAddInvitation([]interface{}{isInvitation}, []interface{}{addedInvitation, nil}, 1)
+	command, _ := ledger.NewInviteCommand(suite.ledgerId, "editor")
+
+	issuedInvitation, err := suite.service.Invite(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), addedInvitation, issuedInvitation.Invitation)
+	assert.NotEmpty(suite.T(), issuedInvitation.Token)
+	assert.Equal(suite.T(), hash(issuedInvitation.Token), storedInvitation.TokenHash())
+	assert.Equal(suite.T(), now.Add(7*24*time.Hour), storedInvitation.ExpiresAt())
+}
+
+func (suite *ServiceTestSuite) TestGivenAnEditor_WhenInvite_ThenReturnForbiddenError() {
+	suite.mockMember(models.EditorLedgerRole)
+	command, _ := ledger.NewInviteCommand(suite.ledgerId, "viewer")
+
+	issuedInvitation, err := suite.service.Invite(context.Background(), suite.userId, command)
+
+	assert.ErrorAs(suite.T(), err, &ledger.ForbiddenError{})
+	assert.Nil(suite.T(), issuedInvitation)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "AddInvitation", mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestGivenThePersonalLedger_WhenInvite_ThenReturnPersonalLedgerError() {
+	command, _ := ledger.NewInviteCommand(models.PersonalLedgerId(suite.userId), "viewer")
+
+	issuedInvitation, err := suite.service.Invite(context.Background(), suite.userId, command)
+
+	assert.ErrorAs(suite.T(), err, &ledger.PersonalLedgerError{})
+	assert.Nil(suite.T(), issuedInvitation)
+}
+
+func (suite *ServiceTestSuite) TestGivenTheOwnerRole_WhenNewInviteCommand_ThenReturnError() {
+	command, err := ledger.NewInviteCommand(suite.ledgerId, "owner")
+
+	assert.EqualError(suite.T(), err, "invalid command")
+	assert.Nil(suite.T(), command)
+}
+
+func (suite *ServiceTestSuite) TestGivenAValidToken_WhenAcceptInvitation_ThenAddTheUserWithTheRoleOfTheInvitation() {
+	invitation, _ := models.NewLedgerInvitation(suite.ledgerId, models.ViewerLedgerRole, hash("token"), time.Now().Add(time.Hour))
+	suite.repositoryMock.MockGetInvitationByTokenHash([]interface{}{hash("token")}, []interface{}{invitation, nil}, 1)
+	suite.repositoryMock.MockGetMember([]interface{}{suite.ledgerId, suite.userId}, []interface{}{nil, nil}, 1)
+	expectedMember, _ := models.NewLedgerMember(suite.ledgerId, suite.userId, models.ViewerLedgerRole)
+	suite.repositoryMock.MockAcceptInvitation([]interface{}{invitation, expectedMember}, []interface{}{nil}, 1)
+	command, _ := ledger.NewAcceptInvitationCommand("token")
+
+	member, err := suite.service.AcceptInvitation(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedMember, member)
+	suite.repositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestGivenAnExpiredToken_WhenAcceptInvitation_ThenReturnInvalidInvitationError() {
+	invitation, _ := models.NewLedgerInvitation(suite.ledgerId, models.ViewerLedgerRole, hash("token"), time.Now().Add(-time.Minute))
+	suite.repositoryMock.MockGetInvitationByTokenHash([]interface{}{hash("token")}, []interface{}{invitation, nil}, 1)
+	command, _ := ledger.NewAcceptInvitationCommand("token")
+
+	member, err := suite.service.AcceptInvitation(context.Background(), suite.userId, command)
+
+	assert.ErrorAs(suite.T(), err, &ledger.InvalidInvitationError{})
+	assert.Nil(suite.T(), member)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "AcceptInvitation", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestGivenAMember_WhenAcceptInvitation_ThenReturnMemberAlreadyExistsError() {
+	invitation, _ := models.NewLedgerInvitation(suite.ledgerId, models.ViewerLedgerRole, hash("token"), time.Now().Add(time.Hour))
+	suite.repositoryMock.MockGetInvitationByTokenHash([]interface{}{hash("token")}, []interface{}{invitation, nil}, 1)
+	suite.mockMember(models.EditorLedgerRole)
+	command, _ := ledger.NewAcceptInvitationCommand("token")
+
+	member, err := suite.service.AcceptInvitation(context.Background(), suite.userId, command)
+
+	assert.ErrorAs(suite.T(), err, &ledger.MemberAlreadyExistsError{})
+	assert.Nil(suite.T(), member)
+}
+
+func (suite *ServiceTestSuite) mockMember(role models.LedgerRole) {
+	member, _ := models.NewLedgerMember(suite.ledgerId, suite.userId, role)
+	suite.repositoryMock.MockGetMember([]interface{}{suite.ledgerId, suite.userId}, []interface{}{member, nil}, 1)
+}
+
+func hash(invitationToken string) string {
+	tokenHash := sha256.Sum256([]byte(invitationToken))
+	return hex.EncodeToString(tokenHash[:])
+}
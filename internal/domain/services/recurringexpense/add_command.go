@@ -0,0 +1,50 @@
+package recurringexpense
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+type AddCommand struct {
+	amount        string
+	currency      string
+	description   string
+	expenseTypeId uuid.UUID
+	frequency     string
+	interval      int
+	startDate     time.Time
+	endDate       time.Time
+	count         int
+}
+
+// NewAddCommand builds the command to add a recurring expense. A zero endDate and a zero count mean that the schedule
+// never ends.
+func NewAddCommand(amount string, currency string, description string, expenseTypeId uuid.UUID, frequency string, interval int, startDate time.Time, endDate time.Time, count int) (*AddCommand, error) {
+	if expenseTypeId == uuid.Nil || !models.IsValidRecurrenceFrequency(frequency) {
+		return nil, errors.New("invalid command")
+	}
+
+	money, err := models.NewMoney(amount, currency)
+	if err != nil || !money.IsPositive() {
+		return nil, errors.New("invalid command")
+	}
+
+	if _, err = models.NewRecurrenceRule(models.RecurrenceFrequency(frequency), interval, startDate, endDate, count); err != nil {
+		return nil, errors.New("invalid command")
+	}
+
+	return &AddCommand{
+		amount:        amount,
+		currency:      currency,
+		description:   strings.TrimSpace(description),
+		expenseTypeId: expenseTypeId,
+		frequency:     frequency,
+		interval:      interval,
+		startDate:     startDate,
+		endDate:       endDate,
+		count:         count,
+	}, nil
+}
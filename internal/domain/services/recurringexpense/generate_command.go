@@ -0,0 +1,22 @@
+package recurringexpense
+
+import (
+	"errors"
+	"time"
+)
+
+type GenerateCommand struct {
+	until time.Time
+}
+
+// NewGenerateCommand builds the command to generate the expenses of every occurrence due up to the given date.
+func NewGenerateCommand(until time.Time) (*GenerateCommand, error) {
+	if until.IsZero() {
+		return nil, errors.New("invalid command")
+	}
+	return &GenerateCommand{until: time.Date(until.Year(), until.Month(), until.Day(), 0, 0, 0, 0, time.UTC)}, nil
+}
+
+func (g GenerateCommand) Until() time.Time {
+	return g.until
+}
@@ -0,0 +1,93 @@
+package recurringexpense
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func NewRepositoryMock() *RepositoryMock {
+	return &RepositoryMock{}
+}
+
+func (r *RepositoryMock) Add(ctx context.Context, userId uuid.UUID, recurringExpense *models.RecurringExpense) (*models.RecurringExpense, error) {
+	args := r.Called(ctx, userId, recurringExpense)
+
+	err := args.Error(1)
+	recurringExpenseToReturn := args.Get(0)
+	if err == nil && recurringExpenseToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return recurringExpenseToReturn.(*models.RecurringExpense), nil
+	}
+}
+
+func (r *RepositoryMock) GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.RecurringExpense, error) {
+	args := r.Called(ctx, userId, id)
+
+	err := args.Error(1)
+	recurringExpenseToReturn := args.Get(0)
+	if err == nil && recurringExpenseToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return recurringExpenseToReturn.(*models.RecurringExpense), nil
+	}
+}
+
+func (r *RepositoryMock) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.RecurringExpense, error) {
+	args := r.Called(ctx, userId)
+
+	err := args.Error(1)
+	recurringExpenses := args.Get(0)
+	if err == nil && recurringExpenses == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return recurringExpenses.([]*models.RecurringExpense), nil
+	}
+}
+
+func (r *RepositoryMock) Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	args := r.Called(ctx, userId, id)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) UpdateLastOccurrence(ctx context.Context, userId uuid.UUID, id uuid.UUID, lastOccurrence time.Time) (bool, error) {
+	args := r.Called(ctx, userId, id, lastOccurrence)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *RepositoryMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
+	r.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetByID(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetByID", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetAll(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockDelete(callArguments, returnArguments []interface{}, times int) {
+	r.On("Delete", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockUpdateLastOccurrence(callArguments, returnArguments []interface{}, times int) {
+	r.On("UpdateLastOccurrence", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func anyContext(callArguments []interface{}) []interface{} {
+	return append([]interface{}{mock.Anything}, callArguments...)
+}
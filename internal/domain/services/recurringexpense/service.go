@@ -0,0 +1,213 @@
+package recurringexpense
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/expense"
+	"finfit-backend/internal/domain/services/expensetype"
+	"finfit-backend/internal/domain/services/unitofwork"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	recurringExpenseNotFoundErrorMsg = "the recurring expense doesn't exists"
+	invalidExpenseTypeErrorMsg       = "the expense type doesn't exists"
+)
+
+type Repository interface {
+	Add(ctx context.Context, userId uuid.UUID, recurringExpense *models.RecurringExpense) (*models.RecurringExpense, error)
+	GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.RecurringExpense, error)
+	GetAll(ctx context.Context, userId uuid.UUID) ([]*models.RecurringExpense, error)
+	Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error
+	// UpdateLastOccurrence moves the last occurrence of the recurring expense forward to lastOccurrence, unless it's
+	// already there or later. It reports whether it moved it, which only one of the concurrent callers does.
+	UpdateLastOccurrence(ctx context.Context, userId uuid.UUID, id uuid.UUID, lastOccurrence time.Time) (bool, error)
+}
+
+// Repositories are the ones Generate claims the occurrences in and adds their expenses to, bound to the same
+// transaction by the unit of work of the service.
+type Repositories struct {
+	RecurringExpenses Repository
+	Expenses          expense.Repository
+}
+
+// Service manages the recurring expenses of a user, which are generated in their personal ledger.
+type Service interface {
+	Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.RecurringExpense, error)
+	GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.RecurringExpense, error)
+	GetAll(ctx context.Context, userId uuid.UUID) ([]*models.RecurringExpense, error)
+	Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error
+	Generate(ctx context.Context, userId uuid.UUID, command *GenerateCommand) ([]*models.Expense, error)
+}
+
+type service struct {
+	repository         Repository
+	unitOfWork         unitofwork.UnitOfWork[Repositories]
+	expenseTypeService expensetype.Service
+}
+
+func NewService(repository Repository, unitOfWork unitofwork.UnitOfWork[Repositories], expenseTypeService expensetype.Service) *service {
+	return &service{repository: repository, unitOfWork: unitOfWork, expenseTypeService: expenseTypeService}
+}
+
+func (s service) Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.RecurringExpense, error) {
+	expenseType, err := s.expenseTypeService.GetById(ctx, userId, models.PersonalLedgerId(userId), command.expenseTypeId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	if expenseType == nil {
+		return nil, InvalidExpenseTypeError{Msg: invalidExpenseTypeErrorMsg}
+	}
+
+	recurringExpenseToAdd, err := s.mapAddCommandToRecurringExpense(command, expenseType)
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	addedRecurringExpense, err := s.repository.Add(ctx, userId, recurringExpenseToAdd)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return addedRecurringExpense, nil
+}
+
+func (s service) GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.RecurringExpense, error) {
+	storedRecurringExpense, err := s.repository.GetByID(ctx, userId, id)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return storedRecurringExpense, nil
+}
+
+func (s service) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.RecurringExpense, error) {
+	recurringExpenses, err := s.repository.GetAll(ctx, userId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return recurringExpenses, nil
+}
+
+func (s service) Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	storedRecurringExpense, err := s.repository.GetByID(ctx, userId, id)
+	if err != nil {
+		return UnexpectedError{Msg: err.Error()}
+	}
+
+	if storedRecurringExpense == nil {
+		return RecurringExpenseNotFoundError{Msg: recurringExpenseNotFoundErrorMsg}
+	}
+
+	if err = s.repository.Delete(ctx, userId, id); err != nil {
+		return UnexpectedError{Msg: err.Error()}
+	}
+
+	return nil
+}
+
+// Generate adds an expense for every occurrence of the user due up to the command date that wasn't generated yet. Each
+// expense is added in the same transaction that moves the last occurrence forward to it, so running it again for the
+// same date, resuming after a failure or running it concurrently doesn't create duplicates.
+func (s service) Generate(ctx context.Context, userId uuid.UUID, command *GenerateCommand) ([]*models.Expense, error) {
+	recurringExpenses, err := s.repository.GetAll(ctx, userId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	generatedExpenses := []*models.Expense{}
+	for _, recurringExpense := range recurringExpenses {
+		for _, occurrence := range recurringExpense.DueOccurrences(command.until) {
+			generatedExpense, err := s.generateExpense(ctx, userId, recurringExpense, occurrence)
+			if err != nil {
+				return generatedExpenses, err
+			}
+			if generatedExpense != nil {
+				generatedExpenses = append(generatedExpenses, generatedExpense)
+			}
+		}
+	}
+
+	return generatedExpenses, nil
+}
+
+// generateExpense claims the occurrence and adds its expense to the personal ledger of the user. It returns nil when
+// another run already claimed it.
+func (s service) generateExpense(ctx context.Context, userId uuid.UUID, recurringExpense *models.RecurringExpense, occurrence time.Time) (*models.Expense, error) {
+	expenseToGenerate, err := models.NewExpense(recurringExpense.Amount(), occurrence, recurringExpense.Description(), recurringExpense.ExpenseType())
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	var generatedExpense *models.Expense
+	err = s.unitOfWork.Do(ctx, func(repositories Repositories) error {
+		claimed, err := repositories.RecurringExpenses.UpdateLastOccurrence(ctx, userId, recurringExpense.Id(), occurrence)
+		if err != nil {
+			return UnexpectedError{Msg: err.Error()}
+		}
+
+		if !claimed {
+			return nil
+		}
+
+		if generatedExpense, err = repositories.Expenses.Add(ctx, models.PersonalLedgerId(userId), expenseToGenerate); err != nil {
+			return UnexpectedError{Msg: err.Error()}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return generatedExpense, nil
+}
+
+func (s service) mapAddCommandToRecurringExpense(command *AddCommand, expenseType *models.ExpenseType) (*models.RecurringExpense, error) {
+	amount, err := models.NewMoney(command.amount, command.currency)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := models.NewRecurrenceRule(models.RecurrenceFrequency(command.frequency), command.interval, command.startDate, command.endDate, command.count)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewRecurringExpense(amount, command.description, expenseType, schedule)
+}
+
+type UnexpectedError struct {
+	Msg string
+}
+
+func (receiver UnexpectedError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidDomainModelError struct {
+	Msg string
+}
+
+func (receiver InvalidDomainModelError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidExpenseTypeError struct {
+	Msg string
+}
+
+func (receiver InvalidExpenseTypeError) Error() string {
+	return receiver.Msg
+}
+
+type RecurringExpenseNotFoundError struct {
+	Msg string
+}
+
+func (receiver RecurringExpenseNotFoundError) Error() string {
+	return receiver.Msg
+}
@@ -0,0 +1,97 @@
+package recurringexpense
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type ServiceMock struct {
+	mock.Mock
+}
+
+func NewServiceMock() *ServiceMock {
+	return &ServiceMock{}
+}
+
+func (s *ServiceMock) Add(ctx context.Context, userId uuid.UUID, command *AddCommand) (*models.RecurringExpense, error) {
+	args := s.Called(ctx, userId, command)
+
+	err := args.Error(1)
+	recurringExpenseToReturn := args.Get(0)
+	if err == nil && recurringExpenseToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return recurringExpenseToReturn.(*models.RecurringExpense), nil
+	}
+}
+
+func (s *ServiceMock) GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.RecurringExpense, error) {
+	args := s.Called(ctx, userId, id)
+
+	err := args.Error(1)
+	recurringExpenseToReturn := args.Get(0)
+	if err == nil && recurringExpenseToReturn == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return recurringExpenseToReturn.(*models.RecurringExpense), nil
+	}
+}
+
+func (s *ServiceMock) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.RecurringExpense, error) {
+	args := s.Called(ctx, userId)
+
+	err := args.Error(1)
+	recurringExpenses := args.Get(0)
+	if err == nil && recurringExpenses == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return recurringExpenses.([]*models.RecurringExpense), nil
+	}
+}
+
+func (s *ServiceMock) Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	args := s.Called(ctx, userId, id)
+	return args.Error(0)
+}
+
+func (s *ServiceMock) Generate(ctx context.Context, userId uuid.UUID, command *GenerateCommand) ([]*models.Expense, error) {
+	args := s.Called(ctx, userId, command)
+
+	err := args.Error(1)
+	expenses := args.Get(0)
+	if err == nil && expenses == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return expenses.([]*models.Expense), nil
+	}
+}
+
+func (s *ServiceMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
+	s.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGetByID(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetById", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGetAll(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockDelete(callArguments, returnArguments []interface{}, times int) {
+	s.On("Delete", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGenerate(callArguments, returnArguments []interface{}, times int) {
+	s.On("Generate", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
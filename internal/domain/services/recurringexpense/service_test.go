@@ -0,0 +1,188 @@
+package recurringexpense_test
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/expense"
+	"finfit-backend/internal/domain/services/expensetype"
+	"finfit-backend/internal/domain/services/recurringexpense"
+	"finfit-backend/internal/domain/services/unitofwork"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type RecurringExpenseServiceTestSuite struct {
+	suite.Suite
+	userId                 uuid.UUID
+	repositoryMock         *recurringexpense.RepositoryMock
+	expenseRepositoryMock  *expense.RepositoryMock
+	expenseTypeServiceMock *expensetype.ServiceMock
+	service                recurringexpense.Service
+}
+
+func (suite *RecurringExpenseServiceTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.repositoryMock = recurringexpense.NewRepositoryMock()
+	suite.expenseRepositoryMock = expense.NewRepositoryMock()
+	suite.expenseTypeServiceMock = expensetype.NewServiceMock()
+	unitOfWork := unitofwork.WithoutTransaction(recurringexpense.Repositories{RecurringExpenses: suite.repositoryMock, Expenses: suite.expenseRepositoryMock})
+	suite.service = recurringexpense.NewService(suite.repositoryMock, unitOfWork, suite.expenseTypeServiceMock)
+	id := uuid.New()
+	pkg.NewUUID = func() uuid.UUID {
+		return id
+	}
+}
+
+func (suite *RecurringExpenseServiceTestSuite) TearDownSuite() {
+	pkg.NewUUID = uuid.New
+}
+
+func (suite *RecurringExpenseServiceTestSuite) TearDownTest() {
+	suite.repositoryMock.ExpectedCalls = nil
+	suite.repositoryMock.Calls = nil
+	suite.expenseRepositoryMock.ExpectedCalls = nil
+	suite.expenseRepositoryMock.Calls = nil
+	suite.expenseTypeServiceMock.ExpectedCalls = nil
+}
+
+func TestRecurringExpenseServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(RecurringExpenseServiceTestSuite))
+}
+
+func (suite *RecurringExpenseServiceTestSuite) TestGivenARecurringExpense_WhenAdd_ThenReturnCreatedRecurringExpense() {
+	expenseType, _ := models.NewExpenseTypeWithId(uuid.New(), "Rent")
+	amount, _ := models.NewMoney("800", "EUR")
+	schedule, _ := models.NewRecurrenceRule(models.MonthlyRecurrenceFrequency, 1, date(2022, 1, 1), time.Time{}, 12)
+	expectedRecurringExpense, _ := models.NewRecurringExpense(amount, "Rent", expenseType, schedule)
+	suite.expenseTypeServiceMock.MockGetByID([]interface{}{suite.userId, models.PersonalLedgerId(suite.userId), expenseType.Id()}, []interface{}{expenseType, nil}, 1)
+	suite.repositoryMock.MockAdd([]interface{}{suite.userId, expectedRecurringExpense}, []interface{}{expectedRecurringExpense, nil}, 1)
+
+	command, _ := recurringexpense.NewAddCommand("800", "EUR", " Rent ", expenseType.Id(), "monthly", 1, date(2022, 1, 1), time.Time{}, 12)
+	actualRecurringExpense, err := suite.service.Add(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedRecurringExpense, actualRecurringExpense)
+}
+
+func (suite *RecurringExpenseServiceTestSuite) TestGivenANonExistentExpenseType_WhenAdd_ThenReturnInvalidExpenseTypeError() {
+	expenseTypeId := uuid.New()
+	suite.expenseTypeServiceMock.MockGetByID([]interface{}{suite.userId, models.PersonalLedgerId(suite.userId), expenseTypeId}, []interface{}{nil, nil}, 1)
+
+	command, _ := recurringexpense.NewAddCommand("800", "EUR", "Rent", expenseTypeId, "monthly", 1, date(2022, 1, 1), time.Time{}, 0)
+	actualRecurringExpense, err := suite.service.Add(context.Background(), suite.userId, command)
+
+	assert.Nil(suite.T(), actualRecurringExpense)
+	assert.Equal(suite.T(), recurringexpense.InvalidExpenseTypeError{Msg: "the expense type doesn't exists"}, err)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "Add", mock.Anything, suite.userId, mock.Anything)
+}
+
+func (suite *RecurringExpenseServiceTestSuite) TestGivenAnEndDateAndACount_WhenNewAddCommand_ThenReturnError() {
+	_, err := recurringexpense.NewAddCommand("800", "EUR", "Rent", uuid.New(), "monthly", 1, date(2022, 1, 1), date(2022, 12, 1), 12)
+
+	require.Error(suite.T(), err)
+}
+
+func (suite *RecurringExpenseServiceTestSuite) TestGivenDueOccurrences_WhenGenerate_ThenAddAnExpenseForEachOneAndStoreTheLastOccurrence() {
+	recurringExpense := suite.getRecurringExpense(date(2022, 1, 5), date(2022, 1, 5))
+	suite.repositoryMock.MockGetAll([]interface{}{suite.userId}, []interface{}{[]*models.RecurringExpense{recurringExpense}, nil}, 1)
+	expectedExpenses := []*models.Expense{}
+	for _, occurrence := range []time.Time{date(2022, 2, 5), date(2022, 3, 5)} {
+		suite.repositoryMock.MockUpdateLastOccurrence([]interface{}{suite.userId, recurringExpense.Id(), occurrence}, []interface{}{true, nil}, 1)
+		expectedExpenses = append(expectedExpenses, suite.mockExpenseAdd(recurringExpense, occurrence, nil))
+	}
+
+	command, _ := recurringexpense.NewGenerateCommand(date(2022, 3, 10))
+	generatedExpenses, err := suite.service.Generate(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedExpenses, generatedExpenses)
+	suite.repositoryMock.AssertNumberOfCalls(suite.T(), "UpdateLastOccurrence", 2)
+}
+
+func (suite *RecurringExpenseServiceTestSuite) TestGivenOccurrencesAlreadyGenerated_WhenGenerate_ThenDoNotAddExpenses() {
+	recurringExpense := suite.getRecurringExpense(date(2022, 1, 5), date(2022, 3, 5))
+	suite.repositoryMock.MockGetAll([]interface{}{suite.userId}, []interface{}{[]*models.RecurringExpense{recurringExpense}, nil}, 1)
+
+	command, _ := recurringexpense.NewGenerateCommand(date(2022, 3, 10))
+	generatedExpenses, err := suite.service.Generate(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), generatedExpenses)
+	suite.expenseRepositoryMock.AssertNotCalled(suite.T(), "Add", mock.Anything, models.PersonalLedgerId(suite.userId), mock.Anything)
+}
+
+func (suite *RecurringExpenseServiceTestSuite) TestGivenAnOccurrenceGeneratedByAConcurrentRun_WhenGenerate_ThenDoNotAddItsExpense() {
+	recurringExpense := suite.getRecurringExpense(date(2022, 1, 5), date(2022, 2, 5))
+	suite.repositoryMock.MockGetAll([]interface{}{suite.userId}, []interface{}{[]*models.RecurringExpense{recurringExpense}, nil}, 1)
+	suite.repositoryMock.MockUpdateLastOccurrence([]interface{}{suite.userId, recurringExpense.Id(), date(2022, 3, 5)}, []interface{}{false, nil}, 1)
+
+	command, _ := recurringexpense.NewGenerateCommand(date(2022, 3, 10))
+	generatedExpenses, err := suite.service.Generate(context.Background(), suite.userId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), generatedExpenses)
+	suite.expenseRepositoryMock.AssertNotCalled(suite.T(), "Add", mock.Anything, models.PersonalLedgerId(suite.userId), mock.Anything)
+}
+
+func (suite *RecurringExpenseServiceTestSuite) TestGivenThatFailToAddTheExpense_WhenGenerate_ThenReturnErrorAndStopGenerating() {
+	recurringExpense := suite.getRecurringExpense(date(2022, 1, 5), date(2022, 1, 5))
+	suite.repositoryMock.MockGetAll([]interface{}{suite.userId}, []interface{}{[]*models.RecurringExpense{recurringExpense}, nil}, 1)
+	suite.repositoryMock.MockUpdateLastOccurrence([]interface{}{suite.userId, recurringExpense.Id(), date(2022, 2, 5)}, []interface{}{true, nil}, 1)
+	suite.mockExpenseAdd(recurringExpense, date(2022, 2, 5), errors.New("fail"))
+
+	command, _ := recurringexpense.NewGenerateCommand(date(2022, 3, 10))
+	generatedExpenses, err := suite.service.Generate(context.Background(), suite.userId, command)
+
+	assert.Empty(suite.T(), generatedExpenses)
+	assert.Equal(suite.T(), recurringexpense.UnexpectedError{Msg: "fail"}, err)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "UpdateLastOccurrence", mock.Anything, suite.userId, recurringExpense.Id(), date(2022, 3, 5))
+}
+
+func (suite *RecurringExpenseServiceTestSuite) TestGivenThatFailToStoreTheLastOccurrence_WhenGenerateAgain_ThenAddItsExpenseOnce() {
+	recurringExpense := suite.getRecurringExpense(date(2022, 1, 5), date(2022, 2, 5))
+	suite.repositoryMock.MockGetAll([]interface{}{suite.userId}, []interface{}{[]*models.RecurringExpense{recurringExpense}, nil}, 2)
+	suite.repositoryMock.MockUpdateLastOccurrence([]interface{}{suite.userId, recurringExpense.Id(), date(2022, 3, 5)}, []interface{}{false, errors.New("fail")}, 1)
+	suite.repositoryMock.MockUpdateLastOccurrence([]interface{}{suite.userId, recurringExpense.Id(), date(2022, 3, 5)}, []interface{}{true, nil}, 1)
+	expectedExpense := suite.mockExpenseAdd(recurringExpense, date(2022, 3, 5), nil)
+	command, _ := recurringexpense.NewGenerateCommand(date(2022, 3, 10))
+
+	generatedExpenses, err := suite.service.Generate(context.Background(), suite.userId, command)
+	assert.Empty(suite.T(), generatedExpenses)
+	assert.Equal(suite.T(), recurringexpense.UnexpectedError{Msg: "fail"}, err)
+	suite.expenseRepositoryMock.AssertNotCalled(suite.T(), "Add", mock.Anything, models.PersonalLedgerId(suite.userId), mock.Anything)
+
+	generatedExpenses, err = suite.service.Generate(context.Background(), suite.userId, command)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []*models.Expense{expectedExpense}, generatedExpenses)
+	suite.expenseRepositoryMock.AssertNumberOfCalls(suite.T(), "Add", 1)
+}
+
+func (suite *RecurringExpenseServiceTestSuite) getRecurringExpense(startDate time.Time, lastOccurrence time.Time) *models.RecurringExpense {
+	expenseType, _ := models.NewExpenseTypeWithId(uuid.New(), "Rent")
+	amount, _ := models.NewMoney("800", "EUR")
+	schedule, _ := models.NewRecurrenceRule(models.MonthlyRecurrenceFrequency, 1, startDate, time.Time{}, 0)
+	recurringExpense, _ := models.NewRecurringExpenseWithId(uuid.New(), amount, "Rent", expenseType, schedule, lastOccurrence)
+	return recurringExpense
+}
+
+func (suite *RecurringExpenseServiceTestSuite) mockExpenseAdd(recurringExpense *models.RecurringExpense, occurrence time.Time, err error) *models.Expense {
+	expenseToAdd, _ := models.NewExpense(recurringExpense.Amount(), occurrence, "Rent", recurringExpense.ExpenseType())
+	if err != nil {
+		suite.expenseRepositoryMock.MockAdd([]interface{}{models.PersonalLedgerId(suite.userId), expenseToAdd}, []interface{}{nil, err}, 1)
+		return nil
+	}
+
+	suite.expenseRepositoryMock.MockAdd([]interface{}{models.PersonalLedgerId(suite.userId), expenseToAdd}, []interface{}{expenseToAdd, nil}, 1)
+	return expenseToAdd
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,104 @@
+package report
+
+import (
+	"finfit-backend/internal/domain/models"
+	"time"
+)
+
+type convertedGroup struct {
+	label string
+	total *models.Money
+	count int64
+}
+
+// convertedAggregation adds up converted spending entries by group keeping the order in which groups first appear,
+// and collects the distinct rates used and missing.
+type convertedAggregation struct {
+	targetCurrency string
+	keys           []string
+	groups         map[string]*convertedGroup
+	rates          []*models.ExchangeRate
+	missingRates   []*models.MissingRate
+	seen           map[string]bool
+}
+
+func newConvertedAggregation(targetCurrency string) *convertedAggregation {
+	return &convertedAggregation{
+		targetCurrency: targetCurrency,
+		groups:         map[string]*convertedGroup{},
+		rates:          []*models.ExchangeRate{},
+		missingRates:   []*models.MissingRate{},
+		seen:           map[string]bool{},
+	}
+}
+
+func (c *convertedAggregation) add(entry *models.SpendingEntry, conversion *models.Conversion) error {
+	if conversion.IsMissingRate() {
+		if c.isFirstSeen("missing", entry.Total().Currency(), c.targetCurrency, entry.Date()) {
+			c.missingRates = append(c.missingRates, models.NewMissingRate(entry.Total().Currency(), entry.Date()))
+		}
+		return nil
+	}
+
+	if rate := conversion.Rate(); rate != nil && c.isFirstSeen("rate", rate.BaseCurrency(), rate.QuoteCurrency(), rate.Date()) {
+		c.rates = append(c.rates, rate)
+	}
+
+	group, ok := c.groups[entry.Key()]
+	if !ok {
+		zero, _ := models.NewMoneyFromMinorUnits(0, c.targetCurrency)
+		group = &convertedGroup{label: entry.Label(), total: zero}
+		c.groups[entry.Key()] = group
+		c.keys = append(c.keys, entry.Key())
+	}
+
+	total, err := group.total.Add(conversion.Amount())
+	if err != nil {
+		return err
+	}
+	group.total = total
+	group.count += entry.Count()
+	return nil
+}
+
+func (c *convertedAggregation) isFirstSeen(kind string, baseCurrency string, quoteCurrency string, date time.Time) bool {
+	key := kind + baseCurrency + quoteCurrency + date.Format("2006-01-02")
+	if c.seen[key] {
+		return false
+	}
+	c.seen[key] = true
+	return true
+}
+
+func (c *convertedAggregation) currencySpending() ([]*models.CurrencySpending, error) {
+	if len(c.keys) == 0 {
+		return []*models.CurrencySpending{}, nil
+	}
+
+	currencyTotal, _ := models.NewMoneyFromMinorUnits(0, c.targetCurrency)
+	var currencyCount int64
+	for _, key := range c.keys {
+		var err error
+		if currencyTotal, err = currencyTotal.Add(c.groups[key].total); err != nil {
+			return nil, err
+		}
+		currencyCount += c.groups[key].count
+	}
+
+	spendingGroups := []*models.SpendingGroup{}
+	for _, key := range c.keys {
+		group := c.groups[key]
+		spendingGroup, err := models.NewSpendingGroup(key, group.label, group.total, group.count, currencyTotal)
+		if err != nil {
+			return nil, err
+		}
+		spendingGroups = append(spendingGroups, spendingGroup)
+	}
+
+	currencySpending, err := models.NewCurrencySpending(currencyTotal, currencyCount, spendingGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*models.CurrencySpending{currencySpending}, nil
+}
@@ -0,0 +1,57 @@
+package report
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+// GetSpendingCommand asks for the expenses between two dates aggregated by the given grouping. An empty currency
+// means that the report includes every currency, an empty target currency that amounts aren't converted and a nil
+// expense type that the report includes every expense type.
+type GetSpendingCommand struct {
+	startDate      time.Time
+	endDate        time.Time
+	groupBy        models.ReportGrouping
+	currency       string
+	targetCurrency string
+	expenseTypeId  uuid.UUID
+}
+
+func NewGetSpendingCommand(startDate time.Time, endDate time.Time, groupBy string, currency string) (*GetSpendingCommand, error) {
+	if startDate.IsZero() || endDate.IsZero() || endDate.Before(startDate) || !models.IsValidReportGrouping(groupBy) {
+		return nil, errors.New("invalid command")
+	}
+
+	if !isValidOptionalCurrency(currency) {
+		return nil, errors.New("invalid command")
+	}
+
+	return &GetSpendingCommand{startDate: startDate, endDate: endDate, groupBy: models.ReportGrouping(groupBy), currency: currency}, nil
+}
+
+// WithTargetCurrency returns a copy of the command that asks for every amount converted to the given currency.
+func (g GetSpendingCommand) WithTargetCurrency(targetCurrency string) (*GetSpendingCommand, error) {
+	if !isValidOptionalCurrency(targetCurrency) {
+		return nil, errors.New("invalid command")
+	}
+
+	g.targetCurrency = targetCurrency
+	return &g, nil
+}
+
+// WithExpenseType returns a copy of the command that only reports the expenses of the given type or any of its
+// subtypes.
+func (g GetSpendingCommand) WithExpenseType(expenseTypeId uuid.UUID) *GetSpendingCommand {
+	g.expenseTypeId = expenseTypeId
+	return &g
+}
+
+func isValidOptionalCurrency(currency string) bool {
+	if currency == "" {
+		return true
+	}
+	_, err := models.NewMoneyFromMinorUnits(0, currency)
+	return err == nil
+}
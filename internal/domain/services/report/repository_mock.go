@@ -0,0 +1,57 @@
+package report
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func NewRepositoryMock() *RepositoryMock {
+	return &RepositoryMock{}
+}
+
+func (r *RepositoryMock) GetSpending(ctx context.Context, ledgerId uuid.UUID, startDate time.Time, endDate time.Time, groupBy models.ReportGrouping, currency string, expenseTypeIds []uuid.UUID) ([]*models.CurrencySpending, error) {
+	args := r.Called(ctx, ledgerId, startDate, endDate, groupBy, currency, expenseTypeIds)
+
+	err := args.Error(1)
+	spending := args.Get(0)
+	if err == nil && spending == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return spending.([]*models.CurrencySpending), nil
+	}
+}
+
+func (r *RepositoryMock) GetDailySpending(ctx context.Context, ledgerId uuid.UUID, startDate time.Time, endDate time.Time, groupBy models.ReportGrouping, currency string, expenseTypeIds []uuid.UUID) ([]*models.SpendingEntry, error) {
+	args := r.Called(ctx, ledgerId, startDate, endDate, groupBy, currency, expenseTypeIds)
+
+	err := args.Error(1)
+	entries := args.Get(0)
+	if err == nil && entries == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return entries.([]*models.SpendingEntry), nil
+	}
+}
+
+func (r *RepositoryMock) MockGetSpending(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetSpending", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetDailySpending(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetDailySpending", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func anyContext(callArguments []interface{}) []interface{} {
+	return append([]interface{}{mock.Anything}, callArguments...)
+}
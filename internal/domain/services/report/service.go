@@ -0,0 +1,126 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/exchangerate"
+	"finfit-backend/internal/domain/services/expensetype"
+	"finfit-backend/internal/domain/services/ledger"
+	"github.com/google/uuid"
+	"time"
+)
+
+const invalidExpenseTypeErrorMsg = "the expense type doesn't exists"
+
+// Repository aggregates the expenses of a ledger, of any type when expenseTypeIds is empty.
+type Repository interface {
+	GetSpending(ctx context.Context, ledgerId uuid.UUID, startDate time.Time, endDate time.Time, groupBy models.ReportGrouping, currency string, expenseTypeIds []uuid.UUID) ([]*models.CurrencySpending, error)
+	GetDailySpending(ctx context.Context, ledgerId uuid.UUID, startDate time.Time, endDate time.Time, groupBy models.ReportGrouping, currency string, expenseTypeIds []uuid.UUID) ([]*models.SpendingEntry, error)
+}
+
+// Service reports on the expenses of a ledger. It checks that the user can view the ledger first and returns the errors
+// of the ledger service when they can't.
+type Service interface {
+	GetSpending(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *GetSpendingCommand) (*models.SpendingReport, error)
+}
+
+type service struct {
+	repository          Repository
+	exchangeRateService exchangerate.Service
+	expenseTypeService  expensetype.Service
+	ledgerService       ledger.Service
+}
+
+func NewService(repository Repository, exchangeRateService exchangerate.Service, expenseTypeService expensetype.Service, ledgerService ledger.Service) *service {
+	return &service{repository: repository, exchangeRateService: exchangeRateService, expenseTypeService: expenseTypeService, ledgerService: ledgerService}
+}
+
+func (s service) GetSpending(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *GetSpendingCommand) (*models.SpendingReport, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.ViewerLedgerRole); err != nil {
+		return nil, err
+	}
+
+	expenseTypeIds, err := s.getExpenseTypeSubtreeIds(ctx, userId, ledgerId, command.expenseTypeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if command.targetCurrency != "" {
+		return s.getConvertedSpending(ctx, ledgerId, command, expenseTypeIds)
+	}
+
+	spending, err := s.repository.GetSpending(ctx, ledgerId, command.startDate, command.endDate, command.groupBy, command.currency, expenseTypeIds)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return models.NewSpendingReport(spending, []*models.ExchangeRate{}, []*models.MissingRate{}), nil
+}
+
+// getConvertedSpending converts the daily totals of every group at the rate of their day and adds them up in the
+// target currency. Days without a rate are left out of the totals and reported as missing.
+func (s service) getConvertedSpending(ctx context.Context, ledgerId uuid.UUID, command *GetSpendingCommand, expenseTypeIds []uuid.UUID) (*models.SpendingReport, error) {
+	entries, err := s.repository.GetDailySpending(ctx, ledgerId, command.startDate, command.endDate, command.groupBy, command.currency, expenseTypeIds)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	aggregation := newConvertedAggregation(command.targetCurrency)
+	for _, entry := range entries {
+		conversion, err := s.exchangeRateService.Convert(ctx, entry.Total(), command.targetCurrency, entry.Date())
+		if err != nil {
+			return nil, UnexpectedError{Msg: err.Error()}
+		}
+
+		if err = aggregation.add(entry, conversion); err != nil {
+			return nil, UnexpectedError{Msg: err.Error()}
+		}
+	}
+
+	spending, err := aggregation.currencySpending()
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return models.NewSpendingReport(spending, aggregation.rates, aggregation.missingRates), nil
+}
+
+// getExpenseTypeSubtreeIds returns the ids of the expense type and all its subtypes in the ledger, so the report rolls
+// up the whole subtree. It returns nil when the report isn't limited to an expense type.
+func (s service) getExpenseTypeSubtreeIds(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, expenseTypeId uuid.UUID) ([]uuid.UUID, error) {
+	if expenseTypeId == uuid.Nil {
+		return nil, nil
+	}
+
+	subtree, err := s.expenseTypeService.GetSubtree(ctx, userId, ledgerId, expenseTypeId)
+	if errors.As(err, &expensetype.ExpenseTypeNotFoundError{}) {
+		return nil, InvalidExpenseTypeError{Msg: invalidExpenseTypeErrorMsg}
+	}
+
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	expenseTypeIds := []uuid.UUID{}
+	for _, expenseType := range subtree {
+		expenseTypeIds = append(expenseTypeIds, expenseType.Id())
+	}
+	return expenseTypeIds, nil
+}
+
+type InvalidExpenseTypeError struct {
+	Msg string
+}
+
+func (receiver InvalidExpenseTypeError) Error() string {
+	return receiver.Msg
+}
+
+type UnexpectedError struct {
+	Msg string
+}
+
+func (receiver UnexpectedError) Error() string {
+	return receiver.Msg
+}
@@ -0,0 +1,34 @@
+package report
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type ServiceMock struct {
+	mock.Mock
+}
+
+func NewServiceMock() *ServiceMock {
+	return &ServiceMock{}
+}
+
+func (s *ServiceMock) GetSpending(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *GetSpendingCommand) (*models.SpendingReport, error) {
+	args := s.Called(ctx, userId, ledgerId, command)
+
+	err := args.Error(1)
+	spendingReport := args.Get(0)
+	if err == nil && spendingReport == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return spendingReport.(*models.SpendingReport), nil
+	}
+}
+
+func (s *ServiceMock) MockGetSpending(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetSpending", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
@@ -0,0 +1,182 @@
+package report_test
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/exchangerate"
+	"finfit-backend/internal/domain/services/expensetype"
+	"finfit-backend/internal/domain/services/ledger"
+	"finfit-backend/internal/domain/services/report"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type ReportServiceTestSuite struct {
+	suite.Suite
+	userId                  uuid.UUID
+	ledgerId                uuid.UUID
+	repositoryMock          *report.RepositoryMock
+	exchangeRateServiceMock *exchangerate.ServiceMock
+	expenseTypeServiceMock  *expensetype.ServiceMock
+	ledgerServiceMock       *ledger.ServiceMock
+	service                 report.Service
+}
+
+func (suite *ReportServiceTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.ledgerId = uuid.New()
+	suite.repositoryMock = report.NewRepositoryMock()
+	suite.exchangeRateServiceMock = exchangerate.NewServiceMock()
+	suite.expenseTypeServiceMock = expensetype.NewServiceMock()
+	suite.ledgerServiceMock = ledger.NewServiceMock()
+	suite.service = report.NewService(suite.repositoryMock, suite.exchangeRateServiceMock, suite.expenseTypeServiceMock, suite.ledgerServiceMock)
+}
+
+func (suite *ReportServiceTestSuite) SetupTest() {
+	suite.ledgerServiceMock.MockAuthorize([]interface{}{suite.userId, suite.ledgerId, models.ViewerLedgerRole}, []interface{}{nil}, 0)
+}
+
+func (suite *ReportServiceTestSuite) TearDownTest() {
+	suite.repositoryMock.ExpectedCalls = nil
+	suite.repositoryMock.Calls = nil
+	suite.exchangeRateServiceMock.ExpectedCalls = nil
+	suite.exchangeRateServiceMock.Calls = nil
+	suite.expenseTypeServiceMock.ExpectedCalls = nil
+	suite.expenseTypeServiceMock.Calls = nil
+	suite.ledgerServiceMock.ExpectedCalls = nil
+	suite.ledgerServiceMock.Calls = nil
+}
+
+func TestReportServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportServiceTestSuite))
+}
+
+func (suite *ReportServiceTestSuite) TestGivenAPeriod_WhenGetSpending_ThenReturnRepositoryAggregation() {
+	startDate := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC)
+	total, _ := models.NewMoney("30", "EUR")
+	group, _ := models.NewSpendingGroup("2022-03", "2022-03", total, 3, total)
+	currencySpending, _ := models.NewCurrencySpending(total, 3, []*models.SpendingGroup{group})
+	expectedSpending := []*models.CurrencySpending{currencySpending}
+	suite.repositoryMock.MockGetSpending([]interface{}{suite.ledgerId, startDate, endDate, models.MonthReportGrouping, "EUR", []uuid.UUID(nil)}, []interface{}{expectedSpending, nil}, 1)
+
+	command, _ := report.NewGetSpendingCommand(startDate, endDate, "month", "EUR")
+	spendingReport, err := suite.service.GetSpending(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedSpending, spendingReport.Currencies())
+	assert.Empty(suite.T(), spendingReport.MissingRates())
+}
+
+func (suite *ReportServiceTestSuite) TestGivenThatRepositoryFails_WhenGetSpending_ThenReturnUnexpectedError() {
+	startDate := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC)
+	suite.repositoryMock.MockGetSpending([]interface{}{suite.ledgerId, startDate, endDate, models.DayReportGrouping, "", []uuid.UUID(nil)}, []interface{}{nil, errors.New("fail")}, 1)
+
+	command, _ := report.NewGetSpendingCommand(startDate, endDate, "day", "")
+	spendingReport, err := suite.service.GetSpending(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.ErrorAs(suite.T(), err, &report.UnexpectedError{})
+	require.Nil(suite.T(), spendingReport)
+}
+
+func (suite *ReportServiceTestSuite) TestGivenAnExpenseType_WhenGetSpending_ThenAggregateTheExpensesOfItsWholeSubtree() {
+	startDate := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC)
+	food, _ := models.NewExpenseTypeWithId(uuid.New(), "Food")
+	delivery, _ := models.NewExpenseTypeWithId(uuid.New(), "Delivery")
+	delivery, _ = delivery.WithParent(food)
+	total, _ := models.NewMoney("30", "EUR")
+	group, _ := models.NewSpendingGroup("2022-03", "2022-03", total, 3, total)
+	currencySpending, _ := models.NewCurrencySpending(total, 3, []*models.SpendingGroup{group})
+	expectedSpending := []*models.CurrencySpending{currencySpending}
+	suite.expenseTypeServiceMock.MockGetSubtree([]interface{}{suite.userId, suite.ledgerId, food.Id()}, []interface{}{[]*models.ExpenseType{food, delivery}, nil}, 1)
+	suite.repositoryMock.MockGetSpending([]interface{}{suite.ledgerId, startDate, endDate, models.MonthReportGrouping, "", []uuid.UUID{food.Id(), delivery.Id()}}, []interface{}{expectedSpending, nil}, 1)
+
+	command, _ := report.NewGetSpendingCommand(startDate, endDate, "month", "")
+	spendingReport, err := suite.service.GetSpending(context.Background(), suite.userId, suite.ledgerId, command.WithExpenseType(food.Id()))
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedSpending, spendingReport.Currencies())
+}
+
+func (suite *ReportServiceTestSuite) TestGivenANonExistentExpenseType_WhenGetSpending_ThenReturnInvalidExpenseTypeError() {
+	startDate := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC)
+	expenseTypeId := uuid.New()
+	suite.expenseTypeServiceMock.MockGetSubtree([]interface{}{suite.userId, suite.ledgerId, expenseTypeId}, []interface{}{nil, expensetype.ExpenseTypeNotFoundError{Msg: "not found"}}, 1)
+
+	command, _ := report.NewGetSpendingCommand(startDate, endDate, "month", "")
+	spendingReport, err := suite.service.GetSpending(context.Background(), suite.userId, suite.ledgerId, command.WithExpenseType(expenseTypeId))
+
+	require.ErrorAs(suite.T(), err, &report.InvalidExpenseTypeError{})
+	require.Nil(suite.T(), spendingReport)
+}
+
+func (suite *ReportServiceTestSuite) TestGivenATargetCurrency_WhenGetSpending_ThenConvertEveryDayAtItsRate() {
+	startDate := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC)
+	firstDay := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	secondDay := time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC)
+	arsTotal, _ := models.NewMoney("1000", "ARS")
+	usdTotal, _ := models.NewMoney("10", "USD")
+	otherArsTotal, _ := models.NewMoney("500", "ARS")
+	arsEntry, _ := models.NewSpendingEntry("food", "Food", firstDay, arsTotal, 2)
+	usdEntry, _ := models.NewSpendingEntry("food", "Food", firstDay, usdTotal, 1)
+	missingEntry, _ := models.NewSpendingEntry("rent", "Rent", secondDay, otherArsTotal, 1)
+	suite.repositoryMock.MockGetDailySpending([]interface{}{suite.ledgerId, startDate, endDate, models.ExpenseTypeReportGrouping, "", []uuid.UUID(nil)}, []interface{}{[]*models.SpendingEntry{arsEntry, usdEntry, missingEntry}, nil}, 1)
+	rate, _ := models.NewExchangeRate("USD", "ARS", firstDay, "100")
+	arsConversion, _ := models.NewConversion(arsTotal, "USD", rate)
+	usdConversion, _ := models.NewConversion(usdTotal, "USD", nil)
+	missingConversion, _ := models.NewConversion(otherArsTotal, "USD", nil)
+	suite.exchangeRateServiceMock.MockConvert([]interface{}{arsTotal, "USD", firstDay}, []interface{}{arsConversion, nil}, 1)
+	suite.exchangeRateServiceMock.MockConvert([]interface{}{usdTotal, "USD", firstDay}, []interface{}{usdConversion, nil}, 1)
+	suite.exchangeRateServiceMock.MockConvert([]interface{}{otherArsTotal, "USD", secondDay}, []interface{}{missingConversion, nil}, 1)
+
+	command, _ := report.NewGetSpendingCommand(startDate, endDate, "expense_type", "")
+	command, _ = command.WithTargetCurrency("USD")
+	spendingReport, err := suite.service.GetSpending(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), spendingReport.Currencies(), 1)
+	currencySpending := spendingReport.Currencies()[0]
+	assert.Equal(suite.T(), "USD", currencySpending.Currency())
+	assert.Equal(suite.T(), "20.00", currencySpending.Total().Amount())
+	assert.Equal(suite.T(), int64(3), currencySpending.Count())
+	require.Len(suite.T(), currencySpending.Groups(), 1)
+	assert.Equal(suite.T(), "Food", currencySpending.Groups()[0].Label())
+	assert.Equal(suite.T(), []*models.ExchangeRate{rate}, spendingReport.Rates())
+	assert.Equal(suite.T(), []*models.MissingRate{models.NewMissingRate("ARS", secondDay)}, spendingReport.MissingRates())
+}
+
+func (suite *ReportServiceTestSuite) TestGivenAUserOutsideTheLedger_WhenGetSpending_ThenReturnLedgerNotFoundErrorWithoutReadingIt() {
+	otherLedgerId := uuid.New()
+	suite.ledgerServiceMock.MockAuthorize([]interface{}{suite.userId, otherLedgerId, models.ViewerLedgerRole}, []interface{}{ledger.LedgerNotFoundError{Msg: "not found"}}, 1)
+
+	command, _ := report.NewGetSpendingCommand(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC), "month", "")
+	spendingReport, err := suite.service.GetSpending(context.Background(), suite.userId, otherLedgerId, command)
+
+	require.ErrorAs(suite.T(), err, &ledger.LedgerNotFoundError{})
+	require.Nil(suite.T(), spendingReport)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "GetSpending", mock.Anything, otherLedgerId, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ReportServiceTestSuite) TestGivenInvalidArguments_WhenNewGetSpendingCommand_ThenReturnError() {
+	startDate := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	_, err := report.NewGetSpendingCommand(endDate, startDate, "day", "")
+	require.Error(suite.T(), err)
+
+	_, err = report.NewGetSpendingCommand(startDate, endDate, "quarter", "")
+	require.Error(suite.T(), err)
+
+	_, err = report.NewGetSpendingCommand(startDate, endDate, "day", "EURO")
+	require.Error(suite.T(), err)
+}
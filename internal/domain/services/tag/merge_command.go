@@ -0,0 +1,28 @@
+package tag
+
+import (
+	"errors"
+	"github.com/google/uuid"
+)
+
+// MergeCommand moves every expense tagged with the source tags to the target tag and deletes the source tags.
+type MergeCommand struct {
+	targetId  uuid.UUID
+	sourceIds []uuid.UUID
+}
+
+func NewMergeCommand(targetId uuid.UUID, sourceIds []uuid.UUID) (*MergeCommand, error) {
+	if targetId == uuid.Nil || len(sourceIds) == 0 {
+		return nil, errors.New("invalid command")
+	}
+
+	uniqueSourceIds := map[uuid.UUID]bool{}
+	for _, sourceId := range sourceIds {
+		if sourceId == uuid.Nil || sourceId == targetId || uniqueSourceIds[sourceId] {
+			return nil, errors.New("invalid command")
+		}
+		uniqueSourceIds[sourceId] = true
+	}
+
+	return &MergeCommand{targetId: targetId, sourceIds: sourceIds}, nil
+}
@@ -0,0 +1,20 @@
+package tag
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type RenameCommand struct {
+	id   uuid.UUID
+	name string
+}
+
+func NewRenameCommand(id uuid.UUID, name string) (*RenameCommand, error) {
+	name = models.NormalizeTagName(name)
+	if id == uuid.Nil || name == "" {
+		return nil, errors.New("invalid command")
+	}
+	return &RenameCommand{id: id, name: name}, nil
+}
@@ -0,0 +1,101 @@
+package tag
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func NewRepositoryMock() *RepositoryMock {
+	return &RepositoryMock{}
+}
+
+func (r *RepositoryMock) GetAll(ctx context.Context, ledgerId uuid.UUID) ([]*models.Tag, error) {
+	args := r.Called(ctx, ledgerId)
+
+	err := args.Error(1)
+	tags := args.Get(0)
+	if err == nil && tags == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return tags.([]*models.Tag), nil
+	}
+}
+
+func (r *RepositoryMock) GetByID(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (*models.Tag, error) {
+	args := r.Called(ctx, ledgerId, id)
+
+	err := args.Error(1)
+	tag := args.Get(0)
+	if err == nil && tag == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return tag.(*models.Tag), nil
+	}
+}
+
+func (r *RepositoryMock) GetByName(ctx context.Context, ledgerId uuid.UUID, name string) (*models.Tag, error) {
+	args := r.Called(ctx, ledgerId, name)
+
+	err := args.Error(1)
+	tag := args.Get(0)
+	if err == nil && tag == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return tag.(*models.Tag), nil
+	}
+}
+
+func (r *RepositoryMock) Update(ctx context.Context, ledgerId uuid.UUID, tag *models.Tag) (*models.Tag, error) {
+	args := r.Called(ctx, ledgerId, tag)
+
+	err := args.Error(1)
+	updatedTag := args.Get(0)
+	if err == nil && updatedTag == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return updatedTag.(*models.Tag), nil
+	}
+}
+
+func (r *RepositoryMock) Merge(ctx context.Context, ledgerId uuid.UUID, targetId uuid.UUID, sourceIds []uuid.UUID) error {
+	args := r.Called(ctx, ledgerId, targetId, sourceIds)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) MockGetAll(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetByID(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetByID", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetByName(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetByName", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockUpdate(callArguments, returnArguments []interface{}, times int) {
+	r.On("Update", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockMerge(callArguments, returnArguments []interface{}, times int) {
+	r.On("Merge", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func anyContext(callArguments []interface{}) []interface{} {
+	return append([]interface{}{mock.Anything}, callArguments...)
+}
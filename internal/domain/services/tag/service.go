@@ -0,0 +1,159 @@
+package tag
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/ledger"
+	"github.com/google/uuid"
+)
+
+const (
+	tagNotFoundErrorMsg      = "the tag doesn't exists"
+	tagAlreadyExistsErrorMsg = "a tag with the same name already exists, merge both tags instead"
+)
+
+type Repository interface {
+	GetAll(ctx context.Context, ledgerId uuid.UUID) ([]*models.Tag, error)
+	GetByID(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (*models.Tag, error)
+	GetByName(ctx context.Context, ledgerId uuid.UUID, name string) (*models.Tag, error)
+	Update(ctx context.Context, ledgerId uuid.UUID, tag *models.Tag) (*models.Tag, error)
+	// Merge moves the expenses of the source tags to the target one and deletes the source tags in a single
+	// transaction, so no expense loses its tag if it fails.
+	Merge(ctx context.Context, ledgerId uuid.UUID, targetId uuid.UUID, sourceIds []uuid.UUID) error
+}
+
+// Service works on the tags of a ledger. Tags are created along with the expenses that use them, so there's no way to
+// add one alone. Every method checks the role of the user in the ledger first and returns the errors of the ledger
+// service when it isn't enough.
+type Service interface {
+	GetAll(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID) ([]*models.Tag, error)
+	Rename(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *RenameCommand) (*models.Tag, error)
+	Merge(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *MergeCommand) (*models.Tag, error)
+}
+
+type service struct {
+	repository    Repository
+	ledgerService ledger.Service
+}
+
+func NewService(repository Repository, ledgerService ledger.Service) *service {
+	return &service{repository: repository, ledgerService: ledgerService}
+}
+
+func (s service) GetAll(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID) ([]*models.Tag, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.ViewerLedgerRole); err != nil {
+		return nil, err
+	}
+
+	tags, err := s.repository.GetAll(ctx, ledgerId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return tags, nil
+}
+
+func (s service) Rename(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *RenameCommand) (*models.Tag, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.EditorLedgerRole); err != nil {
+		return nil, err
+	}
+
+	storedTag, err := s.getTag(ctx, ledgerId, command.id)
+	if err != nil {
+		return nil, err
+	}
+
+	renamedTag, err := storedTag.WithName(command.name)
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	if renamedTag.Name() == storedTag.Name() {
+		return storedTag, nil
+	}
+
+	tagWithSameName, err := s.repository.GetByName(ctx, ledgerId, renamedTag.Name())
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	if tagWithSameName != nil {
+		return nil, TagAlreadyExistsError{Msg: tagAlreadyExistsErrorMsg}
+	}
+
+	updatedTag, err := s.repository.Update(ctx, ledgerId, renamedTag)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return updatedTag, nil
+}
+
+// Merge returns the target tag, which keeps its name.
+func (s service) Merge(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *MergeCommand) (*models.Tag, error) {
+	if err := s.ledgerService.Authorize(ctx, userId, ledgerId, models.EditorLedgerRole); err != nil {
+		return nil, err
+	}
+
+	targetTag, err := s.getTag(ctx, ledgerId, command.targetId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sourceId := range command.sourceIds {
+		if _, err = s.getTag(ctx, ledgerId, sourceId); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.repository.Merge(ctx, ledgerId, command.targetId, command.sourceIds); err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return targetTag, nil
+}
+
+func (s service) getTag(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (*models.Tag, error) {
+	tag, err := s.repository.GetByID(ctx, ledgerId, id)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	if tag == nil {
+		return nil, TagNotFoundError{Msg: tagNotFoundErrorMsg}
+	}
+
+	return tag, nil
+}
+
+type UnexpectedError struct {
+	Msg string
+}
+
+func (receiver UnexpectedError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidDomainModelError struct {
+	Msg string
+}
+
+func (receiver InvalidDomainModelError) Error() string {
+	return receiver.Msg
+}
+
+type TagNotFoundError struct {
+	Msg string
+}
+
+func (receiver TagNotFoundError) Error() string {
+	return receiver.Msg
+}
+
+type TagAlreadyExistsError struct {
+	Msg string
+}
+
+func (receiver TagAlreadyExistsError) Error() string {
+	return receiver.Msg
+}
@@ -0,0 +1,70 @@
+package tag
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type ServiceMock struct {
+	mock.Mock
+}
+
+func NewServiceMock() *ServiceMock {
+	return &ServiceMock{}
+}
+
+func (s *ServiceMock) GetAll(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID) ([]*models.Tag, error) {
+	args := s.Called(ctx, userId, ledgerId)
+
+	err := args.Error(1)
+	tags := args.Get(0)
+	if err == nil && tags == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return tags.([]*models.Tag), nil
+	}
+}
+
+func (s *ServiceMock) Rename(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *RenameCommand) (*models.Tag, error) {
+	args := s.Called(ctx, userId, ledgerId, command)
+
+	err := args.Error(1)
+	tag := args.Get(0)
+	if err == nil && tag == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return tag.(*models.Tag), nil
+	}
+}
+
+func (s *ServiceMock) Merge(ctx context.Context, userId uuid.UUID, ledgerId uuid.UUID, command *MergeCommand) (*models.Tag, error) {
+	args := s.Called(ctx, userId, ledgerId, command)
+
+	err := args.Error(1)
+	tag := args.Get(0)
+	if err == nil && tag == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return tag.(*models.Tag), nil
+	}
+}
+
+func (s *ServiceMock) MockGetAll(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockRename(callArguments, returnArguments []interface{}, times int) {
+	s.On("Rename", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockMerge(callArguments, returnArguments []interface{}, times int) {
+	s.On("Merge", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
@@ -0,0 +1,169 @@
+package tag_test
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/ledger"
+	"finfit-backend/internal/domain/services/tag"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type TagServiceTestSuite struct {
+	suite.Suite
+	userId            uuid.UUID
+	ledgerId          uuid.UUID
+	repositoryMock    *tag.RepositoryMock
+	ledgerServiceMock *ledger.ServiceMock
+	service           tag.Service
+}
+
+func (suite *TagServiceTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.ledgerId = uuid.New()
+	suite.repositoryMock = tag.NewRepositoryMock()
+	suite.ledgerServiceMock = ledger.NewServiceMock()
+	suite.service = tag.NewService(suite.repositoryMock, suite.ledgerServiceMock)
+}
+
+func (suite *TagServiceTestSuite) SetupTest() {
+	suite.ledgerServiceMock.MockAuthorize([]interface{}{suite.userId, suite.ledgerId, mock.Anything}, []interface{}{nil}, 0)
+}
+
+func (suite *TagServiceTestSuite) TearDownTest() {
+	suite.repositoryMock.ExpectedCalls = nil
+	suite.repositoryMock.Calls = nil
+	suite.ledgerServiceMock.ExpectedCalls = nil
+	suite.ledgerServiceMock.Calls = nil
+}
+
+func TestTagServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TagServiceTestSuite))
+}
+
+func (suite *TagServiceTestSuite) TestGivenStoredTags_WhenGetAll_ThenReturnThem() {
+	expectedTags := []*models.Tag{suite.getTag("reimbursable"), suite.getTag("vacation-2026")}
+	suite.repositoryMock.MockGetAll([]interface{}{suite.ledgerId}, []interface{}{expectedTags, nil}, 1)
+
+	tags, err := suite.service.GetAll(context.Background(), suite.userId, suite.ledgerId)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedTags, tags)
+	suite.ledgerServiceMock.AssertCalled(suite.T(), "Authorize", mock.Anything, suite.userId, suite.ledgerId, models.ViewerLedgerRole)
+}
+
+func (suite *TagServiceTestSuite) TestGivenANewName_WhenRename_ThenUpdateTheTag() {
+	storedTag := suite.getTag("vacation")
+	renamedTag, _ := storedTag.WithName("vacation-2026")
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedTag.Id()}, []interface{}{storedTag, nil}, 1)
+	suite.repositoryMock.MockGetByName([]interface{}{suite.ledgerId, "vacation-2026"}, []interface{}{nil, nil}, 1)
+	suite.repositoryMock.MockUpdate([]interface{}{suite.ledgerId, renamedTag}, []interface{}{renamedTag, nil}, 1)
+	command, _ := tag.NewRenameCommand(storedTag.Id(), "Vacation-2026")
+
+	updatedTag, err := suite.service.Rename(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), renamedTag, updatedTag)
+	suite.ledgerServiceMock.AssertCalled(suite.T(), "Authorize", mock.Anything, suite.userId, suite.ledgerId, models.EditorLedgerRole)
+}
+
+func (suite *TagServiceTestSuite) TestGivenTheNameOfAnotherTag_WhenRename_ThenReturnTagAlreadyExistsError() {
+	storedTag := suite.getTag("vacation")
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, storedTag.Id()}, []interface{}{storedTag, nil}, 1)
+	suite.repositoryMock.MockGetByName([]interface{}{suite.ledgerId, "holidays"}, []interface{}{suite.getTag("holidays"), nil}, 1)
+	command, _ := tag.NewRenameCommand(storedTag.Id(), "holidays")
+
+	updatedTag, err := suite.service.Rename(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.ErrorAs(suite.T(), err, &tag.TagAlreadyExistsError{})
+	require.Nil(suite.T(), updatedTag)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (suite *TagServiceTestSuite) TestGivenANonExistentTag_WhenRename_ThenReturnTagNotFoundError() {
+	id := uuid.New()
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, id}, []interface{}{nil, nil}, 1)
+	command, _ := tag.NewRenameCommand(id, "holidays")
+
+	updatedTag, err := suite.service.Rename(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.ErrorAs(suite.T(), err, &tag.TagNotFoundError{})
+	require.Nil(suite.T(), updatedTag)
+}
+
+func (suite *TagServiceTestSuite) TestGivenSourceTags_WhenMerge_ThenMoveTheirExpensesToTheTarget() {
+	target := suite.getTag("vacation")
+	firstSource := suite.getTag("holidays")
+	secondSource := suite.getTag("trip")
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, target.Id()}, []interface{}{target, nil}, 1)
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, firstSource.Id()}, []interface{}{firstSource, nil}, 1)
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, secondSource.Id()}, []interface{}{secondSource, nil}, 1)
+	sourceIds := []uuid.UUID{firstSource.Id(), secondSource.Id()}
+	suite.repositoryMock.MockMerge([]interface{}{suite.ledgerId, target.Id(), sourceIds}, []interface{}{nil}, 1)
+	command, _ := tag.NewMergeCommand(target.Id(), sourceIds)
+
+	mergedTag, err := suite.service.Merge(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), target, mergedTag)
+	suite.repositoryMock.AssertCalled(suite.T(), "Merge", mock.Anything, suite.ledgerId, target.Id(), sourceIds)
+}
+
+func (suite *TagServiceTestSuite) TestGivenANonExistentSourceTag_WhenMerge_ThenReturnTagNotFoundErrorWithoutMerging() {
+	target := suite.getTag("vacation")
+	sourceId := uuid.New()
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, target.Id()}, []interface{}{target, nil}, 1)
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, sourceId}, []interface{}{nil, nil}, 1)
+	command, _ := tag.NewMergeCommand(target.Id(), []uuid.UUID{sourceId})
+
+	mergedTag, err := suite.service.Merge(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.ErrorAs(suite.T(), err, &tag.TagNotFoundError{})
+	require.Nil(suite.T(), mergedTag)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "Merge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TagServiceTestSuite) TestGivenThatRepositoryFails_WhenMerge_ThenReturnUnexpectedError() {
+	target := suite.getTag("vacation")
+	source := suite.getTag("holidays")
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, target.Id()}, []interface{}{target, nil}, 1)
+	suite.repositoryMock.MockGetByID([]interface{}{suite.ledgerId, source.Id()}, []interface{}{source, nil}, 1)
+	suite.repositoryMock.MockMerge([]interface{}{suite.ledgerId, target.Id(), []uuid.UUID{source.Id()}}, []interface{}{errors.New("fail")}, 1)
+	command, _ := tag.NewMergeCommand(target.Id(), []uuid.UUID{source.Id()})
+
+	mergedTag, err := suite.service.Merge(context.Background(), suite.userId, suite.ledgerId, command)
+
+	require.ErrorAs(suite.T(), err, &tag.UnexpectedError{})
+	require.Nil(suite.T(), mergedTag)
+}
+
+func (suite *TagServiceTestSuite) TestGivenTheTargetAmongTheSources_WhenNewMergeCommand_ThenReturnError() {
+	targetId := uuid.New()
+
+	command, err := tag.NewMergeCommand(targetId, []uuid.UUID{uuid.New(), targetId})
+
+	require.Error(suite.T(), err)
+	require.Nil(suite.T(), command)
+}
+
+func (suite *TagServiceTestSuite) TestGivenAUserOutsideTheLedger_WhenMerge_ThenReturnLedgerNotFoundError() {
+	otherLedgerId := uuid.New()
+	suite.ledgerServiceMock.MockAuthorize([]interface{}{suite.userId, otherLedgerId, models.EditorLedgerRole}, []interface{}{ledger.LedgerNotFoundError{Msg: "not found"}}, 1)
+	command, _ := tag.NewMergeCommand(uuid.New(), []uuid.UUID{uuid.New()})
+
+	mergedTag, err := suite.service.Merge(context.Background(), suite.userId, otherLedgerId, command)
+
+	require.ErrorAs(suite.T(), err, &ledger.LedgerNotFoundError{})
+	require.Nil(suite.T(), mergedTag)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "Merge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TagServiceTestSuite) getTag(name string) *models.Tag {
+	tag, _ := models.NewTagWithId(uuid.New(), name)
+	return tag
+}
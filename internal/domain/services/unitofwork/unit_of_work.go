@@ -0,0 +1,26 @@
+package unitofwork
+
+import "context"
+
+// UnitOfWork runs an operation over the repositories R a service writes through, all of them bound to the same
+// transaction. Every service declares its own R, and the implementations build it again for each operation.
+type UnitOfWork[R any] interface {
+	// Do runs operation and returns its error. The units of work over a transactional store commit the changes made
+	// through the repositories when operation returns nil, and roll them back when it returns an error or panics, or
+	// when ctx is cancelled. WithoutTransaction keeps them instead.
+	Do(ctx context.Context, operation func(repositories R) error) error
+}
+
+type withoutTransaction[R any] struct {
+	repositories R
+}
+
+// WithoutTransaction runs the operations straight on the repositories, for the stores without transactions, such as
+// the memory ones. The changes made before an error are kept.
+func WithoutTransaction[R any](repositories R) UnitOfWork[R] {
+	return withoutTransaction[R]{repositories: repositories}
+}
+
+func (u withoutTransaction[R]) Do(_ context.Context, operation func(repositories R) error) error {
+	return operation(u.repositories)
+}
@@ -0,0 +1,18 @@
+package user
+
+import (
+	"errors"
+	"finfit-backend/pkg"
+)
+
+type LoginCommand struct {
+	email    string
+	password string
+}
+
+func NewLoginCommand(email string, password string) (*LoginCommand, error) {
+	if pkg.IsEmptyOrBlankString(email) || pkg.IsEmptyOrBlankString(password) {
+		return nil, errors.New("invalid command")
+	}
+	return &LoginCommand{email: email, password: password}, nil
+}
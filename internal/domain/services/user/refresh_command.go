@@ -0,0 +1,17 @@
+package user
+
+import (
+	"errors"
+	"finfit-backend/pkg"
+)
+
+type RefreshCommand struct {
+	refreshToken string
+}
+
+func NewRefreshCommand(refreshToken string) (*RefreshCommand, error) {
+	if pkg.IsEmptyOrBlankString(refreshToken) {
+		return nil, errors.New("invalid command")
+	}
+	return &RefreshCommand{refreshToken: refreshToken}, nil
+}
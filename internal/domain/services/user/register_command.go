@@ -0,0 +1,24 @@
+package user
+
+import (
+	"errors"
+	"finfit-backend/pkg"
+)
+
+// bcrypt ignores everything after the 72nd byte, so longer passwords are rejected instead of silently truncated.
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 72
+)
+
+type RegisterCommand struct {
+	email    string
+	password string
+}
+
+func NewRegisterCommand(email string, password string) (*RegisterCommand, error) {
+	if pkg.IsEmptyOrBlankString(email) || pkg.ExceedsMax(email, 254) || !pkg.HasMin(password, minPasswordLength) || pkg.ExceedsMax(password, maxPasswordLength) {
+		return nil, errors.New("invalid command")
+	}
+	return &RegisterCommand{email: email, password: password}, nil
+}
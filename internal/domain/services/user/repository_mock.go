@@ -0,0 +1,102 @@
+package user
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func NewRepositoryMock() *RepositoryMock {
+	return &RepositoryMock{}
+}
+
+func (r *RepositoryMock) Add(ctx context.Context, user *models.User) (*models.User, error) {
+	args := r.Called(ctx, user)
+
+	err := args.Error(1)
+	addedUser := args.Get(0)
+	if err == nil && addedUser == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return addedUser.(*models.User), nil
+	}
+}
+
+func (r *RepositoryMock) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	args := r.Called(ctx, id)
+
+	err := args.Error(1)
+	storedUser := args.Get(0)
+	if err == nil && storedUser == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return storedUser.(*models.User), nil
+	}
+}
+
+func (r *RepositoryMock) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	args := r.Called(ctx, email)
+
+	err := args.Error(1)
+	storedUser := args.Get(0)
+	if err == nil && storedUser == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return storedUser.(*models.User), nil
+	}
+}
+
+func (r *RepositoryMock) GetAll(ctx context.Context) ([]*models.User, error) {
+	args := r.Called(ctx)
+
+	err := args.Error(1)
+	users := args.Get(0)
+	if err == nil && users == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return users.([]*models.User), nil
+	}
+}
+
+func (r *RepositoryMock) UseRefreshToken(ctx context.Context, userId uuid.UUID, tokenId string, expiresAt time.Time) (bool, error) {
+	args := r.Called(ctx, userId, tokenId, expiresAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *RepositoryMock) MockAdd(callArguments, returnArguments []interface{}, times int) {
+	r.On("Add", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetByID(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetByID", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetByEmail(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetByEmail", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockGetAll(callArguments, returnArguments []interface{}, times int) {
+	r.On("GetAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (r *RepositoryMock) MockUseRefreshToken(callArguments, returnArguments []interface{}, times int) {
+	r.On("UseRefreshToken", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func anyContext(callArguments []interface{}) []interface{} {
+	return append([]interface{}{mock.Anything}, callArguments...)
+}
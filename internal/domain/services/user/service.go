@@ -0,0 +1,221 @@
+package user
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/pkg/token"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"time"
+)
+
+const (
+	userAlreadyExistsErrorMsg  = "a user with the same email already exists"
+	invalidCredentialsErrorMsg = "the email or the password are wrong"
+	invalidTokenErrorMsg       = "the token is invalid or has expired"
+)
+
+// PasswordHashCost is the bcrypt cost of the stored password hashes, tests lower it to keep them fast.
+var PasswordHashCost = bcrypt.DefaultCost
+
+type Repository interface {
+	Add(ctx context.Context, user *models.User) (*models.User, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	GetAll(ctx context.Context) ([]*models.User, error)
+	// UseRefreshToken records that the refresh token with the id was exchanged, and returns false when it already was.
+	UseRefreshToken(ctx context.Context, userId uuid.UUID, tokenId string, expiresAt time.Time) (bool, error)
+}
+type Service interface {
+	Register(ctx context.Context, command *RegisterCommand) (*models.User, error)
+	Login(ctx context.Context, command *LoginCommand) (*Session, error)
+	Refresh(ctx context.Context, command *RefreshCommand) (*Session, error)
+	// Authenticate verifies an access token and returns the id of the user it was issued to.
+	Authenticate(accessToken string) (uuid.UUID, error)
+	GetAll(ctx context.Context) ([]*models.User, error)
+}
+
+type service struct {
+	repository   Repository
+	tokenManager *token.Manager
+}
+
+func NewService(repository Repository, tokenManager *token.Manager) *service {
+	return &service{repository: repository, tokenManager: tokenManager}
+}
+
+func (s service) Register(ctx context.Context, command *RegisterCommand) (*models.User, error) {
+	storedUser, err := s.repository.GetByEmail(ctx, models.NormalizeEmail(command.email))
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	if storedUser != nil {
+		return nil, UserAlreadyExistsError{Msg: userAlreadyExistsErrorMsg}
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(command.password), PasswordHashCost)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	userToAdd, err := models.NewUser(command.email, string(passwordHash))
+	if err != nil {
+		return nil, InvalidDomainModelError{Msg: err.Error()}
+	}
+
+	addedUser, err := s.repository.Add(ctx, userToAdd)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return addedUser, nil
+}
+
+func (s service) Login(ctx context.Context, command *LoginCommand) (*Session, error) {
+	storedUser, err := s.repository.GetByEmail(ctx, models.NormalizeEmail(command.email))
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	// A hash is compared even when the user doesn't exist, so the response time doesn't tell which emails are
+	// registered.
+	passwordHash := unknownUserPasswordHash
+	if storedUser != nil {
+		passwordHash = []byte(storedUser.PasswordHash())
+	}
+
+	if err = bcrypt.CompareHashAndPassword(passwordHash, []byte(command.password)); err != nil || storedUser == nil {
+		return nil, InvalidCredentialsError{Msg: invalidCredentialsErrorMsg}
+	}
+
+	return s.startSession(storedUser)
+}
+
+// Refresh issues a new pair of tokens from a refresh token, as long as its user still exists. Each refresh token is
+// exchanged only once, the ones already used are rejected.
+func (s service) Refresh(ctx context.Context, command *RefreshCommand) (*Session, error) {
+	claims, err := s.tokenManager.Parse(command.refreshToken, token.RefreshKind)
+	if err != nil {
+		return nil, InvalidTokenError{Msg: invalidTokenErrorMsg}
+	}
+
+	userId, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, InvalidTokenError{Msg: invalidTokenErrorMsg}
+	}
+
+	storedUser, err := s.repository.GetByID(ctx, userId)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	if storedUser == nil {
+		return nil, InvalidTokenError{Msg: invalidTokenErrorMsg}
+	}
+
+	firstUse, err := s.repository.UseRefreshToken(ctx, userId, claims.ID, time.Unix(claims.ExpiresAt, 0))
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	if !firstUse {
+		return nil, InvalidTokenError{Msg: invalidTokenErrorMsg}
+	}
+
+	return s.startSession(storedUser)
+}
+
+func (s service) Authenticate(accessToken string) (uuid.UUID, error) {
+	claims, err := s.tokenManager.Parse(accessToken, token.AccessKind)
+	if err != nil {
+		return uuid.Nil, InvalidTokenError{Msg: invalidTokenErrorMsg}
+	}
+
+	userId, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, InvalidTokenError{Msg: invalidTokenErrorMsg}
+	}
+
+	return userId, nil
+}
+
+func (s service) GetAll(ctx context.Context) ([]*models.User, error) {
+	users, err := s.repository.GetAll(ctx)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return users, nil
+}
+
+func (s service) startSession(user *models.User) (*Session, error) {
+	accessToken, accessTokenExpiresAt, err := s.tokenManager.Issue(user.Id().String(), token.AccessKind)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	refreshToken, refreshTokenExpiresAt, err := s.tokenManager.Issue(user.Id().String(), token.RefreshKind)
+	if err != nil {
+		return nil, UnexpectedError{Msg: err.Error()}
+	}
+
+	return &Session{
+		User:                  user,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessTokenExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshTokenExpiresAt,
+	}, nil
+}
+
+// unknownUserPasswordHash is a bcrypt hash with the default cost of a random password nobody knows.
+var unknownUserPasswordHash = []byte("$2a$10$MITnt4ZAvSSEz3hauKxSDeNwx.TQvwtjpYp.HAPsC3o5JTWMiza9.")
+
+type Session struct {
+	User                  *models.User
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+type UnexpectedError struct {
+	Msg string
+}
+
+func (receiver UnexpectedError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidDomainModelError struct {
+	Msg string
+}
+
+func (receiver InvalidDomainModelError) Error() string {
+	return receiver.Msg
+}
+
+type UserAlreadyExistsError struct {
+	Msg string
+}
+
+func (receiver UserAlreadyExistsError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidCredentialsError struct {
+	Msg string
+}
+
+func (receiver InvalidCredentialsError) Error() string {
+	return receiver.Msg
+}
+
+type InvalidTokenError struct {
+	Msg string
+}
+
+func (receiver InvalidTokenError) Error() string {
+	return receiver.Msg
+}
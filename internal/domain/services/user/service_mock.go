@@ -0,0 +1,97 @@
+package user
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type ServiceMock struct {
+	mock.Mock
+}
+
+func NewServiceMock() *ServiceMock {
+	return &ServiceMock{}
+}
+
+func (s *ServiceMock) Register(ctx context.Context, command *RegisterCommand) (*models.User, error) {
+	args := s.Called(ctx, command)
+
+	err := args.Error(1)
+	registeredUser := args.Get(0)
+	if err == nil && registeredUser == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return registeredUser.(*models.User), nil
+	}
+}
+
+func (s *ServiceMock) Login(ctx context.Context, command *LoginCommand) (*Session, error) {
+	args := s.Called(ctx, command)
+
+	err := args.Error(1)
+	session := args.Get(0)
+	if err == nil && session == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return session.(*Session), nil
+	}
+}
+
+func (s *ServiceMock) Refresh(ctx context.Context, command *RefreshCommand) (*Session, error) {
+	args := s.Called(ctx, command)
+
+	err := args.Error(1)
+	session := args.Get(0)
+	if err == nil && session == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return session.(*Session), nil
+	}
+}
+
+func (s *ServiceMock) GetAll(ctx context.Context) ([]*models.User, error) {
+	args := s.Called(ctx)
+
+	err := args.Error(1)
+	users := args.Get(0)
+	if err == nil && users == nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return users.([]*models.User), nil
+	}
+}
+
+func (s *ServiceMock) Authenticate(accessToken string) (uuid.UUID, error) {
+	args := s.Called(accessToken)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (s *ServiceMock) MockRegister(callArguments, returnArguments []interface{}, times int) {
+	s.On("Register", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockLogin(callArguments, returnArguments []interface{}, times int) {
+	s.On("Login", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockRefresh(callArguments, returnArguments []interface{}, times int) {
+	s.On("Refresh", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockGetAll(callArguments, returnArguments []interface{}, times int) {
+	s.On("GetAll", anyContext(callArguments)...).Return(returnArguments...).Times(times)
+}
+
+func (s *ServiceMock) MockAuthenticate(callArguments, returnArguments []interface{}, times int) {
+	s.On("Authenticate", callArguments...).Return(returnArguments...).Times(times)
+}
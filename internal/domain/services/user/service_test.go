@@ -0,0 +1,229 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/user"
+	"finfit-backend/pkg"
+	"finfit-backend/pkg/token"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+	"time"
+)
+
+const password = "correct horse battery"
+
+type ServiceTestSuite struct {
+	suite.Suite
+	repositoryMock *user.RepositoryMock
+	tokenManager   *token.Manager
+	service        user.Service
+}
+
+func (suite *ServiceTestSuite) SetupSuite() {
+	suite.repositoryMock = user.NewRepositoryMock()
+	suite.tokenManager, _ = token.NewManager([]byte("a-secret-that-is-long-enough-for-hs256"), 15*time.Minute, 24*time.Hour)
+	suite.service = user.NewService(suite.repositoryMock, suite.tokenManager)
+	user.PasswordHashCost = bcrypt.MinCost
+	suite.patchUUIDFunction()
+}
+
+func (suite *ServiceTestSuite) patchUUIDFunction() {
+	id := uuid.New()
+	pkg.NewUUID = func() uuid.UUID {
+		return id
+	}
+}
+
+func (suite *ServiceTestSuite) TearDownTest() {
+	suite.repositoryMock.ExpectedCalls = nil
+	suite.repositoryMock.Calls = nil
+	token.Now = time.Now
+}
+
+func (suite *ServiceTestSuite) TearDownSuite() {
+	pkg.NewUUID = uuid.New
+	user.PasswordHashCost = bcrypt.DefaultCost
+}
+
+func TestServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ServiceTestSuite))
+}
+
+func (suite *ServiceTestSuite) TestGivenANewEmail_WhenRegister_ThenStoreTheUserWithAHashedPassword() {
+	suite.repositoryMock.MockGetByEmail([]interface{}{"ana@mail.com"}, []interface{}{nil, nil}, 1)
+	storedUser := suite.getUser()
+	isHashedUser := mock.MatchedBy(func(userToAdd *models.User) bool {
+		return userToAdd.Email() == "ana@mail.com" && bcrypt.CompareHashAndPassword([]byte(userToAdd.PasswordHash()), []byte(password)) == nil
+	})
+	suite.repositoryMock.MockAdd([]interface{}{isHashedUser}, []interface{}{storedUser, nil}, 1)
+	command, _ := user.NewRegisterCommand(" Ana@Mail.com ", password)
+
+	registeredUser, err := suite.service.Register(context.Background(), command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), storedUser, registeredUser)
+	suite.repositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestGivenAnEmailAlreadyRegistered_WhenRegister_ThenReturnUserAlreadyExistsError() {
+	suite.repositoryMock.MockGetByEmail([]interface{}{"ana@mail.com"}, []interface{}{suite.getUser(), nil}, 1)
+	command, _ := user.NewRegisterCommand("ana@mail.com", password)
+
+	registeredUser, err := suite.service.Register(context.Background(), command)
+
+	assert.ErrorAs(suite.T(), err, &user.UserAlreadyExistsError{})
+	assert.Nil(suite.T(), registeredUser)
+	suite.repositoryMock.AssertNotCalled(suite.T(), "Add", mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestGivenAShortPassword_WhenNewRegisterCommand_ThenReturnError() {
+	command, err := user.NewRegisterCommand("ana@mail.com", "short")
+
+	assert.EqualError(suite.T(), err, "invalid command")
+	assert.Nil(suite.T(), command)
+}
+
+func (suite *ServiceTestSuite) TestGivenTheRightPassword_WhenLogin_ThenReturnTokensForTheUser() {
+	storedUser := suite.getUser()
+	suite.repositoryMock.MockGetByEmail([]interface{}{"ana@mail.com"}, []interface{}{storedUser, nil}, 1)
+	command, _ := user.NewLoginCommand("ana@mail.com", password)
+
+	session, err := suite.service.Login(context.Background(), command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), storedUser, session.User)
+	userId, err := suite.service.Authenticate(session.AccessToken)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), storedUser.Id(), userId)
+	_, err = suite.service.Authenticate(session.RefreshToken)
+	assert.ErrorAs(suite.T(), err, &user.InvalidTokenError{})
+}
+
+func (suite *ServiceTestSuite) TestGivenAWrongPassword_WhenLogin_ThenReturnInvalidCredentialsError() {
+	suite.repositoryMock.MockGetByEmail([]interface{}{"ana@mail.com"}, []interface{}{suite.getUser(), nil}, 1)
+	command, _ := user.NewLoginCommand("ana@mail.com", "wrong password")
+
+	session, err := suite.service.Login(context.Background(), command)
+
+	assert.ErrorAs(suite.T(), err, &user.InvalidCredentialsError{})
+	assert.Nil(suite.T(), session)
+}
+
+func (suite *ServiceTestSuite) TestGivenAnUnknownEmail_WhenLogin_ThenReturnInvalidCredentialsError() {
+	suite.repositoryMock.MockGetByEmail([]interface{}{"nobody@mail.com"}, []interface{}{nil, nil}, 1)
+	command, _ := user.NewLoginCommand("nobody@mail.com", password)
+
+	session, err := suite.service.Login(context.Background(), command)
+
+	assert.ErrorAs(suite.T(), err, &user.InvalidCredentialsError{})
+	assert.Nil(suite.T(), session)
+}
+
+func (suite *ServiceTestSuite) TestGivenThatRepositoryFails_WhenLogin_ThenReturnUnexpectedError() {
+	suite.repositoryMock.MockGetByEmail([]interface{}{"ana@mail.com"}, []interface{}{nil, errors.New("fail")}, 1)
+	command, _ := user.NewLoginCommand("ana@mail.com", password)
+
+	session, err := suite.service.Login(context.Background(), command)
+
+	assert.ErrorAs(suite.T(), err, &user.UnexpectedError{})
+	assert.Nil(suite.T(), session)
+}
+
+func (suite *ServiceTestSuite) TestGivenARefreshToken_WhenRefresh_ThenReturnNewTokens() {
+	storedUser := suite.getUser()
+	refreshToken, _, _ := suite.tokenManager.Issue(storedUser.Id().String(), token.RefreshKind)
+	suite.repositoryMock.MockGetByID([]interface{}{storedUser.Id()}, []interface{}{storedUser, nil}, 1)
+	suite.repositoryMock.MockUseRefreshToken([]interface{}{storedUser.Id(), mock.Anything, mock.Anything}, []interface{}{true, nil}, 1)
+	command, _ := user.NewRefreshCommand(refreshToken)
+
+	session, err := suite.service.Refresh(context.Background(), command)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), storedUser, session.User)
+	assert.NotEqual(suite.T(), refreshToken, session.RefreshToken)
+	suite.repositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestGivenARefreshTokenAlreadyUsed_WhenRefresh_ThenReturnInvalidTokenError() {
+	storedUser := suite.getUser()
+	refreshToken, expiresAt, _ := suite.tokenManager.Issue(storedUser.Id().String(), token.RefreshKind)
+	claims, _ := suite.tokenManager.Parse(refreshToken, token.RefreshKind)
+	suite.repositoryMock.MockGetByID([]interface{}{storedUser.Id()}, []interface{}{storedUser, nil}, 1)
+	suite.repositoryMock.MockUseRefreshToken([]interface{}{storedUser.Id(), claims.ID, time.Unix(expiresAt.Unix(), 0)}, []interface{}{false, nil}, 1)
+	command, _ := user.NewRefreshCommand(refreshToken)
+
+	session, err := suite.service.Refresh(context.Background(), command)
+
+	assert.ErrorAs(suite.T(), err, &user.InvalidTokenError{})
+	assert.Nil(suite.T(), session)
+}
+
+func (suite *ServiceTestSuite) TestGivenThatFailToRecordTheRefreshToken_WhenRefresh_ThenReturnUnexpectedError() {
+	storedUser := suite.getUser()
+	refreshToken, _, _ := suite.tokenManager.Issue(storedUser.Id().String(), token.RefreshKind)
+	suite.repositoryMock.MockGetByID([]interface{}{storedUser.Id()}, []interface{}{storedUser, nil}, 1)
+	suite.repositoryMock.MockUseRefreshToken([]interface{}{storedUser.Id(), mock.Anything, mock.Anything}, []interface{}{false, errors.New("fail")}, 1)
+	command, _ := user.NewRefreshCommand(refreshToken)
+
+	session, err := suite.service.Refresh(context.Background(), command)
+
+	assert.ErrorAs(suite.T(), err, &user.UnexpectedError{})
+	assert.Nil(suite.T(), session)
+}
+
+func (suite *ServiceTestSuite) TestGivenAnAccessToken_WhenRefresh_ThenReturnInvalidTokenError() {
+	accessToken, _, _ := suite.tokenManager.Issue(uuid.NewString(), token.AccessKind)
+	command, _ := user.NewRefreshCommand(accessToken)
+
+	session, err := suite.service.Refresh(context.Background(), command)
+
+	assert.ErrorAs(suite.T(), err, &user.InvalidTokenError{})
+	assert.Nil(suite.T(), session)
+}
+
+func (suite *ServiceTestSuite) TestGivenARefreshTokenOfADeletedUser_WhenRefresh_ThenReturnInvalidTokenError() {
+	userId := uuid.New()
+	refreshToken, _, _ := suite.tokenManager.Issue(userId.String(), token.RefreshKind)
+	suite.repositoryMock.MockGetByID([]interface{}{userId}, []interface{}{nil, nil}, 1)
+	command, _ := user.NewRefreshCommand(refreshToken)
+
+	session, err := suite.service.Refresh(context.Background(), command)
+
+	assert.ErrorAs(suite.T(), err, &user.InvalidTokenError{})
+	assert.Nil(suite.T(), session)
+}
+
+func (suite *ServiceTestSuite) TestGivenAnExpiredAccessToken_WhenAuthenticate_ThenReturnInvalidTokenError() {
+	accessToken, _, _ := suite.tokenManager.Issue(uuid.NewString(), token.AccessKind)
+	token.Now = func() time.Time {
+		return time.Now().Add(16 * time.Minute)
+	}
+
+	userId, err := suite.service.Authenticate(accessToken)
+
+	assert.ErrorAs(suite.T(), err, &user.InvalidTokenError{})
+	assert.Equal(suite.T(), uuid.Nil, userId)
+}
+
+func (suite *ServiceTestSuite) TestGivenATokenSignedWithAnotherSecret_WhenAuthenticate_ThenReturnInvalidTokenError() {
+	otherManager, _ := token.NewManager([]byte("another-secret-that-is-long-enough-too"), time.Minute, time.Hour)
+	accessToken, _, _ := otherManager.Issue(uuid.NewString(), token.AccessKind)
+
+	userId, err := suite.service.Authenticate(accessToken)
+
+	assert.ErrorAs(suite.T(), err, &user.InvalidTokenError{})
+	assert.Equal(suite.T(), uuid.Nil, userId)
+}
+
+func (suite *ServiceTestSuite) getUser() *models.User {
+	passwordHash, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	storedUser, _ := models.NewUserWithId(uuid.New(), "ana@mail.com", string(passwordHash))
+	return storedUser
+}
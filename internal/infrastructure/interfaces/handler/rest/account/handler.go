@@ -0,0 +1,275 @@
+package account
+
+import (
+	"encoding/json"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/account"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/pkg/fieldvalidation"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+const (
+	FieldValidationErrorMessage  = "some fields are invalid"
+	BodyIsInvalidErrorMessage    = "body is invalid"
+	ParamsAreInvalidErrorMessage = "params are invalid, query param date must have the format YYYY-MM-DD"
+	InvalidIdErrorMessage        = "id path param is invalid, it must be a valid UUID"
+	AccountNotFoundErrorMessage  = "the account doesn't exists"
+	UnexpectedErrorMessage       = "unexpected error"
+	DateFormat                   = "2006-01-02"
+)
+
+type Handler interface {
+	Add(context echo.Context) error
+	GetAll(context echo.Context) error
+	GetById(context echo.Context) error
+	GetBalance(context echo.Context) error
+	Transfer(context echo.Context) error
+}
+
+type handler struct {
+	service         account.Service
+	fieldsValidator fieldvalidation.FieldsValidator
+}
+
+func NewHandler(service account.Service, fieldsValidator fieldvalidation.FieldsValidator) *handler {
+	return &handler{service: service, fieldsValidator: fieldsValidator}
+}
+
+func (h handler) Add(context echo.Context) error {
+	requestBody := new(AddAccountRequest)
+
+	if err := context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := account.NewAddCommand(requestBody.Name, requestBody.Kind, requestBody.OpeningBalance.Amount.String(), requestBody.OpeningBalance.Currency)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	addedAccount, err := h.service.Add(context.Request().Context(), rest.UserId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusCreated, Response{Account: h.mapAccountToAccountBody(addedAccount)})
+}
+
+func (h handler) GetAll(context echo.Context) error {
+	accounts, err := h.service.GetAll(context.Request().Context(), rest.UserId(context))
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	accountBodies := []Body{}
+	for _, storedAccount := range accounts {
+		accountBodies = append(accountBodies, h.mapAccountToAccountBody(storedAccount))
+	}
+
+	return context.JSON(http.StatusOK, GetAllResponse{Accounts: accountBodies})
+}
+
+func (h handler) GetById(context echo.Context) error {
+	id, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	storedAccount, err := h.service.GetById(context.Request().Context(), rest.UserId(context), id)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	if storedAccount == nil {
+		return h.buildErrorResponse(context, http.StatusNotFound, AccountNotFoundErrorMessage, AccountNotFoundErrorMessage, []fieldvalidation.FieldError{}, 0)
+	}
+
+	return context.JSON(http.StatusOK, Response{Account: h.mapAccountToAccountBody(storedAccount)})
+}
+
+// GetBalance returns the balance of the account at the end of the date query param, or at the end of today when it
+// isn't sent.
+func (h handler) GetBalance(context echo.Context) error {
+	id, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	requestParams := new(GetBalanceQueryParams)
+	if err = context.Bind(requestParams); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, ParamsAreInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestParams); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	asOf := time.Now()
+	if requestParams.Date != "" {
+		asOf, _ = time.Parse(DateFormat, requestParams.Date)
+	}
+
+	command, err := account.NewGetBalanceCommand(id, asOf)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	balance, err := h.service.GetBalance(context.Request().Context(), rest.UserId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusOK, BalanceResponse{
+		AccountID: id.String(),
+		Date:      command.AsOf().Format(DateFormat),
+		Balance:   Money{Amount: json.Number(balance.Amount()), Currency: balance.Currency()},
+	})
+}
+
+func (h handler) Transfer(context echo.Context) error {
+	requestBody := new(TransferRequest)
+
+	if err := context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := h.mapTransferCommandFromRequestBody(*requestBody)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	transfer, err := h.service.Transfer(context.Request().Context(), rest.UserId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusCreated, TransferResponse{Transfer: TransferBody{
+		ID:            transfer.Id().String(),
+		FromAccountID: transfer.FromAccount().Id().String(),
+		ToAccountID:   transfer.ToAccount().Id().String(),
+		Amount:        Money{Amount: json.Number(transfer.Amount().Amount()), Currency: transfer.Amount().Currency()},
+		TransferDate:  transfer.TransferDate().Format(DateFormat),
+		Description:   transfer.Description(),
+	}})
+}
+
+func (h handler) mapTransferCommandFromRequestBody(body TransferRequest) (*account.TransferCommand, error) {
+	fromAccountId, err := uuid.Parse(body.FromAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	toAccountId, err := uuid.Parse(body.ToAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	date, _ := time.Parse(DateFormat, body.TransferDate)
+
+	return account.NewTransferCommand(fromAccountId, toAccountId, body.Amount.Amount.String(), body.Amount.Currency, date, body.Description)
+}
+
+func (h handler) manageServiceError(ctx echo.Context, err error) error {
+	if errors.As(err, &account.InvalidDomainModelError{}) {
+		return h.buildErrorResponse(ctx, http.StatusBadRequest, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &account.AccountNotFoundError{}) {
+		return h.buildErrorResponse(ctx, http.StatusNotFound, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if statusCode, msg, ok := rest.EndedRequestError(ctx); ok {
+		return h.buildErrorResponse(ctx, statusCode, msg, err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else {
+		return h.buildErrorResponse(ctx, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+}
+
+func (h handler) buildErrorResponse(ctx echo.Context, statusCode int, errorMessage string, errorDetail string, fieldErrors []fieldvalidation.FieldError, errorCode uint) error {
+	errorResponse := rest.ErrorResponse{StatusCode: statusCode, Msg: errorMessage, ErrorDetail: errorDetail, FieldErrors: fieldErrors, ErrorCode: errorCode}
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+func (h handler) mapAccountToAccountBody(account *models.Account) Body {
+	return Body{
+		ID:   account.Id().String(),
+		Name: account.Name(),
+		Kind: string(account.Kind()),
+		OpeningBalance: Money{
+			Amount:   json.Number(account.OpeningBalance().Amount()),
+			Currency: account.OpeningBalance().Currency(),
+		},
+	}
+}
+
+type AddAccountRequest struct {
+	Name           string         `json:"name,omitempty" validate:"required,max=32"`
+	Kind           string         `json:"kind,omitempty" validate:"required,oneof=bank credit_card cash savings"`
+	OpeningBalance OpeningBalance `json:"opening_balance"`
+}
+
+// OpeningBalance accepts zero and negative amounts, a credit card usually starts with a debt.
+type OpeningBalance struct {
+	Amount   json.Number `json:"amount" validate:"required,numeric"`
+	Currency string      `json:"currency" validate:"iso4217"`
+}
+
+type GetBalanceQueryParams struct {
+	Date string `query:"date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type TransferRequest struct {
+	FromAccountID string `json:"from_account_id,omitempty" validate:"required,uuid"`
+	ToAccountID   string `json:"to_account_id,omitempty" validate:"required,uuid,nefield=FromAccountID"`
+	Amount        Money  `json:"amount"`
+	TransferDate  string `json:"transfer_date,omitempty" validate:"required,datetime=2006-01-02"`
+	Description   string `json:"description,omitempty" validate:"max=40"`
+}
+
+type Response struct {
+	Account Body `json:"account"`
+}
+
+type GetAllResponse struct {
+	Accounts []Body `json:"accounts"`
+}
+
+type BalanceResponse struct {
+	AccountID string `json:"account_id"`
+	Date      string `json:"date"`
+	Balance   Money  `json:"balance"`
+}
+
+type TransferResponse struct {
+	Transfer TransferBody `json:"transfer"`
+}
+
+type Body struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Kind           string `json:"kind"`
+	OpeningBalance Money  `json:"opening_balance"`
+}
+
+type TransferBody struct {
+	ID            string `json:"id"`
+	FromAccountID string `json:"from_account_id"`
+	ToAccountID   string `json:"to_account_id"`
+	Amount        Money  `json:"amount"`
+	TransferDate  string `json:"transfer_date"`
+	Description   string `json:"description"`
+}
+
+type Money struct {
+	Amount   json.Number `json:"amount" validate:"required,positiveDecimal"`
+	Currency string      `json:"currency" validate:"iso4217"`
+}
@@ -0,0 +1,177 @@
+package account_test
+
+import (
+	"encoding/json"
+	"finfit-backend/internal/domain/models"
+	accountService "finfit-backend/internal/domain/services/account"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/account"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	errorResponse = `{"status_code":%d,"msg":"%s","error_detail":"%v","field_errors":%v,"error_code":%d}
+`
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+	userId             uuid.UUID
+	accountServiceMock *accountService.ServiceMock
+}
+
+func (suite *HandlerTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.accountServiceMock = accountService.NewServiceMock()
+}
+
+func (suite *HandlerTestSuite) TearDownTest() {
+	suite.accountServiceMock.ExpectedCalls = nil
+	suite.accountServiceMock.Calls = nil
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (suite *HandlerTestSuite) TestGivenAnAccountToAdd_WhenAdd_ThenReturnStatusCreatedWithCreatedAccount() {
+	expectedAccount := suite.getAccount("Visa", models.CreditCardAccountKind, "-150.5")
+	addCommand, _ := accountService.NewAddCommand("Visa", "credit_card", "-150.5", "ARS")
+	suite.accountServiceMock.MockAdd([]interface{}{suite.userId, addCommand}, []interface{}{expectedAccount, nil}, 1)
+
+	c, rec := suite.mockRequest(http.MethodPost, "/accounts", `{"name":"Visa","kind":"credit_card","opening_balance":{"amount":-150.5,"currency":"ARS"}}`)
+	handler := account.NewHandler(suite.accountServiceMock, suite.getValidator())
+
+	bodyBytes, _ := json.Marshal(account.Response{Account: suite.getAccountBody(expectedAccount)})
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+		assert.Equal(suite.T(), string(bodyBytes)+"\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnAccountWithInvalidKind_WhenAdd_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequest(http.MethodPost, "/accounts", `{"name":"Visa","kind":"crypto","opening_balance":{"amount":0,"currency":"ARS"}}`)
+	handler := account.NewHandler(suite.accountServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, account.FieldValidationErrorMessage, account.FieldValidationErrorMessage, "[{\"field\":\"Kind\",\"message\":\"Kind must be one of [bank credit_card cash savings]\"}]", rest.FieldValidationErrorCode)
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+	suite.accountServiceMock.AssertNotCalled(suite.T(), "Add", mock.Anything, suite.userId, mock.Anything)
+}
+
+func (suite *HandlerTestSuite) TestGivenAnAccountAndDate_WhenGetBalance_ThenReturnStatusOkWithBalance() {
+	storedAccount := suite.getAccount("Wallet", models.CashAccountKind, "100")
+	balance, _ := models.NewMoney("42.5", "ARS")
+	command, _ := accountService.NewGetBalanceCommand(storedAccount.Id(), time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC))
+	suite.accountServiceMock.MockGetBalance([]interface{}{suite.userId, command}, []interface{}{balance, nil}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodGet, "/accounts/:id/balance?date=2022-03-31", storedAccount.Id().String(), "")
+	handler := account.NewHandler(suite.accountServiceMock, suite.getValidator())
+
+	bodyBytes, _ := json.Marshal(account.BalanceResponse{
+		AccountID: storedAccount.Id().String(),
+		Date:      "2022-03-31",
+		Balance:   account.Money{Amount: "42.50", Currency: "ARS"},
+	})
+	if assert.NoError(suite.T(), handler.GetBalance(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), string(bodyBytes)+"\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenANonExistentAccount_WhenGetBalance_ThenReturnStatusNotFound() {
+	id := uuid.New()
+	command, _ := accountService.NewGetBalanceCommand(id, time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC))
+	serviceErr := accountService.AccountNotFoundError{Msg: "the account doesn't exists"}
+	suite.accountServiceMock.MockGetBalance([]interface{}{suite.userId, command}, []interface{}{nil, serviceErr}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodGet, "/accounts/:id/balance?date=2022-03-31", id.String(), "")
+	handler := account.NewHandler(suite.accountServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusNotFound, serviceErr.Error(), serviceErr.Error(), "[]", 0)
+	if assert.NoError(suite.T(), handler.GetBalance(c)) {
+		assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenATransfer_WhenTransfer_ThenReturnStatusCreatedWithTransfer() {
+	fromAccount := suite.getAccount("Bank", models.BankAccountKind, "1000")
+	toAccount := suite.getAccount("Wallet", models.CashAccountKind, "0")
+	amount, _ := models.NewMoney("200", "ARS")
+	transferDate := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	transfer, _ := models.NewTransfer(fromAccount, toAccount, amount, transferDate, "ATM")
+	command, _ := accountService.NewTransferCommand(fromAccount.Id(), toAccount.Id(), "200", "ARS", transferDate, "ATM")
+	suite.accountServiceMock.MockTransfer([]interface{}{suite.userId, command}, []interface{}{transfer, nil}, 1)
+
+	requestBody := fmt.Sprintf(`{"from_account_id":"%s","to_account_id":"%s","amount":{"amount":200,"currency":"ARS"},"transfer_date":"2022-03-01","description":"ATM"}`,
+		fromAccount.Id().String(), toAccount.Id().String())
+	c, rec := suite.mockRequest(http.MethodPost, "/transfers", requestBody)
+	handler := account.NewHandler(suite.accountServiceMock, suite.getValidator())
+
+	bodyBytes, _ := json.Marshal(account.TransferResponse{Transfer: account.TransferBody{
+		ID:            transfer.Id().String(),
+		FromAccountID: fromAccount.Id().String(),
+		ToAccountID:   toAccount.Id().String(),
+		Amount:        account.Money{Amount: "200.00", Currency: "ARS"},
+		TransferDate:  "2022-03-01",
+		Description:   "ATM",
+	}})
+	if assert.NoError(suite.T(), handler.Transfer(c)) {
+		assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+		assert.Equal(suite.T(), string(bodyBytes)+"\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) getAccount(name string, kind models.AccountKind, openingBalance string) *models.Account {
+	money, _ := models.NewMoney(openingBalance, "ARS")
+	storedAccount, _ := models.NewAccount(name, kind, money)
+	return storedAccount
+}
+
+func (suite *HandlerTestSuite) getAccountBody(storedAccount *models.Account) account.Body {
+	return account.Body{
+		ID:   storedAccount.Id().String(),
+		Name: storedAccount.Name(),
+		Kind: string(storedAccount.Kind()),
+		OpeningBalance: account.Money{
+			Amount:   json.Number(storedAccount.OpeningBalance().Amount()),
+			Currency: storedAccount.OpeningBalance().Currency(),
+		},
+	}
+}
+
+func (suite *HandlerTestSuite) getValidator() fieldvalidation.FieldsValidator {
+	validator, _ := fieldvalidation.RegisterFieldsValidator(nil, nil)
+	return validator
+}
+
+func (suite *HandlerTestSuite) mockRequest(method string, path string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	return c, rec
+}
+
+func (suite *HandlerTestSuite) mockRequestWithId(method string, path string, id string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	c, rec := suite.mockRequest(method, path, body)
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	return c, rec
+}
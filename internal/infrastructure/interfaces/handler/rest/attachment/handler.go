@@ -0,0 +1,215 @@
+package attachment
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/attachment"
+	"finfit-backend/internal/domain/services/expense"
+	"finfit-backend/internal/domain/services/ledger"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	BodyIsInvalidErrorMessage     = "body is invalid"
+	InvalidIdErrorMessage         = "id path param is invalid, it must be a valid UUID"
+	InvalidAttachmentErrorMessage = "attachment_id path param is invalid, it must be a valid UUID"
+	UnexpectedErrorMessage        = "unexpected error"
+	FileFormField                 = "file"
+	ExpenseIdParam                = "id"
+	AttachmentIdParam             = "attachment_id"
+	// maxUploadRequestSize leaves room for the parts and headers of the multipart form around the file.
+	maxUploadRequestSize = models.MaxAttachmentSize + 1<<20
+)
+
+type Handler interface {
+	Add(context echo.Context) error
+	GetAll(context echo.Context) error
+	Download(context echo.Context) error
+	Delete(context echo.Context) error
+}
+
+type handler struct {
+	service         attachment.Service
+	fieldsValidator fieldvalidation.FieldsValidator
+}
+
+func NewHandler(service attachment.Service, fieldsValidator fieldvalidation.FieldsValidator) *handler {
+	return &handler{service: service, fieldsValidator: fieldsValidator}
+}
+
+// Add attaches the file sent in the file field of a multipart form to the expense of the path. Requests much larger
+// than MaxAttachmentSize are cut off while they are read.
+func (h handler) Add(context echo.Context) error {
+	expenseId, err := uuid.Parse(context.Param(ExpenseIdParam))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	request := context.Request()
+	request.Body = http.MaxBytesReader(context.Response(), request.Body, maxUploadRequestSize)
+	fileHeader, err := context.FormFile(FileFormField)
+	if maxBytesError := new(http.MaxBytesError); errors.As(err, &maxBytesError) {
+		return h.manageServiceError(context, attachment.AttachmentTooLargeError{Msg: "the file is larger than 10 MiB"})
+	} else if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	content, err := readFormFile(fileHeader)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	command, err := attachment.NewAddCommand(expenseId, fileHeader.Filename, content)
+	if errors.As(err, &attachment.AttachmentTooLargeError{}) || errors.As(err, &attachment.UnsupportedContentTypeError{}) {
+		return h.manageServiceError(context, err)
+	} else if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	createdAttachment, err := h.service.Add(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusCreated, Response{Attachment: mapAttachmentToAttachmentBody(createdAttachment)})
+}
+
+func (h handler) GetAll(context echo.Context) error {
+	expenseId, err := uuid.Parse(context.Param(ExpenseIdParam))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	attachments, err := h.service.GetAll(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), expenseId)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	attachmentBodies := []Body{}
+	for _, storedAttachment := range attachments {
+		attachmentBodies = append(attachmentBodies, mapAttachmentToAttachmentBody(storedAttachment))
+	}
+
+	return context.JSON(http.StatusOK, SearchResponse{Attachments: attachmentBodies})
+}
+
+// Download streams the content of the attachment as a file to save, never to render, so an uploaded file can't run in
+// the pages of the app.
+func (h handler) Download(context echo.Context) error {
+	expenseId, err := uuid.Parse(context.Param(ExpenseIdParam))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	attachmentId, err := uuid.Parse(context.Param(AttachmentIdParam))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidAttachmentErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	storedAttachment, content, err := h.service.Download(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), expenseId, attachmentId)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+	defer content.Close()
+
+	response := context.Response()
+	response.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", storedAttachment.FileName()))
+	response.Header().Set(echo.HeaderContentLength, strconv.FormatInt(storedAttachment.Size(), 10))
+	response.Header().Set(echo.HeaderXContentTypeOptions, "nosniff")
+	return context.Stream(http.StatusOK, storedAttachment.ContentType(), content)
+}
+
+func (h handler) Delete(context echo.Context) error {
+	expenseId, err := uuid.Parse(context.Param(ExpenseIdParam))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	attachmentId, err := uuid.Parse(context.Param(AttachmentIdParam))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidAttachmentErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if err = h.service.Delete(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), expenseId, attachmentId); err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.NoContent(http.StatusNoContent)
+}
+
+// readFormFile reads at most one byte over MaxAttachmentSize, enough for the command to tell the file is too large.
+func readFormFile(fileHeader *multipart.FileHeader) ([]byte, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return io.ReadAll(io.LimitReader(file, models.MaxAttachmentSize+1))
+}
+
+func (h handler) manageServiceError(ctx echo.Context, err error) error {
+	if errors.As(err, &attachment.AttachmentTooLargeError{}) {
+		return h.buildErrorResponse(ctx, http.StatusRequestEntityTooLarge, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &attachment.UnsupportedContentTypeError{}) {
+		return h.buildErrorResponse(ctx, http.StatusUnsupportedMediaType, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &attachment.DuplicateAttachmentError{}) {
+		return h.buildErrorResponse(ctx, http.StatusConflict, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &attachment.InvalidDomainModelError{}) {
+		return h.buildErrorResponse(ctx, http.StatusBadRequest, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &attachment.AttachmentNotFoundError{}) || errors.As(err, &expense.ExpenseNotFoundError{}) {
+		return h.buildErrorResponse(ctx, http.StatusNotFound, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.LedgerNotFoundError{}) {
+		return h.buildErrorResponse(ctx, http.StatusNotFound, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.ForbiddenError{}) {
+		return h.buildErrorResponse(ctx, http.StatusForbidden, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if statusCode, msg, ok := rest.EndedRequestError(ctx); ok {
+		return h.buildErrorResponse(ctx, statusCode, msg, err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else {
+		return h.buildErrorResponse(ctx, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+}
+
+func (h handler) buildErrorResponse(ctx echo.Context, statusCode int, errorMessage string, errorDetail string, fieldErrors []fieldvalidation.FieldError, errorCode uint) error {
+	errorResponse := rest.ErrorResponse{StatusCode: statusCode, Msg: errorMessage, ErrorDetail: errorDetail, FieldErrors: fieldErrors, ErrorCode: errorCode}
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+func mapAttachmentToAttachmentBody(attachment *models.Attachment) Body {
+	return Body{
+		ID:          attachment.Id().String(),
+		ExpenseID:   attachment.ExpenseId().String(),
+		FileName:    attachment.FileName(),
+		ContentType: attachment.ContentType(),
+		Size:        attachment.Size(),
+		SHA256:      attachment.Checksum(),
+		UploadedAt:  attachment.UploadedAt().Format(time.RFC3339),
+	}
+}
+
+type Response struct {
+	Attachment Body `json:"attachment"`
+}
+
+type SearchResponse struct {
+	Attachments []Body `json:"attachments"`
+}
+
+type Body struct {
+	ID          string `json:"id"`
+	ExpenseID   string `json:"expense_id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	SHA256      string `json:"sha256"`
+	UploadedAt  string `json:"uploaded_at"`
+}
@@ -0,0 +1,232 @@
+package attachment_test
+
+import (
+	"bytes"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	attachmentService "finfit-backend/internal/domain/services/attachment"
+	expenseService "finfit-backend/internal/domain/services/expense"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/attachment"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	errorResponse = `{"status_code":%d,"msg":"%s","error_detail":"%v","field_errors":%v,"error_code":%d}
+`
+	pdfContent = "%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\n%%EOF"
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+	userId                uuid.UUID
+	ledgerId              uuid.UUID
+	expenseId             uuid.UUID
+	attachmentServiceMock *attachmentService.ServiceMock
+}
+
+func (suite *HandlerTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.ledgerId = uuid.New()
+	suite.expenseId = uuid.New()
+	suite.attachmentServiceMock = attachmentService.NewServiceMock()
+}
+
+func (suite *HandlerTestSuite) TearDownTest() {
+	suite.attachmentServiceMock.ExpectedCalls = nil
+	suite.attachmentServiceMock.Calls = nil
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (suite *HandlerTestSuite) TestGivenAReceipt_WhenAdd_ThenReturnStatusCreatedWithTheAttachment() {
+	createdAttachment := suite.getAttachment()
+	command, _ := attachmentService.NewAddCommand(suite.expenseId, "receipt.pdf", []byte(pdfContent))
+	suite.attachmentServiceMock.MockAdd([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{createdAttachment, nil}, 1)
+
+	c, rec := suite.mockUploadRequest(suite.expenseId.String(), "receipt.pdf", []byte(pdfContent))
+	handler := attachment.NewHandler(suite.attachmentServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(`{"attachment":{"id":"%s","expense_id":"%s","file_name":"receipt.pdf","content_type":"application/pdf","size":%d,"sha256":"%s","uploaded_at":"2022-05-14T10:00:00Z"}}`+"\n",
+		createdAttachment.Id(), suite.expenseId, len(pdfContent), createdAttachment.Checksum())
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenATextFile_WhenAdd_ThenReturnStatusUnsupportedMediaType() {
+	c, rec := suite.mockUploadRequest(suite.expenseId.String(), "receipt.pdf", []byte("just some notes"))
+	handler := attachment.NewHandler(suite.attachmentServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusUnsupportedMediaType, rec.Code)
+	}
+	suite.attachmentServiceMock.AssertNotCalled(suite.T(), "Add", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *HandlerTestSuite) TestGivenATooLargeFile_WhenAdd_ThenReturnStatusRequestEntityTooLarge() {
+	content := append([]byte(pdfContent), bytes.Repeat([]byte{0}, models.MaxAttachmentSize)...)
+
+	c, rec := suite.mockUploadRequest(suite.expenseId.String(), "scan.pdf", content)
+	handler := attachment.NewHandler(suite.attachmentServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusRequestEntityTooLarge, rec.Code)
+	}
+	suite.attachmentServiceMock.AssertNotCalled(suite.T(), "Add", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *HandlerTestSuite) TestGivenARequestFarLargerThanTheLimit_WhenAdd_ThenReturnStatusRequestEntityTooLarge() {
+	content := bytes.Repeat([]byte{0}, 2*models.MaxAttachmentSize)
+
+	c, rec := suite.mockUploadRequest(suite.expenseId.String(), "scan.pdf", content)
+	handler := attachment.NewHandler(suite.attachmentServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusRequestEntityTooLarge, rec.Code)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAFileAlreadyAttached_WhenAdd_ThenReturnStatusConflict() {
+	command, _ := attachmentService.NewAddCommand(suite.expenseId, "receipt.pdf", []byte(pdfContent))
+	serviceError := attachmentService.DuplicateAttachmentError{Msg: "the same file is already attached to the expense"}
+	suite.attachmentServiceMock.MockAdd([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{nil, serviceError}, 1)
+
+	c, rec := suite.mockUploadRequest(suite.expenseId.String(), "receipt.pdf", []byte(pdfContent))
+	handler := attachment.NewHandler(suite.attachmentServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusConflict, serviceError.Msg, serviceError.Msg, "[]", 0)
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusConflict, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnExpenseThatNotExists_WhenGetAll_ThenReturnStatusNotFound() {
+	serviceError := expenseService.ExpenseNotFoundError{Msg: "the expense doesn't exists"}
+	suite.attachmentServiceMock.MockGetAll([]interface{}{suite.userId, suite.ledgerId, suite.expenseId}, []interface{}{nil, serviceError}, 1)
+
+	c, rec := suite.mockRequest(http.MethodGet, suite.expenseId.String(), "")
+	handler := attachment.NewHandler(suite.attachmentServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.GetAll(c)) {
+		assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnAttachment_WhenDownload_ThenReturnItsContentAsAFile() {
+	storedAttachment := suite.getAttachment()
+	suite.attachmentServiceMock.MockDownload([]interface{}{suite.userId, suite.ledgerId, suite.expenseId, storedAttachment.Id()}, []interface{}{storedAttachment, io.NopCloser(strings.NewReader(pdfContent)), nil}, 1)
+
+	c, rec := suite.mockRequest(http.MethodGet, suite.expenseId.String(), storedAttachment.Id().String())
+	handler := attachment.NewHandler(suite.attachmentServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Download(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), pdfContent, rec.Body.String())
+		assert.Equal(suite.T(), "application/pdf", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(suite.T(), `attachment; filename="receipt.pdf"`, rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Equal(suite.T(), "nosniff", rec.Header().Get(echo.HeaderXContentTypeOptions))
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnInvalidAttachmentId_WhenDownload_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequest(http.MethodGet, suite.expenseId.String(), "not-an-id")
+	handler := attachment.NewHandler(suite.attachmentServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Download(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Contains(suite.T(), rec.Body.String(), attachment.InvalidAttachmentErrorMessage)
+	}
+	suite.attachmentServiceMock.AssertNotCalled(suite.T(), "Download", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *HandlerTestSuite) TestGivenAnAttachment_WhenDelete_ThenReturnStatusNoContent() {
+	id := uuid.New()
+	suite.attachmentServiceMock.MockDelete([]interface{}{suite.userId, suite.ledgerId, suite.expenseId, id}, []interface{}{nil}, 1)
+
+	c, rec := suite.mockRequest(http.MethodDelete, suite.expenseId.String(), id.String())
+	handler := attachment.NewHandler(suite.attachmentServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Delete(c)) {
+		assert.Equal(suite.T(), http.StatusNoContent, rec.Code)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenThatServiceFails_WhenDelete_ThenReturnStatusInternalServerError() {
+	id := uuid.New()
+	serviceError := attachmentService.UnexpectedError{Msg: "connection lost"}
+	suite.attachmentServiceMock.MockDelete([]interface{}{suite.userId, suite.ledgerId, suite.expenseId, id}, []interface{}{serviceError}, 1)
+
+	c, rec := suite.mockRequest(http.MethodDelete, suite.expenseId.String(), id.String())
+	handler := attachment.NewHandler(suite.attachmentServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusInternalServerError, attachment.UnexpectedErrorMessage, serviceError.Msg, "[]", 0)
+	if assert.NoError(suite.T(), handler.Delete(c)) {
+		assert.Equal(suite.T(), http.StatusInternalServerError, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) getAttachment() *models.Attachment {
+	checksum := strings.Repeat("ab", 32)
+	storedAttachment, _ := models.NewAttachmentWithId(uuid.New(), suite.expenseId, "receipt.pdf", "application/pdf", int64(len(pdfContent)), checksum, time.Date(2022, 5, 14, 10, 0, 0, 0, time.UTC))
+	return storedAttachment
+}
+
+func (suite *HandlerTestSuite) getValidator() fieldvalidation.FieldsValidator {
+	validator, _ := fieldvalidation.RegisterFieldsValidator(nil, nil)
+	return validator
+}
+
+// mockRequest builds a request to an attachment of the expense, or to the list of its attachments when attachmentId is
+// empty.
+func (suite *HandlerTestSuite) mockRequest(method string, expenseId string, attachmentId string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, "/expenses/"+expenseId+"/attachments/"+attachmentId, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	rest.SetLedgerId(c, suite.ledgerId)
+	c.SetParamNames(attachment.ExpenseIdParam, attachment.AttachmentIdParam)
+	c.SetParamValues(expenseId, attachmentId)
+	return c, rec
+}
+
+func (suite *HandlerTestSuite) mockUploadRequest(expenseId string, fileName string, content []byte) (echo.Context, *httptest.ResponseRecorder) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	fileWriter, err := writer.CreateFormFile(attachment.FileFormField, fileName)
+	if err != nil {
+		panic(errors.New("the request couldn't be built"))
+	}
+	_, _ = fileWriter.Write(content)
+	_ = writer.Close()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/expenses/"+expenseId+"/attachments", body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	rest.SetLedgerId(c, suite.ledgerId)
+	c.SetParamNames(attachment.ExpenseIdParam)
+	c.SetParamValues(expenseId)
+	return c, rec
+}
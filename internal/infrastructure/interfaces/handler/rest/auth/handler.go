@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/user"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/pkg/fieldvalidation"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+const (
+	FieldValidationErrorMessage = "some fields are invalid"
+	BodyIsInvalidErrorMessage   = "body is invalid"
+	UnexpectedErrorMessage      = "unexpected error"
+	tokenType                   = "Bearer"
+)
+
+type Handler interface {
+	Register(context echo.Context) error
+	Login(context echo.Context) error
+	Refresh(context echo.Context) error
+}
+
+type handler struct {
+	service         user.Service
+	fieldsValidator fieldvalidation.FieldsValidator
+}
+
+func NewHandler(service user.Service, fieldsValidator fieldvalidation.FieldsValidator) *handler {
+	return &handler{service: service, fieldsValidator: fieldsValidator}
+}
+
+func (h handler) Register(context echo.Context) error {
+	requestBody := new(RegisterRequest)
+	if err := context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := user.NewRegisterCommand(requestBody.Email, requestBody.Password)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	registeredUser, err := h.service.Register(context.Request().Context(), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusCreated, RegisterResponse{User: h.mapUserToUserBody(registeredUser)})
+}
+
+func (h handler) Login(context echo.Context) error {
+	requestBody := new(LoginRequest)
+	if err := context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := user.NewLoginCommand(requestBody.Email, requestBody.Password)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	session, err := h.service.Login(context.Request().Context(), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusOK, h.mapSessionToTokenResponse(session))
+}
+
+func (h handler) Refresh(context echo.Context) error {
+	requestBody := new(RefreshRequest)
+	if err := context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := user.NewRefreshCommand(requestBody.RefreshToken)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	session, err := h.service.Refresh(context.Request().Context(), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusOK, h.mapSessionToTokenResponse(session))
+}
+
+func (h handler) manageServiceError(ctx echo.Context, err error) error {
+	if errors.As(err, &user.UserAlreadyExistsError{}) {
+		return h.buildErrorResponse(ctx, http.StatusConflict, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &user.InvalidCredentialsError{}) || errors.As(err, &user.InvalidTokenError{}) {
+		return h.buildErrorResponse(ctx, http.StatusUnauthorized, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &user.InvalidDomainModelError{}) {
+		return h.buildErrorResponse(ctx, http.StatusBadRequest, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if statusCode, msg, ok := rest.EndedRequestError(ctx); ok {
+		return h.buildErrorResponse(ctx, statusCode, msg, err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else {
+		return h.buildErrorResponse(ctx, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+}
+
+func (h handler) buildErrorResponse(ctx echo.Context, statusCode int, errorMessage string, errorDetail string, fieldErrors []fieldvalidation.FieldError, errorCode uint) error {
+	errorResponse := rest.ErrorResponse{StatusCode: statusCode, Msg: errorMessage, ErrorDetail: errorDetail, FieldErrors: fieldErrors, ErrorCode: errorCode}
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+func (h handler) mapUserToUserBody(registeredUser *models.User) UserBody {
+	return UserBody{
+		ID:    registeredUser.Id().String(),
+		Email: registeredUser.Email(),
+	}
+}
+
+func (h handler) mapSessionToTokenResponse(session *user.Session) TokenResponse {
+	return TokenResponse{
+		User:                  h.mapUserToUserBody(session.User),
+		TokenType:             tokenType,
+		AccessToken:           session.AccessToken,
+		AccessTokenExpiresAt:  session.AccessTokenExpiresAt.UTC().Format(time.RFC3339),
+		RefreshToken:          session.RefreshToken,
+		RefreshTokenExpiresAt: session.RefreshTokenExpiresAt.UTC().Format(time.RFC3339),
+	}
+}
+
+type RegisterRequest struct {
+	Email    string `json:"email" validate:"required,email,max=254"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type RegisterResponse struct {
+	User UserBody `json:"user"`
+}
+
+type TokenResponse struct {
+	User                  UserBody `json:"user"`
+	TokenType             string   `json:"token_type"`
+	AccessToken           string   `json:"access_token"`
+	AccessTokenExpiresAt  string   `json:"access_token_expires_at"`
+	RefreshToken          string   `json:"refresh_token"`
+	RefreshTokenExpiresAt string   `json:"refresh_token_expires_at"`
+}
+
+type UserBody struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+}
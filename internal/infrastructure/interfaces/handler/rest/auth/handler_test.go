@@ -0,0 +1,158 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"finfit-backend/internal/domain/models"
+	userService "finfit-backend/internal/domain/services/user"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/auth"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	errorResponse = `{"status_code":%d,"msg":"%s","error_detail":"%v","field_errors":%v,"error_code":%d}
+`
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+	userServiceMock *userService.ServiceMock
+}
+
+func (suite *HandlerTestSuite) SetupSuite() {
+	suite.userServiceMock = userService.NewServiceMock()
+}
+
+func (suite *HandlerTestSuite) TearDownTest() {
+	suite.userServiceMock.ExpectedCalls = nil
+	suite.userServiceMock.Calls = nil
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (suite *HandlerTestSuite) TestGivenANewUser_WhenRegister_ThenReturnStatusCreatedWithoutThePassword() {
+	registeredUser, _ := models.NewUserWithId(uuid.New(), "ana@mail.com", "$2a$10$hash")
+	command, _ := userService.NewRegisterCommand("ana@mail.com", "correct horse battery")
+	suite.userServiceMock.MockRegister([]interface{}{command}, []interface{}{registeredUser, nil}, 1)
+
+	c, rec := suite.mockRequest("/auth/register", `{"email":"ana@mail.com","password":"correct horse battery"}`)
+	handler := auth.NewHandler(suite.userServiceMock, suite.getValidator())
+
+	bodyBytes, _ := json.Marshal(auth.RegisterResponse{User: auth.UserBody{ID: registeredUser.Id().String(), Email: "ana@mail.com"}})
+	if assert.NoError(suite.T(), handler.Register(c)) {
+		assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+		assert.Equal(suite.T(), string(bodyBytes)+"\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnInvalidEmailAndShortPassword_WhenRegister_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequest("/auth/register", `{"email":"ana","password":"short"}`)
+	handler := auth.NewHandler(suite.userServiceMock, suite.getValidator())
+
+	fieldErrors := `[{"field":"Email","message":"Email must be a valid email address"},{"field":"Password","message":"Password must be at least 8 characters in length"}]`
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, auth.FieldValidationErrorMessage, auth.FieldValidationErrorMessage, fieldErrors, rest.FieldValidationErrorCode)
+	if assert.NoError(suite.T(), handler.Register(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnEmailAlreadyRegistered_WhenRegister_ThenReturnStatusConflict() {
+	command, _ := userService.NewRegisterCommand("ana@mail.com", "correct horse battery")
+	suite.userServiceMock.MockRegister([]interface{}{command}, []interface{}{nil, userService.UserAlreadyExistsError{Msg: "a user with the same email already exists"}}, 1)
+
+	c, rec := suite.mockRequest("/auth/register", `{"email":"ana@mail.com","password":"correct horse battery"}`)
+	handler := auth.NewHandler(suite.userServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusConflict, "a user with the same email already exists", "a user with the same email already exists", "[]", 0)
+	if assert.NoError(suite.T(), handler.Register(c)) {
+		assert.Equal(suite.T(), http.StatusConflict, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenValidCredentials_WhenLogin_ThenReturnStatusOkWithTheTokens() {
+	session := suite.getSession()
+	command, _ := userService.NewLoginCommand("ana@mail.com", "correct horse battery")
+	suite.userServiceMock.MockLogin([]interface{}{command}, []interface{}{session, nil}, 1)
+
+	c, rec := suite.mockRequest("/auth/login", `{"email":"ana@mail.com","password":"correct horse battery"}`)
+	handler := auth.NewHandler(suite.userServiceMock, suite.getValidator())
+
+	bodyBytes, _ := json.Marshal(auth.TokenResponse{
+		User:                  auth.UserBody{ID: session.User.Id().String(), Email: "ana@mail.com"},
+		TokenType:             "Bearer",
+		AccessToken:           "access",
+		AccessTokenExpiresAt:  "2022-03-01T10:15:00Z",
+		RefreshToken:          "refresh",
+		RefreshTokenExpiresAt: "2022-03-02T10:00:00Z",
+	})
+	if assert.NoError(suite.T(), handler.Login(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), string(bodyBytes)+"\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenWrongCredentials_WhenLogin_ThenReturnStatusUnauthorized() {
+	command, _ := userService.NewLoginCommand("ana@mail.com", "wrong password")
+	suite.userServiceMock.MockLogin([]interface{}{command}, []interface{}{nil, userService.InvalidCredentialsError{Msg: "the email or the password are wrong"}}, 1)
+
+	c, rec := suite.mockRequest("/auth/login", `{"email":"ana@mail.com","password":"wrong password"}`)
+	handler := auth.NewHandler(suite.userServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusUnauthorized, "the email or the password are wrong", "the email or the password are wrong", "[]", 0)
+	if assert.NoError(suite.T(), handler.Login(c)) {
+		assert.Equal(suite.T(), http.StatusUnauthorized, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnInvalidRefreshToken_WhenRefresh_ThenReturnStatusUnauthorized() {
+	command, _ := userService.NewRefreshCommand("expired")
+	suite.userServiceMock.MockRefresh([]interface{}{command}, []interface{}{nil, userService.InvalidTokenError{Msg: "the token is invalid or has expired"}}, 1)
+
+	c, rec := suite.mockRequest("/auth/refresh", `{"refresh_token":"expired"}`)
+	handler := auth.NewHandler(suite.userServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusUnauthorized, "the token is invalid or has expired", "the token is invalid or has expired", "[]", 0)
+	if assert.NoError(suite.T(), handler.Refresh(c)) {
+		assert.Equal(suite.T(), http.StatusUnauthorized, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) getSession() *userService.Session {
+	sessionUser, _ := models.NewUserWithId(uuid.New(), "ana@mail.com", "$2a$10$hash")
+	return &userService.Session{
+		User:                  sessionUser,
+		AccessToken:           "access",
+		AccessTokenExpiresAt:  time.Date(2022, 3, 1, 10, 15, 0, 0, time.UTC),
+		RefreshToken:          "refresh",
+		RefreshTokenExpiresAt: time.Date(2022, 3, 2, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func (suite *HandlerTestSuite) getValidator() fieldvalidation.FieldsValidator {
+	validator, _ := fieldvalidation.RegisterFieldsValidator(nil, nil)
+	return validator
+}
+
+func (suite *HandlerTestSuite) mockRequest(path string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
@@ -0,0 +1,57 @@
+package rest
+
+import (
+	"finfit-backend/pkg/fieldvalidation"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strings"
+)
+
+const (
+	MissingAccessTokenErrorMessage = "the Authorization header must have a Bearer access token"
+	InvalidAccessTokenErrorMessage = "the access token is invalid or has expired"
+	userIdContextKey               = "user_id"
+	bearerScheme                   = "Bearer "
+)
+
+type Authenticator interface {
+	Authenticate(accessToken string) (uuid.UUID, error)
+}
+
+// Authentication rejects the requests without a valid access token and keeps the id of the user it was issued to, so
+// the handlers can scope everything they do to that user with UserId.
+func Authentication(authenticator Authenticator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(context echo.Context) error {
+			authorization := context.Request().Header.Get(echo.HeaderAuthorization)
+			if len(authorization) <= len(bearerScheme) || !strings.EqualFold(authorization[:len(bearerScheme)], bearerScheme) {
+				return unauthorized(context, MissingAccessTokenErrorMessage, MissingAccessTokenErrorMessage)
+			}
+
+			userId, err := authenticator.Authenticate(strings.TrimSpace(authorization[len(bearerScheme):]))
+			if err != nil {
+				return unauthorized(context, InvalidAccessTokenErrorMessage, err.Error())
+			}
+
+			SetUserId(context, userId)
+			return next(context)
+		}
+	}
+}
+
+// UserId returns the id of the authenticated user, or uuid.Nil when the route isn't behind Authentication.
+func UserId(context echo.Context) uuid.UUID {
+	userId, _ := context.Get(userIdContextKey).(uuid.UUID)
+	return userId
+}
+
+func SetUserId(context echo.Context, userId uuid.UUID) {
+	context.Set(userIdContextKey, userId)
+}
+
+func unauthorized(context echo.Context, errorMessage string, errorDetail string) error {
+	context.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+	errorResponse := ErrorResponse{StatusCode: http.StatusUnauthorized, Msg: errorMessage, ErrorDetail: errorDetail, FieldErrors: []fieldvalidation.FieldError{}}
+	return context.JSON(http.StatusUnauthorized, errorResponse)
+}
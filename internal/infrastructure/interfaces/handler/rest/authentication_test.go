@@ -0,0 +1,78 @@
+package rest_test
+
+import (
+	userService "finfit-backend/internal/domain/services/user"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	errorResponse = `{"status_code":%d,"msg":"%s","error_detail":"%v","field_errors":%v,"error_code":%d}
+`
+)
+
+func TestGivenAValidAccessToken_WhenAuthentication_ThenCallTheHandlerWithTheUserId(t *testing.T) {
+	userId := uuid.New()
+	authenticator := userService.NewServiceMock()
+	authenticator.MockAuthenticate([]interface{}{"valid"}, []interface{}{userId, nil}, 1)
+	c, rec := mockRequest("Bearer valid")
+
+	var handledUserId uuid.UUID
+	err := rest.Authentication(authenticator)(func(context echo.Context) error {
+		handledUserId = rest.UserId(context)
+		return context.NoContent(http.StatusNoContent)
+	})(c)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, userId, handledUserId)
+}
+
+func TestGivenNoAuthorizationHeader_WhenAuthentication_ThenReturnStatusUnauthorized(t *testing.T) {
+	c, rec := mockRequest("")
+
+	err := rest.Authentication(userService.NewServiceMock())(failIfCalled(t))(c)
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusUnauthorized, rest.MissingAccessTokenErrorMessage, rest.MissingAccessTokenErrorMessage, "[]", 0)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "Bearer", rec.Header().Get(echo.HeaderWWWAuthenticate))
+	assert.Equal(t, expectedResponseBody, rec.Body.String())
+}
+
+func TestGivenAnInvalidAccessToken_WhenAuthentication_ThenReturnStatusUnauthorized(t *testing.T) {
+	authenticator := userService.NewServiceMock()
+	authenticator.MockAuthenticate([]interface{}{"expired"}, []interface{}{uuid.Nil, userService.InvalidTokenError{Msg: "the token is invalid or has expired"}}, 1)
+	c, rec := mockRequest("Bearer expired")
+
+	err := rest.Authentication(authenticator)(failIfCalled(t))(c)
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusUnauthorized, rest.InvalidAccessTokenErrorMessage, "the token is invalid or has expired", "[]", 0)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, expectedResponseBody, rec.Body.String())
+}
+
+func failIfCalled(t *testing.T) echo.HandlerFunc {
+	return func(context echo.Context) error {
+		t.Fatal("the handler must not be called without a valid access token")
+		return nil
+	}
+}
+
+func mockRequest(authorization string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/expenses", nil)
+	if authorization != "" {
+		req.Header.Set(echo.HeaderAuthorization, authorization)
+	}
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
@@ -0,0 +1,184 @@
+package balance
+
+import (
+	"encoding/json"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/balance"
+	"finfit-backend/internal/domain/services/ledger"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/pkg/fieldvalidation"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+const (
+	FieldValidationErrorMessage = "some fields are invalid"
+	BodyIsInvalidErrorMessage   = "body is invalid"
+	UnexpectedErrorMessage      = "unexpected error"
+	DateFormat                  = "2006-01-02"
+)
+
+type Handler interface {
+	GetBalances(context echo.Context) error
+	Settle(context echo.Context) error
+}
+
+type handler struct {
+	service         balance.Service
+	fieldsValidator fieldvalidation.FieldsValidator
+}
+
+func NewHandler(service balance.Service, fieldsValidator fieldvalidation.FieldsValidator) *handler {
+	return &handler{service: service, fieldsValidator: fieldsValidator}
+}
+
+func (h handler) GetBalances(context echo.Context) error {
+	balances, err := h.service.GetBalances(context.Request().Context(), rest.UserId(context), rest.LedgerId(context))
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusOK, h.mapBalancesToBalancesResponse(balances))
+}
+
+// Settle records a repayment between two members of the ledger. Without a settled_at date it is recorded as made
+// today.
+func (h handler) Settle(context echo.Context) error {
+	requestBody := new(SettleRequest)
+
+	if err := context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := requestBody.mapToSettleCommand()
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	settlement, err := h.service.Settle(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusCreated, SettleResponse{Settlement: h.mapSettlementToSettlementBody(settlement)})
+}
+
+func (h handler) manageServiceError(ctx echo.Context, err error) error {
+	if errors.As(err, &balance.InvalidMemberError{}) || errors.As(err, &balance.InvalidDomainModelError{}) {
+		return h.buildErrorResponse(ctx, http.StatusBadRequest, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.LedgerNotFoundError{}) {
+		return h.buildErrorResponse(ctx, http.StatusNotFound, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.ForbiddenError{}) {
+		return h.buildErrorResponse(ctx, http.StatusForbidden, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if statusCode, msg, ok := rest.EndedRequestError(ctx); ok {
+		return h.buildErrorResponse(ctx, statusCode, msg, err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else {
+		return h.buildErrorResponse(ctx, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+}
+
+func (h handler) buildErrorResponse(ctx echo.Context, statusCode int, errorMessage string, errorDetail string, fieldErrors []fieldvalidation.FieldError, errorCode uint) error {
+	errorResponse := rest.ErrorResponse{StatusCode: statusCode, Msg: errorMessage, ErrorDetail: errorDetail, FieldErrors: fieldErrors, ErrorCode: errorCode}
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+func (h handler) mapBalancesToBalancesResponse(balances *balance.Balances) BalancesResponse {
+	balanceBodies := []Body{}
+	for _, userBalance := range balances.Balances {
+		balanceBodies = append(balanceBodies, Body{UserID: userBalance.UserId().String(), Amount: mapMoneyToMoneyBody(userBalance.Amount())})
+	}
+
+	transferBodies := []TransferBody{}
+	for _, transfer := range balances.Transfers {
+		transferBodies = append(transferBodies, TransferBody{
+			FromUserID: transfer.From().String(),
+			ToUserID:   transfer.To().String(),
+			Amount:     mapMoneyToMoneyBody(transfer.Amount()),
+		})
+	}
+
+	return BalancesResponse{Balances: balanceBodies, Transfers: transferBodies}
+}
+
+func (h handler) mapSettlementToSettlementBody(settlement *models.Settlement) SettlementBody {
+	return SettlementBody{
+		ID:         settlement.Id().String(),
+		FromUserID: settlement.From().String(),
+		ToUserID:   settlement.To().String(),
+		Amount:     mapMoneyToMoneyBody(settlement.Amount()),
+		SettledAt:  settlement.SettledAt().Format(DateFormat),
+	}
+}
+
+func mapMoneyToMoneyBody(money *models.Money) Money {
+	return Money{Amount: json.Number(money.Amount()), Currency: money.Currency()}
+}
+
+type SettleRequest struct {
+	FromUserID string `json:"from_user_id" validate:"required,uuid"`
+	ToUserID   string `json:"to_user_id" validate:"required,uuid,nefield=FromUserID"`
+	Amount     Money  `json:"amount"`
+	SettledAt  string `json:"settled_at,omitempty" validate:"omitempty,datetime=2006-01-02"`
+}
+
+func (r SettleRequest) mapToSettleCommand() (*balance.SettleCommand, error) {
+	from, err := uuid.Parse(r.FromUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := uuid.Parse(r.ToUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	settledAt := time.Now().UTC().Truncate(24 * time.Hour)
+	if r.SettledAt != "" {
+		settledAt, _ = time.Parse(DateFormat, r.SettledAt)
+	}
+
+	return balance.NewSettleCommand(from, to, r.Amount.Amount.String(), r.Amount.Currency, settledAt)
+}
+
+// BalancesResponse has what every member is owed, negative when they owe, and the fewest transfers that even them out.
+type BalancesResponse struct {
+	Balances  []Body         `json:"balances"`
+	Transfers []TransferBody `json:"transfers"`
+}
+
+type Body struct {
+	UserID string `json:"user_id"`
+	Amount Money  `json:"amount"`
+}
+
+type TransferBody struct {
+	FromUserID string `json:"from_user_id"`
+	ToUserID   string `json:"to_user_id"`
+	Amount     Money  `json:"amount"`
+}
+
+type SettleResponse struct {
+	Settlement SettlementBody `json:"settlement"`
+}
+
+type SettlementBody struct {
+	ID         string `json:"id"`
+	FromUserID string `json:"from_user_id"`
+	ToUserID   string `json:"to_user_id"`
+	Amount     Money  `json:"amount"`
+	SettledAt  string `json:"settled_at"`
+}
+
+// Money carries the amount as a json.Number so the decimal literal reaches the domain untouched instead of being
+// rounded through a float64.
+type Money struct {
+	Amount   json.Number `json:"amount" validate:"required,positiveDecimal"`
+	Currency string      `json:"currency" validate:"required,iso4217"`
+}
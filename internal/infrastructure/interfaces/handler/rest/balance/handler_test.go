@@ -0,0 +1,147 @@
+package balance_test
+
+import (
+	"finfit-backend/internal/domain/models"
+	balanceService "finfit-backend/internal/domain/services/balance"
+	ledgerService "finfit-backend/internal/domain/services/ledger"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/balance"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	errorResponse = `{"status_code":%d,"msg":"%s","error_detail":"%v","field_errors":%v,"error_code":%d}
+`
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+	userId             uuid.UUID
+	otherUserId        uuid.UUID
+	ledgerId           uuid.UUID
+	balanceServiceMock *balanceService.ServiceMock
+}
+
+func (suite *HandlerTestSuite) SetupSuite() {
+	suite.userId = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	suite.otherUserId = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	suite.ledgerId = uuid.New()
+	suite.balanceServiceMock = balanceService.NewServiceMock()
+}
+
+func (suite *HandlerTestSuite) TearDownTest() {
+	suite.balanceServiceMock.ExpectedCalls = nil
+	suite.balanceServiceMock.Calls = nil
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (suite *HandlerTestSuite) TestGivenALedgerWithSplitExpenses_WhenGetBalances_ThenReturnStatusOkWithBalancesAndTransfers() {
+	owed, _ := models.NewMoney("25.50", "ARS")
+	owing, _ := models.NewMoney("-25.50", "ARS")
+	creditor, _ := models.NewBalance(suite.userId, owed)
+	debtor, _ := models.NewBalance(suite.otherUserId, owing)
+	transfer, _ := models.NewDebt(suite.otherUserId, suite.userId, owed)
+	balances := &balanceService.Balances{Balances: []*models.Balance{creditor, debtor}, Transfers: []*models.Debt{transfer}}
+	suite.balanceServiceMock.MockGetBalances([]interface{}{suite.userId, suite.ledgerId}, []interface{}{balances, nil}, 1)
+
+	c, rec := suite.mockRequest(http.MethodGet, "/balances", "")
+	handler := balance.NewHandler(suite.balanceServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(`{"balances":[{"user_id":"%s","amount":{"amount":25.50,"currency":"ARS"}},{"user_id":"%s","amount":{"amount":-25.50,"currency":"ARS"}}],"transfers":[{"from_user_id":"%s","to_user_id":"%s","amount":{"amount":25.50,"currency":"ARS"}}]}`+"\n",
+		suite.userId, suite.otherUserId, suite.otherUserId, suite.userId)
+	if assert.NoError(suite.T(), handler.GetBalances(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenANonMember_WhenGetBalances_ThenReturnStatusNotFound() {
+	serviceError := ledgerService.LedgerNotFoundError{Msg: "the ledger doesn't exists"}
+	suite.balanceServiceMock.MockGetBalances([]interface{}{suite.userId, suite.ledgerId}, []interface{}{nil, serviceError}, 1)
+
+	c, rec := suite.mockRequest(http.MethodGet, "/balances", "")
+	handler := balance.NewHandler(suite.balanceServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusNotFound, serviceError.Msg, serviceError.Msg, "[]", 0)
+	if assert.NoError(suite.T(), handler.GetBalances(c)) {
+		assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenARepayment_WhenSettle_ThenReturnStatusCreatedWithTheSettlement() {
+	settledAt := time.Date(2022, 3, 8, 0, 0, 0, 0, time.UTC)
+	amount, _ := models.NewMoney("25.50", "ARS")
+	settlement, _ := models.NewSettlementWithId(suite.ledgerId, suite.otherUserId, suite.userId, amount, settledAt)
+	command, _ := balanceService.NewSettleCommand(suite.otherUserId, suite.userId, "25.50", "ARS", settledAt)
+	suite.balanceServiceMock.MockSettle([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{settlement, nil}, 1)
+
+	requestBody := fmt.Sprintf(`{"from_user_id":"%s","to_user_id":"%s","amount":{"amount":25.50,"currency":"ARS"},"settled_at":"2022-03-08"}`, suite.otherUserId, suite.userId)
+	c, rec := suite.mockRequest(http.MethodPost, "/balances/settlements", requestBody)
+	handler := balance.NewHandler(suite.balanceServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(`{"settlement":{"id":"%s","from_user_id":"%s","to_user_id":"%s","amount":{"amount":25.50,"currency":"ARS"},"settled_at":"2022-03-08"}}`+"\n",
+		suite.ledgerId, suite.otherUserId, suite.userId)
+	if assert.NoError(suite.T(), handler.Settle(c)) {
+		assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenARepaymentToTheSameUser_WhenSettle_ThenReturnStatusBadRequest() {
+	requestBody := fmt.Sprintf(`{"from_user_id":"%s","to_user_id":"%s","amount":{"amount":25.50,"currency":"ARS"}}`, suite.userId, suite.userId)
+	c, rec := suite.mockRequest(http.MethodPost, "/balances/settlements", requestBody)
+	handler := balance.NewHandler(suite.balanceServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Settle(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		suite.balanceServiceMock.AssertNotCalled(suite.T(), "Settle", mock.Anything, mock.Anything, mock.Anything)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAUserOutsideTheLedger_WhenSettle_ThenReturnStatusBadRequest() {
+	settledAt := time.Date(2022, 3, 8, 0, 0, 0, 0, time.UTC)
+	command, _ := balanceService.NewSettleCommand(suite.otherUserId, suite.userId, "10", "ARS", settledAt)
+	serviceError := balanceService.InvalidMemberError{Msg: "both users of a settlement must be members of the ledger"}
+	suite.balanceServiceMock.MockSettle([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{nil, serviceError}, 1)
+
+	requestBody := fmt.Sprintf(`{"from_user_id":"%s","to_user_id":"%s","amount":{"amount":10,"currency":"ARS"},"settled_at":"2022-03-08"}`, suite.otherUserId, suite.userId)
+	c, rec := suite.mockRequest(http.MethodPost, "/balances/settlements", requestBody)
+	handler := balance.NewHandler(suite.balanceServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, serviceError.Msg, serviceError.Msg, "[]", 0)
+	if assert.NoError(suite.T(), handler.Settle(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) getValidator() fieldvalidation.FieldsValidator {
+	validator, _ := fieldvalidation.RegisterFieldsValidator(nil, nil)
+	return validator
+}
+
+func (suite *HandlerTestSuite) mockRequest(method string, path string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	rest.SetLedgerId(c, suite.ledgerId)
+	return c, rec
+}
@@ -0,0 +1,283 @@
+package budget
+
+import (
+	"encoding/json"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/budget"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/pkg/fieldvalidation"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+const (
+	FieldValidationErrorMessage  = "some fields are invalid"
+	BodyIsInvalidErrorMessage    = "body is invalid"
+	ParamsAreInvalidErrorMessage = "params are invalid, query param month is required and must have the format YYYY-MM"
+	InvalidIdErrorMessage        = "id path param is invalid, it must be a valid UUID"
+	BudgetNotFoundErrorMessage   = "the budget doesn't exists"
+	UnexpectedErrorMessage       = "unexpected error"
+	MonthFormat                  = "2006-01"
+)
+
+type Handler interface {
+	Add(context echo.Context) error
+	GetAll(context echo.Context) error
+	GetById(context echo.Context) error
+	Update(context echo.Context) error
+	Delete(context echo.Context) error
+	GetStatus(context echo.Context) error
+}
+
+type handler struct {
+	service         budget.Service
+	fieldsValidator fieldvalidation.FieldsValidator
+}
+
+func NewHandler(service budget.Service, fieldsValidator fieldvalidation.FieldsValidator) *handler {
+	return &handler{service: service, fieldsValidator: fieldsValidator}
+}
+
+func (h handler) Add(context echo.Context) error {
+	requestBody := new(BudgetRequest)
+
+	if err := context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	expenseTypeId, err := uuid.Parse(requestBody.ExpenseType.ID)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	command, err := budget.NewAddCommand(expenseTypeId, requestBody.Period, requestBody.Limit.Amount.String(), requestBody.Limit.Currency, requestBody.Rollover)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	addedBudget, err := h.service.Add(context.Request().Context(), rest.UserId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusCreated, Response{Budget: h.mapBudgetToBudgetBody(addedBudget)})
+}
+
+func (h handler) GetAll(context echo.Context) error {
+	budgets, err := h.service.GetAll(context.Request().Context(), rest.UserId(context))
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	budgetBodies := []Body{}
+	for _, storedBudget := range budgets {
+		budgetBodies = append(budgetBodies, h.mapBudgetToBudgetBody(storedBudget))
+	}
+
+	return context.JSON(http.StatusOK, GetAllResponse{Budgets: budgetBodies})
+}
+
+func (h handler) GetById(context echo.Context) error {
+	id, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	storedBudget, err := h.service.GetById(context.Request().Context(), rest.UserId(context), id)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	if storedBudget == nil {
+		return h.buildErrorResponse(context, http.StatusNotFound, BudgetNotFoundErrorMessage, BudgetNotFoundErrorMessage, []fieldvalidation.FieldError{}, 0)
+	}
+
+	return context.JSON(http.StatusOK, Response{Budget: h.mapBudgetToBudgetBody(storedBudget)})
+}
+
+func (h handler) Update(context echo.Context) error {
+	id, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	requestBody := new(BudgetRequest)
+	if err = context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	expenseTypeId, err := uuid.Parse(requestBody.ExpenseType.ID)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	command, err := budget.NewUpdateCommand(id, expenseTypeId, requestBody.Period, requestBody.Limit.Amount.String(), requestBody.Limit.Currency, requestBody.Rollover)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	updatedBudget, err := h.service.Update(context.Request().Context(), rest.UserId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusOK, Response{Budget: h.mapBudgetToBudgetBody(updatedBudget)})
+}
+
+func (h handler) Delete(context echo.Context) error {
+	id, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if err = h.service.Delete(context.Request().Context(), rest.UserId(context), id); err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (h handler) GetStatus(context echo.Context) error {
+	requestParams := new(GetStatusQueryParams)
+
+	if err := context.Bind(requestParams); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, ParamsAreInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestParams); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	month, _ := time.Parse(MonthFormat, requestParams.Month)
+	command, err := budget.NewGetStatusCommand(month)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, ParamsAreInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	statuses, err := h.service.GetStatus(context.Request().Context(), rest.UserId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	statusBodies := []StatusBody{}
+	for _, status := range statuses {
+		statusBodies = append(statusBodies, h.mapBudgetStatusToStatusBody(status))
+	}
+
+	return context.JSON(http.StatusOK, StatusResponse{Month: command.Month().Format(MonthFormat), Budgets: statusBodies})
+}
+
+func (h handler) manageServiceError(ctx echo.Context, err error) error {
+	if errors.As(err, &budget.InvalidExpenseTypeError{}) || errors.As(err, &budget.InvalidDomainModelError{}) {
+		return h.buildErrorResponse(ctx, http.StatusBadRequest, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &budget.BudgetNotFoundError{}) {
+		return h.buildErrorResponse(ctx, http.StatusNotFound, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &budget.BudgetAlreadyExistsError{}) {
+		return h.buildErrorResponse(ctx, http.StatusConflict, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if statusCode, msg, ok := rest.EndedRequestError(ctx); ok {
+		return h.buildErrorResponse(ctx, statusCode, msg, err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else {
+		return h.buildErrorResponse(ctx, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+}
+
+func (h handler) buildErrorResponse(ctx echo.Context, statusCode int, errorMessage string, errorDetail string, fieldErrors []fieldvalidation.FieldError, errorCode uint) error {
+	errorResponse := rest.ErrorResponse{StatusCode: statusCode, Msg: errorMessage, ErrorDetail: errorDetail, FieldErrors: fieldErrors, ErrorCode: errorCode}
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+func (h handler) mapBudgetToBudgetBody(budget *models.Budget) Body {
+	return Body{
+		ID: budget.Id().String(),
+		ExpenseType: TypeBody{
+			ID:   budget.ExpenseType().Id().String(),
+			Name: budget.ExpenseType().Name(),
+		},
+		Period:   string(budget.Period()),
+		Limit:    mapMoney(budget.Limit()),
+		Rollover: budget.Rollover(),
+	}
+}
+
+func (h handler) mapBudgetStatusToStatusBody(status *models.BudgetStatus) StatusBody {
+	return StatusBody{
+		Budget:      h.mapBudgetToBudgetBody(status.Budget()),
+		Spent:       mapMoney(status.Spent()),
+		RolledOver:  mapMoney(status.RolledOver()),
+		Available:   mapMoney(status.Available()),
+		Remaining:   mapMoney(status.Remaining()),
+		PercentUsed: status.PercentUsed(),
+		Overspent:   status.IsOverspent(),
+	}
+}
+
+func mapMoney(money *models.Money) Money {
+	return Money{Amount: json.Number(money.Amount()), Currency: money.Currency()}
+}
+
+type BudgetRequest struct {
+	ExpenseType *BudgetRequestExpenseTypeBody `json:"expense_type,omitempty" validate:"required"`
+	Period      string                        `json:"period,omitempty" validate:"required,oneof=monthly"`
+	Limit       Money                         `json:"limit"`
+	Rollover    bool                          `json:"rollover,omitempty"`
+}
+
+type BudgetRequestExpenseTypeBody struct {
+	ID string `json:"id" validate:"required,uuid"`
+}
+
+type GetStatusQueryParams struct {
+	Month string `query:"month" validate:"required,datetime=2006-01"`
+}
+
+type Response struct {
+	Budget Body `json:"budget"`
+}
+
+type GetAllResponse struct {
+	Budgets []Body `json:"budgets"`
+}
+
+type StatusResponse struct {
+	Month   string       `json:"month"`
+	Budgets []StatusBody `json:"budgets"`
+}
+
+type Body struct {
+	ID          string   `json:"id"`
+	ExpenseType TypeBody `json:"expense_type"`
+	Period      string   `json:"period"`
+	Limit       Money    `json:"limit"`
+	Rollover    bool     `json:"rollover"`
+}
+
+type StatusBody struct {
+	Budget      Body    `json:"budget"`
+	Spent       Money   `json:"spent"`
+	RolledOver  Money   `json:"rolled_over"`
+	Available   Money   `json:"available"`
+	Remaining   Money   `json:"remaining"`
+	PercentUsed float64 `json:"percent_used"`
+	Overspent   bool    `json:"overspent"`
+}
+
+type TypeBody struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Money struct {
+	Amount   json.Number `json:"amount" validate:"required,positiveDecimal"`
+	Currency string      `json:"currency" validate:"iso4217"`
+}
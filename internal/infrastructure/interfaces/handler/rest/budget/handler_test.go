@@ -0,0 +1,171 @@
+package budget_test
+
+import (
+	"encoding/json"
+	"finfit-backend/internal/domain/models"
+	budgetService "finfit-backend/internal/domain/services/budget"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/budget"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	errorResponse = `{"status_code":%d,"msg":"%s","error_detail":"%v","field_errors":%v,"error_code":%d}
+`
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+	userId            uuid.UUID
+	budgetServiceMock *budgetService.ServiceMock
+}
+
+func (suite *HandlerTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.budgetServiceMock = budgetService.NewServiceMock()
+}
+
+func (suite *HandlerTestSuite) TearDownTest() {
+	suite.budgetServiceMock.ExpectedCalls = nil
+	suite.budgetServiceMock.Calls = nil
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (suite *HandlerTestSuite) TestGivenABudgetToAdd_WhenAdd_ThenReturnStatusCreatedWithCreatedBudget() {
+	expectedBudget := suite.getBudget()
+	command, _ := budgetService.NewAddCommand(expectedBudget.ExpenseType().Id(), "monthly", "400", "EUR", true)
+	suite.budgetServiceMock.MockAdd([]interface{}{suite.userId, command}, []interface{}{expectedBudget, nil}, 1)
+
+	requestBody := fmt.Sprintf(`{"expense_type":{"id":"%s"},"period":"monthly","limit":{"amount":400,"currency":"EUR"},"rollover":true}`, expectedBudget.ExpenseType().Id().String())
+	c, rec := suite.mockRequest(http.MethodPost, "/budgets", requestBody)
+	handler := budget.NewHandler(suite.budgetServiceMock, suite.getValidator())
+
+	bodyBytes, _ := json.Marshal(budget.Response{Budget: suite.getBudgetBody(expectedBudget)})
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+		assert.Equal(suite.T(), string(bodyBytes)+"\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnExistingBudget_WhenAdd_ThenReturnStatusConflict() {
+	expenseTypeId := uuid.New()
+	command, _ := budgetService.NewAddCommand(expenseTypeId, "monthly", "400", "EUR", false)
+	serviceErr := budgetService.BudgetAlreadyExistsError{Msg: "a budget for the same expense type and period already exists"}
+	suite.budgetServiceMock.MockAdd([]interface{}{suite.userId, command}, []interface{}{nil, serviceErr}, 1)
+
+	requestBody := fmt.Sprintf(`{"expense_type":{"id":"%s"},"period":"monthly","limit":{"amount":400,"currency":"EUR"}}`, expenseTypeId.String())
+	c, rec := suite.mockRequest(http.MethodPost, "/budgets", requestBody)
+	handler := budget.NewHandler(suite.budgetServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusConflict, serviceErr.Error(), serviceErr.Error(), "[]", 0)
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusConflict, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAMonth_WhenGetStatus_ThenReturnStatusOkWithBudgetsStatus() {
+	storedBudget := suite.getBudget()
+	spent, _ := models.NewMoney("300", "EUR")
+	rolledOver, _ := models.NewMoney("0", "EUR")
+	status, _ := models.NewBudgetStatus(storedBudget, spent, rolledOver)
+	command, _ := budgetService.NewGetStatusCommand(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	suite.budgetServiceMock.MockGetStatus([]interface{}{suite.userId, command}, []interface{}{[]*models.BudgetStatus{status}, nil}, 1)
+
+	c, rec := suite.mockRequest(http.MethodGet, "/budgets/status?month=2022-03", "")
+	handler := budget.NewHandler(suite.budgetServiceMock, suite.getValidator())
+
+	bodyBytes, _ := json.Marshal(budget.StatusResponse{Month: "2022-03", Budgets: []budget.StatusBody{{
+		Budget:      suite.getBudgetBody(storedBudget),
+		Spent:       budget.Money{Amount: "300.00", Currency: "EUR"},
+		RolledOver:  budget.Money{Amount: "0.00", Currency: "EUR"},
+		Available:   budget.Money{Amount: "400.00", Currency: "EUR"},
+		Remaining:   budget.Money{Amount: "100.00", Currency: "EUR"},
+		PercentUsed: 75,
+		Overspent:   false,
+	}}})
+	if assert.NoError(suite.T(), handler.GetStatus(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), string(bodyBytes)+"\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnInvalidMonth_WhenGetStatus_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequest(http.MethodGet, "/budgets/status?month=2022-03-01", "")
+	handler := budget.NewHandler(suite.budgetServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, budget.FieldValidationErrorMessage, budget.FieldValidationErrorMessage, "[{\"field\":\"Month\",\"message\":\"Month does not match the 2006-01 format\"}]", rest.FieldValidationErrorCode)
+	if assert.NoError(suite.T(), handler.GetStatus(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+	suite.budgetServiceMock.AssertNotCalled(suite.T(), "GetStatus", mock.Anything, suite.userId, mock.Anything)
+}
+
+func (suite *HandlerTestSuite) TestGivenANonExistentBudget_WhenDelete_ThenReturnStatusNotFound() {
+	id := uuid.New()
+	serviceErr := budgetService.BudgetNotFoundError{Msg: "the budget doesn't exists"}
+	suite.budgetServiceMock.MockDelete([]interface{}{suite.userId, id}, []interface{}{serviceErr}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodDelete, id.String())
+	handler := budget.NewHandler(suite.budgetServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusNotFound, serviceErr.Error(), serviceErr.Error(), "[]", 0)
+	if assert.NoError(suite.T(), handler.Delete(c)) {
+		assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) getBudget() *models.Budget {
+	expenseType, _ := models.NewExpenseTypeWithId(uuid.New(), "Groceries")
+	limit, _ := models.NewMoney("400", "EUR")
+	storedBudget, _ := models.NewBudgetWithId(uuid.New(), expenseType, models.MonthlyBudgetPeriod, limit, true)
+	return storedBudget
+}
+
+func (suite *HandlerTestSuite) getBudgetBody(storedBudget *models.Budget) budget.Body {
+	return budget.Body{
+		ID:          storedBudget.Id().String(),
+		ExpenseType: budget.TypeBody{ID: storedBudget.ExpenseType().Id().String(), Name: storedBudget.ExpenseType().Name()},
+		Period:      string(storedBudget.Period()),
+		Limit:       budget.Money{Amount: json.Number(storedBudget.Limit().Amount()), Currency: storedBudget.Limit().Currency()},
+		Rollover:    storedBudget.Rollover(),
+	}
+}
+
+func (suite *HandlerTestSuite) getValidator() fieldvalidation.FieldsValidator {
+	validator, _ := fieldvalidation.RegisterFieldsValidator(nil, nil)
+	return validator
+}
+
+func (suite *HandlerTestSuite) mockRequest(method string, path string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	return c, rec
+}
+
+func (suite *HandlerTestSuite) mockRequestWithId(method string, id string) (echo.Context, *httptest.ResponseRecorder) {
+	c, rec := suite.mockRequest(method, "/budgets/:id", "")
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	return c, rec
+}
@@ -1,11 +1,24 @@
 package rest
 
-import "finfit-backend/pkg/fieldvalidation"
+import (
+	stdcontext "context"
+	"errors"
+	"finfit-backend/pkg/fieldvalidation"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
 
 const (
 	FieldValidationErrorCode = 1
 )
 
+const (
+	// StatusClientClosedRequest is the status nginx logs for the requests whose client went away before the response.
+	StatusClientClosedRequest = 499
+	RequestTimeoutMessage     = "the request took too long"
+	RequestCanceledMessage    = "the request was canceled"
+)
+
 type ErrorResponse struct {
 	StatusCode  int                          `json:"status_code"`
 	Msg         string                       `json:"msg"`
@@ -13,3 +26,15 @@ type ErrorResponse struct {
 	FieldErrors []fieldvalidation.FieldError `json:"field_errors"`
 	ErrorCode   uint                         `json:"error_code"`
 }
+
+// EndedRequestError returns the status and message of a request that failed because its context ended: 503 once its
+// deadline passed and 499 when its client canceled it. ok is false while the context of the request is still alive.
+func EndedRequestError(context echo.Context) (statusCode int, msg string, ok bool) {
+	err := context.Request().Context().Err()
+	if errors.Is(err, stdcontext.DeadlineExceeded) {
+		return http.StatusServiceUnavailable, RequestTimeoutMessage, true
+	} else if errors.Is(err, stdcontext.Canceled) {
+		return StatusClientClosedRequest, RequestCanceledMessage, true
+	}
+	return 0, "", false
+}
@@ -0,0 +1,172 @@
+package exchangerate
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"finfit-backend/internal/domain/services/exchangerate"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	FieldValidationErrorMessage = "some fields are invalid"
+	BodyIsInvalidErrorMessage   = "body is invalid"
+	UnexpectedErrorMessage      = "unexpected error"
+	DateFormat                  = "2006-01-02"
+	MIMETextCSV                 = "text/csv"
+)
+
+// csvColumns are the columns an imported CSV file must have in its header row, in any order.
+var csvColumns = []string{"date", "base_currency", "quote_currency", "rate"}
+
+type Handler interface {
+	Import(context echo.Context) error
+}
+
+type handler struct {
+	service         exchangerate.Service
+	fieldsValidator fieldvalidation.FieldsValidator
+}
+
+func NewHandler(service exchangerate.Service, fieldsValidator fieldvalidation.FieldsValidator) *handler {
+	return &handler{service: service, fieldsValidator: fieldsValidator}
+}
+
+// Import stores historical daily rates sent either as a CSV file (Content-Type text/csv) or as a JSON body.
+func (h handler) Import(context echo.Context) error {
+	requestBody, err := h.readImportRequest(context)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.validateRates(requestBody.Rates); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := exchangerate.NewImportCommand(requestBody.mapToRatesToImport())
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	imported, err := h.service.Import(context.Request().Context(), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusOK, ImportResponse{Imported: imported})
+}
+
+func (h handler) readImportRequest(context echo.Context) (*ImportRequest, error) {
+	requestBody := new(ImportRequest)
+	if !strings.HasPrefix(context.Request().Header.Get(echo.HeaderContentType), MIMETextCSV) {
+		if err := context.Bind(requestBody); err != nil {
+			return nil, err
+		}
+		return requestBody, nil
+	}
+
+	rates, err := readCSVRates(context.Request().Body)
+	if err != nil {
+		return nil, err
+	}
+
+	requestBody.Rates = rates
+	return requestBody, nil
+}
+
+func readCSVRates(reader io.Reader) ([]RateBody, error) {
+	records, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("the CSV file is empty")
+	}
+
+	columnIndexes := map[string]int{}
+	for i, column := range records[0] {
+		columnIndexes[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, column := range csvColumns {
+		if _, ok := columnIndexes[column]; !ok {
+			return nil, fmt.Errorf("the CSV file must have a %s column", column)
+		}
+	}
+
+	rates := []RateBody{}
+	for _, record := range records[1:] {
+		rates = append(rates, RateBody{
+			Date:          strings.TrimSpace(record[columnIndexes["date"]]),
+			BaseCurrency:  strings.TrimSpace(record[columnIndexes["base_currency"]]),
+			QuoteCurrency: strings.TrimSpace(record[columnIndexes["quote_currency"]]),
+			Rate:          json.Number(strings.TrimSpace(record[columnIndexes["rate"]])),
+		})
+	}
+
+	return rates, nil
+}
+
+// validateRates validates every rate on its own so the field errors tell which one is invalid.
+func (h handler) validateRates(rates []RateBody) []fieldvalidation.FieldError {
+	var fieldValidationErrors []fieldvalidation.FieldError
+	for i, rate := range rates {
+		for _, fieldError := range h.fieldsValidator.ValidateFields(rate) {
+			fieldError.Field = fmt.Sprintf("Rates[%d].%s", i, fieldError.Field)
+			fieldValidationErrors = append(fieldValidationErrors, fieldError)
+		}
+	}
+	return fieldValidationErrors
+}
+
+func (h handler) manageServiceError(ctx echo.Context, err error) error {
+	if errors.As(err, &exchangerate.InvalidDomainModelError{}) {
+		return h.buildErrorResponse(ctx, http.StatusBadRequest, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if statusCode, msg, ok := rest.EndedRequestError(ctx); ok {
+		return h.buildErrorResponse(ctx, statusCode, msg, err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else {
+		return h.buildErrorResponse(ctx, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+}
+
+func (h handler) buildErrorResponse(ctx echo.Context, statusCode int, errorMessage string, errorDetail string, fieldErrors []fieldvalidation.FieldError, errorCode uint) error {
+	errorResponse := rest.ErrorResponse{StatusCode: statusCode, Msg: errorMessage, ErrorDetail: errorDetail, FieldErrors: fieldErrors, ErrorCode: errorCode}
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+type ImportRequest struct {
+	Rates []RateBody `json:"rates"`
+}
+
+func (r ImportRequest) mapToRatesToImport() []exchangerate.RateToImport {
+	rates := []exchangerate.RateToImport{}
+	for _, rate := range r.Rates {
+		date, _ := time.Parse(DateFormat, rate.Date)
+		rates = append(rates, exchangerate.RateToImport{
+			Date:          date,
+			BaseCurrency:  rate.BaseCurrency,
+			QuoteCurrency: rate.QuoteCurrency,
+			Rate:          rate.Rate.String(),
+		})
+	}
+	return rates
+}
+
+type RateBody struct {
+	Date          string      `json:"date" validate:"required,datetime=2006-01-02"`
+	BaseCurrency  string      `json:"base_currency" validate:"required,iso4217"`
+	QuoteCurrency string      `json:"quote_currency" validate:"required,iso4217,nefield=BaseCurrency"`
+	Rate          json.Number `json:"rate" validate:"required,positiveDecimal"`
+}
+
+type ImportResponse struct {
+	Imported int `json:"imported"`
+}
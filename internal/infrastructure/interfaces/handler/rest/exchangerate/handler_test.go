@@ -0,0 +1,111 @@
+package exchangerate_test
+
+import (
+	exchangeRateService "finfit-backend/internal/domain/services/exchangerate"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/exchangerate"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	errorResponse = `{"status_code":%d,"msg":"%s","error_detail":"%v","field_errors":%v,"error_code":%d}
+`
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+	exchangeRateServiceMock *exchangeRateService.ServiceMock
+}
+
+func (suite *HandlerTestSuite) SetupSuite() {
+	suite.exchangeRateServiceMock = exchangeRateService.NewServiceMock()
+}
+
+func (suite *HandlerTestSuite) TearDownTest() {
+	suite.exchangeRateServiceMock.ExpectedCalls = nil
+	suite.exchangeRateServiceMock.Calls = nil
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (suite *HandlerTestSuite) TestGivenACSVFile_WhenImport_ThenReturnStatusOkWithTheImportedCount() {
+	command, _ := exchangeRateService.NewImportCommand([]exchangeRateService.RateToImport{
+		{Date: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), BaseCurrency: "USD", QuoteCurrency: "ARS", Rate: "108.5"},
+		{Date: time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC), BaseCurrency: "USD", QuoteCurrency: "ARS", Rate: "108.75"},
+	})
+	suite.exchangeRateServiceMock.MockImport([]interface{}{command}, []interface{}{2, nil}, 1)
+
+	requestBody := "rate,date,base_currency,quote_currency\n108.5,2022-03-01,USD,ARS\n108.75,2022-03-02,USD,ARS\n"
+	c, rec := suite.mockRequest(exchangerate.MIMETextCSV, requestBody)
+	handler := exchangerate.NewHandler(suite.exchangeRateServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Import(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), "{\"imported\":2}\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAJSONBody_WhenImport_ThenReturnStatusOkWithTheImportedCount() {
+	command, _ := exchangeRateService.NewImportCommand([]exchangeRateService.RateToImport{
+		{Date: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: "1.1032"},
+	})
+	suite.exchangeRateServiceMock.MockImport([]interface{}{command}, []interface{}{1, nil}, 1)
+
+	requestBody := `{"rates":[{"date":"2022-03-01","base_currency":"EUR","quote_currency":"USD","rate":1.1032}]}`
+	c, rec := suite.mockRequest(echo.MIMEApplicationJSON, requestBody)
+	handler := exchangerate.NewHandler(suite.exchangeRateServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Import(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), "{\"imported\":1}\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnInvalidRow_WhenImport_ThenReturnStatusBadRequestSayingWhichOne() {
+	requestBody := "date,base_currency,quote_currency,rate\n2022-03-01,USD,ARS,108.5\n2022-03-02,USD,USD,1\n"
+	c, rec := suite.mockRequest(exchangerate.MIMETextCSV, requestBody)
+	handler := exchangerate.NewHandler(suite.exchangeRateServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, exchangerate.FieldValidationErrorMessage, exchangerate.FieldValidationErrorMessage, "[{\"field\":\"Rates[1].QuoteCurrency\",\"message\":\"QuoteCurrency cannot be equal to BaseCurrency\"}]", rest.FieldValidationErrorCode)
+	if assert.NoError(suite.T(), handler.Import(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+	suite.exchangeRateServiceMock.AssertNotCalled(suite.T(), "Import", mock.Anything)
+}
+
+func (suite *HandlerTestSuite) TestGivenACSVFileWithoutRateColumn_WhenImport_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequest(exchangerate.MIMETextCSV, "date,base_currency,quote_currency\n2022-03-01,USD,ARS\n")
+	handler := exchangerate.NewHandler(suite.exchangeRateServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, exchangerate.BodyIsInvalidErrorMessage, "the CSV file must have a rate column", "[]", 0)
+	if assert.NoError(suite.T(), handler.Import(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) getValidator() fieldvalidation.FieldsValidator {
+	validator, _ := fieldvalidation.RegisterFieldsValidator(nil, nil)
+	return validator
+}
+
+func (suite *HandlerTestSuite) mockRequest(contentType string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/exchange-rates/import", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, contentType)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
@@ -1,14 +1,17 @@
 package expense
 
 import (
+	"encoding/json"
 	"errors"
 	"finfit-backend/internal/domain/models"
 	"finfit-backend/internal/domain/services/expense"
+	"finfit-backend/internal/domain/services/ledger"
 	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
 	"finfit-backend/pkg/fieldvalidation"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -16,6 +19,8 @@ const (
 	FieldValidationErrorMessage  = "some fields are invalid"
 	BodyIsInvalidErrorMessage    = "body is invalid"
 	ParamsAreInvalidErrorMessage = "params are invalid, query params start_date and end_date are required"
+	InvalidIdErrorMessage        = "id path param is invalid, it must be a valid UUID"
+	ExpenseNotFoundErrorMessage  = "the expense doesn't exists"
 	UnexpectedErrorMessage       = "unexpected error"
 	DateFormat                   = "2006-01-02"
 )
@@ -23,6 +28,11 @@ const (
 type Handler interface {
 	Add(context echo.Context) error
 	SearchInPeriod(ctx echo.Context) error
+	SearchText(ctx echo.Context) error
+	GetById(context echo.Context) error
+	Update(context echo.Context) error
+	Patch(context echo.Context) error
+	Delete(context echo.Context) error
 }
 
 type handler struct {
@@ -53,7 +63,7 @@ func (h handler) Add(context echo.Context) error {
 		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
 	}
 
-	createdExpense, err := h.service.Add(command)
+	createdExpense, err := h.service.Add(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), command)
 	if err != nil {
 		return h.manageServiceError(context, err)
 	}
@@ -61,7 +71,8 @@ func (h handler) Add(context echo.Context) error {
 	return context.JSON(http.StatusCreated, h.mapCreatedExpenseToExpenseResponse(createdExpense))
 }
 
-// TODO: Analizar limitar el largo del periodo y limite de expenses a buscar
+// SearchInPeriod returns a page of the expenses of the period, of DefaultPageSize expenses unless the limit param asks
+// for another size. The next_cursor of the response is passed as the cursor param to get the next page.
 func (h handler) SearchInPeriod(context echo.Context) error {
 	requestParams := new(SearchInPeriodQueryParams)
 
@@ -78,13 +89,107 @@ func (h handler) SearchInPeriod(context echo.Context) error {
 		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
 	}
 
-	expenses, err := h.service.SearchInPeriod(command)
+	result, err := h.service.SearchInPeriod(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), command)
 	if err != nil {
 		return h.manageServiceError(context, err)
 	}
 
-	return context.JSON(http.StatusOK, h.mapExpensesToSearchResponse(expenses))
+	return context.JSON(http.StatusOK, h.mapSearchResultToSearchResponse(result, command.Limit()))
+}
+
+// SearchText returns the expenses of the ledger whose description or expense type has words that start with the words
+// of the q param, the best matches first.
+func (h handler) SearchText(context echo.Context) error {
+	requestParams := new(TextSearchQueryParams)
+
+	if err := context.Bind(requestParams); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, ParamsAreInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestParams); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := expense.NewTextSearchCommand(requestParams.Q)
+	if err == nil && requestParams.Limit > 0 {
+		command, err = command.WithLimit(requestParams.Limit)
+	}
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	hits, err := h.service.SearchText(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusOK, h.mapTextSearchHitsToTextSearchResponse(hits))
+}
+
+func (h handler) GetById(context echo.Context) error {
+	id, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	storedExpense, err := h.service.GetById(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), id)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	if storedExpense == nil {
+		return h.buildErrorResponse(context, http.StatusNotFound, ExpenseNotFoundErrorMessage, ExpenseNotFoundErrorMessage, []fieldvalidation.FieldError{}, 0)
+	}
+
+	return context.JSON(http.StatusOK, h.mapCreatedExpenseToExpenseResponse(storedExpense))
+}
+
+func (h handler) Update(context echo.Context) error {
+	return h.update(context, new(UpdateExpenseRequest))
+}
+
+func (h handler) Patch(context echo.Context) error {
+	return h.update(context, new(PatchExpenseRequest))
+}
+
+func (h handler) update(context echo.Context, requestBody updateRequest) error {
+	id, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if err = context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := requestBody.mapToUpdateCommand(id)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	updatedExpense, err := h.service.Update(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
 
+	return context.JSON(http.StatusOK, h.mapCreatedExpenseToExpenseResponse(updatedExpense))
+}
+
+func (h handler) Delete(context echo.Context) error {
+	id, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if err = h.service.Delete(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), id); err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.NoContent(http.StatusNoContent)
 }
 
 func (h handler) mapAddCommandFromRequestBody(body AddExpenseRequest) (*expense.AddCommand, error) {
@@ -94,14 +199,89 @@ func (h handler) mapAddCommandFromRequestBody(body AddExpenseRequest) (*expense.
 		return nil, err
 	}
 
-	return expense.NewAddCommand(body.Amount.Amount, body.Amount.Currency, date, body.Description, expenseTypeId)
+	accountId, err := body.Account.parseId()
+	if err != nil {
+		return nil, err
+	}
+
+	command, err := expense.NewAddCommand(body.Amount.Amount.String(), body.Amount.Currency, date, body.Description, expenseTypeId, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	command = command.WithTags(body.Tags)
+	if body.Split == nil {
+		return command, nil
+	}
+
+	split, err := body.Split.mapToSplitCommand()
+	if err != nil {
+		return nil, err
+	}
+
+	return command.WithSplit(split), nil
 }
 
 func (h handler) mapSearchCommandFromRequestBody(params SearchInPeriodQueryParams) (*expense.SearchInPeriodCommand, error) {
 	startDate, _ := time.Parse(DateFormat, params.StartDate)
 	endDate, _ := time.Parse(DateFormat, params.EndDate)
 
-	return expense.NewSearchInPeriodCommand(startDate, endDate)
+	command, err := expense.NewSearchInPeriodCommand(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	expenseTypeIds := []uuid.UUID{}
+	for _, id := range params.ExpenseTypeIDs {
+		expenseTypeId, err := uuid.Parse(id)
+		if err != nil {
+			return nil, err
+		}
+		expenseTypeIds = append(expenseTypeIds, expenseTypeId)
+	}
+
+	if len(expenseTypeIds) > 0 {
+		command = command.WithExpenseTypes(expenseTypeIds)
+	}
+
+	if params.Tags != "" {
+		tagFilter, err := models.NewTagFilter(strings.Split(params.Tags, ","), params.TagMatch)
+		if err != nil {
+			return nil, err
+		}
+		command = command.WithTagFilter(tagFilter)
+	}
+
+	if params.AccountID != "" {
+		accountId, err := uuid.Parse(params.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		command = command.WithAccount(accountId)
+	}
+
+	if command, err = command.WithAmountRange(params.MinAmount, params.MaxAmount); err != nil {
+		return nil, err
+	}
+
+	if command, err = command.WithCurrency(params.Currency); err != nil {
+		return nil, err
+	}
+
+	if command, err = command.WithSort(params.Sort); err != nil {
+		return nil, err
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = expense.DefaultPageSize
+	}
+
+	if command, err = command.WithPage(limit, params.Cursor); err != nil {
+		return nil, err
+	}
+
+	return command.WithDescription(params.Description).WithTargetCurrency(params.TargetCurrency)
 }
 
 func (h handler) mapCreatedExpenseToExpenseResponse(expense *models.Expense) Response {
@@ -109,8 +289,16 @@ func (h handler) mapCreatedExpenseToExpenseResponse(expense *models.Expense) Res
 }
 
 func (h handler) manageServiceError(ctx echo.Context, err error) error {
-	if errors.As(err, &expense.InvalidExpenseTypeError{}) {
+	if errors.As(err, &expense.InvalidExpenseTypeError{}) || errors.As(err, &expense.InvalidAccountError{}) || errors.As(err, &expense.InvalidSplitError{}) || errors.As(err, &expense.InvalidDomainModelError{}) || errors.As(err, &expense.InvalidCursorError{}) || errors.As(err, &expense.InvalidSearchSortError{}) {
 		return h.buildErrorResponse(ctx, http.StatusBadRequest, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &expense.ExpenseNotFoundError{}) {
+		return h.buildErrorResponse(ctx, http.StatusNotFound, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.LedgerNotFoundError{}) {
+		return h.buildErrorResponse(ctx, http.StatusNotFound, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.ForbiddenError{}) {
+		return h.buildErrorResponse(ctx, http.StatusForbidden, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if statusCode, msg, ok := rest.EndedRequestError(ctx); ok {
+		return h.buildErrorResponse(ctx, statusCode, msg, err.Error(), []fieldvalidation.FieldError{}, 0)
 	} else {
 		return h.buildErrorResponse(ctx, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
 	}
@@ -121,20 +309,37 @@ func (h handler) buildErrorResponse(ctx echo.Context, statusCode int, errorMessa
 	return ctx.JSON(statusCode, errorResponse)
 }
 
-func (h handler) mapExpensesToSearchResponse(expenses []*models.Expense) SearchResponse {
+func (h handler) mapSearchResultToSearchResponse(result *expense.SearchResult, limit int) SearchResponse {
 	expenseBodies := []Body{}
-	for _, expense := range expenses {
+	for _, expense := range result.Expenses {
 		expenseBodies = append(expenseBodies, h.mapExpenseToExpenseBody(expense))
 	}
 
-	return SearchResponse{Expenses: expenseBodies}
+	response := SearchResponse{Expenses: expenseBodies, Limit: limit}
+	if result.NextCursor != nil {
+		response.NextCursor = result.NextCursor.String()
+	}
+	return response
+}
+
+func (h handler) mapTextSearchHitsToTextSearchResponse(hits []*expense.TextSearchHit) TextSearchResponse {
+	resultBodies := []TextSearchResultBody{}
+	for _, hit := range hits {
+		resultBodies = append(resultBodies, TextSearchResultBody{Expense: h.mapExpenseToExpenseBody(hit.Expense), Rank: hit.Rank, Snippet: hit.Snippet})
+	}
+	return TextSearchResponse{Results: resultBodies}
 }
 
 func (h handler) mapExpenseToExpenseBody(expense *models.Expense) Body {
+	var accountBody *AccountBody
+	if expense.Account() != nil {
+		accountBody = &AccountBody{ID: expense.Account().Id().String(), Name: expense.Account().Name()}
+	}
+
 	return Body{
 		ID: expense.Id().String(),
 		Amount: Money{
-			Amount:   expense.Amount().Amount(),
+			Amount:   json.Number(expense.Amount().Amount()),
 			Currency: expense.Amount().Currency(),
 		},
 		ExpenseDate: expense.ExpenseDate().Format(DateFormat),
@@ -142,8 +347,54 @@ func (h handler) mapExpenseToExpenseBody(expense *models.Expense) Body {
 		ExpenseType: TypeBody{
 			ID:   expense.ExpenseType().Id().String(),
 			Name: expense.ExpenseType().Name(),
+			Path: expense.ExpenseType().FullPath(),
 		},
+		Account:         accountBody,
+		ConvertedAmount: mapConversionToConversionBody(expense.Conversion()),
+		FitId:           expense.FitId(),
+		Split:           mapSplitToSplitBody(expense.Split()),
+		Tags:            expense.Tags(),
+	}
+}
+
+func mapSplitToSplitBody(split *models.ExpenseSplit) *SplitBody {
+	if split == nil {
+		return nil
 	}
+
+	participants := []SplitParticipantBody{}
+	allocations := split.Allocations()
+	for i, participant := range split.Participants() {
+		participants = append(participants, SplitParticipantBody{
+			UserID: participant.UserId().String(),
+			Value:  json.Number(participant.Value()),
+			Amount: json.Number(allocations[i].Amount().Amount()),
+		})
+	}
+
+	return &SplitBody{PaidBy: split.PaidBy().String(), Method: string(split.Method()), Participants: participants}
+}
+
+func mapConversionToConversionBody(conversion *models.Conversion) *ConversionBody {
+	if conversion == nil {
+		return nil
+	}
+
+	conversionBody := &ConversionBody{Currency: conversion.TargetCurrency(), MissingRate: conversion.IsMissingRate()}
+	if conversion.Amount() != nil {
+		conversionBody.Amount = json.Number(conversion.Amount().Amount())
+	}
+
+	if rate := conversion.Rate(); rate != nil {
+		conversionBody.ExchangeRate = &ExchangeRateBody{
+			BaseCurrency:  rate.BaseCurrency(),
+			QuoteCurrency: rate.QuoteCurrency(),
+			Date:          rate.Date().Format(DateFormat),
+			Rate:          json.Number(rate.Rate()),
+		}
+	}
+
+	return conversionBody
 }
 
 type AddExpenseRequest struct {
@@ -151,39 +402,277 @@ type AddExpenseRequest struct {
 	ExpenseDate string                            `json:"expense_date,omitempty" validate:"required,datetime=2006-01-02"`
 	Description string                            `json:"description,omitempty"`
 	ExpenseType *AddExpenseRequestExpenseTypeBody `json:"expense_type,omitempty" validate:"required"`
+	Account     *AddExpenseRequestAccountBody     `json:"account,omitempty"`
+	Split       *SplitRequestBody                 `json:"split,omitempty"`
+	Tags        []string                          `json:"tags,omitempty" validate:"omitempty,dive,required,max=32"`
 }
 
 type AddExpenseRequestExpenseTypeBody struct {
 	ID string `json:"id" validate:"required,uuid"`
 }
 
+type AddExpenseRequestAccountBody struct {
+	ID string `json:"id" validate:"required,uuid"`
+}
+
+// parseId returns uuid.Nil when the request doesn't reference any account.
+func (b *AddExpenseRequestAccountBody) parseId() (uuid.UUID, error) {
+	if b == nil {
+		return uuid.Nil, nil
+	}
+
+	return uuid.Parse(b.ID)
+}
+
+// SplitRequestBody shares the expense among members of the ledger. Value is the percentage, the number of shares or
+// the exact amount of every participant, and it is left out with the equal method.
+type SplitRequestBody struct {
+	PaidBy       string                    `json:"paid_by" validate:"required,uuid"`
+	Method       string                    `json:"method" validate:"required,oneof=equal percentage shares exact"`
+	Participants []SplitParticipantRequest `json:"participants" validate:"required,min=1,dive"`
+}
+
+type SplitParticipantRequest struct {
+	UserID string      `json:"user_id" validate:"required,uuid"`
+	Value  json.Number `json:"value,omitempty"`
+}
+
+func (b SplitRequestBody) mapToSplitCommand() (*expense.SplitCommand, error) {
+	paidBy, err := uuid.Parse(b.PaidBy)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := []expense.SplitShare{}
+	for _, participant := range b.Participants {
+		userId, err := uuid.Parse(participant.UserID)
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, expense.SplitShare{UserId: userId, Value: participant.Value.String()})
+	}
+
+	return expense.NewSplitCommand(paidBy, b.Method, shares)
+}
+
+type updateRequest interface {
+	mapToUpdateCommand(id uuid.UUID) (*expense.UpdateCommand, error)
+}
+
+type UpdateExpenseRequest struct {
+	Amount      Money                             `json:"amount,omitempty"`
+	ExpenseDate string                            `json:"expense_date,omitempty" validate:"required,datetime=2006-01-02"`
+	Description string                            `json:"description,omitempty"`
+	ExpenseType *AddExpenseRequestExpenseTypeBody `json:"expense_type,omitempty" validate:"required"`
+	Account     *AddExpenseRequestAccountBody     `json:"account,omitempty"`
+	Split       *SplitRequestBody                 `json:"split,omitempty"`
+	Tags        []string                          `json:"tags,omitempty" validate:"omitempty,dive,required,max=32"`
+}
+
+func (r UpdateExpenseRequest) mapToUpdateCommand(id uuid.UUID) (*expense.UpdateCommand, error) {
+	date, _ := time.Parse(DateFormat, r.ExpenseDate)
+	expenseTypeId, err := uuid.Parse(r.ExpenseType.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	accountId, err := r.Account.parseId()
+	if err != nil {
+		return nil, err
+	}
+
+	command, err := expense.NewUpdateCommand(id, r.Amount.Amount.String(), r.Amount.Currency, date, &r.Description, expenseTypeId, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	return withSplit(withTags(command, r.Tags), r.Split)
+}
+
+type PatchExpenseRequest struct {
+	Amount      *PatchMoney                       `json:"amount,omitempty"`
+	ExpenseDate string                            `json:"expense_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Description *string                           `json:"description,omitempty"`
+	ExpenseType *AddExpenseRequestExpenseTypeBody `json:"expense_type,omitempty"`
+	Account     *AddExpenseRequestAccountBody     `json:"account,omitempty"`
+	Split       *SplitRequestBody                 `json:"split,omitempty"`
+	Tags        []string                          `json:"tags,omitempty" validate:"omitempty,dive,required,max=32"`
+}
+
+func (r PatchExpenseRequest) mapToUpdateCommand(id uuid.UUID) (*expense.UpdateCommand, error) {
+	var amount, currency string
+	if r.Amount != nil {
+		amount = r.Amount.Amount.String()
+		currency = r.Amount.Currency
+	}
+
+	date, _ := time.Parse(DateFormat, r.ExpenseDate)
+
+	expenseTypeId := uuid.Nil
+	if r.ExpenseType != nil {
+		parsedId, err := uuid.Parse(r.ExpenseType.ID)
+		if err != nil {
+			return nil, err
+		}
+		expenseTypeId = parsedId
+	}
+
+	accountId, err := r.Account.parseId()
+	if err != nil {
+		return nil, err
+	}
+
+	command, err := expense.NewUpdateCommand(id, amount, currency, date, r.Description, expenseTypeId, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	return withSplit(withTags(command, r.Tags), r.Split)
+}
+
+// withTags replaces the tags of the expense when the request has them, otherwise the stored ones are kept.
+func withTags(command *expense.UpdateCommand, tags []string) *expense.UpdateCommand {
+	if tags == nil {
+		return command
+	}
+
+	return command.WithTags(tags)
+}
+
+// withSplit splits the expense again when the request has a split, otherwise the stored one is kept.
+func withSplit(command *expense.UpdateCommand, splitBody *SplitRequestBody) (*expense.UpdateCommand, error) {
+	if splitBody == nil {
+		return command, nil
+	}
+
+	split, err := splitBody.mapToSplitCommand()
+	if err != nil {
+		return nil, err
+	}
+
+	return command.WithSplit(split), nil
+}
+
+// PatchMoney can change the amount or the currency alone, the other one is kept.
+type PatchMoney struct {
+	Amount   json.Number `json:"amount,omitempty" validate:"omitempty,positiveDecimal"`
+	Currency string      `json:"currency,omitempty" validate:"omitempty,iso4217"`
+}
+
+// PeriodQueryParams are the params shared by the searches of expenses in a period.
+type PeriodQueryParams struct {
+	StartDate      string `query:"start_date" validate:"required,datetime=2006-01-02,lteStrDateField=EndDate0x2C2006-01-02"`
+	EndDate        string `query:"end_date" validate:"required,datetime=2006-01-02"`
+	TargetCurrency string `query:"target_currency" validate:"omitempty,iso4217"`
+}
+
 type SearchInPeriodQueryParams struct {
-	StartDate string `query:"start_date" validate:"required,datetime=2006-01-02,lteStrDateField=EndDate0x2C2006-01-02"`
-	EndDate   string `query:"end_date" validate:"required,datetime=2006-01-02"`
+	PeriodQueryParams
+	// ExpenseTypeIDs can be repeated to match any of the expense types, including every subtype of them.
+	ExpenseTypeIDs []string `query:"expense_type_id" validate:"omitempty,dive,uuid"`
+	// Tags is a comma separated list of tags, and TagMatch tells whether the expenses must have any or all of them.
+	Tags        string `query:"tags"`
+	TagMatch    string `query:"tag_match" validate:"omitempty,oneof=any all"`
+	MinAmount   string `query:"min_amount" validate:"omitempty,numeric"`
+	MaxAmount   string `query:"max_amount" validate:"omitempty,numeric"`
+	Currency    string `query:"currency" validate:"omitempty,iso4217"`
+	Description string `query:"description" validate:"omitempty,max=255"`
+	AccountID   string `query:"account_id" validate:"omitempty,uuid"`
+	// Sort is expense_date or amount, with a leading minus for descending order. It defaults to -expense_date, and
+	// sorting by amount needs the Currency filter.
+	Sort   string `query:"sort" validate:"omitempty,oneof=expense_date -expense_date amount -amount"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1"`
+	Cursor string `query:"cursor"`
+}
+
+// TextSearchQueryParams search the words of Q, which are matched as prefixes of the words of the expenses.
+type TextSearchQueryParams struct {
+	Q     string `query:"q" validate:"required,max=100"`
+	Limit int    `query:"limit" validate:"omitempty,min=1"`
 }
 
 type Response struct {
 	Expense Body `json:"expense"`
 }
 
+type TextSearchResponse struct {
+	Results []TextSearchResultBody `json:"results"`
+}
+
+// TextSearchResultBody has the snippet of the expense with the matched words wrapped in <mark> tags.
+type TextSearchResultBody struct {
+	Expense Body    `json:"expense"`
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+// SearchResponse has a page of expenses and the limit it was read with, which is at most the maximum page size.
+// NextCursor is left out on the last page.
 type SearchResponse struct {
-	Expenses []Body `json:"expenses"`
+	Expenses   []Body `json:"expenses"`
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type Body struct {
-	ID          string   `json:"id"`
-	Amount      Money    `json:"amount"`
-	ExpenseDate string   `json:"expense_date"`
-	Description string   `json:"description"`
-	ExpenseType TypeBody `json:"expense_type"`
+	ID          string       `json:"id"`
+	Amount      Money        `json:"amount"`
+	ExpenseDate string       `json:"expense_date"`
+	Description string       `json:"description"`
+	ExpenseType TypeBody     `json:"expense_type"`
+	Account     *AccountBody `json:"account,omitempty"`
+	// ConvertedAmount is only present when a target currency was requested.
+	ConvertedAmount *ConversionBody `json:"converted_amount,omitempty"`
+	// FitId is the bank transaction id of the expenses imported from an OFX statement.
+	FitId string `json:"fit_id,omitempty"`
+	// Split is only present on the expenses shared among members of the ledger.
+	Split *SplitBody `json:"split,omitempty"`
+	Tags  []string   `json:"tags,omitempty"`
+}
+
+// SplitBody lists the participants in the order the remainder of the split was allocated, with the amount each owes.
+type SplitBody struct {
+	PaidBy       string                 `json:"paid_by"`
+	Method       string                 `json:"method"`
+	Participants []SplitParticipantBody `json:"participants"`
+}
+
+type SplitParticipantBody struct {
+	UserID string      `json:"user_id"`
+	Value  json.Number `json:"value,omitempty"`
+	Amount json.Number `json:"amount"`
+}
+
+// ConversionBody holds the amount in the target currency and the rate used. When no rate was found the amount is
+// left out and MissingRate is true.
+type ConversionBody struct {
+	Amount       json.Number       `json:"amount,omitempty"`
+	Currency     string            `json:"currency"`
+	ExchangeRate *ExchangeRateBody `json:"exchange_rate,omitempty"`
+	MissingRate  bool              `json:"missing_rate"`
+}
+
+type ExchangeRateBody struct {
+	BaseCurrency  string      `json:"base_currency"`
+	QuoteCurrency string      `json:"quote_currency"`
+	Date          string      `json:"date"`
+	Rate          json.Number `json:"rate"`
+}
+
+type AccountBody struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
 type TypeBody struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
+	Path string `json:"path"`
 }
 
+// Money carries the amount as a json.Number so the decimal literal reaches the domain untouched instead of being
+// rounded through a float64.
 type Money struct {
-	Amount   float64 `json:"amount" validate:"required,gt=0"`
-	Currency string  `json:"currency" validate:"iso4217"`
+	Amount   json.Number `json:"amount" validate:"required,positiveDecimal"`
+	Currency string      `json:"currency" validate:"iso4217"`
 }
@@ -1,9 +1,12 @@
 package expense_test
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"finfit-backend/internal/domain/models"
 	expenseService "finfit-backend/internal/domain/services/expense"
+	ledgerService "finfit-backend/internal/domain/services/ledger"
 	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
 	"finfit-backend/internal/infrastructure/interfaces/handler/rest/expense"
 	"finfit-backend/pkg"
@@ -12,6 +15,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"net/http"
 	"net/http/httptest"
@@ -27,10 +31,14 @@ const (
 
 type HandlerTestSuite struct {
 	suite.Suite
+	userId             uuid.UUID
+	ledgerId           uuid.UUID
 	expenseServiceMock *expenseService.ServiceMock
 }
 
 func (suite *HandlerTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.ledgerId = uuid.New()
 	suite.expenseServiceMock = expenseService.NewServiceMock()
 	suite.patchNewUUIDMethod()
 }
@@ -66,8 +74,9 @@ func (suite *HandlerTestSuite) TestGivenAnExpenseToCreate_WhenAdd_ThenReturnStat
 		expectedCreatedExpense.Amount().Currency(),
 		expectedCreatedExpense.ExpenseDate(),
 		expectedCreatedExpense.Description(),
-		expectedCreatedExpense.ExpenseType().Id())
-	suite.expenseServiceMock.MockAdd([]interface{}{addCommand},
+		expectedCreatedExpense.ExpenseType().Id(),
+		uuid.Nil)
+	suite.expenseServiceMock.MockAdd([]interface{}{suite.userId, suite.ledgerId, addCommand},
 		[]interface{}{expectedCreatedExpense, nil}, 1)
 
 	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
@@ -89,8 +98,9 @@ func (suite *HandlerTestSuite) TestGivenAnExpenseToCreateWithoutDescription_When
 		expectedCreatedExpense.Amount().Currency(),
 		expectedCreatedExpense.ExpenseDate(),
 		expectedCreatedExpense.Description(),
-		expectedCreatedExpense.ExpenseType().Id())
-	suite.expenseServiceMock.MockAdd([]interface{}{addCommand},
+		expectedCreatedExpense.ExpenseType().Id(),
+		uuid.Nil)
+	suite.expenseServiceMock.MockAdd([]interface{}{suite.userId, suite.ledgerId, addCommand},
 		[]interface{}{expectedCreatedExpense, nil}, 1)
 
 	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
@@ -110,10 +120,11 @@ func (suite *HandlerTestSuite) TestGivenAnExpenseWithAnInvalidExpenseType_WhenAd
 		expenseToCreate.Amount().Currency(),
 		expenseToCreate.ExpenseDate(),
 		expenseToCreate.Description(),
-		expenseToCreate.ExpenseType().Id())
+		expenseToCreate.ExpenseType().Id(),
+		uuid.Nil)
 
 	serviceErr := expenseService.InvalidExpenseTypeError{Msg: "the expense type doesn't exists"}
-	suite.expenseServiceMock.MockAdd([]interface{}{addCommand},
+	suite.expenseServiceMock.MockAdd([]interface{}{suite.userId, suite.ledgerId, addCommand},
 		[]interface{}{nil, serviceErr}, 1)
 
 	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
@@ -135,9 +146,10 @@ func (suite *HandlerTestSuite) TestGivenAnUnexpectedError_WhenAdd_ThenReturnErro
 		expenseToCreate.Amount().Currency(),
 		expenseToCreate.ExpenseDate(),
 		expenseToCreate.Description(),
-		expenseToCreate.ExpenseType().Id())
+		expenseToCreate.ExpenseType().Id(),
+		uuid.Nil)
 	serviceErr := expenseService.UnexpectedError{Msg: "cagamo fuego"}
-	suite.expenseServiceMock.MockAdd([]interface{}{addCommand},
+	suite.expenseServiceMock.MockAdd([]interface{}{suite.userId, suite.ledgerId, addCommand},
 		[]interface{}{nil, serviceErr}, 1)
 
 	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
@@ -196,7 +208,7 @@ func (suite *HandlerTestSuite) TestGivenAnExpenseWithAmountLowerThanZero_WhenAdd
 
 func (suite *HandlerTestSuite) TestGivenAnExpenseWithoutExpenseDate_WhenAdd_ThenReturnErrorWithBadRequestStatus() {
 	expenseToCreate := suite.getExpenseWithAllFields()
-	requestBody := fmt.Sprintf(`{"amount":{"amount":%f,"currency":"%s"},"description":"%s","expense_type":{"id":"%s"}}`,
+	requestBody := fmt.Sprintf(`{"amount":{"amount":%s,"currency":"%s"},"description":"%s","expense_type":{"id":"%s"}}`,
 		expenseToCreate.Amount().Amount(),
 		expenseToCreate.Amount().Currency(),
 		expenseToCreate.Description(),
@@ -222,7 +234,7 @@ func (suite *HandlerTestSuite) TestGivenAnExpenseWithoutExpenseDate_WhenAdd_Then
 func (suite *HandlerTestSuite) TestGivenAnExpenseWithBadFormattedExpenseDate_WhenAdd_ThenReturnErrorWithBadRequestStatus() {
 	expenseToCreate := suite.getExpenseWithAllFields()
 
-	requestBody := fmt.Sprintf(`{"amount":{"amount":%f,"currency":"%s"},"expense_date":"%s","description":"%s","expense_type":{"id":"%s"}}`,
+	requestBody := fmt.Sprintf(`{"amount":{"amount":%s,"currency":"%s"},"expense_date":"%s","description":"%s","expense_type":{"id":"%s"}}`,
 		expenseToCreate.Amount().Amount(),
 		expenseToCreate.Amount().Currency(),
 		"12-2013-12",
@@ -249,7 +261,7 @@ func (suite *HandlerTestSuite) TestGivenAnExpenseWithBadFormattedExpenseDate_Whe
 func (suite *HandlerTestSuite) TestGivenAnExpenseWithoutExpenseType_WhenAdd_ThenReturnErrorWithBadRequestStatus() {
 	expenseToCreate := suite.getExpenseWithAllFields()
 
-	requestBody := fmt.Sprintf(`{"amount":{"amount":%f,"currency":"%s"},"expense_date":"%s","description":"%s"}`,
+	requestBody := fmt.Sprintf(`{"amount":{"amount":%s,"currency":"%s"},"expense_date":"%s","description":"%s"}`,
 		expenseToCreate.Amount().Amount(),
 		expenseToCreate.Amount().Currency(),
 		"2013-02-01",
@@ -296,12 +308,120 @@ func (suite *HandlerTestSuite) TestGivenAnExpenseWithNoUIIDExpenseTypeID_WhenAdd
 	assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
 }
 
+func (suite *HandlerTestSuite) TestGivenASplitExpense_WhenAdd_ThenReturnStatusCreatedWithTheAllocations() {
+	participantId := uuid.New()
+	expectedCreatedExpense := suite.getSplitExpense(participantId)
+
+	requestBody := fmt.Sprintf(`{"amount":{"amount":100.20,"currency":"ARS"},"expense_date":"2022-03-15","description":"Lomitos","expense_type":{"id":"%s"},"split":{"paid_by":"%s","method":"equal","participants":[{"user_id":"%s"},{"user_id":"%s"}]}}`,
+		expectedCreatedExpense.ExpenseType().Id(), suite.userId, suite.userId, participantId)
+	c, rec := suite.mockAddExpenseRequest(requestBody)
+
+	addCommand, _ := expenseService.NewAddCommand(expectedCreatedExpense.Amount().Amount(),
+		expectedCreatedExpense.Amount().Currency(),
+		expectedCreatedExpense.ExpenseDate(),
+		expectedCreatedExpense.Description(),
+		expectedCreatedExpense.ExpenseType().Id(),
+		uuid.Nil)
+	splitCommand, _ := expenseService.NewSplitCommand(suite.userId, "equal",
+		[]expenseService.SplitShare{{UserId: suite.userId}, {UserId: participantId}})
+	suite.expenseServiceMock.MockAdd([]interface{}{suite.userId, suite.ledgerId, addCommand.WithSplit(splitCommand)},
+		[]interface{}{expectedCreatedExpense, nil}, 1)
+
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	expectedSplit := fmt.Sprintf(`"split":{"paid_by":"%s","method":"equal","participants":[{"user_id":"%s","amount":50.10},{"user_id":"%s","amount":50.10}]}`,
+		suite.userId, suite.userId, participantId)
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+		assert.Contains(suite.T(), rec.Body.String(), expectedSplit)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenASplitWithAnUnknownMethod_WhenAdd_ThenReturnErrorWithBadRequestStatus() {
+	requestBody := fmt.Sprintf(`{"amount":{"amount":100.2,"currency":"ARS"},"expense_date":"2022-03-15","expense_type":{"id":"%s"},"split":{"paid_by":"%s","method":"half","participants":[{"user_id":"%s"}]}}`,
+		uuid.New(), suite.userId, suite.userId)
+	c, rec := suite.mockAddExpenseRequest(requestBody)
+
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	handler.Add(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), expense.FieldValidationErrorMessage)
+}
+
+func (suite *HandlerTestSuite) TestGivenASplitWithUsersOutsideTheLedger_WhenAdd_ThenReturnErrorWithBadRequestStatus() {
+	expenseToCreate := suite.getExpenseWithAllFields()
+	participantId := uuid.New()
+
+	requestBody := fmt.Sprintf(`{"amount":{"amount":100.20,"currency":"ARS"},"expense_date":"2022-03-15","description":"Lomitos","expense_type":{"id":"%s"},"split":{"paid_by":"%s","method":"shares","participants":[{"user_id":"%s","value":2}]}}`,
+		expenseToCreate.ExpenseType().Id(), suite.userId, participantId)
+	c, rec := suite.mockAddExpenseRequest(requestBody)
+
+	addCommand, _ := expenseService.NewAddCommand(expenseToCreate.Amount().Amount(),
+		expenseToCreate.Amount().Currency(),
+		expenseToCreate.ExpenseDate(),
+		expenseToCreate.Description(),
+		expenseToCreate.ExpenseType().Id(),
+		uuid.Nil)
+	splitCommand, _ := expenseService.NewSplitCommand(suite.userId, "shares",
+		[]expenseService.SplitShare{{UserId: participantId, Value: "2"}})
+	serviceErr := expenseService.InvalidSplitError{Msg: "the payer and the participants of a split must be members of the ledger"}
+	suite.expenseServiceMock.MockAdd([]interface{}{suite.userId, suite.ledgerId, addCommand.WithSplit(splitCommand)},
+		[]interface{}{nil, serviceErr}, 1)
+
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	handler.Add(c)
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, serviceErr.Error(), serviceErr.Error(), "[]", 0)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+}
+
+func (suite *HandlerTestSuite) TestGivenAnExpenseWithTags_WhenAdd_ThenReturnStatusCreatedWithItsTags() {
+	expectedCreatedExpense, _ := suite.getExpenseWithAllFields().WithTags([]string{"reimbursable", "vacation-2026"})
+
+	requestBody := fmt.Sprintf(`{"amount":{"amount":100.20,"currency":"ARS"},"expense_date":"2022-03-15","description":"Lomitos","expense_type":{"id":"%s"},"tags":["Vacation-2026","reimbursable"]}`,
+		expectedCreatedExpense.ExpenseType().Id())
+	c, rec := suite.mockAddExpenseRequest(requestBody)
+
+	addCommand, _ := expenseService.NewAddCommand(expectedCreatedExpense.Amount().Amount(),
+		expectedCreatedExpense.Amount().Currency(),
+		expectedCreatedExpense.ExpenseDate(),
+		expectedCreatedExpense.Description(),
+		expectedCreatedExpense.ExpenseType().Id(),
+		uuid.Nil)
+	suite.expenseServiceMock.MockAdd([]interface{}{suite.userId, suite.ledgerId, addCommand.WithTags([]string{"Vacation-2026", "reimbursable"})},
+		[]interface{}{expectedCreatedExpense, nil}, 1)
+
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+		assert.Contains(suite.T(), rec.Body.String(), `"tags":["reimbursable","vacation-2026"]`)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenATooLongTag_WhenAdd_ThenReturnErrorWithBadRequestStatus() {
+	requestBody := fmt.Sprintf(`{"amount":{"amount":100.2,"currency":"ARS"},"expense_date":"2022-03-15","expense_type":{"id":"%s"},"tags":["%s"]}`,
+		uuid.New(), strings.Repeat("a", models.MaxTagNameLength+1))
+	c, rec := suite.mockAddExpenseRequest(requestBody)
+
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	handler.Add(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), expense.FieldValidationErrorMessage)
+}
+
 func (suite *HandlerTestSuite) TestGivenAPeriod_WhenSearchInPeriod_ThenReturnStatusOkWithListOfExpenses() {
 	expectedExpensesToReturn := suite.getExpenses()
 	startDate := time.Date(2022, 5, 13, 0, 0, 0, 0, time.UTC)
 	endDate := time.Date(2022, 8, 13, 0, 0, 0, 0, time.UTC)
-	searchInPeriodCommand, _ := expenseService.NewSearchInPeriodCommand(startDate, endDate)
-	suite.expenseServiceMock.MockSearchInPeriod([]interface{}{searchInPeriodCommand}, []interface{}{expectedExpensesToReturn, nil}, 1)
+	searchInPeriodCommand := suite.getSearchInPeriodCommand(startDate, endDate)
+	suite.expenseServiceMock.MockSearchInPeriod([]interface{}{suite.userId, suite.ledgerId, searchInPeriodCommand}, []interface{}{&expenseService.SearchResult{Expenses: expectedExpensesToReturn}, nil}, 1)
 
 	c, rec := suite.mockSearchInPeriodRequest(fmt.Sprintf("start_date=%s&end_date=%s", startDate.Format(expense.DateFormat), endDate.Format(expense.DateFormat)))
 
@@ -315,12 +435,164 @@ func (suite *HandlerTestSuite) TestGivenAPeriod_WhenSearchInPeriod_ThenReturnSta
 	}
 }
 
-func (suite *HandlerTestSuite) TestGivenThatServiceFails_WhenSearchInPeriod_ThenReturnStatusInternalServerError() {
+func (suite *HandlerTestSuite) TestGivenAnExpenseType_WhenSearchInPeriod_ThenReturnTheExpensesOfItsSubtreeWithTheirPaths() {
+	food, _ := models.NewExpenseTypeWithId(uuid.New(), "Food")
+	delivery, _ := models.NewExpenseTypeWithId(uuid.New(), "Delivery")
+	delivery, _ = delivery.WithParent(food)
+	amount, _ := models.NewMoney("100.20", "ARS")
+	nestedExpense, _ := models.NewExpenseWithId(uuid.New(), amount, time.Date(2022, 5, 15, 0, 0, 0, 0, time.UTC), "Lomitos", delivery)
 	startDate := time.Date(2022, 5, 13, 0, 0, 0, 0, time.UTC)
 	endDate := time.Date(2022, 8, 13, 0, 0, 0, 0, time.UTC)
+	searchInPeriodCommand := suite.getSearchInPeriodCommand(startDate, endDate)
+	searchInPeriodCommand = searchInPeriodCommand.WithExpenseTypes([]uuid.UUID{food.Id()})
+	suite.expenseServiceMock.MockSearchInPeriod([]interface{}{suite.userId, suite.ledgerId, searchInPeriodCommand}, []interface{}{&expenseService.SearchResult{Expenses: []*models.Expense{nestedExpense}}, nil}, 1)
+
+	c, rec := suite.mockSearchInPeriodRequest(fmt.Sprintf("start_date=%s&end_date=%s&expense_type_id=%s", startDate.Format(expense.DateFormat), endDate.Format(expense.DateFormat), food.Id()))
+
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.SearchInPeriod(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Contains(suite.T(), rec.Body.String(), `"path":"Food \u003e Delivery"`)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenTags_WhenSearchInPeriod_ThenFilterTheExpensesByThem() {
+	taggedExpense, _ := suite.getExpenseWithDateInMay152022().WithTags([]string{"reimbursable", "work"})
+	startDate := time.Date(2022, 5, 13, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2022, 8, 13, 0, 0, 0, 0, time.UTC)
+	tagFilter, _ := models.NewTagFilter([]string{"work", "reimbursable"}, "all")
+	searchInPeriodCommand := suite.getSearchInPeriodCommand(startDate, endDate)
+	searchInPeriodCommand = searchInPeriodCommand.WithTagFilter(tagFilter)
+	suite.expenseServiceMock.MockSearchInPeriod([]interface{}{suite.userId, suite.ledgerId, searchInPeriodCommand}, []interface{}{&expenseService.SearchResult{Expenses: []*models.Expense{taggedExpense}}, nil}, 1)
+
+	c, rec := suite.mockSearchInPeriodRequest(fmt.Sprintf("start_date=%s&end_date=%s&tags=work,Reimbursable&tag_match=all", startDate.Format(expense.DateFormat), endDate.Format(expense.DateFormat)))
+
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.SearchInPeriod(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Contains(suite.T(), rec.Body.String(), `"tags":["reimbursable","work"]`)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenFiltersAndASort_WhenSearchInPeriod_ThenSearchWithAllOfThem() {
+	startDate := time.Date(2022, 5, 13, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2022, 8, 13, 0, 0, 0, 0, time.UTC)
+	firstTypeId := uuid.New()
+	secondTypeId := uuid.New()
+	accountId := uuid.New()
+	searchInPeriodCommand, _ := expenseService.NewSearchInPeriodCommand(startDate, endDate)
+	searchInPeriodCommand, _ = searchInPeriodCommand.WithAmountRange("10", "200.50")
+	searchInPeriodCommand, _ = searchInPeriodCommand.WithCurrency("ARS")
+	searchInPeriodCommand, _ = searchInPeriodCommand.WithSort("-amount")
+	searchInPeriodCommand, _ = searchInPeriodCommand.WithPage(20, "")
+	searchInPeriodCommand = searchInPeriodCommand.WithExpenseTypes([]uuid.UUID{firstTypeId, secondTypeId}).WithAccount(accountId).WithDescription("lomitos")
+	suite.expenseServiceMock.MockSearchInPeriod([]interface{}{suite.userId, suite.ledgerId, searchInPeriodCommand}, []interface{}{&expenseService.SearchResult{Expenses: suite.getExpenses()}, nil}, 1)
+
+	c, rec := suite.mockSearchInPeriodRequest(fmt.Sprintf("start_date=2022-05-13&end_date=2022-08-13&expense_type_id=%s&expense_type_id=%s&min_amount=10&max_amount=200.50&currency=ARS&description=lomitos&account_id=%s&sort=-amount&limit=20",
+		firstTypeId, secondTypeId, accountId))
+
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.SearchInPeriod(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Contains(suite.T(), rec.Body.String(), `"limit":20}`)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenALimitOverTheMaximum_WhenSearchInPeriod_ThenReturnAPageOfTheMaximumSizeWithTheNextCursor() {
+	startDate := time.Date(2022, 5, 13, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2022, 8, 13, 0, 0, 0, 0, time.UTC)
+	nextCursor := suite.getCursor()
 	searchInPeriodCommand, _ := expenseService.NewSearchInPeriodCommand(startDate, endDate)
+	searchInPeriodCommand, _ = searchInPeriodCommand.WithPage(expenseService.MaxPageSize, "")
+	suite.expenseServiceMock.MockSearchInPeriod([]interface{}{suite.userId, suite.ledgerId, searchInPeriodCommand}, []interface{}{&expenseService.SearchResult{Expenses: suite.getExpenses(), NextCursor: nextCursor}, nil}, 1)
+
+	c, rec := suite.mockSearchInPeriodRequest("start_date=2022-05-13&end_date=2022-08-13&limit=1000")
+
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.SearchInPeriod(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Contains(suite.T(), rec.Body.String(), fmt.Sprintf(`"limit":%d,"next_cursor":"%s"}`, expenseService.MaxPageSize, nextCursor))
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnInvalidCursor_WhenSearchInPeriod_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockSearchInPeriodRequest("start_date=2022-05-13&end_date=2022-08-13&cursor=not-a-cursor")
+
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	handler.SearchInPeriod(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), "invalid cursor")
+}
+
+func (suite *HandlerTestSuite) TestGivenAnUnknownSort_WhenSearchInPeriod_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockSearchInPeriodRequest("start_date=2022-05-13&end_date=2022-08-13&sort=description")
+
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	handler.SearchInPeriod(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), expense.FieldValidationErrorMessage)
+}
+
+func (suite *HandlerTestSuite) TestGivenAnUnknownTagMatch_WhenSearchInPeriod_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockSearchInPeriodRequest("start_date=2022-05-13&end_date=2022-08-13&tags=work&tag_match=some")
+
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	handler.SearchInPeriod(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), expense.FieldValidationErrorMessage)
+}
+
+func (suite *HandlerTestSuite) TestGivenATargetCurrency_WhenSearchInPeriod_ThenReturnExpensesWithConvertedAmounts() {
+	storedExpenses := suite.getExpenses()
+	startDate := time.Date(2022, 5, 13, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2022, 8, 13, 0, 0, 0, 0, time.UTC)
+	searchInPeriodCommand := suite.getSearchInPeriodCommand(startDate, endDate)
+	searchInPeriodCommand, _ = searchInPeriodCommand.WithTargetCurrency("USD")
+	rate, _ := models.NewExchangeRate("USD", storedExpenses[0].Amount().Currency(), time.Date(2022, 5, 13, 0, 0, 0, 0, time.UTC), "0.5")
+	convertedConversion, _ := models.NewConversion(storedExpenses[0].Amount(), "USD", rate)
+	missingConversion, _ := models.NewConversion(storedExpenses[1].Amount(), "USD", nil)
+	convertedExpenses := []*models.Expense{storedExpenses[0].WithConversion(convertedConversion), storedExpenses[1].WithConversion(missingConversion)}
+	suite.expenseServiceMock.MockSearchInPeriod([]interface{}{suite.userId, suite.ledgerId, searchInPeriodCommand}, []interface{}{&expenseService.SearchResult{Expenses: convertedExpenses}, nil}, 1)
+
+	c, rec := suite.mockSearchInPeriodRequest(fmt.Sprintf("start_date=%s&end_date=%s&target_currency=USD", startDate.Format(expense.DateFormat), endDate.Format(expense.DateFormat)))
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	firstBody := suite.mapExpenseToExpenseBody(storedExpenses[0])
+	firstBody.ConvertedAmount = &expense.ConversionBody{
+		Amount:   json.Number(convertedConversion.Amount().Amount()),
+		Currency: "USD",
+		ExchangeRate: &expense.ExchangeRateBody{
+			BaseCurrency:  "USD",
+			QuoteCurrency: storedExpenses[0].Amount().Currency(),
+			Date:          "2022-05-13",
+			Rate:          "0.5",
+		},
+	}
+	secondBody := suite.mapExpenseToExpenseBody(storedExpenses[1])
+	secondBody.ConvertedAmount = &expense.ConversionBody{Currency: "USD", MissingRate: true}
+	bodyBytes, _ := json.Marshal(expense.SearchResponse{Expenses: []expense.Body{firstBody, secondBody}, Limit: expenseService.DefaultPageSize})
+	if assert.NoError(suite.T(), handler.SearchInPeriod(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), string(bodyBytes)+"\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenThatServiceFails_WhenSearchInPeriod_ThenReturnStatusInternalServerError() {
+	startDate := time.Date(2022, 5, 13, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2022, 8, 13, 0, 0, 0, 0, time.UTC)
+	searchInPeriodCommand := suite.getSearchInPeriodCommand(startDate, endDate)
 	expectedServiceError := expenseService.UnexpectedError{Msg: "fail getting expenses"}
-	suite.expenseServiceMock.MockSearchInPeriod([]interface{}{searchInPeriodCommand}, []interface{}{nil, expectedServiceError}, 1)
+	suite.expenseServiceMock.MockSearchInPeriod([]interface{}{suite.userId, suite.ledgerId, searchInPeriodCommand}, []interface{}{nil, expectedServiceError}, 1)
 
 	c, rec := suite.mockSearchInPeriodRequest(fmt.Sprintf("start_date=%s&end_date=%s", startDate.Format(expense.DateFormat), endDate.Format(expense.DateFormat)))
 
@@ -334,6 +606,50 @@ func (suite *HandlerTestSuite) TestGivenThatServiceFails_WhenSearchInPeriod_Then
 	assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
 }
 
+func (suite *HandlerTestSuite) TestGivenThatTheRequestDeadlinePasses_WhenSearchInPeriod_ThenReturnStatusServiceUnavailable() {
+	startDate := time.Date(2022, 5, 13, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2022, 8, 13, 0, 0, 0, 0, time.UTC)
+	searchInPeriodCommand := suite.getSearchInPeriodCommand(startDate, endDate)
+	expectedServiceError := expenseService.UnexpectedError{Msg: context.DeadlineExceeded.Error()}
+	suite.expenseServiceMock.MockSearchInPeriod([]interface{}{suite.userId, suite.ledgerId, searchInPeriodCommand}, []interface{}{nil, expectedServiceError}, 1)
+
+	c, rec := suite.mockSearchInPeriodRequest(fmt.Sprintf("start_date=%s&end_date=%s", startDate.Format(expense.DateFormat), endDate.Format(expense.DateFormat)))
+	ctx, cancel := context.WithDeadline(c.Request().Context(), time.Now())
+	defer cancel()
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusServiceUnavailable, rest.RequestTimeoutMessage, expectedServiceError.Error(), "[]", 0)
+
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	handler.SearchInPeriod(c)
+
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+}
+
+func (suite *HandlerTestSuite) TestGivenThatTheClientCancelsTheRequest_WhenSearchInPeriod_ThenReturnStatusClientClosedRequest() {
+	startDate := time.Date(2022, 5, 13, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2022, 8, 13, 0, 0, 0, 0, time.UTC)
+	searchInPeriodCommand := suite.getSearchInPeriodCommand(startDate, endDate)
+	expectedServiceError := expenseService.UnexpectedError{Msg: context.Canceled.Error()}
+	suite.expenseServiceMock.MockSearchInPeriod([]interface{}{suite.userId, suite.ledgerId, searchInPeriodCommand}, []interface{}{nil, expectedServiceError}, 1)
+
+	c, rec := suite.mockSearchInPeriodRequest(fmt.Sprintf("start_date=%s&end_date=%s", startDate.Format(expense.DateFormat), endDate.Format(expense.DateFormat)))
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	cancel()
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, rest.StatusClientClosedRequest, rest.RequestCanceledMessage, expectedServiceError.Error(), "[]", 0)
+
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	handler.SearchInPeriod(c)
+
+	assert.Equal(suite.T(), rest.StatusClientClosedRequest, rec.Code)
+	assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+}
+
 func (suite *HandlerTestSuite) TestGivenThatStartDateParamNotExists_WhenSearchInPeriod_ThenReturnStatusBadRequest() {
 	endDate := time.Date(2022, 8, 13, 0, 0, 0, 0, time.UTC)
 
@@ -412,6 +728,207 @@ func (suite *HandlerTestSuite) TestGivenThatStartDateIsGreaterThanEndDate_WhenSe
 	assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
 }
 
+func (suite *HandlerTestSuite) TestGivenAQuery_WhenSearchText_ThenReturnStatusOkWithTheRankedExpenses() {
+	matchedExpense := suite.getExpenseWithAllFields()
+	command, _ := expenseService.NewTextSearchCommand("lomi")
+	command, _ = command.WithLimit(10)
+	hits := []*expenseService.TextSearchHit{{Expense: matchedExpense, Rank: 0.6, Snippet: "<mark>Lomitos</mark> de la esquina"}}
+	suite.expenseServiceMock.MockSearchText([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{hits, nil}, 1)
+
+	c, rec := suite.mockSearchInPeriodRequest("q=Lomi&limit=10")
+
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.SearchText(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		response := expense.TextSearchResponse{}
+		require.NoError(suite.T(), json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Len(suite.T(), response.Results, 1)
+		assert.Equal(suite.T(), matchedExpense.Id().String(), response.Results[0].Expense.ID)
+		assert.Equal(suite.T(), 0.6, response.Results[0].Rank)
+		assert.Equal(suite.T(), "<mark>Lomitos</mark> de la esquina", response.Results[0].Snippet)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAQueryWithoutWords_WhenSearchText_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockSearchInPeriodRequest("q=%3F%21")
+
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	handler.SearchText(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), "invalid command")
+}
+
+func (suite *HandlerTestSuite) TestGivenThatQueryParamNotExists_WhenSearchText_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockSearchInPeriodRequest("limit=10")
+
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	handler.SearchText(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), expense.FieldValidationErrorMessage)
+}
+
+func (suite *HandlerTestSuite) TestGivenAnId_WhenGetById_ThenReturnStatusOkWithExpense() {
+	expectedExpense := suite.getExpenseWithAllFields()
+	suite.expenseServiceMock.MockGetByID([]interface{}{suite.userId, suite.ledgerId, expectedExpense.Id()}, []interface{}{expectedExpense, nil}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodGet, expectedExpense.Id().String(), "")
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.GetById(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), suite.getAddExpenseResponseFromExpense(expectedExpense), rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenThatExpenseNotExists_WhenGetById_ThenReturnStatusNotFound() {
+	id := uuid.New()
+	suite.expenseServiceMock.MockGetByID([]interface{}{suite.userId, suite.ledgerId, id}, []interface{}{nil, nil}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodGet, id.String(), "")
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusNotFound, expense.ExpenseNotFoundErrorMessage, expense.ExpenseNotFoundErrorMessage, "[]", 0)
+	if assert.NoError(suite.T(), handler.GetById(c)) {
+		assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnInvalidId_WhenGetById_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequestWithId(http.MethodGet, "fruta-uuid", "")
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.GetById(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnExpenseToUpdate_WhenUpdate_ThenReturnStatusOkWithUpdatedExpense() {
+	expectedExpense := suite.getExpenseWithAllFields()
+	description := expectedExpense.Description()
+	command, _ := expenseService.NewUpdateCommand(expectedExpense.Id(),
+		expectedExpense.Amount().Amount(),
+		expectedExpense.Amount().Currency(),
+		expectedExpense.ExpenseDate(),
+		&description,
+		expectedExpense.ExpenseType().Id(),
+		uuid.Nil)
+	suite.expenseServiceMock.MockUpdate([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{expectedExpense, nil}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodPut, expectedExpense.Id().String(), suite.getAddExpenseRequestBodyFromExpense(expectedExpense))
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Update(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), suite.getAddExpenseResponseFromExpense(expectedExpense), rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnExpenseToUpdateWithoutExpenseDate_WhenUpdate_ThenReturnStatusBadRequest() {
+	id := uuid.New()
+	requestBody := `{"amount":{"amount":100.2,"currency":"ARS"},"description":"Lomitos","expense_type":{"id":"` + uuid.New().String() + `"}}`
+	c, rec := suite.mockRequestWithId(http.MethodPut, id.String(), requestBody)
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, expense.FieldValidationErrorMessage, expense.FieldValidationErrorMessage, `[{"field":"ExpenseDate","message":"ExpenseDate is a required field"}]`, rest.FieldValidationErrorCode)
+	if assert.NoError(suite.T(), handler.Update(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAPartialExpense_WhenPatch_ThenReturnStatusOkWithUpdatedExpense() {
+	expectedExpense := suite.getExpenseWithAllFields()
+	description := "Pizza"
+	command, _ := expenseService.NewUpdateCommand(expectedExpense.Id(), "", "", time.Time{}, &description, uuid.Nil, uuid.Nil)
+	suite.expenseServiceMock.MockUpdate([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{expectedExpense, nil}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodPatch, expectedExpense.Id().String(), `{"description":"Pizza"}`)
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Patch(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), suite.getAddExpenseResponseFromExpense(expectedExpense), rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnAmountWithoutCurrency_WhenPatch_ThenUpdateTheAmountOnly() {
+	expectedExpense := suite.getExpenseWithAllFields()
+	command, _ := expenseService.NewUpdateCommand(expectedExpense.Id(), "12.5", "", time.Time{}, nil, uuid.Nil, uuid.Nil)
+	suite.expenseServiceMock.MockUpdate([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{expectedExpense, nil}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodPatch, expectedExpense.Id().String(), `{"amount":{"amount":12.5}}`)
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Patch(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), suite.getAddExpenseResponseFromExpense(expectedExpense), rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenThatExpenseNotExists_WhenPatch_ThenReturnStatusNotFound() {
+	id := uuid.New()
+	command, _ := expenseService.NewUpdateCommand(id, "", "", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), nil, uuid.Nil, uuid.Nil)
+	serviceErr := expenseService.ExpenseNotFoundError{Msg: "the expense doesn't exists"}
+	suite.expenseServiceMock.MockUpdate([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{nil, serviceErr}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodPatch, id.String(), `{"expense_date":"2022-03-01"}`)
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusNotFound, serviceErr.Error(), serviceErr.Error(), "[]", 0)
+	if assert.NoError(suite.T(), handler.Patch(c)) {
+		assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnId_WhenDelete_ThenReturnStatusNoContent() {
+	id := uuid.New()
+	suite.expenseServiceMock.MockDelete([]interface{}{suite.userId, suite.ledgerId, id}, []interface{}{nil}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodDelete, id.String(), "")
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Delete(c)) {
+		assert.Equal(suite.T(), http.StatusNoContent, rec.Code)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenThatExpenseNotExists_WhenDelete_ThenReturnStatusNotFound() {
+	id := uuid.New()
+	serviceErr := expenseService.ExpenseNotFoundError{Msg: "the expense doesn't exists"}
+	suite.expenseServiceMock.MockDelete([]interface{}{suite.userId, suite.ledgerId, id}, []interface{}{serviceErr}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodDelete, id.String(), "")
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusNotFound, serviceErr.Error(), serviceErr.Error(), "[]", 0)
+	if assert.NoError(suite.T(), handler.Delete(c)) {
+		assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAViewerOfTheLedger_WhenDelete_ThenReturnStatusForbidden() {
+	id := uuid.New()
+	serviceErr := ledgerService.ForbiddenError{Msg: "your role in the ledger doesn't allow it"}
+	suite.expenseServiceMock.MockDelete([]interface{}{suite.userId, suite.ledgerId, id}, []interface{}{serviceErr}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodDelete, id.String(), "")
+	handler := expense.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusForbidden, serviceErr.Error(), serviceErr.Error(), "[]", 0)
+	if assert.NoError(suite.T(), handler.Delete(c)) {
+		assert.Equal(suite.T(), http.StatusForbidden, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
 func (suite *HandlerTestSuite) getExpenseWithAllFields() *models.Expense {
 	newExpense, _ := models.NewExpense(
 		suite.getMoney(),
@@ -420,6 +937,15 @@ func (suite *HandlerTestSuite) getExpenseWithAllFields() *models.Expense {
 	return newExpense
 }
 
+func (suite *HandlerTestSuite) getSplitExpense(participantId uuid.UUID) *models.Expense {
+	newExpense := suite.getExpenseWithAllFields()
+	payer, _ := models.NewSplitParticipant(suite.userId, "")
+	participant, _ := models.NewSplitParticipant(participantId, "")
+	split, _ := models.NewExpenseSplit(newExpense.Amount(), suite.userId, models.EqualSplitMethod, []*models.SplitParticipant{payer, participant})
+	splitExpense, _ := newExpense.WithSplit(split)
+	return splitExpense
+}
+
 func (suite *HandlerTestSuite) getExpenseWithoutDescription() *models.Expense {
 	newExpense, _ := models.NewExpense(suite.getMoney(),
 		time.Date(2022, time.March, 15, 0, 0, 0, 0, time.UTC),
@@ -442,17 +968,17 @@ func (suite *HandlerTestSuite) getExpenseWithDateInSeptember152022() *models.Exp
 }
 
 func (suite *HandlerTestSuite) getMoney() *models.Money {
-	money, _ := models.NewMoney(100.2, "ARS")
+	money, _ := models.NewMoney("100.2", "ARS")
 	return money
 }
 
 func (suite *HandlerTestSuite) getMoneyWithZeroAmount() *models.Money {
-	money, _ := models.NewMoney(0, "ARS")
+	money, _ := models.NewMoney("0", "ARS")
 	return money
 }
 
 func (suite *HandlerTestSuite) getMoneyWithNegativeAmount() *models.Money {
-	money, _ := models.NewMoney(-1, "ARS")
+	money, _ := models.NewMoney("-1", "ARS")
 	return money
 }
 
@@ -475,7 +1001,7 @@ func (suite *HandlerTestSuite) getAddExpenseResponseFromExpense(domainExpense *m
 	response := expense.Response{Expense: expense.Body{
 		ID: domainExpense.Id().String(),
 		Amount: expense.Money{
-			Amount:   domainExpense.Amount().Amount(),
+			Amount:   json.Number(domainExpense.Amount().Amount()),
 			Currency: domainExpense.Amount().Currency(),
 		},
 		ExpenseDate: domainExpense.ExpenseDate().Format(expense.DateFormat),
@@ -483,6 +1009,7 @@ func (suite *HandlerTestSuite) getAddExpenseResponseFromExpense(domainExpense *m
 		ExpenseType: expense.TypeBody{
 			ID:   domainExpense.ExpenseType().Id().String(),
 			Name: domainExpense.ExpenseType().Name(),
+			Path: domainExpense.ExpenseType().FullPath(),
 		},
 	}}
 
@@ -493,7 +1020,7 @@ func (suite *HandlerTestSuite) getAddExpenseResponseFromExpense(domainExpense *m
 func (suite *HandlerTestSuite) getAddExpenseRequestBodyFromExpense(domainExpense *models.Expense) string {
 	addExpenseBody := expense.AddExpenseRequest{
 		Amount: expense.Money{
-			Amount:   domainExpense.Amount().Amount(),
+			Amount:   json.Number(domainExpense.Amount().Amount()),
 			Currency: domainExpense.Amount().Currency(),
 		},
 		ExpenseDate: domainExpense.ExpenseDate().Format(expense.DateFormat),
@@ -512,7 +1039,10 @@ func (suite *HandlerTestSuite) mockAddExpenseRequest(body string) (echo.Context,
 	req := httptest.NewRequest(http.MethodPost, "/expense", strings.NewReader(body))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	return e.NewContext(req, rec), rec
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	rest.SetLedgerId(c, suite.ledgerId)
+	return c, rec
 }
 
 func (suite *HandlerTestSuite) mockSearchInPeriodRequest(queryParams string) (echo.Context, *httptest.ResponseRecorder) {
@@ -523,7 +1053,37 @@ func (suite *HandlerTestSuite) mockSearchInPeriodRequest(queryParams string) (ec
 		nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	return e.NewContext(req, rec), rec
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	rest.SetLedgerId(c, suite.ledgerId)
+	return c, rec
+}
+
+func (suite *HandlerTestSuite) mockRequestWithId(method string, id string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, "/expenses/"+id, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	rest.SetLedgerId(c, suite.ledgerId)
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	return c, rec
+}
+
+// getCursor returns the cursor of a page whose last expense is dated on May 15, 2022.
+func (suite *HandlerTestSuite) getCursor() *expenseService.Cursor {
+	payload := fmt.Sprintf(`{"s":"-expense_date","v":"2022-05-15","i":"%s"}`, uuid.New())
+	cursor, _ := expenseService.ParseCursor(base64.RawURLEncoding.EncodeToString([]byte(payload)))
+	return cursor
+}
+
+// getSearchInPeriodCommand returns the command of a search of the first page of the period without filters.
+func (suite *HandlerTestSuite) getSearchInPeriodCommand(startDate time.Time, endDate time.Time) *expenseService.SearchInPeriodCommand {
+	command, _ := expenseService.NewSearchInPeriodCommand(startDate, endDate)
+	command, _ = command.WithPage(expenseService.DefaultPageSize, "")
+	return command
 }
 
 func (suite *HandlerTestSuite) getSearchResponseBodyFromExpenses(expenses []*models.Expense) string {
@@ -532,7 +1092,7 @@ func (suite *HandlerTestSuite) getSearchResponseBodyFromExpenses(expenses []*mod
 		expenseBodies = append(expenseBodies, suite.mapExpenseToExpenseBody(expense))
 	}
 
-	response := expense.SearchResponse{Expenses: expenseBodies}
+	response := expense.SearchResponse{Expenses: expenseBodies, Limit: expenseService.DefaultPageSize}
 	bodyBytes, _ := json.Marshal(response)
 	return string(bodyBytes) + "\n"
 }
@@ -541,7 +1101,7 @@ func (suite *HandlerTestSuite) mapExpenseToExpenseBody(domainExpense *models.Exp
 	return expense.Body{
 		ID: domainExpense.Id().String(),
 		Amount: expense.Money{
-			Amount:   domainExpense.Amount().Amount(),
+			Amount:   json.Number(domainExpense.Amount().Amount()),
 			Currency: domainExpense.Amount().Currency(),
 		},
 		ExpenseDate: domainExpense.ExpenseDate().Format(expense.DateFormat),
@@ -549,6 +1109,7 @@ func (suite *HandlerTestSuite) mapExpenseToExpenseBody(domainExpense *models.Exp
 		ExpenseType: expense.TypeBody{
 			ID:   domainExpense.ExpenseType().Id().String(),
 			Name: domainExpense.ExpenseType().Name(),
+			Path: domainExpense.ExpenseType().FullPath(),
 		},
 	}
 }
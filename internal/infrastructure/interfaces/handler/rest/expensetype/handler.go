@@ -1,10 +1,13 @@
 package expensetype
 
 import (
+	"errors"
 	"finfit-backend/internal/domain/models"
 	"finfit-backend/internal/domain/services/expensetype"
+	"finfit-backend/internal/domain/services/ledger"
 	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
 	"finfit-backend/pkg/fieldvalidation"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"net/http"
 )
@@ -12,12 +15,16 @@ import (
 const (
 	FieldValidationErrorMessage = "some fields are invalid"
 	BodyIsInvalidErrorMessage   = "body is invalid"
+	InvalidIdErrorMessage       = "id path param is invalid, it must be a valid UUID"
+	InvalidReassignToMessage    = "reassign_to query param is invalid, it must be a valid UUID different from the deleted one"
 	UnexpectedErrorMessage      = "unexpected error"
 )
 
 type Handler interface {
 	Add(context echo.Context) error
 	GetAll(context echo.Context) error
+	Update(context echo.Context) error
+	Delete(context echo.Context) error
 }
 
 type handler struct {
@@ -45,25 +52,129 @@ func (h handler) Add(context echo.Context) error {
 		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
 	}
 
-	addedExpenseType, err := h.service.Add(command)
+	addedExpenseType, err := h.service.Add(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), command)
 	if err != nil {
-		return h.buildErrorResponse(context, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+		return h.manageServiceError(context, err)
 	}
 
 	return context.JSON(http.StatusCreated, h.mapAddedExpenseTypeToExpenseTypeResponse(addedExpenseType))
 }
 
 func (h handler) GetAll(context echo.Context) error {
-	expenseTypes, err := h.service.GetAll()
+	expenseTypes, err := h.service.GetAll(context.Request().Context(), rest.UserId(context), rest.LedgerId(context))
 	if err != nil {
-		return h.buildErrorResponse(context, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+		return h.manageServiceError(context, err)
 	}
 
 	return context.JSON(http.StatusOK, h.mapExpenseTypesToGetAllResponse(expenseTypes))
 }
 
+func (h handler) Update(context echo.Context) error {
+	id, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	requestBody := new(UpdateExpenseTypeRequest)
+	if err = context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := h.mapUpdateCommandFromRequestBody(id, *requestBody)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	updatedExpenseType, err := h.service.Update(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusOK, h.mapAddedExpenseTypeToExpenseTypeResponse(updatedExpenseType))
+}
+
+func (h handler) Delete(context echo.Context) error {
+	id, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	reassignTo := uuid.Nil
+	if reassignToParam := context.QueryParam("reassign_to"); reassignToParam != "" {
+		reassignTo, err = uuid.Parse(reassignToParam)
+		if err != nil {
+			return h.buildErrorResponse(context, http.StatusBadRequest, InvalidReassignToMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+		}
+	}
+
+	command, err := expensetype.NewDeleteCommand(id, reassignTo)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidReassignToMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if err = h.service.Delete(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), command); err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.NoContent(http.StatusNoContent)
+}
+
 func (h handler) mapAddCommandFromRequestBody(body AddExpenseTypeRequest) (*expensetype.AddCommand, error) {
-	return expensetype.NewAddCommand(body.Name)
+	parentId, err := parseParentId(body.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	command, err := expensetype.NewAddCommand(body.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return command.WithParent(parentId), nil
+}
+
+func (h handler) mapUpdateCommandFromRequestBody(id uuid.UUID, body UpdateExpenseTypeRequest) (*expensetype.UpdateCommand, error) {
+	parentId, err := parseParentId(body.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	command, err := expensetype.NewUpdateCommand(id, body.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return command.WithParent(parentId), nil
+}
+
+// parseParentId returns uuid.Nil for the expense types at the top level.
+func parseParentId(parentId string) (uuid.UUID, error) {
+	if parentId == "" {
+		return uuid.Nil, nil
+	}
+	return uuid.Parse(parentId)
+}
+
+func (h handler) manageServiceError(ctx echo.Context, err error) error {
+	if errors.As(err, &expensetype.ExpenseTypeNotFoundError{}) {
+		return h.buildErrorResponse(ctx, http.StatusNotFound, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &expensetype.ExpenseTypeInUseError{}) || errors.As(err, &expensetype.ExpenseTypeHasSubtypesError{}) || errors.As(err, &expensetype.ExpenseTypeAlreadyExistsError{}) {
+		return h.buildErrorResponse(ctx, http.StatusConflict, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &expensetype.InvalidReassignExpenseTypeError{}) || errors.As(err, &expensetype.InvalidParentExpenseTypeError{}) || errors.As(err, &expensetype.InvalidDomainModelError{}) {
+		return h.buildErrorResponse(ctx, http.StatusBadRequest, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.LedgerNotFoundError{}) {
+		return h.buildErrorResponse(ctx, http.StatusNotFound, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.ForbiddenError{}) {
+		return h.buildErrorResponse(ctx, http.StatusForbidden, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if statusCode, msg, ok := rest.EndedRequestError(ctx); ok {
+		return h.buildErrorResponse(ctx, statusCode, msg, err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else {
+		return h.buildErrorResponse(ctx, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
 }
 
 func (h handler) buildErrorResponse(ctx echo.Context, statusCode int, errorMessage string, errorDetail string, fieldErrors []fieldvalidation.FieldError, errorCode uint) error {
@@ -87,14 +198,28 @@ func (h handler) mapExpenseTypesToGetAllResponse(expenseTypes []*models.ExpenseT
 }
 
 func (h handler) mapExpenseTypeToExpenseTypeBody(expenseType *models.ExpenseType) Body {
+	var parentId string
+	if expenseType.Parent() != nil {
+		parentId = expenseType.ParentId().String()
+	}
+
 	return Body{
-		ID:   expenseType.Id().String(),
-		Name: expenseType.Name(),
+		ID:       expenseType.Id().String(),
+		Name:     expenseType.Name(),
+		ParentID: parentId,
+		Path:     expenseType.FullPath(),
 	}
 }
 
 type AddExpenseTypeRequest struct {
-	Name string `json:"name,omitempty" validate:"required,min=3,max=32"`
+	Name     string `json:"name,omitempty" validate:"required,min=3,max=32"`
+	ParentID string `json:"parent_id,omitempty" validate:"omitempty,uuid"`
+}
+
+// UpdateExpenseTypeRequest replaces the expense type, so leaving out the parent moves it to the top level.
+type UpdateExpenseTypeRequest struct {
+	Name     string `json:"name,omitempty" validate:"required,min=3,max=32"`
+	ParentID string `json:"parent_id,omitempty" validate:"omitempty,uuid"`
 }
 
 type AddExpenseTypeResponse struct {
@@ -106,6 +231,9 @@ type GetAllResponse struct {
 }
 
 type Body struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	ParentID string `json:"parent_id,omitempty"`
+	// Path joins the names of the ancestors of the expense type, e.g. Food > Restaurants > Delivery.
+	Path string `json:"path"`
 }
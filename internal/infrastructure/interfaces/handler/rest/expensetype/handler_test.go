@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"net/http"
 	"net/http/httptest"
@@ -27,10 +28,14 @@ const (
 
 type HandlerTestSuite struct {
 	suite.Suite
+	userId                 uuid.UUID
+	ledgerId               uuid.UUID
 	expenseTypeServiceMock *expenseTypeService.ServiceMock
 }
 
 func (suite *HandlerTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.ledgerId = uuid.New()
 	suite.expenseTypeServiceMock = expenseTypeService.NewServiceMock()
 	suite.patchNewUUIDMethod()
 }
@@ -63,7 +68,7 @@ func (suite *HandlerTestSuite) TestGivenAnExpenseTypeToAdd_WhenAdd_ThenReturnSta
 	expectedResponseBody := suite.getAddExpenseTypeResponseFromExpenseType(expectedAddedExpenseType)
 
 	addCommand, _ := expenseTypeService.NewAddCommand(expectedAddedExpenseType.Name())
-	suite.expenseTypeServiceMock.MockAdd([]interface{}{addCommand},
+	suite.expenseTypeServiceMock.MockAdd([]interface{}{suite.userId, suite.ledgerId, addCommand},
 		[]interface{}{expectedAddedExpenseType, nil}, 1)
 
 	handler := expensetype.NewHandler(suite.expenseTypeServiceMock, suite.getValidator())
@@ -125,12 +130,12 @@ func (suite *HandlerTestSuite) TestGetAllSuccess() {
 	}
 
 	expectedResponseBody := suite.getGetAllExpenseTypeResponseFromExpenseTypes(expectedExpenseTypes)
-	suite.expenseTypeServiceMock.MockGetAll([]interface{}{}, []interface{}{expectedExpenseTypes, nil}, 1)
+	suite.expenseTypeServiceMock.MockGetAll([]interface{}{suite.userId, suite.ledgerId}, []interface{}{expectedExpenseTypes, nil}, 1)
 	c, rec := suite.mockGetAllExpenseTypeRequest()
 	handler := expensetype.NewHandler(suite.expenseTypeServiceMock, suite.getValidator())
 
 	if assert.NoError(suite.T(), handler.GetAll(c)) {
-		suite.expenseTypeServiceMock.AssertCalled(suite.T(), "GetAll")
+		suite.expenseTypeServiceMock.AssertCalled(suite.T(), "GetAll", mock.Anything, suite.userId, suite.ledgerId, mock.Anything)
 		assert.Equal(suite.T(), http.StatusOK, rec.Code)
 		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
 	}
@@ -138,25 +143,159 @@ func (suite *HandlerTestSuite) TestGetAllSuccess() {
 
 func (suite *HandlerTestSuite) TestGivenThatServiceReturnUnexpectedError_whenGetAll_thenReturnErrorResponseWithInternalServerErrorStatus() {
 	expectedServiceError := expense.UnexpectedError{Msg: "fail"}
-	suite.expenseTypeServiceMock.MockGetAll([]interface{}{}, []interface{}{nil, expectedServiceError}, 1)
+	suite.expenseTypeServiceMock.MockGetAll([]interface{}{suite.userId, suite.ledgerId}, []interface{}{nil, expectedServiceError}, 1)
 	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusInternalServerError, expensetype.UnexpectedErrorMessage, expectedServiceError.Error(), "[]", 0)
 
 	c, rec := suite.mockGetAllExpenseTypeRequest()
 	handler := expensetype.NewHandler(suite.expenseTypeServiceMock, suite.getValidator())
 
 	if assert.NoError(suite.T(), handler.GetAll(c)) {
-		suite.expenseTypeServiceMock.AssertCalled(suite.T(), "GetAll")
+		suite.expenseTypeServiceMock.AssertCalled(suite.T(), "GetAll", mock.Anything, suite.userId, suite.ledgerId, mock.Anything)
 		assert.Equal(suite.T(), http.StatusInternalServerError, rec.Code)
 		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
 	}
 }
 
+func (suite *HandlerTestSuite) TestGivenANewName_WhenUpdate_ThenReturnStatusOkWithRenamedExpenseType() {
+	expectedExpenseType := suite.getExpenseType1()
+	command, _ := expenseTypeService.NewUpdateCommand(expectedExpenseType.Id(), expectedExpenseType.Name())
+	suite.expenseTypeServiceMock.MockUpdate([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{expectedExpenseType, nil}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodPut, expectedExpenseType.Id().String(), "", suite.getAddExpenseRequestBodyFromExpenseType(expectedExpenseType))
+	handler := expensetype.NewHandler(suite.expenseTypeServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Update(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), suite.getAddExpenseTypeResponseFromExpenseType(expectedExpenseType), rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenANameAlreadyUsed_WhenUpdate_ThenReturnStatusConflict() {
+	id := uuid.New()
+	command, _ := expenseTypeService.NewUpdateCommand(id, "Travel")
+	serviceErr := expenseTypeService.ExpenseTypeAlreadyExistsError{Msg: "an expense type with the same name already exists"}
+	suite.expenseTypeServiceMock.MockUpdate([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{nil, serviceErr}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodPut, id.String(), "", `{"name":"Travel"}`)
+	handler := expensetype.NewHandler(suite.expenseTypeServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusConflict, serviceErr.Error(), serviceErr.Error(), "[]", 0)
+	if assert.NoError(suite.T(), handler.Update(c)) {
+		assert.Equal(suite.T(), http.StatusConflict, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAParent_WhenUpdate_ThenReturnStatusOkWithTheFullPath() {
+	parent, _ := models.NewExpenseTypeWithId(uuid.New(), "Food")
+	expectedExpenseType, _ := models.NewExpenseTypeWithId(uuid.New(), "Delivery")
+	expectedExpenseType, _ = expectedExpenseType.WithParent(parent)
+	command, _ := expenseTypeService.NewUpdateCommand(expectedExpenseType.Id(), "Delivery")
+	suite.expenseTypeServiceMock.MockUpdate([]interface{}{suite.userId, suite.ledgerId, command.WithParent(parent.Id())}, []interface{}{expectedExpenseType, nil}, 1)
+
+	requestBody := fmt.Sprintf(`{"name":"Delivery","parent_id":"%s"}`, parent.Id())
+	c, rec := suite.mockRequestWithId(http.MethodPut, expectedExpenseType.Id().String(), "", requestBody)
+	handler := expensetype.NewHandler(suite.expenseTypeServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(`{"expense_type":{"id":"%s","name":"Delivery","parent_id":"%s","path":"Food \u003e Delivery"}}`+"\n", expectedExpenseType.Id(), parent.Id())
+	if assert.NoError(suite.T(), handler.Update(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenOneOfItsSubtypesAsParent_WhenUpdate_ThenReturnStatusBadRequest() {
+	id := uuid.New()
+	parentId := uuid.New()
+	command, _ := expenseTypeService.NewUpdateCommand(id, "Food")
+	serviceErr := expenseTypeService.InvalidParentExpenseTypeError{Msg: "invalid parent, an expense type can't be nested under itself or one of its subtypes"}
+	suite.expenseTypeServiceMock.MockUpdate([]interface{}{suite.userId, suite.ledgerId, command.WithParent(parentId)}, []interface{}{nil, serviceErr}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodPut, id.String(), "", fmt.Sprintf(`{"name":"Food","parent_id":"%s"}`, parentId))
+	handler := expensetype.NewHandler(suite.expenseTypeServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, serviceErr.Error(), serviceErr.Error(), "[]", 0)
+	if assert.NoError(suite.T(), handler.Update(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnId_WhenDelete_ThenReturnStatusNoContent() {
+	id := uuid.New()
+	command, _ := expenseTypeService.NewDeleteCommand(id, uuid.Nil)
+	suite.expenseTypeServiceMock.MockDelete([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{nil}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodDelete, id.String(), "", "")
+	handler := expensetype.NewHandler(suite.expenseTypeServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Delete(c)) {
+		assert.Equal(suite.T(), http.StatusNoContent, rec.Code)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAReferencedExpenseType_WhenDelete_ThenReturnStatusConflict() {
+	id := uuid.New()
+	command, _ := expenseTypeService.NewDeleteCommand(id, uuid.Nil)
+	serviceErr := expenseTypeService.ExpenseTypeInUseError{Msg: "in use"}
+	suite.expenseTypeServiceMock.MockDelete([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{serviceErr}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodDelete, id.String(), "", "")
+	handler := expensetype.NewHandler(suite.expenseTypeServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusConflict, serviceErr.Error(), serviceErr.Error(), "[]", 0)
+	if assert.NoError(suite.T(), handler.Delete(c)) {
+		assert.Equal(suite.T(), http.StatusConflict, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAReassignTarget_WhenDelete_ThenReturnStatusNoContent() {
+	id := uuid.New()
+	reassignTo := uuid.New()
+	command, _ := expenseTypeService.NewDeleteCommand(id, reassignTo)
+	suite.expenseTypeServiceMock.MockDelete([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{nil}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodDelete, id.String(), "reassign_to="+reassignTo.String(), "")
+	handler := expensetype.NewHandler(suite.expenseTypeServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Delete(c)) {
+		assert.Equal(suite.T(), http.StatusNoContent, rec.Code)
+		suite.expenseTypeServiceMock.AssertCalled(suite.T(), "Delete", mock.Anything, suite.userId, suite.ledgerId, command)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnInvalidReassignTarget_WhenDelete_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequestWithId(http.MethodDelete, uuid.New().String(), "reassign_to=fruta-uuid", "")
+	handler := expensetype.NewHandler(suite.expenseTypeServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Delete(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	}
+}
+
+func (suite *HandlerTestSuite) mockRequestWithId(method string, id string, queryParams string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, fmt.Sprintf("/expense-types/%s?%s", id, queryParams), strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	rest.SetLedgerId(c, suite.ledgerId)
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	return c, rec
+}
+
 func (suite *HandlerTestSuite) mockAddExpenseTypeRequest(body string) (echo.Context, *httptest.ResponseRecorder) {
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/expense-type", strings.NewReader(body))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	return e.NewContext(req, rec), rec
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	rest.SetLedgerId(c, suite.ledgerId)
+	return c, rec
 }
 
 func (suite *HandlerTestSuite) mockGetAllExpenseTypeRequest() (echo.Context, *httptest.ResponseRecorder) {
@@ -164,7 +303,10 @@ func (suite *HandlerTestSuite) mockGetAllExpenseTypeRequest() (echo.Context, *ht
 	req := httptest.NewRequest(http.MethodGet, "/expense-type", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	return e.NewContext(req, rec), rec
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	rest.SetLedgerId(c, suite.ledgerId)
+	return c, rec
 }
 
 func (suite *HandlerTestSuite) getAddExpenseRequestBodyFromExpenseType(expenseType *models.ExpenseType) string {
@@ -203,6 +345,7 @@ func (suite *HandlerTestSuite) mapExpenseTypeToExpenseTypeBody(expenseType *mode
 	return expensetype.Body{
 		ID:   expenseType.Id().String(),
 		Name: expenseType.Name(),
+		Path: expenseType.FullPath(),
 	}
 }
 
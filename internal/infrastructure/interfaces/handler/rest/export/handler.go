@@ -0,0 +1,116 @@
+package export
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/expense"
+	"finfit-backend/internal/domain/services/ledger"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+const (
+	FieldValidationErrorMessage  = "some fields are invalid"
+	ParamsAreInvalidErrorMessage = "params are invalid, query params start_date, end_date and format are required"
+	UnexpectedErrorMessage       = "unexpected error"
+	DateFormat                   = "2006-01-02"
+)
+
+type Handler interface {
+	ExportExpenses(context echo.Context) error
+}
+
+type handler struct {
+	service         expense.Service
+	fieldsValidator fieldvalidation.FieldsValidator
+}
+
+func NewHandler(service expense.Service, fieldsValidator fieldvalidation.FieldsValidator) *handler {
+	return &handler{service: service, fieldsValidator: fieldsValidator}
+}
+
+// ExportExpenses streams the expenses of the period as a file in the requested format. Nothing is written until the
+// first expense is read, so errors found before that get the usual error response; later ones can only cut the file.
+func (h handler) ExportExpenses(context echo.Context) error {
+	requestParams := new(ExportQueryParams)
+	if err := context.Bind(requestParams); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, ParamsAreInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestParams); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	startDate, _ := time.Parse(DateFormat, requestParams.StartDate)
+	endDate, _ := time.Parse(DateFormat, requestParams.EndDate)
+	command, err := expense.NewExportCommand(startDate, endDate)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	var writer expenseWriter
+	err = h.service.Export(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), command, func(exportedExpense *models.Expense) error {
+		if writer == nil {
+			startedWriter, startError := h.startExport(context, requestParams)
+			writer = startedWriter
+			if startError != nil {
+				return startError
+			}
+		}
+		return writer.write(exportedExpense)
+	})
+
+	if err != nil && writer == nil {
+		return h.manageServiceError(context, err)
+	} else if err != nil {
+		return err
+	}
+
+	if writer == nil {
+		if writer, err = h.startExport(context, requestParams); err != nil {
+			return err
+		}
+	}
+	return writer.close()
+}
+
+// startExport commits the response, so the writer is returned even when its header couldn't be written.
+func (h handler) startExport(context echo.Context, requestParams *ExportQueryParams) (expenseWriter, error) {
+	format := exportFormats[requestParams.Format]
+	fileName := fmt.Sprintf("expenses_%s_%s.%s", requestParams.StartDate, requestParams.EndDate, requestParams.Format)
+
+	response := context.Response()
+	response.Header().Set(echo.HeaderContentType, format.contentType)
+	response.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+	response.WriteHeader(http.StatusOK)
+
+	writer := format.newWriter(response)
+	return writer, writer.writeHeader()
+}
+
+func (h handler) manageServiceError(ctx echo.Context, err error) error {
+	if errors.As(err, &ledger.LedgerNotFoundError{}) {
+		return h.buildErrorResponse(ctx, http.StatusNotFound, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.ForbiddenError{}) {
+		return h.buildErrorResponse(ctx, http.StatusForbidden, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if statusCode, msg, ok := rest.EndedRequestError(ctx); ok {
+		return h.buildErrorResponse(ctx, statusCode, msg, err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else {
+		return h.buildErrorResponse(ctx, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+}
+
+func (h handler) buildErrorResponse(ctx echo.Context, statusCode int, errorMessage string, errorDetail string, fieldErrors []fieldvalidation.FieldError, errorCode uint) error {
+	errorResponse := rest.ErrorResponse{StatusCode: statusCode, Msg: errorMessage, ErrorDetail: errorDetail, FieldErrors: fieldErrors, ErrorCode: errorCode}
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+type ExportQueryParams struct {
+	StartDate string `query:"start_date" validate:"required,datetime=2006-01-02,lteStrDateField=EndDate0x2C2006-01-02"`
+	EndDate   string `query:"end_date" validate:"required,datetime=2006-01-02"`
+	Format    string `query:"format" validate:"required,oneof=csv jsonl xlsx"`
+}
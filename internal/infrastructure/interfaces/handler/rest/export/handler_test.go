@@ -0,0 +1,205 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"finfit-backend/internal/domain/models"
+	expenseService "finfit-backend/internal/domain/services/expense"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/export"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/statementimport"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const (
+	errorResponse = `{"status_code":%d,"msg":"%s","error_detail":"%v","field_errors":%v,"error_code":%d}
+`
+	roundTripMapping = `{"date_column":"date","date_format":"YYYY-MM-DD","amount_column":"amount","currency_column":"currency","description_column":"description","expense_type_column":"expense_type"}`
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+	userId             uuid.UUID
+	ledgerId           uuid.UUID
+	expenseServiceMock *expenseService.ServiceMock
+}
+
+func (suite *HandlerTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.ledgerId = uuid.New()
+	suite.expenseServiceMock = expenseService.NewServiceMock()
+}
+
+func (suite *HandlerTestSuite) TearDownTest() {
+	suite.expenseServiceMock.ExpectedCalls = nil
+	suite.expenseServiceMock.Calls = nil
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (suite *HandlerTestSuite) TestGivenCSVFormat_WhenExportExpenses_ThenStreamACSVFile() {
+	expenses := suite.getExpenses()
+	suite.expenseServiceMock.MockExport([]interface{}{suite.userId, suite.ledgerId, suite.getExportCommand()}, []interface{}{expenses, nil}, 1)
+
+	c, rec := suite.mockRequest("csv")
+	handler := export.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	expectedFile := "id,date,amount,currency,description,expense_type,account,fit_id\n" +
+		expenses[0].Id().String() + ",2022-03-01,1234.50,ARS,\"Super, weekly\",Food,,\n" +
+		expenses[1].Id().String() + ",2022-03-02,99.90,USD,Pharmacy,Health,Wallet,F-1\n"
+	if assert.NoError(suite.T(), handler.ExportExpenses(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), export.MIMETextCSV, rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(suite.T(), `attachment; filename="expenses_2022-03-01_2022-03-31.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Equal(suite.T(), expectedFile, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenJSONLinesFormat_WhenExportExpenses_ThenStreamAnObjectPerLine() {
+	expenses := suite.getExpenses()
+	suite.expenseServiceMock.MockExport([]interface{}{suite.userId, suite.ledgerId, suite.getExportCommand()}, []interface{}{expenses, nil}, 1)
+
+	c, rec := suite.mockRequest("jsonl")
+	handler := export.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	expectedFile := `{"id":"` + expenses[0].Id().String() + `","date":"2022-03-01","amount":1234.50,"currency":"ARS","description":"Super, weekly","expense_type":"Food","account":"","fit_id":""}` + "\n" +
+		`{"id":"` + expenses[1].Id().String() + `","date":"2022-03-02","amount":99.90,"currency":"USD","description":"Pharmacy","expense_type":"Health","account":"Wallet","fit_id":"F-1"}` + "\n"
+	if assert.NoError(suite.T(), handler.ExportExpenses(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), expectedFile, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenXLSXFormat_WhenExportExpenses_ThenStreamAWorkbook() {
+	expenses := suite.getExpenses()
+	suite.expenseServiceMock.MockExport([]interface{}{suite.userId, suite.ledgerId, suite.getExportCommand()}, []interface{}{expenses, nil}, 1)
+
+	c, rec := suite.mockRequest("xlsx")
+	handler := export.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	require.NoError(suite.T(), handler.ExportExpenses(c))
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	workbook, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	require.NoError(suite.T(), err)
+	sheet := suite.readZipFile(workbook, "xl/worksheets/sheet1.xml")
+	assert.Contains(suite.T(), sheet, `<c t="inlineStr"><is><t xml:space="preserve">expense_type</t></is></c>`)
+	assert.Contains(suite.T(), sheet, `<c><v>1234.50</v></c><c t="inlineStr"><is><t xml:space="preserve">ARS</t></is></c>`)
+	assert.Contains(suite.T(), sheet, `<t xml:space="preserve">Wallet</t>`)
+	assert.Contains(suite.T(), suite.readZipFile(workbook, "xl/workbook.xml"), `<sheet name="Expenses" sheetId="1" r:id="rId1"/>`)
+}
+
+func (suite *HandlerTestSuite) TestGivenAnExportedCSVFile_WhenImportingIt_ThenEveryExpenseIsReadBack() {
+	expenses := suite.getExpenses()
+	suite.expenseServiceMock.MockExport([]interface{}{suite.userId, suite.ledgerId, suite.getExportCommand()}, []interface{}{expenses, nil}, 1)
+	c, rec := suite.mockRequest("csv")
+	require.NoError(suite.T(), export.NewHandler(suite.expenseServiceMock, suite.getValidator()).ExportExpenses(c))
+
+	importCommand, _ := expenseService.NewImportCommand([]expenseService.ImportRow{
+		{Date: "2022-03-01", Amount: "1234.50", Currency: "ARS", Description: "Super, weekly", ExpenseType: "Food"},
+		{Date: "2022-03-02", Amount: "99.90", Currency: "USD", Description: "Pharmacy", ExpenseType: "Health"},
+	}, "2006-01-02", uuid.Nil, true)
+	suite.expenseServiceMock.MockImport([]interface{}{suite.userId, suite.ledgerId, importCommand}, []interface{}{expenses, nil}, 1)
+
+	importContext, importRec := suite.mockImportRequest(rec.Body.String())
+	require.NoError(suite.T(), statementimport.NewHandler(suite.expenseServiceMock, suite.getValidator()).ImportCSV(importContext))
+	assert.Equal(suite.T(), http.StatusOK, importRec.Code)
+	suite.expenseServiceMock.AssertExpectations(suite.T())
+}
+
+func (suite *HandlerTestSuite) TestGivenAnUnexpectedErrorBeforeTheFirstExpense_WhenExportExpenses_ThenReturnStatusInternalServerError() {
+	suite.expenseServiceMock.MockExport([]interface{}{suite.userId, suite.ledgerId, suite.getExportCommand()}, []interface{}{nil, expenseService.UnexpectedError{Msg: "connection refused"}}, 1)
+
+	c, rec := suite.mockRequest("csv")
+	handler := export.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusInternalServerError, export.UnexpectedErrorMessage, "connection refused", "[]", 0)
+	if assert.NoError(suite.T(), handler.ExportExpenses(c)) {
+		assert.Equal(suite.T(), http.StatusInternalServerError, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnUnknownFormat_WhenExportExpenses_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequest("pdf")
+	handler := export.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	fieldErrors := `[{"field":"Format","message":"Format must be one of [csv jsonl xlsx]"}]`
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, export.FieldValidationErrorMessage, export.FieldValidationErrorMessage, fieldErrors, rest.FieldValidationErrorCode)
+	if assert.NoError(suite.T(), handler.ExportExpenses(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) getExportCommand() *expenseService.ExportCommand {
+	command, _ := expenseService.NewExportCommand(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC))
+	return command
+}
+
+func (suite *HandlerTestSuite) getExpenses() []*models.Expense {
+	food, _ := models.NewExpenseType("Food")
+	health, _ := models.NewExpenseType("Health")
+	firstAmount, _ := models.NewMoney("1234.50", "ARS")
+	secondAmount, _ := models.NewMoney("99.90", "USD")
+	openingBalance, _ := models.NewMoney("0", "USD")
+	wallet, _ := models.NewAccount("Wallet", models.CashAccountKind, openingBalance)
+	first, _ := models.NewExpense(firstAmount, time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), "Super, weekly", food)
+	second, _ := models.NewExpense(secondAmount, time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC), "Pharmacy", health)
+	second, _ = second.WithAccount(wallet)
+	return []*models.Expense{first, second.WithFitId("F-1")}
+}
+
+func (suite *HandlerTestSuite) readZipFile(reader *zip.Reader, name string) string {
+	file, err := reader.Open(name)
+	require.NoError(suite.T(), err)
+	content, err := io.ReadAll(file)
+	require.NoError(suite.T(), err)
+	return string(content)
+}
+
+func (suite *HandlerTestSuite) getValidator() fieldvalidation.FieldsValidator {
+	validator, _ := fieldvalidation.RegisterFieldsValidator(nil, nil)
+	return validator
+}
+
+func (suite *HandlerTestSuite) mockRequest(format string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/exports/expenses?start_date=2022-03-01&end_date=2022-03-31&format="+format, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	rest.SetLedgerId(c, suite.ledgerId)
+	return c, rec
+}
+
+func (suite *HandlerTestSuite) mockImportRequest(file string) (echo.Context, *httptest.ResponseRecorder) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	fileWriter, _ := writer.CreateFormFile(statementimport.FileFormField, "expenses.csv")
+	_, _ = fileWriter.Write([]byte(file))
+	_ = writer.WriteField(statementimport.MappingFormField, roundTripMapping)
+	_ = writer.Close()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/imports/csv?dry_run=true", body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	rest.SetLedgerId(c, suite.ledgerId)
+	return c, rec
+}
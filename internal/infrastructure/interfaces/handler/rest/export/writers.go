@@ -0,0 +1,122 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"finfit-backend/internal/domain/models"
+	"io"
+)
+
+const (
+	MIMETextCSV              = "text/csv"
+	MIMEApplicationJSONLines = "application/x-ndjson"
+	MIMEApplicationXLSX      = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// exportColumns are named like the columns the CSV import expects, so an exported CSV file can be imported again with
+// the mapping {"date_column":"date","date_format":"YYYY-MM-DD","amount_column":"amount","currency_column":"currency",
+// "description_column":"description","expense_type_column":"expense_type"}.
+var exportColumns = []string{"id", "date", "amount", "currency", "description", "expense_type", "account", "fit_id"}
+
+// amountColumn is the position of the amount in exportColumns, the only column spreadsheets must take as a number.
+const amountColumn = 2
+
+type exportFormat struct {
+	contentType string
+	newWriter   func(writer io.Writer) expenseWriter
+}
+
+var exportFormats = map[string]exportFormat{
+	"csv":   {contentType: MIMETextCSV, newWriter: newCSVExpenseWriter},
+	"jsonl": {contentType: MIMEApplicationJSONLines, newWriter: newJSONLinesExpenseWriter},
+	"xlsx":  {contentType: MIMEApplicationXLSX, newWriter: newXLSXExpenseWriter},
+}
+
+// expenseWriter writes the expenses one at a time. close must be called after the last one to flush what is left.
+type expenseWriter interface {
+	writeHeader() error
+	write(expense *models.Expense) error
+	close() error
+}
+
+// exportValuesOf returns the values of the expense in the order of exportColumns.
+func exportValuesOf(expense *models.Expense) []string {
+	accountName := ""
+	if expense.Account() != nil {
+		accountName = expense.Account().Name()
+	}
+
+	return []string{
+		expense.Id().String(),
+		expense.ExpenseDate().Format(DateFormat),
+		expense.Amount().Amount(),
+		expense.Amount().Currency(),
+		expense.Description(),
+		expense.ExpenseType().FullPath(),
+		accountName,
+		expense.FitId(),
+	}
+}
+
+type csvExpenseWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVExpenseWriter(writer io.Writer) expenseWriter {
+	return &csvExpenseWriter{writer: csv.NewWriter(writer)}
+}
+
+func (c *csvExpenseWriter) writeHeader() error {
+	return c.writer.Write(exportColumns)
+}
+
+func (c *csvExpenseWriter) write(expense *models.Expense) error {
+	return c.writer.Write(exportValuesOf(expense))
+}
+
+func (c *csvExpenseWriter) close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// jsonLinesExpenseWriter writes an object per line with the keys of exportColumns. The amount is a JSON number.
+type jsonLinesExpenseWriter struct {
+	encoder *json.Encoder
+}
+
+func newJSONLinesExpenseWriter(writer io.Writer) expenseWriter {
+	return &jsonLinesExpenseWriter{encoder: json.NewEncoder(writer)}
+}
+
+func (j *jsonLinesExpenseWriter) writeHeader() error {
+	return nil
+}
+
+func (j *jsonLinesExpenseWriter) write(expense *models.Expense) error {
+	values := exportValuesOf(expense)
+	return j.encoder.Encode(ExpenseLine{
+		ID:          values[0],
+		Date:        values[1],
+		Amount:      json.Number(values[2]),
+		Currency:    values[3],
+		Description: values[4],
+		ExpenseType: values[5],
+		Account:     values[6],
+		FitId:       values[7],
+	})
+}
+
+func (j *jsonLinesExpenseWriter) close() error {
+	return nil
+}
+
+type ExpenseLine struct {
+	ID          string      `json:"id"`
+	Date        string      `json:"date"`
+	Amount      json.Number `json:"amount"`
+	Currency    string      `json:"currency"`
+	Description string      `json:"description"`
+	ExpenseType string      `json:"expense_type"`
+	Account     string      `json:"account"`
+	FitId       string      `json:"fit_id"`
+}
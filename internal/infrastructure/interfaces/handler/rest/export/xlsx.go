@@ -0,0 +1,103 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"finfit-backend/internal/domain/models"
+	"io"
+	"strings"
+)
+
+const xlsxSheetPath = "xl/worksheets/sheet1.xml"
+
+// xlsxParts are the fixed parts of a workbook with a single sheet. The sheet itself is written row by row.
+var xlsxParts = [][2]string{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Expenses" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// xlsxExpenseWriter writes a minimal Office Open XML workbook. Texts are inline strings, so there is no shared strings
+// table to hold in memory, and the zip entries are streamed as they are written.
+type xlsxExpenseWriter struct {
+	zipWriter *zip.Writer
+	sheet     io.Writer
+	err       error
+}
+
+func newXLSXExpenseWriter(writer io.Writer) expenseWriter {
+	return &xlsxExpenseWriter{zipWriter: zip.NewWriter(writer)}
+}
+
+func (x *xlsxExpenseWriter) writeHeader() error {
+	for _, part := range xlsxParts {
+		partWriter, err := x.zipWriter.Create(part[0])
+		if err != nil {
+			return err
+		}
+
+		if _, err = io.WriteString(partWriter, part[1]); err != nil {
+			return err
+		}
+	}
+
+	sheet, err := x.zipWriter.Create(xlsxSheetPath)
+	if err != nil {
+		return err
+	}
+
+	x.sheet = sheet
+	if _, err = io.WriteString(x.sheet, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`+
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return err
+	}
+	return x.writeRow(exportColumns, false)
+}
+
+func (x *xlsxExpenseWriter) write(expense *models.Expense) error {
+	return x.writeRow(exportValuesOf(expense), true)
+}
+
+func (x *xlsxExpenseWriter) writeRow(values []string, withNumericAmount bool) error {
+	row := strings.Builder{}
+	row.WriteString("<row>")
+	for i, value := range values {
+		if withNumericAmount && i == amountColumn {
+			row.WriteString(`<c><v>` + value + `</v></c>`)
+			continue
+		}
+
+		row.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		if err := xml.EscapeText(&row, []byte(value)); err != nil {
+			return err
+		}
+		row.WriteString(`</t></is></c>`)
+	}
+	row.WriteString("</row>")
+
+	_, err := io.WriteString(x.sheet, row.String())
+	return err
+}
+
+func (x *xlsxExpenseWriter) close() error {
+	if _, err := io.WriteString(x.sheet, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return x.zipWriter.Close()
+}
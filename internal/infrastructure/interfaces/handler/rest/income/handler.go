@@ -0,0 +1,198 @@
+package income
+
+import (
+	"encoding/json"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/income"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/pkg/fieldvalidation"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+const (
+	FieldValidationErrorMessage  = "some fields are invalid"
+	BodyIsInvalidErrorMessage    = "body is invalid"
+	ParamsAreInvalidErrorMessage = "params are invalid, query params start_date and end_date are required"
+	InvalidIdErrorMessage        = "id path param is invalid, it must be a valid UUID"
+	IncomeNotFoundErrorMessage   = "the income doesn't exists"
+	UnexpectedErrorMessage       = "unexpected error"
+	DateFormat                   = "2006-01-02"
+)
+
+type Handler interface {
+	Add(context echo.Context) error
+	SearchInPeriod(context echo.Context) error
+	GetById(context echo.Context) error
+}
+
+type handler struct {
+	service         income.Service
+	fieldsValidator fieldvalidation.FieldsValidator
+}
+
+func NewHandler(service income.Service, fieldsValidator fieldvalidation.FieldsValidator) Handler {
+	return handler{
+		service:         service,
+		fieldsValidator: fieldsValidator,
+	}
+}
+
+func (h handler) Add(context echo.Context) error {
+	requestBody := new(AddIncomeRequest)
+
+	if err := context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := h.mapAddCommandFromRequestBody(*requestBody)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	createdIncome, err := h.service.Add(context.Request().Context(), rest.UserId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusCreated, Response{Income: h.mapIncomeToIncomeBody(createdIncome)})
+}
+
+func (h handler) SearchInPeriod(context echo.Context) error {
+	requestParams := new(SearchInPeriodQueryParams)
+
+	if err := context.Bind(requestParams); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, ParamsAreInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestParams); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	startDate, _ := time.Parse(DateFormat, requestParams.StartDate)
+	endDate, _ := time.Parse(DateFormat, requestParams.EndDate)
+	command, err := income.NewSearchInPeriodCommand(startDate, endDate)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	incomes, err := h.service.SearchInPeriod(context.Request().Context(), rest.UserId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	incomeBodies := []Body{}
+	for _, storedIncome := range incomes {
+		incomeBodies = append(incomeBodies, h.mapIncomeToIncomeBody(storedIncome))
+	}
+
+	return context.JSON(http.StatusOK, SearchResponse{Incomes: incomeBodies})
+}
+
+func (h handler) GetById(context echo.Context) error {
+	id, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	storedIncome, err := h.service.GetById(context.Request().Context(), rest.UserId(context), id)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	if storedIncome == nil {
+		return h.buildErrorResponse(context, http.StatusNotFound, IncomeNotFoundErrorMessage, IncomeNotFoundErrorMessage, []fieldvalidation.FieldError{}, 0)
+	}
+
+	return context.JSON(http.StatusOK, Response{Income: h.mapIncomeToIncomeBody(storedIncome)})
+}
+
+func (h handler) mapAddCommandFromRequestBody(body AddIncomeRequest) (*income.AddCommand, error) {
+	date, _ := time.Parse(DateFormat, body.IncomeDate)
+	incomeSourceId, err := uuid.Parse(body.IncomeSource.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return income.NewAddCommand(body.Amount.Amount.String(), body.Amount.Currency, date, body.Description, incomeSourceId)
+}
+
+func (h handler) manageServiceError(ctx echo.Context, err error) error {
+	if errors.As(err, &income.InvalidIncomeSourceError{}) || errors.As(err, &income.InvalidDomainModelError{}) {
+		return h.buildErrorResponse(ctx, http.StatusBadRequest, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if statusCode, msg, ok := rest.EndedRequestError(ctx); ok {
+		return h.buildErrorResponse(ctx, statusCode, msg, err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else {
+		return h.buildErrorResponse(ctx, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+}
+
+func (h handler) buildErrorResponse(ctx echo.Context, statusCode int, errorMessage string, errorDetail string, fieldErrors []fieldvalidation.FieldError, errorCode uint) error {
+	errorResponse := rest.ErrorResponse{StatusCode: statusCode, Msg: errorMessage, ErrorDetail: errorDetail, FieldErrors: fieldErrors, ErrorCode: errorCode}
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+func (h handler) mapIncomeToIncomeBody(income *models.Income) Body {
+	return Body{
+		ID: income.Id().String(),
+		Amount: Money{
+			Amount:   json.Number(income.Amount().Amount()),
+			Currency: income.Amount().Currency(),
+		},
+		IncomeDate:  income.IncomeDate().Format(DateFormat),
+		Description: income.Description(),
+		IncomeSource: SourceBody{
+			ID:   income.IncomeSource().Id().String(),
+			Name: income.IncomeSource().Name(),
+		},
+	}
+}
+
+type AddIncomeRequest struct {
+	Amount       Money                             `json:"amount,omitempty"`
+	IncomeDate   string                            `json:"income_date,omitempty" validate:"required,datetime=2006-01-02"`
+	Description  string                            `json:"description,omitempty"`
+	IncomeSource *AddIncomeRequestIncomeSourceBody `json:"income_source,omitempty" validate:"required"`
+}
+
+type AddIncomeRequestIncomeSourceBody struct {
+	ID string `json:"id" validate:"required,uuid"`
+}
+
+type SearchInPeriodQueryParams struct {
+	StartDate string `query:"start_date" validate:"required,datetime=2006-01-02,lteStrDateField=EndDate0x2C2006-01-02"`
+	EndDate   string `query:"end_date" validate:"required,datetime=2006-01-02"`
+}
+
+type Response struct {
+	Income Body `json:"income"`
+}
+
+type SearchResponse struct {
+	Incomes []Body `json:"incomes"`
+}
+
+type Body struct {
+	ID           string     `json:"id"`
+	Amount       Money      `json:"amount"`
+	IncomeDate   string     `json:"income_date"`
+	Description  string     `json:"description"`
+	IncomeSource SourceBody `json:"income_source"`
+}
+
+type SourceBody struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Money struct {
+	Amount   json.Number `json:"amount" validate:"required,positiveDecimal"`
+	Currency string      `json:"currency" validate:"iso4217"`
+}
@@ -0,0 +1,207 @@
+package income_test
+
+import (
+	"encoding/json"
+	"finfit-backend/internal/domain/models"
+	incomeService "finfit-backend/internal/domain/services/income"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/income"
+	"finfit-backend/pkg"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	errorResponse = `{"status_code":%d,"msg":"%s","error_detail":"%v","field_errors":%v,"error_code":%d}
+`
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+	userId            uuid.UUID
+	incomeServiceMock *incomeService.ServiceMock
+}
+
+func (suite *HandlerTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.incomeServiceMock = incomeService.NewServiceMock()
+	id := uuid.New()
+	pkg.NewUUID = func() uuid.UUID {
+		return id
+	}
+}
+
+func (suite *HandlerTestSuite) TearDownSuite() {
+	pkg.NewUUID = uuid.New
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (suite *HandlerTestSuite) TestGivenAnIncomeToCreate_WhenAdd_ThenReturnStatusCreatedWithCreatedIncome() {
+	expectedIncome := suite.getIncome(time.Date(2022, time.March, 15, 0, 0, 0, 0, time.UTC))
+	addCommand, _ := incomeService.NewAddCommand(expectedIncome.Amount().Amount(),
+		expectedIncome.Amount().Currency(),
+		expectedIncome.IncomeDate(),
+		expectedIncome.Description(),
+		expectedIncome.IncomeSource().Id())
+	suite.incomeServiceMock.MockAdd([]interface{}{suite.userId, addCommand}, []interface{}{expectedIncome, nil}, 1)
+
+	c, rec := suite.mockRequest(http.MethodPost, "/incomes", suite.getAddIncomeRequestBodyFromIncome(expectedIncome))
+	handler := income.NewHandler(suite.incomeServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+		assert.Equal(suite.T(), suite.getResponseFromIncome(expectedIncome), rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnIncomeWithAnInvalidIncomeSource_WhenAdd_ThenReturnStatusBadRequest() {
+	incomeToCreate := suite.getIncome(time.Date(2022, time.March, 15, 0, 0, 0, 0, time.UTC))
+	addCommand, _ := incomeService.NewAddCommand(incomeToCreate.Amount().Amount(),
+		incomeToCreate.Amount().Currency(),
+		incomeToCreate.IncomeDate(),
+		incomeToCreate.Description(),
+		incomeToCreate.IncomeSource().Id())
+	serviceErr := incomeService.InvalidIncomeSourceError{Msg: "the income source doesn't exists"}
+	suite.incomeServiceMock.MockAdd([]interface{}{suite.userId, addCommand}, []interface{}{nil, serviceErr}, 1)
+
+	c, rec := suite.mockRequest(http.MethodPost, "/incomes", suite.getAddIncomeRequestBodyFromIncome(incomeToCreate))
+	handler := income.NewHandler(suite.incomeServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, serviceErr.Error(), serviceErr.Error(), "[]", 0)
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnIncomeWithoutIncomeDate_WhenAdd_ThenReturnStatusBadRequest() {
+	requestBody := `{"amount":{"amount":100.2,"currency":"ARS"},"description":"Salary","income_source":{"id":"` + uuid.New().String() + `"}}`
+	c, rec := suite.mockRequest(http.MethodPost, "/incomes", requestBody)
+	handler := income.NewHandler(suite.incomeServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, income.FieldValidationErrorMessage, income.FieldValidationErrorMessage, `[{"field":"IncomeDate","message":"IncomeDate is a required field"}]`, rest.FieldValidationErrorCode)
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAPeriod_WhenSearchInPeriod_ThenReturnStatusOkWithListOfIncomes() {
+	expectedIncomes := []*models.Income{
+		suite.getIncome(time.Date(2022, time.May, 15, 0, 0, 0, 0, time.UTC)),
+		suite.getIncome(time.Date(2022, time.June, 15, 0, 0, 0, 0, time.UTC)),
+	}
+	command, _ := incomeService.NewSearchInPeriodCommand(time.Date(2022, 5, 13, 0, 0, 0, 0, time.UTC), time.Date(2022, 8, 13, 0, 0, 0, 0, time.UTC))
+	suite.incomeServiceMock.MockSearchInPeriod([]interface{}{suite.userId, command}, []interface{}{expectedIncomes, nil}, 1)
+
+	c, rec := suite.mockRequest(http.MethodGet, "/incomes?start_date=2022-05-13&end_date=2022-08-13", "")
+	handler := income.NewHandler(suite.incomeServiceMock, suite.getValidator())
+
+	incomeBodies := []income.Body{}
+	for _, expectedIncome := range expectedIncomes {
+		incomeBodies = append(incomeBodies, suite.mapIncomeToIncomeBody(expectedIncome))
+	}
+	bodyBytes, _ := json.Marshal(income.SearchResponse{Incomes: incomeBodies})
+
+	if assert.NoError(suite.T(), handler.SearchInPeriod(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), string(bodyBytes)+"\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenThatStartDateIsGreaterThanEndDate_WhenSearchInPeriod_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequest(http.MethodGet, "/incomes?start_date=2022-09-13&end_date=2022-08-13", "")
+	handler := income.NewHandler(suite.incomeServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, income.FieldValidationErrorMessage, income.FieldValidationErrorMessage, "[{\"field\":\"StartDate\",\"message\":\"StartDate must be before or equal to EndDate\"}]", rest.FieldValidationErrorCode)
+	if assert.NoError(suite.T(), handler.SearchInPeriod(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenThatIncomeNotExists_WhenGetById_ThenReturnStatusNotFound() {
+	id := uuid.New()
+	suite.incomeServiceMock.MockGetByID([]interface{}{suite.userId, id}, []interface{}{nil, nil}, 1)
+
+	c, rec := suite.mockRequest(http.MethodGet, "/incomes/"+id.String(), "")
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+	handler := income.NewHandler(suite.incomeServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusNotFound, income.IncomeNotFoundErrorMessage, income.IncomeNotFoundErrorMessage, "[]", 0)
+	if assert.NoError(suite.T(), handler.GetById(c)) {
+		assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) getIncome(incomeDate time.Time) *models.Income {
+	money, _ := models.NewMoney("1500.5", "ARS")
+	incomeSource, _ := models.NewIncomeSource("Salary")
+	newIncome, _ := models.NewIncome(money, incomeDate, "Monthly salary", incomeSource)
+	return newIncome
+}
+
+func (suite *HandlerTestSuite) getValidator() fieldvalidation.FieldsValidator {
+	validator, _ := fieldvalidation.RegisterFieldsValidator(nil, nil)
+	return validator
+}
+
+func (suite *HandlerTestSuite) getAddIncomeRequestBodyFromIncome(domainIncome *models.Income) string {
+	addIncomeBody := income.AddIncomeRequest{
+		Amount: income.Money{
+			Amount:   json.Number(domainIncome.Amount().Amount()),
+			Currency: domainIncome.Amount().Currency(),
+		},
+		IncomeDate:   domainIncome.IncomeDate().Format(income.DateFormat),
+		Description:  domainIncome.Description(),
+		IncomeSource: &income.AddIncomeRequestIncomeSourceBody{ID: domainIncome.IncomeSource().Id().String()},
+	}
+
+	bodyBytes, _ := json.Marshal(addIncomeBody)
+	return string(bodyBytes)
+}
+
+func (suite *HandlerTestSuite) getResponseFromIncome(domainIncome *models.Income) string {
+	bodyBytes, _ := json.Marshal(income.Response{Income: suite.mapIncomeToIncomeBody(domainIncome)})
+	return string(bodyBytes) + "\n"
+}
+
+func (suite *HandlerTestSuite) mapIncomeToIncomeBody(domainIncome *models.Income) income.Body {
+	return income.Body{
+		ID: domainIncome.Id().String(),
+		Amount: income.Money{
+			Amount:   json.Number(domainIncome.Amount().Amount()),
+			Currency: domainIncome.Amount().Currency(),
+		},
+		IncomeDate:  domainIncome.IncomeDate().Format(income.DateFormat),
+		Description: domainIncome.Description(),
+		IncomeSource: income.SourceBody{
+			ID:   domainIncome.IncomeSource().Id().String(),
+			Name: domainIncome.IncomeSource().Name(),
+		},
+	}
+}
+
+func (suite *HandlerTestSuite) mockRequest(method string, target string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	return c, rec
+}
@@ -0,0 +1,104 @@
+package incomesource
+
+import (
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/incomesource"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/pkg/fieldvalidation"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+const (
+	FieldValidationErrorMessage = "some fields are invalid"
+	BodyIsInvalidErrorMessage   = "body is invalid"
+	UnexpectedErrorMessage      = "unexpected error"
+)
+
+type Handler interface {
+	Add(context echo.Context) error
+	GetAll(context echo.Context) error
+}
+
+type handler struct {
+	service         incomesource.Service
+	fieldsValidator fieldvalidation.FieldsValidator
+}
+
+func NewHandler(service incomesource.Service, fieldsValidator fieldvalidation.FieldsValidator) *handler {
+	return &handler{service: service, fieldsValidator: fieldsValidator}
+}
+
+func (h handler) Add(context echo.Context) error {
+	requestBody := new(AddIncomeSourceRequest)
+
+	if err := context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := incomesource.NewAddCommand(requestBody.Name)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	addedIncomeSource, err := h.service.Add(context.Request().Context(), rest.UserId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusCreated, AddIncomeSourceResponse{IncomeSource: h.mapIncomeSourceToIncomeSourceBody(addedIncomeSource)})
+}
+
+func (h handler) GetAll(context echo.Context) error {
+	incomeSources, err := h.service.GetAll(context.Request().Context(), rest.UserId(context))
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	incomeSourceBodies := []Body{}
+	for _, incomeSource := range incomeSources {
+		incomeSourceBodies = append(incomeSourceBodies, h.mapIncomeSourceToIncomeSourceBody(incomeSource))
+	}
+
+	return context.JSON(http.StatusOK, GetAllResponse{IncomeSources: incomeSourceBodies})
+}
+
+func (h handler) manageServiceError(ctx echo.Context, err error) error {
+	if statusCode, msg, ok := rest.EndedRequestError(ctx); ok {
+		return h.buildErrorResponse(ctx, statusCode, msg, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+	return h.buildErrorResponse(ctx, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+}
+
+func (h handler) buildErrorResponse(ctx echo.Context, statusCode int, errorMessage string, errorDetail string, fieldErrors []fieldvalidation.FieldError, errorCode uint) error {
+	errorResponse := rest.ErrorResponse{StatusCode: statusCode, Msg: errorMessage, ErrorDetail: errorDetail, FieldErrors: fieldErrors, ErrorCode: errorCode}
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+func (h handler) mapIncomeSourceToIncomeSourceBody(incomeSource *models.IncomeSource) Body {
+	return Body{
+		ID:   incomeSource.Id().String(),
+		Name: incomeSource.Name(),
+	}
+}
+
+type AddIncomeSourceRequest struct {
+	Name string `json:"name,omitempty" validate:"required,min=3,max=32"`
+}
+
+type AddIncomeSourceResponse struct {
+	IncomeSource Body `json:"income_source"`
+}
+
+type GetAllResponse struct {
+	IncomeSources []Body `json:"income_sources"`
+}
+
+type Body struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
@@ -0,0 +1,98 @@
+package incomesource_test
+
+import (
+	"encoding/json"
+	"finfit-backend/internal/domain/models"
+	incomeSourceService "finfit-backend/internal/domain/services/incomesource"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/incomesource"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const (
+	errorResponse = `{"status_code":%d,"msg":"%s","error_detail":"%v","field_errors":%v,"error_code":%d}
+`
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+	userId                  uuid.UUID
+	incomeSourceServiceMock *incomeSourceService.ServiceMock
+}
+
+func (suite *HandlerTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.incomeSourceServiceMock = incomeSourceService.NewServiceMock()
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (suite *HandlerTestSuite) TestGivenAnIncomeSourceToAdd_WhenAdd_ThenReturnStatusCreatedWithCreatedIncomeSource() {
+	expectedIncomeSource, _ := models.NewIncomeSource("Salary")
+	addCommand, _ := incomeSourceService.NewAddCommand(expectedIncomeSource.Name())
+	suite.incomeSourceServiceMock.MockAdd([]interface{}{suite.userId, addCommand}, []interface{}{expectedIncomeSource, nil}, 1)
+
+	c, rec := suite.mockRequest(http.MethodPost, `{"name":"Salary"}`)
+	handler := incomesource.NewHandler(suite.incomeSourceServiceMock, suite.getValidator())
+
+	bodyBytes, _ := json.Marshal(incomesource.AddIncomeSourceResponse{IncomeSource: incomesource.Body{ID: expectedIncomeSource.Id().String(), Name: expectedIncomeSource.Name()}})
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+		assert.Equal(suite.T(), string(bodyBytes)+"\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnIncomeSourceWithEmptyName_WhenAdd_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequest(http.MethodPost, `{"name":""}`)
+	handler := incomesource.NewHandler(suite.incomeSourceServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, incomesource.FieldValidationErrorMessage, incomesource.FieldValidationErrorMessage, "[{\"field\":\"Name\",\"message\":\"Name is a required field\"}]", rest.FieldValidationErrorCode)
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGetAllSuccess() {
+	incomeSource1, _ := models.NewIncomeSource("Salary")
+	incomeSource2, _ := models.NewIncomeSource("Freelance")
+	suite.incomeSourceServiceMock.MockGetAll([]interface{}{suite.userId}, []interface{}{[]*models.IncomeSource{incomeSource1, incomeSource2}, nil}, 1)
+
+	c, rec := suite.mockRequest(http.MethodGet, "")
+	handler := incomesource.NewHandler(suite.incomeSourceServiceMock, suite.getValidator())
+
+	bodyBytes, _ := json.Marshal(incomesource.GetAllResponse{IncomeSources: []incomesource.Body{
+		{ID: incomeSource1.Id().String(), Name: incomeSource1.Name()},
+		{ID: incomeSource2.Id().String(), Name: incomeSource2.Name()},
+	}})
+	if assert.NoError(suite.T(), handler.GetAll(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), string(bodyBytes)+"\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) getValidator() fieldvalidation.FieldsValidator {
+	validator, _ := fieldvalidation.RegisterFieldsValidator(nil, nil)
+	return validator
+}
+
+func (suite *HandlerTestSuite) mockRequest(method string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, "/income-sources", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	return c, rec
+}
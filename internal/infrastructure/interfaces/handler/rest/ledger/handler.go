@@ -0,0 +1,231 @@
+package ledger
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/ledger"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/pkg/fieldvalidation"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+const (
+	FieldValidationErrorMessage = "some fields are invalid"
+	BodyIsInvalidErrorMessage   = "body is invalid"
+	InvalidIdErrorMessage       = "id path param is invalid, it must be a valid UUID"
+	UnexpectedErrorMessage      = "unexpected error"
+)
+
+type Handler interface {
+	Add(context echo.Context) error
+	GetAll(context echo.Context) error
+	GetMembers(context echo.Context) error
+	Invite(context echo.Context) error
+	AcceptInvitation(context echo.Context) error
+}
+
+type handler struct {
+	service         ledger.Service
+	fieldsValidator fieldvalidation.FieldsValidator
+}
+
+func NewHandler(service ledger.Service, fieldsValidator fieldvalidation.FieldsValidator) *handler {
+	return &handler{service: service, fieldsValidator: fieldsValidator}
+}
+
+func (h handler) Add(context echo.Context) error {
+	requestBody := new(AddLedgerRequest)
+
+	if err := context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := ledger.NewAddCommand(requestBody.Name)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	addedLedger, err := h.service.Add(context.Request().Context(), rest.UserId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusCreated, AddLedgerResponse{Ledger: h.mapLedgerToLedgerBody(addedLedger)})
+}
+
+func (h handler) GetAll(context echo.Context) error {
+	ledgers, err := h.service.GetAll(context.Request().Context(), rest.UserId(context))
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	ledgerBodies := []Body{}
+	for _, storedLedger := range ledgers {
+		ledgerBodies = append(ledgerBodies, h.mapLedgerToLedgerBody(storedLedger))
+	}
+
+	return context.JSON(http.StatusOK, GetAllResponse{Ledgers: ledgerBodies})
+}
+
+func (h handler) GetMembers(context echo.Context) error {
+	id, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	members, err := h.service.GetMembers(context.Request().Context(), rest.UserId(context), id)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	memberBodies := []MemberBody{}
+	for _, member := range members {
+		memberBodies = append(memberBodies, h.mapLedgerMemberToMemberBody(member))
+	}
+
+	return context.JSON(http.StatusOK, GetMembersResponse{Members: memberBodies})
+}
+
+func (h handler) Invite(context echo.Context) error {
+	id, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	requestBody := new(InviteRequest)
+
+	if err = context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := ledger.NewInviteCommand(id, requestBody.Role)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	issuedInvitation, err := h.service.Invite(context.Request().Context(), rest.UserId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusCreated, InviteResponse{
+		LedgerID:  issuedInvitation.Invitation.LedgerId().String(),
+		Role:      string(issuedInvitation.Invitation.Role()),
+		Token:     issuedInvitation.Token,
+		ExpiresAt: issuedInvitation.Invitation.ExpiresAt().Format(time.RFC3339),
+	})
+}
+
+func (h handler) AcceptInvitation(context echo.Context) error {
+	requestBody := new(AcceptInvitationRequest)
+
+	if err := context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := ledger.NewAcceptInvitationCommand(requestBody.Token)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	member, err := h.service.AcceptInvitation(context.Request().Context(), rest.UserId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusOK, h.mapLedgerMemberToMemberBody(member))
+}
+
+func (h handler) manageServiceError(ctx echo.Context, err error) error {
+	if errors.As(err, &ledger.LedgerNotFoundError{}) {
+		return h.buildErrorResponse(ctx, http.StatusNotFound, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.ForbiddenError{}) {
+		return h.buildErrorResponse(ctx, http.StatusForbidden, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.MemberAlreadyExistsError{}) {
+		return h.buildErrorResponse(ctx, http.StatusConflict, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.InvalidInvitationError{}) || errors.As(err, &ledger.PersonalLedgerError{}) || errors.As(err, &ledger.InvalidDomainModelError{}) {
+		return h.buildErrorResponse(ctx, http.StatusBadRequest, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if statusCode, msg, ok := rest.EndedRequestError(ctx); ok {
+		return h.buildErrorResponse(ctx, statusCode, msg, err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else {
+		return h.buildErrorResponse(ctx, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+}
+
+func (h handler) buildErrorResponse(ctx echo.Context, statusCode int, errorMessage string, errorDetail string, fieldErrors []fieldvalidation.FieldError, errorCode uint) error {
+	errorResponse := rest.ErrorResponse{StatusCode: statusCode, Msg: errorMessage, ErrorDetail: errorDetail, FieldErrors: fieldErrors, ErrorCode: errorCode}
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+func (h handler) mapLedgerToLedgerBody(ledger *models.Ledger) Body {
+	return Body{
+		ID:   ledger.Id().String(),
+		Name: ledger.Name(),
+	}
+}
+
+func (h handler) mapLedgerMemberToMemberBody(member *models.LedgerMember) MemberBody {
+	return MemberBody{
+		LedgerID: member.LedgerId().String(),
+		UserID:   member.UserId().String(),
+		Role:     string(member.Role()),
+	}
+}
+
+type AddLedgerRequest struct {
+	Name string `json:"name,omitempty" validate:"required,min=3,max=50"`
+}
+
+type InviteRequest struct {
+	Role string `json:"role,omitempty" validate:"required,oneof=editor viewer"`
+}
+
+type AcceptInvitationRequest struct {
+	Token string `json:"token,omitempty" validate:"required"`
+}
+
+type AddLedgerResponse struct {
+	Ledger Body `json:"ledger"`
+}
+
+type GetAllResponse struct {
+	Ledgers []Body `json:"ledgers"`
+}
+
+type GetMembersResponse struct {
+	Members []MemberBody `json:"members"`
+}
+
+// InviteResponse is the only time the token is shown, it can't be recovered later.
+type InviteResponse struct {
+	LedgerID  string `json:"ledger_id"`
+	Role      string `json:"role"`
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type Body struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type MemberBody struct {
+	LedgerID string `json:"ledger_id"`
+	UserID   string `json:"user_id"`
+	Role     string `json:"role"`
+}
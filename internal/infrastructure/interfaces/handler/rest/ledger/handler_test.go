@@ -0,0 +1,152 @@
+package ledger_test
+
+import (
+	"encoding/json"
+	"finfit-backend/internal/domain/models"
+	ledgerService "finfit-backend/internal/domain/services/ledger"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/ledger"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	errorResponse = `{"status_code":%d,"msg":"%s","error_detail":"%v","field_errors":%v,"error_code":%d}
+`
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+	userId            uuid.UUID
+	ledgerId          uuid.UUID
+	ledgerServiceMock *ledgerService.ServiceMock
+}
+
+func (suite *HandlerTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.ledgerId = uuid.New()
+	suite.ledgerServiceMock = ledgerService.NewServiceMock()
+}
+
+func (suite *HandlerTestSuite) TearDownTest() {
+	suite.ledgerServiceMock.ExpectedCalls = nil
+	suite.ledgerServiceMock.Calls = nil
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (suite *HandlerTestSuite) TestGivenALedgerToAdd_WhenAdd_ThenReturnStatusCreatedWithAddedLedger() {
+	expectedLedger, _ := models.NewLedgerWithId(suite.ledgerId, "Home")
+	command, _ := ledgerService.NewAddCommand("Home")
+	suite.ledgerServiceMock.MockAdd([]interface{}{suite.userId, command}, []interface{}{expectedLedger, nil}, 1)
+
+	c, rec := suite.mockRequest(http.MethodPost, "/ledgers", `{"name":"Home"}`)
+	handler := ledger.NewHandler(suite.ledgerServiceMock, suite.getValidator())
+
+	bodyBytes, _ := json.Marshal(ledger.AddLedgerResponse{Ledger: ledger.Body{ID: suite.ledgerId.String(), Name: "Home"}})
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+		assert.Equal(suite.T(), string(bodyBytes)+"\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnInvalidId_WhenGetMembers_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequestWithId(http.MethodGet, "/ledgers/:id/members", "invalid", "")
+	handler := ledger.NewHandler(suite.ledgerServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.GetMembers(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		suite.ledgerServiceMock.AssertNotCalled(suite.T(), "GetMembers", mock.Anything, mock.Anything)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenTheOwner_WhenInvite_ThenReturnStatusCreatedWithTheToken() {
+	expiresAt := time.Date(2022, 3, 8, 10, 0, 0, 0, time.UTC)
+	invitation, _ := models.NewLedgerInvitation(suite.ledgerId, models.EditorLedgerRole, "hash", expiresAt)
+	command, _ := ledgerService.NewInviteCommand(suite.ledgerId, "editor")
+	issuedInvitation := &ledgerService.IssuedInvitation{Invitation: invitation, Token: "token"}
+	suite.ledgerServiceMock.MockInvite([]interface{}{suite.userId, command}, []interface{}{issuedInvitation, nil}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodPost, "/ledgers/:id/invitations", suite.ledgerId.String(), `{"role":"editor"}`)
+	handler := ledger.NewHandler(suite.ledgerServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(`{"ledger_id":"%s","role":"editor","token":"token","expires_at":"2022-03-08T10:00:00Z"}`+"\n", suite.ledgerId.String())
+	if assert.NoError(suite.T(), handler.Invite(c)) {
+		assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnEditor_WhenInvite_ThenReturnStatusForbidden() {
+	command, _ := ledgerService.NewInviteCommand(suite.ledgerId, "viewer")
+	serviceError := ledgerService.ForbiddenError{Msg: "your role in the ledger doesn't allow it"}
+	suite.ledgerServiceMock.MockInvite([]interface{}{suite.userId, command}, []interface{}{nil, serviceError}, 1)
+
+	c, rec := suite.mockRequestWithId(http.MethodPost, "/ledgers/:id/invitations", suite.ledgerId.String(), `{"role":"viewer"}`)
+	handler := ledger.NewHandler(suite.ledgerServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusForbidden, serviceError.Msg, serviceError.Msg, "[]", 0)
+	if assert.NoError(suite.T(), handler.Invite(c)) {
+		assert.Equal(suite.T(), http.StatusForbidden, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenTheOwnerRole_WhenInvite_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequestWithId(http.MethodPost, "/ledgers/:id/invitations", suite.ledgerId.String(), `{"role":"owner"}`)
+	handler := ledger.NewHandler(suite.ledgerServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Invite(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		suite.ledgerServiceMock.AssertNotCalled(suite.T(), "Invite", mock.Anything, mock.Anything)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAMember_WhenAcceptInvitation_ThenReturnStatusConflict() {
+	command, _ := ledgerService.NewAcceptInvitationCommand("token")
+	serviceError := ledgerService.MemberAlreadyExistsError{Msg: "the user is already a member of the ledger"}
+	suite.ledgerServiceMock.MockAcceptInvitation([]interface{}{suite.userId, command}, []interface{}{nil, serviceError}, 1)
+
+	c, rec := suite.mockRequest(http.MethodPost, "/ledgers/invitations/accept", `{"token":"token"}`)
+	handler := ledger.NewHandler(suite.ledgerServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusConflict, serviceError.Msg, serviceError.Msg, "[]", 0)
+	if assert.NoError(suite.T(), handler.AcceptInvitation(c)) {
+		assert.Equal(suite.T(), http.StatusConflict, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) getValidator() fieldvalidation.FieldsValidator {
+	validator, _ := fieldvalidation.RegisterFieldsValidator(nil, nil)
+	return validator
+}
+
+func (suite *HandlerTestSuite) mockRequest(method string, path string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	return c, rec
+}
+
+func (suite *HandlerTestSuite) mockRequestWithId(method string, path string, id string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	c, rec := suite.mockRequest(method, path, body)
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	return c, rec
+}
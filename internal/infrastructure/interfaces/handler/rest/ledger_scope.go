@@ -0,0 +1,49 @@
+package rest
+
+import (
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/pkg/fieldvalidation"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+const (
+	InvalidLedgerIdErrorMessage = "the ledger_id query param must be a valid UUID"
+	ledgerIdQueryParam          = "ledger_id"
+	ledgerIdContextKey          = "ledger_id"
+)
+
+// LedgerScope reads the ledger the request works on from the ledger_id query param. Requests without it work on the
+// personal ledger of the user. Whether the user can access the ledger is checked by the services.
+func LedgerScope() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(context echo.Context) error {
+			rawLedgerId := context.QueryParam(ledgerIdQueryParam)
+			if rawLedgerId == "" {
+				return next(context)
+			}
+
+			ledgerId, err := uuid.Parse(rawLedgerId)
+			if err != nil {
+				errorResponse := ErrorResponse{StatusCode: http.StatusBadRequest, Msg: InvalidLedgerIdErrorMessage, ErrorDetail: err.Error(), FieldErrors: []fieldvalidation.FieldError{}}
+				return context.JSON(http.StatusBadRequest, errorResponse)
+			}
+
+			SetLedgerId(context, ledgerId)
+			return next(context)
+		}
+	}
+}
+
+// LedgerId returns the ledger chosen with LedgerScope, or the personal ledger of the authenticated user.
+func LedgerId(context echo.Context) uuid.UUID {
+	if ledgerId, ok := context.Get(ledgerIdContextKey).(uuid.UUID); ok {
+		return ledgerId
+	}
+	return models.PersonalLedgerId(UserId(context))
+}
+
+func SetLedgerId(context echo.Context, ledgerId uuid.UUID) {
+	context.Set(ledgerIdContextKey, ledgerId)
+}
@@ -0,0 +1,69 @@
+package rest_test
+
+import (
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGivenNoLedgerId_WhenLedgerScope_ThenCallTheHandlerWithThePersonalLedger(t *testing.T) {
+	userId := uuid.New()
+	c, rec := mockLedgerScopedRequest(userId, "")
+
+	handledLedgerId := callLedgerScope(t, c)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, models.PersonalLedgerId(userId), handledLedgerId)
+}
+
+func TestGivenALedgerId_WhenLedgerScope_ThenCallTheHandlerWithIt(t *testing.T) {
+	ledgerId := uuid.New()
+	c, rec := mockLedgerScopedRequest(uuid.New(), ledgerId.String())
+
+	handledLedgerId := callLedgerScope(t, c)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, ledgerId, handledLedgerId)
+}
+
+func TestGivenAnInvalidLedgerId_WhenLedgerScope_ThenReturnStatusBadRequest(t *testing.T) {
+	c, rec := mockLedgerScopedRequest(uuid.New(), "home")
+
+	err := rest.LedgerScope()(func(context echo.Context) error {
+		t.Fatal("the handler must not be called with an invalid ledger id")
+		return nil
+	})(c)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), rest.InvalidLedgerIdErrorMessage)
+}
+
+func callLedgerScope(t *testing.T, c echo.Context) uuid.UUID {
+	var handledLedgerId uuid.UUID
+	err := rest.LedgerScope()(func(context echo.Context) error {
+		handledLedgerId = rest.LedgerId(context)
+		return context.NoContent(http.StatusNoContent)
+	})(c)
+	require.NoError(t, err)
+	return handledLedgerId
+}
+
+func mockLedgerScopedRequest(userId uuid.UUID, ledgerId string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	target := "/expenses"
+	if ledgerId != "" {
+		target = target + "?ledger_id=" + ledgerId
+	}
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, userId)
+	return c, rec
+}
@@ -0,0 +1,295 @@
+package recurringexpense
+
+import (
+	"encoding/json"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/recurringexpense"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/pkg/fieldvalidation"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+const (
+	FieldValidationErrorMessage          = "some fields are invalid"
+	BodyIsInvalidErrorMessage            = "body is invalid"
+	ParamsAreInvalidErrorMessage         = "params are invalid, query param date must have the format YYYY-MM-DD"
+	InvalidIdErrorMessage                = "id path param is invalid, it must be a valid UUID"
+	RecurringExpenseNotFoundErrorMessage = "the recurring expense doesn't exists"
+	UnexpectedErrorMessage               = "unexpected error"
+	DateFormat                           = "2006-01-02"
+)
+
+type Handler interface {
+	Add(context echo.Context) error
+	GetAll(context echo.Context) error
+	GetById(context echo.Context) error
+	Delete(context echo.Context) error
+	Generate(context echo.Context) error
+}
+
+type handler struct {
+	service         recurringexpense.Service
+	fieldsValidator fieldvalidation.FieldsValidator
+}
+
+func NewHandler(service recurringexpense.Service, fieldsValidator fieldvalidation.FieldsValidator) *handler {
+	return &handler{service: service, fieldsValidator: fieldsValidator}
+}
+
+func (h handler) Add(context echo.Context) error {
+	requestBody := new(AddRecurringExpenseRequest)
+
+	if err := context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := h.mapAddCommandFromRequestBody(*requestBody)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	addedRecurringExpense, err := h.service.Add(context.Request().Context(), rest.UserId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusCreated, Response{RecurringExpense: h.mapRecurringExpenseToBody(addedRecurringExpense)})
+}
+
+func (h handler) GetAll(context echo.Context) error {
+	recurringExpenses, err := h.service.GetAll(context.Request().Context(), rest.UserId(context))
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	recurringExpenseBodies := []Body{}
+	for _, recurringExpense := range recurringExpenses {
+		recurringExpenseBodies = append(recurringExpenseBodies, h.mapRecurringExpenseToBody(recurringExpense))
+	}
+
+	return context.JSON(http.StatusOK, GetAllResponse{RecurringExpenses: recurringExpenseBodies})
+}
+
+func (h handler) GetById(context echo.Context) error {
+	id, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	storedRecurringExpense, err := h.service.GetById(context.Request().Context(), rest.UserId(context), id)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	if storedRecurringExpense == nil {
+		return h.buildErrorResponse(context, http.StatusNotFound, RecurringExpenseNotFoundErrorMessage, RecurringExpenseNotFoundErrorMessage, []fieldvalidation.FieldError{}, 0)
+	}
+
+	return context.JSON(http.StatusOK, Response{RecurringExpense: h.mapRecurringExpenseToBody(storedRecurringExpense)})
+}
+
+func (h handler) Delete(context echo.Context) error {
+	id, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if err = h.service.Delete(context.Request().Context(), rest.UserId(context), id); err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.NoContent(http.StatusNoContent)
+}
+
+// Generate materializes the occurrences due up to the date query param, or up to today when it isn't sent. It is
+// safe to call it many times for the same date.
+func (h handler) Generate(context echo.Context) error {
+	requestParams := new(GenerateQueryParams)
+
+	// Bind only reads query params on GET and DELETE requests
+	if err := (&echo.DefaultBinder{}).BindQueryParams(context, requestParams); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, ParamsAreInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestParams); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	until := time.Now()
+	if requestParams.Date != "" {
+		until, _ = time.Parse(DateFormat, requestParams.Date)
+	}
+
+	command, err := recurringexpense.NewGenerateCommand(until)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, ParamsAreInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	generatedExpenses, err := h.service.Generate(context.Request().Context(), rest.UserId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	generatedExpenseBodies := []GeneratedExpenseBody{}
+	for _, generatedExpense := range generatedExpenses {
+		generatedExpenseBodies = append(generatedExpenseBodies, GeneratedExpenseBody{
+			ID:          generatedExpense.Id().String(),
+			Amount:      mapMoney(generatedExpense.Amount()),
+			ExpenseDate: generatedExpense.ExpenseDate().Format(DateFormat),
+			Description: generatedExpense.Description(),
+			ExpenseType: TypeBody{ID: generatedExpense.ExpenseType().Id().String(), Name: generatedExpense.ExpenseType().Name()},
+		})
+	}
+
+	return context.JSON(http.StatusOK, GenerateResponse{Expenses: generatedExpenseBodies})
+}
+
+func (h handler) mapAddCommandFromRequestBody(body AddRecurringExpenseRequest) (*recurringexpense.AddCommand, error) {
+	expenseTypeId, err := uuid.Parse(body.ExpenseType.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, _ := time.Parse(DateFormat, body.Schedule.StartDate)
+	var endDate time.Time
+	if body.Schedule.EndDate != "" {
+		endDate, _ = time.Parse(DateFormat, body.Schedule.EndDate)
+	}
+
+	interval := body.Schedule.Interval
+	if interval == 0 {
+		interval = 1
+	}
+
+	return recurringexpense.NewAddCommand(body.Amount.Amount.String(), body.Amount.Currency, body.Description, expenseTypeId,
+		body.Schedule.Frequency, interval, startDate, endDate, body.Schedule.Count)
+}
+
+func (h handler) manageServiceError(ctx echo.Context, err error) error {
+	if errors.As(err, &recurringexpense.InvalidExpenseTypeError{}) || errors.As(err, &recurringexpense.InvalidDomainModelError{}) {
+		return h.buildErrorResponse(ctx, http.StatusBadRequest, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &recurringexpense.RecurringExpenseNotFoundError{}) {
+		return h.buildErrorResponse(ctx, http.StatusNotFound, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if statusCode, msg, ok := rest.EndedRequestError(ctx); ok {
+		return h.buildErrorResponse(ctx, statusCode, msg, err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else {
+		return h.buildErrorResponse(ctx, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+}
+
+func (h handler) buildErrorResponse(ctx echo.Context, statusCode int, errorMessage string, errorDetail string, fieldErrors []fieldvalidation.FieldError, errorCode uint) error {
+	errorResponse := rest.ErrorResponse{StatusCode: statusCode, Msg: errorMessage, ErrorDetail: errorDetail, FieldErrors: fieldErrors, ErrorCode: errorCode}
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+func (h handler) mapRecurringExpenseToBody(recurringExpense *models.RecurringExpense) Body {
+	schedule := recurringExpense.Schedule()
+	body := Body{
+		ID:          recurringExpense.Id().String(),
+		Amount:      mapMoney(recurringExpense.Amount()),
+		Description: recurringExpense.Description(),
+		ExpenseType: TypeBody{
+			ID:   recurringExpense.ExpenseType().Id().String(),
+			Name: recurringExpense.ExpenseType().Name(),
+		},
+		Schedule: ScheduleBody{
+			Frequency: string(schedule.Frequency()),
+			Interval:  schedule.Interval(),
+			StartDate: schedule.StartDate().Format(DateFormat),
+			Count:     schedule.Count(),
+		},
+	}
+
+	if !schedule.EndDate().IsZero() {
+		body.Schedule.EndDate = schedule.EndDate().Format(DateFormat)
+	}
+
+	if !recurringExpense.LastOccurrence().IsZero() {
+		body.LastOccurrence = recurringExpense.LastOccurrence().Format(DateFormat)
+	}
+
+	return body
+}
+
+func mapMoney(money *models.Money) Money {
+	return Money{Amount: json.Number(money.Amount()), Currency: money.Currency()}
+}
+
+type AddRecurringExpenseRequest struct {
+	Amount      Money                                  `json:"amount"`
+	Description string                                 `json:"description,omitempty" validate:"max=40"`
+	ExpenseType *AddRecurringExpenseRequestExpenseType `json:"expense_type,omitempty" validate:"required"`
+	Schedule    ScheduleRequest                        `json:"schedule"`
+}
+
+type AddRecurringExpenseRequestExpenseType struct {
+	ID string `json:"id" validate:"required,uuid"`
+}
+
+// ScheduleRequest defaults interval to 1. end_date and count are optional and mutually exclusive.
+type ScheduleRequest struct {
+	Frequency string `json:"frequency,omitempty" validate:"required,oneof=daily weekly monthly yearly"`
+	Interval  int    `json:"interval,omitempty" validate:"gte=0"`
+	StartDate string `json:"start_date,omitempty" validate:"required,datetime=2006-01-02"`
+	EndDate   string `json:"end_date,omitempty" validate:"omitempty,datetime=2006-01-02,excluded_with=Count"`
+	Count     int    `json:"count,omitempty" validate:"gte=0"`
+}
+
+type GenerateQueryParams struct {
+	Date string `query:"date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type Response struct {
+	RecurringExpense Body `json:"recurring_expense"`
+}
+
+type GetAllResponse struct {
+	RecurringExpenses []Body `json:"recurring_expenses"`
+}
+
+type GenerateResponse struct {
+	Expenses []GeneratedExpenseBody `json:"expenses"`
+}
+
+type Body struct {
+	ID             string       `json:"id"`
+	Amount         Money        `json:"amount"`
+	Description    string       `json:"description"`
+	ExpenseType    TypeBody     `json:"expense_type"`
+	Schedule       ScheduleBody `json:"schedule"`
+	LastOccurrence string       `json:"last_occurrence,omitempty"`
+}
+
+type ScheduleBody struct {
+	Frequency string `json:"frequency"`
+	Interval  int    `json:"interval"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date,omitempty"`
+	Count     int    `json:"count,omitempty"`
+}
+
+type GeneratedExpenseBody struct {
+	ID          string   `json:"id"`
+	Amount      Money    `json:"amount"`
+	ExpenseDate string   `json:"expense_date"`
+	Description string   `json:"description"`
+	ExpenseType TypeBody `json:"expense_type"`
+}
+
+type TypeBody struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Money struct {
+	Amount   json.Number `json:"amount" validate:"required,positiveDecimal"`
+	Currency string      `json:"currency" validate:"iso4217"`
+}
@@ -0,0 +1,122 @@
+package recurringexpense_test
+
+import (
+	"encoding/json"
+	"finfit-backend/internal/domain/models"
+	recurringExpenseService "finfit-backend/internal/domain/services/recurringexpense"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/recurringexpense"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	errorResponse = `{"status_code":%d,"msg":"%s","error_detail":"%v","field_errors":%v,"error_code":%d}
+`
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+	userId                      uuid.UUID
+	recurringExpenseServiceMock *recurringExpenseService.ServiceMock
+}
+
+func (suite *HandlerTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.recurringExpenseServiceMock = recurringExpenseService.NewServiceMock()
+}
+
+func (suite *HandlerTestSuite) TearDownTest() {
+	suite.recurringExpenseServiceMock.ExpectedCalls = nil
+	suite.recurringExpenseServiceMock.Calls = nil
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (suite *HandlerTestSuite) TestGivenARecurringExpenseToAdd_WhenAdd_ThenReturnStatusCreated() {
+	expenseType, _ := models.NewExpenseTypeWithId(uuid.New(), "Rent")
+	amount, _ := models.NewMoney("800", "EUR")
+	schedule, _ := models.NewRecurrenceRule(models.MonthlyRecurrenceFrequency, 1, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}, 12)
+	expectedRecurringExpense, _ := models.NewRecurringExpenseWithId(uuid.New(), amount, "Rent", expenseType, schedule, time.Time{})
+	command, _ := recurringExpenseService.NewAddCommand("800", "EUR", "Rent", expenseType.Id(), "monthly", 1, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}, 12)
+	suite.recurringExpenseServiceMock.MockAdd([]interface{}{suite.userId, command}, []interface{}{expectedRecurringExpense, nil}, 1)
+
+	requestBody := fmt.Sprintf(`{"amount":{"amount":800,"currency":"EUR"},"description":"Rent","expense_type":{"id":"%s"},"schedule":{"frequency":"monthly","start_date":"2022-01-01","count":12}}`, expenseType.Id().String())
+	c, rec := suite.mockRequest(http.MethodPost, "/recurring-expenses", requestBody)
+	handler := recurringexpense.NewHandler(suite.recurringExpenseServiceMock, suite.getValidator())
+
+	bodyBytes, _ := json.Marshal(recurringexpense.Response{RecurringExpense: recurringexpense.Body{
+		ID:          expectedRecurringExpense.Id().String(),
+		Amount:      recurringexpense.Money{Amount: "800.00", Currency: "EUR"},
+		Description: "Rent",
+		ExpenseType: recurringexpense.TypeBody{ID: expenseType.Id().String(), Name: "Rent"},
+		Schedule:    recurringexpense.ScheduleBody{Frequency: "monthly", Interval: 1, StartDate: "2022-01-01", Count: 12},
+	}})
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+		assert.Equal(suite.T(), string(bodyBytes)+"\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnInvalidFrequency_WhenAdd_ThenReturnStatusBadRequest() {
+	requestBody := fmt.Sprintf(`{"amount":{"amount":800,"currency":"EUR"},"expense_type":{"id":"%s"},"schedule":{"frequency":"hourly","start_date":"2022-01-01"}}`, uuid.New().String())
+	c, rec := suite.mockRequest(http.MethodPost, "/recurring-expenses", requestBody)
+	handler := recurringexpense.NewHandler(suite.recurringExpenseServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, recurringexpense.FieldValidationErrorMessage, recurringexpense.FieldValidationErrorMessage, "[{\"field\":\"Frequency\",\"message\":\"Frequency must be one of [daily weekly monthly yearly]\"}]", rest.FieldValidationErrorCode)
+	if assert.NoError(suite.T(), handler.Add(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+	suite.recurringExpenseServiceMock.AssertNotCalled(suite.T(), "Add", mock.Anything, suite.userId, mock.Anything)
+}
+
+func (suite *HandlerTestSuite) TestGivenADate_WhenGenerate_ThenReturnStatusOkWithGeneratedExpenses() {
+	expenseType, _ := models.NewExpenseTypeWithId(uuid.New(), "Rent")
+	amount, _ := models.NewMoney("800", "EUR")
+	generatedExpense, _ := models.NewExpenseWithId(uuid.New(), amount, time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), "Rent", expenseType)
+	command, _ := recurringExpenseService.NewGenerateCommand(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	suite.recurringExpenseServiceMock.MockGenerate([]interface{}{suite.userId, command}, []interface{}{[]*models.Expense{generatedExpense}, nil}, 1)
+
+	c, rec := suite.mockRequest(http.MethodPost, "/recurring-expenses/generate?date=2022-03-01", "")
+	handler := recurringexpense.NewHandler(suite.recurringExpenseServiceMock, suite.getValidator())
+
+	bodyBytes, _ := json.Marshal(recurringexpense.GenerateResponse{Expenses: []recurringexpense.GeneratedExpenseBody{{
+		ID:          generatedExpense.Id().String(),
+		Amount:      recurringexpense.Money{Amount: "800.00", Currency: "EUR"},
+		ExpenseDate: "2022-03-01",
+		Description: "Rent",
+		ExpenseType: recurringexpense.TypeBody{ID: expenseType.Id().String(), Name: "Rent"},
+	}}})
+	if assert.NoError(suite.T(), handler.Generate(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), string(bodyBytes)+"\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) getValidator() fieldvalidation.FieldsValidator {
+	validator, _ := fieldvalidation.RegisterFieldsValidator(nil, nil)
+	return validator
+}
+
+func (suite *HandlerTestSuite) mockRequest(method string, path string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	return c, rec
+}
@@ -0,0 +1,203 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/ledger"
+	"finfit-backend/internal/domain/services/report"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/expense"
+	"finfit-backend/pkg/fieldvalidation"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+const (
+	FieldValidationErrorMessage  = "some fields are invalid"
+	ParamsAreInvalidErrorMessage = "params are invalid, query params start_date, end_date and group_by are required"
+	UnexpectedErrorMessage       = "unexpected error"
+	DateFormat                   = "2006-01-02"
+)
+
+type Handler interface {
+	GetSpending(context echo.Context) error
+}
+
+type handler struct {
+	service         report.Service
+	fieldsValidator fieldvalidation.FieldsValidator
+}
+
+func NewHandler(service report.Service, fieldsValidator fieldvalidation.FieldsValidator) *handler {
+	return &handler{service: service, fieldsValidator: fieldsValidator}
+}
+
+func (h handler) GetSpending(context echo.Context) error {
+	requestParams := new(SpendingQueryParams)
+
+	if err := context.Bind(requestParams); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, ParamsAreInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestParams); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	startDate, _ := time.Parse(DateFormat, requestParams.StartDate)
+	endDate, _ := time.Parse(DateFormat, requestParams.EndDate)
+	command, err := report.NewGetSpendingCommand(startDate, endDate, requestParams.GroupBy, requestParams.Currency)
+	if err == nil {
+		command, err = command.WithTargetCurrency(requestParams.TargetCurrency)
+	}
+	if err == nil && requestParams.ExpenseTypeID != "" {
+		var expenseTypeId uuid.UUID
+		if expenseTypeId, err = uuid.Parse(requestParams.ExpenseTypeID); err == nil {
+			command = command.WithExpenseType(expenseTypeId)
+		}
+	}
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, ParamsAreInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	spendingReport, err := h.service.GetSpending(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusOK, h.mapSpendingReportToResponse(*requestParams, spendingReport))
+}
+
+func (h handler) manageServiceError(ctx echo.Context, err error) error {
+	if errors.As(err, &report.InvalidExpenseTypeError{}) {
+		return h.buildErrorResponse(ctx, http.StatusBadRequest, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.LedgerNotFoundError{}) {
+		return h.buildErrorResponse(ctx, http.StatusNotFound, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.ForbiddenError{}) {
+		return h.buildErrorResponse(ctx, http.StatusForbidden, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if statusCode, msg, ok := rest.EndedRequestError(ctx); ok {
+		return h.buildErrorResponse(ctx, statusCode, msg, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+	return h.buildErrorResponse(ctx, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+}
+
+func (h handler) buildErrorResponse(ctx echo.Context, statusCode int, errorMessage string, errorDetail string, fieldErrors []fieldvalidation.FieldError, errorCode uint) error {
+	errorResponse := rest.ErrorResponse{StatusCode: statusCode, Msg: errorMessage, ErrorDetail: errorDetail, FieldErrors: fieldErrors, ErrorCode: errorCode}
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+func (h handler) mapSpendingReportToResponse(params SpendingQueryParams, spendingReport *models.SpendingReport) SpendingResponse {
+	currencyBodies := []CurrencySpendingBody{}
+	for _, currencySpending := range spendingReport.Currencies() {
+		currencyBodies = append(currencyBodies, h.mapCurrencySpendingToBody(currencySpending))
+	}
+
+	response := SpendingResponse{
+		StartDate:  params.StartDate,
+		EndDate:    params.EndDate,
+		GroupBy:    params.GroupBy,
+		Currencies: currencyBodies,
+	}
+
+	if params.TargetCurrency == "" {
+		return response
+	}
+
+	response.Conversion = &ConversionBody{TargetCurrency: params.TargetCurrency, ExchangeRates: []ExchangeRateBody{}, MissingRates: []MissingRateBody{}}
+	for _, rate := range spendingReport.Rates() {
+		response.Conversion.ExchangeRates = append(response.Conversion.ExchangeRates, ExchangeRateBody{
+			BaseCurrency:  rate.BaseCurrency(),
+			QuoteCurrency: rate.QuoteCurrency(),
+			Date:          rate.Date().Format(DateFormat),
+			Rate:          json.Number(rate.Rate()),
+		})
+	}
+
+	for _, missingRate := range spendingReport.MissingRates() {
+		response.Conversion.MissingRates = append(response.Conversion.MissingRates, MissingRateBody{
+			Currency: missingRate.Currency(),
+			Date:     missingRate.Date().Format(DateFormat),
+		})
+	}
+
+	return response
+}
+
+func (h handler) mapCurrencySpendingToBody(spending *models.CurrencySpending) CurrencySpendingBody {
+	groupBodies := []GroupBody{}
+	for _, group := range spending.Groups() {
+		groupBodies = append(groupBodies, GroupBody{
+			Key:     group.Key(),
+			Label:   group.Label(),
+			Total:   json.Number(group.Total().Amount()),
+			Count:   group.Count(),
+			Average: json.Number(group.Average().Amount()),
+			Share:   group.Share(),
+		})
+	}
+
+	return CurrencySpendingBody{
+		Currency: spending.Currency(),
+		Total:    json.Number(spending.Total().Amount()),
+		Count:    spending.Count(),
+		Average:  json.Number(spending.Average().Amount()),
+		Groups:   groupBodies,
+	}
+}
+
+// SpendingQueryParams reuses the period params of the expense search, so the dates are validated the same way.
+type SpendingQueryParams struct {
+	expense.PeriodQueryParams
+	// ExpenseTypeID also matches the expenses of every subtype of the expense type.
+	ExpenseTypeID string `query:"expense_type_id" validate:"omitempty,uuid"`
+	GroupBy       string `query:"group_by" validate:"required,oneof=expense_type day week month year"`
+	Currency      string `query:"currency" validate:"omitempty,iso4217"`
+}
+
+type SpendingResponse struct {
+	StartDate  string                 `json:"start_date"`
+	EndDate    string                 `json:"end_date"`
+	GroupBy    string                 `json:"group_by"`
+	Currencies []CurrencySpendingBody `json:"currencies"`
+	// Conversion is only present when a target currency was requested.
+	Conversion *ConversionBody `json:"conversion,omitempty"`
+}
+
+// ConversionBody lists the rates used to convert the report and the currencies and days without any rate, whose
+// expenses were left out of the totals.
+type ConversionBody struct {
+	TargetCurrency string             `json:"target_currency"`
+	ExchangeRates  []ExchangeRateBody `json:"exchange_rates"`
+	MissingRates   []MissingRateBody  `json:"missing_rates"`
+}
+
+type CurrencySpendingBody struct {
+	Currency string      `json:"currency"`
+	Total    json.Number `json:"total"`
+	Count    int64       `json:"count"`
+	Average  json.Number `json:"average"`
+	Groups   []GroupBody `json:"groups"`
+}
+
+type GroupBody struct {
+	Key     string      `json:"key"`
+	Label   string      `json:"label"`
+	Total   json.Number `json:"total"`
+	Count   int64       `json:"count"`
+	Average json.Number `json:"average"`
+	Share   float64     `json:"share"`
+}
+
+type ExchangeRateBody struct {
+	BaseCurrency  string      `json:"base_currency"`
+	QuoteCurrency string      `json:"quote_currency"`
+	Date          string      `json:"date"`
+	Rate          json.Number `json:"rate"`
+}
+
+type MissingRateBody struct {
+	Currency string `json:"currency"`
+	Date     string `json:"date"`
+}
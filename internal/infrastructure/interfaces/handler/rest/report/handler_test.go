@@ -0,0 +1,198 @@
+package report_test
+
+import (
+	"encoding/json"
+	"finfit-backend/internal/domain/models"
+	ledgerService "finfit-backend/internal/domain/services/ledger"
+	reportService "finfit-backend/internal/domain/services/report"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/report"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	errorResponse = `{"status_code":%d,"msg":"%s","error_detail":"%v","field_errors":%v,"error_code":%d}
+`
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+	userId            uuid.UUID
+	ledgerId          uuid.UUID
+	reportServiceMock *reportService.ServiceMock
+}
+
+func (suite *HandlerTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.ledgerId = uuid.New()
+	suite.reportServiceMock = reportService.NewServiceMock()
+}
+
+func (suite *HandlerTestSuite) TearDownTest() {
+	suite.reportServiceMock.ExpectedCalls = nil
+	suite.reportServiceMock.Calls = nil
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (suite *HandlerTestSuite) TestGivenAPeriod_WhenGetSpending_ThenReturnStatusOkWithTheReport() {
+	currencyTotal, _ := models.NewMoney("100", "EUR")
+	marchTotal, _ := models.NewMoney("25", "EUR")
+	aprilTotal, _ := models.NewMoney("75", "EUR")
+	march, _ := models.NewSpendingGroup("2022-03", "2022-03", marchTotal, 2, currencyTotal)
+	april, _ := models.NewSpendingGroup("2022-04", "2022-04", aprilTotal, 1, currencyTotal)
+	currencySpending, _ := models.NewCurrencySpending(currencyTotal, 3, []*models.SpendingGroup{march, april})
+	command, _ := reportService.NewGetSpendingCommand(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 4, 30, 0, 0, 0, 0, time.UTC), "month", "EUR")
+	spendingReport := models.NewSpendingReport([]*models.CurrencySpending{currencySpending}, []*models.ExchangeRate{}, []*models.MissingRate{})
+	suite.reportServiceMock.MockGetSpending([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{spendingReport, nil}, 1)
+
+	c, rec := suite.mockRequest(http.MethodGet, "/reports/spending?start_date=2022-03-01&end_date=2022-04-30&group_by=month&currency=EUR")
+	handler := report.NewHandler(suite.reportServiceMock, suite.getValidator())
+
+	bodyBytes, _ := json.Marshal(report.SpendingResponse{StartDate: "2022-03-01", EndDate: "2022-04-30", GroupBy: "month", Currencies: []report.CurrencySpendingBody{{
+		Currency: "EUR",
+		Total:    "100.00",
+		Count:    3,
+		Average:  "33.33",
+		Groups: []report.GroupBody{
+			{Key: "2022-03", Label: "2022-03", Total: "25.00", Count: 2, Average: "12.50", Share: 25},
+			{Key: "2022-04", Label: "2022-04", Total: "75.00", Count: 1, Average: "75.00", Share: 75},
+		},
+	}}})
+	if assert.NoError(suite.T(), handler.GetSpending(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), string(bodyBytes)+"\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenATargetCurrency_WhenGetSpending_ThenReturnTheRatesUsedAndTheMissingOnes() {
+	currencyTotal, _ := models.NewMoney("12", "USD")
+	group, _ := models.NewSpendingGroup("2022", "2022", currencyTotal, 4, currencyTotal)
+	currencySpending, _ := models.NewCurrencySpending(currencyTotal, 4, []*models.SpendingGroup{group})
+	rate, _ := models.NewExchangeRate("USD", "ARS", time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC), "108.5")
+	missingRate := models.NewMissingRate("EUR", time.Date(2022, 3, 5, 0, 0, 0, 0, time.UTC))
+	spendingReport := models.NewSpendingReport([]*models.CurrencySpending{currencySpending}, []*models.ExchangeRate{rate}, []*models.MissingRate{missingRate})
+	command, _ := reportService.NewGetSpendingCommand(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC), "year", "")
+	command, _ = command.WithTargetCurrency("USD")
+	suite.reportServiceMock.MockGetSpending([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{spendingReport, nil}, 1)
+
+	c, rec := suite.mockRequest(http.MethodGet, "/reports/spending?start_date=2022-01-01&end_date=2022-12-31&group_by=year&target_currency=USD")
+	handler := report.NewHandler(suite.reportServiceMock, suite.getValidator())
+
+	bodyBytes, _ := json.Marshal(report.SpendingResponse{StartDate: "2022-01-01", EndDate: "2022-12-31", GroupBy: "year",
+		Currencies: []report.CurrencySpendingBody{{
+			Currency: "USD",
+			Total:    "12.00",
+			Count:    4,
+			Average:  "3.00",
+			Groups:   []report.GroupBody{{Key: "2022", Label: "2022", Total: "12.00", Count: 4, Average: "3.00", Share: 100}},
+		}},
+		Conversion: &report.ConversionBody{
+			TargetCurrency: "USD",
+			ExchangeRates:  []report.ExchangeRateBody{{BaseCurrency: "USD", QuoteCurrency: "ARS", Date: "2022-03-04", Rate: "108.5"}},
+			MissingRates:   []report.MissingRateBody{{Currency: "EUR", Date: "2022-03-05"}},
+		},
+	})
+	if assert.NoError(suite.T(), handler.GetSpending(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), string(bodyBytes)+"\n", rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenThatStartDateIsAfterEndDate_WhenGetSpending_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequest(http.MethodGet, "/reports/spending?start_date=2022-05-01&end_date=2022-04-30&group_by=week")
+	handler := report.NewHandler(suite.reportServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, report.FieldValidationErrorMessage, report.FieldValidationErrorMessage, "[{\"field\":\"StartDate\",\"message\":\"StartDate must be before or equal to EndDate\"}]", rest.FieldValidationErrorCode)
+	if assert.NoError(suite.T(), handler.GetSpending(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+	suite.reportServiceMock.AssertNotCalled(suite.T(), "GetSpending", mock.Anything, suite.userId, mock.Anything, mock.Anything)
+}
+
+func (suite *HandlerTestSuite) TestGivenAnInvalidGrouping_WhenGetSpending_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequest(http.MethodGet, "/reports/spending?start_date=2022-03-01&end_date=2022-04-30&group_by=quarter")
+	handler := report.NewHandler(suite.reportServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, report.FieldValidationErrorMessage, report.FieldValidationErrorMessage, "[{\"field\":\"GroupBy\",\"message\":\"GroupBy must be one of [expense_type day week month year]\"}]", rest.FieldValidationErrorCode)
+	if assert.NoError(suite.T(), handler.GetSpending(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+	suite.reportServiceMock.AssertNotCalled(suite.T(), "GetSpending", mock.Anything, suite.userId, mock.Anything, mock.Anything)
+}
+
+func (suite *HandlerTestSuite) TestGivenANonExistentExpenseType_WhenGetSpending_ThenReturnStatusBadRequest() {
+	expenseTypeId := uuid.New()
+	command, _ := reportService.NewGetSpendingCommand(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 4, 30, 0, 0, 0, 0, time.UTC), "month", "")
+	serviceErr := reportService.InvalidExpenseTypeError{Msg: "the expense type doesn't exists"}
+	suite.reportServiceMock.MockGetSpending([]interface{}{suite.userId, suite.ledgerId, command.WithExpenseType(expenseTypeId)}, []interface{}{nil, serviceErr}, 1)
+
+	c, rec := suite.mockRequest(http.MethodGet, "/reports/spending?start_date=2022-03-01&end_date=2022-04-30&group_by=month&expense_type_id="+expenseTypeId.String())
+	handler := report.NewHandler(suite.reportServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, serviceErr.Error(), serviceErr.Error(), "[]", 0)
+	if assert.NoError(suite.T(), handler.GetSpending(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAUserOutsideTheLedger_WhenGetSpending_ThenReturnStatusNotFound() {
+	command, _ := reportService.NewGetSpendingCommand(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 4, 30, 0, 0, 0, 0, time.UTC), "month", "")
+	serviceErr := ledgerService.LedgerNotFoundError{Msg: "the ledger doesn't exists"}
+	suite.reportServiceMock.MockGetSpending([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{nil, serviceErr}, 1)
+
+	c, rec := suite.mockRequest(http.MethodGet, "/reports/spending?start_date=2022-03-01&end_date=2022-04-30&group_by=month")
+	handler := report.NewHandler(suite.reportServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusNotFound, serviceErr.Error(), serviceErr.Error(), "[]", 0)
+	if assert.NoError(suite.T(), handler.GetSpending(c)) {
+		assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenThatServiceFails_WhenGetSpending_ThenReturnStatusInternalServerError() {
+	command, _ := reportService.NewGetSpendingCommand(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 4, 30, 0, 0, 0, 0, time.UTC), "expense_type", "")
+	serviceErr := reportService.UnexpectedError{Msg: "fail"}
+	suite.reportServiceMock.MockGetSpending([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{nil, serviceErr}, 1)
+
+	c, rec := suite.mockRequest(http.MethodGet, "/reports/spending?start_date=2022-03-01&end_date=2022-04-30&group_by=expense_type")
+	handler := report.NewHandler(suite.reportServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusInternalServerError, report.UnexpectedErrorMessage, serviceErr.Error(), "[]", 0)
+	if assert.NoError(suite.T(), handler.GetSpending(c)) {
+		assert.Equal(suite.T(), http.StatusInternalServerError, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) getValidator() fieldvalidation.FieldsValidator {
+	validator, _ := fieldvalidation.RegisterFieldsValidator(nil, nil)
+	return validator
+}
+
+func (suite *HandlerTestSuite) mockRequest(method string, path string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, path, strings.NewReader(""))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	rest.SetLedgerId(c, suite.ledgerId)
+	return c, rec
+}
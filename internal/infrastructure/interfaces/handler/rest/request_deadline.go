@@ -0,0 +1,41 @@
+package rest
+
+import (
+	stdcontext "context"
+	"github.com/labstack/echo/v4"
+	"time"
+)
+
+// RequestDeadlineConfig is the timeout of the requests, except the ones the skipper leaves out to give them a deadline
+// of their own.
+type RequestDeadlineConfig struct {
+	Skipper func(context echo.Context) bool
+	Timeout time.Duration
+}
+
+// RequestDeadline cancels the context of the request once timeout passes, so the services and the queries it reaches
+// stop along with the ones of the clients that disconnect.
+func RequestDeadline(timeout time.Duration) echo.MiddlewareFunc {
+	return RequestDeadlineWithConfig(RequestDeadlineConfig{Skipper: neverSkip, Timeout: timeout})
+}
+
+// RequestDeadlineWithConfig is RequestDeadline for the requests that the skipper of config doesn't leave out.
+func RequestDeadlineWithConfig(config RequestDeadlineConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(context echo.Context) error {
+			if config.Skipper(context) {
+				return next(context)
+			}
+
+			ctx, cancel := stdcontext.WithTimeout(context.Request().Context(), config.Timeout)
+			defer cancel()
+
+			context.SetRequest(context.Request().WithContext(ctx))
+			return next(context)
+		}
+	}
+}
+
+func neverSkip(echo.Context) bool {
+	return false
+}
@@ -0,0 +1,61 @@
+package rest_test
+
+import (
+	"context"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGivenATimeout_WhenRequestDeadline_ThenCallTheHandlerWithARequestContextThatEndsByThen(t *testing.T) {
+	e := echo.New()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/expenses", nil), httptest.NewRecorder())
+	var handledContext context.Context
+
+	before := time.Now()
+	err := rest.RequestDeadline(time.Minute)(func(c echo.Context) error {
+		handledContext = c.Request().Context()
+		return nil
+	})(c)
+
+	require.NoError(t, err)
+	deadline, ok := handledContext.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, before.Add(time.Minute), deadline, time.Second)
+	assert.ErrorIs(t, handledContext.Err(), context.Canceled, "the context must be released once the handler returns")
+}
+
+func TestGivenAHandlerSlowerThanTheTimeout_WhenRequestDeadline_ThenItsContextIsDone(t *testing.T) {
+	e := echo.New()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/expenses", nil), httptest.NewRecorder())
+
+	err := rest.RequestDeadline(time.Millisecond)(func(c echo.Context) error {
+		<-c.Request().Context().Done()
+		return c.Request().Context().Err()
+	})(c)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestGivenASkippedRequest_WhenRequestDeadlineWithConfig_ThenCallTheHandlerWithoutADeadline(t *testing.T) {
+	e := echo.New()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/exports/expenses", nil), httptest.NewRecorder())
+	var handledContext context.Context
+
+	err := rest.RequestDeadlineWithConfig(rest.RequestDeadlineConfig{
+		Skipper: func(c echo.Context) bool { return true },
+		Timeout: time.Minute,
+	})(func(c echo.Context) error {
+		handledContext = c.Request().Context()
+		return nil
+	})(c)
+
+	require.NoError(t, err)
+	_, ok := handledContext.Deadline()
+	assert.False(t, ok)
+}
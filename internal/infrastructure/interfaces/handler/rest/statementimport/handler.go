@@ -0,0 +1,434 @@
+package statementimport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/expense"
+	"finfit-backend/internal/domain/services/ledger"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	expenseHandler "finfit-backend/internal/infrastructure/interfaces/handler/rest/expense"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+const (
+	FieldValidationErrorMessage = "some fields are invalid"
+	BodyIsInvalidErrorMessage   = "body is invalid"
+	UnexpectedErrorMessage      = "unexpected error"
+	DateFormat                  = "2006-01-02"
+	FileFormField               = "file"
+	MappingFormField            = "mapping"
+)
+
+// dateFormatTokens translates the usual date format tokens (DD/MM/YYYY) to a Go time layout. YYYY goes first so it
+// isn't taken as two YY.
+var dateFormatTokens = strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02")
+
+type Handler interface {
+	ImportCSV(context echo.Context) error
+	ImportOFX(context echo.Context) error
+	ImportQIF(context echo.Context) error
+}
+
+// statementParser reads the expenses of a statement file in a given format.
+type statementParser func(reader io.Reader, options StatementOptions) ([]*expense.AddCommand, error)
+
+type handler struct {
+	service         expense.Service
+	fieldsValidator fieldvalidation.FieldsValidator
+}
+
+func NewHandler(service expense.Service, fieldsValidator fieldvalidation.FieldsValidator) *handler {
+	return &handler{service: service, fieldsValidator: fieldsValidator}
+}
+
+// ImportCSV imports the expenses of a bank statement sent as a multipart form with the CSV file and the JSON mapping
+// of its columns. With dry_run=true nothing is stored and the expenses that would be imported are returned.
+func (h handler) ImportCSV(context echo.Context) error {
+	queryParams := new(ImportQueryParams)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(context, queryParams); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	mapping := new(CSVMapping)
+	if err := json.Unmarshal([]byte(context.FormValue(MappingFormField)), mapping); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(mapping); fieldValidationErrors != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	rows, err := h.readCSVRows(context, mapping)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	defaultExpenseTypeId, _ := uuid.Parse(mapping.DefaultExpenseTypeId)
+	command, err := expense.NewImportCommand(rows, dateFormatTokens.Replace(mapping.DateFormat), defaultExpenseTypeId, queryParams.DryRun)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	expenses, err := h.service.Import(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	if command.DryRun() {
+		return context.JSON(http.StatusOK, mapExpensesToImportResponse(expenses, true))
+	}
+	return context.JSON(http.StatusCreated, mapExpensesToImportResponse(expenses, false))
+}
+
+// ImportOFX imports the debits of an OFX or QFX statement sent as a multipart form. Transactions already imported are
+// recognized by their FITID and skipped, so overlapping statements can be imported safely.
+func (h handler) ImportOFX(context echo.Context) error {
+	return h.importStatement(context, new(OFXImportRequest), ParseOFX)
+}
+
+// ImportQIF imports the debits of a QIF statement sent as a multipart form. QIF transactions have no id, so the ones
+// already imported are recognized by their date, amount and description.
+func (h handler) ImportQIF(context echo.Context) error {
+	return h.importStatement(context, new(QIFImportRequest), ParseQIF)
+}
+
+func (h handler) importStatement(context echo.Context, requestBody statementImportRequest, parse statementParser) error {
+	if err := context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); fieldValidationErrors != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	file, err := h.openFormFile(context)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+	defer file.Close()
+
+	commands, err := parse(file, requestBody.options())
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	summary, err := h.service.ImportStatement(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), commands)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusOK, mapSummaryToStatementImportResponse(summary))
+}
+
+func (h handler) openFormFile(context echo.Context) (multipart.File, error) {
+	fileHeader, err := context.FormFile(FileFormField)
+	if err != nil {
+		return nil, err
+	}
+
+	return fileHeader.Open()
+}
+
+func (h handler) readCSVRows(context echo.Context, mapping *CSVMapping) ([]expense.ImportRow, error) {
+	file, err := h.openFormFile(context)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return readCSVRows(file, mapping)
+}
+
+func readCSVRows(reader io.Reader, mapping *CSVMapping) ([]expense.ImportRow, error) {
+	csvReader := csv.NewReader(reader)
+	if mapping.Delimiter != "" {
+		csvReader.Comma = []rune(mapping.Delimiter)[0]
+	}
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) < 2 {
+		return nil, errors.New("the CSV file must have a header row and at least one row")
+	}
+
+	columnIndexes := map[string]int{}
+	for i, column := range records[0] {
+		columnIndexes[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, column := range mapping.columns() {
+		if _, ok := columnIndexes[strings.ToLower(column)]; !ok {
+			return nil, fmt.Errorf("the CSV file doesn't have a %s column", column)
+		}
+	}
+
+	valueOf := func(record []string, column string) string {
+		if column == "" {
+			return ""
+		}
+		return strings.TrimSpace(record[columnIndexes[strings.ToLower(column)]])
+	}
+
+	rows := []expense.ImportRow{}
+	for _, record := range records[1:] {
+		currency := mapping.Currency
+		if mapping.CurrencyColumn != "" {
+			currency = strings.ToUpper(valueOf(record, mapping.CurrencyColumn))
+		}
+
+		rows = append(rows, expense.ImportRow{
+			Date:        valueOf(record, mapping.DateColumn),
+			Amount:      mapping.normalizeAmount(valueOf(record, mapping.AmountColumn)),
+			Currency:    currency,
+			Description: valueOf(record, mapping.DescriptionColumn),
+			ExpenseType: valueOf(record, mapping.ExpenseTypeColumn),
+		})
+	}
+
+	return rows, nil
+}
+
+func (h handler) manageServiceError(ctx echo.Context, err error) error {
+	invalidRowsError := expense.InvalidImportRowsError{}
+	if errors.As(err, &invalidRowsError) {
+		return h.buildErrorResponse(ctx, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), mapRowErrorsToFieldErrors(invalidRowsError.RowErrors), rest.FieldValidationErrorCode)
+	} else if errors.As(err, &expense.InvalidExpenseTypeError{}) || errors.As(err, &expense.InvalidAccountError{}) || errors.As(err, &expense.InvalidDomainModelError{}) {
+		return h.buildErrorResponse(ctx, http.StatusBadRequest, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.LedgerNotFoundError{}) {
+		return h.buildErrorResponse(ctx, http.StatusNotFound, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.ForbiddenError{}) {
+		return h.buildErrorResponse(ctx, http.StatusForbidden, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if statusCode, msg, ok := rest.EndedRequestError(ctx); ok {
+		return h.buildErrorResponse(ctx, statusCode, msg, err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else {
+		return h.buildErrorResponse(ctx, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+}
+
+func (h handler) buildErrorResponse(ctx echo.Context, statusCode int, errorMessage string, errorDetail string, fieldErrors []fieldvalidation.FieldError, errorCode uint) error {
+	errorResponse := rest.ErrorResponse{StatusCode: statusCode, Msg: errorMessage, ErrorDetail: errorDetail, FieldErrors: fieldErrors, ErrorCode: errorCode}
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+// mapRowErrorsToFieldErrors names every field error after the row it belongs to, Rows[3].Amount for example.
+func mapRowErrorsToFieldErrors(rowErrors []expense.ImportRowError) []fieldvalidation.FieldError {
+	fieldErrors := []fieldvalidation.FieldError{}
+	for _, rowError := range rowErrors {
+		field := fmt.Sprintf("Rows[%d]", rowError.Row)
+		if rowError.Field != "" {
+			field = field + "." + rowError.Field
+		}
+		fieldErrors = append(fieldErrors, fieldvalidation.FieldError{Field: field, Message: rowError.Msg})
+	}
+	return fieldErrors
+}
+
+func mapExpensesToImportResponse(expenses []*models.Expense, dryRun bool) ImportResponse {
+	response := ImportResponse{DryRun: dryRun, Expenses: []ExpenseBody{}}
+	if !dryRun {
+		response.Imported = len(expenses)
+	}
+
+	for _, importedExpense := range expenses {
+		response.Expenses = append(response.Expenses, mapExpenseToExpenseBody(importedExpense))
+	}
+	return response
+}
+
+func mapSummaryToStatementImportResponse(summary *expense.StatementImportSummary) StatementImportResponse {
+	response := StatementImportResponse{
+		Created:            len(summary.Created),
+		Skipped:            len(summary.Skipped),
+		Conflicting:        len(summary.Conflicting),
+		Expenses:           []ExpenseBody{},
+		SkippedRecords:     []RecordBody{},
+		ConflictingRecords: []RecordBody{},
+	}
+
+	for _, createdExpense := range summary.Created {
+		response.Expenses = append(response.Expenses, mapExpenseToExpenseBody(createdExpense))
+	}
+
+	for _, record := range summary.Skipped {
+		response.SkippedRecords = append(response.SkippedRecords, mapStatementRecordToRecordBody(record))
+	}
+
+	for _, record := range summary.Conflicting {
+		response.ConflictingRecords = append(response.ConflictingRecords, mapStatementRecordToRecordBody(record))
+	}
+
+	return response
+}
+
+func mapExpenseToExpenseBody(importedExpense *models.Expense) ExpenseBody {
+	return ExpenseBody{
+		ID: importedExpense.Id().String(),
+		Amount: expenseHandler.Money{
+			Amount:   json.Number(importedExpense.Amount().Amount()),
+			Currency: importedExpense.Amount().Currency(),
+		},
+		ExpenseDate: importedExpense.ExpenseDate().Format(DateFormat),
+		Description: importedExpense.Description(),
+		ExpenseType: expenseHandler.TypeBody{
+			ID:   importedExpense.ExpenseType().Id().String(),
+			Name: importedExpense.ExpenseType().Name(),
+			Path: importedExpense.ExpenseType().FullPath(),
+		},
+		FitId: importedExpense.FitId(),
+	}
+}
+
+func mapStatementRecordToRecordBody(record expense.StatementRecord) RecordBody {
+	recordBody := RecordBody{
+		FitId: record.Expense.FitId(),
+		Amount: expenseHandler.Money{
+			Amount:   json.Number(record.Expense.Amount().Amount()),
+			Currency: record.Expense.Amount().Currency(),
+		},
+		ExpenseDate: record.Expense.ExpenseDate().Format(DateFormat),
+		Description: record.Expense.Description(),
+	}
+
+	if record.ExistingExpense != nil {
+		recordBody.ExistingExpenseId = record.ExistingExpense.Id().String()
+	}
+	return recordBody
+}
+
+type ImportQueryParams struct {
+	DryRun bool `query:"dry_run"`
+}
+
+// CSVMapping tells which header of the CSV file holds each value of an expense. The currency and the expense type
+// can either come from a column or be fixed for the whole file.
+type CSVMapping struct {
+	DateColumn           string `json:"date_column" validate:"required"`
+	DateFormat           string `json:"date_format" validate:"required"`
+	AmountColumn         string `json:"amount_column" validate:"required"`
+	DecimalSeparator     string `json:"decimal_separator" validate:"omitempty,oneof=. 0x2C"`
+	InvertAmounts        bool   `json:"invert_amounts"`
+	CurrencyColumn       string `json:"currency_column" validate:"required_without=Currency"`
+	Currency             string `json:"currency" validate:"omitempty,iso4217"`
+	DescriptionColumn    string `json:"description_column"`
+	ExpenseTypeColumn    string `json:"expense_type_column" validate:"required_without=DefaultExpenseTypeId"`
+	DefaultExpenseTypeId string `json:"default_expense_type_id" validate:"omitempty,uuid"`
+	Delimiter            string `json:"delimiter" validate:"omitempty,len=1"`
+}
+
+func (m CSVMapping) columns() []string {
+	columns := []string{m.DateColumn, m.AmountColumn}
+	for _, column := range []string{m.CurrencyColumn, m.DescriptionColumn, m.ExpenseTypeColumn} {
+		if column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// normalizeAmount turns an amount as written by the bank (1.234,56 or -1,234.56) into a plain decimal literal, and
+// flips its sign for statements where expenses are negative.
+func (m CSVMapping) normalizeAmount(amount string) string {
+	thousandsSeparator, decimalSeparator := ",", "."
+	if m.DecimalSeparator == "," {
+		thousandsSeparator, decimalSeparator = ".", ","
+	}
+
+	amount = strings.ReplaceAll(amount, " ", "")
+	amount = strings.ReplaceAll(amount, thousandsSeparator, "")
+	amount = strings.ReplaceAll(amount, decimalSeparator, ".")
+
+	if m.InvertAmounts {
+		if strings.HasPrefix(amount, "-") {
+			return strings.TrimPrefix(amount, "-")
+		}
+		return "-" + amount
+	}
+	return amount
+}
+
+type statementImportRequest interface {
+	options() StatementOptions
+}
+
+// StatementImportRequest holds the form fields shared by every statement format, besides the file itself.
+type StatementImportRequest struct {
+	ExpenseTypeId string `form:"expense_type_id" validate:"required,uuid"`
+	AccountId     string `form:"account_id" validate:"omitempty,uuid"`
+}
+
+func (r StatementImportRequest) options() StatementOptions {
+	expenseTypeId, _ := uuid.Parse(r.ExpenseTypeId)
+	accountId, _ := uuid.Parse(r.AccountId)
+	return StatementOptions{ExpenseTypeId: expenseTypeId, AccountId: accountId}
+}
+
+// OFXImportRequest takes the currency from the statement unless one is sent.
+type OFXImportRequest struct {
+	StatementImportRequest
+	Currency string `form:"currency" validate:"omitempty,iso4217"`
+}
+
+func (r OFXImportRequest) options() StatementOptions {
+	options := r.StatementImportRequest.options()
+	options.Currency = r.Currency
+	return options
+}
+
+type QIFImportRequest struct {
+	StatementImportRequest
+	Currency   string `form:"currency" validate:"required,iso4217"`
+	DateFormat string `form:"date_format"`
+}
+
+func (r QIFImportRequest) options() StatementOptions {
+	options := r.StatementImportRequest.options()
+	options.Currency = r.Currency
+	options.DateFormat = r.DateFormat
+	return options
+}
+
+type ImportResponse struct {
+	DryRun   bool          `json:"dry_run"`
+	Imported int           `json:"imported"`
+	Expenses []ExpenseBody `json:"expenses"`
+}
+
+type ExpenseBody struct {
+	ID          string                  `json:"id"`
+	Amount      expenseHandler.Money    `json:"amount"`
+	ExpenseDate string                  `json:"expense_date"`
+	Description string                  `json:"description"`
+	ExpenseType expenseHandler.TypeBody `json:"expense_type"`
+	FitId       string                  `json:"fit_id,omitempty"`
+}
+
+type StatementImportResponse struct {
+	Created            int           `json:"created"`
+	Skipped            int           `json:"skipped"`
+	Conflicting        int           `json:"conflicting"`
+	Expenses           []ExpenseBody `json:"expenses"`
+	SkippedRecords     []RecordBody  `json:"skipped_records"`
+	ConflictingRecords []RecordBody  `json:"conflicting_records"`
+}
+
+// RecordBody is a statement record that wasn't imported. ExistingExpenseId is the stored expense it matched, empty
+// when the record was repeated in the same statement.
+type RecordBody struct {
+	FitId             string               `json:"fit_id,omitempty"`
+	Amount            expenseHandler.Money `json:"amount"`
+	ExpenseDate       string               `json:"expense_date"`
+	Description       string               `json:"description"`
+	ExistingExpenseId string               `json:"existing_expense_id,omitempty"`
+}
@@ -0,0 +1,248 @@
+package statementimport_test
+
+import (
+	"bytes"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	expenseService "finfit-backend/internal/domain/services/expense"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/statementimport"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	errorResponse = `{"status_code":%d,"msg":"%s","error_detail":"%v","field_errors":%v,"error_code":%d}
+`
+	statement = "Fecha;Importe;Concepto;Rubro\n01/03/2022;-1.234,50;Supermercado;Food\n02/03/2022;-99,90;Farmacia;\n"
+	mapping   = `{"date_column":"fecha","date_format":"DD/MM/YYYY","amount_column":"importe","decimal_separator":",","invert_amounts":true,"currency":"ARS","description_column":"concepto","expense_type_column":"rubro","default_expense_type_id":"4a1e2f3c-6b1d-4f4e-9a55-0f5f3a2c1b10","delimiter":";"}`
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+	userId             uuid.UUID
+	ledgerId           uuid.UUID
+	expenseServiceMock *expenseService.ServiceMock
+}
+
+func (suite *HandlerTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.ledgerId = uuid.New()
+	suite.expenseServiceMock = expenseService.NewServiceMock()
+}
+
+func (suite *HandlerTestSuite) TearDownTest() {
+	suite.expenseServiceMock.ExpectedCalls = nil
+	suite.expenseServiceMock.Calls = nil
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (suite *HandlerTestSuite) TestGivenAStatementAndItsMapping_WhenImportCSV_ThenReturnStatusCreatedWithTheImportedExpenses() {
+	command := suite.getImportCommand(false)
+	expenses := suite.getExpenses()
+	suite.expenseServiceMock.MockImport([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{expenses, nil}, 1)
+
+	c, rec := suite.mockRequest("", statement, mapping)
+	handler := statementimport.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	expectedResponseBody := `{"dry_run":false,"imported":2,"expenses":[` +
+		`{"id":"` + expenses[0].Id().String() + `","amount":{"amount":1234.50,"currency":"ARS"},"expense_date":"2022-03-01","description":"Supermercado","expense_type":{"id":"` + expenses[0].ExpenseType().Id().String() + `","name":"Food","path":"Food"}},` +
+		`{"id":"` + expenses[1].Id().String() + `","amount":{"amount":99.90,"currency":"ARS"},"expense_date":"2022-03-02","description":"Farmacia","expense_type":{"id":"` + expenses[1].ExpenseType().Id().String() + `","name":"Health","path":"Health"}}]}` + "\n"
+	if assert.NoError(suite.T(), handler.ImportCSV(c)) {
+		assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenADryRun_WhenImportCSV_ThenReturnStatusOkWithoutImportingAnything() {
+	command := suite.getImportCommand(true)
+	suite.expenseServiceMock.MockImport([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{suite.getExpenses(), nil}, 1)
+
+	c, rec := suite.mockRequest("?dry_run=true", statement, mapping)
+	handler := statementimport.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.ImportCSV(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Contains(suite.T(), rec.Body.String(), `{"dry_run":true,"imported":0,"expenses":[{`)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenInvalidRows_WhenImportCSV_ThenReturnStatusBadRequestWithAFieldErrorPerRow() {
+	command := suite.getImportCommand(false)
+	serviceError := expenseService.InvalidImportRowsError{Msg: "some rows are invalid, nothing was imported", RowErrors: []expenseService.ImportRowError{
+		{Row: 0, Field: "Amount", Msg: "the amount is invalid"},
+		{Row: 1, Msg: "invalid command"},
+	}}
+	suite.expenseServiceMock.MockImport([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{nil, serviceError}, 1)
+
+	c, rec := suite.mockRequest("", statement, mapping)
+	handler := statementimport.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	fieldErrors := `[{"field":"Rows[0].Amount","message":"the amount is invalid"},{"field":"Rows[1]","message":"invalid command"}]`
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, statementimport.FieldValidationErrorMessage, serviceError.Msg, fieldErrors, rest.FieldValidationErrorCode)
+	if assert.NoError(suite.T(), handler.ImportCSV(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAMappingWithoutCurrency_WhenImportCSV_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequest("", statement, `{"date_column":"fecha","date_format":"DD/MM/YYYY","amount_column":"importe","expense_type_column":"rubro"}`)
+	handler := statementimport.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	fieldErrors := `[{"field":"CurrencyColumn","message":"CurrencyColumn is required when Currency is empty"}]`
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, statementimport.FieldValidationErrorMessage, statementimport.FieldValidationErrorMessage, fieldErrors, rest.FieldValidationErrorCode)
+	if assert.NoError(suite.T(), handler.ImportCSV(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+		suite.expenseServiceMock.AssertNotCalled(suite.T(), "Import", mock.Anything, suite.userId, suite.ledgerId, mock.Anything)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAMappedColumnMissingInTheFile_WhenImportCSV_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequest("", "Fecha;Importe\n01/03/2022;-10,00\n", mapping)
+	handler := statementimport.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, statementimport.BodyIsInvalidErrorMessage, "the CSV file doesn't have a concepto column", "[]", 0)
+	if assert.NoError(suite.T(), handler.ImportCSV(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnUnexpectedServiceError_WhenImportCSV_ThenReturnStatusInternalServerError() {
+	command := suite.getImportCommand(false)
+	suite.expenseServiceMock.MockImport([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{nil, expenseService.UnexpectedError{Msg: "connection refused"}}, 1)
+
+	c, rec := suite.mockRequest("", statement, mapping)
+	handler := statementimport.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusInternalServerError, statementimport.UnexpectedErrorMessage, "connection refused", "[]", 0)
+	if assert.NoError(suite.T(), handler.ImportCSV(c)) {
+		assert.Equal(suite.T(), http.StatusInternalServerError, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnOFXStatement_WhenImportOFX_ThenReturnStatusOkWithTheImportSummary() {
+	expenseTypeId := uuid.New()
+	commands, _ := statementimport.ParseOFX(strings.NewReader(xmlStatement), statementimport.StatementOptions{ExpenseTypeId: expenseTypeId})
+	expenses := suite.getExpenses()
+	existingExpense := expenses[1]
+	summary := &expenseService.StatementImportSummary{
+		Created:     []*models.Expense{expenses[0].WithFitId("A1")},
+		Skipped:     []expenseService.StatementRecord{{Expense: expenses[1].WithFitId("A2"), ExistingExpense: existingExpense}},
+		Conflicting: []expenseService.StatementRecord{},
+	}
+	suite.expenseServiceMock.MockImportStatement([]interface{}{suite.userId, suite.ledgerId, commands}, []interface{}{summary, nil}, 1)
+
+	c, rec := suite.mockStatementRequest(map[string]string{"expense_type_id": expenseTypeId.String()}, xmlStatement)
+	handler := statementimport.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	expectedResponseBody := `{"created":1,"skipped":1,"conflicting":0,"expenses":[` +
+		`{"id":"` + expenses[0].Id().String() + `","amount":{"amount":1234.50,"currency":"ARS"},"expense_date":"2022-03-01","description":"Supermercado","expense_type":{"id":"` + expenses[0].ExpenseType().Id().String() + `","name":"Food","path":"Food"},"fit_id":"A1"}],` +
+		`"skipped_records":[{"fit_id":"A2","amount":{"amount":99.90,"currency":"ARS"},"expense_date":"2022-03-02","description":"Farmacia","existing_expense_id":"` + existingExpense.Id().String() + `"}],` +
+		`"conflicting_records":[]}` + "\n"
+	if assert.NoError(suite.T(), handler.ImportOFX(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAQIFStatementWithoutCurrency_WhenImportQIF_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockStatementRequest(map[string]string{"expense_type_id": uuid.New().String()}, qifStatement)
+	handler := statementimport.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	fieldErrors := `[{"field":"Currency","message":"Currency is a required field"}]`
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, statementimport.FieldValidationErrorMessage, statementimport.FieldValidationErrorMessage, fieldErrors, rest.FieldValidationErrorCode)
+	if assert.NoError(suite.T(), handler.ImportQIF(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+		suite.expenseServiceMock.AssertNotCalled(suite.T(), "ImportStatement", mock.Anything, suite.userId, suite.ledgerId, mock.Anything)
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnExpenseTypeThatNotExists_WhenImportQIF_ThenReturnStatusBadRequest() {
+	expenseTypeId := uuid.New()
+	commands, _ := statementimport.ParseQIF(strings.NewReader(qifStatement), statementimport.StatementOptions{Currency: "USD", ExpenseTypeId: expenseTypeId})
+	serviceError := expenseService.InvalidExpenseTypeError{Msg: "the expense type doesn't exists"}
+	suite.expenseServiceMock.MockImportStatement([]interface{}{suite.userId, suite.ledgerId, commands}, []interface{}{nil, serviceError}, 1)
+
+	c, rec := suite.mockStatementRequest(map[string]string{"expense_type_id": expenseTypeId.String(), "currency": "USD"}, qifStatement)
+	handler := statementimport.NewHandler(suite.expenseServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusBadRequest, serviceError.Msg, serviceError.Msg, "[]", 0)
+	if assert.NoError(suite.T(), handler.ImportQIF(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) getImportCommand(dryRun bool) *expenseService.ImportCommand {
+	rows := []expenseService.ImportRow{
+		{Date: "01/03/2022", Amount: "1234.50", Currency: "ARS", Description: "Supermercado", ExpenseType: "Food"},
+		{Date: "02/03/2022", Amount: "99.90", Currency: "ARS", Description: "Farmacia", ExpenseType: ""},
+	}
+	command, _ := expenseService.NewImportCommand(rows, "02/01/2006", uuid.MustParse("4a1e2f3c-6b1d-4f4e-9a55-0f5f3a2c1b10"), dryRun)
+	return command
+}
+
+func (suite *HandlerTestSuite) getExpenses() []*models.Expense {
+	food, _ := models.NewExpenseType("Food")
+	health, _ := models.NewExpenseType("Health")
+	firstAmount, _ := models.NewMoney("1234.50", "ARS")
+	secondAmount, _ := models.NewMoney("99.90", "ARS")
+	first, _ := models.NewExpense(firstAmount, time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), "Supermercado", food)
+	second, _ := models.NewExpense(secondAmount, time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC), "Farmacia", health)
+	return []*models.Expense{first, second}
+}
+
+func (suite *HandlerTestSuite) getValidator() fieldvalidation.FieldsValidator {
+	validator, _ := fieldvalidation.RegisterFieldsValidator(nil, nil)
+	return validator
+}
+
+func (suite *HandlerTestSuite) mockRequest(query string, file string, mapping string) (echo.Context, *httptest.ResponseRecorder) {
+	return suite.mockMultipartRequest("/imports/csv"+query, map[string]string{statementimport.MappingFormField: mapping}, file)
+}
+
+func (suite *HandlerTestSuite) mockStatementRequest(fields map[string]string, file string) (echo.Context, *httptest.ResponseRecorder) {
+	return suite.mockMultipartRequest("/imports/statement", fields, file)
+}
+
+func (suite *HandlerTestSuite) mockMultipartRequest(target string, fields map[string]string, file string) (echo.Context, *httptest.ResponseRecorder) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	fileWriter, err := writer.CreateFormFile(statementimport.FileFormField, "statement")
+	if err != nil {
+		panic(errors.New("the request couldn't be built"))
+	}
+	_, _ = fileWriter.Write([]byte(file))
+	for field, value := range fields {
+		_ = writer.WriteField(field, value)
+	}
+	_ = writer.Close()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, target, body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	rest.SetLedgerId(c, suite.ledgerId)
+	return c, rec
+}
@@ -0,0 +1,93 @@
+package statementimport
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/services/expense"
+	"fmt"
+	"github.com/google/uuid"
+	"html"
+	"io"
+	"strings"
+	"time"
+)
+
+const ofxDateFormat = "20060102"
+
+// StatementOptions holds what a statement file doesn't tell about its transactions.
+type StatementOptions struct {
+	// Currency is used when the statement doesn't say its currency, QIF files never do.
+	Currency string
+	// DateFormat is the format of the QIF dates, with the DD, MM, YY and YYYY tokens.
+	DateFormat    string
+	ExpenseTypeId uuid.UUID
+	AccountId     uuid.UUID
+}
+
+// ParseOFX reads the debit transactions of an OFX or QFX statement, both the SGML (1.x) flavour, where the elements
+// with a value aren't closed, and the XML (2.x) one. Credits aren't expenses, so they are left out.
+func ParseOFX(reader io.Reader, options StatementOptions) ([]*expense.AddCommand, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.Contains(strings.ToUpper(string(content)), "<OFX>") {
+		return nil, errors.New("the file isn't an OFX statement")
+	}
+
+	currency := options.Currency
+	transactions := []map[string]string{}
+	var transaction map[string]string
+	for _, element := range strings.Split(string(content), "<")[1:] {
+		tag, value, _ := strings.Cut(element, ">")
+		tag = strings.ToUpper(strings.TrimSpace(tag))
+		value = html.UnescapeString(strings.TrimSpace(value))
+
+		switch {
+		case tag == "STMTTRN":
+			transaction = map[string]string{}
+		case tag == "/STMTTRN" && transaction != nil:
+			transactions = append(transactions, transaction)
+			transaction = nil
+		case tag == "CURDEF" && currency == "":
+			currency = value
+		case transaction != nil && !strings.HasPrefix(tag, "/"):
+			transaction[tag] = value
+		}
+	}
+
+	if currency == "" {
+		return nil, errors.New("the statement doesn't have a currency, it must be sent in the currency field")
+	}
+
+	commands := []*expense.AddCommand{}
+	for i, transaction := range transactions {
+		amount := strings.ReplaceAll(transaction["TRNAMT"], ",", ".")
+		if !strings.HasPrefix(amount, "-") {
+			continue
+		}
+
+		postedDate := transaction["DTPOSTED"]
+		if len(postedDate) > len(ofxDateFormat) {
+			postedDate = postedDate[:len(ofxDateFormat)]
+		}
+
+		expenseDate, err := time.Parse(ofxDateFormat, postedDate)
+		if err != nil {
+			return nil, fmt.Errorf("the transaction %d doesn't have a valid posted date", i+1)
+		}
+
+		description := transaction["NAME"]
+		if description == "" {
+			description = transaction["MEMO"]
+		}
+
+		command, err := expense.NewAddCommand(strings.TrimPrefix(amount, "-"), currency, expenseDate, description, options.ExpenseTypeId, options.AccountId)
+		if err != nil {
+			return nil, fmt.Errorf("the transaction %d is invalid: %s", i+1, err.Error())
+		}
+		commands = append(commands, command.WithFitId(transaction["FITID"]))
+	}
+
+	return commands, nil
+}
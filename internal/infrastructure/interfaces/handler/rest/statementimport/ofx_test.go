@@ -0,0 +1,94 @@
+package statementimport_test
+
+import (
+	"finfit-backend/internal/domain/services/expense"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/statementimport"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sgmlStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>USD
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20220301120000[-3:ART]
+<TRNAMT>-45.10
+<FITID>2022030101
+<NAME>Grocery &amp; Co
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20220302
+<TRNAMT>1500.00
+<FITID>2022030201
+<NAME>Salary
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20220303
+<TRNAMT>-12,5
+<FITID>2022030301
+<MEMO>Subway ticket
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+const xmlStatement = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="211"?>
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>EUR</CURDEF>
+<BANKTRANLIST>
+<STMTTRN><TRNTYPE>POS</TRNTYPE><DTPOSTED>20220310</DTPOSTED><TRNAMT>-9.99</TRNAMT><FITID>A1</FITID><NAME>Streaming</NAME></STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>
+`
+
+func TestGivenAnSGMLStatement_WhenParseOFX_ThenReturnACommandPerDebit(t *testing.T) {
+	expenseTypeId := uuid.New()
+
+	commands, err := statementimport.ParseOFX(strings.NewReader(sgmlStatement), statementimport.StatementOptions{ExpenseTypeId: expenseTypeId})
+
+	require.NoError(t, err)
+	groceries, _ := expense.NewAddCommand("45.10", "USD", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), "Grocery & Co", expenseTypeId, uuid.Nil)
+	subway, _ := expense.NewAddCommand("12.5", "USD", time.Date(2022, 3, 3, 0, 0, 0, 0, time.UTC), "Subway ticket", expenseTypeId, uuid.Nil)
+	assert.Equal(t, []*expense.AddCommand{groceries.WithFitId("2022030101"), subway.WithFitId("2022030301")}, commands)
+}
+
+func TestGivenAnXMLStatementAndACurrency_WhenParseOFX_ThenUseTheGivenCurrency(t *testing.T) {
+	expenseTypeId := uuid.New()
+	accountId := uuid.New()
+
+	commands, err := statementimport.ParseOFX(strings.NewReader(xmlStatement), statementimport.StatementOptions{Currency: "USD", ExpenseTypeId: expenseTypeId, AccountId: accountId})
+
+	require.NoError(t, err)
+	streaming, _ := expense.NewAddCommand("9.99", "USD", time.Date(2022, 3, 10, 0, 0, 0, 0, time.UTC), "Streaming", expenseTypeId, accountId)
+	assert.Equal(t, []*expense.AddCommand{streaming.WithFitId("A1")}, commands)
+}
+
+func TestGivenATransactionWithoutDate_WhenParseOFX_ThenReturnError(t *testing.T) {
+	statement := "<OFX><CURDEF>USD<STMTTRN><TRNAMT>-1.00<FITID>1</STMTTRN></OFX>"
+
+	commands, err := statementimport.ParseOFX(strings.NewReader(statement), statementimport.StatementOptions{ExpenseTypeId: uuid.New()})
+
+	assert.EqualError(t, err, "the transaction 1 doesn't have a valid posted date")
+	assert.Nil(t, commands)
+}
+
+func TestGivenAFileThatIsNotOFX_WhenParseOFX_ThenReturnError(t *testing.T) {
+	commands, err := statementimport.ParseOFX(strings.NewReader("date,amount\n"), statementimport.StatementOptions{ExpenseTypeId: uuid.New()})
+
+	assert.EqualError(t, err, "the file isn't an OFX statement")
+	assert.Nil(t, commands)
+}
@@ -0,0 +1,116 @@
+package statementimport
+
+import (
+	"bufio"
+	"finfit-backend/internal/domain/services/expense"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const defaultQIFDateFormat = "MM/DD/YYYY"
+
+// qifDateFormatTokens is like dateFormatTokens but takes days and months with one or two digits, as QIF files
+// usually don't pad them (1/31/2022).
+var qifDateFormatTokens = strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "1", "DD", "2")
+
+// qifTransactionSections are the QIF headers followed by transactions, the rest (accounts, categories, investments)
+// are skipped.
+var qifTransactionSections = []string{"!TYPE:BANK", "!TYPE:CASH", "!TYPE:CCARD", "!TYPE:OTH A", "!TYPE:OTH L"}
+
+// ParseQIF reads the debit transactions of a QIF statement. QIF doesn't say the currency of the transactions nor how
+// its dates are written, so both come from the options; a year after an apostrophe (1/31'22) is also accepted.
+func ParseQIF(reader io.Reader, options StatementOptions) ([]*expense.AddCommand, error) {
+	if options.DateFormat == "" {
+		options.DateFormat = defaultQIFDateFormat
+	}
+
+	commands := []*expense.AddCommand{}
+	isTransactionSection := false
+	transaction := map[byte]string{}
+	transactionNumber := 0
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "!"):
+			isTransactionSection = isQIFTransactionSection(line)
+		case line == "^":
+			if isTransactionSection && len(transaction) > 0 {
+				transactionNumber++
+				command, err := mapQIFTransactionToAddCommand(transaction, transactionNumber, options)
+				if err != nil {
+					return nil, err
+				}
+
+				if command != nil {
+					commands = append(commands, command)
+				}
+			}
+			transaction = map[byte]string{}
+		default:
+			// only the first line of every field is kept, the split lines (S, E, $) of a transaction are ignored
+			if _, isSet := transaction[line[0]]; !isSet {
+				transaction[line[0]] = strings.TrimSpace(line[1:])
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return commands, nil
+}
+
+func isQIFTransactionSection(header string) bool {
+	for _, section := range qifTransactionSections {
+		if strings.EqualFold(header, section) {
+			return true
+		}
+	}
+	return false
+}
+
+// mapQIFTransactionToAddCommand returns nil without error for credits.
+func mapQIFTransactionToAddCommand(transaction map[byte]string, number int, options StatementOptions) (*expense.AddCommand, error) {
+	amount, ok := transaction['T']
+	if !ok {
+		amount = transaction['U']
+	}
+
+	amount = strings.ReplaceAll(amount, ",", "")
+	if !strings.HasPrefix(amount, "-") {
+		return nil, nil
+	}
+
+	expenseDate, err := parseQIFDate(transaction['D'], options.DateFormat)
+	if err != nil {
+		return nil, fmt.Errorf("the transaction %d doesn't have a date with the format %s", number, options.DateFormat)
+	}
+
+	description := transaction['P']
+	if description == "" {
+		description = transaction['M']
+	}
+
+	command, err := expense.NewAddCommand(strings.TrimPrefix(amount, "-"), options.Currency, expenseDate, description, options.ExpenseTypeId, options.AccountId)
+	if err != nil {
+		return nil, fmt.Errorf("the transaction %d is invalid: %s", number, err.Error())
+	}
+	return command, nil
+}
+
+// parseQIFDate also tries the layout with a two digit year, since many banks write 1/31'22 even for four digit formats.
+func parseQIFDate(date string, dateFormat string) (time.Time, error) {
+	dateLayout := qifDateFormatTokens.Replace(dateFormat)
+	date = strings.ReplaceAll(strings.ReplaceAll(date, "'", "/"), " ", "")
+	expenseDate, err := time.Parse(dateLayout, date)
+	if err != nil && strings.Contains(dateLayout, "2006") {
+		return time.Parse(strings.Replace(dateLayout, "2006", "06", 1), date)
+	}
+	return expenseDate, err
+}
@@ -0,0 +1,65 @@
+package statementimport_test
+
+import (
+	"finfit-backend/internal/domain/services/expense"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/statementimport"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+const qifStatement = `!Account
+NChecking
+TBank
+^
+!Type:Bank
+D1/31'22
+T-1,234.50
+PRent
+MJanuary
+^
+D2/ 1'22
+T2,000.00
+PSalary
+^
+D2/03/2022
+U-7.25
+MCoffee
+SGroceries
+$-7.25
+^
+`
+
+func TestGivenAQIFStatement_WhenParseQIF_ThenReturnACommandPerDebit(t *testing.T) {
+	expenseTypeId := uuid.New()
+
+	commands, err := statementimport.ParseQIF(strings.NewReader(qifStatement), statementimport.StatementOptions{Currency: "USD", ExpenseTypeId: expenseTypeId})
+
+	require.NoError(t, err)
+	rent, _ := expense.NewAddCommand("1234.50", "USD", time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC), "Rent", expenseTypeId, uuid.Nil)
+	coffee, _ := expense.NewAddCommand("7.25", "USD", time.Date(2022, 2, 3, 0, 0, 0, 0, time.UTC), "Coffee", expenseTypeId, uuid.Nil)
+	assert.Equal(t, []*expense.AddCommand{rent, coffee}, commands)
+}
+
+func TestGivenADateFormat_WhenParseQIF_ThenParseTheDatesWithIt(t *testing.T) {
+	expenseTypeId := uuid.New()
+	statement := "!Type:CCard\nD31.01.2022\nT-10\nPBooks\n^\n"
+
+	commands, err := statementimport.ParseQIF(strings.NewReader(statement), statementimport.StatementOptions{Currency: "EUR", DateFormat: "DD.MM.YYYY", ExpenseTypeId: expenseTypeId})
+
+	require.NoError(t, err)
+	books, _ := expense.NewAddCommand("10", "EUR", time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC), "Books", expenseTypeId, uuid.Nil)
+	assert.Equal(t, []*expense.AddCommand{books}, commands)
+}
+
+func TestGivenADateWithAnotherFormat_WhenParseQIF_ThenReturnError(t *testing.T) {
+	statement := "!Type:Bank\nD2022-01-31\nT-10\n^\n"
+
+	commands, err := statementimport.ParseQIF(strings.NewReader(statement), statementimport.StatementOptions{Currency: "EUR", ExpenseTypeId: uuid.New()})
+
+	assert.EqualError(t, err, "the transaction 1 doesn't have a date with the format MM/DD/YYYY")
+	assert.Nil(t, commands)
+}
@@ -0,0 +1,169 @@
+package tag
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/ledger"
+	"finfit-backend/internal/domain/services/tag"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/pkg/fieldvalidation"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+const (
+	FieldValidationErrorMessage = "some fields are invalid"
+	BodyIsInvalidErrorMessage   = "body is invalid"
+	InvalidIdErrorMessage       = "id path param is invalid, it must be a valid UUID"
+	UnexpectedErrorMessage      = "unexpected error"
+)
+
+type Handler interface {
+	GetAll(context echo.Context) error
+	Rename(context echo.Context) error
+	Merge(context echo.Context) error
+}
+
+type handler struct {
+	service         tag.Service
+	fieldsValidator fieldvalidation.FieldsValidator
+}
+
+func NewHandler(service tag.Service, fieldsValidator fieldvalidation.FieldsValidator) *handler {
+	return &handler{service: service, fieldsValidator: fieldsValidator}
+}
+
+func (h handler) GetAll(context echo.Context) error {
+	tags, err := h.service.GetAll(context.Request().Context(), rest.UserId(context), rest.LedgerId(context))
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	tagBodies := []Body{}
+	for _, storedTag := range tags {
+		tagBodies = append(tagBodies, mapTagToTagBody(storedTag))
+	}
+
+	return context.JSON(http.StatusOK, SearchResponse{Tags: tagBodies})
+}
+
+// Rename changes the name of the tag on every expense it is linked to.
+func (h handler) Rename(context echo.Context) error {
+	id, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	requestBody := new(RenameRequest)
+	if err := context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := tag.NewRenameCommand(id, requestBody.Name)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	renamedTag, err := h.service.Rename(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusOK, Response{Tag: mapTagToTagBody(renamedTag)})
+}
+
+// Merge moves the expenses of the tags in the body to the tag of the path and deletes them.
+func (h handler) Merge(context echo.Context) error {
+	id, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, InvalidIdErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	requestBody := new(MergeRequest)
+	if err := context.Bind(requestBody); err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, BodyIsInvalidErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	if fieldValidationErrors := h.fieldsValidator.ValidateFields(requestBody); len(fieldValidationErrors) > 0 {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, FieldValidationErrorMessage, fieldValidationErrors, rest.FieldValidationErrorCode)
+	}
+
+	command, err := requestBody.mapToMergeCommand(id)
+	if err != nil {
+		return h.buildErrorResponse(context, http.StatusBadRequest, FieldValidationErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+
+	mergedTag, err := h.service.Merge(context.Request().Context(), rest.UserId(context), rest.LedgerId(context), command)
+	if err != nil {
+		return h.manageServiceError(context, err)
+	}
+
+	return context.JSON(http.StatusOK, Response{Tag: mapTagToTagBody(mergedTag)})
+}
+
+func (h handler) manageServiceError(ctx echo.Context, err error) error {
+	if errors.As(err, &tag.InvalidDomainModelError{}) {
+		return h.buildErrorResponse(ctx, http.StatusBadRequest, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &tag.TagNotFoundError{}) {
+		return h.buildErrorResponse(ctx, http.StatusNotFound, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &tag.TagAlreadyExistsError{}) {
+		return h.buildErrorResponse(ctx, http.StatusConflict, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.LedgerNotFoundError{}) {
+		return h.buildErrorResponse(ctx, http.StatusNotFound, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if errors.As(err, &ledger.ForbiddenError{}) {
+		return h.buildErrorResponse(ctx, http.StatusForbidden, err.Error(), err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else if statusCode, msg, ok := rest.EndedRequestError(ctx); ok {
+		return h.buildErrorResponse(ctx, statusCode, msg, err.Error(), []fieldvalidation.FieldError{}, 0)
+	} else {
+		return h.buildErrorResponse(ctx, http.StatusInternalServerError, UnexpectedErrorMessage, err.Error(), []fieldvalidation.FieldError{}, 0)
+	}
+}
+
+func (h handler) buildErrorResponse(ctx echo.Context, statusCode int, errorMessage string, errorDetail string, fieldErrors []fieldvalidation.FieldError, errorCode uint) error {
+	errorResponse := rest.ErrorResponse{StatusCode: statusCode, Msg: errorMessage, ErrorDetail: errorDetail, FieldErrors: fieldErrors, ErrorCode: errorCode}
+	return ctx.JSON(statusCode, errorResponse)
+}
+
+func mapTagToTagBody(tag *models.Tag) Body {
+	return Body{ID: tag.Id().String(), Name: tag.Name()}
+}
+
+type RenameRequest struct {
+	Name string `json:"name" validate:"required,max=32"`
+}
+
+// MergeRequest has the ids of the tags merged into the tag of the path.
+type MergeRequest struct {
+	TagIDs []string `json:"tag_ids" validate:"required,min=1,dive,uuid"`
+}
+
+func (r MergeRequest) mapToMergeCommand(targetId uuid.UUID) (*tag.MergeCommand, error) {
+	sourceIds := []uuid.UUID{}
+	for _, tagId := range r.TagIDs {
+		sourceId, err := uuid.Parse(tagId)
+		if err != nil {
+			return nil, err
+		}
+		sourceIds = append(sourceIds, sourceId)
+	}
+
+	return tag.NewMergeCommand(targetId, sourceIds)
+}
+
+type Response struct {
+	Tag Body `json:"tag"`
+}
+
+type SearchResponse struct {
+	Tags []Body `json:"tags"`
+}
+
+type Body struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
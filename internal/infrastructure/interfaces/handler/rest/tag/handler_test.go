@@ -0,0 +1,189 @@
+package tag_test
+
+import (
+	"finfit-backend/internal/domain/models"
+	ledgerService "finfit-backend/internal/domain/services/ledger"
+	tagService "finfit-backend/internal/domain/services/tag"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest"
+	"finfit-backend/internal/infrastructure/interfaces/handler/rest/tag"
+	"finfit-backend/pkg/fieldvalidation"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const (
+	errorResponse = `{"status_code":%d,"msg":"%s","error_detail":"%v","field_errors":%v,"error_code":%d}
+`
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+	userId         uuid.UUID
+	ledgerId       uuid.UUID
+	tagServiceMock *tagService.ServiceMock
+}
+
+func (suite *HandlerTestSuite) SetupSuite() {
+	suite.userId = uuid.New()
+	suite.ledgerId = uuid.New()
+	suite.tagServiceMock = tagService.NewServiceMock()
+}
+
+func (suite *HandlerTestSuite) TearDownTest() {
+	suite.tagServiceMock.ExpectedCalls = nil
+	suite.tagServiceMock.Calls = nil
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (suite *HandlerTestSuite) TestGivenStoredTags_WhenGetAll_ThenReturnStatusOkWithTheTags() {
+	reimbursable := suite.getTag("reimbursable")
+	vacation := suite.getTag("vacation-2026")
+	suite.tagServiceMock.MockGetAll([]interface{}{suite.userId, suite.ledgerId}, []interface{}{[]*models.Tag{reimbursable, vacation}, nil}, 1)
+
+	c, rec := suite.mockRequest(http.MethodGet, "", "")
+	handler := tag.NewHandler(suite.tagServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(`{"tags":[{"id":"%s","name":"reimbursable"},{"id":"%s","name":"vacation-2026"}]}`+"\n", reimbursable.Id(), vacation.Id())
+	if assert.NoError(suite.T(), handler.GetAll(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenANewName_WhenRename_ThenReturnStatusOkWithTheRenamedTag() {
+	renamedTag := suite.getTag("vacation-2026")
+	command, _ := tagService.NewRenameCommand(renamedTag.Id(), "Vacation-2026")
+	suite.tagServiceMock.MockRename([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{renamedTag, nil}, 1)
+
+	c, rec := suite.mockRequest(http.MethodPatch, renamedTag.Id().String(), `{"name":"Vacation-2026"}`)
+	handler := tag.NewHandler(suite.tagServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(`{"tag":{"id":"%s","name":"vacation-2026"}}`+"\n", renamedTag.Id())
+	if assert.NoError(suite.T(), handler.Rename(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenTheNameOfAnotherTag_WhenRename_ThenReturnStatusConflict() {
+	id := uuid.New()
+	command, _ := tagService.NewRenameCommand(id, "holidays")
+	serviceError := tagService.TagAlreadyExistsError{Msg: "a tag with the same name already exists, merge both tags instead"}
+	suite.tagServiceMock.MockRename([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{nil, serviceError}, 1)
+
+	c, rec := suite.mockRequest(http.MethodPatch, id.String(), `{"name":"holidays"}`)
+	handler := tag.NewHandler(suite.tagServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusConflict, serviceError.Msg, serviceError.Msg, "[]", 0)
+	if assert.NoError(suite.T(), handler.Rename(c)) {
+		assert.Equal(suite.T(), http.StatusConflict, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAnInvalidId_WhenRename_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequest(http.MethodPatch, "not-an-id", `{"name":"holidays"}`)
+	handler := tag.NewHandler(suite.tagServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Rename(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Contains(suite.T(), rec.Body.String(), tag.InvalidIdErrorMessage)
+	}
+	suite.tagServiceMock.AssertNotCalled(suite.T(), "Rename", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *HandlerTestSuite) TestGivenSourceTags_WhenMerge_ThenReturnStatusOkWithTheTargetTag() {
+	target := suite.getTag("vacation")
+	sourceIds := []uuid.UUID{uuid.New(), uuid.New()}
+	command, _ := tagService.NewMergeCommand(target.Id(), sourceIds)
+	suite.tagServiceMock.MockMerge([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{target, nil}, 1)
+
+	c, rec := suite.mockRequest(http.MethodPost, target.Id().String(), fmt.Sprintf(`{"tag_ids":["%s","%s"]}`, sourceIds[0], sourceIds[1]))
+	handler := tag.NewHandler(suite.tagServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(`{"tag":{"id":"%s","name":"vacation"}}`+"\n", target.Id())
+	if assert.NoError(suite.T(), handler.Merge(c)) {
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenNoSourceTags_WhenMerge_ThenReturnStatusBadRequest() {
+	c, rec := suite.mockRequest(http.MethodPost, uuid.New().String(), `{"tag_ids":[]}`)
+	handler := tag.NewHandler(suite.tagServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Merge(c)) {
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+		assert.Contains(suite.T(), rec.Body.String(), tag.FieldValidationErrorMessage)
+	}
+	suite.tagServiceMock.AssertNotCalled(suite.T(), "Merge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *HandlerTestSuite) TestGivenANonExistentSourceTag_WhenMerge_ThenReturnStatusNotFound() {
+	targetId := uuid.New()
+	sourceId := uuid.New()
+	command, _ := tagService.NewMergeCommand(targetId, []uuid.UUID{sourceId})
+	serviceError := tagService.TagNotFoundError{Msg: "the tag doesn't exists"}
+	suite.tagServiceMock.MockMerge([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{nil, serviceError}, 1)
+
+	c, rec := suite.mockRequest(http.MethodPost, targetId.String(), fmt.Sprintf(`{"tag_ids":["%s"]}`, sourceId))
+	handler := tag.NewHandler(suite.tagServiceMock, suite.getValidator())
+
+	expectedResponseBody := fmt.Sprintf(errorResponse, http.StatusNotFound, serviceError.Msg, serviceError.Msg, "[]", 0)
+	if assert.NoError(suite.T(), handler.Merge(c)) {
+		assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+		assert.Equal(suite.T(), expectedResponseBody, rec.Body.String())
+	}
+}
+
+func (suite *HandlerTestSuite) TestGivenAViewerOfTheLedger_WhenMerge_ThenReturnStatusForbidden() {
+	targetId := uuid.New()
+	sourceId := uuid.New()
+	command, _ := tagService.NewMergeCommand(targetId, []uuid.UUID{sourceId})
+	serviceError := ledgerService.ForbiddenError{Msg: "forbidden"}
+	suite.tagServiceMock.MockMerge([]interface{}{suite.userId, suite.ledgerId, command}, []interface{}{nil, serviceError}, 1)
+
+	c, rec := suite.mockRequest(http.MethodPost, targetId.String(), fmt.Sprintf(`{"tag_ids":["%s"]}`, sourceId))
+	handler := tag.NewHandler(suite.tagServiceMock, suite.getValidator())
+
+	if assert.NoError(suite.T(), handler.Merge(c)) {
+		assert.Equal(suite.T(), http.StatusForbidden, rec.Code)
+	}
+}
+
+func (suite *HandlerTestSuite) getTag(name string) *models.Tag {
+	newTag, _ := models.NewTagWithId(uuid.New(), name)
+	return newTag
+}
+
+func (suite *HandlerTestSuite) getValidator() fieldvalidation.FieldsValidator {
+	validator, _ := fieldvalidation.RegisterFieldsValidator(nil, nil)
+	return validator
+}
+
+// mockRequest builds a request to a tag, or to the list of tags when id is empty.
+func (suite *HandlerTestSuite) mockRequest(method string, id string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, "/tags/"+id, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	rest.SetUserId(c, suite.userId)
+	rest.SetLedgerId(c, suite.ledgerId)
+	if id != "" {
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+	}
+	return c, rec
+}
@@ -0,0 +1,97 @@
+package local
+
+import (
+	"errors"
+	"finfit-backend/internal/domain/services/attachment"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	blobNotFoundErrorMsg = "there's no content stored under the key"
+	directoryPermissions = 0o750
+	filePermissions      = 0o640
+)
+
+// blobStore keeps every blob as a file under the root directory, with the segments of the key as subdirectories.
+type blobStore struct {
+	root string
+}
+
+func NewBlobStore(root string) *blobStore {
+	return &blobStore{root: root}
+}
+
+// Put writes the content to a temporary file first and renames it, so a failed write never leaves half a file under
+// the key.
+func (b blobStore) Put(key string, content []byte, contentType string) error {
+	blobPath, err := b.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(blobPath), directoryPermissions); err != nil {
+		return err
+	}
+
+	temporaryFile, err := os.CreateTemp(filepath.Dir(blobPath), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temporaryFile.Name())
+
+	if _, err = temporaryFile.Write(content); err != nil {
+		temporaryFile.Close()
+		return err
+	}
+
+	if err = temporaryFile.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Chmod(temporaryFile.Name(), filePermissions); err != nil {
+		return err
+	}
+
+	return os.Rename(temporaryFile.Name(), blobPath)
+}
+
+func (b blobStore) Get(key string) (io.ReadCloser, error) {
+	blobPath, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(blobPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, attachment.BlobNotFoundError{Msg: blobNotFoundErrorMsg}
+	}
+
+	return file, err
+}
+
+func (b blobStore) Delete(key string) error {
+	blobPath, err := b.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(blobPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// path rejects the keys that could point outside the root directory.
+func (b blobStore) path(key string) (string, error) {
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." || strings.ContainsRune(segment, '\\') {
+			return "", errors.New("invalid blob key " + key)
+		}
+	}
+
+	return filepath.Join(b.root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,80 @@
+package local_test
+
+import (
+	"finfit-backend/internal/domain/services/attachment"
+	"finfit-backend/internal/infrastructure/repository/blobstore/local"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type BlobStoreTestSuite struct {
+	suite.Suite
+	root      string
+	blobStore attachment.BlobStore
+}
+
+func (suite *BlobStoreTestSuite) SetupTest() {
+	suite.root = suite.T().TempDir()
+	suite.blobStore = local.NewBlobStore(suite.root)
+}
+
+func TestBlobStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(BlobStoreTestSuite))
+}
+
+func (suite *BlobStoreTestSuite) TestGivenAStoredBlob_WhenGet_ThenReturnItsContent() {
+	require.NoError(suite.T(), suite.blobStore.Put("ledger/expense/receipt", []byte("%PDF-1.4"), "application/pdf"))
+
+	content, err := suite.blobStore.Get("ledger/expense/receipt")
+
+	require.NoError(suite.T(), err)
+	defer content.Close()
+	readContent, _ := io.ReadAll(content)
+	assert.Equal(suite.T(), "%PDF-1.4", string(readContent))
+	assert.FileExists(suite.T(), filepath.Join(suite.root, "ledger", "expense", "receipt"))
+}
+
+func (suite *BlobStoreTestSuite) TestGivenAStoredBlob_WhenPutAgain_ThenReplaceItsContent() {
+	require.NoError(suite.T(), suite.blobStore.Put("ledger/expense/receipt", []byte("first"), "application/pdf"))
+	require.NoError(suite.T(), suite.blobStore.Put("ledger/expense/receipt", []byte("second"), "application/pdf"))
+
+	content, err := suite.blobStore.Get("ledger/expense/receipt")
+
+	require.NoError(suite.T(), err)
+	defer content.Close()
+	readContent, _ := io.ReadAll(content)
+	assert.Equal(suite.T(), "second", string(readContent))
+	entries, _ := os.ReadDir(filepath.Join(suite.root, "ledger", "expense"))
+	assert.Len(suite.T(), entries, 1)
+}
+
+func (suite *BlobStoreTestSuite) TestGivenAMissingBlob_WhenGet_ThenReturnBlobNotFoundError() {
+	content, err := suite.blobStore.Get("ledger/expense/missing")
+
+	require.Nil(suite.T(), content)
+	assert.ErrorAs(suite.T(), err, &attachment.BlobNotFoundError{})
+}
+
+func (suite *BlobStoreTestSuite) TestGivenAMissingBlob_WhenDelete_ThenDoNothing() {
+	assert.NoError(suite.T(), suite.blobStore.Delete("ledger/expense/missing"))
+}
+
+func (suite *BlobStoreTestSuite) TestGivenAStoredBlob_WhenDelete_ThenRemoveIt() {
+	require.NoError(suite.T(), suite.blobStore.Put("ledger/expense/receipt", []byte("%PDF-1.4"), "application/pdf"))
+
+	require.NoError(suite.T(), suite.blobStore.Delete("ledger/expense/receipt"))
+
+	assert.NoFileExists(suite.T(), filepath.Join(suite.root, "ledger", "expense", "receipt"))
+}
+
+func (suite *BlobStoreTestSuite) TestGivenAKeyOutsideTheRoot_WhenPut_ThenReturnError() {
+	for _, key := range []string{"../escaped", "ledger/../../escaped", "/absolute", "ledger//receipt", `ledger\..\escaped`} {
+		assert.Error(suite.T(), suite.blobStore.Put(key, []byte("content"), "application/pdf"), key)
+	}
+	assert.NoFileExists(suite.T(), filepath.Join(filepath.Dir(suite.root), "escaped"))
+}
@@ -0,0 +1,127 @@
+package s3
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"finfit-backend/internal/domain/services/attachment"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	blobNotFoundErrorMsg = "there's no content stored under the key"
+	// maxErrorBodySize bounds how much of an error response is read to find its code.
+	maxErrorBodySize = 64 << 10
+)
+
+// blobStore keeps the blobs as objects of a bucket of S3 or of any store compatible with its API, like MinIO. The
+// objects are addressed path-style, endpoint/bucket/key, which every compatible store supports.
+type blobStore struct {
+	endpoint string
+	bucket   string
+	signer   signer
+	client   *http.Client
+	now      func() time.Time
+}
+
+func NewBlobStore(endpoint string, region string, bucket string, accessKeyId string, secretAccessKey string, client *http.Client) *blobStore {
+	return &blobStore{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		bucket:   bucket,
+		signer:   signer{accessKeyId: accessKeyId, secretAccessKey: secretAccessKey, region: region},
+		client:   client,
+		now:      time.Now,
+	}
+}
+
+func (b blobStore) Put(key string, content []byte, contentType string) error {
+	response, err := b.do(http.MethodPut, key, content, contentType)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return readError(response)
+	}
+
+	return nil
+}
+
+func (b blobStore) Get(key string) (io.ReadCloser, error) {
+	response, err := b.do(http.MethodGet, key, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		response.Body.Close()
+		return nil, attachment.BlobNotFoundError{Msg: blobNotFoundErrorMsg}
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		return nil, readError(response)
+	}
+
+	return response.Body, nil
+}
+
+// Delete succeeds for missing objects too, since S3 doesn't tell them apart.
+func (b blobStore) Delete(key string) error {
+	response, err := b.do(http.MethodDelete, key, nil, "")
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNotFound {
+		return readError(response)
+	}
+
+	return nil
+}
+
+func (b blobStore) do(method string, key string, content []byte, contentType string) (*http.Response, error) {
+	request, err := http.NewRequest(method, b.endpoint+"/"+encodePath(b.bucket)+"/"+encodePath(key), bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	b.signer.sign(request, hashHex(content), b.now())
+	return b.client.Do(request)
+}
+
+// encodePath escapes every segment of the path as the signature expects, which escapes more than url.PathEscape.
+func encodePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(url.QueryEscape(segment), "+", "%20")
+	}
+	return strings.Join(segments, "/")
+}
+
+type errorResponse struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func readError(response *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+
+	storeError := errorResponse{}
+	if err := xml.Unmarshal(body, &storeError); err != nil || storeError.Code == "" {
+		return errors.New("the blob store answered " + response.Status)
+	}
+
+	return fmt.Errorf("the blob store answered %s: %s %s", response.Status, storeError.Code, storeError.Message)
+}
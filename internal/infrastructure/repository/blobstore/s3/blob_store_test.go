@@ -0,0 +1,131 @@
+package s3_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"finfit-backend/internal/domain/services/attachment"
+	"finfit-backend/internal/infrastructure/repository/blobstore/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	bucket      = "receipts"
+	accessKeyId = "minio"
+)
+
+// objectStoreStub answers the object requests of the S3 API like MinIO does, keeping the objects in memory. It
+// rejects the requests that aren't signed or whose payload doesn't match the signed hash.
+type objectStoreStub struct {
+	mutex        sync.Mutex
+	objects      map[string][]byte
+	contentTypes map[string]string
+}
+
+func (s *objectStoreStub) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	body, _ := io.ReadAll(request.Body)
+	payloadHash := sha256.Sum256(body)
+	if !strings.HasPrefix(request.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential="+accessKeyId+"/") ||
+		request.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(payloadHash[:]) {
+		s.writeError(writer, http.StatusForbidden, "SignatureDoesNotMatch")
+		return
+	}
+
+	if !strings.HasPrefix(request.URL.Path, "/"+bucket+"/") {
+		s.writeError(writer, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	key := strings.TrimPrefix(request.URL.Path, "/"+bucket+"/")
+	switch request.Method {
+	case http.MethodPut:
+		s.objects[key] = body
+		s.contentTypes[key] = request.Header.Get("Content-Type")
+		writer.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		object, ok := s.objects[key]
+		if !ok {
+			s.writeError(writer, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		writer.Header().Set("Content-Type", s.contentTypes[key])
+		writer.Write(object)
+	case http.MethodDelete:
+		delete(s.objects, key)
+		writer.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *objectStoreStub) writeError(writer http.ResponseWriter, statusCode int, code string) {
+	writer.Header().Set("Content-Type", "application/xml")
+	writer.WriteHeader(statusCode)
+	writer.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>` + code + `</Code><Message>stub error</Message></Error>`))
+}
+
+type BlobStoreTestSuite struct {
+	suite.Suite
+	stub      *objectStoreStub
+	server    *httptest.Server
+	blobStore attachment.BlobStore
+}
+
+func (suite *BlobStoreTestSuite) SetupTest() {
+	suite.stub = &objectStoreStub{objects: map[string][]byte{}, contentTypes: map[string]string{}}
+	suite.server = httptest.NewServer(suite.stub)
+	suite.blobStore = s3.NewBlobStore(suite.server.URL, "us-east-1", bucket, accessKeyId, "minio-secret", suite.server.Client())
+}
+
+func (suite *BlobStoreTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func TestBlobStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(BlobStoreTestSuite))
+}
+
+func (suite *BlobStoreTestSuite) TestGivenAStoredBlob_WhenGet_ThenReturnItsContent() {
+	require.NoError(suite.T(), suite.blobStore.Put("ledger/expense/receipt", []byte("%PDF-1.4"), "application/pdf"))
+
+	content, err := suite.blobStore.Get("ledger/expense/receipt")
+
+	require.NoError(suite.T(), err)
+	defer content.Close()
+	readContent, _ := io.ReadAll(content)
+	assert.Equal(suite.T(), "%PDF-1.4", string(readContent))
+	assert.Equal(suite.T(), "application/pdf", suite.stub.contentTypes["ledger/expense/receipt"])
+}
+
+func (suite *BlobStoreTestSuite) TestGivenAMissingBlob_WhenGet_ThenReturnBlobNotFoundError() {
+	content, err := suite.blobStore.Get("ledger/expense/missing")
+
+	require.Nil(suite.T(), content)
+	assert.ErrorAs(suite.T(), err, &attachment.BlobNotFoundError{})
+}
+
+func (suite *BlobStoreTestSuite) TestGivenAStoredBlob_WhenDelete_ThenRemoveIt() {
+	require.NoError(suite.T(), suite.blobStore.Put("ledger/expense/receipt", []byte("%PDF-1.4"), "application/pdf"))
+
+	require.NoError(suite.T(), suite.blobStore.Delete("ledger/expense/receipt"))
+
+	assert.Empty(suite.T(), suite.stub.objects)
+	assert.NoError(suite.T(), suite.blobStore.Delete("ledger/expense/receipt"))
+}
+
+func (suite *BlobStoreTestSuite) TestGivenABucketThatNotExists_WhenPut_ThenReturnTheErrorOfTheStore() {
+	blobStore := s3.NewBlobStore(suite.server.URL, "us-east-1", "missing-bucket", accessKeyId, "minio-secret", suite.server.Client())
+
+	err := blobStore.Put("ledger/expense/receipt", []byte("%PDF-1.4"), "application/pdf")
+
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "NoSuchBucket")
+}
@@ -0,0 +1,174 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+var (
+	errDuplicateAccount = errors.New("there's already an account with the same id")
+	errAccountNotFound  = errors.New("the account doesn't exist")
+)
+
+type accountRecord struct {
+	userId  uuid.UUID
+	account *models.Account
+}
+
+type transferRecord struct {
+	userId   uuid.UUID
+	transfer *models.Transfer
+}
+
+type accountRepository struct {
+	db *Database
+}
+
+func NewAccountRepository(db *Database) *accountRepository {
+	return &accountRepository{db: db}
+}
+
+func (r accountRepository) Add(ctx context.Context, userId uuid.UUID, account *models.Account) (*models.Account, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if _, ok := r.db.accounts[account.Id()]; ok {
+		return nil, errDuplicateAccount
+	}
+
+	if !r.db.hasUser(userId) {
+		return nil, errUserNotFound
+	}
+
+	r.db.accounts[account.Id()] = accountRecord{userId: userId, account: account}
+	return account, nil
+}
+
+func (r accountRepository) GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Account, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	record, ok := r.db.accounts[id]
+	if !ok || record.userId != userId {
+		return nil, nil
+	}
+	return record.account, nil
+}
+
+// GetAll returns the accounts of the user ordered by name.
+func (r accountRepository) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.Account, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	accounts := []*models.Account{}
+	for _, record := range r.db.accounts {
+		if record.userId == userId {
+			accounts = append(accounts, record.account)
+		}
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Name() < accounts[j].Name()
+	})
+	return accounts, nil
+}
+
+func (r accountRepository) AddTransfer(ctx context.Context, userId uuid.UUID, transfer *models.Transfer) (*models.Transfer, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	for _, account := range []*models.Account{transfer.FromAccount(), transfer.ToAccount()} {
+		if _, ok := r.db.accounts[account.Id()]; !ok {
+			return nil, errAccountNotFound
+		}
+	}
+
+	if !r.db.hasUser(userId) {
+		return nil, errUserNotFound
+	}
+
+	r.db.transfers = append(r.db.transfers, transferRecord{userId: userId, transfer: transfer})
+	return transfer, nil
+}
+
+// GetExpensesTotal sums the expenses paid from the account up to the given date, inclusive, in any ledger. Expenses
+// belong to ledgers, which may be shared, so the owner of the account is checked instead.
+func (r accountRepository) GetExpensesTotal(ctx context.Context, userId uuid.UUID, account *models.Account, until time.Time) (*models.Money, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	amounts := []*models.Money{}
+	if record, ok := r.db.accounts[account.Id()]; ok && record.userId == userId {
+		for _, expenseRecord := range r.db.expenses {
+			expense := expenseRecord.expense
+			if expense.Account() != nil && expense.Account().Id() == account.Id() && isOnOrBefore(expense.ExpenseDate(), until) {
+				amounts = append(amounts, expense.Amount())
+			}
+		}
+	}
+
+	return sumAmounts(amounts, account.Currency())
+}
+
+// GetTransfersTotals sums the transfers received and sent by the account up to the given date, inclusive.
+func (r accountRepository) GetTransfersTotals(ctx context.Context, userId uuid.UUID, account *models.Account, until time.Time) (*models.Money, *models.Money, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	incomingAmounts, outgoingAmounts := []*models.Money{}, []*models.Money{}
+	for _, record := range r.db.transfers {
+		transfer := record.transfer
+		if record.userId != userId || !isOnOrBefore(transfer.TransferDate(), until) {
+			continue
+		}
+
+		if transfer.ToAccount().Id() == account.Id() {
+			incomingAmounts = append(incomingAmounts, transfer.Amount())
+		}
+		if transfer.FromAccount().Id() == account.Id() {
+			outgoingAmounts = append(outgoingAmounts, transfer.Amount())
+		}
+	}
+
+	incoming, err := sumAmounts(incomingAmounts, account.Currency())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outgoing, err := sumAmounts(outgoingAmounts, account.Currency())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return incoming, outgoing, nil
+}
+
+// sumAmounts adds up the amounts in the currency, like summing the amount column does whatever the currency of the
+// rows.
+func sumAmounts(amounts []*models.Money, currency string) (*models.Money, error) {
+	total, err := models.NewMoneyFromMinorUnits(0, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, amount := range amounts {
+		money, err := models.NewMoney(amount.Amount(), currency)
+		if err != nil {
+			return nil, err
+		}
+
+		if total, err = total.Add(money); err != nil {
+			return nil, err
+		}
+	}
+	return total, nil
+}
+
+// isOnOrBefore compares the dates alone.
+func isOnOrBefore(date time.Time, until time.Time) bool {
+	return date.Format(dateFormat) <= until.Format(dateFormat)
+}
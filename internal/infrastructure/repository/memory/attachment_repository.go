@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"sort"
+)
+
+var errDuplicateAttachment = errors.New("the expense already has an attachment with the same id or content")
+
+// attachmentRecord keeps the attachment as it was stored. It's deleted along with its expense.
+type attachmentRecord struct {
+	ledgerId   uuid.UUID
+	expenseId  uuid.UUID
+	attachment *models.Attachment
+}
+
+type attachmentRepository struct {
+	db *Database
+}
+
+func NewAttachmentRepository(db *Database) *attachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+func (r attachmentRepository) Add(ctx context.Context, ledgerId uuid.UUID, attachment *models.Attachment) (*models.Attachment, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if _, ok := r.db.expenses[attachment.ExpenseId()]; !ok {
+		return nil, errExpenseNotFound
+	}
+
+	for id, record := range r.db.attachments {
+		if id == attachment.Id() || (record.expenseId == attachment.ExpenseId() && record.attachment.Checksum() == attachment.Checksum()) {
+			return nil, errDuplicateAttachment
+		}
+	}
+
+	r.db.attachments[attachment.Id()] = attachmentRecord{ledgerId: ledgerId, expenseId: attachment.ExpenseId(), attachment: attachment}
+	return attachment, nil
+}
+
+// GetAll returns the attachments of the expense in the order they were uploaded.
+func (r attachmentRepository) GetAll(ctx context.Context, ledgerId uuid.UUID, expenseId uuid.UUID) ([]*models.Attachment, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	attachments := []*models.Attachment{}
+	for _, record := range r.db.attachments {
+		if record.ledgerId == ledgerId && record.expenseId == expenseId {
+			attachments = append(attachments, record.attachment)
+		}
+	}
+
+	sort.Slice(attachments, func(i, j int) bool {
+		if !attachments[i].UploadedAt().Equal(attachments[j].UploadedAt()) {
+			return attachments[i].UploadedAt().Before(attachments[j].UploadedAt())
+		}
+		return attachments[i].Id().String() < attachments[j].Id().String()
+	})
+	return attachments, nil
+}
+
+func (r attachmentRepository) GetByID(ctx context.Context, ledgerId uuid.UUID, expenseId uuid.UUID, id uuid.UUID) (*models.Attachment, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	record, ok := r.db.attachments[id]
+	if !ok || record.ledgerId != ledgerId || record.expenseId != expenseId {
+		return nil, nil
+	}
+	return record.attachment, nil
+}
+
+func (r attachmentRepository) GetByChecksum(ctx context.Context, ledgerId uuid.UUID, expenseId uuid.UUID, checksum string) (*models.Attachment, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	for _, record := range r.db.attachments {
+		if record.ledgerId == ledgerId && record.expenseId == expenseId && record.attachment.Checksum() == checksum {
+			return record.attachment, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r attachmentRepository) Delete(ctx context.Context, ledgerId uuid.UUID, expenseId uuid.UUID, id uuid.UUID) error {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if record, ok := r.db.attachments[id]; ok && record.ledgerId == ledgerId && record.expenseId == expenseId {
+		delete(r.db.attachments, id)
+	}
+	return nil
+}
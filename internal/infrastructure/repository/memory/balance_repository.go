@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"sort"
+)
+
+// settlementRecord keeps the settlement as it was stored. The settlements are kept in the order they were added, like
+// the created_at column sorts them.
+type settlementRecord struct {
+	ledgerId   uuid.UUID
+	settlement *models.Settlement
+}
+
+type balanceRepository struct {
+	db *Database
+}
+
+func NewBalanceRepository(db *Database) *balanceRepository {
+	return &balanceRepository{db: db}
+}
+
+// GetDebts reads the allocations of the split expenses of the ledger. The payer doesn't owe their own part, and
+// participants with nothing allocated owe nothing.
+func (r balanceRepository) GetDebts(ctx context.Context, ledgerId uuid.UUID) ([]*models.Debt, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	debts := []*models.Debt{}
+	for _, record := range r.db.expenses {
+		split := record.expense.Split()
+		if record.ledgerId != ledgerId || split == nil {
+			continue
+		}
+
+		for _, allocation := range split.Allocations() {
+			if allocation.UserId() == split.PaidBy() || !allocation.Amount().IsPositive() {
+				continue
+			}
+
+			debt, err := models.NewDebt(allocation.UserId(), split.PaidBy(), allocation.Amount())
+			if err != nil {
+				return nil, err
+			}
+			debts = append(debts, debt)
+		}
+	}
+	return debts, nil
+}
+
+// GetSettlements returns the settlements of the ledger ordered by the day they were settled.
+func (r balanceRepository) GetSettlements(ctx context.Context, ledgerId uuid.UUID) ([]*models.Settlement, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	settlements := []*models.Settlement{}
+	for _, record := range r.db.settlements {
+		if record.ledgerId == ledgerId {
+			settlements = append(settlements, record.settlement)
+		}
+	}
+
+	sort.SliceStable(settlements, func(i, j int) bool {
+		return settlements[i].SettledAt().Format(dateFormat) < settlements[j].SettledAt().Format(dateFormat)
+	})
+	return settlements, nil
+}
+
+func (r balanceRepository) AddSettlement(ctx context.Context, ledgerId uuid.UUID, settlement *models.Settlement) (*models.Settlement, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if _, ok := r.db.ledgers[ledgerId]; !ok {
+		return nil, errLedgerNotFound
+	}
+
+	if !r.db.hasUser(settlement.From()) || !r.db.hasUser(settlement.To()) {
+		return nil, errUserNotFound
+	}
+
+	r.db.settlements = append(r.db.settlements, settlementRecord{ledgerId: ledgerId, settlement: settlement})
+	return settlement, nil
+}
@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"sort"
+)
+
+var errDuplicateBudget = errors.New("there's already a budget with the same id, or for the same expense type and period")
+
+// budgetRecord keeps the expense type by id, like the expense_type_id column, so renaming it is seen by the budget. It
+// is deleted along with its expense type.
+type budgetRecord struct {
+	userId        uuid.UUID
+	id            uuid.UUID
+	expenseTypeId uuid.UUID
+	period        models.BudgetPeriod
+	limit         *models.Money
+	rollover      bool
+}
+
+type budgetRepository struct {
+	db *Database
+}
+
+func NewBudgetRepository(db *Database) *budgetRepository {
+	return &budgetRepository{db: db}
+}
+
+func (r budgetRepository) Add(ctx context.Context, userId uuid.UUID, budget *models.Budget) (*models.Budget, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if _, ok := r.db.budgets[budget.Id()]; ok {
+		return nil, errDuplicateBudget
+	}
+
+	if !r.db.hasUser(userId) {
+		return nil, errUserNotFound
+	}
+
+	record := newBudgetRecord(userId, budget)
+	if err := r.db.validateBudget(record); err != nil {
+		return nil, err
+	}
+
+	r.db.budgets[record.id] = record
+	return budget, nil
+}
+
+func (r budgetRepository) GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Budget, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	record, ok := r.db.budgets[id]
+	if !ok || record.userId != userId {
+		return nil, nil
+	}
+
+	return r.db.mapToDomainBudget(record)
+}
+
+func (r budgetRepository) GetByExpenseTypeAndPeriod(ctx context.Context, userId uuid.UUID, expenseTypeId uuid.UUID, period models.BudgetPeriod) (*models.Budget, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	for _, record := range r.db.budgets {
+		if record.userId == userId && record.expenseTypeId == expenseTypeId && record.period == period {
+			return r.db.mapToDomainBudget(record)
+		}
+	}
+	return nil, nil
+}
+
+// GetAll returns the budgets of the user ordered by the name of their expense type.
+func (r budgetRepository) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.Budget, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	budgets := []*models.Budget{}
+	for _, record := range r.db.budgets {
+		if record.userId != userId {
+			continue
+		}
+
+		budget, err := r.db.mapToDomainBudget(record)
+		if err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, budget)
+	}
+
+	sort.Slice(budgets, func(i, j int) bool {
+		if budgets[i].ExpenseType().Name() != budgets[j].ExpenseType().Name() {
+			return budgets[i].ExpenseType().Name() < budgets[j].ExpenseType().Name()
+		}
+		return budgets[i].Id().String() < budgets[j].Id().String()
+	})
+	return budgets, nil
+}
+
+// Update replaces the expense type, the period, the limit and the rollover of the budget. Updating a budget that
+// doesn't exist changes nothing.
+func (r budgetRepository) Update(ctx context.Context, userId uuid.UUID, budget *models.Budget) (*models.Budget, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if storedRecord, ok := r.db.budgets[budget.Id()]; !ok || storedRecord.userId != userId {
+		return budget, nil
+	}
+
+	record := newBudgetRecord(userId, budget)
+	if err := r.db.validateBudget(record); err != nil {
+		return nil, err
+	}
+
+	r.db.budgets[record.id] = record
+	return budget, nil
+}
+
+func (r budgetRepository) Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if record, ok := r.db.budgets[id]; ok && record.userId == userId {
+		delete(r.db.budgets, id)
+	}
+	return nil
+}
+
+func newBudgetRecord(userId uuid.UUID, budget *models.Budget) budgetRecord {
+	return budgetRecord{
+		userId:        userId,
+		id:            budget.Id(),
+		expenseTypeId: budget.ExpenseType().Id(),
+		period:        budget.Period(),
+		limit:         budget.Limit(),
+		rollover:      budget.Rollover(),
+	}
+}
+
+// validateBudget checks the constraints of the budget table: the expense type must exist and there can only be one
+// budget per expense type and period. The caller must hold the lock.
+func (db *Database) validateBudget(record budgetRecord) error {
+	if _, ok := db.expenseTypes[record.expenseTypeId]; !ok {
+		return errExpenseTypeNotFound
+	}
+
+	for _, otherRecord := range db.budgets {
+		if otherRecord.id != record.id && otherRecord.expenseTypeId == record.expenseTypeId && otherRecord.period == record.period {
+			return errDuplicateBudget
+		}
+	}
+	return nil
+}
+
+// mapToDomainBudget links the budget to the current state of its expense type. The caller must hold the lock.
+func (db *Database) mapToDomainBudget(record budgetRecord) (*models.Budget, error) {
+	expenseTypeRecord, ok := db.expenseTypes[record.expenseTypeId]
+	if !ok {
+		return nil, errExpenseTypeNotFound
+	}
+
+	expenseType, err := db.mapToDomainExpenseType(expenseTypeRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewBudgetWithId(record.id, expenseType, record.period, record.limit, record.rollover)
+}
@@ -0,0 +1,32 @@
+package memory_test
+
+import (
+	"finfit-backend/internal/infrastructure/repository/memory"
+	"finfit-backend/internal/infrastructure/repository/repositorytest"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+func newRepositories() repositorytest.Repositories {
+	db := memory.NewDatabase()
+	return repositorytest.Repositories{
+		Users:             memory.NewUserRepository(db),
+		ExpenseTypes:      memory.NewExpenseTypeRepository(db),
+		Expenses:          memory.NewExpenseRepository(db),
+		Budgets:           memory.NewBudgetRepository(db),
+		RecurringExpenses: memory.NewRecurringExpenseRepository(db),
+		Reports:           memory.NewReportRepository(db),
+	}
+}
+
+func TestExpenseTypeRepositoryContract(t *testing.T) {
+	suite.Run(t, &repositorytest.ExpenseTypeRepositoryContract{NewRepositories: newRepositories})
+}
+
+func TestExpenseRepositoryContract(t *testing.T) {
+	suite.Run(t, &repositorytest.ExpenseRepositoryContract{NewRepositories: newRepositories})
+}
+
+func TestReportRepositoryContract(t *testing.T) {
+	suite.Run(t, &repositorytest.ReportRepositoryContract{NewRepositories: newRepositories})
+}
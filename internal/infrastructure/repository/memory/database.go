@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"github.com/google/uuid"
+	"sync"
+)
+
+// Database keeps every entity in memory, for tests and for running the app without a database. All the repositories
+// share one, so they check the same constraints the foreign keys of the SQL database do: expense types referenced by
+// expenses or recurring expenses can't be deleted and take their budgets with them, expenses take their attachments
+// with them, and expenses read the current name and parent of their expense type and the current names of their tags.
+// Its content is lost when the app stops.
+type Database struct {
+	mutex             sync.RWMutex
+	users             []userRecord
+	usedRefreshTokens map[string]usedRefreshTokenRecord
+	ledgers           map[uuid.UUID]ledgerRecord
+	ledgerMembers     []ledgerMemberRecord
+	ledgerInvitations map[uuid.UUID]ledgerInvitationRecord
+	expenseTypes      map[uuid.UUID]expenseTypeRecord
+	expenses          map[uuid.UUID]expenseRecord
+	tags              map[uuid.UUID]tagRecord
+	attachments       map[uuid.UUID]attachmentRecord
+	settlements       []settlementRecord
+	accounts          map[uuid.UUID]accountRecord
+	transfers         []transferRecord
+	budgets           map[uuid.UUID]budgetRecord
+	recurringExpenses map[uuid.UUID]recurringExpenseRecord
+	incomeSources     map[uuid.UUID]incomeSourceRecord
+	incomes           map[uuid.UUID]incomeRecord
+	exchangeRates     map[exchangeRateKey]exchangeRateRecord
+}
+
+func NewDatabase() *Database {
+	return &Database{
+		usedRefreshTokens: map[string]usedRefreshTokenRecord{},
+		ledgers:           map[uuid.UUID]ledgerRecord{},
+		ledgerInvitations: map[uuid.UUID]ledgerInvitationRecord{},
+		expenseTypes:      map[uuid.UUID]expenseTypeRecord{},
+		expenses:          map[uuid.UUID]expenseRecord{},
+		tags:              map[uuid.UUID]tagRecord{},
+		attachments:       map[uuid.UUID]attachmentRecord{},
+		accounts:          map[uuid.UUID]accountRecord{},
+		budgets:           map[uuid.UUID]budgetRecord{},
+		recurringExpenses: map[uuid.UUID]recurringExpenseRecord{},
+		incomeSources:     map[uuid.UUID]incomeSourceRecord{},
+		incomes:           map[uuid.UUID]incomeRecord{},
+		exchangeRates:     map[exchangeRateKey]exchangeRateRecord{},
+	}
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"time"
+)
+
+// exchangeRateKey identifies a rate like the primary key of the exchange_rate table.
+type exchangeRateKey struct {
+	baseCurrency  string
+	quoteCurrency string
+	date          string
+}
+
+type exchangeRateRecord struct {
+	rate *models.ExchangeRate
+}
+
+type exchangeRateRepository struct {
+	db *Database
+}
+
+func NewExchangeRateRepository(db *Database) *exchangeRateRepository {
+	return &exchangeRateRepository{db: db}
+}
+
+// Save stores the rates replacing the ones already stored for the same currencies and date.
+func (r exchangeRateRepository) Save(ctx context.Context, rates []*models.ExchangeRate) error {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	for _, rate := range rates {
+		r.db.exchangeRates[newExchangeRateKey(rate.BaseCurrency(), rate.QuoteCurrency(), rate.Date())] = exchangeRateRecord{rate: rate}
+	}
+	return nil
+}
+
+// GetRate looks for the latest rate up to the date in both directions. When both are stored for the same day the
+// requested one wins.
+func (r exchangeRateRepository) GetRate(ctx context.Context, baseCurrency string, quoteCurrency string, date time.Time) (*models.ExchangeRate, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	var found *exchangeRateKey
+	for key := range r.db.exchangeRates {
+		key := key
+		isRequested := key.baseCurrency == baseCurrency && key.quoteCurrency == quoteCurrency
+		isInverse := key.baseCurrency == quoteCurrency && key.quoteCurrency == baseCurrency
+		if (!isRequested && !isInverse) || key.date > date.Format(dateFormat) {
+			continue
+		}
+
+		if found == nil || key.date > found.date || (key.date == found.date && isRequested) {
+			found = &key
+		}
+	}
+
+	if found == nil {
+		return nil, nil
+	}
+	return r.db.exchangeRates[*found].rate, nil
+}
+
+func newExchangeRateKey(baseCurrency string, quoteCurrency string, date time.Time) exchangeRateKey {
+	return exchangeRateKey{baseCurrency: baseCurrency, quoteCurrency: quoteCurrency, date: date.Format(dateFormat)}
+}
@@ -0,0 +1,348 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	expenseService "finfit-backend/internal/domain/services/expense"
+	"github.com/google/uuid"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+)
+
+const dateFormat = "2006-01-02"
+
+var (
+	errDuplicateExpense = errors.New("the ledger already has an expense with the same id")
+	errExpenseNotFound  = errors.New("the expense doesn't exist")
+)
+
+// expenseRecord keeps the expense type and the tags by id, like the expense_type_id column and the expense_tag table,
+// and the rest of the expense as it was stored.
+type expenseRecord struct {
+	ledgerId      uuid.UUID
+	expenseTypeId uuid.UUID
+	tagIds        []uuid.UUID
+	expense       *models.Expense
+}
+
+type expenseRepository struct {
+	db *Database
+}
+
+func NewExpenseRepository(db *Database) *expenseRepository {
+	return &expenseRepository{db: db}
+}
+
+func (r expenseRepository) Add(ctx context.Context, ledgerId uuid.UUID, expense *models.Expense) (*models.Expense, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if err := r.db.validateExpenses([]*models.Expense{expense}); err != nil {
+		return nil, err
+	}
+
+	r.db.expenses[expense.Id()] = r.db.newExpenseRecord(ledgerId, expense)
+	return expense, nil
+}
+
+// AddAll stores either all the expenses or none of them.
+func (r expenseRepository) AddAll(ctx context.Context, ledgerId uuid.UUID, expenses []*models.Expense) error {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if err := r.db.validateExpenses(expenses); err != nil {
+		return err
+	}
+
+	for _, expense := range expenses {
+		r.db.expenses[expense.Id()] = r.db.newExpenseRecord(ledgerId, expense)
+	}
+	return nil
+}
+
+func (r expenseRepository) GetByFitIds(ctx context.Context, ledgerId uuid.UUID, fitIds []string) ([]*models.Expense, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	wantedFitIds := map[string]bool{}
+	for _, fitId := range fitIds {
+		wantedFitIds[fitId] = true
+	}
+
+	return r.db.findExpenses(ledgerId, func(expense *models.Expense) bool {
+		return expense.FitId() != "" && wantedFitIds[expense.FitId()]
+	})
+}
+
+// ForEachInPeriod consumes the expenses of the period ordered by date and id. The expenses are read before consuming
+// them, so consume may use the repository.
+func (r expenseRepository) ForEachInPeriod(ctx context.Context, ledgerId uuid.UUID, startDate time.Time, endDate time.Time, consume func(expense *models.Expense) error) error {
+	r.db.mutex.RLock()
+	expenses, err := r.db.findExpenses(ledgerId, func(expense *models.Expense) bool {
+		return isInPeriod(expense, startDate, endDate)
+	})
+	r.db.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	sortExpenses(expenses, expenseService.ExpenseDateAscSort)
+	for _, expense := range expenses {
+		if err = consume(expense); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r expenseRepository) SearchInPeriod(ctx context.Context, ledgerId uuid.UUID, criteria expenseService.SearchCriteria) ([]*models.Expense, error) {
+	expenseTypeIds := map[uuid.UUID]bool{}
+	for _, expenseTypeId := range criteria.ExpenseTypeIds {
+		expenseTypeIds[expenseTypeId] = true
+	}
+
+	description := strings.ToLower(criteria.Description)
+	sortBy := criteria.Sort
+	if sortBy == "" {
+		sortBy = expenseService.ExpenseDateDescSort
+	}
+
+	r.db.mutex.RLock()
+	expenses, err := r.db.findExpenses(ledgerId, func(expense *models.Expense) bool {
+		switch {
+		case !isInPeriod(expense, criteria.StartDate, criteria.EndDate),
+			len(expenseTypeIds) > 0 && !expenseTypeIds[expense.ExpenseType().Id()],
+			criteria.TagFilter != nil && !criteria.TagFilter.Matches(expense.Tags()),
+			criteria.MinAmount != "" && compareAmounts(expense.Amount().Amount(), criteria.MinAmount) < 0,
+			criteria.MaxAmount != "" && compareAmounts(expense.Amount().Amount(), criteria.MaxAmount) > 0,
+			criteria.Currency != "" && expense.Amount().Currency() != criteria.Currency,
+			description != "" && !strings.Contains(strings.ToLower(expense.Description()), description),
+			criteria.AccountId != uuid.Nil && (expense.Account() == nil || expense.Account().Id() != criteria.AccountId),
+			criteria.After != nil && !isAfterCursor(expense, sortBy, criteria.After):
+			return false
+		}
+		return true
+	})
+	r.db.mutex.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	sortExpenses(expenses, sortBy)
+	if criteria.Limit > 0 && len(expenses) > criteria.Limit {
+		expenses = expenses[:criteria.Limit]
+	}
+	return expenses, nil
+}
+
+func (r expenseRepository) GetByID(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (*models.Expense, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	record, ok := r.db.expenses[id]
+	if !ok || record.ledgerId != ledgerId {
+		return nil, nil
+	}
+
+	return r.db.mapToDomainExpense(record)
+}
+
+// Update replaces every field of the expense but its FITID, which only an import sets, along with its split and its
+// tags. Updating an expense that doesn't exist changes nothing.
+func (r expenseRepository) Update(ctx context.Context, ledgerId uuid.UUID, expense *models.Expense) (*models.Expense, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	record, ok := r.db.expenses[expense.Id()]
+	if !ok || record.ledgerId != ledgerId {
+		return expense, nil
+	}
+
+	if err := r.db.validateExpenseReferences(expense); err != nil {
+		return nil, err
+	}
+
+	r.db.expenses[expense.Id()] = r.db.newExpenseRecord(ledgerId, expense.WithFitId(record.expense.FitId()))
+	return expense, nil
+}
+
+// Delete removes the attachments of the expense along with it.
+func (r expenseRepository) Delete(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) error {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if record, ok := r.db.expenses[id]; ok && record.ledgerId == ledgerId {
+		delete(r.db.expenses, id)
+		for attachmentId, attachment := range r.db.attachments {
+			if attachment.expenseId == id {
+				delete(r.db.attachments, attachmentId)
+			}
+		}
+	}
+	return nil
+}
+
+// validateExpenses checks the constraints of the expense table: the ids must be new and the expense types and the
+// accounts must exist. The caller must hold the lock.
+func (db *Database) validateExpenses(expenses []*models.Expense) error {
+	ids := map[uuid.UUID]bool{}
+	for _, expense := range expenses {
+		if _, ok := db.expenses[expense.Id()]; ok || ids[expense.Id()] {
+			return errDuplicateExpense
+		}
+		ids[expense.Id()] = true
+
+		if err := db.validateExpenseReferences(expense); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateExpenseReferences checks the foreign keys of the expense table. The caller must hold the lock.
+func (db *Database) validateExpenseReferences(expense *models.Expense) error {
+	if _, ok := db.expenseTypes[expense.ExpenseType().Id()]; !ok {
+		return errExpenseTypeNotFound
+	}
+
+	if expense.Account() != nil {
+		if _, ok := db.accounts[expense.Account().Id()]; !ok {
+			return errAccountNotFound
+		}
+	}
+	return nil
+}
+
+// newExpenseRecord links the expense to the tags of the ledger with its tag names, creating the ones that don't exist
+// yet. The caller must hold the lock.
+func (db *Database) newExpenseRecord(ledgerId uuid.UUID, expense *models.Expense) expenseRecord {
+	tagIds := []uuid.UUID{}
+	for _, name := range expense.Tags() {
+		tagIds = append(tagIds, db.tagIdOf(ledgerId, name))
+	}
+
+	return expenseRecord{ledgerId: ledgerId, expenseTypeId: expense.ExpenseType().Id(), tagIds: tagIds, expense: expense}
+}
+
+// findExpenses returns the expenses of the ledger that pass the filter, in no particular order. The caller must hold
+// the lock.
+func (db *Database) findExpenses(ledgerId uuid.UUID, filter func(expense *models.Expense) bool) ([]*models.Expense, error) {
+	expenses := []*models.Expense{}
+	for _, record := range db.expenses {
+		if record.ledgerId != ledgerId {
+			continue
+		}
+
+		expense, err := db.mapToDomainExpense(record)
+		if err != nil {
+			return nil, err
+		}
+
+		if filter(expense) {
+			expenses = append(expenses, expense)
+		}
+	}
+	return expenses, nil
+}
+
+// mapToDomainExpense links the expense to the current state of its expense type and its tags. The caller must hold the
+// lock.
+func (db *Database) mapToDomainExpense(record expenseRecord) (*models.Expense, error) {
+	expenseTypeRecord, ok := db.expenseTypes[record.expenseTypeId]
+	if !ok {
+		return nil, errExpenseTypeNotFound
+	}
+
+	expenseType, err := db.mapToDomainExpenseType(expenseTypeRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := record.expense
+	expense, err := models.NewExpenseWithId(stored.Id(), stored.Amount(), stored.ExpenseDate(), stored.Description(), expenseType)
+	if err != nil {
+		return nil, err
+	}
+
+	if expense, err = expense.WithAccount(stored.Account()); err != nil {
+		return nil, err
+	}
+
+	if expense, err = expense.WithSplit(stored.Split()); err != nil {
+		return nil, err
+	}
+
+	if tags := db.tagNamesOf(record.tagIds); len(tags) > 0 {
+		if expense, err = expense.WithTags(tags); err != nil {
+			return nil, err
+		}
+	}
+
+	return expense.WithFitId(stored.FitId()), nil
+}
+
+// isInPeriod compares the dates alone, both bounds are inclusive.
+func isInPeriod(expense *models.Expense, startDate time.Time, endDate time.Time) bool {
+	expenseDate := expense.ExpenseDate().Format(dateFormat)
+	return expenseDate >= startDate.Format(dateFormat) && expenseDate <= endDate.Format(dateFormat)
+}
+
+// sortExpenses orders the expenses by the sort field and then by id, in the direction of the sort.
+func sortExpenses(expenses []*models.Expense, sortBy expenseService.SearchSort) {
+	sort.Slice(expenses, func(i, j int) bool {
+		comparison := compareExpenses(expenses[i], sortValue(expenses[j], sortBy), expenses[j].Id(), sortBy)
+		if sortBy.Descending() {
+			return comparison > 0
+		}
+		return comparison < 0
+	})
+}
+
+// isAfterCursor tells whether the expense comes after the last expense of the previous page, in the order of the sort.
+func isAfterCursor(expense *models.Expense, sortBy expenseService.SearchSort, cursor *expenseService.Cursor) bool {
+	comparison := compareExpenses(expense, cursor.Value(), cursor.Id(), sortBy)
+	if sortBy.Descending() {
+		return comparison < 0
+	}
+	return comparison > 0
+}
+
+// compareExpenses compares the sort field of the expense and then its id with the given ones, in ascending order. Ids
+// are compared as strings, which sorts them like Postgres sorts uuids.
+func compareExpenses(expense *models.Expense, value string, id uuid.UUID, sortBy expenseService.SearchSort) int {
+	var comparison int
+	if sortBy.Field() == string(expenseService.AmountAscSort) {
+		comparison = compareAmounts(expense.Amount().Amount(), value)
+	} else {
+		comparison = strings.Compare(expense.ExpenseDate().Format(dateFormat), value)
+	}
+
+	if comparison != 0 {
+		return comparison
+	}
+	return strings.Compare(expense.Id().String(), id.String())
+}
+
+func sortValue(expense *models.Expense, sortBy expenseService.SearchSort) string {
+	if sortBy.Field() == string(expenseService.AmountAscSort) {
+		return expense.Amount().Amount()
+	}
+	return expense.ExpenseDate().Format(dateFormat)
+}
+
+// compareAmounts compares decimal amounts by value, whatever their number of decimals. Amounts that can't be parsed
+// compare as zero.
+func compareAmounts(amount string, otherAmount string) int {
+	value, _ := new(big.Rat).SetString(amount)
+	otherValue, _ := new(big.Rat).SetString(otherAmount)
+	if value == nil {
+		value = new(big.Rat)
+	}
+	if otherValue == nil {
+		otherValue = new(big.Rat)
+	}
+	return value.Cmp(otherValue)
+}
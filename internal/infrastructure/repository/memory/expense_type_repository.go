@@ -0,0 +1,256 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	expenseTypeService "finfit-backend/internal/domain/services/expensetype"
+	"github.com/google/uuid"
+	"sort"
+	"strings"
+)
+
+var (
+	errDuplicateExpenseType      = errors.New("the ledger already has an expense type with the same id")
+	errDuplicateExpenseTypeName  = errors.New("the parent already has an expense type with the same name")
+	errExpenseTypeNotFound       = errors.New("the expense type doesn't exist")
+	errExpenseTypeHasSubtypes    = errors.New("the expense type has subtypes")
+	errExpenseTypeParentNotFound = errors.New("the parent of the expense type doesn't exist")
+)
+
+// expenseTypeRecord keeps the parent by id, like the parent_id column, so renaming or moving an expense type is seen
+// by its subtypes.
+type expenseTypeRecord struct {
+	ledgerId uuid.UUID
+	id       uuid.UUID
+	parentId uuid.UUID
+	name     string
+}
+
+type expenseTypeRepository struct {
+	db *Database
+}
+
+func NewExpenseTypeRepository(db *Database) *expenseTypeRepository {
+	return &expenseTypeRepository{db: db}
+}
+
+func (r expenseTypeRepository) GetByID(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (*models.ExpenseType, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	record, ok := r.db.expenseTypes[id]
+	if !ok || record.ledgerId != ledgerId {
+		return nil, nil
+	}
+
+	return r.db.mapToDomainExpenseType(record)
+}
+
+// GetByName looks for the expense type among the children of parentId, or at the top level when it is uuid.Nil, since
+// names are only unique among siblings.
+func (r expenseTypeRepository) GetByName(ctx context.Context, ledgerId uuid.UUID, parentId uuid.UUID, name string) (*models.ExpenseType, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	for _, record := range r.db.expenseTypes {
+		if record.ledgerId == ledgerId && record.parentId == parentId && record.name == name {
+			return r.db.mapToDomainExpenseType(record)
+		}
+	}
+
+	return nil, nil
+}
+
+// GetAll returns the expense types ordered by their full path, so every one comes right before its subtypes.
+func (r expenseTypeRepository) GetAll(ctx context.Context, ledgerId uuid.UUID) ([]*models.ExpenseType, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	expenseTypes := []*models.ExpenseType{}
+	for _, record := range r.db.expenseTypes {
+		if record.ledgerId != ledgerId {
+			continue
+		}
+
+		expenseType, err := r.db.mapToDomainExpenseType(record)
+		if err != nil {
+			return nil, err
+		}
+		expenseTypes = append(expenseTypes, expenseType)
+	}
+
+	sort.Slice(expenseTypes, func(i, j int) bool {
+		return comparePaths(expenseTypes[i].Path(), expenseTypes[j].Path()) < 0
+	})
+	return expenseTypes, nil
+}
+
+func (r expenseTypeRepository) Add(ctx context.Context, ledgerId uuid.UUID, expenseType *models.ExpenseType) (*models.ExpenseType, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if _, ok := r.db.expenseTypes[expenseType.Id()]; ok {
+		return nil, errDuplicateExpenseType
+	}
+
+	record := expenseTypeRecord{ledgerId: ledgerId, id: expenseType.Id(), parentId: expenseType.ParentId(), name: expenseType.Name()}
+	if err := r.db.validateExpenseType(record); err != nil {
+		return nil, err
+	}
+
+	r.db.expenseTypes[record.id] = record
+	return expenseType, nil
+}
+
+// Update renames and moves the expense type. Updating an expense type that doesn't exist changes nothing.
+func (r expenseTypeRepository) Update(ctx context.Context, ledgerId uuid.UUID, expenseType *models.ExpenseType) (*models.ExpenseType, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	storedRecord, ok := r.db.expenseTypes[expenseType.Id()]
+	if !ok || storedRecord.ledgerId != ledgerId {
+		return expenseType, nil
+	}
+
+	record := expenseTypeRecord{ledgerId: ledgerId, id: expenseType.Id(), parentId: expenseType.ParentId(), name: expenseType.Name()}
+	if err := r.db.validateExpenseType(record); err != nil {
+		return nil, err
+	}
+
+	r.db.expenseTypes[record.id] = record
+	return expenseType, nil
+}
+
+// Delete fails when the expense type has subtypes, expenses or recurring expenses, and deletes its budgets along with
+// it, like the foreign keys of the SQL database do.
+func (r expenseTypeRepository) Delete(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) error {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	record, ok := r.db.expenseTypes[id]
+	if !ok || record.ledgerId != ledgerId {
+		return nil
+	}
+
+	if r.db.hasSubtypes(ledgerId, id) {
+		return errExpenseTypeHasSubtypes
+	}
+
+	if r.db.isReferencedByExpenses(ledgerId, id) || r.db.isReferencedByRecurringExpenses(id) {
+		return expenseTypeService.ErrExpenseTypeReferenced
+	}
+
+	delete(r.db.expenseTypes, id)
+	for budgetId, budget := range r.db.budgets {
+		if budget.expenseTypeId == id {
+			delete(r.db.budgets, budgetId)
+		}
+	}
+	return nil
+}
+
+func (r expenseTypeRepository) HasSubtypes(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (bool, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	return r.db.hasSubtypes(ledgerId, id), nil
+}
+
+func (r expenseTypeRepository) IsReferencedByExpenses(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (bool, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	return r.db.isReferencedByExpenses(ledgerId, id), nil
+}
+
+func (r expenseTypeRepository) ReassignExpenses(ctx context.Context, ledgerId uuid.UUID, fromId uuid.UUID, toId uuid.UUID) error {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if _, ok := r.db.expenseTypes[toId]; !ok {
+		return errExpenseTypeNotFound
+	}
+
+	for id, record := range r.db.expenses {
+		if record.ledgerId == ledgerId && record.expenseTypeId == fromId {
+			record.expenseTypeId = toId
+			r.db.expenses[id] = record
+		}
+	}
+	return nil
+}
+
+// LockAll does nothing, the memory database has no transactions to hold a lock for.
+func (r expenseTypeRepository) LockAll(ctx context.Context, ledgerId uuid.UUID) error {
+	return nil
+}
+
+// validateExpenseType checks the constraints of the expense_type table: the parent must exist and the name must be
+// unique among the siblings. The caller must hold the lock.
+func (db *Database) validateExpenseType(record expenseTypeRecord) error {
+	if record.parentId != uuid.Nil {
+		if _, ok := db.expenseTypes[record.parentId]; !ok {
+			return errExpenseTypeParentNotFound
+		}
+	}
+
+	for _, sibling := range db.expenseTypes {
+		if sibling.id != record.id && sibling.ledgerId == record.ledgerId && sibling.parentId == record.parentId && sibling.name == record.name {
+			return errDuplicateExpenseTypeName
+		}
+	}
+	return nil
+}
+
+func (db *Database) hasSubtypes(ledgerId uuid.UUID, id uuid.UUID) bool {
+	for _, record := range db.expenseTypes {
+		if record.ledgerId == ledgerId && record.parentId == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (db *Database) isReferencedByExpenses(ledgerId uuid.UUID, id uuid.UUID) bool {
+	for _, record := range db.expenses {
+		if record.ledgerId == ledgerId && record.expenseTypeId == id {
+			return true
+		}
+	}
+	return false
+}
+
+// mapToDomainExpenseType links the expense type to all its ancestors. The caller must hold the lock.
+func (db *Database) mapToDomainExpenseType(record expenseTypeRecord) (*models.ExpenseType, error) {
+	expenseType, err := models.NewExpenseTypeWithId(record.id, record.name)
+	if err != nil {
+		return nil, err
+	}
+
+	if record.parentId == uuid.Nil {
+		return expenseType, nil
+	}
+
+	parentRecord, ok := db.expenseTypes[record.parentId]
+	if !ok {
+		return nil, errExpenseTypeParentNotFound
+	}
+
+	parent, err := db.mapToDomainExpenseType(parentRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	return expenseType.WithParent(parent)
+}
+
+// comparePaths orders paths name by name, a path goes before the longer ones that start with it.
+func comparePaths(path []string, otherPath []string) int {
+	for i := 0; i < len(path) && i < len(otherPath); i++ {
+		if path[i] != otherPath[i] {
+			return strings.Compare(path[i], otherPath[i])
+		}
+	}
+	return len(path) - len(otherPath)
+}
@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+var errDuplicateIncome = errors.New("there's already an income with the same id")
+
+type incomeRecord struct {
+	userId uuid.UUID
+	income *models.Income
+}
+
+type incomeRepository struct {
+	db *Database
+}
+
+func NewIncomeRepository(db *Database) *incomeRepository {
+	return &incomeRepository{db: db}
+}
+
+func (r incomeRepository) Add(ctx context.Context, userId uuid.UUID, income *models.Income) (*models.Income, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if _, ok := r.db.incomes[income.Id()]; ok {
+		return nil, errDuplicateIncome
+	}
+
+	if _, ok := r.db.incomeSources[income.IncomeSource().Id()]; !ok {
+		return nil, errIncomeSourceNotFound
+	}
+
+	if !r.db.hasUser(userId) {
+		return nil, errUserNotFound
+	}
+
+	r.db.incomes[income.Id()] = incomeRecord{userId: userId, income: income}
+	return income, nil
+}
+
+// SearchInPeriod compares the dates alone, both bounds are inclusive.
+func (r incomeRepository) SearchInPeriod(ctx context.Context, userId uuid.UUID, startDate time.Time, endDate time.Time) ([]*models.Income, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	incomes := []*models.Income{}
+	for _, record := range r.db.incomes {
+		incomeDate := record.income.IncomeDate().Format(dateFormat)
+		if record.userId == userId && incomeDate >= startDate.Format(dateFormat) && incomeDate <= endDate.Format(dateFormat) {
+			incomes = append(incomes, record.income)
+		}
+	}
+	return incomes, nil
+}
+
+func (r incomeRepository) GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Income, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	record, ok := r.db.incomes[id]
+	if !ok || record.userId != userId {
+		return nil, nil
+	}
+	return record.income, nil
+}
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"sort"
+)
+
+var (
+	errDuplicateIncomeSource = errors.New("the user already has an income source with the same id or name")
+	errIncomeSourceNotFound  = errors.New("the income source doesn't exist")
+)
+
+type incomeSourceRecord struct {
+	userId       uuid.UUID
+	incomeSource *models.IncomeSource
+}
+
+type incomeSourceRepository struct {
+	db *Database
+}
+
+func NewIncomeSourceRepository(db *Database) *incomeSourceRepository {
+	return &incomeSourceRepository{db: db}
+}
+
+func (r incomeSourceRepository) GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.IncomeSource, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	record, ok := r.db.incomeSources[id]
+	if !ok || record.userId != userId {
+		return nil, nil
+	}
+	return record.incomeSource, nil
+}
+
+func (r incomeSourceRepository) GetByName(ctx context.Context, userId uuid.UUID, name string) (*models.IncomeSource, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	for _, record := range r.db.incomeSources {
+		if record.userId == userId && record.incomeSource.Name() == name {
+			return record.incomeSource, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetAll returns the income sources of the user ordered by name.
+func (r incomeSourceRepository) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.IncomeSource, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	incomeSources := []*models.IncomeSource{}
+	for _, record := range r.db.incomeSources {
+		if record.userId == userId {
+			incomeSources = append(incomeSources, record.incomeSource)
+		}
+	}
+
+	sort.Slice(incomeSources, func(i, j int) bool {
+		return incomeSources[i].Name() < incomeSources[j].Name()
+	})
+	return incomeSources, nil
+}
+
+func (r incomeSourceRepository) Add(ctx context.Context, userId uuid.UUID, incomeSource *models.IncomeSource) (*models.IncomeSource, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	for id, record := range r.db.incomeSources {
+		if id == incomeSource.Id() || (record.userId == userId && record.incomeSource.Name() == incomeSource.Name()) {
+			return nil, errDuplicateIncomeSource
+		}
+	}
+
+	if !r.db.hasUser(userId) {
+		return nil, errUserNotFound
+	}
+
+	r.db.incomeSources[incomeSource.Id()] = incomeSourceRecord{userId: userId, incomeSource: incomeSource}
+	return incomeSource, nil
+}
@@ -0,0 +1,161 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"sort"
+)
+
+var (
+	errDuplicateLedger       = errors.New("there's already a ledger with the same id")
+	errDuplicateLedgerMember = errors.New("the user is already a member of the ledger")
+	errDuplicateInvitation   = errors.New("there's already an invitation with the same id or token")
+	errLedgerNotFound        = errors.New("the ledger doesn't exist")
+)
+
+type ledgerRecord struct {
+	ledger *models.Ledger
+}
+
+// ledgerMemberRecord keeps the membership as it was stored. The members are kept in the order they joined, like the
+// created_at column sorts them.
+type ledgerMemberRecord struct {
+	member *models.LedgerMember
+}
+
+type ledgerInvitationRecord struct {
+	invitation *models.LedgerInvitation
+}
+
+type ledgerRepository struct {
+	db *Database
+}
+
+func NewLedgerRepository(db *Database) *ledgerRepository {
+	return &ledgerRepository{db: db}
+}
+
+// Add stores the ledger together with the membership of its owner, or neither of them.
+func (r ledgerRepository) Add(ctx context.Context, ledger *models.Ledger, owner *models.LedgerMember) (*models.Ledger, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if _, ok := r.db.ledgers[ledger.Id()]; ok {
+		return nil, errDuplicateLedger
+	}
+
+	if owner.LedgerId() != ledger.Id() {
+		return nil, errLedgerNotFound
+	}
+
+	if !r.db.hasUser(owner.UserId()) {
+		return nil, errUserNotFound
+	}
+
+	r.db.ledgers[ledger.Id()] = ledgerRecord{ledger: ledger}
+	r.db.ledgerMembers = append(r.db.ledgerMembers, ledgerMemberRecord{member: owner})
+	return ledger, nil
+}
+
+// GetAllByMember returns the ledgers the user is a member of, ordered by name.
+func (r ledgerRepository) GetAllByMember(ctx context.Context, userId uuid.UUID) ([]*models.Ledger, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	ledgers := []*models.Ledger{}
+	for _, record := range r.db.ledgerMembers {
+		if record.member.UserId() == userId {
+			ledgers = append(ledgers, r.db.ledgers[record.member.LedgerId()].ledger)
+		}
+	}
+
+	sort.SliceStable(ledgers, func(i, j int) bool {
+		return ledgers[i].Name() < ledgers[j].Name()
+	})
+	return ledgers, nil
+}
+
+func (r ledgerRepository) GetMember(ctx context.Context, ledgerId uuid.UUID, userId uuid.UUID) (*models.LedgerMember, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	return r.db.findLedgerMember(ledgerId, userId), nil
+}
+
+func (r ledgerRepository) GetMembers(ctx context.Context, ledgerId uuid.UUID) ([]*models.LedgerMember, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	members := []*models.LedgerMember{}
+	for _, record := range r.db.ledgerMembers {
+		if record.member.LedgerId() == ledgerId {
+			members = append(members, record.member)
+		}
+	}
+	return members, nil
+}
+
+func (r ledgerRepository) AddInvitation(ctx context.Context, invitation *models.LedgerInvitation) (*models.LedgerInvitation, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if _, ok := r.db.ledgers[invitation.LedgerId()]; !ok {
+		return nil, errLedgerNotFound
+	}
+
+	for id, record := range r.db.ledgerInvitations {
+		if id == invitation.Id() || record.invitation.TokenHash() == invitation.TokenHash() {
+			return nil, errDuplicateInvitation
+		}
+	}
+
+	r.db.ledgerInvitations[invitation.Id()] = ledgerInvitationRecord{invitation: invitation}
+	return invitation, nil
+}
+
+func (r ledgerRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*models.LedgerInvitation, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	for _, record := range r.db.ledgerInvitations {
+		if record.invitation.TokenHash() == tokenHash {
+			return record.invitation, nil
+		}
+	}
+	return nil, nil
+}
+
+// AcceptInvitation adds the member and deletes the invitation, or does neither when the member can't be added.
+func (r ledgerRepository) AcceptInvitation(ctx context.Context, invitation *models.LedgerInvitation, member *models.LedgerMember) error {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if _, ok := r.db.ledgers[member.LedgerId()]; !ok {
+		return errLedgerNotFound
+	}
+
+	if !r.db.hasUser(member.UserId()) {
+		return errUserNotFound
+	}
+
+	if r.db.findLedgerMember(member.LedgerId(), member.UserId()) != nil {
+		return errDuplicateLedgerMember
+	}
+
+	r.db.ledgerMembers = append(r.db.ledgerMembers, ledgerMemberRecord{member: member})
+	delete(r.db.ledgerInvitations, invitation.Id())
+	return nil
+}
+
+// findLedgerMember returns the membership of the user in the ledger, or nil when they aren't a member. The caller must
+// hold the lock.
+func (db *Database) findLedgerMember(ledgerId uuid.UUID, userId uuid.UUID) *models.LedgerMember {
+	for _, record := range db.ledgerMembers {
+		if record.member.LedgerId() == ledgerId && record.member.UserId() == userId {
+			return record.member
+		}
+	}
+	return nil
+}
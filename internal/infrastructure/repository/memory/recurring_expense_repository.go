@@ -0,0 +1,151 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+var errDuplicateRecurringExpense = errors.New("there's already a recurring expense with the same id")
+
+// recurringExpenseRecord keeps the expense type by id, like the expense_type_id column, which keeps the expense type
+// from being deleted, and the last occurrence apart, since it's the only thing that changes.
+type recurringExpenseRecord struct {
+	userId           uuid.UUID
+	expenseTypeId    uuid.UUID
+	recurringExpense *models.RecurringExpense
+	lastOccurrence   time.Time
+}
+
+type recurringExpenseRepository struct {
+	db *Database
+}
+
+func NewRecurringExpenseRepository(db *Database) *recurringExpenseRepository {
+	return &recurringExpenseRepository{db: db}
+}
+
+func (r recurringExpenseRepository) Add(ctx context.Context, userId uuid.UUID, recurringExpense *models.RecurringExpense) (*models.RecurringExpense, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if _, ok := r.db.recurringExpenses[recurringExpense.Id()]; ok {
+		return nil, errDuplicateRecurringExpense
+	}
+
+	if _, ok := r.db.expenseTypes[recurringExpense.ExpenseType().Id()]; !ok {
+		return nil, errExpenseTypeNotFound
+	}
+
+	if !r.db.hasUser(userId) {
+		return nil, errUserNotFound
+	}
+
+	r.db.recurringExpenses[recurringExpense.Id()] = recurringExpenseRecord{
+		userId:           userId,
+		expenseTypeId:    recurringExpense.ExpenseType().Id(),
+		recurringExpense: recurringExpense,
+		lastOccurrence:   recurringExpense.LastOccurrence(),
+	}
+	return recurringExpense, nil
+}
+
+func (r recurringExpenseRepository) GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.RecurringExpense, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	record, ok := r.db.recurringExpenses[id]
+	if !ok || record.userId != userId {
+		return nil, nil
+	}
+
+	return r.db.mapToDomainRecurringExpense(record)
+}
+
+// GetAll returns the recurring expenses of the user ordered by the day they start.
+func (r recurringExpenseRepository) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.RecurringExpense, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	recurringExpenses := []*models.RecurringExpense{}
+	for _, record := range r.db.recurringExpenses {
+		if record.userId != userId {
+			continue
+		}
+
+		recurringExpense, err := r.db.mapToDomainRecurringExpense(record)
+		if err != nil {
+			return nil, err
+		}
+		recurringExpenses = append(recurringExpenses, recurringExpense)
+	}
+
+	sort.Slice(recurringExpenses, func(i, j int) bool {
+		startDate := recurringExpenses[i].Schedule().StartDate().Format(dateFormat)
+		otherStartDate := recurringExpenses[j].Schedule().StartDate().Format(dateFormat)
+		if startDate != otherStartDate {
+			return startDate < otherStartDate
+		}
+		return recurringExpenses[i].Id().String() < recurringExpenses[j].Id().String()
+	})
+	return recurringExpenses, nil
+}
+
+func (r recurringExpenseRepository) Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if record, ok := r.db.recurringExpenses[id]; ok && record.userId == userId {
+		delete(r.db.recurringExpenses, id)
+	}
+	return nil
+}
+
+// UpdateLastOccurrence checks and moves the last occurrence holding the lock, so only one of the concurrent callers
+// moves it.
+func (r recurringExpenseRepository) UpdateLastOccurrence(ctx context.Context, userId uuid.UUID, id uuid.UUID, lastOccurrence time.Time) (bool, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	record, ok := r.db.recurringExpenses[id]
+	if !ok || record.userId != userId {
+		return false, nil
+	}
+
+	if !record.lastOccurrence.IsZero() && record.lastOccurrence.Format(dateFormat) >= lastOccurrence.Format(dateFormat) {
+		return false, nil
+	}
+
+	record.lastOccurrence = lastOccurrence
+	r.db.recurringExpenses[id] = record
+	return true, nil
+}
+
+func (db *Database) isReferencedByRecurringExpenses(id uuid.UUID) bool {
+	for _, record := range db.recurringExpenses {
+		if record.expenseTypeId == id {
+			return true
+		}
+	}
+	return false
+}
+
+// mapToDomainRecurringExpense links the recurring expense to the current state of its expense type and its last
+// occurrence. The caller must hold the lock.
+func (db *Database) mapToDomainRecurringExpense(record recurringExpenseRecord) (*models.RecurringExpense, error) {
+	expenseTypeRecord, ok := db.expenseTypes[record.expenseTypeId]
+	if !ok {
+		return nil, errExpenseTypeNotFound
+	}
+
+	expenseType, err := db.mapToDomainExpenseType(expenseTypeRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := record.recurringExpense
+	return models.NewRecurringExpenseWithId(stored.Id(), stored.Amount(), stored.Description(), expenseType, stored.Schedule(), record.lastOccurrence)
+}
@@ -0,0 +1,199 @@
+package memory
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"fmt"
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+// spendingGroup identifies a group of the expenses in a currency, or a group in a day when date is set.
+type spendingGroup struct {
+	currency string
+	key      string
+	label    string
+	date     string
+}
+
+// spendingTotal adds up the expenses of a group.
+type spendingTotal struct {
+	spendingGroup
+	total *models.Money
+	count int64
+}
+
+type reportRepository struct {
+	db *Database
+}
+
+func NewReportRepository(db *Database) *reportRepository {
+	return &reportRepository{db: db}
+}
+
+// GetSpending returns the spending of every currency with its groups ordered by label.
+func (r reportRepository) GetSpending(ctx context.Context, ledgerId uuid.UUID, startDate time.Time, endDate time.Time, groupBy models.ReportGrouping, currency string, expenseTypeIds []uuid.UUID) ([]*models.CurrencySpending, error) {
+	totals, err := r.sumExpenses(ledgerId, startDate, endDate, groupBy, currency, expenseTypeIds, false)
+	if err != nil {
+		return nil, err
+	}
+
+	spending := []*models.CurrencySpending{}
+	for start := 0; start < len(totals); {
+		end := start
+		for end < len(totals) && totals[end].currency == totals[start].currency {
+			end++
+		}
+
+		currencySpending, err := mapCurrencyTotals(totals[start:end])
+		if err != nil {
+			return nil, err
+		}
+		spending = append(spending, currencySpending)
+		start = end
+	}
+	return spending, nil
+}
+
+// GetDailySpending returns the spending of every group in every day, ordered by label, day and currency.
+func (r reportRepository) GetDailySpending(ctx context.Context, ledgerId uuid.UUID, startDate time.Time, endDate time.Time, groupBy models.ReportGrouping, currency string, expenseTypeIds []uuid.UUID) ([]*models.SpendingEntry, error) {
+	totals, err := r.sumExpenses(ledgerId, startDate, endDate, groupBy, currency, expenseTypeIds, true)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(totals, func(i, j int) bool {
+		if totals[i].label != totals[j].label {
+			return totals[i].label < totals[j].label
+		}
+		if totals[i].date != totals[j].date {
+			return totals[i].date < totals[j].date
+		}
+		return totals[i].currency < totals[j].currency
+	})
+
+	entries := []*models.SpendingEntry{}
+	for _, total := range totals {
+		date, err := time.Parse(dateFormat, total.date)
+		if err != nil {
+			return nil, err
+		}
+
+		entry, err := models.NewSpendingEntry(total.key, total.label, date, total.total, total.count)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// sumExpenses adds up the expenses of the ledger between both dates, in the currency if there's one and of the expense
+// types if there are any, by currency and group, and by day too when daily is set. The totals are ordered by currency
+// and label.
+func (r reportRepository) sumExpenses(ledgerId uuid.UUID, startDate time.Time, endDate time.Time, groupBy models.ReportGrouping, currency string, expenseTypeIds []uuid.UUID, daily bool) ([]*spendingTotal, error) {
+	wantedExpenseTypeIds := map[uuid.UUID]bool{}
+	for _, expenseTypeId := range expenseTypeIds {
+		wantedExpenseTypeIds[expenseTypeId] = true
+	}
+
+	r.db.mutex.RLock()
+	expenses, err := r.db.findExpenses(ledgerId, func(expense *models.Expense) bool {
+		return isInPeriod(expense, startDate, endDate) &&
+			(currency == "" || expense.Amount().Currency() == currency) &&
+			(len(wantedExpenseTypeIds) == 0 || wantedExpenseTypeIds[expense.ExpenseType().Id()])
+	})
+	r.db.mutex.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	totalsByGroup := map[spendingGroup]*spendingTotal{}
+	totals := []*spendingTotal{}
+	for _, expense := range expenses {
+		key, label := groupOf(expense, groupBy)
+		group := spendingGroup{currency: expense.Amount().Currency(), key: key, label: label}
+		if daily {
+			group.date = expense.ExpenseDate().Format(dateFormat)
+		}
+
+		total, ok := totalsByGroup[group]
+		if !ok {
+			zero, err := models.NewMoneyFromMinorUnits(0, group.currency)
+			if err != nil {
+				return nil, err
+			}
+			total = &spendingTotal{spendingGroup: group, total: zero}
+			totalsByGroup[group] = total
+			totals = append(totals, total)
+		}
+
+		if total.total, err = total.total.Add(expense.Amount()); err != nil {
+			return nil, err
+		}
+		total.count++
+	}
+
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].currency != totals[j].currency {
+			return totals[i].currency < totals[j].currency
+		}
+		if totals[i].label != totals[j].label {
+			return totals[i].label < totals[j].label
+		}
+		if totals[i].key != totals[j].key {
+			return totals[i].key < totals[j].key
+		}
+		return totals[i].date < totals[j].date
+	})
+	return totals, nil
+}
+
+// groupOf returns the key and the label of the group of the expense. Time buckets are labeled with their key, and
+// weeks follow ISO 8601.
+func groupOf(expense *models.Expense, groupBy models.ReportGrouping) (string, string) {
+	date := expense.ExpenseDate()
+	var bucket string
+	switch groupBy {
+	case models.ExpenseTypeReportGrouping:
+		return expense.ExpenseType().Id().String(), expense.ExpenseType().Name()
+	case models.WeekReportGrouping:
+		year, week := date.ISOWeek()
+		bucket = fmt.Sprintf("%04d-W%02d", year, week)
+	case models.MonthReportGrouping:
+		bucket = date.Format("2006-01")
+	case models.YearReportGrouping:
+		bucket = date.Format("2006")
+	default:
+		bucket = date.Format(dateFormat)
+	}
+	return bucket, bucket
+}
+
+// mapCurrencyTotals assembles the totals of a currency into its CurrencySpending.
+func mapCurrencyTotals(totals []*spendingTotal) (*models.CurrencySpending, error) {
+	currencyTotal, err := models.NewMoneyFromMinorUnits(0, totals[0].currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var currencyCount int64
+	for _, total := range totals {
+		if currencyTotal, err = currencyTotal.Add(total.total); err != nil {
+			return nil, err
+		}
+		currencyCount += total.count
+	}
+
+	groups := []*models.SpendingGroup{}
+	for _, total := range totals {
+		group, err := models.NewSpendingGroup(total.key, total.label, total.total, total.count, currencyTotal)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+
+	return models.NewCurrencySpending(currencyTotal, currencyCount, groups)
+}
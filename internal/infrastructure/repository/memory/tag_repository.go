@@ -0,0 +1,170 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"sort"
+)
+
+var (
+	errDuplicateTagName = errors.New("the ledger already has a tag with the same name")
+	errTagNotFound      = errors.New("the tag doesn't exist")
+)
+
+// tagRecord is linked to the expenses by id, so renaming a tag is seen by all of them.
+type tagRecord struct {
+	ledgerId uuid.UUID
+	id       uuid.UUID
+	name     string
+}
+
+type tagRepository struct {
+	db *Database
+}
+
+func NewTagRepository(db *Database) *tagRepository {
+	return &tagRepository{db: db}
+}
+
+// GetAll returns the tags of the ledger ordered by name.
+func (r tagRepository) GetAll(ctx context.Context, ledgerId uuid.UUID) ([]*models.Tag, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	tags := []*models.Tag{}
+	for _, record := range r.db.tags {
+		if record.ledgerId != ledgerId {
+			continue
+		}
+
+		tag, err := models.NewTagWithId(record.id, record.name)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name() < tags[j].Name()
+	})
+	return tags, nil
+}
+
+func (r tagRepository) GetByID(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (*models.Tag, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	record, ok := r.db.tags[id]
+	if !ok || record.ledgerId != ledgerId {
+		return nil, nil
+	}
+
+	return models.NewTagWithId(record.id, record.name)
+}
+
+func (r tagRepository) GetByName(ctx context.Context, ledgerId uuid.UUID, name string) (*models.Tag, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	for _, record := range r.db.tags {
+		if record.ledgerId == ledgerId && record.name == name {
+			return models.NewTagWithId(record.id, record.name)
+		}
+	}
+	return nil, nil
+}
+
+// Update renames the tag. Updating a tag that doesn't exist changes nothing.
+func (r tagRepository) Update(ctx context.Context, ledgerId uuid.UUID, tag *models.Tag) (*models.Tag, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	record, ok := r.db.tags[tag.Id()]
+	if !ok || record.ledgerId != ledgerId {
+		return tag, nil
+	}
+
+	for _, otherRecord := range r.db.tags {
+		if otherRecord.id != record.id && otherRecord.ledgerId == ledgerId && otherRecord.name == tag.Name() {
+			return nil, errDuplicateTagName
+		}
+	}
+
+	record.name = tag.Name()
+	r.db.tags[record.id] = record
+	return tag, nil
+}
+
+// Merge links the expenses of the source tags to the target one, skipping the expenses that already have it, and then
+// deletes the source tags along with their links.
+func (r tagRepository) Merge(ctx context.Context, ledgerId uuid.UUID, targetId uuid.UUID, sourceIds []uuid.UUID) error {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	if record, ok := r.db.tags[targetId]; !ok || record.ledgerId != ledgerId {
+		return errTagNotFound
+	}
+
+	isSource := map[uuid.UUID]bool{}
+	for _, sourceId := range sourceIds {
+		if record, ok := r.db.tags[sourceId]; ok && record.ledgerId == ledgerId {
+			isSource[sourceId] = true
+		}
+	}
+
+	for id, expense := range r.db.expenses {
+		tagIds := []uuid.UUID{}
+		hasTarget, hadSource := false, false
+		for _, tagId := range expense.tagIds {
+			if isSource[tagId] {
+				hadSource = true
+				continue
+			}
+			hasTarget = hasTarget || tagId == targetId
+			tagIds = append(tagIds, tagId)
+		}
+
+		if !hadSource {
+			continue
+		}
+
+		if !hasTarget {
+			tagIds = append(tagIds, targetId)
+		}
+		expense.tagIds = tagIds
+		r.db.expenses[id] = expense
+	}
+
+	for sourceId := range isSource {
+		delete(r.db.tags, sourceId)
+	}
+	return nil
+}
+
+// tagIdOf returns the id of the tag of the ledger with the name, creating the tag when it doesn't exist yet. The caller
+// must hold the lock.
+func (db *Database) tagIdOf(ledgerId uuid.UUID, name string) uuid.UUID {
+	for _, record := range db.tags {
+		if record.ledgerId == ledgerId && record.name == name {
+			return record.id
+		}
+	}
+
+	record := tagRecord{ledgerId: ledgerId, id: pkg.NewUUID(), name: name}
+	db.tags[record.id] = record
+	return record.id
+}
+
+// tagNamesOf returns the current names of the tags. The caller must hold the lock.
+func (db *Database) tagNamesOf(tagIds []uuid.UUID) []string {
+	names := []string{}
+	for _, tagId := range tagIds {
+		if record, ok := db.tags[tagId]; ok {
+			names = append(names, record.name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+var (
+	errDuplicateUser      = errors.New("there's already a user with the same id")
+	errDuplicateUserEmail = errors.New("there's already a user with the same email")
+	errUserNotFound       = errors.New("the user doesn't exist")
+)
+
+// userRecord keeps the user as it was stored. The users are kept in the order they were added, like the created_at
+// column sorts them.
+type userRecord struct {
+	user *models.User
+}
+
+type usedRefreshTokenRecord struct {
+	userId    uuid.UUID
+	expiresAt time.Time
+}
+
+type userRepository struct {
+	db *Database
+}
+
+func NewUserRepository(db *Database) *userRepository {
+	return &userRepository{db: db}
+}
+
+func (r userRepository) Add(ctx context.Context, user *models.User) (*models.User, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	for _, record := range r.db.users {
+		if record.user.Id() == user.Id() {
+			return nil, errDuplicateUser
+		}
+		if record.user.Email() == user.Email() {
+			return nil, errDuplicateUserEmail
+		}
+	}
+
+	r.db.users = append(r.db.users, userRecord{user: user})
+	return user, nil
+}
+
+func (r userRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	for _, record := range r.db.users {
+		if record.user.Id() == id {
+			return record.user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	for _, record := range r.db.users {
+		if record.user.Email() == email {
+			return record.user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r userRepository) GetAll(ctx context.Context) ([]*models.User, error) {
+	r.db.mutex.RLock()
+	defer r.db.mutex.RUnlock()
+
+	users := []*models.User{}
+	for _, record := range r.db.users {
+		users = append(users, record.user)
+	}
+	return users, nil
+}
+
+func (r userRepository) UseRefreshToken(ctx context.Context, userId uuid.UUID, tokenId string, expiresAt time.Time) (bool, error) {
+	r.db.mutex.Lock()
+	defer r.db.mutex.Unlock()
+
+	// The tokens that expired can't be exchanged anyway, so they don't need to be remembered.
+	now := time.Now().UTC()
+	for id, record := range r.db.usedRefreshTokens {
+		if record.userId == userId && record.expiresAt.Before(now) {
+			delete(r.db.usedRefreshTokens, id)
+		}
+	}
+
+	if !r.db.hasUser(userId) {
+		return false, errUserNotFound
+	}
+
+	if _, ok := r.db.usedRefreshTokens[tokenId]; ok {
+		return false, nil
+	}
+
+	r.db.usedRefreshTokens[tokenId] = usedRefreshTokenRecord{userId: userId, expiresAt: expiresAt.UTC()}
+	return true, nil
+}
+
+// hasUser tells whether the user exists, like the foreign keys to the app_user table check. The caller must hold the
+// lock.
+func (db *Database) hasUser(id uuid.UUID) bool {
+	for _, record := range db.users {
+		if record.user.Id() == id {
+			return true
+		}
+	}
+	return false
+}
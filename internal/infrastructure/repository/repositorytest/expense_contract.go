@@ -0,0 +1,283 @@
+package repositorytest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/expense"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// ExpenseRepositoryContract is the behaviour every expense.Repository must have. NewRepositories is called before
+// every test.
+type ExpenseRepositoryContract struct {
+	suite.Suite
+	NewRepositories func() Repositories
+	repositories    Repositories
+	ledgerId        uuid.UUID
+	food            *models.ExpenseType
+	transport       *models.ExpenseType
+}
+
+func (s *ExpenseRepositoryContract) SetupTest() {
+	s.repositories = s.NewRepositories()
+	s.ledgerId = pkg.NewUUID()
+	s.food = newExpenseType(s.T(), "Food", nil)
+	s.transport = newExpenseType(s.T(), "Transport", nil)
+	for _, expenseType := range []*models.ExpenseType{s.food, s.transport} {
+		_, err := s.repositories.ExpenseTypes.Add(context.Background(), s.ledgerId, expenseType)
+		require.NoError(s.T(), err)
+	}
+}
+
+func (s *ExpenseRepositoryContract) add(expenses ...*models.Expense) {
+	for _, expenseToAdd := range expenses {
+		_, err := s.repositories.Expenses.Add(context.Background(), s.ledgerId, expenseToAdd)
+		require.NoError(s.T(), err)
+	}
+}
+
+func (s *ExpenseRepositoryContract) search(criteria expense.SearchCriteria) []uuid.UUID {
+	expenses, err := s.repositories.Expenses.SearchInPeriod(context.Background(), s.ledgerId, criteria)
+	require.NoError(s.T(), err)
+	return ids(expenses)
+}
+
+func (s *ExpenseRepositoryContract) TestGivenAStoredExpenseWhenGetByIDThenReturnsIt() {
+	// Given
+	storedExpense := newExpense(s.T(), "2023-03-10", "1500.50", "ARS", "Lomitos", s.food, "dinner", "friends").WithFitId("FIT-1")
+	s.add(storedExpense)
+
+	// When
+	foundExpense, err := s.repositories.Expenses.GetByID(context.Background(), s.ledgerId, storedExpense.Id())
+
+	// Then
+	require.NoError(s.T(), err)
+	require.NotNil(s.T(), foundExpense)
+	assert.Equal(s.T(), storedExpense.Id(), foundExpense.Id())
+	assert.Equal(s.T(), "1500.50", foundExpense.Amount().Amount())
+	assert.Equal(s.T(), "ARS", foundExpense.Amount().Currency())
+	assert.Equal(s.T(), "2023-03-10", foundExpense.ExpenseDate().Format("2006-01-02"))
+	assert.Equal(s.T(), "Lomitos", foundExpense.Description())
+	assert.Equal(s.T(), "Food", foundExpense.ExpenseType().Name())
+	assert.Equal(s.T(), "FIT-1", foundExpense.FitId())
+	assert.Equal(s.T(), []string{"dinner", "friends"}, foundExpense.Tags())
+}
+
+func (s *ExpenseRepositoryContract) TestGivenAnUnknownIdOrAnotherLedgerWhenGetByIDThenReturnsNilWithoutError() {
+	// Given
+	storedExpense := newExpense(s.T(), "2023-03-10", "1500", "ARS", "Lomitos", s.food)
+	s.add(storedExpense)
+
+	// When
+	unknownExpense, unknownErr := s.repositories.Expenses.GetByID(context.Background(), s.ledgerId, pkg.NewUUID())
+	otherLedgerExpense, otherLedgerErr := s.repositories.Expenses.GetByID(context.Background(), pkg.NewUUID(), storedExpense.Id())
+
+	// Then
+	assert.NoError(s.T(), unknownErr)
+	assert.Nil(s.T(), unknownExpense)
+	assert.NoError(s.T(), otherLedgerErr)
+	assert.Nil(s.T(), otherLedgerExpense)
+}
+
+func (s *ExpenseRepositoryContract) TestGivenARenamedExpenseTypeWhenGetByIDThenTheExpenseHasTheNewName() {
+	// Given
+	storedExpense := newExpense(s.T(), "2023-03-10", "1500", "ARS", "Lomitos", s.food)
+	s.add(storedExpense)
+	renamedFood, err := models.NewExpenseTypeWithId(s.food.Id(), "Eating out")
+	require.NoError(s.T(), err)
+	_, err = s.repositories.ExpenseTypes.Update(context.Background(), s.ledgerId, renamedFood)
+	require.NoError(s.T(), err)
+
+	// When
+	foundExpense, err := s.repositories.Expenses.GetByID(context.Background(), s.ledgerId, storedExpense.Id())
+
+	// Then
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "Eating out", foundExpense.ExpenseType().Name())
+}
+
+func (s *ExpenseRepositoryContract) TestGivenExpensesAroundThePeriodWhenSearchInPeriodThenBothBoundsAreInclusive() {
+	// Given
+	dayBefore := newExpense(s.T(), "2023-02-28", "100", "ARS", "", s.food)
+	firstDay := newExpense(s.T(), "2023-03-01", "100", "ARS", "", s.food)
+	lastDay := newExpense(s.T(), "2023-03-31", "100", "ARS", "", s.food)
+	dayAfter := newExpense(s.T(), "2023-04-01", "100", "ARS", "", s.food)
+	s.add(dayBefore, firstDay, lastDay, dayAfter)
+
+	// When
+	foundIds := s.search(expense.SearchCriteria{StartDate: date(s.T(), "2023-03-01"), EndDate: date(s.T(), "2023-03-31")})
+
+	// Then
+	assert.ElementsMatch(s.T(), []uuid.UUID{firstDay.Id(), lastDay.Id()}, foundIds)
+}
+
+func (s *ExpenseRepositoryContract) TestGivenNoExpensesWhenSearchInPeriodThenReturnsAnEmptyList() {
+	// When
+	expenses, err := s.repositories.Expenses.SearchInPeriod(context.Background(), s.ledgerId, expense.SearchCriteria{StartDate: date(s.T(), "2023-03-01"), EndDate: date(s.T(), "2023-03-31")})
+
+	// Then
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), expenses)
+}
+
+func (s *ExpenseRepositoryContract) TestGivenFiltersWhenSearchInPeriodThenReturnsTheExpensesThatPassAllOfThem() {
+	// Given
+	lomitos := newExpense(s.T(), "2023-03-10", "1500", "ARS", "Lomitos with FRIENDS", s.food, "dinner", "friends")
+	pizza := newExpense(s.T(), "2023-03-11", "800.50", "ARS", "Pizza", s.food, "dinner")
+	taxi := newExpense(s.T(), "2023-03-12", "12", "USD", "Taxi with friends", s.transport, "friends")
+	s.add(lomitos, pizza, taxi)
+	criteria := expense.SearchCriteria{StartDate: date(s.T(), "2023-03-01"), EndDate: date(s.T(), "2023-03-31")}
+	allTags, err := models.NewTagFilter([]string{"dinner", "friends"}, string(models.AllTagMatch))
+	require.NoError(s.T(), err)
+	anyTag, err := models.NewTagFilter([]string{"friends", "lunch"}, string(models.AnyTagMatch))
+	require.NoError(s.T(), err)
+
+	// When
+	byExpenseType, byMinAmount, byMaxAmount, byCurrency, byDescription, byAllTags, byAnyTag := criteria, criteria, criteria, criteria, criteria, criteria, criteria
+	byExpenseType.ExpenseTypeIds = []uuid.UUID{s.transport.Id()}
+	byMinAmount.MinAmount = "800.5"
+	byMaxAmount.MaxAmount = "800.50"
+	byCurrency.Currency = "USD"
+	byDescription.Description = "with friends"
+	byAllTags.TagFilter = allTags
+	byAnyTag.TagFilter = anyTag
+
+	// Then
+	assert.ElementsMatch(s.T(), []uuid.UUID{taxi.Id()}, s.search(byExpenseType))
+	assert.ElementsMatch(s.T(), []uuid.UUID{lomitos.Id(), pizza.Id()}, s.search(byMinAmount))
+	assert.ElementsMatch(s.T(), []uuid.UUID{pizza.Id(), taxi.Id()}, s.search(byMaxAmount))
+	assert.ElementsMatch(s.T(), []uuid.UUID{taxi.Id()}, s.search(byCurrency))
+	assert.ElementsMatch(s.T(), []uuid.UUID{lomitos.Id(), taxi.Id()}, s.search(byDescription))
+	assert.ElementsMatch(s.T(), []uuid.UUID{lomitos.Id()}, s.search(byAllTags))
+	assert.ElementsMatch(s.T(), []uuid.UUID{lomitos.Id(), taxi.Id()}, s.search(byAnyTag))
+}
+
+func (s *ExpenseRepositoryContract) TestGivenACursorWhenSearchInPeriodThenPagesInTheOrderOfTheSortBreakingTiesById() {
+	// Given
+	expenses := []*models.Expense{
+		newExpense(s.T(), "2023-03-10", "300", "ARS", "", s.food),
+		newExpense(s.T(), "2023-03-11", "300", "ARS", "", s.food),
+		newExpense(s.T(), "2023-03-12", "1000", "ARS", "", s.food),
+		newExpense(s.T(), "2023-03-13", "20.50", "ARS", "", s.food),
+	}
+	s.add(expenses...)
+	criteria := expense.SearchCriteria{StartDate: date(s.T(), "2023-03-01"), EndDate: date(s.T(), "2023-03-31"), Sort: expense.AmountDescSort, Limit: 2}
+	tiedFirst, tiedSecond := expenses[0], expenses[1]
+	if tiedFirst.Id().String() < tiedSecond.Id().String() {
+		tiedFirst, tiedSecond = tiedSecond, tiedFirst
+	}
+
+	// When
+	firstPage := s.search(criteria)
+	criteria.After = s.cursor(expense.AmountDescSort, "300", firstPage[1])
+	secondPage := s.search(criteria)
+
+	// Then
+	assert.Equal(s.T(), []uuid.UUID{expenses[2].Id(), tiedFirst.Id()}, firstPage)
+	assert.Equal(s.T(), []uuid.UUID{tiedSecond.Id(), expenses[3].Id()}, secondPage)
+}
+
+func (s *ExpenseRepositoryContract) TestGivenExpensesOfAPeriodWhenForEachInPeriodThenConsumesThemByDateAndId() {
+	// Given
+	later := newExpense(s.T(), "2023-03-20", "100", "ARS", "", s.food)
+	earlier := newExpense(s.T(), "2023-03-01", "100", "ARS", "", s.food)
+	sameDay := newExpense(s.T(), "2023-03-20", "200", "ARS", "", s.transport)
+	outOfPeriod := newExpense(s.T(), "2023-04-01", "100", "ARS", "", s.food)
+	s.add(later, earlier, sameDay, outOfPeriod)
+	laterFirst, laterSecond := later, sameDay
+	if laterFirst.Id().String() > laterSecond.Id().String() {
+		laterFirst, laterSecond = laterSecond, laterFirst
+	}
+
+	// When
+	consumedIds := []uuid.UUID{}
+	err := s.repositories.Expenses.ForEachInPeriod(context.Background(), s.ledgerId, date(s.T(), "2023-03-01"), date(s.T(), "2023-03-31"), func(consumedExpense *models.Expense) error {
+		consumedIds = append(consumedIds, consumedExpense.Id())
+		return nil
+	})
+
+	// Then
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []uuid.UUID{earlier.Id(), laterFirst.Id(), laterSecond.Id()}, consumedIds)
+}
+
+func (s *ExpenseRepositoryContract) TestGivenImportedExpensesWhenGetByFitIdsThenReturnsTheOnesWithThoseIds() {
+	// Given
+	firstImported := newExpense(s.T(), "2023-03-10", "100", "ARS", "", s.food).WithFitId("FIT-1")
+	secondImported := newExpense(s.T(), "2023-03-11", "100", "ARS", "", s.food).WithFitId("FIT-2")
+	notImported := newExpense(s.T(), "2023-03-12", "100", "ARS", "", s.food)
+
+	// When
+	err := s.repositories.Expenses.AddAll(context.Background(), s.ledgerId, []*models.Expense{firstImported, secondImported, notImported})
+
+	// Then
+	require.NoError(s.T(), err)
+	foundExpenses, err := s.repositories.Expenses.GetByFitIds(context.Background(), s.ledgerId, []string{"FIT-2", "FIT-3"})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []uuid.UUID{secondImported.Id()}, ids(foundExpenses))
+}
+
+func (s *ExpenseRepositoryContract) TestGivenAStoredExpenseWhenUpdateThenReplacesItsFieldsButTheFitId() {
+	// Given
+	storedExpense := newExpense(s.T(), "2023-03-10", "1500", "ARS", "Lomitos", s.food, "dinner").WithFitId("FIT-1")
+	s.add(storedExpense)
+	money, err := models.NewMoney("2000", "ARS")
+	require.NoError(s.T(), err)
+	updatedExpense, err := models.NewExpenseWithId(storedExpense.Id(), money, date(s.T(), "2023-03-11"), "Taxi", s.transport)
+	require.NoError(s.T(), err)
+	updatedExpense, err = updatedExpense.WithTags([]string{"friends"})
+	require.NoError(s.T(), err)
+
+	// When
+	_, err = s.repositories.Expenses.Update(context.Background(), s.ledgerId, updatedExpense)
+
+	// Then
+	require.NoError(s.T(), err)
+	foundExpense, err := s.repositories.Expenses.GetByID(context.Background(), s.ledgerId, storedExpense.Id())
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "2000.00", foundExpense.Amount().Amount())
+	assert.Equal(s.T(), "2023-03-11", foundExpense.ExpenseDate().Format("2006-01-02"))
+	assert.Equal(s.T(), "Taxi", foundExpense.Description())
+	assert.Equal(s.T(), s.transport.Id(), foundExpense.ExpenseType().Id())
+	assert.Equal(s.T(), []string{"friends"}, foundExpense.Tags())
+	assert.Equal(s.T(), "FIT-1", foundExpense.FitId())
+}
+
+func (s *ExpenseRepositoryContract) TestGivenAStoredExpenseWhenDeleteThenItIsNotFoundAnymore() {
+	// Given
+	storedExpense := newExpense(s.T(), "2023-03-10", "1500", "ARS", "Lomitos", s.food, "dinner")
+	s.add(storedExpense)
+
+	// When
+	err := s.repositories.Expenses.Delete(context.Background(), s.ledgerId, storedExpense.Id())
+
+	// Then
+	require.NoError(s.T(), err)
+	foundExpense, err := s.repositories.Expenses.GetByID(context.Background(), s.ledgerId, storedExpense.Id())
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), foundExpense)
+}
+
+// cursor builds the cursor the service returns for a page ending at the expense with the given sort value and id.
+func (s *ExpenseRepositoryContract) cursor(sort expense.SearchSort, value string, id uuid.UUID) *expense.Cursor {
+	payload, err := json.Marshal(map[string]interface{}{"s": sort, "v": value, "i": id})
+	require.NoError(s.T(), err)
+
+	cursor, err := expense.ParseCursor(base64.RawURLEncoding.EncodeToString(payload))
+	require.NoError(s.T(), err)
+	return cursor
+}
+
+func ids(expenses []*models.Expense) []uuid.UUID {
+	expenseIds := []uuid.UUID{}
+	for _, foundExpense := range expenses {
+		expenseIds = append(expenseIds, foundExpense.Id())
+	}
+	return expenseIds
+}
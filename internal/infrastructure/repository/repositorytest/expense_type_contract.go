@@ -0,0 +1,278 @@
+package repositorytest
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/expensetype"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"time"
+)
+
+// ExpenseTypeRepositoryContract is the behaviour every expensetype.Repository must have. NewRepositories is called
+// before every test.
+type ExpenseTypeRepositoryContract struct {
+	suite.Suite
+	NewRepositories func() Repositories
+	repositories    Repositories
+	ledgerId        uuid.UUID
+}
+
+func (s *ExpenseTypeRepositoryContract) SetupTest() {
+	s.repositories = s.NewRepositories()
+	s.ledgerId = pkg.NewUUID()
+}
+
+func (s *ExpenseTypeRepositoryContract) add(name string, parent *models.ExpenseType) *models.ExpenseType {
+	expenseType := newExpenseType(s.T(), name, parent)
+	_, err := s.repositories.ExpenseTypes.Add(context.Background(), s.ledgerId, expenseType)
+	require.NoError(s.T(), err)
+	return expenseType
+}
+
+func (s *ExpenseTypeRepositoryContract) TestGivenAStoredExpenseTypeWhenGetByIDThenReturnsItWithItsAncestors() {
+	// Given
+	food := s.add("Food", nil)
+	restaurants := s.add("Restaurants", food)
+
+	// When
+	storedExpenseType, err := s.repositories.ExpenseTypes.GetByID(context.Background(), s.ledgerId, restaurants.Id())
+
+	// Then
+	require.NoError(s.T(), err)
+	require.NotNil(s.T(), storedExpenseType)
+	assert.Equal(s.T(), restaurants.Id(), storedExpenseType.Id())
+	assert.Equal(s.T(), food.Id(), storedExpenseType.ParentId())
+	assert.Equal(s.T(), []string{"Food", "Restaurants"}, storedExpenseType.Path())
+}
+
+func (s *ExpenseTypeRepositoryContract) TestGivenAnUnknownIdOrAnotherLedgerWhenGetByIDThenReturnsNilWithoutError() {
+	// Given
+	food := s.add("Food", nil)
+
+	// When
+	unknownExpenseType, unknownErr := s.repositories.ExpenseTypes.GetByID(context.Background(), s.ledgerId, pkg.NewUUID())
+	otherLedgerExpenseType, otherLedgerErr := s.repositories.ExpenseTypes.GetByID(context.Background(), pkg.NewUUID(), food.Id())
+
+	// Then
+	assert.NoError(s.T(), unknownErr)
+	assert.Nil(s.T(), unknownExpenseType)
+	assert.NoError(s.T(), otherLedgerErr)
+	assert.Nil(s.T(), otherLedgerExpenseType)
+}
+
+func (s *ExpenseTypeRepositoryContract) TestGivenNamesRepeatedAtDifferentLevelsWhenGetByNameThenLooksAmongTheSiblings() {
+	// Given
+	food := s.add("Food", nil)
+	s.add("Other", nil)
+	foodOther := s.add("Other", food)
+
+	// When
+	topLevelExpenseType, topLevelErr := s.repositories.ExpenseTypes.GetByName(context.Background(), s.ledgerId, uuid.Nil, "Other")
+	childExpenseType, childErr := s.repositories.ExpenseTypes.GetByName(context.Background(), s.ledgerId, food.Id(), "Other")
+	missingExpenseType, missingErr := s.repositories.ExpenseTypes.GetByName(context.Background(), s.ledgerId, food.Id(), "Restaurants")
+
+	// Then
+	require.NoError(s.T(), topLevelErr)
+	require.NotNil(s.T(), topLevelExpenseType)
+	assert.Equal(s.T(), uuid.Nil, topLevelExpenseType.ParentId())
+	require.NoError(s.T(), childErr)
+	require.NotNil(s.T(), childExpenseType)
+	assert.Equal(s.T(), foodOther.Id(), childExpenseType.Id())
+	assert.NoError(s.T(), missingErr)
+	assert.Nil(s.T(), missingExpenseType)
+}
+
+func (s *ExpenseTypeRepositoryContract) TestGivenASiblingWithTheSameNameWhenAddThenReturnsError() {
+	// Given
+	food := s.add("Food", nil)
+	s.add("Restaurants", food)
+
+	// When
+	_, topLevelErr := s.repositories.ExpenseTypes.Add(context.Background(), s.ledgerId, newExpenseType(s.T(), "Food", nil))
+	_, childErr := s.repositories.ExpenseTypes.Add(context.Background(), s.ledgerId, newExpenseType(s.T(), "Restaurants", food))
+
+	// Then
+	assert.Error(s.T(), topLevelErr)
+	assert.Error(s.T(), childErr)
+}
+
+func (s *ExpenseTypeRepositoryContract) TestGivenTheSameNameInAnotherLedgerWhenAddThenStoresIt() {
+	// Given
+	s.add("Food", nil)
+
+	// When
+	_, err := s.repositories.ExpenseTypes.Add(context.Background(), pkg.NewUUID(), newExpenseType(s.T(), "Food", nil))
+
+	// Then
+	assert.NoError(s.T(), err)
+}
+
+func (s *ExpenseTypeRepositoryContract) TestGivenNestedExpenseTypesWhenGetAllThenReturnsThemOrderedByPath() {
+	// Given
+	transport := s.add("Transport", nil)
+	food := s.add("Food", nil)
+	s.add("Taxi", transport)
+	s.add("Restaurants", food)
+	s.add("Groceries", food)
+	_, err := s.repositories.ExpenseTypes.Add(context.Background(), pkg.NewUUID(), newExpenseType(s.T(), "Other", nil))
+	require.NoError(s.T(), err)
+
+	// When
+	expenseTypes, err := s.repositories.ExpenseTypes.GetAll(context.Background(), s.ledgerId)
+
+	// Then
+	require.NoError(s.T(), err)
+	paths := []string{}
+	for _, expenseType := range expenseTypes {
+		paths = append(paths, expenseType.FullPath())
+	}
+	assert.Equal(s.T(), []string{"Food", "Food > Groceries", "Food > Restaurants", "Transport", "Transport > Taxi"}, paths)
+}
+
+func (s *ExpenseTypeRepositoryContract) TestGivenARenamedExpenseTypeWhenGetByIDThenItsSubtypesHaveTheNewPath() {
+	// Given
+	food := s.add("Food", nil)
+	restaurants := s.add("Restaurants", food)
+	renamedFood, err := models.NewExpenseTypeWithId(food.Id(), "Eating out")
+	require.NoError(s.T(), err)
+
+	// When
+	_, err = s.repositories.ExpenseTypes.Update(context.Background(), s.ledgerId, renamedFood)
+
+	// Then
+	require.NoError(s.T(), err)
+	storedExpenseType, err := s.repositories.ExpenseTypes.GetByID(context.Background(), s.ledgerId, restaurants.Id())
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "Eating out > Restaurants", storedExpenseType.FullPath())
+}
+
+func (s *ExpenseTypeRepositoryContract) TestGivenTheNameOfASiblingWhenUpdateThenReturnsError() {
+	// Given
+	s.add("Food", nil)
+	transport := s.add("Transport", nil)
+	renamedTransport, err := models.NewExpenseTypeWithId(transport.Id(), "Food")
+	require.NoError(s.T(), err)
+
+	// When
+	_, err = s.repositories.ExpenseTypes.Update(context.Background(), s.ledgerId, renamedTransport)
+
+	// Then
+	assert.Error(s.T(), err)
+}
+
+func (s *ExpenseTypeRepositoryContract) TestGivenAStoredExpenseTypeWhenDeleteThenItIsNotFoundAnymore() {
+	// Given
+	food := s.add("Food", nil)
+
+	// When
+	err := s.repositories.ExpenseTypes.Delete(context.Background(), s.ledgerId, food.Id())
+
+	// Then
+	require.NoError(s.T(), err)
+	storedExpenseType, err := s.repositories.ExpenseTypes.GetByID(context.Background(), s.ledgerId, food.Id())
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), storedExpenseType)
+}
+
+func (s *ExpenseTypeRepositoryContract) TestGivenSubtypesAndExpensesWhenCheckingReferencesThenTellsWhichExpenseTypesHaveThem() {
+	// Given
+	food := s.add("Food", nil)
+	restaurants := s.add("Restaurants", food)
+	_, err := s.repositories.Expenses.Add(context.Background(), s.ledgerId, newExpense(s.T(), "2023-03-10", "1500", "ARS", "Lomitos", restaurants))
+	require.NoError(s.T(), err)
+
+	// When
+	foodHasSubtypes, foodSubtypesErr := s.repositories.ExpenseTypes.HasSubtypes(context.Background(), s.ledgerId, food.Id())
+	restaurantsHasSubtypes, restaurantsSubtypesErr := s.repositories.ExpenseTypes.HasSubtypes(context.Background(), s.ledgerId, restaurants.Id())
+	foodIsReferenced, foodReferencesErr := s.repositories.ExpenseTypes.IsReferencedByExpenses(context.Background(), s.ledgerId, food.Id())
+	restaurantsIsReferenced, restaurantsReferencesErr := s.repositories.ExpenseTypes.IsReferencedByExpenses(context.Background(), s.ledgerId, restaurants.Id())
+
+	// Then
+	require.NoError(s.T(), foodSubtypesErr)
+	require.NoError(s.T(), restaurantsSubtypesErr)
+	require.NoError(s.T(), foodReferencesErr)
+	require.NoError(s.T(), restaurantsReferencesErr)
+	assert.True(s.T(), foodHasSubtypes)
+	assert.False(s.T(), restaurantsHasSubtypes)
+	assert.False(s.T(), foodIsReferenced)
+	assert.True(s.T(), restaurantsIsReferenced)
+}
+
+func (s *ExpenseTypeRepositoryContract) TestGivenExpensesOfAnExpenseTypeWhenReassignExpensesThenTheyMoveToTheOtherOne() {
+	// Given
+	food := s.add("Food", nil)
+	restaurants := s.add("Restaurants", nil)
+	expense := newExpense(s.T(), "2023-03-10", "1500", "ARS", "Lomitos", restaurants)
+	_, err := s.repositories.Expenses.Add(context.Background(), s.ledgerId, expense)
+	require.NoError(s.T(), err)
+
+	// When
+	err = s.repositories.ExpenseTypes.ReassignExpenses(context.Background(), s.ledgerId, restaurants.Id(), food.Id())
+
+	// Then
+	require.NoError(s.T(), err)
+	storedExpense, err := s.repositories.Expenses.GetByID(context.Background(), s.ledgerId, expense.Id())
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), food.Id(), storedExpense.ExpenseType().Id())
+	isReferenced, err := s.repositories.ExpenseTypes.IsReferencedByExpenses(context.Background(), s.ledgerId, restaurants.Id())
+	require.NoError(s.T(), err)
+	assert.False(s.T(), isReferenced)
+}
+
+func (s *ExpenseTypeRepositoryContract) TestGivenAnExpenseTypeWithExpensesWhenDeleteThenReturnsError() {
+	// Given
+	food := s.add("Food", nil)
+	_, err := s.repositories.Expenses.Add(context.Background(), s.ledgerId, newExpense(s.T(), "2023-03-10", "1500", "ARS", "Lomitos", food))
+	require.NoError(s.T(), err)
+
+	// When
+	err = s.repositories.ExpenseTypes.Delete(context.Background(), s.ledgerId, food.Id())
+
+	// Then
+	assert.ErrorIs(s.T(), err, expensetype.ErrExpenseTypeReferenced)
+}
+
+func (s *ExpenseTypeRepositoryContract) TestGivenAnExpenseTypeWithARecurringExpenseWhenDeleteThenReturnsError() {
+	// Given
+	userId := addUser(s.T(), s.repositories.Users)
+	food := s.add("Food", nil)
+	amount, err := models.NewMoney("1500", "ARS")
+	require.NoError(s.T(), err)
+	schedule, err := models.NewRecurrenceRule(models.MonthlyRecurrenceFrequency, 1, date(s.T(), "2023-03-10"), time.Time{}, 0)
+	require.NoError(s.T(), err)
+	recurringExpense, err := models.NewRecurringExpense(amount, "Gym", food, schedule)
+	require.NoError(s.T(), err)
+	_, err = s.repositories.RecurringExpenses.Add(context.Background(), userId, recurringExpense)
+	require.NoError(s.T(), err)
+
+	// When
+	err = s.repositories.ExpenseTypes.Delete(context.Background(), s.ledgerId, food.Id())
+
+	// Then
+	assert.ErrorIs(s.T(), err, expensetype.ErrExpenseTypeReferenced)
+}
+
+func (s *ExpenseTypeRepositoryContract) TestGivenAnExpenseTypeWithABudgetWhenDeleteThenDeletesTheBudget() {
+	// Given
+	userId := addUser(s.T(), s.repositories.Users)
+	food := s.add("Food", nil)
+	limit, err := models.NewMoney("1500", "ARS")
+	require.NoError(s.T(), err)
+	budget, err := models.NewBudget(food, models.MonthlyBudgetPeriod, limit, false)
+	require.NoError(s.T(), err)
+	_, err = s.repositories.Budgets.Add(context.Background(), userId, budget)
+	require.NoError(s.T(), err)
+
+	// When
+	err = s.repositories.ExpenseTypes.Delete(context.Background(), s.ledgerId, food.Id())
+
+	// Then
+	require.NoError(s.T(), err)
+	storedBudget, err := s.repositories.Budgets.GetByID(context.Background(), userId, budget.Id())
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), storedBudget)
+}
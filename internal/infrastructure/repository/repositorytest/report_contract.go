@@ -0,0 +1,117 @@
+package repositorytest
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// ReportRepositoryContract is the behaviour every report.Repository must have. NewRepositories is called before every
+// test.
+type ReportRepositoryContract struct {
+	suite.Suite
+	NewRepositories func() Repositories
+	repositories    Repositories
+	ledgerId        uuid.UUID
+	food            *models.ExpenseType
+	transport       *models.ExpenseType
+	health          *models.ExpenseType
+}
+
+func (s *ReportRepositoryContract) SetupTest() {
+	s.repositories = s.NewRepositories()
+	s.ledgerId = pkg.NewUUID()
+	s.food = newExpenseType(s.T(), "Food", nil)
+	s.transport = newExpenseType(s.T(), "Transport", nil)
+	s.health = newExpenseType(s.T(), "Health", nil)
+	for _, expenseType := range []*models.ExpenseType{s.food, s.transport, s.health} {
+		_, err := s.repositories.ExpenseTypes.Add(context.Background(), s.ledgerId, expenseType)
+		require.NoError(s.T(), err)
+	}
+}
+
+func (s *ReportRepositoryContract) add(ledgerId uuid.UUID, expenses ...*models.Expense) {
+	for _, expenseToAdd := range expenses {
+		_, err := s.repositories.Expenses.Add(context.Background(), ledgerId, expenseToAdd)
+		require.NoError(s.T(), err)
+	}
+}
+
+func (s *ReportRepositoryContract) TestGivenExpensesInSeveralCurrenciesWhenGetSpendingByMonthThenGroupsThemByCurrencyAndMonth() {
+	// Given
+	s.add(s.ledgerId,
+		newExpense(s.T(), "2023-03-10", "1500", "ARS", "Lomitos", s.food),
+		newExpense(s.T(), "2023-03-20", "500", "ARS", "Bus", s.transport),
+		newExpense(s.T(), "2023-04-01", "1000", "ARS", "Pizza", s.food),
+		newExpense(s.T(), "2023-03-15", "10", "USD", "Coffee", s.food),
+		newExpense(s.T(), "2023-05-01", "700", "ARS", "Taxi", s.transport))
+	s.add(pkg.NewUUID(), newExpense(s.T(), "2023-03-11", "999", "ARS", "Burger", s.food))
+
+	// When
+	spending, err := s.repositories.Reports.GetSpending(context.Background(), s.ledgerId, date(s.T(), "2023-03-01"), date(s.T(), "2023-04-30"), models.MonthReportGrouping, "", nil)
+
+	// Then
+	require.NoError(s.T(), err)
+	require.Len(s.T(), spending, 2)
+	assert.Equal(s.T(), "3000.00", spending[0].Total().Amount())
+	assert.Equal(s.T(), "ARS", spending[0].Currency())
+	assert.Equal(s.T(), int64(3), spending[0].Count())
+	require.Len(s.T(), spending[0].Groups(), 2)
+	assert.Equal(s.T(), "2023-03", spending[0].Groups()[0].Key())
+	assert.Equal(s.T(), "2000.00", spending[0].Groups()[0].Total().Amount())
+	assert.Equal(s.T(), int64(2), spending[0].Groups()[0].Count())
+	assert.Equal(s.T(), "2023-04", spending[0].Groups()[1].Label())
+	assert.Equal(s.T(), "1000.00", spending[0].Groups()[1].Total().Amount())
+	assert.Equal(s.T(), "USD", spending[1].Currency())
+	assert.Equal(s.T(), "10.00", spending[1].Total().Amount())
+	require.Len(s.T(), spending[1].Groups(), 1)
+	assert.Equal(s.T(), "2023-03", spending[1].Groups()[0].Key())
+}
+
+func (s *ReportRepositoryContract) TestGivenExpensesOfSeveralTypesWhenGetSpendingByExpenseTypeOfSomeThenGroupsOnlyThemByName() {
+	// Given
+	s.add(s.ledgerId,
+		newExpense(s.T(), "2023-03-10", "1500", "ARS", "Lomitos", s.food),
+		newExpense(s.T(), "2023-03-20", "500", "ARS", "Bus", s.transport),
+		newExpense(s.T(), "2023-03-21", "800", "ARS", "Doctor", s.health),
+		newExpense(s.T(), "2023-03-22", "10", "USD", "Coffee", s.food))
+
+	// When
+	spending, err := s.repositories.Reports.GetSpending(context.Background(), s.ledgerId, date(s.T(), "2023-03-01"), date(s.T(), "2023-03-31"), models.ExpenseTypeReportGrouping, "ARS", []uuid.UUID{s.transport.Id(), s.food.Id()})
+
+	// Then
+	require.NoError(s.T(), err)
+	require.Len(s.T(), spending, 1)
+	assert.Equal(s.T(), "2000.00", spending[0].Total().Amount())
+	require.Len(s.T(), spending[0].Groups(), 2)
+	assert.Equal(s.T(), s.food.Id().String(), spending[0].Groups()[0].Key())
+	assert.Equal(s.T(), "Food", spending[0].Groups()[0].Label())
+	assert.Equal(s.T(), s.transport.Id().String(), spending[0].Groups()[1].Key())
+	assert.Equal(s.T(), "Transport", spending[0].Groups()[1].Label())
+}
+
+func (s *ReportRepositoryContract) TestGivenExpensesAroundTheNewYearWhenGetDailySpendingByWeekThenGroupsTheDaysByISOWeek() {
+	// Given
+	s.add(s.ledgerId,
+		newExpense(s.T(), "2023-01-02", "500", "ARS", "Bus", s.transport),
+		newExpense(s.T(), "2023-01-01", "1500", "ARS", "Lomitos", s.food),
+		newExpense(s.T(), "2023-01-02", "800", "ARS", "Doctor", s.health))
+
+	// When
+	entries, err := s.repositories.Reports.GetDailySpending(context.Background(), s.ledgerId, date(s.T(), "2023-01-01"), date(s.T(), "2023-01-31"), models.WeekReportGrouping, "", nil)
+
+	// Then
+	require.NoError(s.T(), err)
+	require.Len(s.T(), entries, 2)
+	assert.Equal(s.T(), "2022-W52", entries[0].Key())
+	assert.Equal(s.T(), "2023-01-01", entries[0].Date().Format("2006-01-02"))
+	assert.Equal(s.T(), "1500.00", entries[0].Total().Amount())
+	assert.Equal(s.T(), "2023-W01", entries[1].Label())
+	assert.Equal(s.T(), "2023-01-02", entries[1].Date().Format("2006-01-02"))
+	assert.Equal(s.T(), "1300.00", entries[1].Total().Amount())
+	assert.Equal(s.T(), int64(2), entries[1].Count())
+}
@@ -0,0 +1,73 @@
+// Package repositorytest holds the contract test suites every implementation of the repositories must pass, so the
+// in-memory ones behave like the SQL ones the app runs on.
+package repositorytest
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/domain/services/budget"
+	"finfit-backend/internal/domain/services/expense"
+	"finfit-backend/internal/domain/services/expensetype"
+	"finfit-backend/internal/domain/services/recurringexpense"
+	"finfit-backend/internal/domain/services/report"
+	"finfit-backend/internal/domain/services/user"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Repositories are the implementations under test, sharing the same storage since the expense types can't be deleted
+// while expenses or recurring expenses reference them, and take their budgets with them. The suites give every test a
+// new ledger, so the storage can be shared by all of them.
+type Repositories struct {
+	Users             user.Repository
+	ExpenseTypes      expensetype.Repository
+	Expenses          expense.Repository
+	Budgets           budget.Repository
+	RecurringExpenses recurringexpense.Repository
+	Reports           report.Repository
+}
+
+// addUser stores a new user with a unique email, so the storage can be shared by the tests.
+func addUser(t *testing.T, users user.Repository) uuid.UUID {
+	newUser, err := models.NewUser(pkg.NewUUID().String()+"@example.com", "hash")
+	require.NoError(t, err)
+
+	_, err = users.Add(context.Background(), newUser)
+	require.NoError(t, err)
+	return newUser.Id()
+}
+
+func newExpenseType(t *testing.T, name string, parent *models.ExpenseType) *models.ExpenseType {
+	expenseType, err := models.NewExpenseType(name)
+	require.NoError(t, err)
+
+	expenseType, err = expenseType.WithParent(parent)
+	require.NoError(t, err)
+	return expenseType
+}
+
+func newExpense(t *testing.T, expenseDate string, amount string, currency string, description string, expenseType *models.ExpenseType, tags ...string) *models.Expense {
+	money, err := models.NewMoney(amount, currency)
+	require.NoError(t, err)
+
+	date, err := time.Parse("2006-01-02", expenseDate)
+	require.NoError(t, err)
+
+	newExpense, err := models.NewExpense(money, date, description, expenseType)
+	require.NoError(t, err)
+
+	if len(tags) > 0 {
+		newExpense, err = newExpense.WithTags(tags)
+		require.NoError(t, err)
+	}
+	return newExpense
+}
+
+func date(t *testing.T, value string) time.Time {
+	parsedDate, err := time.Parse("2006-01-02", value)
+	require.NoError(t, err)
+	return parsedDate
+}
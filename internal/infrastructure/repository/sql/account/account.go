@@ -0,0 +1,29 @@
+package account
+
+import (
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Account struct {
+	ID             string `gorm:"primaryKey"`
+	UserID         string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Name           string
+	Kind           string
+	Currency       string
+	OpeningBalance sql.Amount
+}
+
+func (receiver Account) MapToDomainAccount() (*models.Account, error) {
+	id, _ := uuid.Parse(receiver.ID)
+	openingBalance, err := models.NewMoney(string(receiver.OpeningBalance), receiver.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewAccountWithId(id, receiver.Name, models.AccountKind(receiver.Kind), openingBalance)
+}
@@ -0,0 +1,129 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type repository struct {
+	table         string
+	transferTable string
+	expenseTable  string
+	db            sql.Database
+}
+
+func NewRepository(db sql.Database, table string, transferTable string, expenseTable string) *repository {
+	return &repository{db: db, table: table, transferTable: transferTable, expenseTable: expenseTable}
+}
+
+func (r repository) Add(ctx context.Context, userId uuid.UUID, account *models.Account) (*models.Account, error) {
+	accountDbModel := Account{
+		ID:             account.Id().String(),
+		UserID:         userId.String(),
+		Name:           account.Name(),
+		Kind:           string(account.Kind()),
+		Currency:       account.Currency(),
+		OpeningBalance: sql.Amount(account.OpeningBalance().Amount()),
+	}
+	result := r.db.WithContext(ctx).Table(r.table).Create(&accountDbModel)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (r repository) GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Account, error) {
+	var storedAccount Account
+	result := r.db.WithContext(ctx).Table(r.table).First(&storedAccount, "id = ? AND user_id = ?", id.String(), userId.String())
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return storedAccount.MapToDomainAccount()
+}
+
+func (r repository) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.Account, error) {
+	storedAccounts := []Account{}
+	result := r.db.WithContext(ctx).Table(r.table).Where("user_id = ?", userId.String()).Order("name").Find(&storedAccounts)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	accounts := []*models.Account{}
+	for _, storedAccount := range storedAccounts {
+		account, err := storedAccount.MapToDomainAccount()
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, nil
+}
+
+func (r repository) AddTransfer(ctx context.Context, userId uuid.UUID, transfer *models.Transfer) (*models.Transfer, error) {
+	transferDbModel := Transfer{
+		ID:            transfer.Id().String(),
+		UserID:        userId.String(),
+		FromAccountID: transfer.FromAccount().Id().String(),
+		ToAccountID:   transfer.ToAccount().Id().String(),
+		Amount:        sql.Amount(transfer.Amount().Amount()),
+		Currency:      transfer.Amount().Currency(),
+		TransferDate:  transfer.TransferDate(),
+		Description:   transfer.Description(),
+	}
+	result := r.db.WithContext(ctx).Table(r.transferTable).Create(&transferDbModel)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+func (r repository) GetExpensesTotal(ctx context.Context, userId uuid.UUID, account *models.Account, until time.Time) (*models.Money, error) {
+	// expenses belong to ledgers, which may be shared, so the owner of the account is checked instead
+	return r.sum(ctx, r.expenseTable, account, "account_id = ? AND expense_date <= ? AND account_id IN (SELECT id FROM "+r.table+" WHERE user_id = ?)", account.Id().String(), sql.Date(until), userId.String())
+}
+
+func (r repository) GetTransfersTotals(ctx context.Context, userId uuid.UUID, account *models.Account, until time.Time) (*models.Money, *models.Money, error) {
+	incoming, err := r.sum(ctx, r.transferTable, account, "user_id = ? AND to_account_id = ? AND transfer_date <= ?", userId.String(), account.Id().String(), sql.Date(until))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outgoing, err := r.sum(ctx, r.transferTable, account, "user_id = ? AND from_account_id = ? AND transfer_date <= ?", userId.String(), account.Id().String(), sql.Date(until))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return incoming, outgoing, nil
+}
+
+// sum adds up the amount column in the database so the totals keep the exactness of the decimal column.
+func (r repository) sum(ctx context.Context, table string, account *models.Account, query string, args ...interface{}) (*models.Money, error) {
+	var total sql.Amount
+	result := r.db.WithContext(ctx).Table(table).
+		Select("COALESCE(SUM(amount), 0)").
+		Where(query, args...).
+		Scan(&total)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return models.NewMoney(string(total), account.Currency())
+}
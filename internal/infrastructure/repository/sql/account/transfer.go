@@ -0,0 +1,19 @@
+package account
+
+import (
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"time"
+)
+
+type Transfer struct {
+	ID            string `gorm:"primaryKey"`
+	UserID        string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	FromAccountID string
+	ToAccountID   string
+	Amount        sql.Amount
+	Currency      string
+	TransferDate  time.Time
+	Description   string
+}
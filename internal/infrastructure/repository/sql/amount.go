@@ -0,0 +1,65 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"math/big"
+)
+
+// sqliteAmountScale is the number of decimal places of the amounts stored in SQLite, the most any currency uses.
+const sqliteAmountScale = 4
+
+var sqliteAmountUnit = big.NewInt(10000)
+
+// Amount is a decimal amount column. Postgres stores it as a decimal, and SQLite, whose decimals are floating point, as
+// an integer number of ten-thousandths, so both compare and sum the amounts exactly.
+type Amount string
+
+// GormValue binds the amount the way the database of the statement stores it.
+func (a Amount) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	if db.Dialector.Name() != "sqlite" {
+		return clause.Expr{SQL: "?", Vars: []interface{}{string(a)}}
+	}
+
+	scaledAmount, err := scaleAmount(string(a))
+	if err != nil {
+		_ = db.AddError(err)
+	}
+	return clause.Expr{SQL: "?", Vars: []interface{}{scaledAmount}}
+}
+
+// Scan reads the decimals of Postgres and the integers of SQLite, as well as their sums.
+func (a *Amount) Scan(value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		*a = ""
+	case string:
+		*a = Amount(value)
+	case []byte:
+		*a = Amount(value)
+	case int64:
+		*a = Amount(new(big.Rat).SetFrac(big.NewInt(value), sqliteAmountUnit).FloatString(sqliteAmountScale))
+	default:
+		return fmt.Errorf("invalid amount, unsupported type %T", value)
+	}
+	return nil
+}
+
+func scaleAmount(amount string) (int64, error) {
+	decimal, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return 0, errors.New("invalid amount, must be a decimal number")
+	}
+
+	scaledAmount := decimal.Mul(decimal, new(big.Rat).SetInt(sqliteAmountUnit))
+	if !scaledAmount.IsInt() {
+		return 0, fmt.Errorf("invalid amount, SQLite stores up to %d decimal places", sqliteAmountScale)
+	}
+	if !scaledAmount.Num().IsInt64() {
+		return 0, errors.New("invalid amount, it's too large to be stored in SQLite")
+	}
+	return scaledAmount.Num().Int64(), nil
+}
@@ -0,0 +1,101 @@
+package sql_test
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql/account"
+	"finfit-backend/internal/infrastructure/repository/sql/expense"
+	"finfit-backend/internal/infrastructure/repository/sql/report"
+	"finfit-backend/internal/infrastructure/repository/sql/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func addUser(t *testing.T, db *gorm.DB) *models.User {
+	storedUser, err := models.NewUser("jane@example.com", "hash")
+	require.NoError(t, err)
+	_, err = user.NewRepository(db, "app_user", "used_refresh_token").Add(context.Background(), storedUser)
+	require.NoError(t, err)
+	return storedUser
+}
+
+// addExpenses stores the amounts as expenses of the personal ledger of a new user, paid from a new account.
+func addExpenses(t *testing.T, db *gorm.DB, amounts ...string) (*models.User, *models.Account) {
+	storedUser := addUser(t, db)
+	openingBalance, err := models.NewMoney("0.10", "USD")
+	require.NoError(t, err)
+	storedAccount, err := models.NewAccount("Checking", models.BankAccountKind, openingBalance)
+	require.NoError(t, err)
+	_, err = account.NewRepository(db, "account", "transfer", "expense").Add(context.Background(), storedUser.Id(), storedAccount)
+	require.NoError(t, err)
+
+	ledgerId := models.PersonalLedgerId(storedUser.Id())
+	food := addExpenseType(t, newExpenseTypeRepository(db), ledgerId, "Food")
+	expenseRepository := expense.NewRepository(db, "expense", "expense_split_participant", "expense_type", "tag", "expense_tag")
+	for _, amount := range amounts {
+		money, err := models.NewMoney(amount, "USD")
+		require.NoError(t, err)
+		storedExpense, err := models.NewExpense(money, time.Date(2023, 3, 14, 0, 0, 0, 0, time.UTC), "Groceries", food)
+		require.NoError(t, err)
+		storedExpense, err = storedExpense.WithAccount(storedAccount)
+		require.NoError(t, err)
+		_, err = expenseRepository.Add(context.Background(), ledgerId, storedExpense)
+		require.NoError(t, err)
+	}
+
+	return storedUser, storedAccount
+}
+
+func TestGivenAmountsThatDriftAsFloats_WhenGetSpendingOnSQLite_ThenTheTotalsAreExact(t *testing.T) {
+	db := openSQLiteTestDatabase(t)
+	storedUser, _ := addExpenses(t, db, "10.10", "20.20", "20.36")
+
+	spending, err := report.NewRepository(db, "expense", "expense_type").GetSpending(context.Background(), models.PersonalLedgerId(storedUser.Id()),
+		time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC),
+		models.MonthReportGrouping, "", nil)
+
+	require.NoError(t, err)
+	require.Len(t, spending, 1)
+	assert.Equal(t, "50.66", spending[0].Total().Amount())
+	assert.Equal(t, "50.66", spending[0].Groups()[0].Total().Amount())
+}
+
+func TestGivenAmountsThatDriftAsFloats_WhenGetDailySpendingOnSQLite_ThenTheTotalsAreExact(t *testing.T) {
+	db := openSQLiteTestDatabase(t)
+	storedUser, _ := addExpenses(t, db, "10.10", "20.20", "20.36")
+
+	entries, err := report.NewRepository(db, "expense", "expense_type").GetDailySpending(context.Background(), models.PersonalLedgerId(storedUser.Id()),
+		time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC),
+		models.MonthReportGrouping, "", nil)
+
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "50.66", entries[0].Total().Amount())
+}
+
+func TestGivenAmountsThatDriftAsFloats_WhenGetExpensesTotalOnSQLite_ThenTheBalanceIsExact(t *testing.T) {
+	db := openSQLiteTestDatabase(t)
+	storedUser, storedAccount := addExpenses(t, db, "10.10", "20.20", "20.36")
+	accountRepository := account.NewRepository(db, "account", "transfer", "expense")
+
+	total, err := accountRepository.GetExpensesTotal(context.Background(), storedUser.Id(), storedAccount, time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "50.66", total.Amount())
+
+	storedAccount, err = accountRepository.GetByID(context.Background(), storedUser.Id(), storedAccount.Id())
+	require.NoError(t, err)
+	assert.Equal(t, "0.10", storedAccount.OpeningBalance().Amount())
+}
+
+func TestGivenAnAmountWithMoreDigitsThanAFloatKeeps_WhenStoredOnSQLite_ThenItIsReadBackExactly(t *testing.T) {
+	db := openSQLiteTestDatabase(t)
+	storedUser, storedAccount := addExpenses(t, db, "12345678901234.56")
+
+	total, err := account.NewRepository(db, "account", "transfer", "expense").GetExpensesTotal(context.Background(), storedUser.Id(), storedAccount, time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.Equal(t, "12345678901234.56", total.Amount())
+}
@@ -0,0 +1,24 @@
+package attachment
+
+import (
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Attachment struct {
+	ID          string    `gorm:"primaryKey,column:id"`
+	LedgerID    string    `gorm:"column:ledger_id"`
+	ExpenseID   string    `gorm:"column:expense_id"`
+	FileName    string    `gorm:"column:file_name"`
+	ContentType string    `gorm:"column:content_type"`
+	Size        int64     `gorm:"column:size"`
+	Checksum    string    `gorm:"column:checksum"`
+	UploadedAt  time.Time `gorm:"column:uploaded_at"`
+}
+
+func (receiver Attachment) MapToDomainAttachment() (*models.Attachment, error) {
+	id, _ := uuid.Parse(receiver.ID)
+	expenseId, _ := uuid.Parse(receiver.ExpenseID)
+	return models.NewAttachmentWithId(id, expenseId, receiver.FileName, receiver.ContentType, receiver.Size, receiver.Checksum, receiver.UploadedAt.UTC())
+}
@@ -0,0 +1,88 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	table string
+	db    sql.Database
+}
+
+func NewRepository(db sql.Database, table string) *repository {
+	return &repository{db: db, table: table}
+}
+
+func (r repository) Add(ctx context.Context, ledgerId uuid.UUID, attachment *models.Attachment) (*models.Attachment, error) {
+	attachmentDbModel := Attachment{
+		ID:          attachment.Id().String(),
+		LedgerID:    ledgerId.String(),
+		ExpenseID:   attachment.ExpenseId().String(),
+		FileName:    attachment.FileName(),
+		ContentType: attachment.ContentType(),
+		Size:        attachment.Size(),
+		Checksum:    attachment.Checksum(),
+		UploadedAt:  attachment.UploadedAt(),
+	}
+
+	if err := r.db.WithContext(ctx).Table(r.table).Create(&attachmentDbModel).Error; err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (r repository) GetAll(ctx context.Context, ledgerId uuid.UUID, expenseId uuid.UUID) ([]*models.Attachment, error) {
+	storedAttachments := []Attachment{}
+	result := r.db.WithContext(ctx).Table(r.table).
+		Where("ledger_id = ? AND expense_id = ?", ledgerId.String(), expenseId.String()).
+		Order("uploaded_at, id").
+		Find(&storedAttachments)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	attachments := []*models.Attachment{}
+	for _, storedAttachment := range storedAttachments {
+		attachment, err := storedAttachment.MapToDomainAttachment()
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, nil
+}
+
+func (r repository) GetByID(ctx context.Context, ledgerId uuid.UUID, expenseId uuid.UUID, id uuid.UUID) (*models.Attachment, error) {
+	return r.getFirst(ctx, "id = ? AND ledger_id = ? AND expense_id = ?", id.String(), ledgerId.String(), expenseId.String())
+}
+
+func (r repository) GetByChecksum(ctx context.Context, ledgerId uuid.UUID, expenseId uuid.UUID, checksum string) (*models.Attachment, error) {
+	return r.getFirst(ctx, "checksum = ? AND ledger_id = ? AND expense_id = ?", checksum, ledgerId.String(), expenseId.String())
+}
+
+func (r repository) Delete(ctx context.Context, ledgerId uuid.UUID, expenseId uuid.UUID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Table(r.table).Delete(&Attachment{}, "id = ? AND ledger_id = ? AND expense_id = ?", id.String(), ledgerId.String(), expenseId.String()).Error
+}
+
+func (r repository) getFirst(ctx context.Context, query string, args ...interface{}) (*models.Attachment, error) {
+	var storedAttachment Attachment
+	result := r.db.WithContext(ctx).Table(r.table).Where(query, args...).First(&storedAttachment)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return storedAttachment.MapToDomainAttachment()
+}
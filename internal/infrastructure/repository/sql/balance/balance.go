@@ -0,0 +1,48 @@
+package balance
+
+import (
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Debt is the part of a split expense a participant owes its payer.
+type Debt struct {
+	UserID      string     `gorm:"column:user_id"`
+	SplitPaidBy string     `gorm:"column:split_paid_by"`
+	Amount      sql.Amount `gorm:"column:amount"`
+	Currency    string     `gorm:"column:currency"`
+}
+
+func (receiver Debt) MapToDomainDebt() (*models.Debt, error) {
+	from, _ := uuid.Parse(receiver.UserID)
+	to, _ := uuid.Parse(receiver.SplitPaidBy)
+	amount, err := models.NewMoney(string(receiver.Amount), receiver.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewDebt(from, to, amount)
+}
+
+type Settlement struct {
+	ID         string     `gorm:"primaryKey,column:id"`
+	LedgerID   string     `gorm:"column:ledger_id"`
+	FromUserID string     `gorm:"column:from_user_id"`
+	ToUserID   string     `gorm:"column:to_user_id"`
+	Amount     sql.Amount `gorm:"column:amount"`
+	Currency   string     `gorm:"column:currency"`
+	SettledAt  time.Time  `gorm:"column:settled_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at"`
+}
+
+func (receiver Settlement) MapToDomainSettlement() (*models.Settlement, error) {
+	id, _ := uuid.Parse(receiver.ID)
+	from, _ := uuid.Parse(receiver.FromUserID)
+	to, _ := uuid.Parse(receiver.ToUserID)
+	amount, err := models.NewMoney(string(receiver.Amount), receiver.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewSettlementWithId(id, from, to, amount, receiver.SettledAt)
+}
@@ -0,0 +1,87 @@
+package balance
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"github.com/google/uuid"
+)
+
+type repository struct {
+	expenseTable    string
+	splitTable      string
+	settlementTable string
+	db              sql.Database
+}
+
+func NewRepository(db sql.Database, expenseTable string, splitTable string, settlementTable string) *repository {
+	return &repository{db: db, expenseTable: expenseTable, splitTable: splitTable, settlementTable: settlementTable}
+}
+
+// GetDebts reads the allocations of the split expenses of the ledger. The payer doesn't owe their own part, and
+// participants with nothing allocated owe nothing.
+func (r repository) GetDebts(ctx context.Context, ledgerId uuid.UUID) ([]*models.Debt, error) {
+	storedDebts := []Debt{}
+	result := r.db.WithContext(ctx).Table(r.splitTable).
+		Select(r.splitTable+".user_id, "+r.expenseTable+".split_paid_by, "+r.splitTable+".amount, "+r.expenseTable+".currency").
+		Joins("JOIN "+r.expenseTable+" ON "+r.expenseTable+".id = "+r.splitTable+".expense_id").
+		Where(r.expenseTable+".ledger_id = ? AND "+r.splitTable+".user_id <> "+r.expenseTable+".split_paid_by AND "+r.splitTable+".amount > 0", ledgerId.String()).
+		Find(&storedDebts)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	debts := []*models.Debt{}
+	for _, storedDebt := range storedDebts {
+		debt, err := storedDebt.MapToDomainDebt()
+		if err != nil {
+			return nil, err
+		}
+		debts = append(debts, debt)
+	}
+
+	return debts, nil
+}
+
+func (r repository) GetSettlements(ctx context.Context, ledgerId uuid.UUID) ([]*models.Settlement, error) {
+	storedSettlements := []Settlement{}
+	result := r.db.WithContext(ctx).Table(r.settlementTable).
+		Where("ledger_id = ?", ledgerId.String()).
+		Order("settled_at, created_at").
+		Find(&storedSettlements)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	settlements := []*models.Settlement{}
+	for _, storedSettlement := range storedSettlements {
+		settlement, err := storedSettlement.MapToDomainSettlement()
+		if err != nil {
+			return nil, err
+		}
+		settlements = append(settlements, settlement)
+	}
+
+	return settlements, nil
+}
+
+func (r repository) AddSettlement(ctx context.Context, ledgerId uuid.UUID, settlement *models.Settlement) (*models.Settlement, error) {
+	settlementDbModel := Settlement{
+		ID:         settlement.Id().String(),
+		LedgerID:   ledgerId.String(),
+		FromUserID: settlement.From().String(),
+		ToUserID:   settlement.To().String(),
+		Amount:     sql.Amount(settlement.Amount().Amount()),
+		Currency:   settlement.Amount().Currency(),
+		SettledAt:  settlement.SettledAt(),
+	}
+	result := r.db.WithContext(ctx).Table(r.settlementTable).Create(&settlementDbModel)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return settlement, nil
+}
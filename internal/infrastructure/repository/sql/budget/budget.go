@@ -0,0 +1,36 @@
+package budget
+
+import (
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"finfit-backend/internal/infrastructure/repository/sql/expensetype"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Budget struct {
+	ID            string `gorm:"primaryKey"`
+	UserID        string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	ExpenseTypeID string
+	ExpenseType   expensetype.ExpenseType
+	Period        string
+	LimitAmount   sql.Amount
+	Currency      string
+	Rollover      bool
+}
+
+func (receiver Budget) MapToDomainBudget() (*models.Budget, error) {
+	id, _ := uuid.Parse(receiver.ID)
+	limit, err := models.NewMoney(string(receiver.LimitAmount), receiver.Currency)
+	if err != nil {
+		return nil, err
+	}
+	expenseType, err := receiver.ExpenseType.MapToDomainExpenseType()
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewBudgetWithId(id, expenseType, models.BudgetPeriod(receiver.Period), limit, receiver.Rollover)
+}
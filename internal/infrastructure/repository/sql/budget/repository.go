@@ -0,0 +1,112 @@
+package budget
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	table string
+	db    sql.Database
+}
+
+func NewRepository(db sql.Database, table string) *repository {
+	return &repository{db: db, table: table}
+}
+
+func (r repository) Add(ctx context.Context, userId uuid.UUID, budget *models.Budget) (*models.Budget, error) {
+	budgetDbModel := r.mapBudgetDBModelFromBudget(userId, budget)
+	result := r.db.WithContext(ctx).Table(r.table).Create(&budgetDbModel)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+func (r repository) GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Budget, error) {
+	return r.first(ctx, userId, r.table+".id = ?", id.String())
+}
+
+func (r repository) GetByExpenseTypeAndPeriod(ctx context.Context, userId uuid.UUID, expenseTypeId uuid.UUID, period models.BudgetPeriod) (*models.Budget, error) {
+	return r.first(ctx, userId, r.table+".expense_type_id = ? AND "+r.table+".period = ?", expenseTypeId.String(), string(period))
+}
+
+func (r repository) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.Budget, error) {
+	storedBudgets := []Budget{}
+	result := r.db.WithContext(ctx).Table(r.table).
+		Joins("ExpenseType").
+		Where(r.table+".user_id = ?", userId.String()).
+		Order("\"ExpenseType\".name").
+		Find(&storedBudgets)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	budgets := []*models.Budget{}
+	for _, storedBudget := range storedBudgets {
+		budget, err := storedBudget.MapToDomainBudget()
+		if err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, budget)
+	}
+
+	return budgets, nil
+}
+
+func (r repository) Update(ctx context.Context, userId uuid.UUID, budget *models.Budget) (*models.Budget, error) {
+	budgetDbModel := r.mapBudgetDBModelFromBudget(userId, budget)
+	result := r.db.WithContext(ctx).Table(r.table).
+		Where("id = ? AND user_id = ?", budgetDbModel.ID, budgetDbModel.UserID).
+		Select("expense_type_id", "period", "limit_amount", "currency", "rollover", "updated_at").
+		Updates(&budgetDbModel)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+func (r repository) Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Table(r.table).Delete(&Budget{}, "id = ? AND user_id = ?", id.String(), userId.String())
+	return result.Error
+}
+
+func (r repository) first(ctx context.Context, userId uuid.UUID, query string, args ...interface{}) (*models.Budget, error) {
+	var storedBudget Budget
+	result := r.db.WithContext(ctx).Table(r.table).
+		Joins("ExpenseType").
+		Where(r.table+".user_id = ?", userId.String()).
+		Where(query, args...).
+		First(&storedBudget)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return storedBudget.MapToDomainBudget()
+}
+
+func (r repository) mapBudgetDBModelFromBudget(userId uuid.UUID, budget *models.Budget) Budget {
+	return Budget{
+		ID:            budget.Id().String(),
+		UserID:        userId.String(),
+		ExpenseTypeID: budget.ExpenseType().Id().String(),
+		Period:        string(budget.Period()),
+		LimitAmount:   sql.Amount(budget.Limit().Amount()),
+		Currency:      budget.Limit().Currency(),
+		Rollover:      budget.Rollover(),
+	}
+}
@@ -0,0 +1,103 @@
+package sql_test
+
+import (
+	dbmigrations "finfit-backend/db_migrations"
+	"finfit-backend/internal/infrastructure/repository/repositorytest"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"finfit-backend/internal/infrastructure/repository/sql/budget"
+	"finfit-backend/internal/infrastructure/repository/sql/expense"
+	"finfit-backend/internal/infrastructure/repository/sql/expensetype"
+	"finfit-backend/internal/infrastructure/repository/sql/migration"
+	"finfit-backend/internal/infrastructure/repository/sql/recurringexpense"
+	"finfit-backend/internal/infrastructure/repository/sql/report"
+	"finfit-backend/internal/infrastructure/repository/sql/user"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testDatabaseDsnEnv names the Postgres database the contract runs against, e.g.
+// host=localhost port=5432 user=finfit password=finfit dbname=finfit_test sslmode=disable. Its schema is migrated
+// before running the tests, which are skipped when it isn't set.
+const testDatabaseDsnEnv = "TEST_DATABASE_DSN"
+
+func openPostgresTestDatabase(t *testing.T) *gorm.DB {
+	dsn := os.Getenv(testDatabaseDsnEnv)
+	if dsn == "" {
+		t.Skip(testDatabaseDsnEnv + " isn't set, the SQL repositories need a Postgres database")
+	}
+
+	return openTestDatabase(t, postgres.Open(dsn), migration.PostgresDialect, dbmigrations.Files)
+}
+
+// openSQLiteTestDatabase migrates a new SQLite database file, removed after the test.
+func openSQLiteTestDatabase(t *testing.T) *gorm.DB {
+	path := filepath.Join(t.TempDir(), "finfit.db")
+	return openTestDatabase(t, sqlite.Open(sql.SQLiteDSN(path)), migration.SQLiteDialect, dbmigrations.SQLiteFiles())
+}
+
+func openTestDatabase(t *testing.T, dialector gorm.Dialector, dialect migration.Dialect, migrations fs.FS) *gorm.DB {
+	db, err := gorm.Open(dialector, &gorm.Config{NamingStrategy: schema.NamingStrategy{SingularTable: true}})
+	require.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	migrator, err := migration.NewMigrator(sqlDB, dialect, migrations)
+	require.NoError(t, err)
+	_, err = migrator.Up()
+	require.NoError(t, err)
+
+	return db
+}
+
+func newRepositories(db *gorm.DB) func() repositorytest.Repositories {
+	return func() repositorytest.Repositories {
+		return repositorytest.Repositories{
+			Users:             user.NewRepository(db, "app_user", "used_refresh_token"),
+			ExpenseTypes:      expensetype.NewRepository(db, "expense_type", "expense"),
+			Expenses:          expense.NewRepository(db, "expense", "expense_split_participant", "expense_type", "tag", "expense_tag"),
+			Budgets:           budget.NewRepository(db, "budget"),
+			RecurringExpenses: recurringexpense.NewRepository(db, "recurring_expense"),
+			Reports:           report.NewRepository(db, "expense", "expense_type"),
+		}
+	}
+}
+
+func TestExpenseTypeRepositoryContract(t *testing.T) {
+	db := openPostgresTestDatabase(t)
+	suite.Run(t, &repositorytest.ExpenseTypeRepositoryContract{NewRepositories: newRepositories(db)})
+}
+
+func TestExpenseRepositoryContract(t *testing.T) {
+	db := openPostgresTestDatabase(t)
+	suite.Run(t, &repositorytest.ExpenseRepositoryContract{NewRepositories: newRepositories(db)})
+}
+
+func TestReportRepositoryContract(t *testing.T) {
+	db := openPostgresTestDatabase(t)
+	suite.Run(t, &repositorytest.ReportRepositoryContract{NewRepositories: newRepositories(db)})
+}
+
+func TestSQLiteExpenseTypeRepositoryContract(t *testing.T) {
+	db := openSQLiteTestDatabase(t)
+	suite.Run(t, &repositorytest.ExpenseTypeRepositoryContract{NewRepositories: newRepositories(db)})
+}
+
+func TestSQLiteExpenseRepositoryContract(t *testing.T) {
+	db := openSQLiteTestDatabase(t)
+	suite.Run(t, &repositorytest.ExpenseRepositoryContract{NewRepositories: newRepositories(db)})
+}
+
+func TestSQLiteReportRepositoryContract(t *testing.T) {
+	db := openSQLiteTestDatabase(t)
+	suite.Run(t, &repositorytest.ReportRepositoryContract{NewRepositories: newRepositories(db)})
+}
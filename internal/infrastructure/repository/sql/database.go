@@ -1,10 +1,45 @@
 package sql
 
-import "gorm.io/gorm"
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
 
 // TODO: Esta interfaz es algo inutil por el momento, los metodos no deberian devolver el DB de gorm, deberian devolver esta interfaz (Solucionar)
 type Database interface {
 	First(out interface{}, where ...interface{}) *gorm.DB
 	Create(value interface{}) *gorm.DB
 	Table(name string, args ...interface{}) (tx *gorm.DB)
+	Transaction(fc func(tx *gorm.DB) error, opts ...*dbsql.TxOptions) error
+	WithContext(ctx context.Context) *gorm.DB
+}
+
+// SQLiteDSN opens the SQLite database file at path enforcing the foreign keys, which SQLite doesn't by default. Writers
+// take the lock when their transaction begins and wait for each other while the database is busy.
+func SQLiteDSN(path string) string {
+	return "file:" + path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_txlock=immediate"
+}
+
+// Date binds the day of t at midnight UTC, the way the dates are written. Postgres compares it as a date, and SQLite,
+// which stores the dates as the text of the times written, compares the same text.
+func Date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// IsForeignKeyViolation tells whether err is the one of Postgres, SQLSTATE 23503, or of SQLite,
+// SQLITE_CONSTRAINT_FOREIGNKEY, for a row that is still referenced by another.
+func IsForeignKeyViolation(err error) bool {
+	var postgresErr interface{ SQLState() string }
+	if errors.As(err, &postgresErr) {
+		return postgresErr.SQLState() == "23503"
+	}
+
+	var sqliteErr interface{ Code() int }
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code() == 787
+	}
+	return false
 }
@@ -0,0 +1,19 @@
+package exchangerate
+
+import (
+	"finfit-backend/internal/domain/models"
+	"time"
+)
+
+type ExchangeRate struct {
+	BaseCurrency  string    `gorm:"primaryKey"`
+	QuoteCurrency string    `gorm:"primaryKey"`
+	RateDate      time.Time `gorm:"primaryKey"`
+	Rate          string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (receiver ExchangeRate) MapToDomainExchangeRate() (*models.ExchangeRate, error) {
+	return models.NewExchangeRate(receiver.BaseCurrency, receiver.QuoteCurrency, receiver.RateDate, receiver.Rate)
+}
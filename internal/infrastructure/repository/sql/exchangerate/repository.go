@@ -0,0 +1,63 @@
+package exchangerate
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type repository struct {
+	table string
+	db    sql.Database
+}
+
+func NewRepository(db sql.Database, table string) *repository {
+	return &repository{db: db, table: table}
+}
+
+// Save inserts the rates replacing the ones already stored for the same currencies and date.
+func (r repository) Save(ctx context.Context, rates []*models.ExchangeRate) error {
+	rateDbModels := []ExchangeRate{}
+	for _, rate := range rates {
+		rateDbModels = append(rateDbModels, ExchangeRate{
+			BaseCurrency:  rate.BaseCurrency(),
+			QuoteCurrency: rate.QuoteCurrency(),
+			RateDate:      rate.Date(),
+			Rate:          rate.Rate(),
+		})
+	}
+
+	result := r.db.WithContext(ctx).Table(r.table).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "base_currency"}, {Name: "quote_currency"}, {Name: "rate_date"}},
+			DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+		}).
+		Create(&rateDbModels)
+
+	return result.Error
+}
+
+// GetRate looks for the rate in both directions. When both are stored for the same day the requested one wins.
+func (r repository) GetRate(ctx context.Context, baseCurrency string, quoteCurrency string, date time.Time) (*models.ExchangeRate, error) {
+	var storedRate ExchangeRate
+	result := r.db.WithContext(ctx).Table(r.table).
+		Where("((base_currency = ? AND quote_currency = ?) OR (base_currency = ? AND quote_currency = ?)) AND rate_date <= ?",
+			baseCurrency, quoteCurrency, quoteCurrency, baseCurrency, sql.Date(date)).
+		Order("rate_date DESC").
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: "base_currency = ? DESC", Vars: []interface{}{baseCurrency}, WithoutParentheses: true}}).
+		Take(&storedRate)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return storedRate.MapToDomainExchangeRate()
+}
@@ -2,6 +2,8 @@ package expense
 
 import (
 	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"finfit-backend/internal/infrastructure/repository/sql/account"
 	"finfit-backend/internal/infrastructure/repository/sql/expensetype"
 	"github.com/google/uuid"
 	"time"
@@ -9,26 +11,95 @@ import (
 
 type Expense struct {
 	ID            string `gorm:"primaryKey"`
+	LedgerID      string
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
-	Amount        float64
+	Amount        sql.Amount
 	Currency      string
 	ExpenseDate   time.Time
 	Description   string
 	ExpenseTypeID string
 	ExpenseType   expensetype.ExpenseType
+	AccountID     *string
+	Account       *account.Account
+	FitID         *string
+	SplitPaidBy   *string
+	SplitMethod   *string
+	// SplitParticipants are read apart, ordered by position, since the split depends on their order.
+	SplitParticipants []ExpenseSplitParticipant `gorm:"-"`
+	// Tags are the names of the tags of the expense, which are linked through the expense tag table.
+	Tags []string `gorm:"-"`
 }
 
-func (receiver Expense) MapToDomainExpense() (*models.Expense, error) {
+type ExpenseSplitParticipant struct {
+	ExpenseID string `gorm:"primaryKey"`
+	UserID    string `gorm:"primaryKey"`
+	Position  int
+	Value     string
+	Amount    sql.Amount
+}
+
+// MapToDomainExpense links the expense type to its ancestors in the hierarchy, which can be nil when it isn't nested.
+func (receiver Expense) MapToDomainExpense(hierarchy *expensetype.Hierarchy) (*models.Expense, error) {
 	id, _ := uuid.Parse(receiver.ID)
-	money, err := models.NewMoney(receiver.Amount, receiver.Currency)
+	money, err := models.NewMoney(string(receiver.Amount), receiver.Currency)
+	if err != nil {
+		return nil, err
+	}
+	expenseType, err := hierarchy.MapToDomainExpenseType(receiver.ExpenseType)
+	if err != nil {
+		return nil, err
+	}
+
+	expense, err := models.NewExpenseWithId(id, money, receiver.ExpenseDate, receiver.Description, expenseType)
+	if err != nil {
+		return nil, err
+	}
+
+	if receiver.FitID != nil {
+		expense = expense.WithFitId(*receiver.FitID)
+	}
+
+	if len(receiver.Tags) > 0 {
+		if expense, err = expense.WithTags(receiver.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	if receiver.SplitPaidBy != nil && receiver.SplitMethod != nil {
+		if expense, err = receiver.mapToSplitExpense(expense); err != nil {
+			return nil, err
+		}
+	}
+
+	if receiver.AccountID == nil || receiver.Account == nil {
+		return expense, nil
+	}
+
+	expenseAccount, err := receiver.Account.MapToDomainAccount()
 	if err != nil {
 		return nil, err
 	}
-	expenseType, err := receiver.ExpenseType.MapToDomainExpenseType()
+
+	return expense.WithAccount(expenseAccount)
+}
+
+func (receiver Expense) mapToSplitExpense(expense *models.Expense) (*models.Expense, error) {
+	paidBy, _ := uuid.Parse(*receiver.SplitPaidBy)
+	participants := []*models.SplitParticipant{}
+	for _, storedParticipant := range receiver.SplitParticipants {
+		userId, _ := uuid.Parse(storedParticipant.UserID)
+		participant, err := models.NewSplitParticipant(userId, storedParticipant.Value)
+		if err != nil {
+			return nil, err
+		}
+		participants = append(participants, participant)
+	}
+
+	split, err := models.NewExpenseSplit(expense.Amount(), paidBy, models.SplitMethod(*receiver.SplitMethod), participants)
 	if err != nil {
 		return nil, err
 	}
 
-	return models.NewExpenseWithId(id, money, receiver.ExpenseDate, receiver.Description, expenseType)
+	return expense.WithSplit(split)
 }
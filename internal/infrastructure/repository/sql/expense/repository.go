@@ -1,41 +1,137 @@
 package expense
 
 import (
+	"context"
 	"errors"
 	"finfit-backend/internal/domain/models"
+	expenseService "finfit-backend/internal/domain/services/expense"
 	"finfit-backend/internal/infrastructure/repository/sql"
+	"finfit-backend/internal/infrastructure/repository/sql/expensetype"
+	"finfit-backend/internal/infrastructure/repository/sql/tag"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"strings"
 	"time"
 )
 
-const dateFormat = "2006-01-02"
+const (
+	dateFormat      = "2006-01-02"
+	importBatchSize = 100
+	exportBatchSize = 500
+)
 
 type repository struct {
-	table string
-	db    sql.Database
+	table            string
+	splitTable       string
+	expenseTypeTable string
+	tagTable         string
+	expenseTagTable  string
+	db               sql.Database
 }
 
-func NewRepository(db sql.Database, table string) *repository {
-	return &repository{db: db, table: table}
+func NewRepository(db sql.Database, table string, splitTable string, expenseTypeTable string, tagTable string, expenseTagTable string) *repository {
+	return &repository{db: db, table: table, splitTable: splitTable, expenseTypeTable: expenseTypeTable, tagTable: tagTable, expenseTagTable: expenseTagTable}
 }
 
-func (r repository) Add(expense *models.Expense) (*models.Expense, error) {
-	expenseDbModel := r.mapExpenseDBModelFromExpense(expense)
-	result := r.db.Table(r.table).Create(&expenseDbModel)
+func (r repository) Add(ctx context.Context, ledgerId uuid.UUID, expense *models.Expense) (*models.Expense, error) {
+	expenseDbModel := r.mapExpenseDBModelFromExpense(ledgerId, expense)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(r.table).Create(&expenseDbModel).Error; err != nil {
+			return err
+		}
+		if err := r.addSplitParticipants(tx, []Expense{expenseDbModel}); err != nil {
+			return err
+		}
+		return r.addTags(tx, ledgerId, []Expense{expenseDbModel})
+	})
 
-	if err := result.Error; err != nil {
+	if err != nil {
 		return nil, err
 	}
 
 	return expense, nil
 }
 
+// AddAll stores the expenses in batches of importBatchSize inside a single transaction, so either all the expenses
+// are stored or none is.
+func (r repository) AddAll(ctx context.Context, ledgerId uuid.UUID, expenses []*models.Expense) error {
+	expenseDbModels := []Expense{}
+	for _, expenseToAdd := range expenses {
+		expenseDbModels = append(expenseDbModels, r.mapExpenseDBModelFromExpense(ledgerId, expenseToAdd))
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(r.table).CreateInBatches(&expenseDbModels, importBatchSize).Error; err != nil {
+			return err
+		}
+		if err := r.addSplitParticipants(tx, expenseDbModels); err != nil {
+			return err
+		}
+		return r.addTags(tx, ledgerId, expenseDbModels)
+	})
+}
+
+func (r repository) GetByFitIds(ctx context.Context, ledgerId uuid.UUID, fitIds []string) ([]*models.Expense, error) {
+	storedExpenses := []Expense{}
+	result := r.db.WithContext(ctx).Table(r.table).
+		Joins("ExpenseType").
+		Joins("Account").
+		Find(&storedExpenses, r.table+".ledger_id = ? AND "+r.table+".fit_id IN ?", ledgerId.String(), fitIds)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.loadSplitParticipants(ctx, storedExpenses); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadTags(ctx, storedExpenses); err != nil {
+		return nil, err
+	}
+
+	return r.mapToDomainExpenses(ctx, ledgerId, storedExpenses)
+}
+
 // TODO: no me gusta que el nombre de las tablas este atado a como lo resuelve GORM
-func (r repository) SearchInPeriod(startDate time.Time, endDate time.Time) ([]*models.Expense, error) {
+func (r repository) SearchInPeriod(ctx context.Context, ledgerId uuid.UUID, criteria expenseService.SearchCriteria) ([]*models.Expense, error) {
 	storedExpenses := []Expense{}
-	result := r.db.Table(r.table).
+	query := r.db.WithContext(ctx).Table(r.table).
 		Joins("ExpenseType").
-		Find(&storedExpenses, "expense_date >= ?  AND expense_date <= ?", startDate.Format(dateFormat), endDate.Format(dateFormat))
+		Joins("Account").
+		Where(r.table+".ledger_id = ? AND "+r.table+".expense_date >= ? AND "+r.table+".expense_date <= ?", ledgerId.String(), sql.Date(criteria.StartDate), sql.Date(criteria.EndDate))
+
+	if len(criteria.ExpenseTypeIds) > 0 {
+		query = query.Where(r.table+".expense_type_id IN ?", mapIdsToStrings(criteria.ExpenseTypeIds))
+	}
+
+	if criteria.TagFilter != nil {
+		query = query.Where(r.table+".id IN (?)", tag.TaggedExpenseIds(r.db.WithContext(ctx), r.tagTable, r.expenseTagTable, ledgerId, criteria.TagFilter))
+	}
+
+	if criteria.MinAmount != "" {
+		query = query.Where(r.table+".amount >= ?", sql.Amount(criteria.MinAmount))
+	}
+
+	if criteria.MaxAmount != "" {
+		query = query.Where(r.table+".amount <= ?", sql.Amount(criteria.MaxAmount))
+	}
+
+	if criteria.Currency != "" {
+		query = query.Where(r.table+".currency = ?", criteria.Currency)
+	}
+
+	if criteria.Description != "" {
+		query = query.Where("LOWER("+r.table+".description) LIKE ? ESCAPE '\\'", "%"+escapeLike(strings.ToLower(criteria.Description))+"%")
+	}
+
+	if criteria.AccountId != uuid.Nil {
+		query = query.Where(r.table+".account_id = ?", criteria.AccountId.String())
+	}
+
+	query = r.sortAndPage(query, criteria)
+
+	result := query.Find(&storedExpenses)
 
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
@@ -45,25 +141,423 @@ func (r repository) SearchInPeriod(startDate time.Time, endDate time.Time) ([]*m
 		return nil, err
 	}
 
-	expenses := []*models.Expense{}
-	for _, expense := range storedExpenses {
-		domainExpense, err := expense.MapToDomainExpense()
+	if err := r.loadSplitParticipants(ctx, storedExpenses); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadTags(ctx, storedExpenses); err != nil {
+		return nil, err
+	}
+
+	return r.mapToDomainExpenses(ctx, ledgerId, storedExpenses)
+}
+
+// sortAndPage orders the expenses by the sort field and then by id, and keeps the ones after the cursor. Comparing
+// both columns as a row lets the keyset work with repeated dates or amounts.
+func (r repository) sortAndPage(query *gorm.DB, criteria expenseService.SearchCriteria) *gorm.DB {
+	sort := criteria.Sort
+	if sort == "" {
+		sort = expenseService.ExpenseDateDescSort
+	}
+
+	column := r.table + "." + sort.Field()
+	direction, comparison := "ASC", ">"
+	if sort.Descending() {
+		direction, comparison = "DESC", "<"
+	}
+
+	if criteria.After != nil {
+		query = query.Where("("+column+", "+r.table+".id) "+comparison+" (?, ?)", cursorValue(sort, criteria.After), criteria.After.Id().String())
+	}
+
+	query = query.Order(column + " " + direction + ", " + r.table + ".id " + direction)
+	if criteria.Limit > 0 {
+		query = query.Limit(criteria.Limit)
+	}
+	return query
+}
+
+// cursorValue binds the dates of the cursor as times and its amounts as amounts, so they compare like the stored ones
+// in every database: SQLite keeps the dates as the text of the times written and the amounts as integers.
+func cursorValue(sort expenseService.SearchSort, cursor *expenseService.Cursor) interface{} {
+	if sort.Field() == string(expenseService.AmountAscSort) {
+		return sql.Amount(cursor.Value())
+	}
+
+	date, err := time.Parse(dateFormat, cursor.Value())
+	if err != nil {
+		return cursor.Value()
+	}
+	return date
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern, so they match literally.
+func escapeLike(text string) string {
+	return strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(text)
+}
+
+// ForEachInPeriod reads the expenses of the period in batches of exportBatchSize, ordered by date and id. Every batch
+// starts after the last expense of the previous one, so only a batch is in memory at a time.
+func (r repository) ForEachInPeriod(ctx context.Context, ledgerId uuid.UUID, startDate time.Time, endDate time.Time, consume func(expense *models.Expense) error) error {
+	var lastExpense *Expense
+	for {
+		query := r.db.WithContext(ctx).Table(r.table).
+			Joins("ExpenseType").
+			Joins("Account").
+			Where(r.table+".ledger_id = ? AND "+r.table+".expense_date >= ? AND "+r.table+".expense_date <= ?", ledgerId.String(), sql.Date(startDate), sql.Date(endDate))
+
+		if lastExpense != nil {
+			query = query.Where("("+r.table+".expense_date, "+r.table+".id) > (?, ?)", sql.Date(lastExpense.ExpenseDate), lastExpense.ID)
+		}
+
+		storedExpenses := []Expense{}
+		result := query.Order(r.table + ".expense_date, " + r.table + ".id").Limit(exportBatchSize).Find(&storedExpenses)
+		if err := result.Error; err != nil {
+			return err
+		}
+
+		if err := r.loadSplitParticipants(ctx, storedExpenses); err != nil {
+			return err
+		}
+
+		if err := r.loadTags(ctx, storedExpenses); err != nil {
+			return err
+		}
+
+		expenses, err := r.mapToDomainExpenses(ctx, ledgerId, storedExpenses)
 		if err != nil {
-			return nil, err
+			return err
+		}
+
+		for _, expense := range expenses {
+			if err = consume(expense); err != nil {
+				return err
+			}
 		}
-		expenses = append(expenses, domainExpense)
+
+		if len(storedExpenses) < exportBatchSize {
+			return nil
+		}
+		lastExpense = &storedExpenses[len(storedExpenses)-1]
 	}
+}
 
-	return expenses, nil
+// repositoryWithoutFullTextSearch hides SearchText, so the service scans the expenses of the ledger instead.
+type repositoryWithoutFullTextSearch struct {
+	expenseService.Repository
+}
+
+// WithoutFullTextSearch is the repository for the databases without the full-text search of Postgres, like SQLite.
+func WithoutFullTextSearch(repository expenseService.Repository) expenseService.Repository {
+	return repositoryWithoutFullTextSearch{Repository: repository}
+}
+
+type textSearchRow struct {
+	ID      string
+	Rank    float64
+	Snippet string
+}
+
+// SearchText matches the terms as prefixes against the full-text indexes of the descriptions of the expenses and of the
+// names of the expense types.
+func (r repository) SearchText(ctx context.Context, ledgerId uuid.UUID, terms []string, limit int) ([]*expenseService.TextSearchHit, error) {
+	prefixes := []string{}
+	for _, term := range terms {
+		prefixes = append(prefixes, term+":*")
+	}
+	tsQuery := strings.Join(prefixes, " | ")
+
+	descriptionVector := "to_tsvector('simple', coalesce(" + r.table + ".description, ''))"
+	expenseTypeVector := "to_tsvector('simple', " + r.expenseTypeTable + ".name)"
+	rows := []textSearchRow{}
+	result := r.db.WithContext(ctx).Table(r.table).
+		Select(r.table+".id AS id, "+
+			"ts_rank(setweight("+descriptionVector+", 'A') || setweight("+expenseTypeVector+", 'B'), query) AS rank, "+
+			"ts_headline('simple', coalesce(nullif("+r.table+".description, ''), "+r.expenseTypeTable+".name), query, ?) AS snippet",
+			"StartSel="+expenseService.SnippetStartSel+", StopSel="+expenseService.SnippetStopSel+", HighlightAll=true").
+		Joins("JOIN "+r.expenseTypeTable+" ON "+r.expenseTypeTable+".id = "+r.table+".expense_type_id").
+		Joins("CROSS JOIN to_tsquery('simple', ?) AS query", tsQuery).
+		Where(r.table+".ledger_id = ?", ledgerId.String()).
+		Where(descriptionVector + " @@ query OR " + r.table + ".expense_type_id IN (SELECT id FROM " + r.expenseTypeTable + " WHERE to_tsvector('simple', name) @@ query)").
+		Order("rank DESC, " + r.table + ".expense_date DESC, " + r.table + ".id").
+		Limit(limit).
+		Scan(&rows)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return []*expenseService.TextSearchHit{}, nil
+	}
+
+	ids := []string{}
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	storedExpenses := []Expense{}
+	result = r.db.WithContext(ctx).Table(r.table).
+		Joins("ExpenseType").
+		Joins("Account").
+		Find(&storedExpenses, r.table+".id IN ? AND "+r.table+".ledger_id = ?", ids, ledgerId.String())
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.loadSplitParticipants(ctx, storedExpenses); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadTags(ctx, storedExpenses); err != nil {
+		return nil, err
+	}
+
+	expenses, err := r.mapToDomainExpenses(ctx, ledgerId, storedExpenses)
+	if err != nil {
+		return nil, err
+	}
+
+	expensesById := map[string]*models.Expense{}
+	for _, expense := range expenses {
+		expensesById[expense.Id().String()] = expense
+	}
+
+	hits := []*expenseService.TextSearchHit{}
+	for _, row := range rows {
+		if expense, ok := expensesById[row.ID]; ok {
+			hits = append(hits, &expenseService.TextSearchHit{Expense: expense, Rank: row.Rank, Snippet: row.Snippet})
+		}
+	}
+
+	return hits, nil
+}
+
+func (r repository) GetByID(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (*models.Expense, error) {
+	var storedExpense Expense
+	result := r.db.WithContext(ctx).Table(r.table).
+		Joins("ExpenseType").
+		Joins("Account").
+		First(&storedExpense, r.table+".id = ? AND "+r.table+".ledger_id = ?", id.String(), ledgerId.String())
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	storedExpenses := []Expense{storedExpense}
+	if err := r.loadSplitParticipants(ctx, storedExpenses); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadTags(ctx, storedExpenses); err != nil {
+		return nil, err
+	}
+
+	expenses, err := r.mapToDomainExpenses(ctx, ledgerId, storedExpenses)
+	if err != nil {
+		return nil, err
+	}
+
+	return expenses[0], nil
 }
 
-func (r repository) mapExpenseDBModelFromExpense(expenseToAdd *models.Expense) Expense {
-	return Expense{
+// Update replaces the split and the tags of the expense along with the rest of its fields, since the allocations follow
+// the amount.
+func (r repository) Update(ctx context.Context, ledgerId uuid.UUID, expense *models.Expense) (*models.Expense, error) {
+	expenseDbModel := r.mapExpenseDBModelFromExpense(ledgerId, expense)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Table(r.table).
+			Where("id = ? AND ledger_id = ?", expenseDbModel.ID, expenseDbModel.LedgerID).
+			Select("amount", "currency", "expense_date", "description", "expense_type_id", "account_id", "split_paid_by", "split_method", "updated_at").
+			Updates(&expenseDbModel)
+		if err := result.Error; err != nil {
+			return err
+		}
+
+		if err := tx.Table(r.splitTable).Delete(&ExpenseSplitParticipant{}, "expense_id = ?", expenseDbModel.ID).Error; err != nil {
+			return err
+		}
+		if err := r.addSplitParticipants(tx, []Expense{expenseDbModel}); err != nil {
+			return err
+		}
+
+		if err := tx.Table(r.expenseTagTable).Delete(&tag.ExpenseTag{}, "expense_id = ?", expenseDbModel.ID).Error; err != nil {
+			return err
+		}
+		return r.addTags(tx, ledgerId, []Expense{expenseDbModel})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return expense, nil
+}
+
+func (r repository) Delete(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Table(r.table).Delete(&Expense{}, "id = ? AND ledger_id = ?", id.String(), ledgerId.String())
+	return result.Error
+}
+
+func (r repository) mapExpenseDBModelFromExpense(ledgerId uuid.UUID, expenseToAdd *models.Expense) Expense {
+	var accountId *string
+	if expenseToAdd.Account() != nil {
+		storedAccountId := expenseToAdd.Account().Id().String()
+		accountId = &storedAccountId
+	}
+
+	var fitId *string
+	if expenseToAdd.FitId() != "" {
+		storedFitId := expenseToAdd.FitId()
+		fitId = &storedFitId
+	}
+
+	expenseDbModel := Expense{
 		ID:            expenseToAdd.Id().String(),
-		Amount:        expenseToAdd.Amount().Amount(),
+		LedgerID:      ledgerId.String(),
+		Amount:        sql.Amount(expenseToAdd.Amount().Amount()),
 		Currency:      expenseToAdd.Amount().Currency(),
 		ExpenseDate:   expenseToAdd.ExpenseDate(),
 		Description:   expenseToAdd.Description(),
 		ExpenseTypeID: expenseToAdd.ExpenseType().Id().String(),
+		AccountID:     accountId,
+		FitID:         fitId,
+		Tags:          expenseToAdd.Tags(),
+	}
+
+	if split := expenseToAdd.Split(); split != nil {
+		paidBy := split.PaidBy().String()
+		method := string(split.Method())
+		expenseDbModel.SplitPaidBy = &paidBy
+		expenseDbModel.SplitMethod = &method
+
+		allocations := split.Allocations()
+		for position, participant := range split.Participants() {
+			expenseDbModel.SplitParticipants = append(expenseDbModel.SplitParticipants, ExpenseSplitParticipant{
+				ExpenseID: expenseDbModel.ID,
+				UserID:    participant.UserId().String(),
+				Position:  position,
+				Value:     participant.Value(),
+				Amount:    sql.Amount(allocations[position].Amount().Amount()),
+			})
+		}
+	}
+
+	return expenseDbModel
+}
+
+func (r repository) addSplitParticipants(tx *gorm.DB, expenses []Expense) error {
+	participants := []ExpenseSplitParticipant{}
+	for _, expense := range expenses {
+		participants = append(participants, expense.SplitParticipants...)
+	}
+
+	if len(participants) == 0 {
+		return nil
+	}
+
+	return tx.Table(r.splitTable).CreateInBatches(&participants, importBatchSize).Error
+}
+
+func (r repository) addTags(tx *gorm.DB, ledgerId uuid.UUID, expenses []Expense) error {
+	tagsByExpense := map[string][]string{}
+	for _, expense := range expenses {
+		if len(expense.Tags) > 0 {
+			tagsByExpense[expense.ID] = expense.Tags
+		}
+	}
+
+	return tag.AddExpenseTags(tx, r.tagTable, r.expenseTagTable, ledgerId, tagsByExpense)
+}
+
+// loadTags reads the tags of the expenses with a single query.
+func (r repository) loadTags(ctx context.Context, expenses []Expense) error {
+	expenseIds := []string{}
+	for _, expense := range expenses {
+		expenseIds = append(expenseIds, expense.ID)
+	}
+
+	tagsByExpense, err := tag.LoadExpenseTags(r.db.WithContext(ctx), r.tagTable, r.expenseTagTable, expenseIds)
+	if err != nil {
+		return err
+	}
+
+	for i := range expenses {
+		expenses[i].Tags = tagsByExpense[expenses[i].ID]
+	}
+	return nil
+}
+
+// loadSplitParticipants reads the participants of the split expenses with a single query.
+func (r repository) loadSplitParticipants(ctx context.Context, expenses []Expense) error {
+	splitExpenseIds := []string{}
+	for _, expense := range expenses {
+		if expense.SplitMethod != nil {
+			splitExpenseIds = append(splitExpenseIds, expense.ID)
+		}
+	}
+
+	if len(splitExpenseIds) == 0 {
+		return nil
+	}
+
+	participants := []ExpenseSplitParticipant{}
+	result := r.db.WithContext(ctx).Table(r.splitTable).
+		Where("expense_id IN ?", splitExpenseIds).
+		Order("expense_id, position").
+		Find(&participants)
+	if err := result.Error; err != nil {
+		return err
+	}
+
+	participantsByExpense := map[string][]ExpenseSplitParticipant{}
+	for _, participant := range participants {
+		participantsByExpense[participant.ExpenseID] = append(participantsByExpense[participant.ExpenseID], participant)
+	}
+
+	for i := range expenses {
+		expenses[i].SplitParticipants = participantsByExpense[expenses[i].ID]
+	}
+	return nil
+}
+
+// mapToDomainExpenses reads the hierarchy of expense types of the ledger only when any of the expenses has a nested
+// expense type.
+func (r repository) mapToDomainExpenses(ctx context.Context, ledgerId uuid.UUID, storedExpenses []Expense) ([]*models.Expense, error) {
+	var hierarchy *expensetype.Hierarchy
+	storedExpenseTypes := []expensetype.ExpenseType{}
+	for _, storedExpense := range storedExpenses {
+		storedExpenseTypes = append(storedExpenseTypes, storedExpense.ExpenseType)
+	}
+
+	if expensetype.NeedsHierarchy(storedExpenseTypes...) {
+		var err error
+		if hierarchy, err = expensetype.LoadHierarchy(r.db.WithContext(ctx), r.expenseTypeTable, ledgerId); err != nil {
+			return nil, err
+		}
+	}
+
+	expenses := []*models.Expense{}
+	for _, storedExpense := range storedExpenses {
+		expense, err := storedExpense.MapToDomainExpense(hierarchy)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, expense)
+	}
+
+	return expenses, nil
+}
+
+func mapIdsToStrings(ids []uuid.UUID) []string {
+	idStrings := []string{}
+	for _, id := range ids {
+		idStrings = append(idStrings, id.String())
 	}
+	return idStrings
 }
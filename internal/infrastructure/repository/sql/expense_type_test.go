@@ -0,0 +1,87 @@
+package sql_test
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	expenseTypeService "finfit-backend/internal/domain/services/expensetype"
+	"finfit-backend/internal/domain/services/ledger"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"finfit-backend/internal/infrastructure/repository/sql/expensetype"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"testing"
+)
+
+// failingExpenseTypeRepository fails to delete the expense types, after their expenses have been reassigned.
+type failingExpenseTypeRepository struct {
+	expenseTypeService.Repository
+}
+
+func (failingExpenseTypeRepository) Delete(context.Context, uuid.UUID, uuid.UUID) error {
+	return errors.New("disk I/O error")
+}
+
+func TestGivenThatFailToDeleteAnExpenseType_WhenDeleteReassigningItsExpenses_ThenTheReassignmentIsRolledBack(t *testing.T) {
+	db := openSQLiteTestDatabase(t)
+	storedUser, _ := addExpenses(t, db, "10.10")
+	ledgerId := models.PersonalLedgerId(storedUser.Id())
+	food, err := newExpenseTypeRepository(db).GetByName(context.Background(), ledgerId, uuid.Nil, "Food")
+	require.NoError(t, err)
+	groceries := addExpenseType(t, newExpenseTypeRepository(db), ledgerId, "Groceries")
+	ledgerServiceMock := ledger.NewServiceMock()
+	ledgerServiceMock.MockAuthorize([]interface{}{storedUser.Id(), ledgerId, mock.Anything}, []interface{}{nil}, 1)
+	unitOfWork := sql.NewUnitOfWork(db, func(tx *gorm.DB) expenseTypeService.Repository {
+		return failingExpenseTypeRepository{Repository: newExpenseTypeRepository(tx)}
+	})
+	command, err := expenseTypeService.NewDeleteCommand(food.Id(), groceries.Id())
+	require.NoError(t, err)
+
+	err = expenseTypeService.NewService(newExpenseTypeRepository(db), unitOfWork, ledgerServiceMock).Delete(context.Background(), storedUser.Id(), ledgerId, command)
+
+	require.Error(t, err)
+	var foodExpensesCount int64
+	require.NoError(t, db.Table("expense").Where("expense_type_id = ?", food.Id().String()).Count(&foodExpensesCount).Error)
+	assert.Equal(t, int64(1), foodExpensesCount)
+}
+
+func TestGivenTwoExpenseTypes_WhenEachIsMovedUnderTheOther_ThenTheSecondMoveIsRejected(t *testing.T) {
+	db := openSQLiteTestDatabase(t)
+	userId := pkg.NewUUID()
+	ledgerId := models.PersonalLedgerId(userId)
+	food := addExpenseType(t, newExpenseTypeRepository(db), ledgerId, "Food")
+	restaurants := addExpenseType(t, newExpenseTypeRepository(db), ledgerId, "Restaurants")
+	ledgerServiceMock := ledger.NewServiceMock()
+	ledgerServiceMock.MockAuthorize([]interface{}{userId, ledgerId, mock.Anything}, []interface{}{nil}, 2)
+	service := expenseTypeService.NewService(newExpenseTypeRepository(db), sql.NewUnitOfWork(db, newExpenseTypeRepository), ledgerServiceMock)
+	moveRestaurants, err := expenseTypeService.NewUpdateCommand(restaurants.Id(), "Restaurants")
+	require.NoError(t, err)
+	moveFood, err := expenseTypeService.NewUpdateCommand(food.Id(), "Food")
+	require.NoError(t, err)
+
+	_, err = service.Update(context.Background(), userId, ledgerId, moveRestaurants.WithParent(food.Id()))
+	require.NoError(t, err)
+	_, err = service.Update(context.Background(), userId, ledgerId, moveFood.WithParent(restaurants.Id()))
+
+	assert.ErrorAs(t, err, &expenseTypeService.InvalidParentExpenseTypeError{})
+	expenseTypes, err := newExpenseTypeRepository(db).GetAll(context.Background(), ledgerId)
+	require.NoError(t, err)
+	assert.Len(t, expenseTypes, 2)
+}
+
+func TestGivenExpenseTypesStoredUnderTheirOwnSubtypes_WhenGetAll_ThenReturnTheCycle(t *testing.T) {
+	db := openSQLiteTestDatabase(t)
+	ledgerId := pkg.NewUUID()
+	food := addExpenseType(t, newExpenseTypeRepository(db), ledgerId, "Food")
+	restaurants := addExpenseType(t, newExpenseTypeRepository(db), ledgerId, "Restaurants")
+	require.NoError(t, db.Table("expense_type").Where("id = ?", restaurants.Id().String()).Update("parent_id", food.Id().String()).Error)
+	require.NoError(t, db.Table("expense_type").Where("id = ?", food.Id().String()).Update("parent_id", restaurants.Id().String()).Error)
+
+	_, err := newExpenseTypeRepository(db).GetAll(context.Background(), ledgerId)
+
+	assert.ErrorIs(t, err, expensetype.ErrCyclicHierarchy)
+}
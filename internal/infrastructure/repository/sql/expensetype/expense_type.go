@@ -1,19 +1,111 @@
 package expensetype
 
 import (
+	"errors"
 	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"fmt"
 	"github.com/google/uuid"
 	"time"
 )
 
+var (
+	errMissingParent = errors.New("invalid expense type hierarchy, a parent is missing")
+	// ErrCyclicHierarchy is returned when the stored expense types are nested under their own subtypes, which the
+	// service never writes.
+	ErrCyclicHierarchy = errors.New("invalid expense type hierarchy, an expense type is nested under its own subtypes")
+)
+
 type ExpenseType struct {
 	ID        string    `gorm:"primaryKey,column:id"`
+	LedgerID  string    `gorm:"column:ledger_id"`
+	ParentID  *string   `gorm:"column:parent_id"`
 	Name      string    `gorm:"column:name"`
 	CreatedAt time.Time `gorm:"column:created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
 }
 
+// MapToDomainExpenseType maps the expense type alone, without its ancestors. Use a Hierarchy for nested ones.
 func (receiver ExpenseType) MapToDomainExpenseType() (*models.ExpenseType, error) {
 	id, _ := uuid.Parse(receiver.ID)
 	return models.NewExpenseTypeWithId(id, receiver.Name)
 }
+
+// Hierarchy holds every expense type of a ledger to link the nested ones to their ancestors.
+type Hierarchy struct {
+	storedExpenseTypes map[string]ExpenseType
+	expenseTypes       map[string]*models.ExpenseType
+}
+
+// LoadHierarchy reads all the expense types of the ledger with a single query.
+func LoadHierarchy(db sql.Database, table string, ledgerId uuid.UUID) (*Hierarchy, error) {
+	storedExpenseTypes := []ExpenseType{}
+	result := db.Table(table).Where("ledger_id = ?", ledgerId.String()).Find(&storedExpenseTypes)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return newHierarchy(storedExpenseTypes), nil
+}
+
+func newHierarchy(storedExpenseTypes []ExpenseType) *Hierarchy {
+	hierarchy := &Hierarchy{storedExpenseTypes: map[string]ExpenseType{}, expenseTypes: map[string]*models.ExpenseType{}}
+	for _, storedExpenseType := range storedExpenseTypes {
+		hierarchy.storedExpenseTypes[storedExpenseType.ID] = storedExpenseType
+	}
+	return hierarchy
+}
+
+// MapToDomainExpenseType maps the expense type linked to its ancestors. A nil Hierarchy maps it alone.
+func (h *Hierarchy) MapToDomainExpenseType(storedExpenseType ExpenseType) (*models.ExpenseType, error) {
+	if h == nil || storedExpenseType.ParentID == nil {
+		return storedExpenseType.MapToDomainExpenseType()
+	}
+	return h.mapWithAncestors(storedExpenseType.ID, map[string]bool{})
+}
+
+func (h *Hierarchy) mapWithAncestors(id string, visited map[string]bool) (*models.ExpenseType, error) {
+	if expenseType, ok := h.expenseTypes[id]; ok {
+		return expenseType, nil
+	}
+
+	if visited[id] {
+		return nil, fmt.Errorf("%w: %s", ErrCyclicHierarchy, id)
+	}
+	visited[id] = true
+
+	storedExpenseType, ok := h.storedExpenseTypes[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errMissingParent, id)
+	}
+
+	expenseType, err := storedExpenseType.MapToDomainExpenseType()
+	if err != nil {
+		return nil, err
+	}
+
+	if storedExpenseType.ParentID != nil {
+		parent, err := h.mapWithAncestors(*storedExpenseType.ParentID, visited)
+		if err != nil {
+			return nil, err
+		}
+
+		if expenseType, err = expenseType.WithParent(parent); err != nil {
+			return nil, err
+		}
+	}
+
+	h.expenseTypes[id] = expenseType
+	return expenseType, nil
+}
+
+// NeedsHierarchy tells whether any of the expense types is nested, so their ancestors have to be read.
+func NeedsHierarchy(storedExpenseTypes ...ExpenseType) bool {
+	for _, storedExpenseType := range storedExpenseTypes {
+		if storedExpenseType.ParentID != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -1,25 +1,32 @@
 package expensetype
 
 import (
+	"context"
 	"errors"
 	"finfit-backend/internal/domain/models"
+	expenseTypeService "finfit-backend/internal/domain/services/expensetype"
 	"finfit-backend/internal/infrastructure/repository/sql"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sort"
+	"strings"
+	"time"
 )
 
 type repository struct {
-	table string
-	db    sql.Database
+	table        string
+	expenseTable string
+	db           sql.Database
 }
 
-func NewRepository(db sql.Database, table string) *repository {
-	return &repository{db: db, table: table}
+func NewRepository(db sql.Database, table string, expenseTable string) *repository {
+	return &repository{db: db, table: table, expenseTable: expenseTable}
 }
 
-func (r repository) GetByID(id uuid.UUID) (*models.ExpenseType, error) {
+func (r repository) GetByID(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (*models.ExpenseType, error) {
 	var storedExpenseType ExpenseType
-	result := r.db.Table(r.table).First(&storedExpenseType, "id = ?", id.String())
+	result := r.db.WithContext(ctx).Table(r.table).First(&storedExpenseType, "id = ? AND ledger_id = ?", id.String(), ledgerId.String())
 
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
@@ -29,13 +36,21 @@ func (r repository) GetByID(id uuid.UUID) (*models.ExpenseType, error) {
 		return nil, err
 	}
 
-	return storedExpenseType.MapToDomainExpenseType()
+	return r.mapWithAncestors(ctx, ledgerId, storedExpenseType)
 }
 
-func (r repository) GetByName(name string) (*models.ExpenseType, error) {
+// GetByName looks for the expense type among the children of parentId, or at the top level when it is uuid.Nil, since
+// names are only unique among siblings.
+func (r repository) GetByName(ctx context.Context, ledgerId uuid.UUID, parentId uuid.UUID, name string) (*models.ExpenseType, error) {
+	query := r.db.WithContext(ctx).Table(r.table).Where("name = ? AND ledger_id = ?", name, ledgerId.String())
+	if parentId == uuid.Nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", parentId.String())
+	}
+
 	var storedExpenseType ExpenseType
-	result := r.db.Table(r.table).First(&storedExpenseType, "name = ?", name)
-	result.Row()
+	result := query.First(&storedExpenseType)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -44,12 +59,51 @@ func (r repository) GetByName(name string) (*models.ExpenseType, error) {
 		return nil, err
 	}
 
-	return storedExpenseType.MapToDomainExpenseType()
+	return r.mapWithAncestors(ctx, ledgerId, storedExpenseType)
+}
+
+func (r repository) Add(ctx context.Context, ledgerId uuid.UUID, expenseType *models.ExpenseType) (*models.ExpenseType, error) {
+	expenseDbModel := r.mapExpenseTypeDBModelFromExpenseType(ledgerId, expenseType)
+	result := r.db.WithContext(ctx).Table(r.table).Create(&expenseDbModel)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return expenseType, nil
+}
+
+// GetAll returns the expense types ordered by their full path, so every one comes right before its subtypes.
+func (r repository) GetAll(ctx context.Context, ledgerId uuid.UUID) ([]*models.ExpenseType, error) {
+	storedExpenseTypes := []ExpenseType{}
+	result := r.db.WithContext(ctx).Table(r.table).Where("ledger_id = ?", ledgerId.String()).Find(&storedExpenseTypes)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	hierarchy := newHierarchy(storedExpenseTypes)
+	expenseTypes := []*models.ExpenseType{}
+	for _, storedExpenseType := range storedExpenseTypes {
+		expenseType, err := hierarchy.MapToDomainExpenseType(storedExpenseType)
+		if err != nil {
+			return nil, err
+		}
+		expenseTypes = append(expenseTypes, expenseType)
+	}
+
+	sort.Slice(expenseTypes, func(i, j int) bool {
+		return comparePaths(expenseTypes[i].Path(), expenseTypes[j].Path()) < 0
+	})
+	return expenseTypes, nil
 }
 
-func (r repository) Add(expenseType *models.ExpenseType) (*models.ExpenseType, error) {
-	expenseDbModel := r.mapExpenseTypeDBModelFromExpenseType(expenseType)
-	result := r.db.Table(r.table).Create(&expenseDbModel)
+func (r repository) Update(ctx context.Context, ledgerId uuid.UUID, expenseType *models.ExpenseType) (*models.ExpenseType, error) {
+	expenseTypeDbModel := r.mapExpenseTypeDBModelFromExpenseType(ledgerId, expenseType)
+	result := r.db.WithContext(ctx).Table(r.table).
+		Where("id = ? AND ledger_id = ?", expenseTypeDbModel.ID, expenseTypeDbModel.LedgerID).
+		Select("name", "parent_id", "updated_at").
+		Updates(&expenseTypeDbModel)
 
 	if err := result.Error; err != nil {
 		return nil, err
@@ -58,14 +112,88 @@ func (r repository) Add(expenseType *models.ExpenseType) (*models.ExpenseType, e
 	return expenseType, nil
 }
 
-func (r repository) GetAll() ([]*models.ExpenseType, error) {
-	//TODO implement me
-	panic("implement me")
+func (r repository) Delete(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Table(r.table).Delete(&ExpenseType{}, "id = ? AND ledger_id = ?", id.String(), ledgerId.String())
+	if sql.IsForeignKeyViolation(result.Error) {
+		return expenseTypeService.ErrExpenseTypeReferenced
+	}
+	return result.Error
+}
+
+func (r repository) HasSubtypes(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (bool, error) {
+	var subtypesCount int64
+	result := r.db.WithContext(ctx).Table(r.table).Where("parent_id = ? AND ledger_id = ?", id.String(), ledgerId.String()).Count(&subtypesCount)
+
+	if err := result.Error; err != nil {
+		return false, err
+	}
+
+	return subtypesCount > 0, nil
+}
+
+func (r repository) IsReferencedByExpenses(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (bool, error) {
+	var referencesCount int64
+	result := r.db.WithContext(ctx).Table(r.expenseTable).Where("expense_type_id = ? AND ledger_id = ?", id.String(), ledgerId.String()).Count(&referencesCount)
+
+	if err := result.Error; err != nil {
+		return false, err
+	}
+
+	return referencesCount > 0, nil
+}
+
+func (r repository) ReassignExpenses(ctx context.Context, ledgerId uuid.UUID, fromId uuid.UUID, toId uuid.UUID) error {
+	result := r.db.WithContext(ctx).Table(r.expenseTable).
+		Where("expense_type_id = ? AND ledger_id = ?", fromId.String(), ledgerId.String()).
+		Updates(map[string]interface{}{"expense_type_id": toId.String(), "updated_at": time.Now()})
+	return result.Error
+}
+
+// LockAll locks the rows of the expense types of the ledger until the end of the transaction. SQLite has no row locks,
+// but its writers already lock the whole database when their transaction begins.
+func (r repository) LockAll(ctx context.Context, ledgerId uuid.UUID) error {
+	result := r.db.WithContext(ctx).Table(r.table).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("ledger_id = ?", ledgerId.String()).
+		Find(&[]ExpenseType{})
+	return result.Error
 }
 
-func (r repository) mapExpenseTypeDBModelFromExpenseType(expenseType *models.ExpenseType) ExpenseType {
+func (r repository) mapExpenseTypeDBModelFromExpenseType(ledgerId uuid.UUID, expenseType *models.ExpenseType) ExpenseType {
+	var parentId *string
+	if expenseType.Parent() != nil {
+		storedParentId := expenseType.ParentId().String()
+		parentId = &storedParentId
+	}
+
 	return ExpenseType{
-		ID:   expenseType.Id().String(),
-		Name: expenseType.Name(),
+		ID:       expenseType.Id().String(),
+		LedgerID: ledgerId.String(),
+		ParentID: parentId,
+		Name:     expenseType.Name(),
+	}
+}
+
+// mapWithAncestors only reads the rest of the expense types of the ledger when the stored one is nested.
+func (r repository) mapWithAncestors(ctx context.Context, ledgerId uuid.UUID, storedExpenseType ExpenseType) (*models.ExpenseType, error) {
+	if !NeedsHierarchy(storedExpenseType) {
+		return storedExpenseType.MapToDomainExpenseType()
+	}
+
+	hierarchy, err := LoadHierarchy(r.db.WithContext(ctx), r.table, ledgerId)
+	if err != nil {
+		return nil, err
+	}
+
+	return hierarchy.MapToDomainExpenseType(storedExpenseType)
+}
+
+// comparePaths orders paths name by name, a path goes before the longer ones that start with it.
+func comparePaths(path []string, otherPath []string) int {
+	for i := 0; i < len(path) && i < len(otherPath); i++ {
+		if path[i] != otherPath[i] {
+			return strings.Compare(path[i], otherPath[i])
+		}
 	}
+	return len(path) - len(otherPath)
 }
@@ -0,0 +1,36 @@
+package income
+
+import (
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"finfit-backend/internal/infrastructure/repository/sql/incomesource"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Income struct {
+	ID             string `gorm:"primaryKey"`
+	UserID         string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Amount         sql.Amount
+	Currency       string
+	IncomeDate     time.Time
+	Description    string
+	IncomeSourceID string
+	IncomeSource   incomesource.IncomeSource
+}
+
+func (receiver Income) MapToDomainIncome() (*models.Income, error) {
+	id, _ := uuid.Parse(receiver.ID)
+	money, err := models.NewMoney(string(receiver.Amount), receiver.Currency)
+	if err != nil {
+		return nil, err
+	}
+	incomeSource, err := receiver.IncomeSource.MapToDomainIncomeSource()
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewIncomeWithId(id, money, receiver.IncomeDate, receiver.Description, incomeSource)
+}
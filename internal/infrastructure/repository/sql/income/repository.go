@@ -0,0 +1,86 @@
+package income
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type repository struct {
+	table string
+	db    sql.Database
+}
+
+func NewRepository(db sql.Database, table string) *repository {
+	return &repository{db: db, table: table}
+}
+
+func (r repository) Add(ctx context.Context, userId uuid.UUID, income *models.Income) (*models.Income, error) {
+	incomeDbModel := r.mapIncomeDBModelFromIncome(userId, income)
+	result := r.db.WithContext(ctx).Table(r.table).Create(&incomeDbModel)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return income, nil
+}
+
+func (r repository) SearchInPeriod(ctx context.Context, userId uuid.UUID, startDate time.Time, endDate time.Time) ([]*models.Income, error) {
+	storedIncomes := []Income{}
+	result := r.db.WithContext(ctx).Table(r.table).
+		Joins("IncomeSource").
+		Find(&storedIncomes, r.table+".user_id = ? AND income_date >= ? AND income_date <= ?", userId.String(), sql.Date(startDate), sql.Date(endDate))
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	incomes := []*models.Income{}
+	for _, income := range storedIncomes {
+		domainIncome, err := income.MapToDomainIncome()
+		if err != nil {
+			return nil, err
+		}
+		incomes = append(incomes, domainIncome)
+	}
+
+	return incomes, nil
+}
+
+func (r repository) GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Income, error) {
+	var storedIncome Income
+	result := r.db.WithContext(ctx).Table(r.table).
+		Joins("IncomeSource").
+		First(&storedIncome, r.table+".id = ? AND "+r.table+".user_id = ?", id.String(), userId.String())
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return storedIncome.MapToDomainIncome()
+}
+
+func (r repository) mapIncomeDBModelFromIncome(userId uuid.UUID, incomeToAdd *models.Income) Income {
+	return Income{
+		ID:             incomeToAdd.Id().String(),
+		UserID:         userId.String(),
+		Amount:         sql.Amount(incomeToAdd.Amount().Amount()),
+		Currency:       incomeToAdd.Amount().Currency(),
+		IncomeDate:     incomeToAdd.IncomeDate(),
+		Description:    incomeToAdd.Description(),
+		IncomeSourceID: incomeToAdd.IncomeSource().Id().String(),
+	}
+}
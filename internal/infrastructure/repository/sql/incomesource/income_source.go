@@ -0,0 +1,20 @@
+package incomesource
+
+import (
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+type IncomeSource struct {
+	ID        string    `gorm:"primaryKey,column:id"`
+	UserID    string    `gorm:"column:user_id"`
+	Name      string    `gorm:"column:name"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+func (receiver IncomeSource) MapToDomainIncomeSource() (*models.IncomeSource, error) {
+	id, _ := uuid.Parse(receiver.ID)
+	return models.NewIncomeSourceWithId(id, receiver.Name)
+}
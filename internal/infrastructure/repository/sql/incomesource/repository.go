@@ -0,0 +1,84 @@
+package incomesource
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	table string
+	db    sql.Database
+}
+
+func NewRepository(db sql.Database, table string) *repository {
+	return &repository{db: db, table: table}
+}
+
+func (r repository) GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.IncomeSource, error) {
+	var storedIncomeSource IncomeSource
+	result := r.db.WithContext(ctx).Table(r.table).First(&storedIncomeSource, "id = ? AND user_id = ?", id.String(), userId.String())
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return storedIncomeSource.MapToDomainIncomeSource()
+}
+
+func (r repository) GetByName(ctx context.Context, userId uuid.UUID, name string) (*models.IncomeSource, error) {
+	var storedIncomeSource IncomeSource
+	result := r.db.WithContext(ctx).Table(r.table).First(&storedIncomeSource, "name = ? AND user_id = ?", name, userId.String())
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return storedIncomeSource.MapToDomainIncomeSource()
+}
+
+func (r repository) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.IncomeSource, error) {
+	storedIncomeSources := []IncomeSource{}
+	result := r.db.WithContext(ctx).Table(r.table).Where("user_id = ?", userId.String()).Order("name").Find(&storedIncomeSources)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	incomeSources := []*models.IncomeSource{}
+	for _, storedIncomeSource := range storedIncomeSources {
+		incomeSource, err := storedIncomeSource.MapToDomainIncomeSource()
+		if err != nil {
+			return nil, err
+		}
+		incomeSources = append(incomeSources, incomeSource)
+	}
+
+	return incomeSources, nil
+}
+
+func (r repository) Add(ctx context.Context, userId uuid.UUID, incomeSource *models.IncomeSource) (*models.IncomeSource, error) {
+	incomeSourceDbModel := IncomeSource{
+		ID:     incomeSource.Id().String(),
+		UserID: userId.String(),
+		Name:   incomeSource.Name(),
+	}
+	result := r.db.WithContext(ctx).Table(r.table).Create(&incomeSourceDbModel)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return incomeSource, nil
+}
@@ -0,0 +1,47 @@
+package ledger
+
+import (
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Ledger struct {
+	ID        string    `gorm:"primaryKey,column:id"`
+	Name      string    `gorm:"column:name"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+func (receiver Ledger) MapToDomainLedger() (*models.Ledger, error) {
+	id, _ := uuid.Parse(receiver.ID)
+	return models.NewLedgerWithId(id, receiver.Name)
+}
+
+type LedgerMember struct {
+	LedgerID  string    `gorm:"primaryKey,column:ledger_id"`
+	UserID    string    `gorm:"primaryKey,column:user_id"`
+	Role      string    `gorm:"column:role"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (receiver LedgerMember) MapToDomainLedgerMember() (*models.LedgerMember, error) {
+	ledgerId, _ := uuid.Parse(receiver.LedgerID)
+	userId, _ := uuid.Parse(receiver.UserID)
+	return models.NewLedgerMember(ledgerId, userId, models.LedgerRole(receiver.Role))
+}
+
+type LedgerInvitation struct {
+	ID        string    `gorm:"primaryKey,column:id"`
+	LedgerID  string    `gorm:"column:ledger_id"`
+	Role      string    `gorm:"column:role"`
+	TokenHash string    `gorm:"column:token_hash"`
+	ExpiresAt time.Time `gorm:"column:expires_at"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (receiver LedgerInvitation) MapToDomainLedgerInvitation() (*models.LedgerInvitation, error) {
+	id, _ := uuid.Parse(receiver.ID)
+	ledgerId, _ := uuid.Parse(receiver.LedgerID)
+	return models.NewLedgerInvitationWithId(id, ledgerId, models.LedgerRole(receiver.Role), receiver.TokenHash, receiver.ExpiresAt)
+}
@@ -0,0 +1,150 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	table           string
+	memberTable     string
+	invitationTable string
+	db              sql.Database
+}
+
+func NewRepository(db sql.Database, table string, memberTable string, invitationTable string) *repository {
+	return &repository{db: db, table: table, memberTable: memberTable, invitationTable: invitationTable}
+}
+
+func (r repository) Add(ctx context.Context, ledger *models.Ledger, owner *models.LedgerMember) (*models.Ledger, error) {
+	ledgerDbModel := Ledger{ID: ledger.Id().String(), Name: ledger.Name()}
+	ownerDbModel := r.mapLedgerMemberDBModelFromLedgerMember(owner)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(r.table).Create(&ledgerDbModel).Error; err != nil {
+			return err
+		}
+		return tx.Table(r.memberTable).Create(&ownerDbModel).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ledger, nil
+}
+
+func (r repository) GetAllByMember(ctx context.Context, userId uuid.UUID) ([]*models.Ledger, error) {
+	storedLedgers := []Ledger{}
+	result := r.db.WithContext(ctx).Table(r.table).
+		Select(r.table+".*").
+		Joins("JOIN "+r.memberTable+" ON "+r.memberTable+".ledger_id = "+r.table+".id").
+		Where(r.memberTable+".user_id = ?", userId.String()).
+		Order(r.table + ".name").
+		Find(&storedLedgers)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	ledgers := []*models.Ledger{}
+	for _, storedLedger := range storedLedgers {
+		ledger, err := storedLedger.MapToDomainLedger()
+		if err != nil {
+			return nil, err
+		}
+		ledgers = append(ledgers, ledger)
+	}
+
+	return ledgers, nil
+}
+
+func (r repository) GetMember(ctx context.Context, ledgerId uuid.UUID, userId uuid.UUID) (*models.LedgerMember, error) {
+	var storedMember LedgerMember
+	result := r.db.WithContext(ctx).Table(r.memberTable).First(&storedMember, "ledger_id = ? AND user_id = ?", ledgerId.String(), userId.String())
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return storedMember.MapToDomainLedgerMember()
+}
+
+func (r repository) GetMembers(ctx context.Context, ledgerId uuid.UUID) ([]*models.LedgerMember, error) {
+	storedMembers := []LedgerMember{}
+	result := r.db.WithContext(ctx).Table(r.memberTable).Where("ledger_id = ?", ledgerId.String()).Order("created_at").Find(&storedMembers)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	members := []*models.LedgerMember{}
+	for _, storedMember := range storedMembers {
+		member, err := storedMember.MapToDomainLedgerMember()
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+func (r repository) AddInvitation(ctx context.Context, invitation *models.LedgerInvitation) (*models.LedgerInvitation, error) {
+	invitationDbModel := LedgerInvitation{
+		ID:        invitation.Id().String(),
+		LedgerID:  invitation.LedgerId().String(),
+		Role:      string(invitation.Role()),
+		TokenHash: invitation.TokenHash(),
+		ExpiresAt: invitation.ExpiresAt(),
+	}
+	result := r.db.WithContext(ctx).Table(r.invitationTable).Create(&invitationDbModel)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (r repository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*models.LedgerInvitation, error) {
+	var storedInvitation LedgerInvitation
+	result := r.db.WithContext(ctx).Table(r.invitationTable).First(&storedInvitation, "token_hash = ?", tokenHash)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return storedInvitation.MapToDomainLedgerInvitation()
+}
+
+func (r repository) AcceptInvitation(ctx context.Context, invitation *models.LedgerInvitation, member *models.LedgerMember) error {
+	memberDbModel := r.mapLedgerMemberDBModelFromLedgerMember(member)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(r.memberTable).Create(&memberDbModel).Error; err != nil {
+			return err
+		}
+		return tx.Table(r.invitationTable).Where("id = ?", invitation.Id().String()).Delete(&LedgerInvitation{}).Error
+	})
+}
+
+func (r repository) mapLedgerMemberDBModelFromLedgerMember(member *models.LedgerMember) LedgerMember {
+	return LedgerMember{
+		LedgerID: member.LedgerId().String(),
+		UserID:   member.UserId().String(),
+		Role:     string(member.Role()),
+	}
+}
@@ -0,0 +1,149 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var fileNameRegexp = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is a change of the database schema, with the statements that apply it and the ones that revert it.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// AppliedMigration is a migration recorded in the schema_migrations table.
+type AppliedMigration struct {
+	Version   uint64
+	Name      string
+	AppliedAt time.Time
+}
+
+// Load reads the migrations in the root of fsys, sorted by version. Every version must have both an up and a down file
+// with the same name, and every other .sql file is an error so a misnamed migration is never skipped.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	migrationsByVersion := map[uint64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		match := fileNameRegexp.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("the migration file %s must be named VERSION_NAME.up.sql or VERSION_NAME.down.sql", entry.Name())
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("the migration file %s must have a version greater than zero", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := migrationsByVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			migrationsByVersion[version] = migration
+		}
+
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("the migrations %s and %s have the same version %d", migration.Name, match[2], version)
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := []Migration{}
+	for _, migration := range migrationsByVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("the migration %d_%s must have both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// pendingMigrations returns the migrations to apply, in order. A pending migration older than the last applied one
+// is an error, it was probably added in a branch merged after newer migrations ran and must get a new version.
+// Applied migrations unknown to this binary are ignored, they come from a newer one.
+func pendingMigrations(migrations []Migration, applied []AppliedMigration) ([]Migration, error) {
+	appliedVersions := map[uint64]bool{}
+	lastApplied := uint64(0)
+	for _, appliedMigration := range applied {
+		appliedVersions[appliedMigration.Version] = true
+		if appliedMigration.Version > lastApplied {
+			lastApplied = appliedMigration.Version
+		}
+	}
+
+	pending := []Migration{}
+	for _, migration := range migrations {
+		if appliedVersions[migration.Version] {
+			continue
+		}
+
+		if migration.Version < lastApplied {
+			return nil, fmt.Errorf("the migration %d_%s is pending but the newer migration %d is already applied", migration.Version, migration.Name, lastApplied)
+		}
+		pending = append(pending, migration)
+	}
+
+	return pending, nil
+}
+
+// migrationsToRevert returns the last steps applied migrations, the newest first.
+func migrationsToRevert(migrations []Migration, applied []AppliedMigration, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, errors.New("the migrations to revert must be at least one")
+	}
+
+	if steps > len(applied) {
+		return nil, fmt.Errorf("there are only %d applied migrations, %d can't be reverted", len(applied), steps)
+	}
+
+	migrationsByVersion := map[uint64]Migration{}
+	for _, migration := range migrations {
+		migrationsByVersion[migration.Version] = migration
+	}
+
+	newestFirst := append([]AppliedMigration{}, applied...)
+	sort.Slice(newestFirst, func(i, j int) bool {
+		return newestFirst[i].Version > newestFirst[j].Version
+	})
+
+	toRevert := []Migration{}
+	for _, appliedMigration := range newestFirst[:steps] {
+		migration, ok := migrationsByVersion[appliedMigration.Version]
+		if !ok {
+			return nil, fmt.Errorf("the applied migration %d_%s is unknown to this binary, it can't be reverted", appliedMigration.Version, appliedMigration.Name)
+		}
+		toRevert = append(toRevert, migration)
+	}
+
+	return toRevert, nil
+}
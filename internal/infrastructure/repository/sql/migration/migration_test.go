@@ -0,0 +1,197 @@
+package migration
+
+import (
+	dbmigrations "finfit-backend/db_migrations"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+type MigrationTestSuite struct {
+	suite.Suite
+	migrations []Migration
+}
+
+func (s *MigrationTestSuite) SetupTest() {
+	s.migrations = []Migration{
+		{Version: 1, Name: "create_expense_type_table", Up: "CREATE TABLE expense_type (id uuid)", Down: "DROP TABLE expense_type"},
+		{Version: 2, Name: "create_expense_table", Up: "CREATE TABLE expense (id uuid)", Down: "DROP TABLE expense"},
+		{Version: 3, Name: "add_currency_column_to_expense", Up: "ALTER TABLE expense ADD COLUMN currency VARCHAR(3)", Down: "ALTER TABLE expense DROP COLUMN currency"},
+	}
+}
+
+func TestMigrationSuite(t *testing.T) {
+	suite.Run(t, new(MigrationTestSuite))
+}
+
+func (s *MigrationTestSuite) TestGivenMigrationFilesWhenLoadThenReturnsThemSortedByVersion() {
+	fsys := fstest.MapFS{
+		"000002_create_expense_table.down.sql":      {Data: []byte("DROP TABLE expense")},
+		"000002_create_expense_table.up.sql":        {Data: []byte("CREATE TABLE expense (id uuid)")},
+		"000001_create_expense_type_table.up.sql":   {Data: []byte("CREATE TABLE expense_type (id uuid)")},
+		"000001_create_expense_type_table.down.sql": {Data: []byte("DROP TABLE expense_type")},
+		"migrations.go": {Data: []byte("package dbmigrations")},
+	}
+
+	migrations, err := Load(fsys)
+
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.migrations[:2], migrations)
+}
+
+func (s *MigrationTestSuite) TestGivenMigrationWithoutDownFileWhenLoadThenReturnsError() {
+	fsys := fstest.MapFS{
+		"000001_create_expense_type_table.up.sql": {Data: []byte("CREATE TABLE expense_type (id uuid)")},
+	}
+
+	migrations, err := Load(fsys)
+
+	assert.Nil(s.T(), migrations)
+	assert.EqualError(s.T(), err, "the migration 1_create_expense_type_table must have both an up and a down file")
+}
+
+func (s *MigrationTestSuite) TestGivenMigrationsWithTheSameVersionWhenLoadThenReturnsError() {
+	fsys := fstest.MapFS{
+		"000001_create_expense_type_table.up.sql": {Data: []byte("CREATE TABLE expense_type (id uuid)")},
+		"000001_create_expense_table.up.sql":      {Data: []byte("CREATE TABLE expense (id uuid)")},
+	}
+
+	_, err := Load(fsys)
+
+	assert.EqualError(s.T(), err, "the migrations create_expense_table and create_expense_type_table have the same version 1")
+}
+
+func (s *MigrationTestSuite) TestGivenMisnamedMigrationFileWhenLoadThenReturnsError() {
+	fsys := fstest.MapFS{
+		"create_expense_type_table.sql": {Data: []byte("CREATE TABLE expense_type (id uuid)")},
+	}
+
+	_, err := Load(fsys)
+
+	assert.EqualError(s.T(), err, "the migration file create_expense_type_table.sql must be named VERSION_NAME.up.sql or VERSION_NAME.down.sql")
+}
+
+func (s *MigrationTestSuite) TestGivenEmbeddedMigrationsWhenLoadThenEveryOneIsValid() {
+	migrations, err := Load(dbmigrations.Files)
+
+	require.NoError(s.T(), err)
+	require.NotEmpty(s.T(), migrations)
+	for i, migration := range migrations {
+		assert.Equal(s.T(), uint64(i+1), migration.Version, "the versions of the migrations must not have gaps")
+	}
+}
+
+func (s *MigrationTestSuite) TestGivenEmbeddedSQLiteMigrationsWhenLoadThenTheyEndAtTheLastPostgresVersion() {
+	postgresMigrations, err := Load(dbmigrations.Files)
+	require.NoError(s.T(), err)
+
+	migrations, err := Load(dbmigrations.SQLiteFiles())
+
+	require.NoError(s.T(), err)
+	require.NotEmpty(s.T(), migrations)
+	for i, migration := range migrations {
+		assert.Equal(s.T(), migrations[0].Version+uint64(i), migration.Version, "the versions of the migrations must not have gaps")
+	}
+	assert.Equal(s.T(), postgresMigrations[len(postgresMigrations)-1].Version, migrations[len(migrations)-1].Version)
+}
+
+func (s *MigrationTestSuite) TestGivenAppliedMigrationsWhenPendingMigrationsThenReturnsTheNewerOnes() {
+	applied := []AppliedMigration{{Version: 1, Name: "create_expense_type_table", AppliedAt: time.Now()}}
+
+	pending, err := pendingMigrations(s.migrations, applied)
+
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.migrations[1:], pending)
+}
+
+func (s *MigrationTestSuite) TestGivenPendingMigrationOlderThanAnAppliedOneWhenPendingMigrationsThenReturnsError() {
+	applied := []AppliedMigration{{Version: 1}, {Version: 3}}
+
+	pending, err := pendingMigrations(s.migrations, applied)
+
+	assert.Nil(s.T(), pending)
+	assert.EqualError(s.T(), err, "the migration 2_create_expense_table is pending but the newer migration 3 is already applied")
+}
+
+func (s *MigrationTestSuite) TestGivenMigrationsAppliedByANewerBinaryWhenPendingMigrationsThenIgnoresThem() {
+	applied := []AppliedMigration{{Version: 1}, {Version: 2}, {Version: 3}, {Version: 4, Name: "create_income_table"}}
+
+	pending, err := pendingMigrations(s.migrations, applied)
+
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), pending)
+}
+
+func (s *MigrationTestSuite) TestGivenAppliedMigrationsWhenMigrationsToRevertThenReturnsTheNewestFirst() {
+	applied := []AppliedMigration{{Version: 1}, {Version: 2}, {Version: 3}}
+
+	toRevert, err := migrationsToRevert(s.migrations, applied, 2)
+
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []Migration{s.migrations[2], s.migrations[1]}, toRevert)
+}
+
+func (s *MigrationTestSuite) TestGivenMoreStepsThanAppliedMigrationsWhenMigrationsToRevertThenReturnsError() {
+	applied := []AppliedMigration{{Version: 1}}
+
+	toRevert, err := migrationsToRevert(s.migrations, applied, 2)
+
+	assert.Nil(s.T(), toRevert)
+	assert.EqualError(s.T(), err, "there are only 1 applied migrations, 2 can't be reverted")
+}
+
+func (s *MigrationTestSuite) TestGivenUnknownAppliedMigrationWhenMigrationsToRevertThenReturnsError() {
+	applied := []AppliedMigration{{Version: 1}, {Version: 4, Name: "create_income_table"}}
+
+	toRevert, err := migrationsToRevert(s.migrations, applied, 1)
+
+	assert.Nil(s.T(), toRevert)
+	assert.EqualError(s.T(), err, "the applied migration 4_create_income_table is unknown to this binary, it can't be reverted")
+}
+
+func (s *MigrationTestSuite) TestGivenANewDatabaseWhenStatusThenListsEveryMigrationAsPendingWithoutCreatingTheTable() {
+	migrator := s.newSQLiteMigrator()
+
+	statuses, err := migrator.Status()
+
+	require.NoError(s.T(), err)
+	require.Len(s.T(), statuses, 3)
+	for _, status := range statuses {
+		assert.False(s.T(), status.Applied)
+	}
+	var tables int
+	require.NoError(s.T(), migrator.db.QueryRow("SELECT count(*) FROM sqlite_master WHERE name = 'schema_migrations'").Scan(&tables))
+	assert.Zero(s.T(), tables)
+}
+
+func (s *MigrationTestSuite) TestGivenAppliedMigrationsWhenStatusThenListsThemAsApplied() {
+	migrator := s.newSQLiteMigrator()
+	migrator.migrations = s.migrations[:2]
+	_, err := migrator.Up()
+	require.NoError(s.T(), err)
+	migrator.migrations = s.migrations
+
+	statuses, err := migrator.Status()
+
+	require.NoError(s.T(), err)
+	require.Len(s.T(), statuses, 3)
+	assert.True(s.T(), statuses[0].Applied)
+	assert.True(s.T(), statuses[1].Applied)
+	assert.False(s.T(), statuses[2].Applied)
+}
+
+func (s *MigrationTestSuite) newSQLiteMigrator() *Migrator {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(s.T().TempDir(), "finfit.db")), &gorm.Config{})
+	require.NoError(s.T(), err)
+	sqlDB, err := db.DB()
+	require.NoError(s.T(), err)
+	s.T().Cleanup(func() { _ = sqlDB.Close() })
+
+	return &Migrator{db: sqlDB, dialect: SQLiteDialect, migrations: s.migrations}
+}
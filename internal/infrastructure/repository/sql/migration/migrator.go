@@ -0,0 +1,295 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"sort"
+	"time"
+)
+
+const (
+	createMigrationsTableStatement = `CREATE TABLE IF NOT EXISTS schema_migrations
+(
+    version    BIGINT PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMP    NOT NULL
+)`
+	selectAppliedMigrationsStatement = "SELECT version, name, applied_at FROM schema_migrations ORDER BY version"
+	// lockKey identifies the advisory lock held while migrating, any number no other feature locks on.
+	lockKey = 8_316_410_563_273
+)
+
+// Dialect holds the statements that differ between the databases the migrator runs on.
+type Dialect struct {
+	insertMigrationStatement       string
+	deleteMigrationStatement       string
+	deleteNewerMigrationsStatement string
+	migrationsTableExistsStatement string
+	lockStatement                  string
+	unlockStatement                string
+}
+
+var (
+	// PostgresDialect holds an advisory lock while migrating.
+	PostgresDialect = Dialect{
+		insertMigrationStatement:       "INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)",
+		deleteMigrationStatement:       "DELETE FROM schema_migrations WHERE version = $1",
+		deleteNewerMigrationsStatement: "DELETE FROM schema_migrations WHERE version > $1",
+		migrationsTableExistsStatement: "SELECT to_regclass('schema_migrations') IS NOT NULL",
+		lockStatement:                  "SELECT pg_advisory_lock($1)",
+		unlockStatement:                "SELECT pg_advisory_unlock($1)",
+	}
+	// SQLiteDialect takes no lock, the database file belongs to a single instance.
+	SQLiteDialect = Dialect{
+		insertMigrationStatement:       "INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+		deleteMigrationStatement:       "DELETE FROM schema_migrations WHERE version = ?",
+		deleteNewerMigrationsStatement: "DELETE FROM schema_migrations WHERE version > ?",
+		migrationsTableExistsStatement: "SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations')",
+	}
+)
+
+// Status tells whether a migration is applied. The migrations applied by a newer binary are listed too, with their
+// stored name and no statements.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator applies and reverts the migrations of the database schema, recording the applied ones in the
+// schema_migrations table. Every migration runs in a transaction along with its record, and on Postgres every operation
+// that writes holds an advisory lock, so instances started at the same time wait for each other instead of migrating
+// twice.
+type Migrator struct {
+	db         *sql.DB
+	dialect    Dialect
+	migrations []Migration
+}
+
+func NewMigrator(db *sql.DB, dialect Dialect, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}, nil
+}
+
+// Up applies the pending migrations in order and returns them. It stops at the first one that fails, keeping the ones
+// applied before.
+func (m Migrator) Up() ([]Migration, error) {
+	applied := []Migration{}
+	err := m.withLock(func(ctx context.Context, conn *sql.Conn, appliedMigrations []AppliedMigration) error {
+		pending, err := pendingMigrations(m.migrations, appliedMigrations)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range pending {
+			err = inTransaction(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+					return err
+				}
+				_, err := tx.ExecContext(ctx, m.dialect.insertMigrationStatement, migration.Version, migration.Name, time.Now().UTC())
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("the migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the last steps applied migrations, the newest first, and returns them.
+func (m Migrator) Down(steps int) ([]Migration, error) {
+	reverted := []Migration{}
+	err := m.withLock(func(ctx context.Context, conn *sql.Conn, appliedMigrations []AppliedMigration) error {
+		toRevert, err := migrationsToRevert(m.migrations, appliedMigrations, steps)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range toRevert {
+			err = inTransaction(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+					return err
+				}
+				_, err := tx.ExecContext(ctx, m.dialect.deleteMigrationStatement, migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("reverting the migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status returns every known or applied migration sorted by version. It only reads, so it neither waits for the lock
+// nor creates the schema_migrations table, and while another instance migrates it may list a migration as pending.
+func (m Migrator) Status() ([]Status, error) {
+	appliedMigrations, err := m.readAppliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	appliedByVersion := map[uint64]AppliedMigration{}
+	for _, appliedMigration := range appliedMigrations {
+		appliedByVersion[appliedMigration.Version] = appliedMigration
+	}
+
+	statuses := []Status{}
+	for _, migration := range m.migrations {
+		appliedMigration, ok := appliedByVersion[migration.Version]
+		statuses = append(statuses, Status{Migration: migration, Applied: ok, AppliedAt: appliedMigration.AppliedAt})
+		delete(appliedByVersion, migration.Version)
+	}
+
+	for _, appliedMigration := range appliedMigrations {
+		if _, unknown := appliedByVersion[appliedMigration.Version]; unknown {
+			statuses = append(statuses, Status{
+				Migration: Migration{Version: appliedMigration.Version, Name: appliedMigration.Name},
+				Applied:   true,
+				AppliedAt: appliedMigration.AppliedAt,
+			})
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses, nil
+}
+
+// Force records the migrations up to version as applied and the newer ones as pending, without running any of them.
+// It adopts a database migrated by hand, or fixes the records after a migration that failed halfway through
+// statements that can't be rolled back. A version of zero records every migration as pending.
+func (m Migrator) Force(version uint64) error {
+	if version != 0 && !m.isKnown(version) {
+		return fmt.Errorf("the migration %d doesn't exist", version)
+	}
+
+	return m.withLock(func(ctx context.Context, conn *sql.Conn, appliedMigrations []AppliedMigration) error {
+		appliedVersions := map[uint64]bool{}
+		for _, appliedMigration := range appliedMigrations {
+			appliedVersions[appliedMigration.Version] = true
+		}
+
+		return inTransaction(ctx, conn, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, m.dialect.deleteNewerMigrationsStatement, version); err != nil {
+				return err
+			}
+
+			for _, migration := range m.migrations {
+				if migration.Version > version || appliedVersions[migration.Version] {
+					continue
+				}
+				if _, err := tx.ExecContext(ctx, m.dialect.insertMigrationStatement, migration.Version, migration.Name, time.Now().UTC()); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+func (m Migrator) isKnown(version uint64) bool {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// withLock runs operation on a single connection holding the advisory lock of the dialect, if it has one, since the
+// lock belongs to the session that took it, along with the migrations applied once the lock is taken.
+func (m Migrator) withLock(operation func(ctx context.Context, conn *sql.Conn, appliedMigrations []AppliedMigration) error) error {
+	ctx := context.Background()
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if m.dialect.lockStatement != "" {
+		if _, err = conn.ExecContext(ctx, m.dialect.lockStatement, lockKey); err != nil {
+			return err
+		}
+		defer conn.ExecContext(ctx, m.dialect.unlockStatement, lockKey)
+	}
+
+	if _, err = conn.ExecContext(ctx, createMigrationsTableStatement); err != nil {
+		return err
+	}
+
+	appliedMigrations, err := selectAppliedMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	return operation(ctx, conn, appliedMigrations)
+}
+
+// readAppliedMigrations returns the applied migrations without taking the lock, none when nothing was migrated yet.
+func (m Migrator) readAppliedMigrations() ([]AppliedMigration, error) {
+	ctx := context.Background()
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var migrationsTableExists bool
+	if err = conn.QueryRowContext(ctx, m.dialect.migrationsTableExistsStatement).Scan(&migrationsTableExists); err != nil {
+		return nil, err
+	}
+
+	if !migrationsTableExists {
+		return []AppliedMigration{}, nil
+	}
+
+	return selectAppliedMigrations(ctx, conn)
+}
+
+func selectAppliedMigrations(ctx context.Context, conn *sql.Conn) ([]AppliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, selectAppliedMigrationsStatement)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	appliedMigrations := []AppliedMigration{}
+	for rows.Next() {
+		appliedMigration := AppliedMigration{}
+		if err = rows.Scan(&appliedMigration.Version, &appliedMigration.Name, &appliedMigration.AppliedAt); err != nil {
+			return nil, err
+		}
+		appliedMigrations = append(appliedMigrations, appliedMigration)
+	}
+
+	return appliedMigrations, rows.Err()
+}
+
+func inTransaction(ctx context.Context, conn *sql.Conn, operation func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = operation(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
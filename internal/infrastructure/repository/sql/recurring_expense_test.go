@@ -0,0 +1,77 @@
+package sql_test
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	expenseService "finfit-backend/internal/domain/services/expense"
+	recurringExpenseService "finfit-backend/internal/domain/services/recurringexpense"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"finfit-backend/internal/infrastructure/repository/sql/expense"
+	"finfit-backend/internal/infrastructure/repository/sql/recurringexpense"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+// failingExpenseRepository fails to add the expenses, after the generator has claimed their occurrence.
+type failingExpenseRepository struct {
+	expenseService.Repository
+}
+
+func (failingExpenseRepository) Add(context.Context, uuid.UUID, *models.Expense) (*models.Expense, error) {
+	return nil, errors.New("disk I/O error")
+}
+
+func newRecurringExpenseService(db *gorm.DB, newExpenseRepository func(db *gorm.DB) expenseService.Repository) recurringExpenseService.Service {
+	unitOfWork := sql.NewUnitOfWork(db, func(tx *gorm.DB) recurringExpenseService.Repositories {
+		return recurringExpenseService.Repositories{RecurringExpenses: recurringexpense.NewRepository(tx, "recurring_expense"), Expenses: newExpenseRepository(tx)}
+	})
+	return recurringExpenseService.NewService(recurringexpense.NewRepository(db, "recurring_expense"), unitOfWork, nil)
+}
+
+func newSQLExpenseRepository(db *gorm.DB) expenseService.Repository {
+	return expense.NewRepository(db, "expense", "expense_split_participant", "expense_type", "tag", "expense_tag")
+}
+
+func addMonthlyRecurringExpense(t *testing.T, db *gorm.DB, userId uuid.UUID, startDate time.Time) *models.RecurringExpense {
+	rent := addExpenseType(t, newExpenseTypeRepository(db), models.PersonalLedgerId(userId), "Rent")
+	amount, err := models.NewMoney("800", "EUR")
+	require.NoError(t, err)
+	schedule, err := models.NewRecurrenceRule(models.MonthlyRecurrenceFrequency, 1, startDate, time.Time{}, 0)
+	require.NoError(t, err)
+	recurringExpense, err := models.NewRecurringExpense(amount, "Rent", rent, schedule)
+	require.NoError(t, err)
+	_, err = recurringexpense.NewRepository(db, "recurring_expense").Add(context.Background(), userId, recurringExpense)
+	require.NoError(t, err)
+	return recurringExpense
+}
+
+func TestGivenThatFailToAddAGeneratedExpense_WhenGenerateAgain_ThenEveryOccurrenceIsAddedOnce(t *testing.T) {
+	db := openSQLiteTestDatabase(t)
+	storedUser := addUser(t, db)
+	recurringExpense := addMonthlyRecurringExpense(t, db, storedUser.Id(), time.Date(2022, 1, 5, 0, 0, 0, 0, time.UTC))
+	command, err := recurringExpenseService.NewGenerateCommand(time.Date(2022, 3, 10, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	_, err = newRecurringExpenseService(db, func(*gorm.DB) expenseService.Repository { return failingExpenseRepository{} }).Generate(context.Background(), storedUser.Id(), command)
+	require.Error(t, err)
+	storedRecurringExpense, err := recurringexpense.NewRepository(db, "recurring_expense").GetByID(context.Background(), storedUser.Id(), recurringExpense.Id())
+	require.NoError(t, err)
+	assert.True(t, storedRecurringExpense.LastOccurrence().IsZero(), "the claim of the occurrence must be rolled back")
+
+	generatedExpenses, err := newRecurringExpenseService(db, newSQLExpenseRepository).Generate(context.Background(), storedUser.Id(), command)
+	require.NoError(t, err)
+	assert.Len(t, generatedExpenses, 3)
+
+	generatedExpenses, err = newRecurringExpenseService(db, newSQLExpenseRepository).Generate(context.Background(), storedUser.Id(), command)
+	require.NoError(t, err)
+	assert.Empty(t, generatedExpenses)
+
+	var storedExpensesCount int64
+	require.NoError(t, db.Table("expense").Where("ledger_id = ?", models.PersonalLedgerId(storedUser.Id()).String()).Count(&storedExpensesCount).Error)
+	assert.Equal(t, int64(3), storedExpensesCount)
+}
@@ -0,0 +1,59 @@
+package recurringexpense
+
+import (
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"finfit-backend/internal/infrastructure/repository/sql/expensetype"
+	"github.com/google/uuid"
+	"time"
+)
+
+type RecurringExpense struct {
+	ID               string `gorm:"primaryKey"`
+	UserID           string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Amount           sql.Amount
+	Currency         string
+	Description      string
+	ExpenseTypeID    string
+	ExpenseType      expensetype.ExpenseType
+	Frequency        string
+	ScheduleInterval int
+	StartDate        time.Time
+	EndDate          *time.Time
+	OccurrencesCount int
+	LastOccurrence   *time.Time
+}
+
+func (receiver RecurringExpense) MapToDomainRecurringExpense() (*models.RecurringExpense, error) {
+	id, _ := uuid.Parse(receiver.ID)
+	amount, err := models.NewMoney(string(receiver.Amount), receiver.Currency)
+	if err != nil {
+		return nil, err
+	}
+	expenseType, err := receiver.ExpenseType.MapToDomainExpenseType()
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := models.NewRecurrenceRule(models.RecurrenceFrequency(receiver.Frequency), receiver.ScheduleInterval, receiver.StartDate, valueOrZero(receiver.EndDate), receiver.OccurrencesCount)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewRecurringExpenseWithId(id, amount, receiver.Description, expenseType, schedule, valueOrZero(receiver.LastOccurrence))
+}
+
+func valueOrZero(date *time.Time) time.Time {
+	if date == nil {
+		return time.Time{}
+	}
+	return *date
+}
+
+func nilIfZero(date time.Time) *time.Time {
+	if date.IsZero() {
+		return nil
+	}
+	return &date
+}
@@ -0,0 +1,103 @@
+package recurringexpense
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type repository struct {
+	table string
+	db    sql.Database
+}
+
+func NewRepository(db sql.Database, table string) *repository {
+	return &repository{db: db, table: table}
+}
+
+func (r repository) Add(ctx context.Context, userId uuid.UUID, recurringExpense *models.RecurringExpense) (*models.RecurringExpense, error) {
+	recurringExpenseDbModel := RecurringExpense{
+		ID:               recurringExpense.Id().String(),
+		UserID:           userId.String(),
+		Amount:           sql.Amount(recurringExpense.Amount().Amount()),
+		Currency:         recurringExpense.Amount().Currency(),
+		Description:      recurringExpense.Description(),
+		ExpenseTypeID:    recurringExpense.ExpenseType().Id().String(),
+		Frequency:        string(recurringExpense.Schedule().Frequency()),
+		ScheduleInterval: recurringExpense.Schedule().Interval(),
+		StartDate:        recurringExpense.Schedule().StartDate(),
+		EndDate:          nilIfZero(recurringExpense.Schedule().EndDate()),
+		OccurrencesCount: recurringExpense.Schedule().Count(),
+		LastOccurrence:   nilIfZero(recurringExpense.LastOccurrence()),
+	}
+	result := r.db.WithContext(ctx).Table(r.table).Create(&recurringExpenseDbModel)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return recurringExpense, nil
+}
+
+func (r repository) GetByID(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.RecurringExpense, error) {
+	var storedRecurringExpense RecurringExpense
+	result := r.db.WithContext(ctx).Table(r.table).
+		Joins("ExpenseType").
+		First(&storedRecurringExpense, r.table+".id = ? AND "+r.table+".user_id = ?", id.String(), userId.String())
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return storedRecurringExpense.MapToDomainRecurringExpense()
+}
+
+func (r repository) GetAll(ctx context.Context, userId uuid.UUID) ([]*models.RecurringExpense, error) {
+	storedRecurringExpenses := []RecurringExpense{}
+	result := r.db.WithContext(ctx).Table(r.table).
+		Joins("ExpenseType").
+		Where(r.table+".user_id = ?", userId.String()).
+		Order(r.table + ".start_date").
+		Find(&storedRecurringExpenses)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	recurringExpenses := []*models.RecurringExpense{}
+	for _, storedRecurringExpense := range storedRecurringExpenses {
+		recurringExpense, err := storedRecurringExpense.MapToDomainRecurringExpense()
+		if err != nil {
+			return nil, err
+		}
+		recurringExpenses = append(recurringExpenses, recurringExpense)
+	}
+
+	return recurringExpenses, nil
+}
+
+func (r repository) Delete(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Table(r.table).Delete(&RecurringExpense{}, "id = ? AND user_id = ?", id.String(), userId.String())
+	return result.Error
+}
+
+// UpdateLastOccurrence checks the stored last occurrence in the update itself, which waits for the transactions that
+// are updating the row, so only one of them moves it.
+func (r repository) UpdateLastOccurrence(ctx context.Context, userId uuid.UUID, id uuid.UUID, lastOccurrence time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Table(r.table).
+		Where("id = ? AND user_id = ? AND (last_occurrence IS NULL OR last_occurrence < ?)", id.String(), userId.String(), sql.Date(lastOccurrence)).
+		Updates(map[string]interface{}{"last_occurrence": sql.Date(lastOccurrence), "updated_at": time.Now()})
+	if err := result.Error; err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected > 0, nil
+}
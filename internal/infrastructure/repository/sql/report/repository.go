@@ -0,0 +1,180 @@
+package report
+
+import (
+	"context"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// dateBucketFormats are the to_char patterns used to build the key of each time bucket. Weeks follow ISO 8601.
+var dateBucketFormats = map[models.ReportGrouping]string{
+	models.DayReportGrouping:   "YYYY-MM-DD",
+	models.WeekReportGrouping:  `IYYY-"W"IW`,
+	models.MonthReportGrouping: "YYYY-MM",
+	models.YearReportGrouping:  "YYYY",
+}
+
+// sqliteDateBucketFormats build the same keys in SQLite, where %s is the date. Its strftime has no ISO weeks, so the
+// week and its year are the ones of the Thursday of the week of the date.
+var sqliteDateBucketFormats = map[models.ReportGrouping]string{
+	models.DayReportGrouping:   "strftime('%%Y-%%m-%%d', %s)",
+	models.WeekReportGrouping:  "strftime('%%Y-W', date(%[1]s, 'weekday 0', '-3 days')) || printf('%%02d', (strftime('%%j', date(%[1]s, 'weekday 0', '-3 days')) - 1) / 7 + 1)",
+	models.MonthReportGrouping: "strftime('%%Y-%%m', %s)",
+	models.YearReportGrouping:  "strftime('%%Y', %s)",
+}
+
+type repository struct {
+	table            string
+	expenseTypeTable string
+	db               sql.Database
+}
+
+func NewRepository(db sql.Database, table string, expenseTypeTable string) *repository {
+	return &repository{db: db, table: table, expenseTypeTable: expenseTypeTable}
+}
+
+func (r repository) GetSpending(ctx context.Context, ledgerId uuid.UUID, startDate time.Time, endDate time.Time, groupBy models.ReportGrouping, currency string, expenseTypeIds []uuid.UUID) ([]*models.CurrencySpending, error) {
+	query := r.filteredQuery(ctx, ledgerId, startDate, endDate, currency, expenseTypeIds)
+	groupKey, groupLabel := r.groupColumns(query, groupBy)
+	currencyColumn := r.table + ".currency"
+	amountColumn := r.table + ".amount"
+
+	query = query.
+		Select(fmt.Sprintf("%s AS currency, %s AS group_key, %s AS group_label, "+
+			"SUM(%s) AS total, COUNT(*) AS count, "+
+			"SUM(SUM(%s)) OVER (PARTITION BY %s) AS currency_total, "+
+			"CAST(SUM(COUNT(*)) OVER (PARTITION BY %s) AS BIGINT) AS currency_count",
+			currencyColumn, groupKey, groupLabel, amountColumn, amountColumn, currencyColumn, currencyColumn))
+
+	rows := []SpendingRow{}
+	result := query.
+		Group(fmt.Sprintf("%s, %s, %s", currencyColumn, groupKey, groupLabel)).
+		Order(fmt.Sprintf("%s, %s", currencyColumn, groupLabel)).
+		Scan(&rows)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return mapToDomainSpending(rows)
+}
+
+func (r repository) GetDailySpending(ctx context.Context, ledgerId uuid.UUID, startDate time.Time, endDate time.Time, groupBy models.ReportGrouping, currency string, expenseTypeIds []uuid.UUID) ([]*models.SpendingEntry, error) {
+	query := r.filteredQuery(ctx, ledgerId, startDate, endDate, currency, expenseTypeIds)
+	groupKey, groupLabel := r.groupColumns(query, groupBy)
+	currencyColumn := r.table + ".currency"
+	dateColumn := r.table + ".expense_date"
+
+	query = query.
+		Select(fmt.Sprintf("%s AS currency, %s AS group_key, %s AS group_label, %s AS expense_date, SUM(%s.amount) AS total, COUNT(*) AS count",
+			currencyColumn, groupKey, groupLabel, dateColumn, r.table))
+
+	rows := []SpendingEntryRow{}
+	result := query.
+		Group(fmt.Sprintf("%s, %s, %s, %s", groupKey, groupLabel, dateColumn, currencyColumn)).
+		Order(fmt.Sprintf("%s, %s, %s", groupLabel, dateColumn, currencyColumn)).
+		Scan(&rows)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	entries := []*models.SpendingEntry{}
+	for _, row := range rows {
+		entry, err := row.MapToDomainSpendingEntry()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// filteredQuery selects the expenses of the ledger, joined with their type, between both dates,
+// in the currency if there's one and of the expense types if there are any.
+func (r repository) filteredQuery(ctx context.Context, ledgerId uuid.UUID, startDate time.Time, endDate time.Time, currency string, expenseTypeIds []uuid.UUID) *gorm.DB {
+	query := r.db.WithContext(ctx).Table(r.table).
+		Joins(fmt.Sprintf("JOIN %s ON %s.id = %s.expense_type_id", r.expenseTypeTable, r.expenseTypeTable, r.table)).
+		Where(r.table+".ledger_id = ?", ledgerId.String()).
+		Where(r.table+".expense_date BETWEEN ? AND ?", sql.Date(startDate), sql.Date(endDate))
+
+	if currency != "" {
+		query = query.Where(r.table+".currency = ?", currency)
+	}
+
+	if len(expenseTypeIds) > 0 {
+		expenseTypeIdStrings := []string{}
+		for _, expenseTypeId := range expenseTypeIds {
+			expenseTypeIdStrings = append(expenseTypeIdStrings, expenseTypeId.String())
+		}
+		query = query.Where(r.table+".expense_type_id IN ?", expenseTypeIdStrings)
+	}
+
+	return query
+}
+
+// groupColumns returns the SQL expressions for the key and the label of each group. Groups are sorted by label, which
+// for time buckets is the same as the key. The time buckets are built with the functions of the database of the query.
+func (r repository) groupColumns(query *gorm.DB, groupBy models.ReportGrouping) (string, string) {
+	if groupBy == models.ExpenseTypeReportGrouping {
+		return "CAST(" + r.expenseTypeTable + ".id AS TEXT)", r.expenseTypeTable + ".name"
+	}
+
+	dateColumn := r.table + ".expense_date"
+	if query.Dialector.Name() == "sqlite" {
+		bucket := fmt.Sprintf(sqliteDateBucketFormats[groupBy], dateColumn)
+		return bucket, bucket
+	}
+
+	bucket := fmt.Sprintf("to_char(%s, '%s')", dateColumn, dateBucketFormats[groupBy])
+	return bucket, bucket
+}
+
+// mapToDomainSpending assembles the rows, already sorted by currency, into one CurrencySpending per currency.
+func mapToDomainSpending(rows []SpendingRow) ([]*models.CurrencySpending, error) {
+	spending := []*models.CurrencySpending{}
+
+	for start := 0; start < len(rows); {
+		end := start
+		for end < len(rows) && rows[end].Currency == rows[start].Currency {
+			end++
+		}
+
+		currencySpending, err := mapCurrencyRows(rows[start:end])
+		if err != nil {
+			return nil, err
+		}
+		spending = append(spending, currencySpending)
+		start = end
+	}
+
+	return spending, nil
+}
+
+func mapCurrencyRows(rows []SpendingRow) (*models.CurrencySpending, error) {
+	currencyTotal, err := models.NewMoney(string(rows[0].CurrencyTotal), rows[0].Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []*models.SpendingGroup{}
+	for _, row := range rows {
+		total, err := models.NewMoney(string(row.Total), row.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		group, err := models.NewSpendingGroup(row.GroupKey, row.GroupLabel, total, row.Count, currencyTotal)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+
+	return models.NewCurrencySpending(currencyTotal, rows[0].CurrencyCount, groups)
+}
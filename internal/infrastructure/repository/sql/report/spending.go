@@ -0,0 +1,37 @@
+package report
+
+import (
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"time"
+)
+
+// SpendingRow is one group of the spending report as returned by the aggregation query. The currency totals are
+// computed with window functions so every row also carries the totals of its currency.
+type SpendingRow struct {
+	Currency      string
+	GroupKey      string
+	GroupLabel    string
+	Total         sql.Amount
+	Count         int64
+	CurrencyTotal sql.Amount
+	CurrencyCount int64
+}
+
+// SpendingEntryRow is the total of one group in one currency on a single day.
+type SpendingEntryRow struct {
+	Currency    string
+	GroupKey    string
+	GroupLabel  string
+	ExpenseDate time.Time
+	Total       sql.Amount
+	Count       int64
+}
+
+func (receiver SpendingEntryRow) MapToDomainSpendingEntry() (*models.SpendingEntry, error) {
+	total, err := models.NewMoney(string(receiver.Total), receiver.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewSpendingEntry(receiver.GroupKey, receiver.GroupLabel, receiver.ExpenseDate, total, receiver.Count)
+}
@@ -0,0 +1,104 @@
+package tag
+
+import (
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const batchSize = 100
+
+// AddExpenseTags links every expense with its tags, given by name, creating the tags of the ledger that don't exist
+// yet. It must run in the transaction that stores the expenses.
+func AddExpenseTags(tx *gorm.DB, tagTable string, expenseTagTable string, ledgerId uuid.UUID, tagsByExpense map[string][]string) error {
+	uniqueNames := map[string]bool{}
+	names := []string{}
+	for _, expenseTags := range tagsByExpense {
+		for _, name := range expenseTags {
+			if !uniqueNames[name] {
+				uniqueNames[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	newTags := []Tag{}
+	for _, name := range names {
+		newTag, err := models.NewTag(name)
+		if err != nil {
+			return err
+		}
+		newTags = append(newTags, Tag{ID: newTag.Id().String(), LedgerID: ledgerId.String(), Name: newTag.Name()})
+	}
+
+	result := tx.Table(tagTable).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "ledger_id"}, {Name: "name"}}, DoNothing: true}).
+		CreateInBatches(&newTags, batchSize)
+	if err := result.Error; err != nil {
+		return err
+	}
+
+	storedTags := []Tag{}
+	if err := tx.Table(tagTable).Where("ledger_id = ? AND name IN ?", ledgerId.String(), names).Find(&storedTags).Error; err != nil {
+		return err
+	}
+
+	tagIds := map[string]string{}
+	for _, storedTag := range storedTags {
+		tagIds[storedTag.Name] = storedTag.ID
+	}
+
+	expenseTags := []ExpenseTag{}
+	for expenseId, expenseTagNames := range tagsByExpense {
+		for _, name := range expenseTagNames {
+			expenseTags = append(expenseTags, ExpenseTag{ExpenseID: expenseId, TagID: tagIds[name]})
+		}
+	}
+
+	return tx.Table(expenseTagTable).CreateInBatches(&expenseTags, batchSize).Error
+}
+
+// LoadExpenseTags reads the names of the tags of the expenses with a single query, sorted by name.
+func LoadExpenseTags(db sql.Database, tagTable string, expenseTagTable string, expenseIds []string) (map[string][]string, error) {
+	tagsByExpense := map[string][]string{}
+	if len(expenseIds) == 0 {
+		return tagsByExpense, nil
+	}
+
+	rows := []expenseTagName{}
+	result := db.Table(expenseTagTable).
+		Select(expenseTagTable+".expense_id, "+tagTable+".name").
+		Joins("JOIN "+tagTable+" ON "+tagTable+".id = "+expenseTagTable+".tag_id").
+		Where(expenseTagTable+".expense_id IN ?", expenseIds).
+		Order(tagTable + ".name").
+		Scan(&rows)
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		tagsByExpense[row.ExpenseID] = append(tagsByExpense[row.ExpenseID], row.Name)
+	}
+	return tagsByExpense, nil
+}
+
+// TaggedExpenseIds returns a subquery of the ids of the expenses of the ledger that pass the filter. Expenses with all
+// the tags are the ones linked to as many of them as the filter has.
+func TaggedExpenseIds(db sql.Database, tagTable string, expenseTagTable string, ledgerId uuid.UUID, filter *models.TagFilter) *gorm.DB {
+	query := db.Table(expenseTagTable).
+		Select(expenseTagTable+".expense_id").
+		Joins("JOIN "+tagTable+" ON "+tagTable+".id = "+expenseTagTable+".tag_id").
+		Where(tagTable+".ledger_id = ? AND "+tagTable+".name IN ?", ledgerId.String(), filter.Tags())
+
+	if filter.Match() == models.AllTagMatch {
+		query = query.Group(expenseTagTable+".expense_id").Having("COUNT(*) = ?", len(filter.Tags()))
+	}
+
+	return query
+}
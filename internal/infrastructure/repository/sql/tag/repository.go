@@ -0,0 +1,101 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	table           string
+	expenseTagTable string
+	db              sql.Database
+}
+
+func NewRepository(db sql.Database, table string, expenseTagTable string) *repository {
+	return &repository{db: db, table: table, expenseTagTable: expenseTagTable}
+}
+
+func (r repository) GetAll(ctx context.Context, ledgerId uuid.UUID) ([]*models.Tag, error) {
+	storedTags := []Tag{}
+	result := r.db.WithContext(ctx).Table(r.table).Where("ledger_id = ?", ledgerId.String()).Order("name").Find(&storedTags)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	tags := []*models.Tag{}
+	for _, storedTag := range storedTags {
+		tag, err := storedTag.MapToDomainTag()
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+func (r repository) GetByID(ctx context.Context, ledgerId uuid.UUID, id uuid.UUID) (*models.Tag, error) {
+	return r.getFirst(ctx, "id = ? AND ledger_id = ?", id.String(), ledgerId.String())
+}
+
+func (r repository) GetByName(ctx context.Context, ledgerId uuid.UUID, name string) (*models.Tag, error) {
+	return r.getFirst(ctx, "name = ? AND ledger_id = ?", name, ledgerId.String())
+}
+
+func (r repository) Update(ctx context.Context, ledgerId uuid.UUID, tag *models.Tag) (*models.Tag, error) {
+	tagDbModel := Tag{ID: tag.Id().String(), LedgerID: ledgerId.String(), Name: tag.Name()}
+	result := r.db.WithContext(ctx).Table(r.table).
+		Where("id = ? AND ledger_id = ?", tagDbModel.ID, tagDbModel.LedgerID).
+		Select("name", "updated_at").
+		Updates(&tagDbModel)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// Merge links the expenses of the source tags to the target one, skipping the expenses that already have it, and then
+// deletes the source tags along with their links.
+func (r repository) Merge(ctx context.Context, ledgerId uuid.UUID, targetId uuid.UUID, sourceIds []uuid.UUID) error {
+	sourceIdStrings := []string{}
+	for _, sourceId := range sourceIds {
+		sourceIdStrings = append(sourceIdStrings, sourceId.String())
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("INSERT INTO "+r.expenseTagTable+" (expense_id, tag_id) "+
+			"SELECT DISTINCT expense_id, ? FROM "+r.expenseTagTable+" WHERE tag_id IN ? "+
+			"ON CONFLICT DO NOTHING", targetId.String(), sourceIdStrings).Error
+		if err != nil {
+			return err
+		}
+
+		if err = tx.Table(r.expenseTagTable).Delete(&ExpenseTag{}, "tag_id IN ?", sourceIdStrings).Error; err != nil {
+			return err
+		}
+
+		return tx.Table(r.table).Delete(&Tag{}, "ledger_id = ? AND id IN ?", ledgerId.String(), sourceIdStrings).Error
+	})
+}
+
+func (r repository) getFirst(ctx context.Context, query string, args ...interface{}) (*models.Tag, error) {
+	var storedTag Tag
+	result := r.db.WithContext(ctx).Table(r.table).Where(query, args...).First(&storedTag)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return storedTag.MapToDomainTag()
+}
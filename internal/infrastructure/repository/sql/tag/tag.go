@@ -0,0 +1,31 @@
+package tag
+
+import (
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Tag struct {
+	ID        string    `gorm:"primaryKey,column:id"`
+	LedgerID  string    `gorm:"column:ledger_id"`
+	Name      string    `gorm:"column:name"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+// ExpenseTag links an expense with one of its tags.
+type ExpenseTag struct {
+	ExpenseID string `gorm:"primaryKey"`
+	TagID     string `gorm:"primaryKey"`
+}
+
+type expenseTagName struct {
+	ExpenseID string
+	Name      string
+}
+
+func (receiver Tag) MapToDomainTag() (*models.Tag, error) {
+	id, _ := uuid.Parse(receiver.ID)
+	return models.NewTagWithId(id, receiver.Name)
+}
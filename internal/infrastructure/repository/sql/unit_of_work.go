@@ -0,0 +1,25 @@
+package sql
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+type unitOfWork[R any] struct {
+	db              *gorm.DB
+	newRepositories func(db *gorm.DB) R
+}
+
+// NewUnitOfWork runs every operation in a transaction of db, building the repositories of the operation on it with
+// newRepositories. The transaction is committed when the operation returns nil and rolled back when it returns an
+// error, panics, or its context is cancelled; the panic goes on after the rollback. The transactions the repositories
+// open on their own become savepoints of the one of the operation.
+func NewUnitOfWork[R any](db *gorm.DB, newRepositories func(db *gorm.DB) R) *unitOfWork[R] {
+	return &unitOfWork[R]{db: db, newRepositories: newRepositories}
+}
+
+func (u unitOfWork[R]) Do(ctx context.Context, operation func(repositories R) error) error {
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return operation(u.newRepositories(tx))
+	})
+}
@@ -0,0 +1,98 @@
+package sql_test
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	expenseTypeService "finfit-backend/internal/domain/services/expensetype"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"finfit-backend/internal/infrastructure/repository/sql/expensetype"
+	"finfit-backend/pkg"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func newExpenseTypeRepository(db *gorm.DB) expenseTypeService.Repository {
+	return expensetype.NewRepository(db, "expense_type", "expense")
+}
+
+func addExpenseType(t *testing.T, repository expenseTypeService.Repository, ledgerId uuid.UUID, name string) *models.ExpenseType {
+	expenseType, err := models.NewExpenseType(name)
+	require.NoError(t, err)
+	_, err = repository.Add(context.Background(), ledgerId, expenseType)
+	require.NoError(t, err)
+	return expenseType
+}
+
+func TestGivenAnOperationThatSucceeds_WhenDo_ThenItsChangesAreCommitted(t *testing.T) {
+	db := openSQLiteTestDatabase(t)
+	unitOfWork := sql.NewUnitOfWork(db, newExpenseTypeRepository)
+	ledgerId := pkg.NewUUID()
+	var food *models.ExpenseType
+
+	err := unitOfWork.Do(context.Background(), func(repository expenseTypeService.Repository) error {
+		food = addExpenseType(t, repository, ledgerId, "Food")
+		return nil
+	})
+
+	require.NoError(t, err)
+	storedExpenseType, err := newExpenseTypeRepository(db).GetByID(context.Background(), ledgerId, food.Id())
+	require.NoError(t, err)
+	assert.NotNil(t, storedExpenseType)
+}
+
+func TestGivenAnOperationThatFails_WhenDo_ThenItsChangesAreRolledBackAndItsErrorReturned(t *testing.T) {
+	db := openSQLiteTestDatabase(t)
+	unitOfWork := sql.NewUnitOfWork(db, newExpenseTypeRepository)
+	ledgerId := pkg.NewUUID()
+	operationErr := errors.New("operation failed")
+	var food *models.ExpenseType
+
+	err := unitOfWork.Do(context.Background(), func(repository expenseTypeService.Repository) error {
+		food = addExpenseType(t, repository, ledgerId, "Food")
+		return operationErr
+	})
+
+	assert.ErrorIs(t, err, operationErr)
+	storedExpenseType, err := newExpenseTypeRepository(db).GetByID(context.Background(), ledgerId, food.Id())
+	require.NoError(t, err)
+	assert.Nil(t, storedExpenseType)
+}
+
+func TestGivenAnOperationThatPanics_WhenDo_ThenItsChangesAreRolledBackAndThePanicGoesOn(t *testing.T) {
+	db := openSQLiteTestDatabase(t)
+	unitOfWork := sql.NewUnitOfWork(db, newExpenseTypeRepository)
+	ledgerId := pkg.NewUUID()
+	var food *models.ExpenseType
+
+	do := func() {
+		_ = unitOfWork.Do(context.Background(), func(repository expenseTypeService.Repository) error {
+			food = addExpenseType(t, repository, ledgerId, "Food")
+			panic("operation panicked")
+		})
+	}
+
+	assert.PanicsWithValue(t, "operation panicked", do)
+	storedExpenseType, err := newExpenseTypeRepository(db).GetByID(context.Background(), ledgerId, food.Id())
+	require.NoError(t, err)
+	assert.Nil(t, storedExpenseType)
+}
+
+func TestGivenACancelledContext_WhenDo_ThenTheOperationDoesNotRunAndTheCancellationIsReturned(t *testing.T) {
+	db := openSQLiteTestDatabase(t)
+	unitOfWork := sql.NewUnitOfWork(db, newExpenseTypeRepository)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	operationRan := false
+
+	err := unitOfWork.Do(ctx, func(repository expenseTypeService.Repository) error {
+		operationRan = true
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, operationRan)
+}
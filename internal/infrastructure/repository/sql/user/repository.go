@@ -0,0 +1,96 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"finfit-backend/internal/domain/models"
+	"finfit-backend/internal/infrastructure/repository/sql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type repository struct {
+	table                 string
+	usedRefreshTokenTable string
+	db                    sql.Database
+}
+
+func NewRepository(db sql.Database, table string, usedRefreshTokenTable string) *repository {
+	return &repository{db: db, table: table, usedRefreshTokenTable: usedRefreshTokenTable}
+}
+
+func (r repository) Add(ctx context.Context, user *models.User) (*models.User, error) {
+	userDbModel := User{
+		ID:           user.Id().String(),
+		Email:        user.Email(),
+		PasswordHash: user.PasswordHash(),
+	}
+	result := r.db.WithContext(ctx).Table(r.table).Create(&userDbModel)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r repository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	return r.getBy(ctx, "id = ?", id.String())
+}
+
+func (r repository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	return r.getBy(ctx, "email = ?", email)
+}
+
+func (r repository) GetAll(ctx context.Context) ([]*models.User, error) {
+	storedUsers := []User{}
+	result := r.db.WithContext(ctx).Table(r.table).Order("created_at").Find(&storedUsers)
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	users := []*models.User{}
+	for _, storedUser := range storedUsers {
+		user, err := storedUser.MapToDomainUser()
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (r repository) UseRefreshToken(ctx context.Context, userId uuid.UUID, tokenId string, expiresAt time.Time) (bool, error) {
+	// The tokens that expired can't be exchanged anyway, so they don't need to be remembered.
+	result := r.db.WithContext(ctx).Table(r.usedRefreshTokenTable).Where("user_id = ? AND expires_at < ?", userId.String(), time.Now().UTC()).Delete(&UsedRefreshToken{})
+	if err := result.Error; err != nil {
+		return false, err
+	}
+
+	usedRefreshToken := UsedRefreshToken{ID: tokenId, UserID: userId.String(), ExpiresAt: expiresAt.UTC()}
+	result = r.db.WithContext(ctx).Table(r.usedRefreshTokenTable).Clauses(clause.OnConflict{DoNothing: true}).Create(&usedRefreshToken)
+	if err := result.Error; err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r repository) getBy(ctx context.Context, condition string, value string) (*models.User, error) {
+	var storedUser User
+	result := r.db.WithContext(ctx).Table(r.table).First(&storedUser, condition, value)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+
+	return storedUser.MapToDomainUser()
+}
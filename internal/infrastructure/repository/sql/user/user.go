@@ -0,0 +1,26 @@
+package user
+
+import (
+	"finfit-backend/internal/domain/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+type User struct {
+	ID           string    `gorm:"primaryKey,column:id"`
+	Email        string    `gorm:"column:email"`
+	PasswordHash string    `gorm:"column:password_hash"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+	UpdatedAt    time.Time `gorm:"column:updated_at"`
+}
+
+func (receiver User) MapToDomainUser() (*models.User, error) {
+	id, _ := uuid.Parse(receiver.ID)
+	return models.NewUserWithId(id, receiver.Email, receiver.PasswordHash)
+}
+
+type UsedRefreshToken struct {
+	ID        string    `gorm:"primaryKey,column:id"`
+	UserID    string    `gorm:"column:user_id"`
+	ExpiresAt time.Time `gorm:"column:expires_at"`
+}
@@ -0,0 +1,42 @@
+package sql_test
+
+import (
+	"context"
+	"finfit-backend/internal/infrastructure/repository/sql/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestGivenARefreshTokenAlreadyUsed_WhenUseRefreshToken_ThenReturnFalse(t *testing.T) {
+	db := openSQLiteTestDatabase(t)
+	storedUser := addUser(t, db)
+	repository := user.NewRepository(db, "app_user", "used_refresh_token")
+	expiresAt := time.Now().Add(time.Hour)
+
+	firstUse, err := repository.UseRefreshToken(context.Background(), storedUser.Id(), "0123456789abcdef0123456789abcdef", expiresAt)
+	require.NoError(t, err)
+	secondUse, err := repository.UseRefreshToken(context.Background(), storedUser.Id(), "0123456789abcdef0123456789abcdef", expiresAt)
+	require.NoError(t, err)
+
+	assert.True(t, firstUse)
+	assert.False(t, secondUse)
+}
+
+func TestGivenAnExpiredUsedRefreshToken_WhenUseRefreshToken_ThenItIsForgotten(t *testing.T) {
+	db := openSQLiteTestDatabase(t)
+	storedUser := addUser(t, db)
+	repository := user.NewRepository(db, "app_user", "used_refresh_token")
+	_, err := repository.UseRefreshToken(context.Background(), storedUser.Id(), "0123456789abcdef0123456789abcdef", time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+
+	_, err = repository.UseRefreshToken(context.Background(), storedUser.Id(), "fedcba9876543210fedcba9876543210", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	var usedRefreshTokens []user.UsedRefreshToken
+	require.NoError(t, db.Table("used_refresh_token").Find(&usedRefreshTokens).Error)
+	if assert.Len(t, usedRefreshTokens, 1) {
+		assert.Equal(t, "fedcba9876543210fedcba9876543210", usedRefreshTokens[0].ID)
+	}
+}
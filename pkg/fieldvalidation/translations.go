@@ -33,6 +33,23 @@ func registerTranslations(validate *validator.Validate, translator ut.Translator
 				return t
 			},
 		},
+		{
+			tag:              PositiveDecimalValidationTag,
+			formattedMessage: "{0} must be greater than 0",
+			override:         false,
+		},
+		{
+			tag:              "required_without",
+			formattedMessage: "{0} is required when {1} is empty",
+			override:         false,
+			customTransFunc: func(ut ut.Translator, fe validator.FieldError) string {
+				t, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
+				if err != nil {
+					return fe.(error).Error()
+				}
+				return t
+			},
+		},
 	}
 
 	translations = append(translations, customTranslations...)
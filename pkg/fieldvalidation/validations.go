@@ -2,6 +2,7 @@ package fieldvalidation
 
 import (
 	"github.com/go-playground/validator/v10"
+	"regexp"
 	"strings"
 	"time"
 )
@@ -11,7 +12,12 @@ type Validation struct {
 	function validator.Func
 }
 
-const LteStrDateFieldValidationTag = "lteStrDateField"
+const (
+	LteStrDateFieldValidationTag = "lteStrDateField"
+	PositiveDecimalValidationTag = "positiveDecimal"
+)
+
+var decimalRegexp = regexp.MustCompile(`^\+?[0-9]*\.?[0-9]+$`)
 
 func LteStrDateField(fieldLevel validator.FieldLevel) bool {
 	tagParam := fieldLevel.Param()
@@ -34,12 +40,27 @@ func LteStrDateField(fieldLevel validator.FieldLevel) bool {
 	return date.Before(dateToCompare) || date.Equal(dateToCompare)
 }
 
+// PositiveDecimal validates that a string (or json.Number) holds a plain decimal number greater than zero, without
+// going through float64.
+func PositiveDecimal(fieldLevel validator.FieldLevel) bool {
+	strDecimal := fieldLevel.Field().String()
+	if !decimalRegexp.MatchString(strDecimal) {
+		return false
+	}
+
+	return strings.Trim(strDecimal, "+0.") != ""
+}
+
 func registerValidations(validate *validator.Validate, customValidations []Validation) error {
 	validations := []Validation{
 		{
 			tag:      LteStrDateFieldValidationTag,
 			function: LteStrDateField,
 		},
+		{
+			tag:      PositiveDecimalValidationTag,
+			function: PositiveDecimal,
+		},
 	}
 
 	validations = append(validations, customValidations...)
@@ -0,0 +1,177 @@
+package token_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"finfit-backend/pkg/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+var secret = []byte("0123456789abcdef0123456789abcdef")
+
+func newManager(t *testing.T) *token.Manager {
+	manager, err := token.NewManager(secret, 15*time.Minute, 24*time.Hour)
+	require.NoError(t, err)
+	return manager
+}
+
+func issue(t *testing.T, manager *token.Manager, kind token.Kind) string {
+	issuedToken, _, err := manager.Issue("user-1", kind)
+	require.NoError(t, err)
+	return issuedToken
+}
+
+func encode(segment string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(segment))
+}
+
+// signWithManager signs the way the manager does, to build tokens whose signature is valid but whose content isn't.
+func signWithManager(signingInput string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func patchNow(t *testing.T, now time.Time) {
+	token.Now = func() time.Time { return now }
+	t.Cleanup(func() { token.Now = time.Now })
+}
+
+func TestGivenAnIssuedToken_WhenParse_ThenReturnItsClaims(t *testing.T) {
+	issuedAt := time.Date(2023, 3, 14, 10, 0, 0, 0, time.UTC)
+	patchNow(t, issuedAt)
+	manager := newManager(t)
+	issuedToken, expiresAt, err := manager.Issue("user-1", token.AccessKind)
+	require.NoError(t, err)
+
+	claims, err := manager.Parse(issuedToken, token.AccessKind)
+
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+	assert.Equal(t, token.AccessKind, claims.Kind)
+	assert.NotEmpty(t, claims.ID)
+	assert.Equal(t, issuedAt.Unix(), claims.IssuedAt)
+	assert.Equal(t, issuedAt.Add(15*time.Minute), expiresAt)
+	assert.Equal(t, expiresAt.Unix(), claims.ExpiresAt)
+}
+
+func TestGivenTwoIssuedTokens_WhenParse_ThenEachHasItsOwnID(t *testing.T) {
+	manager := newManager(t)
+
+	firstClaims, err := manager.Parse(issue(t, manager, token.RefreshKind), token.RefreshKind)
+	require.NoError(t, err)
+	secondClaims, err := manager.Parse(issue(t, manager, token.RefreshKind), token.RefreshKind)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, firstClaims.ID, secondClaims.ID)
+}
+
+func TestGivenATokenWithTamperedClaims_WhenParse_ThenReturnInvalidToken(t *testing.T) {
+	manager := newManager(t)
+	segments := strings.Split(issue(t, manager, token.AccessKind), ".")
+	segments[1] = encode(`{"sub":"user-2","kind":"access","exp":99999999999}`)
+
+	_, err := manager.Parse(strings.Join(segments, "."), token.AccessKind)
+
+	assert.ErrorIs(t, err, token.ErrInvalidToken)
+}
+
+func TestGivenATokenSignedWithAnotherSecret_WhenParse_ThenReturnInvalidToken(t *testing.T) {
+	otherManager, err := token.NewManager([]byte("fedcba9876543210fedcba9876543210"), 15*time.Minute, 24*time.Hour)
+	require.NoError(t, err)
+
+	_, err = newManager(t).Parse(issue(t, otherManager, token.AccessKind), token.AccessKind)
+
+	assert.ErrorIs(t, err, token.ErrInvalidToken)
+}
+
+func TestGivenAnExpiredToken_WhenParse_ThenReturnExpiredToken(t *testing.T) {
+	issuedAt := time.Date(2023, 3, 14, 10, 0, 0, 0, time.UTC)
+	patchNow(t, issuedAt)
+	manager := newManager(t)
+	issuedToken := issue(t, manager, token.AccessKind)
+	patchNow(t, issuedAt.Add(15*time.Minute))
+
+	_, err := manager.Parse(issuedToken, token.AccessKind)
+
+	assert.ErrorIs(t, err, token.ErrExpiredToken)
+}
+
+func TestGivenATokenAboutToExpire_WhenParse_ThenReturnItsClaims(t *testing.T) {
+	issuedAt := time.Date(2023, 3, 14, 10, 0, 0, 0, time.UTC)
+	patchNow(t, issuedAt)
+	manager := newManager(t)
+	issuedToken := issue(t, manager, token.AccessKind)
+	patchNow(t, issuedAt.Add(15*time.Minute-time.Second))
+
+	_, err := manager.Parse(issuedToken, token.AccessKind)
+
+	assert.NoError(t, err)
+}
+
+func TestGivenARefreshToken_WhenParseAsAccessToken_ThenReturnWrongTokenKind(t *testing.T) {
+	manager := newManager(t)
+
+	_, err := manager.Parse(issue(t, manager, token.RefreshKind), token.AccessKind)
+
+	assert.ErrorIs(t, err, token.ErrWrongTokenKind)
+}
+
+func TestGivenATokenWithAlgorithmNone_WhenParse_ThenReturnInvalidToken(t *testing.T) {
+	manager := newManager(t)
+	segments := strings.Split(issue(t, manager, token.AccessKind), ".")
+	unsignedToken := encode(`{"alg":"none","typ":"JWT"}`) + "." + segments[1] + "."
+
+	_, err := manager.Parse(unsignedToken, token.AccessKind)
+
+	assert.ErrorIs(t, err, token.ErrInvalidToken)
+}
+
+func TestGivenATokenSignedWithTheSecretButAnotherAlgorithmInItsHeader_WhenParse_ThenReturnInvalidToken(t *testing.T) {
+	manager := newManager(t)
+	segments := strings.Split(issue(t, manager, token.AccessKind), ".")
+	segments[0] = encode(`{"alg":"HS512","typ":"JWT"}`)
+	segments[2] = signWithManager(segments[0] + "." + segments[1])
+
+	_, err := manager.Parse(strings.Join(segments, "."), token.AccessKind)
+
+	assert.ErrorIs(t, err, token.ErrInvalidToken)
+}
+
+func TestGivenMalformedTokens_WhenParse_ThenReturnMalformedToken(t *testing.T) {
+	manager := newManager(t)
+	segments := strings.Split(issue(t, manager, token.AccessKind), ".")
+	notJSON := encode("not json")
+	malformedTokens := map[string]string{
+		"empty":           "",
+		"two segments":    segments[0] + "." + segments[1],
+		"four segments":   strings.Join(append(segments, segments[2]), "."),
+		"header not json": notJSON + "." + segments[1] + "." + signWithManager(notJSON+"."+segments[1]),
+		"claims not json": segments[0] + "." + notJSON + "." + signWithManager(segments[0]+"."+notJSON),
+		"header not b64":  "%%%." + segments[1] + "." + signWithManager("%%%."+segments[1]),
+		"claims not b64":  segments[0] + ".%%%." + signWithManager(segments[0]+".%%%"),
+	}
+
+	for name, malformedToken := range malformedTokens {
+		t.Run(name, func(t *testing.T) {
+			_, err := manager.Parse(malformedToken, token.AccessKind)
+
+			assert.ErrorIs(t, err, token.ErrMalformedToken)
+		})
+	}
+}
+
+func TestGivenAShortSecretOrANonPositiveTimeToLive_WhenNewManager_ThenReturnError(t *testing.T) {
+	_, shortSecretErr := token.NewManager([]byte("too short"), 15*time.Minute, 24*time.Hour)
+	_, zeroAccessTTLErr := token.NewManager(secret, 0, 24*time.Hour)
+	_, negativeRefreshTTLErr := token.NewManager(secret, 15*time.Minute, -time.Hour)
+
+	assert.Error(t, shortSecretErr)
+	assert.Error(t, zeroAccessTTLErr)
+	assert.Error(t, negativeRefreshTTLErr)
+}